@@ -51,6 +51,9 @@ func (t *SimpleChaincode) makePurchase(stub shim.ChaincodeStubInterface, args []
 	if err != nil {
 		return shim.Error("4th argument 'quantity' must be a numeric string")
 	}
+	if quantity <= 0 {
+		return shim.Error("4th argument 'quantity' must be positive")
+	}
 	contract.Quantity = quantity
 
 	//get seller
@@ -120,6 +123,12 @@ func (t *SimpleChaincode) makePurchase(stub shim.ChaincodeStubInterface, args []
 		return shim.Error(err.Error())
 	}
 
+	//notify listeners about the new contract
+	err = emitEvent(stub, EVENT_CONTRACT_CREATED, contract)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
 	//return contract info
 	fmt.Println("contractAsBytes")
 	fmt.Println(contractAsBytes)
@@ -129,10 +138,13 @@ func (t *SimpleChaincode) makePurchase(stub shim.ChaincodeStubInterface, args []
 
 // ============================================================================================================================
 // Transact Purchase - update user account, update seller's account and product inventory, update contract state
-// Inputs - memberId, contractID, newState(complete or declined)
+// the seller can complete a contract for fewer units than ordered, the user is only charged for delivered units and
+// the contract stays in 'partial' state with the rest backordered until the seller delivers them or the backorder is
+// declined, which leaves delivered units charged
+// Inputs - memberId, contractID, newState(complete or declined), deliveredQuantity(optional, defaults to what is in stock)
 // ============================================================================================================================
 func (t *SimpleChaincode) transactPurchase(stub shim.ChaincodeStubInterface, args []string) pb.Response {
-	if len(args) != 3 {
+	if len(args) != 3 && len(args) != 4 {
 		return shim.Error("Incorrect number of arguments")
 	}
 	//get contractID args
@@ -153,8 +165,14 @@ func (t *SimpleChaincode) transactPurchase(stub shim.ChaincodeStubInterface, arg
 		return shim.Error("Member not authorized to update contract")
 	}
 
-	//if current contract state is pending, then execute transaction
-	if contract.State == STATE_PENDING {
+	//if current contract state is pending or partial, then execute transaction
+	if contract.State == STATE_PENDING || contract.State == STATE_PARTIAL {
+		if contract.Quantity <= 0 {
+			return shim.Error("Invalid contract quantity")
+		}
+		outstanding := contract.Quantity - contract.DeliveredQuantity
+		unitCost := contract.Cost / contract.Quantity
+
 		if newState == STATE_COMPLETE && memberId == contract.SellerId {
 			//get seller
 			var member Seller
@@ -164,6 +182,50 @@ func (t *SimpleChaincode) transactPurchase(stub shim.ChaincodeStubInterface, arg
 			}
 			json.Unmarshal(memberAsBytes, &member)
 
+			//find seller's product
+			productIndex := -1
+			for h := 0; h < len(member.Products); h++ {
+				if member.Products[h].Id == contract.ProductId {
+					productIndex = h
+					break
+				}
+			}
+			//if product not found decline contract
+			if productIndex < 0 {
+				if contract.State == STATE_PENDING {
+					contract.RefundedCost = contract.Cost
+				}
+				contract.State = STATE_DECLINED
+				contract.BackorderedQuantity = 0
+				declinedContractAsBytes, _ := json.Marshal(contract)
+				err = stub.PutState(contract.Id, declinedContractAsBytes)
+				if err != nil {
+					return shim.Error(err.Error())
+				}
+				return shim.Error("Product not available for sale. Cancelling contract.")
+			}
+
+			//get delivered quantity, defaults to everything outstanding that is in stock
+			delivered := outstanding
+			if len(args) == 4 {
+				delivered, err = strconv.Atoi(args[3])
+				if err != nil {
+					return shim.Error("4th argument 'deliveredQuantity' must be a numeric string")
+				}
+				if delivered <= 0 || delivered > outstanding {
+					return shim.Error("Delivered quantity must be between 1 and " + strconv.Itoa(outstanding))
+				}
+			}
+			if delivered > member.Products[productIndex].Count {
+				if len(args) == 4 {
+					return shim.Error("Not enough stock to deliver " + strconv.Itoa(delivered) + " units")
+				}
+				delivered = member.Products[productIndex].Count
+			}
+			if delivered == 0 {
+				return shim.Error("Product out of stock")
+			}
+
 			//get contract user's current state
 			var contractUser User
 			contractUserAsBytes, err := stub.GetState(contract.UserId)
@@ -172,53 +234,48 @@ func (t *SimpleChaincode) transactPurchase(stub shim.ChaincodeStubInterface, arg
 			}
 			json.Unmarshal(contractUserAsBytes, &contractUser)
 
-			//update user's FitcoinsBalance
-			if (contractUser.FitcoinsBalance - contract.Cost) >= 0 {
-				contractUser.FitcoinsBalance = contractUser.FitcoinsBalance - contract.Cost
+			//update user's FitcoinsBalance, charging only delivered units
+			charge := unitCost * delivered
+			if (contractUser.FitcoinsBalance - charge) >= 0 {
+				contractUser.FitcoinsBalance = contractUser.FitcoinsBalance - charge
 			} else {
 				return shim.Error("Insufficient fitcoins")
 			}
 
-			//update seller's product count
-			productFound := false
-			for h := 0; h < len(member.Products); h++ {
-				if member.Products[h].Id == contract.ProductId {
-					productFound = true
-					if member.Products[h].Count >= contract.Quantity {
-						member.Products[h].Count = member.Products[h].Count - contract.Quantity
-					}
-					break
-				}
+			//update seller's product count and FitcoinsBalance
+			member.Products[productIndex].Count = member.Products[productIndex].Count - delivered
+			member.FitcoinsBalance = member.FitcoinsBalance + charge
+
+			//update user state
+			updatedUserAsBytes, _ := json.Marshal(contractUser)
+			err = stub.PutState(contract.UserId, updatedUserAsBytes)
+			if err != nil {
+				return shim.Error(err.Error())
+			}
+			//update seller state
+			updatedSellerAsBytes, _ := json.Marshal(member)
+			err = stub.PutState(contract.SellerId, updatedSellerAsBytes)
+			if err != nil {
+				return shim.Error(err.Error())
 			}
-			//if product not found return error
-			if productFound == true {
-				//update seller's FitcoinsBalance
-				member.FitcoinsBalance = member.FitcoinsBalance + contract.Cost
-				//update user state
-				updatedUserAsBytes, _ := json.Marshal(contractUser)
-				err = stub.PutState(contract.UserId, updatedUserAsBytes)
-				if err != nil {
-					return shim.Error(err.Error())
-				}
-				//update seller state
-				updatedSellerAsBytes, _ := json.Marshal(member)
-				err = stub.PutState(contract.SellerId, updatedSellerAsBytes)
-				if err != nil {
-					return shim.Error(err.Error())
-				}
-				contract.State = STATE_COMPLETE
 
+			//record delivered and backordered quantities
+			contract.DeliveredQuantity = contract.DeliveredQuantity + delivered
+			contract.BackorderedQuantity = contract.Quantity - contract.DeliveredQuantity
+			contract.ChargedCost = unitCost * contract.DeliveredQuantity
+			contract.RefundedCost = contract.Cost - contract.ChargedCost
+			if contract.BackorderedQuantity == 0 {
+				contract.State = STATE_COMPLETE
 			} else {
-				contract.State = STATE_DECLINED
-				declinedContractAsBytes, _ := json.Marshal(contract)
-				err = stub.PutState(contract.Id, declinedContractAsBytes)
-				if err != nil {
-					return shim.Error(err.Error())
-				}
-				return shim.Error("Product not available for sale. Cancelling contract.")
+				contract.State = STATE_PARTIAL
 			}
 		} else if newState == STATE_DECLINED {
+			if contract.State == STATE_PENDING {
+				contract.RefundedCost = contract.Cost
+			}
+			//cancel the backorder, delivered units stay charged
 			contract.State = STATE_DECLINED
+			contract.BackorderedQuantity = 0
 		} else {
 			return shim.Error("Invalid new state")
 		}
@@ -229,6 +286,19 @@ func (t *SimpleChaincode) transactPurchase(stub shim.ChaincodeStubInterface, arg
 		if err != nil {
 			return shim.Error(err.Error())
 		}
+
+		//notify listeners about the new contract state
+		if contract.State == STATE_COMPLETE {
+			err = emitEvent(stub, EVENT_CONTRACT_COMPLETED, contract)
+		} else if contract.State == STATE_PARTIAL {
+			err = emitEvent(stub, EVENT_CONTRACT_PARTIAL, contract)
+		} else {
+			err = emitEvent(stub, EVENT_CONTRACT_DECLINED, contract)
+		}
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+
 		//return contract info
 		return shim.Success(updatedContractAsBytes)
 	} else {
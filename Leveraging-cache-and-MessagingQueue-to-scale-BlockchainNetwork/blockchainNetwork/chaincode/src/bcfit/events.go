@@ -0,0 +1,84 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements.  See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership.  The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License.  You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+)
+
+//event payload version, bump when a payload changes shape
+const EVENT_VERSION = 1
+
+//event names
+const EVENT_FITCOINS_GENERATED = "fitcoins.generated"
+const EVENT_CONTRACT_CREATED = "contract.created"
+const EVENT_CONTRACT_COMPLETED = "contract.completed"
+const EVENT_CONTRACT_DECLINED = "contract.declined"
+const EVENT_CONTRACT_PARTIAL = "contract.partial"
+const EVENT_PRODUCT_UPDATED = "product.updated"
+
+// Event - envelope for every chaincode event, listeners should check version before reading payload
+type Event struct {
+	Version   int         `json:"version"`
+	Name      string      `json:"name"`
+	TxId      string      `json:"txId"`
+	Timestamp int64       `json:"timestamp"`
+	Payload   interface{} `json:"payload"`
+}
+
+// FitcoinsGeneratedPayload - payload for fitcoins.generated
+type FitcoinsGeneratedPayload struct {
+	UserId            string `json:"userId"`
+	GeneratedFitcoins int    `json:"generatedFitcoins"`
+	FitcoinsBalance   int    `json:"fitcoinsBalance"`
+	TotalSteps        int    `json:"totalSteps"`
+}
+
+// ProductUpdatedPayload - payload for product.updated
+type ProductUpdatedPayload struct {
+	SellerId string  `json:"sellerId"`
+	Product  Product `json:"product"`
+}
+
+// ============================================================================================================================
+// Emit event - set a versioned json chaincode event for the transaction
+// fabric keeps only one event per transaction, so each function emits at most one
+// ============================================================================================================================
+func emitEvent(stub shim.ChaincodeStubInterface, name string, payload interface{}) error {
+	var event Event
+	event.Version = EVENT_VERSION
+	event.Name = name
+	event.TxId = stub.GetTxID()
+	event.Payload = payload
+
+	txTimestamp, err := stub.GetTxTimestamp()
+	if err != nil {
+		return err
+	}
+	event.Timestamp = txTimestamp.Seconds
+
+	eventAsBytes, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return stub.SetEvent(name, eventAsBytes)
+}
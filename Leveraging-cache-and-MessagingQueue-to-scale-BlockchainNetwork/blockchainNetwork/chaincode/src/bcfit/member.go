@@ -146,6 +146,19 @@ func (t *SimpleChaincode) generateFitcoins(stub shim.ChaincodeStubInterface, arg
 		}
 	}
 
+	//notify listeners about minted fitcoins
+	if newFitcoins > 0 {
+		var payload FitcoinsGeneratedPayload
+		payload.UserId = user.Id
+		payload.GeneratedFitcoins = newFitcoins
+		payload.FitcoinsBalance = user.FitcoinsBalance
+		payload.TotalSteps = user.TotalSteps
+		err = emitEvent(stub, EVENT_FITCOINS_GENERATED, payload)
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+	}
+
 	//return updated user with GeneratedFitcoins and GeneratedSteps
 	type ReturnUser struct {
 		User
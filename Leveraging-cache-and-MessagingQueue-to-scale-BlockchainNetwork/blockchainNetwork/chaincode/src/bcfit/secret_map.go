@@ -34,6 +34,7 @@ const STEPS_TO_FITCOIN = 100
 const STATE_COMPLETE = "complete"
 const STATE_PENDING = "pending"
 const STATE_DECLINED = "declined"
+const STATE_PARTIAL = "partial"
 
 //member type
 const TYPE_USER = "user"
@@ -74,14 +75,18 @@ type Product struct {
 
 // Contract
 type Contract struct {
-	Id          string `json:"id"`
-	SellerId    string `json:"sellerId"`
-	UserId      string `json:"userId"`
-	ProductId   string `json:"productId"`
-	ProductName string `json:"productName"`
-	Quantity    int    `json:"quantity"`
-	Cost        int    `json:"cost"`
-	State       string `json:"state"`
+	Id                  string `json:"id"`
+	SellerId            string `json:"sellerId"`
+	UserId              string `json:"userId"`
+	ProductId           string `json:"productId"`
+	ProductName         string `json:"productName"`
+	Quantity            int    `json:"quantity"`
+	Cost                int    `json:"cost"`
+	State               string `json:"state"`
+	DeliveredQuantity   int    `json:"deliveredQuantity"`
+	BackorderedQuantity int    `json:"backorderedQuantity"`
+	ChargedCost         int    `json:"chargedCost"`
+	RefundedCost        int    `json:"refundedCost"`
 }
 
 // ============================================================================================================================
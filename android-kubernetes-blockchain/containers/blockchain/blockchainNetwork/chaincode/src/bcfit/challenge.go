@@ -0,0 +1,452 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements.  See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership.  The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License.  You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	pb "github.com/hyperledger/fabric/protos/peer"
+)
+
+// ============================================================================================================================
+// Create Challenge - admin creates a step challenge with a time window, step goal and prize pool
+// the prize pool is taken from the admin's fitcoins balance
+// Inputs - adminId, challengeId, name, startTime, endTime, stepGoal, prizePool
+// startTime and endTime are unix timestamps in seconds
+// ============================================================================================================================
+func (t *SimpleChaincode) createChallenge(stub shim.ChaincodeStubInterface, args []string) pb.Response {
+	if len(args) != 7 {
+		return shim.Error("Incorrect number of arguments")
+	}
+	var err error
+
+	//get adminId and challenge properties from args
+	admin_id := args[0]
+	var challenge Challenge
+	challenge.Id = args[1]
+	challenge.Name = args[2]
+	challenge.StartTime, err = strconv.ParseInt(args[3], 10, 64)
+	if err != nil {
+		return shim.Error("4th argument 'startTime' must be a numeric string")
+	}
+	challenge.EndTime, err = strconv.ParseInt(args[4], 10, 64)
+	if err != nil {
+		return shim.Error("5th argument 'endTime' must be a numeric string")
+	}
+	challenge.StepGoal, err = strconv.Atoi(args[5])
+	if err != nil {
+		return shim.Error("6th argument 'stepGoal' must be a numeric string")
+	}
+	challenge.PrizePool, err = strconv.Atoi(args[6])
+	if err != nil {
+		return shim.Error("7th argument 'prizePool' must be a numeric string")
+	}
+	if challenge.EndTime <= challenge.StartTime {
+		return shim.Error("Challenge must end after it starts")
+	}
+	if challenge.StepGoal <= 0 || challenge.PrizePool < 0 {
+		return shim.Error("Step goal must be positive and prize pool must not be negative")
+	}
+
+	//get admin
+	adminAsBytes, err := stub.GetState(admin_id)
+	if err != nil {
+		return shim.Error("Failed to get admin")
+	}
+	var admin Member
+	json.Unmarshal(adminAsBytes, &admin)
+	if admin.Type != TYPE_ADMIN {
+		return shim.Error("Not admin type")
+	}
+
+	//make sure the id is not already taken
+	existingAsBytes, err := stub.GetState(challenge.Id)
+	if err != nil {
+		return shim.Error("Failed to get challenge")
+	}
+	if existingAsBytes != nil {
+		return shim.Error("Id " + challenge.Id + " is already in use")
+	}
+
+	//fund the prize pool from the admin's balance
+	if admin.FitcoinsBalance < challenge.PrizePool {
+		return shim.Error("Insufficient funds for prize pool")
+	}
+	admin.FitcoinsBalance = admin.FitcoinsBalance - challenge.PrizePool
+	updatedAdminAsBytes, _ := json.Marshal(admin)
+	err = stub.PutState(admin.Id, updatedAdminAsBytes)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	challenge.AdminId = admin_id
+	challenge.State = CHALLENGE_OPEN
+
+	//store challenge
+	challengeAsBytes, _ := json.Marshal(challenge)
+	err = stub.PutState(challenge.Id, challengeAsBytes)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	//get and update challengeIds
+	challengeIdsBytes, err := stub.GetState("challengeIds")
+	if err != nil {
+		return shim.Error("Unable to get challenges.")
+	}
+	var challengeIds []string
+	json.Unmarshal(challengeIdsBytes, &challengeIds)
+	challengeIds = append(challengeIds, challenge.Id)
+	updatedChallengeIdsBytes, _ := json.Marshal(challengeIds)
+	err = stub.PutState("challengeIds", updatedChallengeIdsBytes)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	//return challenge info
+	return shim.Success(challengeAsBytes)
+}
+
+// ============================================================================================================================
+// Record challenge steps - credit newly submitted steps to every open challenge whose window contains the transaction time
+// called from generateFitcoins, each participant is stored under its own composite key so that
+// concurrent users do not conflict on the challenge record
+// ============================================================================================================================
+func recordChallengeSteps(stub shim.ChaincodeStubInterface, userId string, newSteps int) error {
+	if newSteps <= 0 {
+		return nil
+	}
+
+	txTime, err := getTxTime(stub)
+	if err != nil {
+		return err
+	}
+
+	//get challengeIds
+	challengeIdsBytes, err := stub.GetState("challengeIds")
+	if err != nil {
+		return err
+	}
+	var challengeIds []string
+	json.Unmarshal(challengeIdsBytes, &challengeIds)
+
+	for _, challengeId := range challengeIds {
+		//get challenge
+		challengeAsBytes, err := stub.GetState(challengeId)
+		if err != nil {
+			return err
+		}
+		var challenge Challenge
+		json.Unmarshal(challengeAsBytes, &challenge)
+		if challenge.State != CHALLENGE_OPEN || txTime < challenge.StartTime || txTime >= challenge.EndTime {
+			continue
+		}
+
+		//get participant entry
+		entryKey, err := stub.CreateCompositeKey(prefixChallengeEntry, []string{challenge.Id, userId})
+		if err != nil {
+			return err
+		}
+		entryAsBytes, err := stub.GetState(entryKey)
+		if err != nil {
+			return err
+		}
+		var entry ChallengeEntry
+		if entryAsBytes != nil {
+			json.Unmarshal(entryAsBytes, &entry)
+		} else {
+			entry.ChallengeId = challenge.Id
+			entry.UserId = userId
+		}
+
+		//update steps and remember when the goal was first reached, used to break ties
+		entry.Steps = entry.Steps + newSteps
+		if entry.GoalReachedAt == 0 && entry.Steps >= challenge.StepGoal {
+			entry.GoalReachedAt = txTime
+		}
+
+		//store participant entry
+		updatedEntryAsBytes, _ := json.Marshal(entry)
+		err = stub.PutState(entryKey, updatedEntryAsBytes)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ============================================================================================================================
+// Settle Challenge - rank participants once the window has closed and pay out the prize pool
+// participants that reached the step goal share the pool equally, any remainder goes one fitcoin at a time
+// to the highest ranked winners, and a pool without winners is refunded to the admin
+// Inputs - adminId, challengeId
+// ============================================================================================================================
+func (t *SimpleChaincode) settleChallenge(stub shim.ChaincodeStubInterface, args []string) pb.Response {
+	if len(args) != 2 {
+		return shim.Error("Incorrect number of arguments")
+	}
+
+	//get adminId and challengeId from args
+	admin_id := args[0]
+	challenge_id := args[1]
+
+	//get challenge
+	challengeAsBytes, err := stub.GetState(challenge_id)
+	if err != nil {
+		return shim.Error("Failed to get challenge")
+	}
+	var challenge Challenge
+	json.Unmarshal(challengeAsBytes, &challenge)
+	if challenge.Id == "" {
+		return shim.Error("Challenge not found")
+	}
+
+	//ensure call is made by the challenge admin
+	if admin_id != challenge.AdminId {
+		return shim.Error("Member not authorized to settle challenge")
+	}
+	if challenge.State != CHALLENGE_OPEN {
+		return shim.Error("Challenge already settled")
+	}
+
+	//the window has to be closed
+	txTime, err := getTxTime(stub)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	if txTime < challenge.EndTime {
+		return shim.Error("Challenge window has not closed yet")
+	}
+
+	//rank participants
+	entries, err := getRankedChallengeEntries(stub, challenge)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	//count winners
+	var winners = 0
+	for _, entry := range entries {
+		if entry.Steps >= challenge.StepGoal {
+			winners++
+		}
+	}
+
+	//pay out prize pool to the winners
+	var paid = 0
+	if winners > 0 {
+		share := challenge.PrizePool / winners
+		remainder := challenge.PrizePool % winners
+		for h := 0; h < winners; h++ {
+			prize := share
+			if h < remainder {
+				prize = prize + 1
+			}
+
+			//get user
+			var user User
+			userAsBytes, err := stub.GetState(entries[h].UserId)
+			if err != nil {
+				return shim.Error("Failed to get user")
+			}
+			json.Unmarshal(userAsBytes, &user)
+			if user.Type != TYPE_USER {
+				return shim.Error("Not user type")
+			}
+
+			//update user's FitcoinsBalance
+			user.FitcoinsBalance = user.FitcoinsBalance + prize
+			updatedUserAsBytes, _ := json.Marshal(user)
+			err = stub.PutState(user.Id, updatedUserAsBytes)
+			if err != nil {
+				return shim.Error(err.Error())
+			}
+
+			paid = paid + prize
+
+			challenge.Results = append(challenge.Results, ChallengeResult{
+				Rank:   h + 1,
+				UserId: user.Id,
+				Steps:  entries[h].Steps,
+				Prize:  prize,
+			})
+		}
+	}
+
+	//refund whatever was not paid out to the admin that funded the pool
+	if paid < challenge.PrizePool {
+		var admin Member
+		adminAsBytes, err := stub.GetState(challenge.AdminId)
+		if err != nil {
+			return shim.Error("Failed to get admin")
+		}
+		json.Unmarshal(adminAsBytes, &admin)
+		if admin.Type != TYPE_ADMIN {
+			return shim.Error("Not admin type")
+		}
+		challenge.RefundedPrize = challenge.PrizePool - paid
+		admin.FitcoinsBalance = admin.FitcoinsBalance + challenge.RefundedPrize
+		updatedAdminAsBytes, _ := json.Marshal(admin)
+		err = stub.PutState(admin.Id, updatedAdminAsBytes)
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+	}
+
+	//update challenge state
+	challenge.State = CHALLENGE_SETTLED
+	updatedChallengeAsBytes, _ := json.Marshal(challenge)
+	err = stub.PutState(challenge.Id, updatedChallengeAsBytes)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	//return challenge info
+	return shim.Success(updatedChallengeAsBytes)
+}
+
+// ============================================================================================================================
+// Get challenge leaderboard - current ranking of all participants in a challenge
+// Inputs - challengeId
+// ============================================================================================================================
+func (t *SimpleChaincode) getChallengeLeaderboard(stub shim.ChaincodeStubInterface, args []string) pb.Response {
+	if len(args) != 1 {
+		return shim.Error("Incorrect number of arguments")
+	}
+
+	//get challenge
+	challengeAsBytes, err := stub.GetState(args[0])
+	if err != nil {
+		return shim.Error("Failed to get challenge")
+	}
+	var challenge Challenge
+	json.Unmarshal(challengeAsBytes, &challenge)
+	if challenge.Id == "" {
+		return shim.Error("Challenge not found")
+	}
+
+	//rank participants
+	entries, err := getRankedChallengeEntries(stub, challenge)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	var leaderboard []ChallengeResult
+	for h, entry := range entries {
+		leaderboard = append(leaderboard, ChallengeResult{
+			Rank:   h + 1,
+			UserId: entry.UserId,
+			Steps:  entry.Steps,
+		})
+	}
+
+	//change to array of bytes
+	leaderboardAsBytes, _ := json.Marshal(leaderboard)
+	return shim.Success(leaderboardAsBytes)
+}
+
+// ============================================================================================================================
+// Get all challenges
+// Inputs - (none)
+// ============================================================================================================================
+func (t *SimpleChaincode) getAllChallenges(stub shim.ChaincodeStubInterface, args []string) pb.Response {
+	//get challengeIds
+	challengeIdsBytes, err := stub.GetState("challengeIds")
+	if err != nil {
+		return shim.Error("Unable to get challenges.")
+	}
+	var challengeIds []string
+	json.Unmarshal(challengeIdsBytes, &challengeIds)
+
+	var challenges []Challenge
+	for _, challengeId := range challengeIds {
+		challengeAsBytes, err := stub.GetState(challengeId)
+		if err != nil {
+			return shim.Error("Failed to get challenge")
+		}
+		var challenge Challenge
+		json.Unmarshal(challengeAsBytes, &challenge)
+		challenges = append(challenges, challenge)
+	}
+
+	//change to array of bytes
+	challengesAsBytes, _ := json.Marshal(challenges)
+	return shim.Success(challengesAsBytes)
+}
+
+// get participant entries ordered by rank
+// participants that reached the goal rank first, then more steps, then reaching the goal earlier, then userId
+func getRankedChallengeEntries(stub shim.ChaincodeStubInterface, challenge Challenge) ([]ChallengeEntry, error) {
+	resultsIterator, err := stub.GetStateByPartialCompositeKey(prefixChallengeEntry, []string{challenge.Id})
+	if err != nil {
+		return nil, err
+	}
+	defer resultsIterator.Close()
+
+	var entries []ChallengeEntry
+	for resultsIterator.HasNext() {
+		aKeyValue, err := resultsIterator.Next()
+		if err != nil {
+			return nil, err
+		}
+		var entry ChallengeEntry
+		json.Unmarshal(aKeyValue.Value, &entry)
+		entries = append(entries, entry)
+	}
+
+	sort.Stable(rankedEntries{entries, challenge.StepGoal})
+	return entries, nil
+}
+
+// participant entries sorted by rank for a challenge's step goal
+type rankedEntries struct {
+	entries  []ChallengeEntry
+	stepGoal int
+}
+
+func (r rankedEntries) Len() int      { return len(r.entries) }
+func (r rankedEntries) Swap(i, j int) { r.entries[i], r.entries[j] = r.entries[j], r.entries[i] }
+func (r rankedEntries) Less(i, j int) bool {
+	a, b := r.entries[i], r.entries[j]
+	aReached, bReached := a.Steps >= r.stepGoal, b.Steps >= r.stepGoal
+	if aReached != bReached {
+		return aReached
+	}
+	if a.Steps != b.Steps {
+		return a.Steps > b.Steps
+	}
+	if a.GoalReachedAt != b.GoalReachedAt {
+		return a.GoalReachedAt < b.GoalReachedAt
+	}
+	return a.UserId < b.UserId
+}
+
+// get the transaction timestamp in unix seconds, the same on every endorsing peer
+func getTxTime(stub shim.ChaincodeStubInterface) (int64, error) {
+	txTimestamp, err := stub.GetTxTimestamp()
+	if err != nil {
+		return 0, err
+	}
+	return txTimestamp.Seconds, nil
+}
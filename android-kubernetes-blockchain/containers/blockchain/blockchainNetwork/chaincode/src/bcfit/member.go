@@ -30,7 +30,7 @@ import (
 
 // ============================================================================================================================
 // Create member
-// Inputs - id, type(user or seller)
+// Inputs - id, type(user, seller or admin)
 // ============================================================================================================================
 func (t *SimpleChaincode) createMember(stub shim.ChaincodeStubInterface, args []string) pb.Response {
 	var err error
@@ -94,6 +94,44 @@ func (t *SimpleChaincode) createMember(stub shim.ChaincodeStubInterface, args []
 		//return seller info
 		return shim.Success(sellerAsBytes)
 
+	} else if member_type == TYPE_ADMIN {
+		//check if type is 'admin'
+
+		//only members listed when the chaincode was instantiated can register as admin, and only once
+		adminsAsBytes, err := stub.GetState(KEY_ADMINS)
+		if err != nil {
+			return shim.Error("Failed to get admins")
+		}
+		var admins map[string]int
+		json.Unmarshal(adminsAsBytes, &admins)
+		fitcoins, found := admins[member_id]
+		if !found {
+			return shim.Error("Member not authorized to register as admin")
+		}
+		existingAsBytes, err := stub.GetState(member_id)
+		if err != nil {
+			return shim.Error("Failed to get member")
+		}
+		if existingAsBytes != nil {
+			return shim.Error("Id " + member_id + " is already in use")
+		}
+
+		//create admin
+		var admin Member
+		admin.Id = member_id
+		admin.Type = TYPE_ADMIN
+		admin.FitcoinsBalance = fitcoins
+
+		//store admin
+		adminAsBytes, _ := json.Marshal(admin)
+		err = stub.PutState(admin.Id, adminAsBytes)
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+
+		//return admin info
+		return shim.Success(adminAsBytes)
+
 	}
 
 	return shim.Success(nil)
@@ -128,6 +166,9 @@ func (t *SimpleChaincode) generateFitcoins(stub shim.ChaincodeStubInterface, arg
 		return shim.Error("Not user type")
 	}
 
+	//steps submitted since the last call count toward open challenges
+	var challengeSteps = newTransactionSteps - user.TotalSteps
+
 	//update user account
 	var newSteps = newTransactionSteps - user.StepsUsedForConversion
 	var newFitcoins = 0
@@ -146,6 +187,37 @@ func (t *SimpleChaincode) generateFitcoins(stub shim.ChaincodeStubInterface, arg
 		}
 	}
 
+	//credit steps to challenges
+	if challengeSteps > 0 {
+		err = recordChallengeSteps(stub, user_id, challengeSteps)
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+
+		//keep total steps even when no fitcoins were generated, so the same steps are not counted twice
+		if user.TotalSteps != newTransactionSteps {
+			user.TotalSteps = newTransactionSteps
+			updatedUserAsBytes, _ := json.Marshal(user)
+			err = stub.PutState(user_id, updatedUserAsBytes)
+			if err != nil {
+				return shim.Error(err.Error())
+			}
+		}
+	}
+
+	//notify listeners about minted fitcoins
+	if newFitcoins > 0 {
+		var payload FitcoinsGeneratedPayload
+		payload.UserId = user.Id
+		payload.GeneratedFitcoins = newFitcoins
+		payload.FitcoinsBalance = user.FitcoinsBalance
+		payload.TotalSteps = user.TotalSteps
+		err = emitEvent(stub, EVENT_FITCOINS_GENERATED, payload)
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+	}
+
 	//return updated user with GeneratedFitcoins and GeneratedSteps
 	type ReturnUser struct {
 		User
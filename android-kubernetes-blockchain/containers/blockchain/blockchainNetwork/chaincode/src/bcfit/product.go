@@ -73,25 +73,24 @@ func (t *SimpleChaincode) updateProduct(stub shim.ChaincodeStubInterface, args [
 		return shim.Error("Not seller type")
 	}
 
+	//product with the new properties
+	var product Product
+	product.Id = product_id
+	product.Name = newProductName
+	product.Count = newProductCount
+	product.Price = newProductPrice
+
 	//find the product and update the properties
 	productFound := false
 	for h := 0; h < len(seller.Products); h++ {
 		if seller.Products[h].Id == product_id {
 			productFound = true
-			seller.Products[h].Name = newProductName
-			seller.Products[h].Count = newProductCount
-			seller.Products[h].Price = newProductPrice
+			seller.Products[h] = product
 			break
 		}
 	}
-	//if product not found return error
+	//if product not found append it
 	if productFound != true {
-		var product Product
-		product.Id = product_id
-		product.Name = newProductName
-		product.Count = newProductCount
-		product.Price = newProductPrice
-		//append product
 		seller.Products = append(seller.Products, product)
 	}
 
@@ -102,6 +101,15 @@ func (t *SimpleChaincode) updateProduct(stub shim.ChaincodeStubInterface, args [
 		return shim.Error(err.Error())
 	}
 
+	//notify listeners about the product change
+	var payload ProductUpdatedPayload
+	payload.SellerId = seller_id
+	payload.Product = product
+	err = emitEvent(stub, EVENT_PRODUCT_UPDATED, payload)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
 	//return seller info
 	return shim.Success(updatedSellerAsBytes)
 
@@ -22,6 +22,7 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
 
 	"github.com/hyperledger/fabric/core/chaincode/shim"
 	pb "github.com/hyperledger/fabric/protos/peer"
@@ -34,10 +35,22 @@ const STEPS_TO_FITCOIN = 100
 const STATE_COMPLETE = "complete"
 const STATE_PENDING = "pending"
 const STATE_DECLINED = "declined"
+const STATE_PARTIAL = "partial"
 
 //member type
 const TYPE_USER = "user"
 const TYPE_SELLER = "seller"
+const TYPE_ADMIN = "admin"
+
+//challenge state
+const CHALLENGE_OPEN = "open"
+const CHALLENGE_SETTLED = "settled"
+
+//composite key prefix for challenge participants
+const prefixChallengeEntry = "challengeEntry"
+
+//key of the admins allowed to register, with the fitcoins each starts with
+const KEY_ADMINS = "admins"
 
 // SimpleChaincode example simple Chaincode implementation
 type SimpleChaincode struct {
@@ -74,14 +87,48 @@ type Product struct {
 
 // Contract
 type Contract struct {
-	Id          string `json:"id"`
-	SellerId    string `json:"sellerId"`
-	UserId      string `json:"userId"`
-	ProductId   string `json:"productId"`
-	ProductName string `json:"productName"`
-	Quantity    int    `json:"quantity"`
-	Cost        int    `json:"cost"`
-	State       string `json:"state"`
+	Id                  string `json:"id"`
+	SellerId            string `json:"sellerId"`
+	UserId              string `json:"userId"`
+	ProductId           string `json:"productId"`
+	ProductName         string `json:"productName"`
+	Quantity            int    `json:"quantity"`
+	Cost                int    `json:"cost"`
+	State               string `json:"state"`
+	DeliveredQuantity   int    `json:"deliveredQuantity"`
+	BackorderedQuantity int    `json:"backorderedQuantity"`
+	ChargedCost         int    `json:"chargedCost"`
+	RefundedCost        int    `json:"refundedCost"`
+}
+
+// Challenge - step challenge with a time window and fitcoin prize pool
+type Challenge struct {
+	Id            string            `json:"id"`
+	AdminId       string            `json:"adminId"`
+	Name          string            `json:"name"`
+	StartTime     int64             `json:"startTime"`
+	EndTime       int64             `json:"endTime"`
+	StepGoal      int               `json:"stepGoal"`
+	PrizePool     int               `json:"prizePool"`
+	State         string            `json:"state"`
+	Results       []ChallengeResult `json:"results"`
+	RefundedPrize int               `json:"refundedPrize"`
+}
+
+// ChallengeEntry - steps a user submitted during a challenge window
+type ChallengeEntry struct {
+	ChallengeId   string `json:"challengeId"`
+	UserId        string `json:"userId"`
+	Steps         int    `json:"steps"`
+	GoalReachedAt int64  `json:"goalReachedAt"`
+}
+
+// ChallengeResult - ranked participant and the fitcoins they won
+type ChallengeResult struct {
+	Rank   int    `json:"rank"`
+	UserId string `json:"userId"`
+	Steps  int    `json:"steps"`
+	Prize  int    `json:"prize"`
 }
 
 // ============================================================================================================================
@@ -96,9 +143,45 @@ func main() {
 
 // ============================================================================================================================
 // Init - initialize the chaincode
+// Inputs - adminId, fitcoins pairs for the members allowed to register as admin, optional
+//          an explicit "init" function name ahead of the pairs is skipped, a single empty arg lists no admins
 // ============================================================================================================================
 func (t *SimpleChaincode) Init(stub shim.ChaincodeStubInterface) pb.Response {
 
+	//store admins with the fitcoins they can put into challenge prize pools
+	//the node sdk always sends "init" as the function name, the peer cli sends only the args it is given
+	args := stub.GetStringArgs()
+	if len(args) > 0 && args[0] == "init" {
+		args = args[1:]
+	}
+	if len(args) == 1 && args[0] == "" {
+		args = nil
+	}
+	if len(args)%2 != 0 {
+		return shim.Error("Admins must be given as adminId, fitcoins pairs")
+	}
+	admins := make(map[string]int)
+	for h := 0; h < len(args); h = h + 2 {
+		fitcoins, err := strconv.Atoi(args[h+1])
+		if err != nil || fitcoins < 0 {
+			return shim.Error("Admin fitcoins must be a non-negative numeric string")
+		}
+		admins[args[h]] = fitcoins
+	}
+
+	//an upgrade runs Init again, keep the admins that are already stored
+	existingAdmins, err := stub.GetState(KEY_ADMINS)
+	if err != nil {
+		return shim.Error("Failed to get admins")
+	}
+	if existingAdmins == nil {
+		adminsAsBytes, _ := json.Marshal(admins)
+		err = stub.PutState(KEY_ADMINS, adminsAsBytes)
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+	}
+
 	//store sellerIds
 	var sellerIds []string
 	sellerIdsBytes, err := json.Marshal(sellerIds)
@@ -141,6 +224,14 @@ func (t *SimpleChaincode) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 		return t.getAllUserContracts(stub, args)
 	} else if function == "getAllContracts" {
 		return t.getAllContracts(stub, args)
+	} else if function == "createChallenge" {
+		return t.createChallenge(stub, args)
+	} else if function == "settleChallenge" {
+		return t.settleChallenge(stub, args)
+	} else if function == "getChallengeLeaderboard" {
+		return t.getChallengeLeaderboard(stub, args)
+	} else if function == "getAllChallenges" {
+		return t.getAllChallenges(stub, args)
 	}
 
 	return shim.Error("Function with the name " + function + " does not exist.")
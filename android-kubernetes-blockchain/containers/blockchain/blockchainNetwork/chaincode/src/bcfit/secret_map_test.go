@@ -0,0 +1,95 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+)
+
+func toArgs(args ...string) [][]byte {
+	bytes := make([][]byte, len(args))
+	for h, arg := range args {
+		bytes[h] = []byte(arg)
+	}
+	return bytes
+}
+
+func getAdmins(t *testing.T, stub *shim.MockStub) map[string]int {
+	adminsAsBytes := stub.State[KEY_ADMINS]
+	if adminsAsBytes == nil {
+		t.Fatalf("admins were not stored")
+	}
+	var admins map[string]int
+	if err := json.Unmarshal(adminsAsBytes, &admins); err != nil {
+		t.Fatalf("admins did not unmarshal: %s", err)
+	}
+	return admins
+}
+
+func TestInitAdmins(t *testing.T) {
+	tests := []struct {
+		name   string
+		args   []string
+		admins map[string]int
+	}{
+		{"no args", nil, map[string]int{}},
+		{"single empty arg", []string{""}, map[string]int{}},
+		{"init and single empty arg", []string{"init", ""}, map[string]int{}},
+		{"init only", []string{"init"}, map[string]int{}},
+		{"pairs", []string{"admin1", "5000"}, map[string]int{"admin1": 5000}},
+		{"init and pairs", []string{"init", "admin1", "5000", "admin2", "0"}, map[string]int{"admin1": 5000, "admin2": 0}},
+	}
+	for _, test := range tests {
+		stub := shim.NewMockStub("bcfit", new(SimpleChaincode))
+		res := stub.MockInit("tx1", toArgs(test.args...))
+		if res.Status != shim.OK {
+			t.Errorf("%s: Init failed: %s", test.name, res.Message)
+			continue
+		}
+		admins := getAdmins(t, stub)
+		if len(admins) != len(test.admins) {
+			t.Errorf("%s: expected admins %v, got %v", test.name, test.admins, admins)
+			continue
+		}
+		for id, fitcoins := range test.admins {
+			if admins[id] != fitcoins {
+				t.Errorf("%s: expected admin %s with %d fitcoins, got %v", test.name, id, fitcoins, admins)
+			}
+		}
+	}
+}
+
+func TestInitRejectsBadAdmins(t *testing.T) {
+	tests := [][]string{
+		{"admin1"},
+		{"init", "admin1", "5000", "admin2"},
+		{"admin1", "lots"},
+		{"admin1", "-5"},
+	}
+	for _, args := range tests {
+		stub := shim.NewMockStub("bcfit", new(SimpleChaincode))
+		res := stub.MockInit("tx1", toArgs(args...))
+		if res.Status == shim.OK {
+			t.Errorf("expected Init to reject %v", args)
+		}
+	}
+}
+
+func TestInitKeepsExistingAdmins(t *testing.T) {
+	stub := shim.NewMockStub("bcfit", new(SimpleChaincode))
+	res := stub.MockInit("tx1", toArgs("admin1", "5000"))
+	if res.Status != shim.OK {
+		t.Fatalf("Init failed: %s", res.Message)
+	}
+
+	//an upgrade runs Init again with whatever args it is given
+	res = stub.MockInit("tx2", toArgs("init", ""))
+	if res.Status != shim.OK {
+		t.Fatalf("second Init failed: %s", res.Message)
+	}
+	admins := getAdmins(t, stub)
+	if len(admins) != 1 || admins["admin1"] != 5000 {
+		t.Errorf("expected admin1 with 5000 fitcoins to be kept, got %v", admins)
+	}
+}
@@ -137,7 +137,7 @@ function getAdminOrgs() {
   // Instantiate chaincode on all peers
   // Instantiating the chaincode on a single peer should be enough (for now)
   try {
-    await clients[0].instantiate(config.chaincodeId, config.chaincodeVersion, config.chaincodePath, '{"Args":[""]}');
+    await clients[0].instantiate(config.chaincodeId, config.chaincodeVersion, config.chaincodePath, ...(config.chaincodeAdmins || []));
     console.log('Successfully instantiated chaincode on all peers.');
   } catch(e) {
     console.log('Fatal error instantiating chaincode on some(all) peers!');
@@ -9,6 +9,8 @@ const config = {
   chaincodeId: 'bcfit',
   chaincodeVersion: '1',
   chaincodePath: 'bcfit',
+  // adminId, fitcoins pairs allowed to register as admin, e.g. ['admin1', '5000']
+  chaincodeAdmins: [],
   rabbitmq: 'YOUR_RABBITMQ_URL',
   redisUrl: 'YOUR_REDIS_URL',
   orderer: {
@@ -31,8 +31,20 @@ type contract struct {
 	Void             bool      `json:"void"`
 	ContractTypeUUID string    `json:"contract_type_uuid"`
 	ClaimIndex       []string  `json:"claim_index,omitempty"`
+	Cancelled        bool      `json:"cancelled"`
+	CancelDate       time.Time `json:"cancel_date,omitempty"`
+	Refund           float32   `json:"refund,omitempty"`
+	Renewals         int32     `json:"renewals,omitempty"`
 }
 
+// Status of a contract, derived from its flags and coverage window
+const (
+	contractStatusActive    = "active"
+	contractStatusExpired   = "expired"
+	contractStatusCancelled = "cancelled"
+	contractStatusVoid      = "void"
+)
+
 // Entity not persisted on its own
 type item struct {
 	ID          int32   `json:"id"`
@@ -53,6 +65,7 @@ type claim struct {
 	Reimbursable  float32     `json:"reimbursable"`
 	Repaired      bool        `json:"repaired"`
 	FileReference string      `json:"file_reference"`
+	FraudFlags    []fraudFlag `json:"fraud_flags,omitempty"`
 }
 
 // The claim status indicates how the claim should be treated
@@ -188,6 +201,29 @@ func (c *contract) Claims(stub shim.ChaincodeStubInterface) ([]claim, error) {
 	return claims, nil
 }
 
+func (c *contract) Status(now time.Time) string {
+	switch {
+	case c.Void:
+		return contractStatusVoid
+	case c.Cancelled:
+		return contractStatusCancelled
+	case now.After(c.EndDate):
+		return contractStatusExpired
+	}
+	return contractStatusActive
+}
+
+// Checks whether the date falls inside the coverage window of the contract
+func (c *contract) Covers(date time.Time) bool {
+	if date.Before(c.StartDate) || date.After(c.EndDate) {
+		return false
+	}
+	if c.Cancelled && date.After(c.CancelDate) {
+		return false
+	}
+	return true
+}
+
 func (c *contract) User(stub shim.ChaincodeStubInterface) (*user, error) {
 	user := &user{}
 
@@ -245,3 +281,32 @@ func (c *claim) Contract(stub shim.ChaincodeStubInterface) (*contract, error) {
 	}
 	return nil, nil
 }
+
+func (c *contract) ContractType(stub shim.ChaincodeStubInterface) (*contractType, error) {
+	key, err := stub.CreateCompositeKey(prefixContractType, []string{c.ContractTypeUUID})
+	if err != nil {
+		return nil, err
+	}
+	contractTypeAsBytes, err := stub.GetState(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(contractTypeAsBytes) == 0 {
+		return nil, errors.New("Contract Type could not be found")
+	}
+	ct := &contractType{}
+	err = json.Unmarshal(contractTypeAsBytes, ct)
+	if err != nil {
+		return nil, err
+	}
+	return ct, nil
+}
+
+// The transaction timestamp, identical on all endorsing peers
+func txTime(stub shim.ChaincodeStubInterface) (time.Time, error) {
+	ts, err := stub.GetTxTimestamp()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(ts.Seconds, int64(ts.Nanos)).UTC(), nil
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+)
+
+// Splits a formula into numbers, identifiers, operators and parentheses,
+// everything else is dropped, the same way the shop web app filters formulas
+var formulaLexemes = regexp.MustCompile(`[0-9]+\.[0-9]+|[0-9]+|[A-Za-z]+|[()+\-*/]`)
+
+// Evaluates the contract type formula per day (for example "price * 0.01 + 0.05")
+// for the given item price
+func evalFormulaPerDay(formula string, price float32) (float32, error) {
+	p := &formulaParser{lexemes: formulaLexemes.FindAllString(formula, -1), price: float64(price)}
+	if len(p.lexemes) == 0 {
+		return 0, errors.New("Empty formula.")
+	}
+	value, err := p.expression()
+	if err != nil {
+		return 0, err
+	}
+	if p.pos != len(p.lexemes) {
+		return 0, errors.New("Unexpected token in formula: " + p.lexemes[p.pos])
+	}
+	return float32(value), nil
+}
+
+type formulaParser struct {
+	lexemes []string
+	pos     int
+	price   float64
+}
+
+func (p *formulaParser) peek() string {
+	if p.pos < len(p.lexemes) {
+		return p.lexemes[p.pos]
+	}
+	return ""
+}
+
+// expression = term { ("+" | "-") term }
+func (p *formulaParser) expression() (float64, error) {
+	value, err := p.term()
+	if err != nil {
+		return 0, err
+	}
+	for p.peek() == "+" || p.peek() == "-" {
+		op := p.peek()
+		p.pos++
+		right, err := p.term()
+		if err != nil {
+			return 0, err
+		}
+		if op == "+" {
+			value += right
+		} else {
+			value -= right
+		}
+	}
+	return value, nil
+}
+
+// term = factor { ("*" | "/") factor }
+func (p *formulaParser) term() (float64, error) {
+	value, err := p.factor()
+	if err != nil {
+		return 0, err
+	}
+	for p.peek() == "*" || p.peek() == "/" {
+		op := p.peek()
+		p.pos++
+		right, err := p.factor()
+		if err != nil {
+			return 0, err
+		}
+		if op == "*" {
+			value *= right
+		} else {
+			if right == 0 {
+				return 0, errors.New("Division by zero in formula.")
+			}
+			value /= right
+		}
+	}
+	return value, nil
+}
+
+// factor = number | "price" | "(" expression ")" | "-" factor
+func (p *formulaParser) factor() (float64, error) {
+	lexeme := p.peek()
+	switch {
+	case lexeme == "":
+		return 0, errors.New("Unexpected end of formula.")
+	case lexeme == "(":
+		p.pos++
+		value, err := p.expression()
+		if err != nil {
+			return 0, err
+		}
+		if p.peek() != ")" {
+			return 0, errors.New("Missing closing parenthesis in formula.")
+		}
+		p.pos++
+		return value, nil
+	case lexeme == "-":
+		p.pos++
+		value, err := p.factor()
+		return -value, err
+	case lexeme == "price":
+		p.pos++
+		return p.price, nil
+	}
+	value, err := strconv.ParseFloat(lexeme, 64)
+	if err != nil {
+		return 0, errors.New("Unexpected token in formula: " + lexeme)
+	}
+	p.pos++
+	return value, nil
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+)
+
+// Reasons a claim can be flagged for
+const (
+	// The same item serial number has been claimed on a different contract
+	fraudReasonDuplicateSerial = "duplicate_serial"
+	// The item has already been reimbursed on an earlier claim
+	fraudReasonClaimAfterReimbursement = "claim_after_reimbursement"
+	// Another claim for the item is still being processed
+	fraudReasonOpenClaimSameItem = "open_claim_same_item"
+	// The user filed many claims in a short period of time
+	fraudReasonFrequentClaims = "frequent_claims"
+	// The police file reference was already used for another claim
+	fraudReasonPoliceReportReused = "police_report_reused"
+)
+
+// Number of claims by the same user within the window that gets the claim flagged
+const frequentClaimsThreshold = 3
+const frequentClaimsWindow = 365 * 24 * time.Hour
+
+// Entity not persisted on its own
+type fraudFlag struct {
+	Reason       string `json:"reason"`
+	Description  string `json:"description"`
+	ContractUUID string `json:"contract_uuid,omitempty"`
+	ClaimUUID    string `json:"claim_uuid,omitempty"`
+}
+
+// Index keys map an attribute to the claims carrying it,
+// the last two key parts are always the contract UUID and the claim UUID
+var indexValue = []byte{0x00}
+
+type indexedClaim struct {
+	ContractUUID string
+	UUID         string
+	*claim
+}
+
+// Loads all claims referenced by the index entries for the given attribute
+func claimsByIndex(stub shim.ChaincodeStubInterface, prefix string, attribute string) ([]indexedClaim, error) {
+	resultsIterator, err := stub.GetStateByPartialCompositeKey(prefix, []string{attribute})
+	if err != nil {
+		return nil, err
+	}
+	defer resultsIterator.Close()
+
+	results := []indexedClaim{}
+	for resultsIterator.HasNext() {
+		kvResult, err := resultsIterator.Next()
+		if err != nil {
+			return nil, err
+		}
+		_, keyParts, err := stub.SplitCompositeKey(kvResult.Key)
+		if err != nil {
+			return nil, err
+		}
+		if len(keyParts) != 3 {
+			continue
+		}
+
+		claimKey, err := stub.CreateCompositeKey(prefixClaim, keyParts[1:])
+		if err != nil {
+			return nil, err
+		}
+		claimAsBytes, err := stub.GetState(claimKey)
+		if err != nil {
+			return nil, err
+		}
+		if len(claimAsBytes) == 0 {
+			continue
+		}
+		c := &claim{}
+		err = json.Unmarshal(claimAsBytes, c)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, indexedClaim{ContractUUID: keyParts[1], UUID: keyParts[2], claim: c})
+	}
+	return results, nil
+}
+
+// Adds an index entry pointing from the attribute to the claim
+func indexClaim(stub shim.ChaincodeStubInterface, prefix string, attribute string, contractUUID string, claimUUID string) error {
+	if len(attribute) == 0 {
+		return nil
+	}
+	key, err := stub.CreateCompositeKey(prefix, []string{attribute, contractUUID, claimUUID})
+	if err != nil {
+		return err
+	}
+	return stub.PutState(key, indexValue)
+}
+
+// Screens a new claim against earlier claims on the same item serial number
+// and by the same user, returning the reasons the claim looks suspicious
+func screenClaim(stub shim.ChaincodeStubInterface, contract *contract, c *claim, claimUUID string) ([]fraudFlag, error) {
+	flags := []fraudFlag{}
+
+	if len(contract.Item.SerialNo) > 0 {
+		related, err := claimsByIndex(stub, prefixClaimSerialIndex, contract.Item.SerialNo)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range related {
+			if r.UUID == claimUUID && r.ContractUUID == c.ContractUUID {
+				continue
+			}
+			if r.ContractUUID != c.ContractUUID {
+				flags = append(flags, fraudFlag{
+					Reason:       fraudReasonDuplicateSerial,
+					Description:  fmt.Sprintf("Serial number %s was also claimed on contract %s.", contract.Item.SerialNo, r.ContractUUID),
+					ContractUUID: r.ContractUUID,
+					ClaimUUID:    r.UUID,
+				})
+			}
+			switch r.Status {
+			case ClaimStatusReimbursement:
+				flags = append(flags, fraudFlag{
+					Reason:       fraudReasonClaimAfterReimbursement,
+					Description:  fmt.Sprintf("Serial number %s was already reimbursed on claim %s.", contract.Item.SerialNo, r.UUID),
+					ContractUUID: r.ContractUUID,
+					ClaimUUID:    r.UUID,
+				})
+			case ClaimStatusNew, ClaimStatusTheftConfirmed:
+				flags = append(flags, fraudFlag{
+					Reason:       fraudReasonOpenClaimSameItem,
+					Description:  fmt.Sprintf("Claim %s for serial number %s is still open.", r.UUID, contract.Item.SerialNo),
+					ContractUUID: r.ContractUUID,
+					ClaimUUID:    r.UUID,
+				})
+			}
+		}
+	}
+
+	related, err := claimsByIndex(stub, prefixClaimUserIndex, contract.Username)
+	if err != nil {
+		return nil, err
+	}
+	recent := 1 // The claim being filed
+	for _, r := range related {
+		if r.UUID == claimUUID && r.ContractUUID == c.ContractUUID {
+			continue
+		}
+		delta := c.Date.Sub(r.Date)
+		if delta < 0 {
+			delta = -delta
+		}
+		if delta <= frequentClaimsWindow {
+			recent++
+		}
+	}
+	if recent >= frequentClaimsThreshold {
+		flags = append(flags, fraudFlag{
+			Reason:      fraudReasonFrequentClaims,
+			Description: fmt.Sprintf("User %s filed %d claims within %d days.", contract.Username, recent, int(frequentClaimsWindow.Hours()/24)),
+		})
+	}
+
+	return flags, nil
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"encoding/json"
+	"fmt"
 
 	"strings"
 
@@ -154,6 +155,7 @@ func setActiveContractType(stub shim.ChaincodeStubInterface, args []string) pb.R
 func listContracts(stub shim.ChaincodeStubInterface, args []string) pb.Response {
 	input := struct {
 		Username string `json:"username"`
+		Status   string `json:"status"`
 	}{}
 	if len(args) == 1 {
 		err := json.Unmarshal([]byte(args[0]), &input)
@@ -162,9 +164,14 @@ func listContracts(stub shim.ChaincodeStubInterface, args []string) pb.Response
 		}
 	}
 	filterByUsername := len(input.Username) > 0
+	filterByStatus := len(input.Status) > 0
+
+	now, err := txTime(stub)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
 
 	var resultsIterator shim.StateQueryIteratorInterface
-	var err error
 	// Filtering by username if required
 	if filterByUsername {
 		resultsIterator, err = stub.GetStateByPartialCompositeKey(prefixContract, []string{input.Username})
@@ -188,6 +195,7 @@ func listContracts(stub shim.ChaincodeStubInterface, args []string) pb.Response
 		result := struct {
 			UUID string `json:"uuid"`
 			*contract
+			Status string  `json:"status"`
 			Claims []claim `json:"claims,omitempty"`
 		}{}
 
@@ -196,6 +204,12 @@ func listContracts(stub shim.ChaincodeStubInterface, args []string) pb.Response
 			return shim.Error(err.Error())
 		}
 
+		// Skip contracts with a different status, if the status parameter is specified
+		result.Status = result.contract.Status(now)
+		if filterByStatus && !strings.EqualFold(result.Status, input.Status) {
+			continue
+		}
+
 		// Fetch key
 		prefix, keyParts, err := stub.SplitCompositeKey(kvResult.Key)
 		if len(keyParts) == 2 {
@@ -224,15 +238,18 @@ func listContracts(stub shim.ChaincodeStubInterface, args []string) pb.Response
 
 func listClaims(stub shim.ChaincodeStubInterface, args []string) pb.Response {
 	var status ClaimStatus
+	var flaggedOnly bool
 	if len(args) > 0 {
 		input := struct {
-			Status ClaimStatus `json:"status"`
+			Status  ClaimStatus `json:"status"`
+			Flagged bool        `json:"flagged"`
 		}{}
 		err := json.Unmarshal([]byte(args[0]), &input)
 		if err != nil {
 			return shim.Error(err.Error())
 		}
 		status = input.Status
+		flaggedOnly = input.Flagged
 	}
 
 	results := []interface{}{}
@@ -262,6 +279,10 @@ func listClaims(stub shim.ChaincodeStubInterface, args []string) pb.Response {
 		if result.Status != status && status != ClaimStatusUnknown {
 			continue
 		}
+		// Only list claims with fraud flags, if requested
+		if flaggedOnly && len(result.FraudFlags) == 0 {
+			continue
+		}
 
 		// Fetch key
 		prefix, keyParts, err := stub.SplitCompositeKey(kvResult.Key)
@@ -315,6 +336,26 @@ func fileClaim(stub shim.ChaincodeStubInterface, args []string) pb.Response {
 		return shim.Error("Contract could not be found.")
 	}
 
+	// Check if the contract covers the claim
+	if contract.Void {
+		return shim.Error("Contract is void.")
+	}
+	if contract.Cancelled && claim.Date.After(contract.CancelDate) {
+		return shim.Error("Contract has been cancelled.")
+	}
+	if !contract.Covers(claim.Date) {
+		return shim.Error("Claim date is outside the contract coverage.")
+	}
+
+	// Screen the claim against earlier claims on the same item and by the same user
+	claim.FraudFlags, err = screenClaim(stub, contract, &claim, dto.UUID)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	if len(claim.FraudFlags) > 0 {
+		logger.Infof("Claim %s on contract %s flagged: %d reason(s)", dto.UUID, dto.ContractUUID, len(claim.FraudFlags))
+	}
+
 	// Persist the claim
 	claimKey, err := stub.CreateCompositeKey(prefixClaim,
 		[]string{dto.ContractUUID, dto.UUID})
@@ -331,6 +372,16 @@ func fileClaim(stub shim.ChaincodeStubInterface, args []string) pb.Response {
 		return shim.Error(err.Error())
 	}
 
+	// Index the claim by item serial number and by user
+	err = indexClaim(stub, prefixClaimSerialIndex, contract.Item.SerialNo, dto.ContractUUID, dto.UUID)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	err = indexClaim(stub, prefixClaimUserIndex, contract.Username, dto.ContractUUID, dto.UUID)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
 	// Update the claim index in the contract
 	contract.ClaimIndex = append(contract.ClaimIndex, claimKey)
 	contractKey, err := stub.CreateCompositeKey(prefixContract,
@@ -470,6 +521,167 @@ func processClaim(stub shim.ChaincodeStubInterface, args []string) pb.Response {
 	return shim.Success(nil)
 }
 
+func renewContract(stub shim.ChaincodeStubInterface, args []string) pb.Response {
+	if len(args) != 1 {
+		return shim.Error("Invalid argument count.")
+	}
+
+	input := struct {
+		UUID         string `json:"uuid"`
+		Username     string `json:"username"`
+		DurationDays int32  `json:"duration_days"`
+	}{}
+	err := json.Unmarshal([]byte(args[0]), &input)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	contractKey, err := stub.CreateCompositeKey(prefixContract, []string{input.Username, input.UUID})
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	contractBytes, _ := stub.GetState(contractKey)
+	if len(contractBytes) == 0 {
+		return shim.Error("Contract could not be found.")
+	}
+	contract := contract{}
+	err = json.Unmarshal(contractBytes, &contract)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	// Only active contracts can be renewed
+	now, err := txTime(stub)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	if status := contract.Status(now); status != contractStatusActive {
+		return shim.Error("Cannot renew a contract that is " + status + ".")
+	}
+
+	// The renewal has to respect the contract type terms
+	ct, err := contract.ContractType(stub)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	if !ct.Active {
+		return shim.Error("Contract type is no longer offered.")
+	}
+	if input.DurationDays < ct.MinDurationDays || input.DurationDays > ct.MaxDurationDays {
+		return shim.Error(fmt.Sprintf("Renewal must be between %d and %d days.",
+			ct.MinDurationDays, ct.MaxDurationDays))
+	}
+	pricePerDay, err := evalFormulaPerDay(ct.FormulaPerDay, contract.Item.Price)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	// Extend the coverage
+	contract.EndDate = contract.EndDate.AddDate(0, 0, int(input.DurationDays))
+	contract.Renewals++
+
+	contractBytes, err = json.Marshal(contract)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	err = stub.PutState(contractKey, contractBytes)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	response := struct {
+		EndDate time.Time `json:"end_date"`
+		Price   float32   `json:"price"`
+	}{
+		EndDate: contract.EndDate,
+		Price:   pricePerDay * float32(input.DurationDays),
+	}
+	responseBytes, err := json.Marshal(response)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	return shim.Success(responseBytes)
+}
+
+func cancelContract(stub shim.ChaincodeStubInterface, args []string) pb.Response {
+	if len(args) != 1 {
+		return shim.Error("Invalid argument count.")
+	}
+
+	input := struct {
+		UUID     string `json:"uuid"`
+		Username string `json:"username"`
+	}{}
+	err := json.Unmarshal([]byte(args[0]), &input)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	contractKey, err := stub.CreateCompositeKey(prefixContract, []string{input.Username, input.UUID})
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	contractBytes, _ := stub.GetState(contractKey)
+	if len(contractBytes) == 0 {
+		return shim.Error("Contract could not be found.")
+	}
+	contract := contract{}
+	err = json.Unmarshal(contractBytes, &contract)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	// Only active contracts can be cancelled
+	now, err := txTime(stub)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	if status := contract.Status(now); status != contractStatusActive {
+		return shim.Error("Cannot cancel a contract that is " + status + ".")
+	}
+
+	// Refund the full days left, pro-rated with the daily formula of the contract type
+	ct, err := contract.ContractType(stub)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	pricePerDay, err := evalFormulaPerDay(ct.FormulaPerDay, contract.Item.Price)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	start := now
+	if start.Before(contract.StartDate) {
+		start = contract.StartDate
+	}
+	daysLeft := int32(contract.EndDate.Sub(start).Hours() / 24)
+
+	contract.Cancelled = true
+	contract.CancelDate = now
+	contract.Refund = pricePerDay * float32(daysLeft)
+
+	contractBytes, err = json.Marshal(contract)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	err = stub.PutState(contractKey, contractBytes)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	response := struct {
+		Refund   float32 `json:"refund"`
+		DaysLeft int32   `json:"days_left"`
+	}{
+		Refund:   contract.Refund,
+		DaysLeft: daysLeft,
+	}
+	responseBytes, err := json.Marshal(response)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	return shim.Success(responseBytes)
+}
+
 func authUser(stub shim.ChaincodeStubInterface, args []string) pb.Response {
 	if len(args) != 1 {
 		return shim.Error("Invalid argument count.")
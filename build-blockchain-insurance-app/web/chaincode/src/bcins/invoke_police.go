@@ -40,11 +40,12 @@ func listTheftClaims(stub shim.ChaincodeStubInterface, args []string) pb.Respons
 		}
 
 		result := struct {
-			UUID         string `json:"uuid"`
-			ContractUUID string `json:"contract_uuid"`
-			Item         item   `json:"item"`
-			Description  string `json:"description"`
-			Name         string `json:"name"`
+			UUID         string      `json:"uuid"`
+			ContractUUID string      `json:"contract_uuid"`
+			Item         item        `json:"item"`
+			Description  string      `json:"description"`
+			Name         string      `json:"name"`
+			FraudFlags   []fraudFlag `json:"fraud_flags,omitempty"`
 		}{}
 
 		// Fetch key and set other properties
@@ -65,6 +66,7 @@ func listTheftClaims(stub shim.ChaincodeStubInterface, args []string) pb.Respons
 
 		result.Item = contract.Item
 		result.Description = claim.Description
+		result.FraudFlags = claim.FraudFlags
 		result.Name = fmt.Sprintf("%s %s", user.FirstName, user.LastName)
 
 		results = append(results, result)
@@ -121,6 +123,29 @@ func processTheftClaim(stub shim.ChaincodeStubInterface, args []string) pb.Respo
 	}
 	claim.FileReference = dto.FileReference
 
+	// Flag the claim if the police file was already used for another claim
+	if len(dto.FileReference) > 0 {
+		related, err := claimsByIndex(stub, prefixClaimFileReferenceIndex, dto.FileReference)
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		for _, r := range related {
+			if r.UUID == dto.UUID && r.ContractUUID == dto.ContractUUID {
+				continue
+			}
+			claim.FraudFlags = append(claim.FraudFlags, fraudFlag{
+				Reason:       fraudReasonPoliceReportReused,
+				Description:  fmt.Sprintf("File reference %s was already used for claim %s.", dto.FileReference, r.UUID),
+				ContractUUID: r.ContractUUID,
+				ClaimUUID:    r.UUID,
+			})
+		}
+		err = indexClaim(stub, prefixClaimFileReferenceIndex, dto.FileReference, dto.ContractUUID, dto.UUID)
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+	}
+
 	claimAsBytes, err = json.Marshal(claim)
 	if err != nil {
 		return shim.Error(err.Error())
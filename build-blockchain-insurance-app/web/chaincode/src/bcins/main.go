@@ -14,6 +14,9 @@ const prefixContract = "contract"
 const prefixClaim = "claim"
 const prefixUser = "user"
 const prefixRepairOrder = "repair_order"
+const prefixClaimSerialIndex = "claim_serial"
+const prefixClaimUserIndex = "claim_user"
+const prefixClaimFileReferenceIndex = "claim_file_reference"
 
 var logger = shim.NewLogger("main")
 
@@ -26,6 +29,8 @@ var bcFunctions = map[string]func(shim.ChaincodeStubInterface, []string) pb.Resp
 	"contract_type_create":     createContractType,
 	"contract_type_set_active": setActiveContractType,
 	"contract_ls":              listContracts,
+	"contract_renew":           renewContract,
+	"contract_cancel":          cancelContract,
 	"claim_ls":                 listClaims,
 	"claim_file":               fileClaim,
 	"claim_process":            processClaim,
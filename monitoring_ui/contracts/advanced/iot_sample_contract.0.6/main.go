@@ -90,6 +90,7 @@ import (
     "time"
 
     "github.com/hyperledger/fabric/core/chaincode/shim"
+    "github.com/ibm-watson-iot/blockchain-samples/contracts/platform/contractlogger"
 )
 
 //go:generate go run scripts/generate_go_schema.go
@@ -123,7 +124,7 @@ const TXNUUID string = "txnuuid"
 // ArgsMap is a generic map[string]interface{} to be used as a receiver
 type ArgsMap map[string]interface{}
 
-var log = NewContractLogger(DEFAULTNICKNAME, DEFAULTLOGGINGLEVEL)
+var log = contractlogger.NewContractLogger(DEFAULTNICKNAME+"-"+MYVERSION, contractlogger.DEFAULTLOGGINGLEVEL)
 
 // ************************************
 // start the message pumps
@@ -159,7 +160,7 @@ func (t *SimpleChaincode) Init(stub shim.ChaincodeStubInterface, function string
         stateArg.Nickname = DEFAULTNICKNAME
     }
 
-    (*log).setModule(stateArg.Nickname)
+    log.SetModule(stateArg.Nickname + "-" + MYVERSION)
 
     err = initializeContractState(stub, stateArg.Version, stateArg.Nickname)
     if err != nil {
@@ -172,6 +173,8 @@ func (t *SimpleChaincode) Init(stub shim.ChaincodeStubInterface, function string
 
 // Invoke is called in invoke mode to delegate state changing function messages
 func (t *SimpleChaincode) Invoke(stub shim.ChaincodeStubInterface, function string, args []string) ([]byte, error) {
+    contractlogger.BeginTransaction(stub.GetTxID(), "invoke", function)
+    defer contractlogger.EndTransaction(stub.GetTxID())
     if function == "createAsset" {
         return t.createAsset(stub, args)
     } else if function == "updateAsset" {
@@ -194,6 +197,8 @@ func (t *SimpleChaincode) Invoke(stub shim.ChaincodeStubInterface, function stri
 
 // Query is called in query mode to delegate non-state-changing queries
 func (t *SimpleChaincode) Query(stub shim.ChaincodeStubInterface, function string, args []string) ([]byte, error) {
+    contractlogger.BeginTransaction(stub.GetTxID(), "query", function)
+    defer contractlogger.EndTransaction(stub.GetTxID())
     if function == "readAsset" {
         return t.readAsset(stub, args)
     } else if function == "readAllAssets" {
@@ -274,6 +279,7 @@ func (t *SimpleChaincode) createAsset(stub shim.ChaincodeStubInterface, args []s
             log.Error(err)
             return nil, err
         }
+        contractlogger.SetTransactionAsset(stub.GetTxID(), assetID)
     }
 
     found = assetIsActive(stub, assetID)
@@ -424,6 +430,7 @@ func (t *SimpleChaincode) updateAsset(stub shim.ChaincodeStubInterface, args []s
             log.Error(err)
             return nil, err
         }
+        contractlogger.SetTransactionAsset(stub.GetTxID(), assetID)
     }
     log.Noticef("updateAsset found assetID %s", assetID)
 
@@ -607,6 +614,7 @@ func (t *SimpleChaincode) deleteAsset(stub shim.ChaincodeStubInterface, args []s
             log.Error(err)
             return nil, err
         }
+        contractlogger.SetTransactionAsset(stub.GetTxID(), assetID)
     }
 
     found = assetIsActive(stub, assetID)
@@ -683,6 +691,7 @@ func (t *SimpleChaincode) deletePropertiesFromAsset(stub shim.ChaincodeStubInter
             log.Error(err)
             return nil, err
         }
+        contractlogger.SetTransactionAsset(stub.GetTxID(), assetID)
     }
 
     found = assetIsActive(stub, assetID)
@@ -940,6 +949,7 @@ func (t *SimpleChaincode) readAsset(stub shim.ChaincodeStubInterface, args []str
             log.Error(err)
             return nil, err
         }
+        contractlogger.SetTransactionAsset(stub.GetTxID(), assetID)
     }
 
     found = assetIsActive(stub, assetID)
@@ -1058,6 +1068,7 @@ func (t *SimpleChaincode) readAssetHistory(stub shim.ChaincodeStubInterface, arg
             log.Error(err)
             return nil, err
         }
+        contractlogger.SetTransactionAsset(stub.GetTxID(), assetID)
     }
 
     found = assetIsActive(stub, assetID)
@@ -1169,7 +1180,9 @@ func (t *SimpleChaincode) readContractObjectModel(stub shim.ChaincodeStubInterfa
 // ************************************
 func (t *SimpleChaincode) setLoggingLevel(stub shim.ChaincodeStubInterface, args []string) error {
     type LogLevelArg struct {
-        Level string `json:"logLevel"`
+        Level  string   `json:"logLevel"`
+        Module string   `json:"module"`
+        Redact []string `json:"redact"`
     }
     var level LogLevelArg
     var err error
@@ -1184,9 +1197,16 @@ func (t *SimpleChaincode) setLoggingLevel(stub shim.ChaincodeStubInterface, args
         log.Error(err)
         return err
     }
-    for i, lev := range logLevelNames {
+    for i, lev := range contractlogger.LogLevelNames {
         if strings.ToUpper(level.Level) == lev {
-            (*log).SetLoggingLevel(LogLevel(i))
+            if level.Module != "" {
+                contractlogger.SetModuleLoggingLevel(level.Module, contractlogger.LogLevel(i))
+            } else {
+                log.SetLoggingLevel(contractlogger.LogLevel(i))
+            }
+            if level.Redact != nil {
+                contractlogger.SetRedactedPaths(level.Redact)
+            }
             return nil
         }
     }
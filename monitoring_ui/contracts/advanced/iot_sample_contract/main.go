@@ -85,6 +85,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/ibm-watson-iot/blockchain-samples/contracts/platform/contractlogger"
 	"reflect"
 	"sort"
 	"strings"
@@ -122,7 +123,7 @@ const TXNUUID string = "txnuuid"
 // ArgsMap is a generic map[string]interface{} to be used as a receiver
 type ArgsMap map[string]interface{}
 
-var log = NewContractLogger(DEFAULTNICKNAME, DEFAULTLOGGINGLEVEL)
+var log = contractlogger.NewContractLogger(DEFAULTNICKNAME+"-"+MYVERSION, contractlogger.DEFAULTLOGGINGLEVEL)
 
 // ************************************
 // start the message pumps
@@ -158,7 +159,7 @@ func (t *SimpleChaincode) Init(stub *shim.ChaincodeStub, function string, args [
 		stateArg.Nickname = DEFAULTNICKNAME
 	}
 
-	(*log).setModule(stateArg.Nickname)
+	log.SetModule(stateArg.Nickname + "-" + MYVERSION)
 
 	err = initializeContractState(stub, stateArg.Version, stateArg.Nickname)
 	if err != nil {
@@ -171,6 +172,8 @@ func (t *SimpleChaincode) Init(stub *shim.ChaincodeStub, function string, args [
 
 // Invoke is called in invoke mode to delegate state changing function messages
 func (t *SimpleChaincode) Invoke(stub *shim.ChaincodeStub, function string, args []string) ([]byte, error) {
+	contractlogger.BeginTransaction(stub.UUID, "invoke", function)
+	defer contractlogger.EndTransaction(stub.UUID)
 	if function == "createAsset" {
 		return t.createAsset(stub, args)
 	} else if function == "updateAsset" {
@@ -193,6 +196,8 @@ func (t *SimpleChaincode) Invoke(stub *shim.ChaincodeStub, function string, args
 
 // Query is called in query mode to delegate non-state-changing queries
 func (t *SimpleChaincode) Query(stub *shim.ChaincodeStub, function string, args []string) ([]byte, error) {
+	contractlogger.BeginTransaction(stub.UUID, "query", function)
+	defer contractlogger.EndTransaction(stub.UUID)
 	if function == "readAsset" {
 		return t.readAsset(stub, args)
 	} else if function == "readAllAssets" {
@@ -273,6 +278,7 @@ func (t *SimpleChaincode) createAsset(stub *shim.ChaincodeStub, args []string) (
 			log.Error(err)
 			return nil, err
 		}
+		contractlogger.SetTransactionAsset(stub.UUID, assetID)
 	}
 
 	found = assetIsActive(stub, assetID)
@@ -423,6 +429,7 @@ func (t *SimpleChaincode) updateAsset(stub *shim.ChaincodeStub, args []string) (
 			log.Error(err)
 			return nil, err
 		}
+		contractlogger.SetTransactionAsset(stub.UUID, assetID)
 	}
 	log.Noticef("updateAsset found assetID %s", assetID)
 
@@ -606,6 +613,7 @@ func (t *SimpleChaincode) deleteAsset(stub *shim.ChaincodeStub, args []string) (
 			log.Error(err)
 			return nil, err
 		}
+		contractlogger.SetTransactionAsset(stub.UUID, assetID)
 	}
 
 	found = assetIsActive(stub, assetID)
@@ -682,6 +690,7 @@ func (t *SimpleChaincode) deletePropertiesFromAsset(stub *shim.ChaincodeStub, ar
 			log.Error(err)
 			return nil, err
 		}
+		contractlogger.SetTransactionAsset(stub.UUID, assetID)
 	}
 
 	found = assetIsActive(stub, assetID)
@@ -939,6 +948,7 @@ func (t *SimpleChaincode) readAsset(stub *shim.ChaincodeStub, args []string) ([]
 			log.Error(err)
 			return nil, err
 		}
+		contractlogger.SetTransactionAsset(stub.UUID, assetID)
 	}
 
 	found = assetIsActive(stub, assetID)
@@ -1057,6 +1067,7 @@ func (t *SimpleChaincode) readAssetHistory(stub *shim.ChaincodeStub, args []stri
 			log.Error(err)
 			return nil, err
 		}
+		contractlogger.SetTransactionAsset(stub.UUID, assetID)
 	}
 
 	found = assetIsActive(stub, assetID)
@@ -1168,7 +1179,9 @@ func (t *SimpleChaincode) readContractObjectModel(stub *shim.ChaincodeStub, args
 // ************************************
 func (t *SimpleChaincode) setLoggingLevel(stub *shim.ChaincodeStub, args []string) error {
 	type LogLevelArg struct {
-		Level string `json:"logLevel"`
+		Level  string   `json:"logLevel"`
+		Module string   `json:"module"`
+		Redact []string `json:"redact"`
 	}
 	var level LogLevelArg
 	var err error
@@ -1183,9 +1196,16 @@ func (t *SimpleChaincode) setLoggingLevel(stub *shim.ChaincodeStub, args []strin
 		log.Error(err)
 		return err
 	}
-	for i, lev := range logLevelNames {
+	for i, lev := range contractlogger.LogLevelNames {
 		if strings.ToUpper(level.Level) == lev {
-			(*log).SetLoggingLevel(LogLevel(i))
+			if level.Module != "" {
+				contractlogger.SetModuleLoggingLevel(level.Module, contractlogger.LogLevel(i))
+			} else {
+				log.SetLoggingLevel(contractlogger.LogLevel(i))
+			}
+			if level.Redact != nil {
+				contractlogger.SetRedactedPaths(level.Redact)
+			}
 			return nil
 		}
 	}
@@ -0,0 +1,199 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+)
+
+// commandStub replaces the range query of the fabric 0.6 mock stub, which ignores the
+// start key, and gives transactions a timestamp
+type commandStub struct {
+	*shim.MockStub
+	now time.Time
+}
+
+type sortedKeyIterator struct {
+	stub *commandStub
+	keys []string
+}
+
+func (iter *sortedKeyIterator) HasNext() bool { return len(iter.keys) > 0 }
+func (iter *sortedKeyIterator) Close() error  { return nil }
+func (iter *sortedKeyIterator) Next() (string, []byte, error) {
+	key := iter.keys[0]
+	iter.keys = iter.keys[1:]
+	value, err := iter.stub.GetState(key)
+	return key, value, err
+}
+
+func (stub *commandStub) RangeQueryState(startKey, endKey string) (shim.StateRangeQueryIteratorInterface, error) {
+	var keys = make([]string, 0)
+	for k := range stub.State {
+		if k >= startKey && k < endKey {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return &sortedKeyIterator{stub, keys}, nil
+}
+
+func (stub *commandStub) GetTxTimestamp() (*timestamp.Timestamp, error) {
+	return &timestamp.Timestamp{Seconds: stub.now.Unix(), Nanos: int32(stub.now.Nanosecond())}, nil
+}
+
+func readCommands(t *testing.T, stub *commandStub, assetID string, status string) []DeviceCommand {
+	var commands []DeviceCommand
+	resultsBytes, err := readDeviceCommandsWithStatus(stub, assetID, status)
+	if err != nil {
+		t.Fatalf("readDeviceCommands failed: %s", err)
+	}
+	if err = json.Unmarshal(resultsBytes, &commands); err != nil {
+		t.Fatalf("readDeviceCommands returned bad JSON: %s", err)
+	}
+	return commands
+}
+
+func TestDeviceCommands(t *testing.T) {
+	var cc SimpleChaincode
+	stub := &commandStub{shim.NewMockStub("commands", nil), time.Date(2016, 10, 1, 8, 0, 0, 0, time.UTC)}
+	stub.MockTransactionStart("tx1")
+	if err := initializeContractState(stub, MYVERSION, DEFAULTNICKNAME); err != nil {
+		t.Fatalf("initializeContractState failed: %s", err)
+	}
+	// D1 is a prefix of D10, whose commands must not be read as D1's
+	for _, assetID := range []string{"D1", "D10"} {
+		if err := addAssetToContractState(stub, assetID); err != nil {
+			t.Fatalf("addAssetToContractState failed: %s", err)
+		}
+	}
+
+	send := func(arg string) error {
+		_, err := cc.sendDeviceCommand(stub, []string{arg})
+		return err
+	}
+	if err := send(`{"assetID": "D1", "commandID": "reboot", "command": "reboot", "timeoutSeconds": 60}`); err != nil {
+		t.Fatalf("send failed: %s", err)
+	}
+	stub.now = stub.now.Add(time.Second)
+	if err := send(`{"assetID": "D1", "commandID": "fw", "command": "firmwareUpdate", "payload": {"version": "2.1"}}`); err != nil {
+		t.Fatalf("send failed: %s", err)
+	}
+	if err := send(`{"assetID": "D10", "commandID": "fw", "command": "firmwareUpdate"}`); err != nil {
+		t.Fatalf("send to another device with the same commandID failed: %s", err)
+	}
+	if err := send(`{"assetID": "D1", "commandID": "fw", "command": "firmwareUpdate"}`); err == nil {
+		t.Fatal("a commandID cannot be sent twice to a device")
+	}
+	if err := send(`{"assetID": "D2", "command": "reboot"}`); err == nil {
+		t.Fatal("a command cannot be sent to a device that does not exist")
+	}
+
+	// one key per command
+	if _, found := stub.State[commandKey("D1", "fw")]; !found {
+		t.Fatalf("command fw should have its own key, state has %v", stub.State)
+	}
+	commands := readCommands(t, stub, "D1", "")
+	if len(commands) != 2 || commands[0].CommandID != "reboot" || commands[1].CommandID != "fw" {
+		t.Fatalf("expected D1's two commands oldest first, got %+v", commands)
+	}
+
+	// the reboot expires, the firmware update is acknowledged
+	stub.now = stub.now.Add(2 * time.Minute)
+	if _, err := cc.ackDeviceCommand(stub, []string{`{"assetID": "D1", "commandID": "reboot"}`}); err == nil {
+		t.Fatal("an expired command cannot be acknowledged")
+	}
+	if _, err := cc.ackDeviceCommand(stub, []string{`{"assetID": "D1", "commandID": "fw", "result": {"installed": "2.1"}}`}); err != nil {
+		t.Fatalf("ack failed: %s", err)
+	}
+	if pending := readCommands(t, stub, "D1", CMDPENDING); len(pending) != 0 {
+		t.Fatalf("expected no pending commands, got %+v", pending)
+	}
+	if cmd, _ := getDeviceCommand(stub, "D1", "reboot"); cmd.Status != CMDPENDING {
+		t.Fatalf("a query reports expiry without writing it, got %+v", cmd)
+	}
+	if _, err := cc.expireDeviceCommands(stub, []string{`{"assetID": "D1"}`}); err != nil {
+		t.Fatalf("expire failed: %s", err)
+	}
+	if cmd, _ := getDeviceCommand(stub, "D1", "reboot"); cmd.Status != CMDTIMEDOUT {
+		t.Fatalf("expected the reboot to be written as timed out, got %+v", cmd)
+	}
+	if cmd, _ := getDeviceCommand(stub, "D1", "fw"); cmd.Status != CMDACKNOWLEDGED || cmd.AckTxnUUID != "tx1" {
+		t.Fatalf("expected the firmware update to be acknowledged, got %+v", cmd)
+	}
+
+	if err := deleteDeviceCommands(stub, "D1"); err != nil {
+		t.Fatalf("delete failed: %s", err)
+	}
+	if commands := readCommands(t, stub, "D1", ""); len(commands) != 0 {
+		t.Fatalf("expected D1's commands to be deleted, got %+v", commands)
+	}
+	if commands := readCommands(t, stub, "D10", ""); len(commands) != 1 {
+		t.Fatalf("D10's command should remain, got %+v", commands)
+	}
+}
+
+func TestDeviceCommandsWithDottedIDs(t *testing.T) {
+	var cc SimpleChaincode
+	stub := &commandStub{shim.NewMockStub("commands", nil), time.Date(2016, 10, 1, 8, 0, 0, 0, time.UTC)}
+	stub.MockTransactionStart("tx1")
+	if err := initializeContractState(stub, MYVERSION, DEFAULTNICKNAME); err != nil {
+		t.Fatalf("initializeContractState failed: %s", err)
+	}
+	for _, assetID := range []string{"dev", "dev.1"} {
+		if err := addAssetToContractState(stub, assetID); err != nil {
+			t.Fatalf("addAssetToContractState failed: %s", err)
+		}
+	}
+	send := func(arg string) error {
+		_, err := cc.sendDeviceCommand(stub, []string{arg})
+		return err
+	}
+
+	// with a dot separator both commands would be written under COMMANDS_dev.1.fw
+	if err := send(`{"assetID": "dev", "commandID": "1.fw", "command": "firmwareUpdate"}`); err != nil {
+		t.Fatalf("send failed: %s", err)
+	}
+	if err := send(`{"assetID": "dev.1", "commandID": "fw", "command": "firmwareUpdate"}`); err != nil {
+		t.Fatalf("a dotted commandID must not collide with another device's command: %s", err)
+	}
+	// IDs with characters that sort above '~' are still inside the device's range
+	if err := send(`{"assetID": "dev", "commandID": "fw-ü", "command": "firmwareUpdate"}`); err != nil {
+		t.Fatalf("send failed: %s", err)
+	}
+	if err := send("{\"assetID\": \"dev\", \"commandID\": \"fw\\u0000x\", \"command\": \"reboot\"}"); err == nil {
+		t.Fatal("a commandID containing the key separator should be rejected")
+	}
+
+	commands := readCommands(t, stub, "dev", "")
+	if len(commands) != 2 || commands[0].CommandID != "1.fw" || commands[1].CommandID != "fw-ü" {
+		t.Fatalf("expected only dev's own two commands, got %+v", commands)
+	}
+	commands = readCommands(t, stub, "dev.1", "")
+	if len(commands) != 1 || commands[0].AssetID != "dev.1" || commands[0].CommandID != "fw" {
+		t.Fatalf("expected dev.1's command, got %+v", commands)
+	}
+	if cmd, _ := getDeviceCommand(stub, "dev.1", "fw"); cmd == nil || cmd.AssetID != "dev.1" {
+		t.Fatalf("expected dev.1's command under its own key, got %+v", cmd)
+	}
+}
@@ -0,0 +1,512 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+// v4.6 Device command channel, generalizing ping pong into commands that are
+//      sent to a device and acknowledged by it, with expiry
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+)
+
+// EVTCOMMAND is sent out with the command whenever a command is sent to a device
+const EVTCOMMAND = "EVTCOMMAND"
+
+// EVTCOMMANDACK is sent out with the command whenever a device acknowledges a command
+const EVTCOMMANDACK = "EVTCOMMANDACK"
+
+// EVTCOMMANDTIMEOUT is sent out with the commands that were marked timed out by expireDeviceCommands
+const EVTCOMMANDTIMEOUT = "EVTCOMMANDTIMEOUT"
+
+// COMMANDSPREFIX is prepended to the assetID and commandID to form the key of a
+// command, so that each command is written on its own and a device's commands
+// are read with a range query
+const COMMANDSPREFIX = "COMMANDS_"
+
+// commandKeySeparator ends the assetID in a command key. The IDs are free text and
+// may contain dots, but no printable character sorts below U+0000, so one device's
+// range cannot reach into another's (e.g. "dev" and "dev.1").
+const commandKeySeparator = "\x00"
+const commandKeyMaxRune = "\U0010FFFF"
+
+// DEFAULTCOMMANDTIMEOUT is the number of seconds a device has to acknowledge a command
+const DEFAULTCOMMANDTIMEOUT = 300
+
+// command status values
+const (
+	CMDPENDING      = "PENDING"
+	CMDACKNOWLEDGED = "ACKNOWLEDGED"
+	CMDREJECTED     = "REJECTED"
+	CMDTIMEDOUT     = "TIMEDOUT"
+)
+
+// DeviceCommand is one command sent to a device, it is kept after it completes so
+// that firmware updates and configuration pushes can be audited
+type DeviceCommand struct {
+	CommandID    string      `json:"commandID"`
+	AssetID      string      `json:"assetID"`
+	Command      string      `json:"command"`
+	Payload      interface{} `json:"payload,omitempty"`
+	Status       string      `json:"status"`
+	Sent         string      `json:"sent"`
+	Expires      string      `json:"expires"`
+	SentTxnUUID  string      `json:"senttxnuuid"`
+	Acknowledged string      `json:"acknowledged,omitempty"`
+	AckTxnUUID   string      `json:"acktxnuuid,omitempty"`
+	Result       interface{} `json:"result,omitempty"`
+}
+
+// bySent orders commands oldest first, the key order is that of the commandIDs
+type bySent []DeviceCommand
+
+func (c bySent) Len() int      { return len(c) }
+func (c bySent) Swap(i, j int) { c[i], c[j] = c[j], c[i] }
+func (c bySent) Less(i, j int) bool {
+	if c[i].Sent != c[j].Sent {
+		return c[i].Sent < c[j].Sent
+	}
+	return c[i].CommandID < c[j].CommandID
+}
+
+// txnTime returns the transaction timestamp, which is the same on all peers
+func txnTime(stub *shim.ChaincodeStub) (time.Time, error) {
+	txnunixtime, err := stub.GetTxTimestamp()
+	if err == nil && txnunixtime == nil {
+		err = errors.New("no timestamp")
+	}
+	if err != nil {
+		err = fmt.Errorf("Error getting transaction timestamp: %s", err)
+		log.Error(err)
+		return time.Time{}, err
+	}
+	return time.Unix(txnunixtime.Seconds, int64(txnunixtime.Nanos)).UTC(), nil
+}
+
+// expire marks a pending command whose expiry has passed as timed out and
+// returns true when it changed
+func (c *DeviceCommand) expire(now time.Time) bool {
+	if c.Status != CMDPENDING {
+		return false
+	}
+	expires, err := time.Parse(time.RFC3339Nano, c.Expires)
+	if err == nil && now.After(expires) {
+		c.Status = CMDTIMEDOUT
+		return true
+	}
+	return false
+}
+
+func commandsPrefix(assetID string) string {
+	return COMMANDSPREFIX + assetID + commandKeySeparator
+}
+
+func commandKey(assetID string, commandID string) string {
+	return commandsPrefix(assetID) + commandID
+}
+
+// getDeviceCommand returns nil when the command was not sent to the device
+func getDeviceCommand(stub *shim.ChaincodeStub, assetID string, commandID string) (*DeviceCommand, error) {
+	var cmd DeviceCommand
+	cmdBytes, err := stub.GetState(commandKey(assetID, commandID))
+	if err != nil {
+		err = fmt.Errorf("GETSTATE for device %s command %s failed: %s", assetID, commandID, err)
+		log.Error(err)
+		return nil, err
+	}
+	if len(cmdBytes) == 0 {
+		return nil, nil
+	}
+	err = json.Unmarshal(cmdBytes, &cmd)
+	if err != nil {
+		err = fmt.Errorf("device %s command %s failed to unmarshal: %s", assetID, commandID, err)
+		log.Error(err)
+		return nil, err
+	}
+	return &cmd, nil
+}
+
+// getDeviceCommands range queries a device's commands and returns them oldest first
+func getDeviceCommands(stub *shim.ChaincodeStub, assetID string) ([]DeviceCommand, error) {
+	var commands = make([]DeviceCommand, 0)
+	prefix := commandsPrefix(assetID)
+	iter, err := stub.RangeQueryState(prefix, prefix+commandKeyMaxRune)
+	if err != nil {
+		err = fmt.Errorf("range query for device %s commands failed: %s", assetID, err)
+		log.Error(err)
+		return nil, err
+	}
+	defer iter.Close()
+	for iter.HasNext() {
+		key, cmdBytes, err := iter.Next()
+		if err != nil {
+			err = fmt.Errorf("range query for device %s commands failed: %s", assetID, err)
+			log.Error(err)
+			return nil, err
+		}
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		var cmd DeviceCommand
+		err = json.Unmarshal(cmdBytes, &cmd)
+		if err != nil {
+			err = fmt.Errorf("device %s command %s failed to unmarshal: %s", assetID, key, err)
+			log.Error(err)
+			return nil, err
+		}
+		if cmd.AssetID != assetID {
+			continue
+		}
+		commands = append(commands, cmd)
+	}
+	sort.Sort(bySent(commands))
+	return commands, nil
+}
+
+func putDeviceCommand(stub *shim.ChaincodeStub, cmd DeviceCommand) error {
+	cmdBytes, err := json.Marshal(cmd)
+	if err != nil {
+		err = fmt.Errorf("device %s command %s failed to marshal: %s", cmd.AssetID, cmd.CommandID, err)
+		log.Error(err)
+		return err
+	}
+	err = stub.PutState(commandKey(cmd.AssetID, cmd.CommandID), cmdBytes)
+	if err != nil {
+		err = fmt.Errorf("PUTSTATE for device %s command %s failed: %s", cmd.AssetID, cmd.CommandID, err)
+		log.Error(err)
+		return err
+	}
+	return nil
+}
+
+// deleteDeviceCommands is called when the asset is deleted
+func deleteDeviceCommands(stub *shim.ChaincodeStub, assetID string) error {
+	commands, err := getDeviceCommands(stub, assetID)
+	if err != nil {
+		return err
+	}
+	for _, cmd := range commands {
+		err = stub.DelState(commandKey(assetID, cmd.CommandID))
+		if err != nil {
+			err = fmt.Errorf("DELSTATE for device %s command %s failed: %s", assetID, cmd.CommandID, err)
+			log.Error(err)
+			return err
+		}
+	}
+	return nil
+}
+
+func setCommandEvent(stub *shim.ChaincodeStub, name string, payload interface{}) {
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		log.Errorf("%s event failed to marshal: %s", name, err)
+		return
+	}
+	_ = stub.SetEvent(name, payloadBytes)
+}
+
+// ************************************
+// sendDeviceCommand
+// ************************************
+func (t *SimpleChaincode) sendDeviceCommand(stub *shim.ChaincodeStub, args []string) ([]byte, error) {
+	type SendArg struct {
+		AssetID        string      `json:"assetID"`
+		CommandID      string      `json:"commandID"`
+		Command        string      `json:"command"`
+		Payload        interface{} `json:"payload"`
+		TimeoutSeconds int         `json:"timeoutSeconds"`
+	}
+	var arg SendArg
+	var err error
+	if len(args) != 1 {
+		err = errors.New("sendDeviceCommand expects one JSON command object")
+		log.Error(err)
+		return nil, err
+	}
+	err = json.Unmarshal([]byte(args[0]), &arg)
+	if err != nil {
+		err = fmt.Errorf("sendDeviceCommand failed to unmarshal arg: %s", err)
+		log.Error(err)
+		return nil, err
+	}
+	if arg.AssetID == "" || arg.Command == "" {
+		err = errors.New("sendDeviceCommand arg must include assetID and command")
+		log.Error(err)
+		return nil, err
+	}
+	if arg.TimeoutSeconds < 0 {
+		err = fmt.Errorf("sendDeviceCommand timeoutSeconds cannot be negative: %d", arg.TimeoutSeconds)
+		log.Error(err)
+		return nil, err
+	}
+	if arg.TimeoutSeconds == 0 {
+		arg.TimeoutSeconds = DEFAULTCOMMANDTIMEOUT
+	}
+	if !assetIsActive(stub, arg.AssetID) {
+		err = fmt.Errorf("sendDeviceCommand asset %s does not exist", arg.AssetID)
+		log.Error(err)
+		return nil, err
+	}
+	now, err := txnTime(stub)
+	if err != nil {
+		return nil, err
+	}
+	if arg.CommandID == "" {
+		arg.CommandID = stub.UUID
+	}
+	if strings.Contains(arg.AssetID, commandKeySeparator) || strings.Contains(arg.CommandID, commandKeySeparator) {
+		err = fmt.Errorf("sendDeviceCommand assetID %q and commandID %q cannot contain U+0000", arg.AssetID, arg.CommandID)
+		log.Error(err)
+		return nil, err
+	}
+	existing, err := getDeviceCommand(stub, arg.AssetID, arg.CommandID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		err = fmt.Errorf("sendDeviceCommand command %s already sent to device %s", arg.CommandID, arg.AssetID)
+		log.Error(err)
+		return nil, err
+	}
+	cmd := DeviceCommand{
+		CommandID:   arg.CommandID,
+		AssetID:     arg.AssetID,
+		Command:     arg.Command,
+		Payload:     arg.Payload,
+		Status:      CMDPENDING,
+		Sent:        now.Format(time.RFC3339Nano),
+		Expires:     now.Add(time.Duration(arg.TimeoutSeconds) * time.Second).Format(time.RFC3339Nano),
+		SentTxnUUID: stub.UUID,
+	}
+	err = putDeviceCommand(stub, cmd)
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("sendDeviceCommand sent %s command %s to device %s", cmd.Command, cmd.CommandID, cmd.AssetID)
+	setCommandEvent(stub, EVTCOMMAND, cmd)
+	return nil, nil
+}
+
+// ************************************
+// ackDeviceCommand
+// ************************************
+func (t *SimpleChaincode) ackDeviceCommand(stub *shim.ChaincodeStub, args []string) ([]byte, error) {
+	type AckArg struct {
+		AssetID   string      `json:"assetID"`
+		CommandID string      `json:"commandID"`
+		Success   *bool       `json:"success"`
+		Result    interface{} `json:"result"`
+	}
+	var arg AckArg
+	var err error
+	if len(args) != 1 {
+		err = errors.New("ackDeviceCommand expects one JSON acknowledgement object")
+		log.Error(err)
+		return nil, err
+	}
+	err = json.Unmarshal([]byte(args[0]), &arg)
+	if err != nil {
+		err = fmt.Errorf("ackDeviceCommand failed to unmarshal arg: %s", err)
+		log.Error(err)
+		return nil, err
+	}
+	if arg.AssetID == "" || arg.CommandID == "" {
+		err = errors.New("ackDeviceCommand arg must include assetID and commandID")
+		log.Error(err)
+		return nil, err
+	}
+	now, err := txnTime(stub)
+	if err != nil {
+		return nil, err
+	}
+	cmd, err := getDeviceCommand(stub, arg.AssetID, arg.CommandID)
+	if err != nil {
+		return nil, err
+	}
+	if cmd == nil {
+		err = fmt.Errorf("ackDeviceCommand command %s was not sent to device %s", arg.CommandID, arg.AssetID)
+		log.Error(err)
+		return nil, err
+	}
+	cmd.expire(now)
+	if cmd.Status != CMDPENDING {
+		// the acknowledgement is refused, so a timed out command stays timed out
+		err = fmt.Errorf("ackDeviceCommand command %s for device %s is %s", arg.CommandID, arg.AssetID, cmd.Status)
+		log.Error(err)
+		return nil, err
+	}
+	cmd.Status = CMDACKNOWLEDGED
+	if arg.Success != nil && !*arg.Success {
+		cmd.Status = CMDREJECTED
+	}
+	cmd.Acknowledged = now.Format(time.RFC3339Nano)
+	cmd.AckTxnUUID = stub.UUID
+	cmd.Result = arg.Result
+	err = putDeviceCommand(stub, *cmd)
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("ackDeviceCommand device %s command %s is %s", cmd.AssetID, cmd.CommandID, cmd.Status)
+	setCommandEvent(stub, EVTCOMMANDACK, *cmd)
+	return nil, nil
+}
+
+// ************************************
+// expireDeviceCommands
+// ************************************
+
+// expireDeviceCommands writes the timed out status of expired commands for one
+// device, or for all devices when no assetID is given. Only the commands that
+// changed are written.
+func (t *SimpleChaincode) expireDeviceCommands(stub *shim.ChaincodeStub, args []string) ([]byte, error) {
+	var arg struct {
+		AssetID string `json:"assetID"`
+	}
+	var assets []string
+	var expired = make([]DeviceCommand, 0)
+	var err error
+	if len(args) > 1 {
+		err = errors.New("expireDeviceCommands expects at most one JSON object with an assetID")
+		log.Error(err)
+		return nil, err
+	}
+	if len(args) == 1 {
+		err = json.Unmarshal([]byte(args[0]), &arg)
+		if err != nil {
+			err = fmt.Errorf("expireDeviceCommands failed to unmarshal arg: %s", err)
+			log.Error(err)
+			return nil, err
+		}
+	}
+	if arg.AssetID != "" {
+		assets = []string{arg.AssetID}
+	} else {
+		assets, err = getActiveAssets(stub)
+		if err != nil {
+			return nil, err
+		}
+	}
+	now, err := txnTime(stub)
+	if err != nil {
+		return nil, err
+	}
+	for _, assetID := range assets {
+		commands, err := getDeviceCommands(stub, assetID)
+		if err != nil {
+			return nil, err
+		}
+		for _, cmd := range commands {
+			if !cmd.expire(now) {
+				continue
+			}
+			err = putDeviceCommand(stub, cmd)
+			if err != nil {
+				return nil, err
+			}
+			expired = append(expired, cmd)
+		}
+	}
+	if len(expired) > 0 {
+		log.Noticef("expireDeviceCommands marked %d commands timed out", len(expired))
+		setCommandEvent(stub, EVTCOMMANDTIMEOUT, expired)
+	}
+	return nil, nil
+}
+
+// ************************************
+// readDeviceCommands
+// ************************************
+
+// readDeviceCommands returns a device's commands oldest first, optionally filtered
+// by status. Expired commands are reported as timed out even if that has not yet
+// been written, as queries cannot change the ledger.
+func (t *SimpleChaincode) readDeviceCommands(stub *shim.ChaincodeStub, args []string) ([]byte, error) {
+	var arg struct {
+		AssetID string `json:"assetID"`
+		Status  string `json:"status"`
+	}
+	var err error
+	if len(args) != 1 {
+		err = errors.New("readDeviceCommands expects one JSON object with an assetID")
+		log.Error(err)
+		return nil, err
+	}
+	err = json.Unmarshal([]byte(args[0]), &arg)
+	if err != nil || arg.AssetID == "" {
+		err = errors.New("readDeviceCommands arg does not include assetID")
+		log.Error(err)
+		return nil, err
+	}
+	return readDeviceCommandsWithStatus(stub, arg.AssetID, arg.Status)
+}
+
+// ************************************
+// readPendingDeviceCommands
+// ************************************
+
+// readPendingDeviceCommands returns the commands that a device has yet to acknowledge
+func (t *SimpleChaincode) readPendingDeviceCommands(stub *shim.ChaincodeStub, args []string) ([]byte, error) {
+	var arg struct {
+		AssetID string `json:"assetID"`
+	}
+	var err error
+	if len(args) != 1 {
+		err = errors.New("readPendingDeviceCommands expects one JSON object with an assetID")
+		log.Error(err)
+		return nil, err
+	}
+	err = json.Unmarshal([]byte(args[0]), &arg)
+	if err != nil || arg.AssetID == "" {
+		err = errors.New("readPendingDeviceCommands arg does not include assetID")
+		log.Error(err)
+		return nil, err
+	}
+	return readDeviceCommandsWithStatus(stub, arg.AssetID, CMDPENDING)
+}
+
+func readDeviceCommandsWithStatus(stub *shim.ChaincodeStub, assetID string, status string) ([]byte, error) {
+	commands, err := getDeviceCommands(stub, assetID)
+	if err != nil {
+		return nil, err
+	}
+	// the wall clock is good enough to report expiry to a query that has
+	// no transaction timestamp, as nothing is written
+	now := time.Now().UTC()
+	if txnunixtime, err := stub.GetTxTimestamp(); err == nil && txnunixtime != nil {
+		now = time.Unix(txnunixtime.Seconds, int64(txnunixtime.Nanos)).UTC()
+	}
+	results := make([]DeviceCommand, 0, len(commands))
+	for _, c := range commands {
+		c.expire(now)
+		if status == "" || c.Status == status {
+			results = append(results, c)
+		}
+	}
+	resultsBytes, err := json.Marshal(results)
+	if err != nil {
+		err = fmt.Errorf("readDeviceCommands failed to marshal results: %s", err)
+		log.Error(err)
+		return nil, err
+	}
+	return resultsBytes, nil
+}
@@ -0,0 +1,43 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCommandKeysWithDottedIDs(t *testing.T) {
+	// with a dot separator these two would share the key COMMANDS_dev.1.fw
+	if commandKey("dev", "1.fw") == commandKey("dev.1", "fw") {
+		t.Fatalf("dotted IDs collide on %q", commandKey("dev", "1.fw"))
+	}
+
+	prefix := commandsPrefix("dev")
+	end := prefix + commandKeyMaxRune
+	inRange := func(key string) bool { return key >= prefix && key < end }
+	for _, commandID := range []string{"1.fw", "fw", "fw-ü", "~"} {
+		if key := commandKey("dev", commandID); !inRange(key) || !strings.HasPrefix(key, prefix) {
+			t.Errorf("command %q of dev is outside its range", commandID)
+		}
+	}
+	for _, assetID := range []string{"dev.1", "dev1", "deva"} {
+		if key := commandKey(assetID, "fw"); inRange(key) {
+			t.Errorf("command of %s is inside dev's range", assetID)
+		}
+	}
+}
@@ -77,6 +77,8 @@ Howard McKinney- Initial Contribution
 //                      args into stateOut in create to avoid infinite loop when args are attached into lastEvent.
 // v4.5 KL October 2016 Ping Pong contract to demonstrate two-way communication with
 //                 devices using the Hyperledger event infrastructure
+// v4.6 Device command channel with sendDeviceCommand, ackDeviceCommand, expireDeviceCommands,
+//      readDeviceCommands and readPendingDeviceCommands
 
 package main
 
@@ -85,6 +87,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/ibm-watson-iot/blockchain-samples/contracts/platform/contractlogger"
 	"reflect"
 	"strings"
 	"time"
@@ -130,7 +133,7 @@ const EVTERROR = "EVTERROR"
 // ArgsMap is a generic map[string]interface{} to be used as a receiver
 type ArgsMap map[string]interface{}
 
-var log = NewContractLogger(DEFAULTNICKNAME, DEFAULTLOGGINGLEVEL)
+var log = contractlogger.NewContractLogger(DEFAULTNICKNAME+"-"+MYVERSION, contractlogger.DEFAULTLOGGINGLEVEL)
 
 // ************************************
 // start the message pumps
@@ -181,7 +184,7 @@ func (t *SimpleChaincode) Init(stub *shim.ChaincodeStub, function string, args [
 		stateArg.Nickname = DEFAULTNICKNAME
 	}
 
-	(*log).setModule(stateArg.Nickname)
+	log.SetModule(stateArg.Nickname + "-" + MYVERSION)
 
 	err = initializeContractState(stub, stateArg.Version, stateArg.Nickname)
 	if err != nil {
@@ -195,6 +198,8 @@ func (t *SimpleChaincode) Init(stub *shim.ChaincodeStub, function string, args [
 
 // Invoke is called in invoke mode to delegate state changing function messages
 func (t *SimpleChaincode) Invoke(stub *shim.ChaincodeStub, function string, args []string) ([]byte, error) {
+	contractlogger.BeginTransaction(stub.UUID, "invoke", function)
+	defer contractlogger.EndTransaction(stub.UUID)
 	out, err := t.invokefunc(stub, function, args)
 	if err != nil {
 		setInvokeErrorEvent(stub, err)
@@ -217,6 +222,12 @@ func (t *SimpleChaincode) invokefunc(stub *shim.ChaincodeStub, function string,
 		return nil, t.setLoggingLevel(stub, args)
 	} else if function == "setCreateOnUpdate" {
 		return nil, t.setCreateOnUpdate(stub, args)
+	} else if function == "sendDeviceCommand" {
+		return t.sendDeviceCommand(stub, args)
+	} else if function == "ackDeviceCommand" {
+		return t.ackDeviceCommand(stub, args)
+	} else if function == "expireDeviceCommands" {
+		return t.expireDeviceCommands(stub, args)
 	}
 	err := fmt.Errorf("Invoke received unknown function: %s", function)
 	log.Error(err)
@@ -225,6 +236,8 @@ func (t *SimpleChaincode) invokefunc(stub *shim.ChaincodeStub, function string,
 
 // Query is called in query mode to delegate non-state-changing queries
 func (t *SimpleChaincode) Query(stub *shim.ChaincodeStub, function string, args []string) ([]byte, error) {
+	contractlogger.BeginTransaction(stub.UUID, "query", function)
+	defer contractlogger.EndTransaction(stub.UUID)
 	out, err := t.queryfunc(stub, function, args)
 	if err != nil {
 		setInvokeErrorEvent(stub, err)
@@ -247,6 +260,10 @@ func (t *SimpleChaincode) queryfunc(stub *shim.ChaincodeStub, function string, a
 		return t.readContractObjectModel(stub, args)
 	} else if function == "readContractState" {
 		return t.readContractState(stub, args)
+	} else if function == "readDeviceCommands" {
+		return t.readDeviceCommands(stub, args)
+	} else if function == "readPendingDeviceCommands" {
+		return t.readPendingDeviceCommands(stub, args)
 	}
 	err := fmt.Errorf("Query received unknown function: %s", function)
 	log.Error(err)
@@ -316,6 +333,7 @@ func (t *SimpleChaincode) createAsset(stub *shim.ChaincodeStub, args []string) (
 			setInvokeErrorEvent(stub, err)
 			return nil, err
 		}
+		contractlogger.SetTransactionAsset(stub.UUID, assetID)
 	}
 
 	found = assetIsActive(stub, assetID)
@@ -501,6 +519,7 @@ func (t *SimpleChaincode) updateAsset(stub *shim.ChaincodeStub, args []string) (
 			setInvokeErrorEvent(stub, err)
 			return nil, err
 		}
+		contractlogger.SetTransactionAsset(stub.UUID, assetID)
 	}
 	log.Noticef("updateAsset found assetID %s", assetID)
 
@@ -711,6 +730,7 @@ func (t *SimpleChaincode) deleteAsset(stub *shim.ChaincodeStub, args []string) (
 			setInvokeErrorEvent(stub, err)
 			return nil, err
 		}
+		contractlogger.SetTransactionAsset(stub.UUID, assetID)
 	}
 
 	found = assetIsActive(stub, assetID)
@@ -744,6 +764,12 @@ func (t *SimpleChaincode) deleteAsset(stub *shim.ChaincodeStub, args []string) (
 		setInvokeErrorEvent(stub, err)
 		return nil, err
 	}
+	// the device's commands go with it
+	err = deleteDeviceCommands(stub, assetID)
+	if err != nil {
+		setInvokeErrorEvent(stub, err)
+		return nil, err
+	}
 
 	return nil, nil
 }
@@ -795,6 +821,7 @@ func (t *SimpleChaincode) deletePropertiesFromAsset(stub *shim.ChaincodeStub, ar
 			setInvokeErrorEvent(stub, err)
 			return nil, err
 		}
+		contractlogger.SetTransactionAsset(stub.UUID, assetID)
 	}
 
 	found = assetIsActive(stub, assetID)
@@ -1014,6 +1041,11 @@ func (t *SimpleChaincode) deleteAllAssets(stub *shim.ChaincodeStub, args []strin
 			setInvokeErrorEvent(stub, err)
 			return nil, err
 		}
+		err = deleteDeviceCommands(stub, assetID)
+		if err != nil {
+			setInvokeErrorEvent(stub, err)
+			return nil, err
+		}
 	}
 	err = clearRecentStates(stub)
 	if err != nil {
@@ -1067,6 +1099,7 @@ func (t *SimpleChaincode) readAsset(stub *shim.ChaincodeStub, args []string) ([]
 			log.Error(err)
 			return nil, err
 		}
+		contractlogger.SetTransactionAsset(stub.UUID, assetID)
 	}
 
 	found = assetIsActive(stub, assetID)
@@ -1203,7 +1236,9 @@ func (t *SimpleChaincode) readContractObjectModel(stub *shim.ChaincodeStub, args
 // ************************************
 func (t *SimpleChaincode) setLoggingLevel(stub *shim.ChaincodeStub, args []string) error {
 	type LogLevelArg struct {
-		Level string `json:"logLevel"`
+		Level  string   `json:"logLevel"`
+		Module string   `json:"module"`
+		Redact []string `json:"redact"`
 	}
 	var level LogLevelArg
 	var err error
@@ -1220,9 +1255,16 @@ func (t *SimpleChaincode) setLoggingLevel(stub *shim.ChaincodeStub, args []strin
 		setInvokeErrorEvent(stub, err)
 		return err
 	}
-	for i, lev := range logLevelNames {
+	for i, lev := range contractlogger.LogLevelNames {
 		if strings.ToUpper(level.Level) == lev {
-			(*log).SetLoggingLevel(LogLevel(i))
+			if level.Module != "" {
+				contractlogger.SetModuleLoggingLevel(level.Module, contractlogger.LogLevel(i))
+			} else {
+				log.SetLoggingLevel(contractlogger.LogLevel(i))
+			}
+			if level.Redact != nil {
+				contractlogger.SetRedactedPaths(level.Redact)
+			}
 			return nil
 		}
 	}
@@ -3,6 +3,54 @@ package main
 var schemas = `
 {
     "API": {
+        "ackDeviceCommand": {
+            "description": "Records a device's acknowledgement of a pending command and its result, and sends out an EVTCOMMANDACK event. Commands that have expired cannot be acknowledged.",
+            "properties": {
+                "args": {
+                    "description": "args are JSON encoded strings",
+                    "items": {
+                        "description": "A device's acknowledgement of a command.",
+                        "properties": {
+                            "assetID": {
+                                "description": "The ID of a managed asset. The resource focal point for a smart contract.",
+                                "type": "string"
+                            },
+                            "commandID": {
+                                "description": "The ID of a command, unique for its device. Defaults to the ID of the transaction that sent the command.",
+                                "type": "string"
+                            },
+                            "result": {
+                                "description": "The device's result. Opaque to contract.",
+                                "properties": {},
+                                "type": "object"
+                            },
+                            "success": {
+                                "default": true,
+                                "description": "False when the device could not carry out the command.",
+                                "type": "boolean"
+                            }
+                        },
+                        "required": [
+                            "assetID",
+                            "commandID"
+                        ],
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
+                    "type": "array"
+                },
+                "function": {
+                    "description": "ackDeviceCommand function",
+                    "enum": [
+                        "ackDeviceCommand"
+                    ],
+                    "type": "string"
+                },
+                "method": "invoke"
+            },
+            "type": "object"
+        },
         "createAsset": {
             "description": "Create an asset. One argument, a JSON encoded event. The 'assetID' property is required with zero or more writable properties. Establishes an initial asset state.",
             "properties": {
@@ -149,6 +197,36 @@ var schemas = `
             },
             "type": "object"
         },
+        "expireDeviceCommands": {
+            "description": "Marks the expired commands of a device, or of all devices when there is no 'assetID', as TIMEDOUT and sends them out in an EVTCOMMANDTIMEOUT event.",
+            "properties": {
+                "args": {
+                    "description": "args are JSON encoded strings, the assetID is optional",
+                    "items": {
+                        "description": "An object containing only an 'assetID' for use as an argument to read or delete.",
+                        "properties": {
+                            "assetID": {
+                                "description": "The ID of a managed asset. The resource focal point for a smart contract.",
+                                "type": "string"
+                            }
+                        },
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 0,
+                    "type": "array"
+                },
+                "function": {
+                    "description": "expireDeviceCommands function",
+                    "enum": [
+                        "expireDeviceCommands"
+                    ],
+                    "type": "string"
+                },
+                "method": "invoke"
+            },
+            "type": "object"
+        },
         "init": {
             "description": "Initializes the contract when started, either by deployment or by peer restart.",
             "properties": {
@@ -465,6 +543,195 @@ var schemas = `
             },
             "type": "object"
         },
+        "readDeviceCommands": {
+            "description": "Returns the commands sent to a device oldest first, optionally only those with the given status. Commands that have expired are reported as TIMEDOUT.",
+            "properties": {
+                "args": {
+                    "description": "args are JSON encoded strings",
+                    "items": {
+                        "description": "Requested assetID with an optional command status.",
+                        "properties": {
+                            "assetID": {
+                                "description": "The ID of a managed asset. The resource focal point for a smart contract.",
+                                "type": "string"
+                            },
+                            "status": {
+                                "description": "A command is PENDING until the device acknowledges it as ACKNOWLEDGED or REJECTED, or it expires as TIMEDOUT.",
+                                "enum": [
+                                    "PENDING",
+                                    "ACKNOWLEDGED",
+                                    "REJECTED",
+                                    "TIMEDOUT"
+                                ],
+                                "type": "string"
+                            }
+                        },
+                        "required": [
+                            "assetID"
+                        ],
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
+                    "type": "array"
+                },
+                "function": {
+                    "description": "readDeviceCommands function",
+                    "enum": [
+                        "readDeviceCommands"
+                    ],
+                    "type": "string"
+                },
+                "method": "query",
+                "result": {
+                    "description": "an array of commands for one device, oldest first",
+                    "items": {
+                        "description": "A command sent to a device, kept after completion for audit.",
+                        "properties": {
+                            "acknowledged": {
+                                "description": "RFC3339nanos transaction timestamp of the acknowledgement.",
+                                "type": "string"
+                            },
+                            "acktxnuuid": {
+                                "type": "string"
+                            },
+                            "assetID": {
+                                "description": "The ID of a managed asset. The resource focal point for a smart contract.",
+                                "type": "string"
+                            },
+                            "command": {
+                                "type": "string"
+                            },
+                            "commandID": {
+                                "description": "The ID of a command, unique for its device. Defaults to the ID of the transaction that sent the command.",
+                                "type": "string"
+                            },
+                            "expires": {
+                                "description": "RFC3339nanos time by which the command must be acknowledged.",
+                                "type": "string"
+                            },
+                            "payload": {
+                                "properties": {},
+                                "type": "object"
+                            },
+                            "result": {
+                                "properties": {},
+                                "type": "object"
+                            },
+                            "sent": {
+                                "description": "RFC3339nanos transaction timestamp of the send.",
+                                "type": "string"
+                            },
+                            "senttxnuuid": {
+                                "type": "string"
+                            },
+                            "status": {
+                                "description": "A command is PENDING until the device acknowledges it as ACKNOWLEDGED or REJECTED, or it expires as TIMEDOUT.",
+                                "enum": [
+                                    "PENDING",
+                                    "ACKNOWLEDGED",
+                                    "REJECTED",
+                                    "TIMEDOUT"
+                                ],
+                                "type": "string"
+                            }
+                        },
+                        "type": "object"
+                    },
+                    "minItems": 0,
+                    "type": "array"
+                }
+            },
+            "type": "object"
+        },
+        "readPendingDeviceCommands": {
+            "description": "Returns the commands that a device has yet to acknowledge, oldest first.",
+            "properties": {
+                "args": {
+                    "description": "args are JSON encoded strings",
+                    "items": {
+                        "description": "An object containing only an 'assetID' for use as an argument to read or delete.",
+                        "properties": {
+                            "assetID": {
+                                "description": "The ID of a managed asset. The resource focal point for a smart contract.",
+                                "type": "string"
+                            }
+                        },
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
+                    "type": "array"
+                },
+                "function": {
+                    "description": "readPendingDeviceCommands function",
+                    "enum": [
+                        "readPendingDeviceCommands"
+                    ],
+                    "type": "string"
+                },
+                "method": "query",
+                "result": {
+                    "description": "an array of commands for one device, oldest first",
+                    "items": {
+                        "description": "A command sent to a device, kept after completion for audit.",
+                        "properties": {
+                            "acknowledged": {
+                                "description": "RFC3339nanos transaction timestamp of the acknowledgement.",
+                                "type": "string"
+                            },
+                            "acktxnuuid": {
+                                "type": "string"
+                            },
+                            "assetID": {
+                                "description": "The ID of a managed asset. The resource focal point for a smart contract.",
+                                "type": "string"
+                            },
+                            "command": {
+                                "type": "string"
+                            },
+                            "commandID": {
+                                "description": "The ID of a command, unique for its device. Defaults to the ID of the transaction that sent the command.",
+                                "type": "string"
+                            },
+                            "expires": {
+                                "description": "RFC3339nanos time by which the command must be acknowledged.",
+                                "type": "string"
+                            },
+                            "payload": {
+                                "properties": {},
+                                "type": "object"
+                            },
+                            "result": {
+                                "properties": {},
+                                "type": "object"
+                            },
+                            "sent": {
+                                "description": "RFC3339nanos transaction timestamp of the send.",
+                                "type": "string"
+                            },
+                            "senttxnuuid": {
+                                "type": "string"
+                            },
+                            "status": {
+                                "description": "A command is PENDING until the device acknowledges it as ACKNOWLEDGED or REJECTED, or it expires as TIMEDOUT.",
+                                "enum": [
+                                    "PENDING",
+                                    "ACKNOWLEDGED",
+                                    "REJECTED",
+                                    "TIMEDOUT"
+                                ],
+                                "type": "string"
+                            }
+                        },
+                        "type": "object"
+                    },
+                    "minItems": 0,
+                    "type": "array"
+                }
+            },
+            "type": "object"
+        },
         "readRecentStates": {
             "description": "Returns the state of recently updated assets as an array of objects sorted with the most recently updated asset first. Each asset appears exactly once up to a maxmum of 20 in this version of the contract.",
             "properties": {
@@ -602,6 +869,58 @@ var schemas = `
             },
             "type": "object"
         },
+        "sendDeviceCommand": {
+            "description": "Stores a command for a device and sends it out in an EVTCOMMAND event. The command must be acknowledged before it expires.",
+            "properties": {
+                "args": {
+                    "description": "args are JSON encoded strings",
+                    "items": {
+                        "description": "A command to be sent to a device.",
+                        "properties": {
+                            "assetID": {
+                                "description": "The ID of a managed asset. The resource focal point for a smart contract.",
+                                "type": "string"
+                            },
+                            "command": {
+                                "description": "The command, e.g. 'firmwareUpdate' or 'configure'.",
+                                "type": "string"
+                            },
+                            "commandID": {
+                                "description": "The ID of a command, unique for its device. Defaults to the ID of the transaction that sent the command.",
+                                "type": "string"
+                            },
+                            "payload": {
+                                "description": "Command parameters. Opaque to contract.",
+                                "properties": {},
+                                "type": "object"
+                            },
+                            "timeoutSeconds": {
+                                "default": 300,
+                                "description": "Seconds the device has to acknowledge the command.",
+                                "type": "integer"
+                            }
+                        },
+                        "required": [
+                            "assetID",
+                            "command"
+                        ],
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
+                    "type": "array"
+                },
+                "function": {
+                    "description": "sendDeviceCommand function",
+                    "enum": [
+                        "sendDeviceCommand"
+                    ],
+                    "type": "string"
+                },
+                "method": "invoke"
+            },
+            "type": "object"
+        },
         "setCreateOnUpdate": {
             "description": "Allow updateAsset to redirect to createAsset when 'assetID' does not exist.",
             "properties": {
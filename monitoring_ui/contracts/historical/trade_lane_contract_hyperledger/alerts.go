@@ -32,19 +32,31 @@ type Alerts int32
 const (
     // AlertsOVERTEMP the over temperature alert 
     AlertsOVERTEMP    Alerts = 0
+    // AlertsOFFROUTE the asset is outside the corridor of its planned route
+    AlertsOFFROUTE    Alerts = 1
+    // AlertsUNEXPECTEDSTOP the asset has stopped away from a waypoint or overstayed its dwell
+    AlertsUNEXPECTEDSTOP Alerts = 2
+    // AlertsLATEARRIVAL the asset has missed the eta of a waypoint
+    AlertsLATEARRIVAL Alerts = 3
 
     // AlertsSIZE is to be maintained always as 1 greater than the last alert, giving a size  
-	AlertsSIZE        Alerts = 1
+	AlertsSIZE        Alerts = 4
 )
 
 // AlertsName is a map of ID to name
 var AlertsName = map[int]string{
 	0: "OVERTEMP",
+	1: "OFFROUTE",
+	2: "UNEXPECTEDSTOP",
+	3: "LATEARRIVAL",
 }
 
 // AlertsValue is a map of name to ID
 var AlertsValue = map[string]int32{
 	"OVERTEMP": 0,
+	"OFFROUTE": 1,
+	"UNEXPECTEDSTOP": 2,
+	"LATEARRIVAL": 3,
 }
 
 func (x Alerts) String() string {
@@ -71,6 +71,7 @@ import (
     "strings"
     "reflect"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/ibm-watson-iot/blockchain-samples/contracts/platform/contractlogger"
 )
 
 //go:generate go run scripts/generate_go_schema.go
@@ -97,7 +98,7 @@ const TIMESTAMP string = "timestamp"
 // ArgsMap is a generic map[string]interface{} to be used as a receiver 
 type ArgsMap map[string]interface{} 
 
-var log = NewContractLogger(DEFAULTNICKNAME, DEFAULTLOGGINGLEVEL)
+var log = contractlogger.NewContractLogger(DEFAULTNICKNAME+"-"+MYVERSION, contractlogger.DEFAULTLOGGINGLEVEL)
 
 // ************************************
 // start the message pumps 
@@ -133,7 +134,7 @@ func (t *SimpleChaincode) Init(stub *shim.ChaincodeStub, function string, args [
         stateArg.Nickname = DEFAULTNICKNAME
     } 
 
-	(*log).setModule(stateArg.Nickname)
+	log.SetModule(stateArg.Nickname + "-" + MYVERSION)
     
     err = initializeContractState(stub, stateArg.Version, stateArg.Nickname)
     if err != nil {
@@ -146,6 +147,8 @@ func (t *SimpleChaincode) Init(stub *shim.ChaincodeStub, function string, args [
 
 // Invoke is called in invoke mode to delegate state changing function messages 
 func (t *SimpleChaincode) Invoke(stub *shim.ChaincodeStub, function string, args []string) ([]byte, error) {
+	contractlogger.BeginTransaction(stub.UUID, "invoke", function)
+	defer contractlogger.EndTransaction(stub.UUID)
 	if function == "createAsset" {
 		return t.createAsset(stub, args)
 	} else if function == "updateAsset" {
@@ -160,6 +163,8 @@ func (t *SimpleChaincode) Invoke(stub *shim.ChaincodeStub, function string, args
 		return nil, t.setLoggingLevel(stub, args)
 	} else if function == "setCreateOnUpdate" {
 		return nil, t.setCreateOnUpdate(stub, args)
+	} else if function == "setAssetRoute" {
+		return nil, t.setAssetRoute(stub, args)
 	}
 	err := fmt.Errorf("Invoke received unknown invocation: %s", function)
     log.Warning(err)
@@ -168,6 +173,8 @@ func (t *SimpleChaincode) Invoke(stub *shim.ChaincodeStub, function string, args
 
 // Query is called in query mode to delegate non-state-changing queries
 func (t *SimpleChaincode) Query(stub *shim.ChaincodeStub, function string, args []string) ([]byte, error) {
+	contractlogger.BeginTransaction(stub.UUID, "query", function)
+	defer contractlogger.EndTransaction(stub.UUID)
 	if function == "readAsset" {
 		return t.readAsset(stub, args)
     } else if function == "readAllAssets" {
@@ -184,6 +191,8 @@ func (t *SimpleChaincode) Query(stub *shim.ChaincodeStub, function string, args
 		return t.readContractObjectModel(stub, args)
 	} else if function == "readContractState" {
 		return t.readContractState(stub, args)
+	} else if function == "readAssetRoute" {
+		return t.readAssetRoute(stub, args)
 	}
 	err := fmt.Errorf("Query received unknown invocation: %s", function)
     log.Warning(err)
@@ -250,6 +259,7 @@ func (t *SimpleChaincode) createAsset(stub *shim.ChaincodeStub, args []string) (
             log.Error(err)
             return nil, err
         }
+        contractlogger.SetTransactionAsset(stub.UUID, assetID)
     }
     
     found = assetIsActive(stub, assetID)
@@ -283,10 +293,16 @@ func (t *SimpleChaincode) createAsset(stub *shim.ChaincodeStub, args []string) (
     timeOut = txntimestamp
     //*************************************************//
     argsMap[TIMESTAMP] = timeOut
+    // route progress is calculated by the rules only
+    delete(argsMap, ROUTESTATUS)
     
     // run the rules and raise or clear alerts
     alerts := newAlertStatus()
-    if argsMap.executeRules(&alerts) {
+    route, err := getAssetRoute(stub, assetID)
+    if err != nil {
+        return nil, err
+    }
+    if argsMap.executeRules(&alerts, route) {
         // NOT compliant!
         log.Noticef("createAsset assetID %s is noncompliant", assetID)
         argsMap["alerts"] = alerts
@@ -415,6 +431,7 @@ func (t *SimpleChaincode) updateAsset(stub *shim.ChaincodeStub, args []string) (
             log.Error(err)
             return nil, err
         }
+        contractlogger.SetTransactionAsset(stub.UUID, assetID)
     }
     log.Noticef("updateAsset found assetID %s", assetID)
 
@@ -456,6 +473,8 @@ func (t *SimpleChaincode) updateAsset(stub *shim.ChaincodeStub, args []string) (
     timeOut = txntimestamp
     //*************************************************//
     argsMap[TIMESTAMP] = timeOut
+    // route progress is calculated by the rules only
+    delete(argsMap, ROUTESTATUS)
     // **********************************
     // find the asset state in the ledger
     // **********************************
@@ -502,7 +521,11 @@ func (t *SimpleChaincode) updateAsset(stub *shim.ChaincodeStub, args []string) (
         alerts.alertStatusFromMap(a.(map[string]interface{}))
     }
     // important: rules need access to the entire calculated state 
-    if ledgerMap.executeRules(&alerts) {
+    route, err := getAssetRoute(stub, assetID)
+    if err != nil {
+        return nil, err
+    }
+    if ledgerMap.executeRules(&alerts, route) {
         // true means noncompliant
         log.Noticef("updateAsset assetID %s is noncompliant", assetID)
         // update ledger with new state, if all clear then delete
@@ -600,6 +623,7 @@ func (t *SimpleChaincode) deleteAsset(stub *shim.ChaincodeStub, args []string) (
             log.Error(err)
             return nil, err
         }
+        contractlogger.SetTransactionAsset(stub.UUID, assetID)
     }
 
     found = assetIsActive(stub, assetID)
@@ -636,6 +660,11 @@ func (t *SimpleChaincode) deleteAsset(stub *shim.ChaincodeStub, args []string) (
         log.Critical(err)
         return nil, err 
     }
+    // the planned route goes with the asset
+    err = deleteAssetRoute(stub, assetID)
+    if err != nil {
+        return nil, err
+    }
     
 	return nil, nil
 }
@@ -683,6 +712,7 @@ func (t *SimpleChaincode) deletePropertiesFromAsset(stub *shim.ChaincodeStub, ar
             log.Error(err)
             return nil, err
         }
+        contractlogger.SetTransactionAsset(stub.UUID, assetID)
     }
 
     found = assetIsActive(stub, assetID)
@@ -801,7 +831,11 @@ func (t *SimpleChaincode) deletePropertiesFromAsset(stub *shim.ChaincodeStub, ar
         alerts.alertStatusFromMap(a.(map[string]interface{}))
     }
     // important: rules need access to the entire calculated state 
-    if ledgerMap.executeRules(&alerts) {
+    route, err := getAssetRoute(stub, assetID)
+    if err != nil {
+        return nil, err
+    }
+    if ledgerMap.executeRules(&alerts, route) {
         // true means noncompliant
         log.Noticef("deletePropertiesFromAsset assetID %s is noncompliant", assetID)
         // update ledger with new state, if all clear then delete
@@ -898,6 +932,10 @@ func (t *SimpleChaincode) deleteAllAssets(stub *shim.ChaincodeStub, args []strin
             log.Critical(err)
             return nil, err 
         }
+        err = deleteAssetRoute(stub, assetID)
+        if err != nil {
+            return nil, err
+        }
     }
     err = clearRecentStates(stub)
     if err != nil {
@@ -950,6 +988,7 @@ func (t *SimpleChaincode) readAsset(stub *shim.ChaincodeStub, args []string) ([]
             log.Error(err)
             return nil, err
         }
+        contractlogger.SetTransactionAsset(stub.UUID, assetID)
     }
     
     found = assetIsActive(stub, assetID)
@@ -1063,6 +1102,7 @@ func (t *SimpleChaincode) readAssetHistory(stub *shim.ChaincodeStub, args []stri
             log.Error(err)
             return nil, err
         }
+        contractlogger.SetTransactionAsset(stub.UUID, assetID)
     }
     
     found = assetIsActive(stub, assetID)
@@ -1175,7 +1215,9 @@ func (t *SimpleChaincode) readContractObjectModel(stub *shim.ChaincodeStub, args
 // ************************************
 func (t *SimpleChaincode) setLoggingLevel(stub *shim.ChaincodeStub, args []string) (error) {
     type LogLevelArg struct {
-        Level string `json:"logLevel"`
+        Level  string   `json:"logLevel"`
+        Module string   `json:"module"`
+        Redact []string `json:"redact"`
     }
     var level LogLevelArg
     var err error
@@ -1190,9 +1232,16 @@ func (t *SimpleChaincode) setLoggingLevel(stub *shim.ChaincodeStub, args []strin
 		log.Error(err)
 		return err
     }
-    for i, lev := range logLevelNames {
+    for i, lev := range contractlogger.LogLevelNames {
         if strings.ToUpper(level.Level) == lev {
-            (*log).SetLoggingLevel(LogLevel(i))
+            if level.Module != "" {
+                contractlogger.SetModuleLoggingLevel(level.Module, contractlogger.LogLevel(i))
+            } else {
+                log.SetLoggingLevel(contractlogger.LogLevel(i))
+            }
+            if level.Redact != nil {
+                contractlogger.SetRedactedPaths(level.Redact)
+            }
             return nil
         } 
     }
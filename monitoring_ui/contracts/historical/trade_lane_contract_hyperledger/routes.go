@@ -0,0 +1,391 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+// ************************************
+// Planned routes and route adherence
+// ************************************
+
+package main
+
+import (
+    "encoding/json"
+    "errors"
+    "fmt"
+    "math"
+    "time"
+
+    "github.com/hyperledger/fabric/core/chaincode/shim"
+)
+
+// ROUTEPREFIX is prepended to the assetID to form the key of an asset's planned route
+const ROUTEPREFIX string = "ROUTE_"
+
+// ROUTESTATUS is the JSON tag for the read-only route progress in the asset state
+const ROUTESTATUS string = "routeStatus"
+
+// defaults in km for route tolerances that are not specified
+const (
+    DEFAULTCORRIDORKM   float64 = 25
+    DEFAULTRADIUSKM     float64 = 5
+    DEFAULTSTOPRADIUSKM float64 = 0.5
+)
+
+// Waypoint is a place the asset must pass through in order. The corridor applies
+// to the leg that ends at this waypoint, and the dwell is how long the asset is
+// expected to stay once it arrives (e.g. at a port).
+type Waypoint struct {
+    Name         string   `json:"name"`
+    Latitude     float64  `json:"latitude"`
+    Longitude    float64  `json:"longitude"`
+    RadiusKm     float64  `json:"radiusKm,omitempty"`
+    CorridorKm   float64  `json:"corridorKm,omitempty"`
+    DwellMinutes float64  `json:"dwellMinutes,omitempty"`
+    ETA          string   `json:"eta,omitempty"`
+}
+
+// Route is the planned route for one asset, the waypoints form a polyline and the
+// asset is on route while it is within the corridor of any leg not yet completed
+type Route struct {
+    AssetID        string     `json:"assetID"`
+    RouteID        string     `json:"routeID,omitempty"`
+    CorridorKm     float64    `json:"corridorKm,omitempty"`
+    StopRadiusKm   float64    `json:"stopRadiusKm,omitempty"`
+    MaxStopMinutes float64    `json:"maxStopMinutes,omitempty"`
+    Waypoints      []Waypoint `json:"waypoints"`
+}
+
+// WaypointArrival records when the asset reached and left a waypoint
+type WaypointArrival struct {
+    Name     string `json:"name"`
+    Arrived  string `json:"arrived"`
+    Departed string `json:"departed,omitempty"`
+    Late     bool   `json:"late,omitempty"`
+}
+
+// RouteStatus is calculated by the rules from the location readings and kept in
+// the asset state so that progress carries from one event to the next
+type RouteStatus struct {
+    RouteID       string            `json:"routeID"`
+    NextWaypoint  int               `json:"nextWaypoint"`
+    Arrivals      []WaypointArrival `json:"arrivals,omitempty"`
+    OffRouteKm    float64           `json:"offRouteKm"`
+    LastLatitude  float64           `json:"lastLatitude"`
+    LastLongitude float64           `json:"lastLongitude"`
+    LastReading   string            `json:"lastReading,omitempty"`
+    StoppedSince  string            `json:"stoppedSince,omitempty"`
+}
+
+// ************************************
+// geo calculations
+// ************************************
+
+// distanceKm is the haversine Distance from the platform's ctgeo.go. This contract
+// is built against the older shim and so cannot link the platform package.
+func distanceKm(lat1, lon1, lat2, lon2 float64) float64 {
+    const x = math.Pi / 180
+    const rEarth = 6372.8 // radius earth in km
+    dLat := (lat2 - lat1) * x
+    dLon := (lon2 - lon1) * x
+    lat1 = lat1 * x
+    lat2 = lat2 * x
+    a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Sin(dLon/2)*math.Sin(dLon/2)*math.Cos(lat1)*math.Cos(lat2)
+    c := 2 * math.Asin(math.Sqrt(a))
+    return rEarth * c
+}
+
+// distanceToLegKm finds the closest point on the leg from a to b using a local flat
+// projection, which is accurate enough at corridor scale, and measures the great
+// circle distance to it
+func distanceToLegKm(lat, lon float64, a, b Waypoint) float64 {
+    // keep the leg on the same side of the antimeridian as its start
+    bLon := b.Longitude
+    if bLon-a.Longitude > 180 {
+        bLon -= 360
+    } else if bLon-a.Longitude < -180 {
+        bLon += 360
+    }
+    pLon := lon
+    if pLon-a.Longitude > 180 {
+        pLon -= 360
+    } else if pLon-a.Longitude < -180 {
+        pLon += 360
+    }
+    k := math.Cos(lat * math.Pi / 180)
+    dx, dy := (bLon-a.Longitude)*k, b.Latitude-a.Latitude
+    px, py := (pLon-a.Longitude)*k, lat-a.Latitude
+    t := 0.0
+    if l2 := dx*dx + dy*dy; l2 > 0 {
+        t = math.Max(0, math.Min(1, (px*dx+py*dy)/l2))
+    }
+    return distanceKm(lat, lon, a.Latitude+t*(b.Latitude-a.Latitude), a.Longitude+t*(bLon-a.Longitude))
+}
+
+// ************************************
+// rule support
+// ************************************
+
+func (r *Route) corridorFor(i int) float64 {
+    if r.Waypoints[i].CorridorKm > 0 {
+        return r.Waypoints[i].CorridorKm
+    }
+    if r.CorridorKm > 0 {
+        return r.CorridorKm
+    }
+    return DEFAULTCORRIDORKM
+}
+
+func (r *Route) radiusFor(i int) float64 {
+    if r.Waypoints[i].RadiusKm > 0 {
+        return r.Waypoints[i].RadiusKm
+    }
+    return DEFAULTRADIUSKM
+}
+
+func (r *Route) stopRadius() float64 {
+    if r.StopRadiusKm > 0 {
+        return r.StopRadiusKm
+    }
+    return DEFAULTSTOPRADIUSKM
+}
+
+// offRoute measures the distance from the nearest leg that is still ahead of the
+// asset and reports whether it is outside the corridor of every such leg
+func (r *Route) offRoute(lat, lon float64, next int) (float64, bool) {
+    var nearest = math.MaxFloat64
+    var outside = true
+    check := func(d float64, corridor float64) {
+        nearest = math.Min(nearest, d)
+        if d <= corridor {
+            outside = false
+        }
+    }
+    last := len(r.Waypoints) - 1
+    if next == 0 {
+        // origin not reached yet
+        check(distanceKm(lat, lon, r.Waypoints[0].Latitude, r.Waypoints[0].Longitude), r.corridorFor(0))
+    }
+    for i := next; i <= last; i++ {
+        if i > 0 {
+            check(distanceToLegKm(lat, lon, r.Waypoints[i-1], r.Waypoints[i]), r.corridorFor(i))
+        }
+    }
+    if next > last {
+        // route complete, stay near the destination
+        check(distanceKm(lat, lon, r.Waypoints[last].Latitude, r.Waypoints[last].Longitude), r.corridorFor(last))
+    }
+    return nearest, outside
+}
+
+// getAssetTime reads the event timestamp, which is a time.Time when set by the
+// contract during this transaction and an RFC3339 string when read from the ledger
+func getAssetTime(a *ArgsMap) (time.Time, bool) {
+    ts, found := getObject(*a, TIMESTAMP)
+    if !found {
+        return time.Time{}, false
+    }
+    switch t := ts.(type) {
+    case time.Time:
+        return t, true
+    case string:
+        parsed, err := time.Parse(time.RFC3339Nano, t)
+        if err == nil {
+            return parsed, true
+        }
+    }
+    return time.Time{}, false
+}
+
+func getAssetLocation(a *ArgsMap) (float64, float64, bool) {
+    latBytes, found := getObject(*a, "location.latitude")
+    if !found {
+        return 0, 0, false
+    }
+    lonBytes, found := getObject(*a, "location.longitude")
+    if !found {
+        return 0, 0, false
+    }
+    lat, found := latBytes.(float64)
+    if !found {
+        return 0, 0, false
+    }
+    lon, found := lonBytes.(float64)
+    return lat, lon, found
+}
+
+// routeStatusFromState recovers the progress from the asset state, which holds an
+// untyped map, and restarts it when the route has been replaced
+func routeStatusFromState(a *ArgsMap, routeID string) RouteStatus {
+    var status RouteStatus
+    s, found := (*a)[ROUTESTATUS]
+    if found {
+        statusBytes, err := json.Marshal(s)
+        if err == nil {
+            err = json.Unmarshal(statusBytes, &status)
+        }
+        if err != nil {
+            log.Warningf("route status could not be read, restarting: %s", err)
+            status = RouteStatus{}
+        }
+    }
+    if status.RouteID != routeID {
+        status = RouteStatus{RouteID: routeID}
+    }
+    return status
+}
+
+func minutesBetween(from string, to time.Time) float64 {
+    t, err := time.Parse(time.RFC3339Nano, from)
+    if err != nil {
+        return 0
+    }
+    return to.Sub(t).Minutes()
+}
+
+// ************************************
+// setAssetRoute
+// ************************************
+
+// setAssetRoute stores the planned route for an asset, which may be created
+// before the asset is. Replacing a route restarts the asset's route progress.
+func (t *SimpleChaincode) setAssetRoute(stub *shim.ChaincodeStub, args []string) (error) {
+    var route Route
+    var err error
+    if len(args) != 1 {
+        err = errors.New("setAssetRoute expects a single JSON route object")
+        log.Error(err)
+        return err
+    }
+    err = json.Unmarshal([]byte(args[0]), &route)
+    if err != nil {
+        err = fmt.Errorf("setAssetRoute failed to unmarshal arg: %s", err)
+        log.Error(err)
+        return err
+    }
+    if route.AssetID == "" {
+        err = errors.New("setAssetRoute arg does not include assetID")
+        log.Error(err)
+        return err
+    }
+    if len(route.Waypoints) == 0 {
+        err = fmt.Errorf("setAssetRoute route for asset %s has no waypoints", route.AssetID)
+        log.Error(err)
+        return err
+    }
+    if route.CorridorKm < 0 || route.StopRadiusKm < 0 || route.MaxStopMinutes < 0 {
+        err = fmt.Errorf("setAssetRoute route for asset %s has a negative tolerance", route.AssetID)
+        log.Error(err)
+        return err
+    }
+    for i, w := range route.Waypoints {
+        if w.Latitude < -90 || w.Latitude > 90 || w.Longitude < -180 || w.Longitude > 180 {
+            err = fmt.Errorf("setAssetRoute waypoint %d (%s) has an invalid location", i, w.Name)
+            log.Error(err)
+            return err
+        }
+        if w.RadiusKm < 0 || w.CorridorKm < 0 || w.DwellMinutes < 0 {
+            err = fmt.Errorf("setAssetRoute waypoint %d (%s) has a negative tolerance", i, w.Name)
+            log.Error(err)
+            return err
+        }
+        if w.ETA != "" {
+            if _, err = time.Parse(time.RFC3339Nano, w.ETA); err != nil {
+                err = fmt.Errorf("setAssetRoute waypoint %d (%s) eta is not RFC3339: %s", i, w.Name, err)
+                log.Error(err)
+                return err
+            }
+        }
+    }
+    // progress is tied to this version of the route
+    route.RouteID = stub.UUID
+    routeBytes, err := json.Marshal(route)
+    if err != nil {
+        err = errors.New("setAssetRoute failed to marshal")
+        log.Error(err)
+        return err
+    }
+    err = stub.PutState(ROUTEPREFIX+route.AssetID, routeBytes)
+    if err != nil {
+        err = fmt.Errorf("PUTSTATE route for asset %s failed: %s", route.AssetID, err)
+        log.Error(err)
+        return err
+    }
+    return nil
+}
+
+// ************************************
+// readAssetRoute
+// ************************************
+func (t *SimpleChaincode) readAssetRoute(stub *shim.ChaincodeStub, args []string) ([]byte, error) {
+    var assetID struct {
+        AssetID string `json:"assetID"`
+    }
+    var err error
+    if len(args) != 1 {
+        err = errors.New("readAssetRoute expects a single JSON object with an assetID")
+        log.Error(err)
+        return nil, err
+    }
+    err = json.Unmarshal([]byte(args[0]), &assetID)
+    if err != nil || assetID.AssetID == "" {
+        err = errors.New("readAssetRoute arg does not include assetID")
+        log.Error(err)
+        return nil, err
+    }
+    routeBytes, err := stub.GetState(ROUTEPREFIX + assetID.AssetID)
+    if err != nil {
+        err = fmt.Errorf("readAssetRoute GETSTATE for asset %s failed: %s", assetID.AssetID, err)
+        log.Error(err)
+        return nil, err
+    }
+    if len(routeBytes) == 0 {
+        err = fmt.Errorf("readAssetRoute asset %s has no route", assetID.AssetID)
+        log.Error(err)
+        return nil, err
+    }
+    return routeBytes, nil
+}
+
+// getAssetRoute returns nil when the asset has no planned route
+func getAssetRoute(stub *shim.ChaincodeStub, assetID string) (*Route, error) {
+    var route Route
+    routeBytes, err := stub.GetState(ROUTEPREFIX + assetID)
+    if err != nil {
+        err = fmt.Errorf("GETSTATE route for asset %s failed: %s", assetID, err)
+        log.Error(err)
+        return nil, err
+    }
+    if len(routeBytes) == 0 {
+        return nil, nil
+    }
+    err = json.Unmarshal(routeBytes, &route)
+    if err != nil {
+        err = fmt.Errorf("route for asset %s failed to unmarshal: %s", assetID, err)
+        log.Error(err)
+        return nil, err
+    }
+    return &route, nil
+}
+
+// deleteAssetRoute is called when the asset is deleted
+func deleteAssetRoute(stub *shim.ChaincodeStub, assetID string) (error) {
+    err := stub.DelState(ROUTEPREFIX + assetID)
+    if err != nil {
+        err = fmt.Errorf("DELSTATE route for asset %s failed: %s", assetID, err)
+        log.Error(err)
+        return err
+    }
+    return nil
+}
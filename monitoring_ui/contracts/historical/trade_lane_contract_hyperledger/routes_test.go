@@ -0,0 +1,181 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+// ************************************
+// testing for route adherence
+// ************************************
+
+package main
+
+import (
+    "math"
+    "testing"
+    "time"
+)
+
+// one degree of latitude on the haversine earth used by distanceKm
+const kmPerDegree = 6372.8 * math.Pi / 180
+
+func wp(name string, lat, lon float64) Waypoint {
+    return Waypoint{Name: name, Latitude: lat, Longitude: lon}
+}
+
+func TestDistanceToLegKm(t *testing.T) {
+    var tests = []struct {
+        name     string
+        lat, lon float64
+        a, b     Waypoint
+        want     float64
+    }{
+        {"on the leg", 0, 5, wp("a", 0, 0), wp("b", 0, 10), 0},
+        {"beside the leg", 1, 5, wp("a", 0, 0), wp("b", 0, 10), kmPerDegree},
+        {"before the start", 0, -1, wp("a", 0, 0), wp("b", 0, 10), kmPerDegree},
+        {"past the end", 0, 11, wp("a", 0, 0), wp("b", 0, 10), kmPerDegree},
+        {"single point leg", 1, 0, wp("a", 0, 0), wp("b", 0, 0), kmPerDegree},
+        {"across the antimeridian eastbound", 0, 180, wp("a", 0, 170), wp("b", 0, -170), 0},
+        {"across the antimeridian past it", 0, -175, wp("a", 0, 170), wp("b", 0, -170), 0},
+        {"across the antimeridian westbound", 1, 179, wp("a", 0, -170), wp("b", 0, 170), kmPerDegree},
+        {"across the antimeridian past the end", 0, 169, wp("a", 0, -170), wp("b", 0, 170), kmPerDegree},
+    }
+    for _, tt := range tests {
+        got := distanceToLegKm(tt.lat, tt.lon, tt.a, tt.b)
+        if math.Abs(got-tt.want) > 0.5 {
+            t.Errorf("%s: distance from (%v, %v) is %.3f km, want %.3f", tt.name, tt.lat, tt.lon, got, tt.want)
+        }
+    }
+}
+
+func TestOffRoute(t *testing.T) {
+    var route = Route{
+        CorridorKm: 25,
+        Waypoints:  []Waypoint{wp("origin", 0, 0), wp("turn", 0, 10), wp("destination", 10, 10)},
+    }
+    var wide = route
+    wide.Waypoints = append([]Waypoint{}, route.Waypoints...)
+    wide.Waypoints[1].CorridorKm = 150
+
+    var tests = []struct {
+        name     string
+        route    Route
+        lat, lon float64
+        next     int
+        off      bool
+        nearest  float64
+    }{
+        {"near the origin before it is reached", route, 0.1, 0, 0, false, 0.1 * kmPerDegree},
+        {"far from the origin before it is reached", route, 1, 0, 0, true, kmPerDegree},
+        {"inside the corridor of the first leg", route, 0.1, 5, 1, false, 0.1 * kmPerDegree},
+        {"outside the corridor of the first leg", route, 1, 5, 1, true, kmPerDegree},
+        {"leg corridor set on its waypoint", wide, 1, 5, 1, false, kmPerDegree},
+        {"legs already completed are not considered", route, 0.1, 5, 2, true, 5 * kmPerDegree},
+        {"near the destination once complete", route, 10, 10.1, 3, false, 0.1 * kmPerDegree * math.Cos(10*math.Pi/180)},
+        {"away from the destination once complete", route, 9, 10, 3, true, kmPerDegree},
+    }
+    for _, tt := range tests {
+        nearest, off := tt.route.offRoute(tt.lat, tt.lon, tt.next)
+        if off != tt.off || math.Abs(nearest-tt.nearest) > 0.5 {
+            t.Errorf("%s: got %.3f km off %v, want %.3f km off %v", tt.name, nearest, off, tt.nearest, tt.off)
+        }
+    }
+}
+
+func TestRouteRule(t *testing.T) {
+    start := time.Date(2016, 10, 1, 8, 0, 0, 0, time.UTC)
+    eta := func(minutes int) string {
+        return start.Add(time.Duration(minutes) * time.Minute).Format(time.RFC3339)
+    }
+    route := &Route{
+        RouteID:        "route1",
+        MaxStopMinutes: 30,
+        Waypoints: []Waypoint{
+            {Name: "origin", Latitude: 0, Longitude: 0, DwellMinutes: 60, ETA: eta(60)},
+            {Name: "port", Latitude: 0, Longitude: 1, ETA: eta(300)},
+            {Name: "destination", Latitude: 0, Longitude: 2, ETA: eta(360)},
+        },
+    }
+
+    // readings in minutes from the start with the alerts that are active afterwards
+    var steps = []struct {
+        name     string
+        minutes  int
+        lat, lon float64
+        off      bool
+        stop     bool
+        late     bool
+        next     int
+    }{
+        {"arrival at the origin", 0, 0, 0, false, false, false, 1},
+        {"within the dwell time", 45, 0, 0.001, false, false, false, 1},
+        {"dwell overrun", 90, 0, 0.001, false, true, false, 1},
+        {"departure", 120, 0, 0.5, false, false, false, 1},
+        {"off route", 130, 0.5, 0.5, true, false, false, 1},
+        {"back on route", 140, 0, 0.6, false, false, false, 1},
+        {"short stop on the leg", 160, 0, 0.6, false, false, false, 1},
+        {"long stop on the leg", 180, 0, 0.6, false, true, false, 1},
+        {"late arrival at the port", 330, 0, 1, false, false, true, 2},
+        {"departure from the port", 340, 0, 1.2, false, false, false, 2},
+        {"eta for the destination has passed", 370, 0, 1.5, false, false, true, 2},
+        {"arrival at the destination", 390, 0, 2, false, false, true, 3},
+    }
+    var alerts AlertStatusInternal
+    var a = ArgsMap{}
+    for _, s := range steps {
+        a[TIMESTAMP] = start.Add(time.Duration(s.minutes) * time.Minute)
+        a["location"] = map[string]interface{}{"latitude": s.lat, "longitude": s.lon}
+        alerts.routeRule(&a, route)
+        status := a[ROUTESTATUS].(RouteStatus)
+        if alerts.Active[AlertsOFFROUTE] != s.off || alerts.Active[AlertsUNEXPECTEDSTOP] != s.stop ||
+            alerts.Active[AlertsLATEARRIVAL] != s.late || status.NextWaypoint != s.next {
+            t.Fatalf("%s: active %v next waypoint %d, want off route %v stop %v late %v next %d",
+                s.name, alerts.asAlertStatus().Active, status.NextWaypoint, s.off, s.stop, s.late, s.next)
+        }
+    }
+    status := a[ROUTESTATUS].(RouteStatus)
+    if len(status.Arrivals) != 3 || status.Arrivals[0].Late || !status.Arrivals[1].Late ||
+        status.Arrivals[1].Departed != eta(340) {
+        t.Fatalf("unexpected arrivals %+v", status.Arrivals)
+    }
+
+    // removing the route clears its alerts and progress
+    alerts.routeRule(&a, nil)
+    if _, found := a[ROUTESTATUS]; found || !alerts.NoAlertsActive() {
+        t.Fatalf("route removal should clear the route alerts and status, got %v", alerts.asAlertStatus())
+    }
+}
+
+func TestRouteRuleAntimeridian(t *testing.T) {
+    start := time.Date(2016, 10, 1, 8, 0, 0, 0, time.UTC)
+    route := &Route{
+        RouteID: "pacific",
+        Waypoints: []Waypoint{
+            {Name: "west of the line", Latitude: 0, Longitude: 179},
+            {Name: "east of the line", Latitude: 0, Longitude: -179},
+        },
+    }
+    var alerts AlertStatusInternal
+    var a = ArgsMap{}
+    for i, lon := range []float64{179, 179.6, 180, -179.6, -179} {
+        a[TIMESTAMP] = start.Add(time.Duration(i) * time.Hour)
+        a["location"] = map[string]interface{}{"latitude": 0.0, "longitude": lon}
+        alerts.routeRule(&a, route)
+        if alerts.Active[AlertsOFFROUTE] {
+            t.Fatalf("longitude %v is on the leg across the antimeridian, %+v", lon, a[ROUTESTATUS])
+        }
+    }
+    if status := a[ROUTESTATUS].(RouteStatus); status.NextWaypoint != 2 {
+        t.Fatalf("both waypoints should have been reached, got %+v", status)
+    }
+}
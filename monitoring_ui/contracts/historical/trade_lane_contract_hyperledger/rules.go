@@ -27,15 +27,19 @@ Kim Letkeman - Initial Contribution
 
 package main
 
-import ()
+import (
+    "math"
+    "time"
+)
 
-func (a *ArgsMap) executeRules(alerts *AlertStatus) (bool) {
+func (a *ArgsMap) executeRules(alerts *AlertStatus, route *Route) (bool) {
     log.Debugf("Executing rules input: %v", *alerts)
     var internal = (*alerts).asAlertStatusInternal()
 
     // rule 1 -- overtemp
     internal.overTempRule(a)
-    // rule 2 -- ???
+    // rule 2 -- adherence to the planned route
+    internal.routeRule(a, route)
 
     // now transform internal back to external in order to give the contract the
     // appropriate JSON to send externally
@@ -68,6 +72,100 @@ func (alerts *AlertStatusInternal) overTempRule (a *ArgsMap) {
     alerts.clearAlert(AlertsOVERTEMP)
 }
 
+// routeRule follows the asset's progress along its planned route from the location
+// readings, raising off route, unexpected stop and late arrival alerts
+func (alerts *AlertStatusInternal) routeRule (a *ArgsMap, route *Route) {
+    if route == nil || len(route.Waypoints) == 0 {
+        delete(*a, ROUTESTATUS)
+        alerts.clearAlert(AlertsOFFROUTE)
+        alerts.clearAlert(AlertsUNEXPECTEDSTOP)
+        alerts.clearAlert(AlertsLATEARRIVAL)
+        return
+    }
+    now, found := getAssetTime(a)
+    if !found {
+        log.Warning("routeRule found no timestamp, route alerts unchanged")
+        return
+    }
+    status := routeStatusFromState(a, route.RouteID)
+    nowStr := now.Format(time.RFC3339Nano)
+
+    lat, lon, found := getAssetLocation(a)
+    if found {
+        // arrivals, more than one when waypoint radii overlap
+        for status.NextWaypoint < len(route.Waypoints) {
+            w := route.Waypoints[status.NextWaypoint]
+            if distanceKm(lat, lon, w.Latitude, w.Longitude) > route.radiusFor(status.NextWaypoint) {
+                break
+            }
+            arrival := WaypointArrival{Name: w.Name, Arrived: nowStr}
+            if eta, err := time.Parse(time.RFC3339Nano, w.ETA); err == nil && now.After(eta) {
+                arrival.Late = true
+            }
+            status.Arrivals = append(status.Arrivals, arrival)
+            status.NextWaypoint++
+        }
+        // departure from the waypoint most recently reached
+        if n := len(status.Arrivals); n > 0 && status.Arrivals[n-1].Departed == "" {
+            w := route.Waypoints[n-1]
+            if distanceKm(lat, lon, w.Latitude, w.Longitude) > route.radiusFor(n-1) {
+                status.Arrivals[n-1].Departed = nowStr
+            }
+        }
+        // stopped means not having moved beyond the stop radius since the previous reading
+        if status.LastReading != "" && distanceKm(lat, lon, status.LastLatitude, status.LastLongitude) <= route.stopRadius() {
+            if status.StoppedSince == "" {
+                status.StoppedSince = status.LastReading
+            }
+        } else {
+            status.StoppedSince = ""
+        }
+        status.LastLatitude, status.LastLongitude, status.LastReading = lat, lon, nowStr
+
+        var off bool
+        status.OffRouteKm, off = route.offRoute(lat, lon, status.NextWaypoint)
+        status.OffRouteKm = math.Floor(status.OffRouteKm*1000) / 1000
+        if off {
+            alerts.raiseAlert(AlertsOFFROUTE)
+        } else {
+            alerts.clearAlert(AlertsOFFROUTE)
+        }
+    }
+
+    // a stop at a waypoint is measured against its dwell time, elsewhere against
+    // the route's maximum stop, and a zero limit allows any stop
+    atWaypoint := false
+    unexpected := false
+    if n := len(status.Arrivals); n > 0 && status.Arrivals[n-1].Departed == "" {
+        atWaypoint = true
+        dwell := route.Waypoints[n-1].DwellMinutes
+        unexpected = dwell > 0 && minutesBetween(status.Arrivals[n-1].Arrived, now) > dwell
+    }
+    if !atWaypoint && status.StoppedSince != "" {
+        unexpected = route.MaxStopMinutes > 0 && minutesBetween(status.StoppedSince, now) > route.MaxStopMinutes
+    }
+    if unexpected {
+        alerts.raiseAlert(AlertsUNEXPECTEDSTOP)
+    } else {
+        alerts.clearAlert(AlertsUNEXPECTEDSTOP)
+    }
+
+    // late while still at a waypoint reached after its eta, or once the eta of the
+    // next waypoint has passed
+    late := atWaypoint && status.Arrivals[len(status.Arrivals)-1].Late
+    if status.NextWaypoint < len(route.Waypoints) {
+        eta, err := time.Parse(time.RFC3339Nano, route.Waypoints[status.NextWaypoint].ETA)
+        late = late || (err == nil && now.After(eta))
+    }
+    if late {
+        alerts.raiseAlert(AlertsLATEARRIVAL)
+    } else {
+        alerts.clearAlert(AlertsLATEARRIVAL)
+    }
+
+    (*a)[ROUTESTATUS] = status
+}
+
 //***********************************
 //**         COMPLIANCE            **
 //***********************************
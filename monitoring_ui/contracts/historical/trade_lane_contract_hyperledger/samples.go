@@ -27,13 +27,22 @@ var samples = `
     "state": {
         "alerts": {
             "active": [
-                "OVERTTEMP"
+                "OVERTEMP",
+                "OFFROUTE",
+                "UNEXPECTEDSTOP",
+                "LATEARRIVAL"
             ],
             "cleared": [
-                "OVERTTEMP"
+                "OVERTEMP",
+                "OFFROUTE",
+                "UNEXPECTEDSTOP",
+                "LATEARRIVAL"
             ],
             "raised": [
-                "OVERTTEMP"
+                "OVERTEMP",
+                "OFFROUTE",
+                "UNEXPECTEDSTOP",
+                "LATEARRIVAL"
             ]
         },
         "assetID": "The ID of a managed asset. The resource focal point for a smart contract.",
@@ -51,6 +60,23 @@ var samples = `
             "latitude": 123.456,
             "longitude": 123.456
         },
+        "routeStatus": {
+            "arrivals": [
+                {
+                    "arrived": "carpe noctem",
+                    "departed": "carpe noctem",
+                    "late": true,
+                    "name": "carpe noctem"
+                }
+            ],
+            "lastLatitude": 123.456,
+            "lastLongitude": 123.456,
+            "lastReading": "carpe noctem",
+            "nextWaypoint": 789,
+            "offRouteKm": 123.456,
+            "routeID": "carpe noctem",
+            "stoppedSince": "carpe noctem"
+        },
         "temperature": 123.456,
         "timestamp": "2016-04-27T13:03:48.9263143-04:00"
     }
@@ -219,7 +219,10 @@ var schemas = `
                                         "items": {
                                             "description": "Alerts are triggered or cleared by rules that are run against incoming events. This contract considers any active alert to created a state of non-compliance.",
                                             "enum": [
-                                                "OVERTTEMP"
+                                                "OVERTEMP",
+                                                "OFFROUTE",
+                                                "UNEXPECTEDSTOP",
+                                                "LATEARRIVAL"
                                             ],
                                             "type": "string"
                                         },
@@ -230,7 +233,10 @@ var schemas = `
                                         "items": {
                                             "description": "Alerts are triggered or cleared by rules that are run against incoming events. This contract considers any active alert to created a state of non-compliance.",
                                             "enum": [
-                                                "OVERTTEMP"
+                                                "OVERTEMP",
+                                                "OFFROUTE",
+                                                "UNEXPECTEDSTOP",
+                                                "LATEARRIVAL"
                                             ],
                                             "type": "string"
                                         },
@@ -241,7 +247,10 @@ var schemas = `
                                         "items": {
                                             "description": "Alerts are triggered or cleared by rules that are run against incoming events. This contract considers any active alert to created a state of non-compliance.",
                                             "enum": [
-                                                "OVERTTEMP"
+                                                "OVERTEMP",
+                                                "OFFROUTE",
+                                                "UNEXPECTEDSTOP",
+                                                "LATEARRIVAL"
                                             ],
                                             "type": "string"
                                         },
@@ -301,6 +310,56 @@ var schemas = `
                                 },
                                 "type": "object"
                             },
+                            "routeStatus": {
+                                "description": "Read-only. The asset's progress along its planned route, calculated from the location readings.",
+                                "properties": {
+                                    "arrivals": {
+                                        "items": {
+                                            "properties": {
+                                                "arrived": {
+                                                    "type": "string"
+                                                },
+                                                "departed": {
+                                                    "type": "string"
+                                                },
+                                                "late": {
+                                                    "type": "boolean"
+                                                },
+                                                "name": {
+                                                    "type": "string"
+                                                }
+                                            },
+                                            "type": "object"
+                                        },
+                                        "minItems": 0,
+                                        "type": "array"
+                                    },
+                                    "lastLatitude": {
+                                        "type": "number"
+                                    },
+                                    "lastLongitude": {
+                                        "type": "number"
+                                    },
+                                    "lastReading": {
+                                        "type": "string"
+                                    },
+                                    "nextWaypoint": {
+                                        "description": "Index of the next waypoint to be reached.",
+                                        "type": "integer"
+                                    },
+                                    "offRouteKm": {
+                                        "description": "Distance in km from the nearest leg still ahead.",
+                                        "type": "number"
+                                    },
+                                    "routeID": {
+                                        "type": "string"
+                                    },
+                                    "stoppedSince": {
+                                        "type": "string"
+                                    }
+                                },
+                                "type": "object"
+                            },
                             "temperature": {
                                 "description": "Temperature of the asset in CELSIUS.",
                                 "type": "number"
@@ -354,7 +413,10 @@ var schemas = `
                                     "items": {
                                         "description": "Alerts are triggered or cleared by rules that are run against incoming events. This contract considers any active alert to created a state of non-compliance.",
                                         "enum": [
-                                            "OVERTTEMP"
+                                            "OVERTEMP",
+                                            "OFFROUTE",
+                                            "UNEXPECTEDSTOP",
+                                            "LATEARRIVAL"
                                         ],
                                         "type": "string"
                                     },
@@ -365,7 +427,10 @@ var schemas = `
                                     "items": {
                                         "description": "Alerts are triggered or cleared by rules that are run against incoming events. This contract considers any active alert to created a state of non-compliance.",
                                         "enum": [
-                                            "OVERTTEMP"
+                                            "OVERTEMP",
+                                            "OFFROUTE",
+                                            "UNEXPECTEDSTOP",
+                                            "LATEARRIVAL"
                                         ],
                                         "type": "string"
                                     },
@@ -376,7 +441,10 @@ var schemas = `
                                     "items": {
                                         "description": "Alerts are triggered or cleared by rules that are run against incoming events. This contract considers any active alert to created a state of non-compliance.",
                                         "enum": [
-                                            "OVERTTEMP"
+                                            "OVERTEMP",
+                                            "OFFROUTE",
+                                            "UNEXPECTEDSTOP",
+                                            "LATEARRIVAL"
                                         ],
                                         "type": "string"
                                     },
@@ -436,6 +504,56 @@ var schemas = `
                             },
                             "type": "object"
                         },
+                        "routeStatus": {
+                            "description": "Read-only. The asset's progress along its planned route, calculated from the location readings.",
+                            "properties": {
+                                "arrivals": {
+                                    "items": {
+                                        "properties": {
+                                            "arrived": {
+                                                "type": "string"
+                                            },
+                                            "departed": {
+                                                "type": "string"
+                                            },
+                                            "late": {
+                                                "type": "boolean"
+                                            },
+                                            "name": {
+                                                "type": "string"
+                                            }
+                                        },
+                                        "type": "object"
+                                    },
+                                    "minItems": 0,
+                                    "type": "array"
+                                },
+                                "lastLatitude": {
+                                    "type": "number"
+                                },
+                                "lastLongitude": {
+                                    "type": "number"
+                                },
+                                "lastReading": {
+                                    "type": "string"
+                                },
+                                "nextWaypoint": {
+                                    "description": "Index of the next waypoint to be reached.",
+                                    "type": "integer"
+                                },
+                                "offRouteKm": {
+                                    "description": "Distance in km from the nearest leg still ahead.",
+                                    "type": "number"
+                                },
+                                "routeID": {
+                                    "type": "string"
+                                },
+                                "stoppedSince": {
+                                    "type": "string"
+                                }
+                            },
+                            "type": "object"
+                        },
                         "temperature": {
                             "description": "Temperature of the asset in CELSIUS.",
                             "type": "number"
@@ -494,7 +612,10 @@ var schemas = `
                                         "items": {
                                             "description": "Alerts are triggered or cleared by rules that are run against incoming events. This contract considers any active alert to created a state of non-compliance.",
                                             "enum": [
-                                                "OVERTTEMP"
+                                                "OVERTEMP",
+                                                "OFFROUTE",
+                                                "UNEXPECTEDSTOP",
+                                                "LATEARRIVAL"
                                             ],
                                             "type": "string"
                                         },
@@ -505,7 +626,10 @@ var schemas = `
                                         "items": {
                                             "description": "Alerts are triggered or cleared by rules that are run against incoming events. This contract considers any active alert to created a state of non-compliance.",
                                             "enum": [
-                                                "OVERTTEMP"
+                                                "OVERTEMP",
+                                                "OFFROUTE",
+                                                "UNEXPECTEDSTOP",
+                                                "LATEARRIVAL"
                                             ],
                                             "type": "string"
                                         },
@@ -516,7 +640,10 @@ var schemas = `
                                         "items": {
                                             "description": "Alerts are triggered or cleared by rules that are run against incoming events. This contract considers any active alert to created a state of non-compliance.",
                                             "enum": [
-                                                "OVERTTEMP"
+                                                "OVERTEMP",
+                                                "OFFROUTE",
+                                                "UNEXPECTEDSTOP",
+                                                "LATEARRIVAL"
                                             ],
                                             "type": "string"
                                         },
@@ -576,6 +703,56 @@ var schemas = `
                                 },
                                 "type": "object"
                             },
+                            "routeStatus": {
+                                "description": "Read-only. The asset's progress along its planned route, calculated from the location readings.",
+                                "properties": {
+                                    "arrivals": {
+                                        "items": {
+                                            "properties": {
+                                                "arrived": {
+                                                    "type": "string"
+                                                },
+                                                "departed": {
+                                                    "type": "string"
+                                                },
+                                                "late": {
+                                                    "type": "boolean"
+                                                },
+                                                "name": {
+                                                    "type": "string"
+                                                }
+                                            },
+                                            "type": "object"
+                                        },
+                                        "minItems": 0,
+                                        "type": "array"
+                                    },
+                                    "lastLatitude": {
+                                        "type": "number"
+                                    },
+                                    "lastLongitude": {
+                                        "type": "number"
+                                    },
+                                    "lastReading": {
+                                        "type": "string"
+                                    },
+                                    "nextWaypoint": {
+                                        "description": "Index of the next waypoint to be reached.",
+                                        "type": "integer"
+                                    },
+                                    "offRouteKm": {
+                                        "description": "Distance in km from the nearest leg still ahead.",
+                                        "type": "number"
+                                    },
+                                    "routeID": {
+                                        "type": "string"
+                                    },
+                                    "stoppedSince": {
+                                        "type": "string"
+                                    }
+                                },
+                                "type": "object"
+                            },
                             "temperature": {
                                 "description": "Temperature of the asset in CELSIUS.",
                                 "type": "number"
@@ -593,6 +770,105 @@ var schemas = `
             },
             "type": "object"
         },
+        "readAssetRoute": {
+            "description": "Returns the planned route for an asset.",
+            "properties": {
+                "args": {
+                    "description": "args are JSON encoded strings",
+                    "items": {
+                        "description": "An object containing only an assetID for use as an argument to read or delete.",
+                        "properties": {
+                            "assetID": {
+                                "description": "The ID of a managed asset. The resource focal point for a smart contract.",
+                                "type": "string"
+                            }
+                        },
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
+                    "type": "array"
+                },
+                "function": {
+                    "description": "readAssetRoute function",
+                    "enum": [
+                        "readAssetRoute"
+                    ],
+                    "type": "string"
+                },
+                "result": {
+                    "description": "The planned route for an asset. The waypoints form a polyline and the asset is on route while within the corridor of any leg not yet completed.",
+                    "properties": {
+                        "assetID": {
+                            "description": "The ID of a managed asset. The resource focal point for a smart contract.",
+                            "type": "string"
+                        },
+                        "corridorKm": {
+                            "description": "Tolerance in km either side of each leg. Defaults to 25.",
+                            "type": "number"
+                        },
+                        "maxStopMinutes": {
+                            "description": "Longest stop in minutes allowed away from a waypoint. Zero allows any stop.",
+                            "type": "number"
+                        },
+                        "routeID": {
+                            "description": "Read-only. Identifies the transaction that set this route.",
+                            "type": "string"
+                        },
+                        "stopRadiusKm": {
+                            "description": "The asset is stopped when it moves less than this distance in km between readings. Defaults to 0.5.",
+                            "type": "number"
+                        },
+                        "waypoints": {
+                            "items": {
+                                "description": "A place on the planned route that the asset must pass through in order.",
+                                "properties": {
+                                    "corridorKm": {
+                                        "description": "Tolerance in km either side of the leg that ends at this waypoint. Defaults to the route corridor.",
+                                        "type": "number"
+                                    },
+                                    "dwellMinutes": {
+                                        "description": "Expected time at the waypoint in minutes, staying longer is an unexpected stop. Zero allows any dwell.",
+                                        "type": "number"
+                                    },
+                                    "eta": {
+                                        "description": "RFC3339 expected arrival time, arriving after it is a late arrival.",
+                                        "type": "string"
+                                    },
+                                    "latitude": {
+                                        "type": "number"
+                                    },
+                                    "longitude": {
+                                        "type": "number"
+                                    },
+                                    "name": {
+                                        "description": "The name of the waypoint, e.g. a port.",
+                                        "type": "string"
+                                    },
+                                    "radiusKm": {
+                                        "description": "The asset has arrived when it is within this distance in km. Defaults to 5.",
+                                        "type": "number"
+                                    }
+                                },
+                                "required": [
+                                    "latitude",
+                                    "longitude"
+                                ],
+                                "type": "object"
+                            },
+                            "minItems": 1,
+                            "type": "array"
+                        }
+                    },
+                    "required": [
+                        "assetID",
+                        "waypoints"
+                    ],
+                    "type": "object"
+                }
+            },
+            "type": "object"
+        },
         "readRecentStates": {
             "description": "Returns the state of recently updated assets as an array of objects sorted with the most recently updated asset first. Each asset appears exactly once up to a maxmum of 20 in this version of the contract.",
             "properties": {
@@ -622,7 +898,10 @@ var schemas = `
                                         "items": {
                                             "description": "Alerts are triggered or cleared by rules that are run against incoming events. This contract considers any active alert to created a state of non-compliance.",
                                             "enum": [
-                                                "OVERTTEMP"
+                                                "OVERTEMP",
+                                                "OFFROUTE",
+                                                "UNEXPECTEDSTOP",
+                                                "LATEARRIVAL"
                                             ],
                                             "type": "string"
                                         },
@@ -633,7 +912,10 @@ var schemas = `
                                         "items": {
                                             "description": "Alerts are triggered or cleared by rules that are run against incoming events. This contract considers any active alert to created a state of non-compliance.",
                                             "enum": [
-                                                "OVERTTEMP"
+                                                "OVERTEMP",
+                                                "OFFROUTE",
+                                                "UNEXPECTEDSTOP",
+                                                "LATEARRIVAL"
                                             ],
                                             "type": "string"
                                         },
@@ -644,7 +926,10 @@ var schemas = `
                                         "items": {
                                             "description": "Alerts are triggered or cleared by rules that are run against incoming events. This contract considers any active alert to created a state of non-compliance.",
                                             "enum": [
-                                                "OVERTTEMP"
+                                                "OVERTEMP",
+                                                "OFFROUTE",
+                                                "UNEXPECTEDSTOP",
+                                                "LATEARRIVAL"
                                             ],
                                             "type": "string"
                                         },
@@ -704,6 +989,56 @@ var schemas = `
                                 },
                                 "type": "object"
                             },
+                            "routeStatus": {
+                                "description": "Read-only. The asset's progress along its planned route, calculated from the location readings.",
+                                "properties": {
+                                    "arrivals": {
+                                        "items": {
+                                            "properties": {
+                                                "arrived": {
+                                                    "type": "string"
+                                                },
+                                                "departed": {
+                                                    "type": "string"
+                                                },
+                                                "late": {
+                                                    "type": "boolean"
+                                                },
+                                                "name": {
+                                                    "type": "string"
+                                                }
+                                            },
+                                            "type": "object"
+                                        },
+                                        "minItems": 0,
+                                        "type": "array"
+                                    },
+                                    "lastLatitude": {
+                                        "type": "number"
+                                    },
+                                    "lastLongitude": {
+                                        "type": "number"
+                                    },
+                                    "lastReading": {
+                                        "type": "string"
+                                    },
+                                    "nextWaypoint": {
+                                        "description": "Index of the next waypoint to be reached.",
+                                        "type": "integer"
+                                    },
+                                    "offRouteKm": {
+                                        "description": "Distance in km from the nearest leg still ahead.",
+                                        "type": "number"
+                                    },
+                                    "routeID": {
+                                        "type": "string"
+                                    },
+                                    "stoppedSince": {
+                                        "type": "string"
+                                    }
+                                },
+                                "type": "object"
+                            },
                             "temperature": {
                                 "description": "Temperature of the asset in CELSIUS.",
                                 "type": "number"
@@ -721,6 +1056,95 @@ var schemas = `
             },
             "type": "object"
         },
+        "setAssetRoute": {
+            "description": "Sets the planned route for an asset, replacing any existing route and restarting the asset's route progress. The route may be set before the asset is created and is deleted with the asset.",
+            "properties": {
+                "args": {
+                    "description": "args are JSON encoded strings",
+                    "items": {
+                        "description": "The planned route for an asset. The waypoints form a polyline and the asset is on route while within the corridor of any leg not yet completed.",
+                        "properties": {
+                            "assetID": {
+                                "description": "The ID of a managed asset. The resource focal point for a smart contract.",
+                                "type": "string"
+                            },
+                            "corridorKm": {
+                                "description": "Tolerance in km either side of each leg. Defaults to 25.",
+                                "type": "number"
+                            },
+                            "maxStopMinutes": {
+                                "description": "Longest stop in minutes allowed away from a waypoint. Zero allows any stop.",
+                                "type": "number"
+                            },
+                            "routeID": {
+                                "description": "Read-only. Identifies the transaction that set this route.",
+                                "type": "string"
+                            },
+                            "stopRadiusKm": {
+                                "description": "The asset is stopped when it moves less than this distance in km between readings. Defaults to 0.5.",
+                                "type": "number"
+                            },
+                            "waypoints": {
+                                "items": {
+                                    "description": "A place on the planned route that the asset must pass through in order.",
+                                    "properties": {
+                                        "corridorKm": {
+                                            "description": "Tolerance in km either side of the leg that ends at this waypoint. Defaults to the route corridor.",
+                                            "type": "number"
+                                        },
+                                        "dwellMinutes": {
+                                            "description": "Expected time at the waypoint in minutes, staying longer is an unexpected stop. Zero allows any dwell.",
+                                            "type": "number"
+                                        },
+                                        "eta": {
+                                            "description": "RFC3339 expected arrival time, arriving after it is a late arrival.",
+                                            "type": "string"
+                                        },
+                                        "latitude": {
+                                            "type": "number"
+                                        },
+                                        "longitude": {
+                                            "type": "number"
+                                        },
+                                        "name": {
+                                            "description": "The name of the waypoint, e.g. a port.",
+                                            "type": "string"
+                                        },
+                                        "radiusKm": {
+                                            "description": "The asset has arrived when it is within this distance in km. Defaults to 5.",
+                                            "type": "number"
+                                        }
+                                    },
+                                    "required": [
+                                        "latitude",
+                                        "longitude"
+                                    ],
+                                    "type": "object"
+                                },
+                                "minItems": 1,
+                                "type": "array"
+                            }
+                        },
+                        "required": [
+                            "assetID",
+                            "waypoints"
+                        ],
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
+                    "type": "array"
+                },
+                "function": {
+                    "description": "setAssetRoute function",
+                    "enum": [
+                        "setAssetRoute"
+                    ],
+                    "type": "string"
+                }
+            },
+            "type": "object"
+        },
         "setCreateOnUpdate": {
             "description": "Allow updateAsset to redirect to createAsset when assetID does not exist.",
             "properties": {
@@ -926,6 +1350,76 @@ var schemas = `
             ],
             "type": "object"
         },
+        "route": {
+            "description": "The planned route for an asset. The waypoints form a polyline and the asset is on route while within the corridor of any leg not yet completed.",
+            "properties": {
+                "assetID": {
+                    "description": "The ID of a managed asset. The resource focal point for a smart contract.",
+                    "type": "string"
+                },
+                "corridorKm": {
+                    "description": "Tolerance in km either side of each leg. Defaults to 25.",
+                    "type": "number"
+                },
+                "maxStopMinutes": {
+                    "description": "Longest stop in minutes allowed away from a waypoint. Zero allows any stop.",
+                    "type": "number"
+                },
+                "routeID": {
+                    "description": "Read-only. Identifies the transaction that set this route.",
+                    "type": "string"
+                },
+                "stopRadiusKm": {
+                    "description": "The asset is stopped when it moves less than this distance in km between readings. Defaults to 0.5.",
+                    "type": "number"
+                },
+                "waypoints": {
+                    "items": {
+                        "description": "A place on the planned route that the asset must pass through in order.",
+                        "properties": {
+                            "corridorKm": {
+                                "description": "Tolerance in km either side of the leg that ends at this waypoint. Defaults to the route corridor.",
+                                "type": "number"
+                            },
+                            "dwellMinutes": {
+                                "description": "Expected time at the waypoint in minutes, staying longer is an unexpected stop. Zero allows any dwell.",
+                                "type": "number"
+                            },
+                            "eta": {
+                                "description": "RFC3339 expected arrival time, arriving after it is a late arrival.",
+                                "type": "string"
+                            },
+                            "latitude": {
+                                "type": "number"
+                            },
+                            "longitude": {
+                                "type": "number"
+                            },
+                            "name": {
+                                "description": "The name of the waypoint, e.g. a port.",
+                                "type": "string"
+                            },
+                            "radiusKm": {
+                                "description": "The asset has arrived when it is within this distance in km. Defaults to 5.",
+                                "type": "number"
+                            }
+                        },
+                        "required": [
+                            "latitude",
+                            "longitude"
+                        ],
+                        "type": "object"
+                    },
+                    "minItems": 1,
+                    "type": "array"
+                }
+            },
+            "required": [
+                "assetID",
+                "waypoints"
+            ],
+            "type": "object"
+        },
         "state": {
             "description": "A set of fields that constitute the complete asset state.",
             "properties": {
@@ -936,7 +1430,10 @@ var schemas = `
                             "items": {
                                 "description": "Alerts are triggered or cleared by rules that are run against incoming events. This contract considers any active alert to created a state of non-compliance.",
                                 "enum": [
-                                    "OVERTTEMP"
+                                    "OVERTEMP",
+                                    "OFFROUTE",
+                                    "UNEXPECTEDSTOP",
+                                    "LATEARRIVAL"
                                 ],
                                 "type": "string"
                             },
@@ -947,7 +1444,10 @@ var schemas = `
                             "items": {
                                 "description": "Alerts are triggered or cleared by rules that are run against incoming events. This contract considers any active alert to created a state of non-compliance.",
                                 "enum": [
-                                    "OVERTTEMP"
+                                    "OVERTEMP",
+                                    "OFFROUTE",
+                                    "UNEXPECTEDSTOP",
+                                    "LATEARRIVAL"
                                 ],
                                 "type": "string"
                             },
@@ -958,7 +1458,10 @@ var schemas = `
                             "items": {
                                 "description": "Alerts are triggered or cleared by rules that are run against incoming events. This contract considers any active alert to created a state of non-compliance.",
                                 "enum": [
-                                    "OVERTTEMP"
+                                    "OVERTEMP",
+                                    "OFFROUTE",
+                                    "UNEXPECTEDSTOP",
+                                    "LATEARRIVAL"
                                 ],
                                 "type": "string"
                             },
@@ -1018,6 +1521,56 @@ var schemas = `
                     },
                     "type": "object"
                 },
+                "routeStatus": {
+                    "description": "Read-only. The asset's progress along its planned route, calculated from the location readings.",
+                    "properties": {
+                        "arrivals": {
+                            "items": {
+                                "properties": {
+                                    "arrived": {
+                                        "type": "string"
+                                    },
+                                    "departed": {
+                                        "type": "string"
+                                    },
+                                    "late": {
+                                        "type": "boolean"
+                                    },
+                                    "name": {
+                                        "type": "string"
+                                    }
+                                },
+                                "type": "object"
+                            },
+                            "minItems": 0,
+                            "type": "array"
+                        },
+                        "lastLatitude": {
+                            "type": "number"
+                        },
+                        "lastLongitude": {
+                            "type": "number"
+                        },
+                        "lastReading": {
+                            "type": "string"
+                        },
+                        "nextWaypoint": {
+                            "description": "Index of the next waypoint to be reached.",
+                            "type": "integer"
+                        },
+                        "offRouteKm": {
+                            "description": "Distance in km from the nearest leg still ahead.",
+                            "type": "number"
+                        },
+                        "routeID": {
+                            "type": "string"
+                        },
+                        "stoppedSince": {
+                            "type": "string"
+                        }
+                    },
+                    "type": "object"
+                },
                 "temperature": {
                     "description": "Temperature of the asset in CELSIUS.",
                     "type": "number"
@@ -22,6 +22,8 @@ var contractState = common.BLContractState{common.MYVERSION, "",""}
 
 const blContFunctionCall string = "createContainerLogistics"
 const blCompFunctionCall string = "createUpdateComplianceRecord"
+const blSwapFunctionCall string = "swapContainerLogistics"
+const blStatusFunctionCall string = "readBillOfLadingStatus"
 
 //************* main *******************
 //Create SimpleChaincode instance
@@ -83,6 +85,8 @@ func (t *SimpleChaincode) Invoke(stub shim.ChaincodeStubInterface, function stri
             return t.registerBillOfLading(stub, args)
         case "deregisterBillOfLading" :
             return t.deregisterBillOfLading(stub, args)
+        case "swapBillOfLadingContainer" :
+            return t.swapBillOfLadingContainer(stub, args)
         default:
             return nil, errors.New("Unknown function call to compliance : invoke")
     }
@@ -98,6 +102,8 @@ func (t *SimpleChaincode) Query(stub shim.ChaincodeStubInterface, function strin
             return t.getBillOfLadingRegistration(stub, args)
         case "getBillOfLadingRegistrationSchema" :
             return t.getBillOfLadingRegistrationSchema(stub, args)
+        case "getBillOfLadingStatus" :
+            return t.getBillOfLadingStatus(stub, args)
         default:
             return nil, errors.New("Unknown function call to compliance : invoke")
     }
@@ -136,11 +142,30 @@ func (t *SimpleChaincode) registerBillOfLading(stub shim.ChaincodeStubInterface,
    
     // fmt.Println(" Check if Bill of Lading and container numbers have been sent in correctly")
      blReg.BLNo = strings.TrimSpace(blReg.BLNo)
-     blReg.ContainerNos = strings.TrimSpace(blReg.ContainerNos)
-     if blReg.BLNo=="" || blReg.ContainerNos =="" {
+     if blReg.BLNo=="" || len(blReg.ContainerNos) ==0 {
         err = errors.New("Bill of Lading  / Container numbers cannot be blank")
         fmt.Println(err)
         return nil, err
+    }
+    for i, cont := range blReg.ContainerNos {
+        if blReg.ContainerNos.Contains(cont) != i {
+            return nil, errors.New("Container " + cont + " is listed more than once")
+        }
+    }
+    // Containers are only swapped after registration
+    blReg.SwappedContainerNos = nil
+    // Notify range defaults when notify locations are sent in without one
+    if len(blReg.NotifyLocations) > 0 && blReg.NotifyRange == nil {
+        blReg.NotifyRange = &common.NotifyRange{LatRange: common.DEFAULTLATRANGE, LongRange: common.DEFAULTLONGRANGE}
+    }
+    if blReg.NotifyRange != nil && (blReg.NotifyRange.LatRange < 0 || blReg.NotifyRange.LongRange < 0) {
+        return nil, errors.New("Notify range cannot be negative")
+    }
+    if tol := blReg.Tolerance; tol != nil && (tol.Temperature < 0 || tol.Humidity < 0 || tol.Light < 0 || tol.Acceleration < 0) {
+        return nil, errors.New("Excursion tolerance cannot be negative")
+    }
+    if blReg.ActivationEnergy < 0 {
+        return nil, errors.New("Activation energy cannot be negative")
     }
      //fmt.Println(" After checking blank")
      // Implementing the transaction timestamp feature
@@ -304,6 +329,92 @@ func (t *SimpleChaincode) deregisterBillOfLading(stub shim.ChaincodeStubInterfac
     
 }
 
+// ***********************swapBillOfLadingContainer************************
+// The shipment moves from one container to another mid-transit. The old container stays
+// on the B/L as a swapped container, so its compliance remains part of the B/L compliance
+
+func (t *SimpleChaincode) swapBillOfLadingContainer(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) { 
+    var blReg common.BillOfLadingRegistration
+    var swap common.ContainerSwap
+    var contractState common.BLContractState
+
+    if len(args) !=1 {
+        return nil, errors.New("Incorrect number of arguments. Expecting a single JSON string with mandatory BillofLading, Container Number and New Container Number")
+	}
+    err := json.Unmarshal([]byte(args[0]), &swap)
+    if err != nil {
+		return nil, err
+    }
+    swap.BLNo = strings.TrimSpace(swap.BLNo)
+    swap.ContainerNo = strings.TrimSpace(swap.ContainerNo)
+    swap.NewContainerNo = strings.TrimSpace(swap.NewContainerNo)
+    if swap.BLNo=="" || swap.ContainerNo=="" || swap.NewContainerNo=="" {
+        return nil, errors.New("Bill of Lading / Container / New Container numbers cannot be blank")
+    }
+    blRegData, err := stub.GetState(swap.BLNo)
+    if err != nil || len(blRegData) == 0 {
+        return nil, errors.New("Unable to retrieve Bill of Lading data from the stub")
+    }
+    err = json.Unmarshal(blRegData, &blReg)
+    if err != nil {
+		return nil, err
+    }
+    if blReg.TransitComplete {
+        return nil, errors.New("Transit of Bill of Lading " + swap.BLNo + " is complete, containers cannot be swapped")
+    }
+    pos := blReg.ContainerNos.Contains(swap.ContainerNo)
+    if pos < 0 {
+        return nil, errors.New("Container " + swap.ContainerNo + " is not on Bill of Lading " + swap.BLNo)
+    }
+    if blReg.ContainerNos.Contains(swap.NewContainerNo) >= 0 || blReg.SwappedContainerNos.Contains(swap.NewContainerNo) >= 0 {
+        return nil, errors.New("Container " + swap.NewContainerNo + " is already part of Bill of Lading " + swap.BLNo)
+    }
+    txnTime, err:= stub.GetTxTimestamp()
+    if err !=nil {
+        err=errors.New("Unable to get transction time")
+        return nil, err
+    }
+    txntimestamp := time.Unix(txnTime.Seconds, int64(txnTime.Nanos))
+    if strings.TrimSpace(swap.Timestamp)=="" {
+        swap.Timestamp = txntimestamp.String()
+    }
+    blReg.ContainerNos[pos] = swap.NewContainerNo
+    blReg.SwappedContainerNos = append(blReg.SwappedContainerNos, swap.ContainerNo)
+
+    //*************************************************************************
+    // Invoke the container logistics contract to move the shipment to the new container
+    contractStateJson, err := stub.GetState(common.BLSTATEKEY)
+    if err != nil {
+        return nil,errors.New("Unable to fetch container and compliance contract keys")
+    }
+    err = json.Unmarshal(contractStateJson, &contractState)
+    if err != nil {
+        return nil, err
+    }
+    swapJSON, err := json.Marshal(swap)
+    if err != nil {
+        return nil, errors.New("Marshaling container swap failed")
+    }
+	var invokeArgs = make([]string, 0) 
+    invokeArgs = append(invokeArgs, blSwapFunctionCall)
+    invokeArgs = append(invokeArgs, string(swapJSON))
+	_, err = stub.InvokeChaincode(contractState.ContainerCC, util.ToChaincodeArgs(invokeArgs...))
+	if err != nil {
+		errStr := fmt.Sprintf("Failed to invoke chaincode. Got error: %s", err.Error())
+		return nil, errors.New(errStr)
+	}
+    //*************************************************************************
+    regJSON, err := json.Marshal(blReg)
+    if err != nil {
+        return nil, errors.New("Marshaling bill of lading data in container swap failed")
+    }
+    err = stub.PutState(swap.BLNo, regJSON)
+    if err != nil {
+        return nil, errors.New("Updating bill of lading registration data in the ledger failed")
+    }
+    return nil, nil
+}
+
 // ************************************
 // query functions 
 // ************************************
@@ -318,10 +429,11 @@ func (t *SimpleChaincode) deregisterBillOfLading(stub shim.ChaincodeStubInterfac
 func (t *SimpleChaincode) getBillOfLadingRegistrationSchema(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) { 
     // Temporarily hardcoded. This is not at present intended to be JSON compatible
     // Can be explanded later to use a combination of ast and default definitions
-    bl := []byte (`{ "BLNo": "0000000000", "ContainerNos" : "MSKU000000, MRSK000000",  "Hazmat"  : false,
+    bl := []byte (`{ "BLNo": "0000000000", "ContainerNos" : ["MSKU000000", "MRSK000000"],  "Hazmat"  : false,
      "MinTemperature" : -20.00,  "MaxTemperature" : 0.00,   "MinHumidity" : 20.00,  "MaxHumidity" : 50.00,  
-     "MinLight" : 0.00,   "MaxLight" : 100.00, "MinAcceleration" : 0.001,  "MaxAcceleration" : 1.9  }`)
-      // Notify-aspects are not represented at present. Will be added later
+     "MinLight" : 0.00,   "MaxLight" : 100.00, "MinAcceleration" : 0.001,  "MaxAcceleration" : 1.9,
+     "NotifyLocations" : [{"Latitude" : 51.95, "Longitude" : 4.14}], "NotifyRange" : {"LatRange" : 0.5, "LongRange" : 0.5},
+     "Tolerance" : {"Temperature" : 30, "Humidity" : 60}, "MaxMKT" : -18.00  }`)
       // Will be replaced by the schema implementation later for consumption by the UI
 	return bl, nil
 }
@@ -335,11 +447,80 @@ func (t *SimpleChaincode) getBillOfLadingRegistrationSchema(stub shim.ChaincodeS
 
 func (t *SimpleChaincode) getBillOfLadingRegistration(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
     var err error
+    var blReg common.BillOfLadingRegistration
+    blRegData, err:=t.fetchBLData( stub, args)
+    if err !=nil{
+        return nil, err     
+    }
+    // Transit completes in the container contract, when the containers arrive at a notify location
+    err = json.Unmarshal(blRegData, &blReg)
+    if err != nil || blReg.TransitComplete {
+        return blRegData, nil
+    }
+    blStatus, err := t.queryBillOfLadingStatus(stub, blReg.BLNo)
+    if err != nil || !blStatus.TransitComplete {
+        return blRegData, nil
+    }
+    blReg.TransitComplete = true
+    return json.Marshal(blReg)
+}
+
+// ************************************
+// getBillOfLadingStatus
+// ************************************
+// This returns the compliance and transit of every container on the Bill of Lading,
+// including containers swapped out mid-transit
+
+func (t *SimpleChaincode) getBillOfLadingStatus(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+    var blReg common.BillOfLadingRegistration
     blRegData, err:=t.fetchBLData( stub, args)
     if err !=nil{
         return nil, err     
     }
-    return blRegData, nil
+    err = json.Unmarshal(blRegData, &blReg)
+    if err != nil {
+        return nil, errors.New("Bill of Lading is not registered")
+    }
+    blStatus, err := t.queryBillOfLadingStatus(stub, blReg.BLNo)
+    if err != nil {
+        return nil, err
+    }
+    return json.Marshal(blStatus)
+}
+
+// ************************************
+// queryBillOfLadingStatus 
+// ************************************
+// internal utiltiy function, queries the container contract for the B/L roll up
+
+func (t *SimpleChaincode) queryBillOfLadingStatus(stub shim.ChaincodeStubInterface, bKey string) (common.BillOfLadingStatus, error) {
+    var blStatus common.BillOfLadingStatus
+    var contractState common.BLContractState
+    contractStateJson, err := stub.GetState(common.BLSTATEKEY)
+    if err != nil {
+        return blStatus, errors.New("Unable to fetch container and compliance contract keys")
+    }
+    err = json.Unmarshal(contractStateJson, &contractState)
+    if err != nil {
+        return blStatus, err
+    }
+    blJSON, err := json.Marshal(common.BillOfLadingStatus{BLNo: bKey})
+    if err != nil {
+        return blStatus, err
+    }
+	var queryArgs = make([]string, 0) 
+    queryArgs = append(queryArgs, blStatusFunctionCall)
+    queryArgs = append(queryArgs, string(blJSON))
+    statusJSON, err := stub.QueryChaincode(contractState.ContainerCC, util.ToChaincodeArgs(queryArgs...))
+	if err != nil {
+		errStr := fmt.Sprintf("Failed to query chaincode. Got error: %s", err.Error())
+		return blStatus, errors.New(errStr)
+	}
+    err = json.Unmarshal(statusJSON, &blStatus)
+    if err != nil {
+        return blStatus, errors.New("Bill of Lading status unmarshal failed: " + fmt.Sprint(err))
+    }
+    return blStatus, nil
 }
 // ************************************
 // getBillOfLadingState
@@ -1,6 +1,8 @@
 package Common;
 import (
     "encoding/json"
+    "errors"
+    "strings"
 )
 const BLSTATEKEY string = "BLSTATEKEY" 
 const CONTSTATEKEY string = "CONTSTATEKEY"
@@ -10,6 +12,14 @@ const BLSTATE string = "_STATE"
 
 const MYVERSION string = "2.0.0"
 
+// Default notify range in degrees of latitude and longitude, used when a B/L
+// has notify locations but no notify range
+const DEFAULTLATRANGE float64 = 1.0
+const DEFAULTLONGRANGE float64 = 1.0
+
+// Default activation energy for mean kinetic temperature, in kJ/mol (USP <1160>)
+const DEFAULTACTIVATIONENERGY float64 = 83.144
+
 
 type BLContractState struct {
     Version      string                        `json:"version"`
@@ -36,12 +46,67 @@ type Airquality struct {
 
 // This is  optional. It stands for the 'acceptable range', say 1 degree of lat and long
 // at which the container should be, before it is considered 'arrived' at 'Notified Party' location'
-// If not sent in, some default could be assumed (say 1 degree or )
+// If not sent in, DEFAULTLATRANGE and DEFAULTLONGRANGE apply
 type NotifyRange struct {
     LatRange        float64 `json:"latrange,omitempty"`
     LongRange       float64 `json:"longrange,omitempty"`
 }
 
+// Within returns the first notify location whose range contains the location.
+// A location of 0,0 is treated as not reported.
+func (r NotifyRange) Within(loc Geolocation, notifyLocations []Geolocation) (Geolocation, bool) {
+    if loc == (Geolocation{}) {
+        return Geolocation{}, false
+    }
+    for _, n := range notifyLocations {
+        dLat := loc.Latitude - n.Latitude
+        dLong := loc.Longitude - n.Longitude
+        // longitude wraps at the date line
+        if dLong > 180 {
+            dLong -= 360
+        } else if dLong < -180 {
+            dLong += 360
+        }
+        if dLat <= r.LatRange && dLat >= -r.LatRange && dLong <= r.LongRange && dLong >= -r.LongRange {
+            return n, true
+        }
+    }
+    return Geolocation{}, false
+}
+
+// ContainerList is the list of containers on a Bill of Lading. A comma separated
+// string is still accepted when unmarshalling, for registrations in the old format
+type ContainerList []string
+
+func (c *ContainerList) UnmarshalJSON(data []byte) error {
+    var list []string
+    if err := json.Unmarshal(data, &list); err != nil {
+        var sList string
+        if err := json.Unmarshal(data, &sList); err != nil {
+            return errors.New("containernos must be a list of container numbers")
+        }
+        list = strings.Split(sList, ",")
+    }
+    *c = make(ContainerList, 0, len(list))
+    for _, cont := range list {
+        cont = strings.TrimSpace(cont)
+        if cont != "" {
+            *c = append(*c, cont)
+        }
+    }
+    return nil
+}
+
+// Contains returns the position of the container in the list, or -1
+func (c ContainerList) Contains(containerNo string) int {
+    for i, cont := range c {
+        if cont == containerNo {
+            return i
+        }
+    }
+    return -1
+}
+
 // This is a logistics contract, written in the context of shipping. It tracks the progress of a Bill of Lading 
 // and associated containers, and raises alerts in case of violations in expected conditions
 
@@ -52,13 +117,13 @@ type NotifyRange struct {
 // the same transit rules in terms of allowed ranges in temperature, humidity etc. apply across the B/L - i.e. 
 // applies to all containers attached to a Bill of Lading
 
-// Assumption 3. A shipment may switch from one container to another in transit, for various reasons. We are assuming,
-// for simplicity, that the same containers are used for end to end transit.
+// Assumption 3. A shipment may switch from one container to another in transit, for various reasons. The swap
+// is registered against the B/L, the old container's compliance remains part of the B/L compliance.
 
 // Initial registration of the Bill of Lading. Sets out the constrains for B/L data and the Notification details
 type BillOfLadingRegistration struct {
     BLNo                 string                  `json:"blno"` 
-    ContainerNos         string                  `json:"containernos"`    // Comma separated container numbers - keep json simple    
+    ContainerNos         ContainerList           `json:"containernos"`    // Containers currently carrying the shipment
     Hazmat               bool                    `json:"hazmat,omitempty"`     // shipment hazardous ?
     MinTemperature       float64                 `json:"mintemperature,omitempty"` //split range to min and max: Jeff's input
     MaxTemperature       float64                 `json:"maxtemperature,omitempty"` 
@@ -68,8 +133,12 @@ type BillOfLadingRegistration struct {
     MaxLight             float64                 `json:"maxlight,omitempty"` 
     MinAcceleration      float64                 `json:"minacceleration,omitempty"` //split range to min and max: Jeff's input
     MaxAcceleration      float64                 `json:"maxacceleration,omitempty"`
- //NotifyLocations      *[]Geolocation            `json:"notifylocations,omitempty"` // No implementation right now
- //NotifyRange          *NotifyRange              `json:"notifyrange,omitempty"`     // To be integrated when shipping part gets sorted out
+    NotifyLocations      []Geolocation           `json:"notifylocations,omitempty"` // Notify party locations, arrival at any one completes transit
+    NotifyRange          *NotifyRange            `json:"notifyrange,omitempty"`     // Defaults to DEFAULTLATRANGE, DEFAULTLONGRANGE
+    Tolerance            *ExcursionTolerance     `json:"tolerance,omitempty"`       // If not sent in, any reading out of range is a violation
+    MaxMKT               *float64                `json:"maxmkt,omitempty"`          // Mean kinetic temperature limit, celcius
+    ActivationEnergy     float64                 `json:"activationenergy,omitempty"` // kJ/mol, defaults to DEFAULTACTIVATIONENERGY
+    SwappedContainerNos  ContainerList           `json:"swappedcontainernos,omitempty"` // Containers swapped out mid-transit, still part of B/L compliance
     TransitComplete      bool                    `json:"transitcomplete,omitempty"`
     Timestamp            string                  `json:"timestamp,omitempty"`
 }
@@ -89,6 +158,66 @@ type ContainerLogistics struct {
     Extra               json.RawMessage                `json:"extra,omitempty"`  
     AlertRecord         string                         `json:"alerts,omitempty"`  
     TransitComplete     bool                           `json:"transitcomplete,omitempty"`
+    Compliance          bool                           `json:"compliance"`              // false once any alert has been raised for the container
+    ArrivedAt           *Geolocation                   `json:"arrivedat,omitempty"`     // notify location the container arrived at
+    SwappedTo           string                         `json:"swappedto,omitempty"`     // container the shipment was moved to mid-transit
+    Excursions          map[string]Excursion           `json:"excursions,omitempty"`    // keyed by metric, e.g. "temperature"
+    KineticTemperature  *KineticTemperature            `json:"kinetictemperature,omitempty"`
+    LastReading         string                         `json:"lastreading,omitempty"`   // time of the last sensor reading, RFC3339
+}
+
+// Cumulative minutes a metric may be out of range before the container is non compliant.
+// A metric without a tolerance is a violation on the first reading out of range
+type ExcursionTolerance struct {
+    Temperature         float64                        `json:"temperature,omitempty"`
+    Humidity            float64                        `json:"humidity,omitempty"`
+    Light               float64                        `json:"light,omitempty"`
+    Acceleration        float64                        `json:"acceleration,omitempty"`
+}
+
+// How long and how far a metric has been out of range during the transit. A reading is
+// taken to hold until the next reading comes in
+type Excursion struct {
+    Variation           Variation                      `json:"variation,omitempty"`     // the last reading out of range was above or below
+    Minutes             float64                        `json:"minutes"`                 // cumulative minutes out of range
+    MaxDeviation        float64                        `json:"maxdeviation"`            // furthest a reading was outside min / max
+    Readings            int                            `json:"readings"`                // readings out of range
+    Violation           bool                           `json:"violation,omitempty"`     // tolerance exceeded
+}
+
+// Running, time weighted mean kinetic temperature of a container
+type KineticTemperature struct {
+    Minutes             float64                        `json:"minutes"`                 // time covered by readings
+    Sum                 float64                        `json:"sum"`                     // time weighted sum of exp(-dH/RT)
+    MKT                 float64                        `json:"mkt"`                     // celcius
+}
+
+// Compliance and transit of one container, as rolled up on the Bill of Lading
+type ContainerStatus struct {
+    ContainerNo         string                         `json:"containerno"`
+    Compliance          bool                           `json:"compliance"`
+    AlertRecord         string                         `json:"alerts,omitempty"`
+    TransitComplete     bool                           `json:"transitcomplete"`
+    ArrivedAt           *Geolocation                   `json:"arrivedat,omitempty"`
+    SwappedTo           string                         `json:"swappedto,omitempty"`
+}
+
+// Bill of Lading roll up of its containers, including containers swapped out mid-transit.
+// The B/L is compliant if every container is, and transit is complete when every current
+// container has arrived
+type BillOfLadingStatus struct {
+    BLNo                string                         `json:"blno"`
+    Compliance          bool                           `json:"compliance"`
+    TransitComplete     bool                           `json:"transitcomplete"`
+    Containers          []ContainerStatus              `json:"containers"`
+}
+
+// Request to move a shipment from one container to another mid-transit
+type ContainerSwap struct {
+    BLNo                string                         `json:"blno"`
+    ContainerNo         string                         `json:"containerno"`
+    NewContainerNo      string                         `json:"newcontainerno"`
+    Timestamp           string                         `json:"timestamp,omitempty"`
 }
 
 // Compliance record structure
@@ -120,6 +249,9 @@ type Alerts struct {
      LightAlert     Variation `json:"lightalert,omitempty"` 
      AccAlert       Variation `json:"accalert,omitempty"`
      DoorAlert      bool      `json:"dooralert,omitempty"`
+     MKTAlert       bool      `json:"mktalert,omitempty"`
+     MKT            float64   `json:"mkt,omitempty"`
+     Excursions     map[string]Excursion `json:"excursions,omitempty"` // every metric that has been out of range
 }
 
 
@@ -133,7 +265,7 @@ type Alerts struct {
 // the same transit rules in terms of allowed ranges in temperature, humidity etc. apply across the B/L - i.e. 
 // applies to all containers attached to a Bill of Lading
 
-// Assumption 3. A shipment may switch from one container to another in transit, for various reasons. We are assuming,
-// for simplicity, that the same containers are used for end to end transit.
+// Assumption 3. A shipment may switch from one container to another in transit, for various reasons. The swap
+// is registered against the B/L, the old container's compliance remains part of the B/L compliance.
 
 
@@ -27,8 +27,8 @@ import (
 // the same transit rules in terms of allowed ranges in temperature, humidity etc. apply across the B/L - i.e. 
 // applies to all containers attached to a Bill of Lading
 
-// Assumption 3. A shipment may switch from one container to another in transit, for various reasons. We are assuming,
-// for simplicity, that the same containers are used for end to end transit.
+// Assumption 3. A shipment may switch from one container to another in transit, for various reasons. The swap
+// is registered against the B/L, the old container's compliance remains part of the B/L compliance.
 
 
 // SimpleChaincode example simple Chaincode implementation
@@ -63,6 +63,8 @@ func (t *SimpleChaincode) Invoke(stub shim.ChaincodeStubInterface, function stri
 		return t.createContainerLogistics(stub, args)
     } else if function =="updateContainerLogistics" {
         return t.updateContainerLogistics(stub, args)
+    } else if function =="swapContainerLogistics" {
+        return t.swapContainerLogistics(stub, args)
     } 
 	//fmt.Println("Unknown invocation function: ", function)
 	return nil, errors.New("Received unknown invocation: " + function)
@@ -79,6 +81,8 @@ func (t *SimpleChaincode) Query(stub shim.ChaincodeStubInterface, function strin
         return t.readContainerCurrentStatus(stub, args)
     } else if function =="readContainerHistory" {
             return t.readContainerHistory(stub, args)
+    } else if function =="readBillOfLadingStatus" {
+            return t.readBillOfLadingStatus(stub, args)
     } else if function == "readAssetSchemas" {
 		// returns selected sample objects 
 		return t.readAssetSchemas(stub, args)
@@ -133,7 +137,6 @@ func (t *SimpleChaincode) createContainerLogistics(stub shim.ChaincodeStubInterf
     var contInit common.BillOfLadingRegistration
     //var blDefn common.BillOfLadingRegistration
 	var err error
-    var contState, oldContState common.ContainerLogistics
     //var contHistory ContainerHistory
     
     if len(args) != 1 {
@@ -147,101 +150,85 @@ func (t *SimpleChaincode) createContainerLogistics(stub shim.ChaincodeStubInterf
     //blDefn = contInit
    // fmt.Println("Max temp: ", contInit.MaxTemperature)
    //  fmt.Println("Min temp: ", contInit.MinTemperature)
-   // fmt.Println("Splitting the container list")
     bKey:=contInit.BLNo
-    sContainers:=strings.Split(contInit.ContainerNos, ",")
     sTimeStamp:=contInit.Timestamp
-    iNos:=len(sContainers)
-    mTemp := make(map[string]string, iNos)
-    for i := 0; i < len(sContainers); i++ {
-       // fmt.Println("Inside container list iteration")
-        bCreateContRecord := true // create a new container record
-        //This creates a map of [container number] [alerts] with compliance as a true 
-        sContKey:=sContainers[i]
-	    mTemp[sContKey]= "" // initializing alerts as a blank string
-        // Create an initial ContainerLogisitcs record for each container, in the stub
-        // Use the container number for the records. If there is an exisitng record,
-        //  update it by appending the B/L. 
-        sOldContKey:=sContainers[i] // This will be appended with B/L number for old records
-       // fmt.Println("Check if old container record exists with the key ", sContKey)
-        contData, err := stub.GetState(sContKey)
-        if err ==nil && len(contData) >0 {
-            //fmt.Println(" This container exists in state, probably used with another B/L")
-            // If the container number and B/L number match - unlikely, leave untouched
-            err = json.Unmarshal(contData, &oldContState)
-            if err != nil {
-                //err = errors.New("Unable to unmarshal input JSON data")
-                //fmt.Println(err)
-                return nil, err
-            }
-            if oldContState.BLNo != bKey {
-                // The container - bill of lading combination does not exist
-                // this is the expected case
-                sOldContKey = sOldContKey + "_" + oldContState.BLNo 
-                // We are going to append the old container record's bill of lading number to the contaienr number
-                // This will be the new key for the old record
-                 err = stub.PutState(sOldContKey, contData)
-                if err != nil {
-                    err:=errors.New("re-assigning old container state failed")
-                    //fmt.Println(err)
-                    return nil, err
-                }
-               // here bCreateContRecord is true 
-            } else {
-                // If the bill of lading number is same - shouldnt be - do nothing 
-                bCreateContRecord = false   
-                // Probably throw an error instead...             
-            }
+    bBLCaptured := false
+    for _, sContKey := range contInit.ContainerNos {
+        bCreated, err := t.createContainerRecord(stub, sContKey, bKey, sTimeStamp)
+        if err != nil {
+            return nil, err
         }
-        // If there is no data in the stub for the container, or if there was and we reassigned it,
-        // we can now create the container's initial record
-        // This is needed, because the container record does not come in with a Bill of Lading.
-        // Therefore, we map it here 
-        if bCreateContRecord {
-           // fmt.Println("Inside 'bCreateContRecord'")
-            contState.ContainerNo=sContKey
-            contState.BLNo=bKey
-            contState.Timestamp = sTimeStamp
-            contState.TransitComplete = false
-           // fmt.Println("Before constatate marshal")
-            contJSON, err := json.Marshal(contState)
-           // fmt.Println("After constatate marshal")
-            if err != nil {
-                err:=errors.New("Marshaling initial container data failed")
-                //fmt.Println(err)
-                return nil, err
-            }
-           // fmt.Println("Old container record with different B/L", string(contJSON))
-            contHistKey:=contState.ContainerNo+"_HISTORY"
-            var contHist = ContainerHistory{make([]string, 1)}
-            contHist.ContHistory[0] = string(contJSON)
-            contHState, err := json.Marshal(&contHist)
-            if err != nil {
-                return nil,err
-            }
-            contHistoryState := []byte(contHState)
-            
-            err=stub.PutState(sContKey, contJSON)
-            if err !=nil {
-               // fmt.Println("Unable to create initial container state, ", contJSON)
-                return nil, err
-            }
-            err = stub.PutState(contHistKey, contHistoryState)
-            if err != nil {
-                return nil, errors.New("container history failed PUT to ledger: " + fmt.Sprint(err))
-            } 
-           // fmt.Println("New container record generated", string(contJSON))
+        if bCreated && !bBLCaptured {
             // Put Bill Of Lading reg data in the stub to minimize cross-chaincode calls
             err = stub.PutState(bKey, []byte(args[0]))
             if err != nil {
                 return nil, errors.New("Bill of Lading data failed PUT to ledger: " + fmt.Sprint(err))
             } 
            // fmt.Println("Bill of Lading rules captured", args[0])
-            
-        }        
+            bBLCaptured = true
+        }
     }
     return nil, nil 
 } 
+
+/************************ internal: createContainerRecord ********************/
+// Create an initial ContainerLogisitcs record for the container, in the stub
+// Use the container number for the records. If there is an exisitng record for
+// another B/L, it is moved to the key container number + "_" + old B/L number.
+// Returns false if the container already has a record for this B/L
+func (t *SimpleChaincode) createContainerRecord(stub shim.ChaincodeStubInterface, sContKey string, bKey string, sTimeStamp string) (bool, error) {
+    var contState, oldContState common.ContainerLogistics
+    contData, err := stub.GetState(sContKey)
+    if err ==nil && len(contData) >0 {
+        //fmt.Println(" This container exists in state, probably used with another B/L")
+        // If the container number and B/L number match - unlikely, leave untouched
+        err = json.Unmarshal(contData, &oldContState)
+        if err != nil {
+            return false, err
+        }
+        if oldContState.BLNo == bKey {
+            // If the bill of lading number is same - shouldnt be - do nothing 
+            return false, nil
+        }
+        // The container - bill of lading combination does not exist
+        // this is the expected case
+        // We are going to append the old container record's bill of lading number to the contaienr number
+        // This will be the new key for the old record
+        err = stub.PutState(sContKey + "_" + oldContState.BLNo, contData)
+        if err != nil {
+            return false, errors.New("re-assigning old container state failed")
+        }
+    }
+    // If there is no data in the stub for the container, or if there was and we reassigned it,
+    // we can now create the container's initial record
+    // This is needed, because the container record does not come in with a Bill of Lading.
+    // Therefore, we map it here 
+    contState.ContainerNo=sContKey
+    contState.BLNo=bKey
+    contState.Timestamp = sTimeStamp
+    contState.TransitComplete = false
+    contState.Compliance = true
+    contJSON, err := json.Marshal(contState)
+    if err != nil {
+        return false, errors.New("Marshaling initial container data failed")
+    }
+    contHistKey:=contState.ContainerNo+"_HISTORY"
+    var contHist = ContainerHistory{make([]string, 1)}
+    contHist.ContHistory[0] = string(contJSON)
+    contHState, err := json.Marshal(&contHist)
+    if err != nil {
+        return false, err
+    }
+    err=stub.PutState(sContKey, contJSON)
+    if err !=nil {
+        return false, err
+    }
+    err = stub.PutState(contHistKey, []byte(contHState))
+    if err != nil {
+        return false, errors.New("container history failed PUT to ledger: " + fmt.Sprint(err))
+    } 
+    return true, nil
+}
  
  /************************ updateContainerLogistics ********************/
 
@@ -250,7 +237,6 @@ func (t *SimpleChaincode) updateContainerLogistics(stub shim.ChaincodeStubInterf
     var contState, contIn   common.ContainerLogistics
     var compState common.ComplianceState
     var containerHistory ContainerHistory
-    var contractState common.ContContractState
     //var oldAlert, newAlert  Alerts
     
     
@@ -329,7 +315,21 @@ func (t *SimpleChaincode) updateContainerLogistics(stub shim.ChaincodeStubInterf
     
    // fmt.Println(" Timestamp is ", sTime)
     
+    // A container that was swapped out mid-transit no longer carries the shipment
+    if contState.SwappedTo != "" {
+        err = errors.New("Container " + sContKey + " was swapped to " + contState.SwappedTo + " and no longer carries Bill of Lading " + contState.BLNo)
+        return nil, err
+    }
+    // Compliance and arrival are kept across updates, a partial update does not reset them
+    prevState := contState
     contState = contIn
+    contState.Compliance = prevState.Compliance
+    contState.TransitComplete = prevState.TransitComplete
+    contState.ArrivedAt = prevState.ArrivedAt
+    contState.AlertRecord = prevState.AlertRecord    // last alert raised for the container
+    contState.Excursions = prevState.Excursions
+    contState.KineticTemperature = prevState.KineticTemperature
+    contState.LastReading = prevState.LastReading
 
   
     
@@ -342,59 +342,56 @@ func (t *SimpleChaincode) updateContainerLogistics(stub shim.ChaincodeStubInterf
   //  fmt.Println("B/L number in container in is ", contIn.BLNo)
 
     
+    blDefn, err := t.getBLDefinition(stub, blKey)
+    if err != nil {
+        return nil, err
+    }
   //  fmt.Println("Perform a compliance check on the new record")
-    newAlerts, err:= t.alertsCheck(stub, contIn)
+    newAlerts, err:= t.alertsCheck(blDefn, prevState, &contState, txntimestamp)
+    if err != nil {
+        return nil, err
+    }
     sAlerts := string(newAlerts)
-  //  fmt.Println("Alerts data is : ", string(newAlerts))
     if len(sAlerts)>0 {
-        // This implies a compliance violation. 
-     //   fmt.Println("Update container record with new alert status pertaining to that record")
-        
+        // This implies a compliance violation. The container stays non compliant for the rest of the transit
         contState.AlertRecord = sAlerts
-        
+        contState.Compliance = false
+    }
+
+    // Check if lat-long is in notification range. The container has arrived when it is
+    if !contState.TransitComplete {
+        if loc, arrived := notifyRange(blDefn).Within(contState.Location, blDefn.NotifyLocations); arrived {
+            contState.TransitComplete = true
+            contState.ArrivedAt = &loc
+        }
+    }
+    // Compliance is evaluated per container and rolled up to the B/L
+    blStatus, err := t.rollUpBillOfLading(stub, blDefn, &contState)
+    if err != nil {
+        return nil, err
+    }
+
+  //  fmt.Println("Alerts data is : ", string(newAlerts))
+    if len(sAlerts)>0 {
         // call the compliance contract to maintain the state
         //*************************************************************************
     // Invoke the compliance contract to create and maintain the B/L compliance
-        //get compliance contract uuid from the stub
-        contractStateJSON, err := stub.GetState(common.CONTSTATEKEY)
-        if err != nil {
-            return nil,errors.New("Unable to fetch container and compliance contract keys")
-        }
-        err = json.Unmarshal(contractStateJSON, &contractState)
-        if err != nil {
-            return nil, err
-        }
-        complianceChainCode:=contractState.ComplianceCC
-        f := "createUpdateComplianceRecord"
         compState.BLNo = blKey
         compState.Type = "SHIPPING"
-        sTimeStamp:=contState.Timestamp 
-        sCompAlerts:= sAlerts
         mAlerts:= make(map[string]string)
-        mAlerts[contState.ContainerNo]=sCompAlerts
-        compState.AssetAlerts=mAlerts // every alert even triggers a ui action. cumulation not needed. history maintained
-        compState.Timestamp = sTimeStamp
-        compState.Compliance =false
+        for _, cs := range blStatus.Containers {
+            if cs.AlertRecord != "" {
+                mAlerts[cs.ContainerNo]=cs.AlertRecord
+            }
+        }
+        compState.AssetAlerts=mAlerts // alerts of every container on the B/L, including swapped containers. history maintained
+        compState.Timestamp = contState.Timestamp
+        compState.Compliance = blStatus.Compliance
         compState.Active=true
-        compJSON, err := json.Marshal(compState)
+        err = t.invokeCompliance(stub, "createUpdateComplianceRecord", compState)
         if err != nil {
-            err:=errors.New("Marshaling compliance initialization failed")
-            //fmt.Println(err)
             return nil, err
         }
-        
-        //////////////////////
-        invokeArgs := string(compJSON)
-        var callArgs = make([]string, 0)
-        callArgs = append(callArgs, f)
-        callArgs = append(callArgs, invokeArgs)
-      
-        _, err = stub.InvokeChaincode(complianceChainCode, util.ToChaincodeArgs(callArgs...))
-        if err != nil {
-            errStr := fmt.Sprintf("Failed to invoke chaincode. Got error: %s", err.Error())
-           // fmt.Printf(errStr)
-            return nil, errors.New(errStr)
-        }
      //*************************************************************************
        
     }
@@ -449,14 +446,203 @@ func (t *SimpleChaincode) updateContainerLogistics(stub shim.ChaincodeStubInterf
         return nil, errors.New("Container history updatefailed PUT to ledger: " + fmt.Sprint(err))
     } 
     
-    // Check if lat-long is in notification range
-    // Not implementing notification till clarity from IRL Side
-    // later, implement it to call the shipping contract
+    // Transit of the B/L completes when every current container has arrived
+    if blStatus.TransitComplete && !blDefn.TransitComplete {
+        blDefn.TransitComplete = true
+        blJSON, err := json.Marshal(blDefn)
+        if err != nil {
+            return nil, errors.New("Marshaling bill of lading data failed")
+        }
+        err = stub.PutState(blKey, blJSON)
+        if err != nil {
+            return nil, errors.New("Bill of Lading data failed PUT to ledger: " + fmt.Sprint(err))
+        }
+        compState.BLNo = blKey
+        compState.Timestamp = contState.Timestamp
+        err = t.invokeCompliance(stub, "archiveComplianceRecord", compState)
+        if err != nil {
+            return nil, err
+        }
+    }
    // fmt.Printf("Container %s state successfully written to ledger : %s\n", sContKey, string(updContJSON))
     return nil, nil
  
 }
 
+ /************************ swapContainerLogistics ********************/
+// The shipment moves from one container to another mid-transit. The old container is
+// marked as swapped and stays part of the B/L compliance, the new container gets an
+// initial record for the B/L
+func (t *SimpleChaincode) swapContainerLogistics(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+    var swap common.ContainerSwap
+
+    if len(args) != 1 {
+        return nil, errors.New("Expects one argument, a JSON string with bill of lading, container and new container numbers")
+    }
+    err := json.Unmarshal([]byte(args[0]), &swap)
+    if err != nil {
+        return nil, errors.New("Unable to unmarshal container swap " + fmt.Sprint(err))
+    }
+    bKey := strings.TrimSpace(swap.BLNo)
+    sContKey := strings.TrimSpace(swap.ContainerNo)
+    sNewContKey := strings.TrimSpace(swap.NewContainerNo)
+    if bKey == "" || sContKey == "" || sNewContKey == "" {
+        return nil, errors.New("Bill of Lading, container and new container numbers are mandatory")
+    }
+    blDefn, err := t.getBLDefinition(stub, bKey)
+    if err != nil {
+        return nil, err
+    }
+    if blDefn.TransitComplete {
+        return nil, errors.New("Transit of Bill of Lading " + bKey + " is complete, containers cannot be swapped")
+    }
+    pos := blDefn.ContainerNos.Contains(sContKey)
+    if pos < 0 {
+        return nil, errors.New("Container " + sContKey + " does not carry Bill of Lading " + bKey)
+    }
+    if blDefn.ContainerNos.Contains(sNewContKey) >= 0 || blDefn.SwappedContainerNos.Contains(sNewContKey) >= 0 {
+        return nil, errors.New("Container " + sNewContKey + " is already part of Bill of Lading " + bKey)
+    }
+    oldContState, sOldKey, err := t.getContainerForBL(stub, sContKey, bKey)
+    if err != nil {
+        return nil, err
+    }
+    oldContState.SwappedTo = sNewContKey
+    oldContJSON, err := json.Marshal(oldContState)
+    if err != nil {
+        return nil, errors.New("Marshaling container data failed")
+    }
+    err = stub.PutState(sOldKey, oldContJSON)
+    if err != nil {
+        return nil, errors.New("Writing swapped container state data to the ledger failed")
+    }
+    _, err = t.createContainerRecord(stub, sNewContKey, bKey, swap.Timestamp)
+    if err != nil {
+        return nil, err
+    }
+    // Keep the B/L copy in step, so that the roll up sees the new container
+    blDefn.ContainerNos[pos] = sNewContKey
+    blDefn.SwappedContainerNos = append(blDefn.SwappedContainerNos, sContKey)
+    blJSON, err := json.Marshal(blDefn)
+    if err != nil {
+        return nil, errors.New("Marshaling bill of lading data failed")
+    }
+    err = stub.PutState(bKey, blJSON)
+    if err != nil {
+        return nil, errors.New("Bill of Lading data failed PUT to ledger: " + fmt.Sprint(err))
+    }
+    return nil, nil
+}
+
+/************************ internal: getBLDefinition ********************/
+// Returns the copy of the Bill of Lading registration kept by this contract
+func (t *SimpleChaincode) getBLDefinition(stub shim.ChaincodeStubInterface, bKey string) (common.BillOfLadingRegistration, error) {
+    var blDefn common.BillOfLadingRegistration
+    blData, err := stub.GetState(bKey)
+    if err != nil || len(blData) == 0 {
+        return blDefn, errors.New("Unable to retrieve Bill of Lading data from the stub")
+    }
+    err = json.Unmarshal(blData, &blDefn)
+    if err != nil {
+        return blDefn, errors.New("Bill of Lading record unmarshal failed: " + fmt.Sprint(err))
+    }
+    return blDefn, nil
+}
+
+/************************ internal: notifyRange ********************/
+// Returns the notify range of the Bill of Lading, or the default range if none was registered
+func notifyRange(blDefn common.BillOfLadingRegistration) common.NotifyRange {
+    if blDefn.NotifyRange == nil {
+        return common.NotifyRange{LatRange: common.DEFAULTLATRANGE, LongRange: common.DEFAULTLONGRANGE}
+    }
+    return *blDefn.NotifyRange
+}
+
+/************************ internal: getContainerForBL ********************/
+// Returns the container record for the B/L, and the key it is stored under. Once the
+// container has been re-used for another B/L, the record is under container number + "_" + B/L number
+func (t *SimpleChaincode) getContainerForBL(stub shim.ChaincodeStubInterface, sContKey string, bKey string) (common.ContainerLogistics, string, error) {
+    var contState common.ContainerLogistics
+    for _, key := range []string{sContKey, sContKey + "_" + bKey} {
+        contData, err := stub.GetState(key)
+        if err != nil || len(contData) == 0 {
+            continue
+        }
+        err = json.Unmarshal(contData, &contState)
+        if err != nil {
+            return contState, "", errors.New("Unable to unmarshal JSON data from stub")
+        }
+        if contState.BLNo == bKey {
+            return contState, key, nil
+        }
+    }
+    return contState, "", errors.New("Container " + sContKey + " has no record for Bill of Lading " + bKey)
+}
+
+/************************ internal: rollUpBillOfLading ********************/
+// Rolls up compliance and transit of every container on the B/L, including containers
+// swapped out mid-transit. contState, if not nil, is used in place of the ledger record
+// of that container, as it has not been written yet
+func (t *SimpleChaincode) rollUpBillOfLading(stub shim.ChaincodeStubInterface, blDefn common.BillOfLadingRegistration, contState *common.ContainerLogistics) (common.BillOfLadingStatus, error) {
+    var blStatus common.BillOfLadingStatus
+    blStatus.BLNo = blDefn.BLNo
+    blStatus.Compliance = true
+    blStatus.TransitComplete = len(blDefn.ContainerNos) > 0
+    blStatus.Containers = make([]common.ContainerStatus, 0)
+
+    conts := append(append(common.ContainerList{}, blDefn.ContainerNos...), blDefn.SwappedContainerNos...)
+    for i, sContKey := range conts {
+        var cont common.ContainerLogistics
+        var err error
+        if contState != nil && contState.ContainerNo == sContKey {
+            cont = *contState
+        } else {
+            cont, _, err = t.getContainerForBL(stub, sContKey, blDefn.BLNo)
+            if err != nil {
+                return blStatus, err
+            }
+        }
+        blStatus.Containers = append(blStatus.Containers, common.ContainerStatus{ContainerNo: cont.ContainerNo,
+            Compliance: cont.Compliance, AlertRecord: cont.AlertRecord, TransitComplete: cont.TransitComplete,
+            ArrivedAt: cont.ArrivedAt, SwappedTo: cont.SwappedTo})
+        blStatus.Compliance = blStatus.Compliance && cont.Compliance
+        // Only containers currently carrying the shipment have to arrive
+        if i < len(blDefn.ContainerNos) {
+            blStatus.TransitComplete = blStatus.TransitComplete && cont.TransitComplete
+        }
+    }
+    return blStatus, nil
+}
+
+/************************ internal: invokeCompliance ********************/
+// Invokes the compliance contract registered at Init with the compliance record
+func (t *SimpleChaincode) invokeCompliance(stub shim.ChaincodeStubInterface, f string, compState common.ComplianceState) error {
+    var contractState common.ContContractState
+    //get compliance contract uuid from the stub
+    contractStateJSON, err := stub.GetState(common.CONTSTATEKEY)
+    if err != nil {
+        return errors.New("Unable to fetch container and compliance contract keys")
+    }
+    err = json.Unmarshal(contractStateJSON, &contractState)
+    if err != nil {
+        return err
+    }
+    compJSON, err := json.Marshal(compState)
+    if err != nil {
+        return errors.New("Marshaling compliance record failed")
+    }
+    var callArgs = make([]string, 0)
+    callArgs = append(callArgs, f)
+    callArgs = append(callArgs, string(compJSON))
+
+    _, err = stub.InvokeChaincode(contractState.ComplianceCC, util.ToChaincodeArgs(callArgs...))
+    if err != nil {
+        errStr := fmt.Sprintf("Failed to invoke chaincode. Got error: %s", err.Error())
+        return errors.New(errStr)
+    }
+    return nil
+}
+
 
 // ************************************
 // query functions 
@@ -517,6 +703,33 @@ func (t *SimpleChaincode) readContainerCurrentStatus(stub shim.ChaincodeStubInte
     }
     return contData, nil
 }
+// ************************************
+// readBillOfLadingStatus
+// ************************************
+// This returns the compliance and transit roll up of the containers on a Bill of Lading
+func (t *SimpleChaincode) readBillOfLadingStatus(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+    var blIn common.BillOfLadingStatus
+    if len(args) !=1 {
+        return nil, errors.New("Incorrect number of arguments. Expecting a single JSON string with mandatory Bill of Lading Number")
+    }
+    err := json.Unmarshal([]byte(args[0]), &blIn)
+    if err != nil {
+        return nil, err
+    }
+    blIn.BLNo = strings.TrimSpace(blIn.BLNo)
+    if blIn.BLNo=="" {
+        return nil, errors.New("Bill of Lading number cannot be blank")
+    }
+    blDefn, err := t.getBLDefinition(stub, blIn.BLNo)
+    if err != nil {
+        return nil, err
+    }
+    blStatus, err := t.rollUpBillOfLading(stub, blDefn, nil)
+    if err != nil {
+        return nil, err
+    }
+    return json.Marshal(blStatus)
+}
 func (t *SimpleChaincode) readContainerLogisitcsSchemas(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
 	return []byte(schemas), nil
 }
@@ -565,81 +778,78 @@ func (t *SimpleChaincode) readAssetSchemas(stub shim.ChaincodeStubInterface, arg
 // ************************************
 // alertsCheck
 // ************************************
-// This is an 'internal' function, to check for alert
-func (t *SimpleChaincode) alertsCheck(stub shim.ChaincodeStubInterface, contIn common.ContainerLogistics) ([]byte,  error) {
-    // I will rework thisd - possibly with reflection
-    var blDefn common.BillOfLadingRegistration
-    var blReg common.BillOfLadingRegistration 
-    //alerts will not get raised: blReg doesn't exist today in state
-    // Expensive?
-
+// This is an 'internal' function, to check for alert. Excursions and the mean kinetic
+// temperature of the container are brought up to date with the reading in contState.
+// A metric raises an alert once it has been out of range longer than the B/L tolerance
+func (t *SimpleChaincode) alertsCheck(blReg common.BillOfLadingRegistration, prevState common.ContainerLogistics, contState *common.ContainerLogistics, txntimestamp time.Time) ([]byte,  error) {
     var alert =new(common.Alerts)
     var contAlert []byte
-    var val common.Variation
-    
-    bKey:= contIn.BLNo
-   // fmt.Println("B/L number  inside alertscheck is ",bKey)
+    var tolerance common.ExcursionTolerance
     complianceAlert := false
-    //  use value in global variable to check alerts compliance.
-    blData, err := stub.GetState(bKey)
-    if err!=nil {
-        err:=errors.New("Unable to retrieve Bill of Lading data from the stub")
-       // fmt.Println(err)
-        return nil, err
-    }
-     err = json.Unmarshal(blData, &blDefn)
-    if err != nil {
-        return nil, errors.New("Bill of Lading record unmarshal failed: " + fmt.Sprint(err))
-    }
-    blReg = blDefn
-    //Temperature check
-    var actVal = contIn.Temperature
 
-    val, _ = t.inRange(blReg.MinTemperature, blReg.MaxTemperature, actVal)
-    if (val !=common.Normal) {
-        alert.TempAlert = val
-        complianceAlert = true
-    }
-     cAlert, err := json.Marshal(&alert)
-       // fmt.Println(string(cAlert))
-    //Humidity Check
-    actVal = contIn.Humidity
-
-    val,_ = t.inRange(blReg.MinHumidity, blReg.MaxHumidity, actVal)
-    if (val !=common.Normal) {
-        alert.HumAlert = val
-        complianceAlert = true
+    // The previous reading is taken to hold until this one. The first reading has no previous one
+    readTime := readingTime(contState.Timestamp, txntimestamp)
+    minutes := 0.0
+    lastTime, err := time.Parse(time.RFC3339Nano, prevState.LastReading)
+    if err == nil && readTime.After(lastTime) {
+        minutes = readTime.Sub(lastTime).Minutes()
+    }
+    contState.LastReading = readTime.Format(time.RFC3339Nano)
+
+    if blReg.Tolerance != nil {
+        tolerance = *blReg.Tolerance
+    }
+    excursions := make(map[string]common.Excursion)
+    for metric, exc := range prevState.Excursions {
+        excursions[metric] = exc
+    }
+    metrics := []struct {
+        name        string
+        minVal      float64
+        maxVal      float64
+        prevVal     float64
+        actVal      float64
+        tolerance   float64
+        alert       *common.Variation
+    }{
+        {"temperature", blReg.MinTemperature, blReg.MaxTemperature, prevState.Temperature, contState.Temperature, tolerance.Temperature, &alert.TempAlert},
+        {"humidity", blReg.MinHumidity, blReg.MaxHumidity, prevState.Humidity, contState.Humidity, tolerance.Humidity, &alert.HumAlert},
+        {"light", blReg.MinLight, blReg.MaxLight, prevState.Light, contState.Light, tolerance.Light, &alert.LightAlert},
+        {"acceleration", blReg.MinAcceleration, blReg.MaxAcceleration, prevState.Acceleration, contState.Acceleration, tolerance.Acceleration, &alert.AccAlert},
+    }
+    for _, m := range metrics {
+        exc, val := t.excursionCheck(excursions[m.name], m.minVal, m.maxVal, m.prevVal, m.actVal, minutes, m.tolerance)
+        if exc.Readings > 0 {
+            excursions[m.name] = exc
+        }
+        if val != common.Normal {
+            *m.alert = val
+            complianceAlert = true
+        }
     }
+    contState.Excursions = excursions
 
-     cAlert, err = json.Marshal(&alert)
-       // fmt.Println(string(cAlert))
-    //Light Check
-    actVal = contIn.Light
-
-    val,_ = t.inRange(blReg.MinLight, blReg.MaxLight, actVal)
-    if (val !=common.Normal) {
-        alert.LightAlert = val
-        complianceAlert = true
+    //Mean kinetic temperature check
+    contState.KineticTemperature = t.kineticTemperature(prevState.KineticTemperature, prevState.Temperature, minutes, blReg.ActivationEnergy)
+    if contState.KineticTemperature != nil {
+        alert.MKT = contState.KineticTemperature.MKT
+        if blReg.MaxMKT != nil && alert.MKT > *blReg.MaxMKT {
+            alert.MKTAlert = true
+            complianceAlert = true
+        }
     }
-    
-    // Acceleration check
-    actVal = contIn.Acceleration
-    
-    val,_ = t.inRange(blReg.MinAcceleration, blReg.MaxAcceleration, actVal)
-    if (val !=common.Normal) {
-        alert.AccAlert = val
-        complianceAlert = true
-     }
-    
 
-    if contIn.DoorClosed == false {
+    if contState.DoorClosed == false {
         alert.DoorAlert =true
         complianceAlert = true
     }
         
 
     if complianceAlert {
-        cAlert, err = json.Marshal(&alert)
+        if len(excursions) > 0 {
+            alert.Excursions = excursions
+        }
+        cAlert, err := json.Marshal(&alert)
        // fmt.Println(string(cAlert))
         if err !=nil {
             err = errors.New("Unable to marshal alert data")
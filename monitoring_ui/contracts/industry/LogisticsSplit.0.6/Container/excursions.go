@@ -0,0 +1,80 @@
+package main
+
+import (
+    "math"
+    "strings"
+    "time"
+
+    common "github.com/hyperledger/fabric/examples/chaincode/go/PoD/Common" 
+)
+
+// Universal gas constant, J/(mol K)
+const GASCONSTANT float64 = 8.3144598
+const KELVIN float64 = 273.15
+
+// Layouts accepted for the timestamp of a container reading. The last one is what
+// the contract itself writes, from the transaction time
+var readingLayouts = []string{time.RFC3339Nano, "2006-01-02 15:04:05.999999999 -0700 MST"}
+
+/*********************************  internal: readingTime ****************************/
+// Returns the time of a container reading, or the transaction time if the reading
+// timestamp is not in a known layout
+func readingTime(sTimestamp string, txntimestamp time.Time) time.Time {
+    for _, layout := range readingLayouts {
+        readTime, err := time.Parse(layout, strings.TrimSpace(sTimestamp))
+        if err == nil {
+            return readTime
+        }
+    }
+    return txntimestamp
+}
+
+/*********************************  internal: excursionCheck ****************************/
+// Brings the excursion of a metric up to date. The previous value counts for the minutes
+// since the previous reading, the actual value for the deviation. Returns the variation
+// to alert on, or Normal. The alert is raised on the reading that exceeds the tolerance,
+// and on every reading out of range after that
+func (t *SimpleChaincode) excursionCheck(exc common.Excursion, minVal float64, maxVal float64, prevVal float64, actVal float64, minutes float64, tolerance float64) (common.Excursion, common.Variation) {
+    if minutes > 0 {
+        if val, _ := t.inRange(minVal, maxVal, prevVal); val != common.Normal {
+            exc.Minutes += minutes
+        }
+    }
+    val, _ := t.inRange(minVal, maxVal, actVal)
+    if val != common.Normal {
+        exc.Variation = val
+        exc.Readings++
+        deviation := actVal - maxVal
+        if val == common.Below {
+            deviation = minVal - actVal
+        }
+        exc.MaxDeviation = math.Max(exc.MaxDeviation, deviation)
+    }
+    violation := exc.Violation
+    exc.Violation = violation || (tolerance == 0 && val != common.Normal) || (tolerance > 0 && exc.Minutes > tolerance)
+    if exc.Violation && (val != common.Normal || !violation) {
+        return exc, exc.Variation
+    }
+    return exc, common.Normal
+}
+
+/*********************************  internal: kineticTemperature ****************************/
+// Adds the previous temperature, held for the minutes since the previous reading, to the
+// running mean kinetic temperature. Returns nil until there is a reading to weigh
+func (t *SimpleChaincode) kineticTemperature(prevKT *common.KineticTemperature, prevTemp float64, minutes float64, activationEnergy float64) *common.KineticTemperature {
+    if minutes <= 0 {
+        return prevKT
+    }
+    var kt common.KineticTemperature
+    if prevKT != nil {
+        kt = *prevKT
+    }
+    if activationEnergy <= 0 {
+        activationEnergy = common.DEFAULTACTIVATIONENERGY
+    }
+    dHR := activationEnergy * 1000 / GASCONSTANT
+    kt.Sum += math.Exp(-dHR / (prevTemp + KELVIN)) * minutes
+    kt.Minutes += minutes
+    kt.MKT = dHR / -math.Log(kt.Sum / kt.Minutes) - KELVIN
+    return &kt
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+    "math"
+    "testing"
+
+    common "github.com/hyperledger/fabric/examples/chaincode/go/PoD/Common"
+)
+
+func TestKineticTemperature(t *testing.T) {
+    var cc SimpleChaincode
+
+    if kt := cc.kineticTemperature(nil, 20, 0, 0); kt != nil {
+        t.Fatalf("no time has passed, expected no MKT, got %+v", kt)
+    }
+
+    // a constant temperature is its own MKT
+    var kt *common.KineticTemperature
+    for i := 0; i < 4; i++ {
+        kt = cc.kineticTemperature(kt, 25, 15, 0)
+    }
+    if math.Abs(kt.MKT-25) > 1e-9 || kt.Minutes != 60 {
+        t.Fatalf("constant 25C for 60 minutes, got %+v", kt)
+    }
+
+    // reference values from the USP <1079> formula with dH = 83.144 kJ/mol, dH/R = 10000 K:
+    // equal time at 20C and 30C gives 26.26C, 60 minutes at 25C then 30 at 40C gives 32.71C
+    var tests = []struct {
+        temps   []float64
+        minutes []float64
+        want    float64
+    }{
+        {[]float64{20, 30}, []float64{60, 60}, 26.2599},
+        {[]float64{25, 40}, []float64{60, 30}, 32.7081},
+        {[]float64{25, 25, 40}, []float64{20, 40, 30}, 32.7081},
+    }
+    for _, tt := range tests {
+        kt = nil
+        for i := range tt.temps {
+            kt = cc.kineticTemperature(kt, tt.temps[i], tt.minutes[i], common.DEFAULTACTIVATIONENERGY)
+        }
+        if math.Abs(kt.MKT-tt.want) > 1e-3 {
+            t.Errorf("MKT of %v for %v minutes is %.4f, want %.4f", tt.temps, tt.minutes, kt.MKT, tt.want)
+        }
+    }
+
+    // a higher activation energy weighs the warm period more heavily
+    low := cc.kineticTemperature(cc.kineticTemperature(nil, 20, 60, 60), 30, 60, 60)
+    high := cc.kineticTemperature(cc.kineticTemperature(nil, 20, 60, 120), 30, 60, 120)
+    if !(low.MKT > 25 && high.MKT > low.MKT && high.MKT < 30) {
+        t.Errorf("MKT should rise with activation energy, got %.4f and %.4f", low.MKT, high.MKT)
+    }
+}
+
+func TestExcursionCheck(t *testing.T) {
+    var cc SimpleChaincode
+
+    // range 2..8 with 30 minutes tolerance, each reading holds until the next one
+    var steps = []struct {
+        minutes   float64
+        value     float64
+        alert     common.Variation
+        excMins   float64
+        readings  int
+        violation bool
+    }{
+        {0, 5, common.Normal, 0, 0, false},
+        // the excursion starts, no time out of range yet
+        {10, 9, common.Normal, 0, 1, false},
+        {20, 10, common.Normal, 20, 2, false},
+        // back in range after 35 minutes above, which exceeds the tolerance
+        {15, 7, common.Above, 35, 2, true},
+        // the excursion has ended, in range time is not counted
+        {10, 5, common.Normal, 35, 2, true},
+        // every reading out of range after the violation alerts
+        {10, 1, common.Below, 35, 3, true},
+    }
+    var exc common.Excursion
+    var prev float64
+    for i, s := range steps {
+        var alert common.Variation
+        exc, alert = cc.excursionCheck(exc, 2, 8, prev, s.value, s.minutes, 30)
+        if alert != s.alert || exc.Minutes != s.excMins || exc.Readings != s.readings || exc.Violation != s.violation {
+            t.Fatalf("step %d reading %v: alert %s excursion %+v, want alert %s minutes %v readings %d violation %v",
+                i, s.value, alert, exc, s.alert, s.excMins, s.readings, s.violation)
+        }
+        prev = s.value
+    }
+    if exc.MaxDeviation != 2 || exc.Variation != common.Below {
+        t.Fatalf("expected max deviation 2 and last variation below, got %+v", exc)
+    }
+
+    // without tolerance the first reading out of range is a violation
+    exc, alert := cc.excursionCheck(common.Excursion{}, 2, 8, 5, 1.5, 10, 0)
+    if alert != common.Below || !exc.Violation || exc.Minutes != 0 || exc.MaxDeviation != 0.5 {
+        t.Fatalf("zero tolerance: alert %s excursion %+v", alert, exc)
+    }
+}
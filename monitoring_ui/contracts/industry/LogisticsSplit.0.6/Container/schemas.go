@@ -121,6 +121,85 @@ var schemas = `
             },
             "type": "object"
         },
+        "readBillOfLadingStatus": {
+            "description": "Returns the compliance and transit of every container on a Bill of Lading, including containers swapped out mid-transit. Argument is a JSON encoded string. Bill of Lading No is the only accepted property.",
+            "properties": {
+                "args": {
+                    "description": "args are JSON encoded strings",
+                    "items": {
+                        "properties": {
+                            "blno": {
+                                "description": "The Bill of Lading number",
+                                "type": "string"
+                            }
+                        },
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
+                    "type": "array"
+                },
+                "function": {
+                    "description": "readBillOfLadingStatus function",
+                    "enum": [
+                        "readBillOfLadingStatus"
+                    ],
+                    "type": "string"
+                },
+                "method": "query",
+                "result": {
+                    "description": "The B/L is compliant if every container is. Transit is complete when every current container has arrived.",
+                    "properties": {
+                        "blno": {
+                            "type": "string"
+                        },
+                        "compliance": {
+                            "type": "boolean"
+                        },
+                        "transitcomplete": {
+                            "type": "boolean"
+                        },
+                        "containers": {
+                            "items": {
+                                "properties": {
+                                    "containerno": {
+                                        "type": "string"
+                                    },
+                                    "compliance": {
+                                        "type": "boolean"
+                                    },
+                                    "alerts": {
+                                        "description": "The last alert raised for the container",
+                                        "type": "string"
+                                    },
+                                    "transitcomplete": {
+                                        "type": "boolean"
+                                    },
+                                    "arrivedat": {
+                                        "properties": {
+                                            "latitude": {
+                                                "type": "number"
+                                            },
+                                            "longitude": {
+                                                "type": "number"
+                                            }
+                                        },
+                                        "type": "object"
+                                    },
+                                    "swappedto": {
+                                        "type": "string"
+                                    }
+                                },
+                                "type": "object"
+                            },
+                            "type": "array"
+                        }
+                    },
+                    "type": "object"
+                }
+            },
+            "type": "object"
+        },
         "readContainerCurrentStatus": {
             "description": "Returns the state an asset. Argument is a JSON encoded string. Container No is the only accepted property.",
             "properties": {
@@ -201,6 +280,78 @@ var schemas = `
                                 }
                             },
                             "type": "object"
+                        },
+                        "compliance": {
+                            "description": "false once an alert has been raised for the container",
+                            "type": "boolean"
+                        },
+                        "transitcomplete": {
+                            "description": "true once the container has arrived within the notify range of a notify location",
+                            "type": "boolean"
+                        },
+                        "arrivedat": {
+                            "description": "The notify location the container arrived at",
+                            "properties": {
+                                "latitude": {
+                                    "type": "number"
+                                },
+                                "longitude": {
+                                    "type": "number"
+                                }
+                            },
+                            "type": "object"
+                        },
+                        "swappedto": {
+                            "description": "The container the shipment was moved to mid-transit",
+                            "type": "string"
+                        },
+                        "excursions": {
+                            "description": "How long and how far each metric has been out of range, keyed by metric: temperature, humidity, light, acceleration",
+                            "additionalProperties": {
+                                "properties": {
+                                    "variation": {
+                                        "description": "The last reading out of range was above or below",
+                                        "type": "string"
+                                    },
+                                    "minutes": {
+                                        "description": "Cumulative minutes out of range. A reading holds until the next one",
+                                        "type": "number"
+                                    },
+                                    "maxdeviation": {
+                                        "description": "Furthest a reading was outside min / max",
+                                        "type": "number"
+                                    },
+                                    "readings": {
+                                        "type": "integer"
+                                    },
+                                    "violation": {
+                                        "description": "The B/L tolerance for the metric has been exceeded",
+                                        "type": "boolean"
+                                    }
+                                },
+                                "type": "object"
+                            },
+                            "type": "object"
+                        },
+                        "kinetictemperature": {
+                            "description": "Running, time weighted mean kinetic temperature",
+                            "properties": {
+                                "minutes": {
+                                    "type": "number"
+                                },
+                                "sum": {
+                                    "type": "number"
+                                },
+                                "mkt": {
+                                    "description": "Mean kinetic temperature in CELSIUS.",
+                                    "type": "number"
+                                }
+                            },
+                            "type": "object"
+                        },
+                        "lastreading": {
+                            "description": "Time of the last sensor reading",
+                            "type": "string"
                         }
                     },
                     "type": "object"
@@ -233,6 +384,51 @@ var schemas = `
             },
             "type": "object"
         },
+        "swapContainerLogistics": {
+            "description": "Move the shipment of a Bill of Lading from one container to another mid-transit. The old container remains part of the B/L compliance. Called by the Bill of Lading contract.",
+            "properties": {
+                "args": {
+                    "description": "args are JSON encoded strings",
+                    "items": {
+                        "properties": {
+                            "blno": {
+                                "description": "The Bill of Lading number",
+                                "type": "string"
+                            },
+                            "containerno": {
+                                "description": "The container currently carrying the shipment",
+                                "type": "string"
+                            },
+                            "newcontainerno": {
+                                "description": "The container the shipment is moved to",
+                                "type": "string"
+                            },
+                            "timestamp": {
+                                "type": "string"
+                            }
+                        },
+                        "required": [
+                            "blno",
+                            "containerno",
+                            "newcontainerno"
+                        ],
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
+                    "type": "array"
+                },
+                "function": {
+                    "description": "swapContainerLogistics function",
+                    "enum": [
+                        "swapContainerLogistics"
+                    ],
+                    "type": "string"
+                },
+                "method": "invoke"
+            },
+            "type": "object"
+        },
         "updateContainerLogistics": {
             "description": "Update the state of an asset. The one argument is a JSON encoded event. Container No is required along with one or more writable properties. Establishes the next asset state. ",
             "properties": {
@@ -456,6 +652,78 @@ var schemas = `
                         }
                     },
                     "type": "object"
+                },
+                "compliance": {
+                    "description": "false once an alert has been raised for the container",
+                    "type": "boolean"
+                },
+                "transitcomplete": {
+                    "description": "true once the container has arrived within the notify range of a notify location",
+                    "type": "boolean"
+                },
+                "arrivedat": {
+                    "description": "The notify location the container arrived at",
+                    "properties": {
+                        "latitude": {
+                            "type": "number"
+                        },
+                        "longitude": {
+                            "type": "number"
+                        }
+                    },
+                    "type": "object"
+                },
+                "swappedto": {
+                    "description": "The container the shipment was moved to mid-transit",
+                    "type": "string"
+                },
+                "excursions": {
+                    "description": "How long and how far each metric has been out of range, keyed by metric: temperature, humidity, light, acceleration",
+                    "additionalProperties": {
+                        "properties": {
+                            "variation": {
+                                "description": "The last reading out of range was above or below",
+                                "type": "string"
+                            },
+                            "minutes": {
+                                "description": "Cumulative minutes out of range. A reading holds until the next one",
+                                "type": "number"
+                            },
+                            "maxdeviation": {
+                                "description": "Furthest a reading was outside min / max",
+                                "type": "number"
+                            },
+                            "readings": {
+                                "type": "integer"
+                            },
+                            "violation": {
+                                "description": "The B/L tolerance for the metric has been exceeded",
+                                "type": "boolean"
+                            }
+                        },
+                        "type": "object"
+                    },
+                    "type": "object"
+                },
+                "kinetictemperature": {
+                    "description": "Running, time weighted mean kinetic temperature",
+                    "properties": {
+                        "minutes": {
+                            "type": "number"
+                        },
+                        "sum": {
+                            "type": "number"
+                        },
+                        "mkt": {
+                            "description": "Mean kinetic temperature in CELSIUS.",
+                            "type": "number"
+                        }
+                    },
+                    "type": "object"
+                },
+                "lastreading": {
+                    "description": "Time of the last sensor reading",
+                    "type": "string"
                 }
             },
             "type": "object"
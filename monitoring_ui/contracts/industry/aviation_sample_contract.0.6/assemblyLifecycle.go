@@ -0,0 +1,175 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+// Assembly lifecycle
+// The maintenance event drives an assembly through its lifecycle. The allowed
+// transitions are held in an explicit table so that the state machine is
+// enforced in one place and can be read by applications through the
+// readAssemblyLifecycle query.
+//
+//  From          Action              To
+//  new           commission          inventory
+//  new           scrap               scrapped
+//  inventory     install             aircraft     (aircraft or parent required)
+//  inventory     startMaintenance    maintenance  (aircraft or parent not allowed)
+//  inventory     scrap               scrapped
+//  aircraft      uninstall           inventory    (aircraft or parent required)
+//  maintenance   endMaintenance      inventory
+//  maintenance   scrap               scrapped
+//  inventory     comply              inventory
+//  aircraft      comply              aircraft
+//  maintenance   comply              maintenance
+//
+// comply records compliance with an airworthiness directive named in completesDirective
+// without moving the assembly, so that a directive on an installed part can be closed.
+// scrapped is terminal, no action leaves it. An assembly installed into a parent
+// assembly rather than directly onto an aircraft also has status aircraft.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+)
+
+// assembly lifecycle statuses
+const (
+	AssemblyStatusNew         = "new"
+	AssemblyStatusInventory   = "inventory"
+	AssemblyStatusAircraft    = "aircraft"
+	AssemblyStatusMaintenance = "maintenance"
+	AssemblyStatusScrapped    = "scrapped"
+)
+
+// AssemblyTransition is one row of the lifecycle table
+type AssemblyTransition struct {
+	From   string `json:"from"`
+	Action string `json:"action"`
+	To     string `json:"to"`
+	// aircraft or parent assembly must be provided in the maintenance event
+	RequiresAircraft bool `json:"requiresAircraft"`
+	// aircraft or parent assembly must not be provided in the maintenance event
+	ForbidsAircraft bool `json:"forbidsAircraft"`
+}
+
+// AssemblyLifecycle is the full state machine as returned by readAssemblyLifecycle
+type AssemblyLifecycle struct {
+	Statuses    []string             `json:"statuses"`
+	Terminal    []string             `json:"terminal"`
+	Transitions []AssemblyTransition `json:"transitions"`
+}
+
+var assemblyLifecycle = AssemblyLifecycle{
+	Statuses: []string{
+		AssemblyStatusNew,
+		AssemblyStatusInventory,
+		AssemblyStatusAircraft,
+		AssemblyStatusMaintenance,
+		AssemblyStatusScrapped,
+	},
+	Terminal: []string{AssemblyStatusScrapped},
+	Transitions: []AssemblyTransition{
+		{AssemblyStatusNew, "commission", AssemblyStatusInventory, false, false},
+		{AssemblyStatusNew, "scrap", AssemblyStatusScrapped, false, false},
+		{AssemblyStatusInventory, "install", AssemblyStatusAircraft, true, false},
+		{AssemblyStatusInventory, "startMaintenance", AssemblyStatusMaintenance, false, true},
+		{AssemblyStatusInventory, "scrap", AssemblyStatusScrapped, false, false},
+		{AssemblyStatusAircraft, "uninstall", AssemblyStatusInventory, true, false},
+		{AssemblyStatusMaintenance, "endMaintenance", AssemblyStatusInventory, false, false},
+		{AssemblyStatusMaintenance, "scrap", AssemblyStatusScrapped, false, false},
+		{AssemblyStatusInventory, "comply", AssemblyStatusInventory, false, false},
+		{AssemblyStatusAircraft, "comply", AssemblyStatusAircraft, false, false},
+		{AssemblyStatusMaintenance, "comply", AssemblyStatusMaintenance, false, false},
+	},
+}
+
+// allowedActions returns the actions that may be applied to an assembly in the given status
+func (lc AssemblyLifecycle) allowedActions(status string) []string {
+	var actions = make([]string, 0)
+	for _, t := range lc.Transitions {
+		if t.From == status {
+			actions = append(actions, t.Action)
+		}
+	}
+	return actions
+}
+
+// findTransition looks up the row for a status and action
+func (lc AssemblyLifecycle) findTransition(status string, action string) (AssemblyTransition, bool) {
+	for _, t := range lc.Transitions {
+		if t.From == status && t.Action == action {
+			return t, true
+		}
+	}
+	return AssemblyTransition{}, false
+}
+
+// validateTransition checks the action against the lifecycle table and its preconditions and returns
+// the transition to apply. The targets describe the aircraft or parent assembly named in the event and
+// the one the assembly is installed on, e.g. "aircraft 5678", blank when there is none. The error for
+// a rejected transition reports the current status, the allowed next actions and the aircraft involved.
+func (lc AssemblyLifecycle) validateTransition(assemblyID string, status string, action string, eventTarget string, currentTarget string) (AssemblyTransition, error) {
+	aircraft := currentTarget
+	if aircraft == "" {
+		aircraft = eventTarget
+	}
+	if aircraft == "" {
+		aircraft = "none"
+	}
+	t, found := lc.findTransition(status, action)
+	if !found {
+		err := fmt.Errorf("validateTransition: assembly %s cannot %s from status %s (installed on %s), allowed actions are %v",
+			assemblyID, action, status, aircraft, lc.allowedActions(status))
+		log.Error(err)
+		return t, err
+	}
+	if t.RequiresAircraft && eventTarget == "" {
+		err := fmt.Errorf("validateTransition: assembly %s cannot %s from status %s without an aircraft or parent assembly (installed on %s), allowed actions are %v",
+			assemblyID, action, status, aircraft, lc.allowedActions(status))
+		log.Error(err)
+		return t, err
+	}
+	if t.ForbidsAircraft && eventTarget != "" {
+		err := fmt.Errorf("validateTransition: assembly %s cannot %s from status %s with %s, allowed actions are %v",
+			assemblyID, action, status, eventTarget, lc.allowedActions(status))
+		log.Error(err)
+		return t, err
+	}
+	return t, nil
+}
+
+// getAssemblyStatus returns the lifecycle status of an assembly, assemblies without status are new
+func getAssemblyStatus(state interface{}) string {
+	status, found := getObjectAsString(state, "status")
+	if !found || status == "" {
+		return AssemblyStatusNew
+	}
+	return status
+}
+
+// ************************************
+// readAssemblyLifecycle
+// ************************************
+func readAssemblyLifecycle(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	lcbytes, err := json.Marshal(&assemblyLifecycle)
+	if err != nil {
+		err = fmt.Errorf("readAssemblyLifecycle failed to marshal the lifecycle table: %s", err)
+		log.Error(err)
+		return nil, err
+	}
+	return lcbytes, nil
+}
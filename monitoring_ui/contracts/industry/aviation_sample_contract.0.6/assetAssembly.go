@@ -65,13 +65,13 @@ func injectAircraft(stub shim.ChaincodeStubInterface, assembly interface{}) (int
 		log.Error(err)
 		return nil, err
 	}
-	indexes, err := getAircraftAssemblyIndexesFromLedger(stub)
+	// sub-assemblies fly on the aircraft of their top assembly
+	aircraftID, ok, err := getPartAircraft(stub, assemblyID)
 	if err != nil {
-		err = errors.New("injectAircraft failed to get indexes from ledger: " + err.Error())
+		err = errors.New("injectAircraft failed to get aircraft from part tree: " + err.Error())
 		log.Error(err)
 		return nil, err
 	}
-	aircraftID, ok := indexes.AssemblyToAircraft[assemblyID]
 	if !ok {
 		// assembly has no associated aircraft, this is ok, return original assembly
 		return assembly, nil
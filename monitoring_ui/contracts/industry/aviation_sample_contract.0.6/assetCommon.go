@@ -24,6 +24,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/ibm-watson-iot/blockchain-samples/contracts/platform/contractlogger"
 	"sort"
 	"strings"
 )
@@ -38,6 +39,7 @@ func createAsset(stub shim.ChaincodeStubInterface, args []string, assetName stri
 	if err != nil {
 		return nil, err
 	}
+	contractlogger.SetTransactionAsset(stub.GetTxID(), assetID)
 	// We have a valid assetID in internal format, so verify whether it already exists.
 	assetBytes, err := assetIsActive(stub, assetID)
 	if err == nil && len(assetBytes) > 0 {
@@ -78,6 +80,7 @@ func updateAsset(stub shim.ChaincodeStubInterface, args []string, assetName stri
 	if err != nil {
 		return nil, err
 	}
+	contractlogger.SetTransactionAsset(stub.GetTxID(), assetID)
 	state, err := getUnmarshalledState(stub, caller, assetID)
 	if err != nil {
 		return nil, err
@@ -115,6 +118,7 @@ func deleteAsset(stub shim.ChaincodeStubInterface, args []string, assetName stri
 	if err != nil {
 		return nil, err
 	}
+	contractlogger.SetTransactionAsset(stub.GetTxID(), assetID)
 	err = removeOneAssetFromWorldState(stub, caller, assetName, assetID)
 	if err != nil {
 		return nil, err
@@ -161,6 +165,7 @@ func deletePropertiesFromAsset(stub shim.ChaincodeStubInterface, args []string,
 	if err != nil {
 		return nil, err
 	}
+	contractlogger.SetTransactionAsset(stub.GetTxID(), assetID)
 
 	var qprops []interface{}
 	qpropsBytes, found := getObject(argsMap, "qualPropsToDelete")
@@ -261,6 +266,7 @@ func readAsset(stub shim.ChaincodeStubInterface, args []string, assetName string
 	if err != nil {
 		return nil, err
 	}
+	contractlogger.SetTransactionAsset(stub.GetTxID(), assetID)
 	assetBytes, err := assetIsActive(stub, assetID)
 	if err != nil {
 		// something went wrong
@@ -365,6 +371,7 @@ func readAssetHistory(stub shim.ChaincodeStubInterface, args []string, assetName
 	if err != nil {
 		return nil, err
 	}
+	contractlogger.SetTransactionAsset(stub.GetTxID(), assetID)
 	stateHistory, err := readStateHistory(stub, assetID)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,133 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+package main
+
+import (
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"testing"
+	"time"
+)
+
+func TestDirectives(t *testing.T) {
+	var directives = []Directive{
+		{DirectiveID: "AD-32", ATACode: "32", SerialFrom: "S100", SerialTo: "S199", Deadline: "2017-01-01T00:00:00Z"},
+		{DirectiveID: "AD-ACME", Manufacturer: "acme", Deadline: "2017-01-01T00:00:00Z"},
+	}
+	for _, d := range directives {
+		if err := d.validate(); err != nil {
+			t.Fatalf("directive %s failed validation: %s", d.DirectiveID, err)
+		}
+	}
+	if err := (Directive{DirectiveID: "AD-X", Deadline: "2017-01-01T00:00:00Z"}).validate(); err == nil {
+		t.Fatal("directive without targeting passed validation")
+	}
+	if err := (Directive{DirectiveID: "AD-X", SerialFrom: "S100", SerialTo: "S99", Deadline: "2017-01-01T00:00:00Z"}).validate(); err == nil {
+		t.Fatal("directive with serialFrom S100 after serialTo S99 passed validation")
+	}
+
+	// serials with a shared prefix are ordered by their numeric suffix
+	for _, tt := range []struct {
+		serial  string
+		applies bool
+	}{{"S99", false}, {"S100", true}, {"S0150", true}, {"S199", true}, {"S200", false}, {"S1000", false}, {"T150", false}} {
+		var state = ArgsMap{"assembly": map[string]interface{}{"ataCode": "32", "serialNumber": tt.serial}}
+		if directives[0].appliesTo(state) != tt.applies {
+			t.Errorf("AD-32 for S100..S199 applies to %s should be %v", tt.serial, tt.applies)
+		}
+	}
+	var now = time.Date(2016, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	// steering is in chapter 32 and the serial range, but not made by acme
+	var state = ArgsMap{
+		"assembly": map[string]interface{}{"ataCode": "32-50", "serialNumber": "S150", "manufacturer": "Other"},
+	}
+	if !directives[0].appliesTo(state) || directives[1].appliesTo(state) {
+		t.Fatalf("directives matched incorrectly against %+v", state)
+	}
+	var alerts AlertStatusInternal
+	if err := state.directivesRule(directives, &alerts, ArgsMap{}, now); err != nil {
+		t.Fatalf("directivesRule failed: %s", err)
+	}
+	if !alerts.Active[AlertsADOPEN] {
+		t.Fatal("AD_OPEN not raised for open directive")
+	}
+	if s, _ := getObjectAsString(state, "directives.AD-32.status"); s != DirectiveStatusOpen {
+		t.Fatalf("expected directive AD-32 open, got %s", s)
+	}
+
+	// a directive that does not apply cannot be completed
+	var event = ArgsMap{"maintenance": map[string]interface{}{"completesDirective": "AD-ACME"}}
+	if err := state.directivesRule(directives, &alerts, event, now); err == nil {
+		t.Fatal("completed a directive that does not apply")
+	}
+
+	event = ArgsMap{"maintenance": map[string]interface{}{"completesDirective": "AD-32"}}
+	if err := state.directivesRule(directives, &alerts, event, now); err != nil {
+		t.Fatalf("directivesRule failed: %s", err)
+	}
+	if alerts.Active[AlertsADOPEN] {
+		t.Fatal("AD_OPEN still active after the directive was completed")
+	}
+	if s, _ := getObjectAsString(state, "directives.AD-32.status"); s != DirectiveStatusClosed {
+		t.Fatalf("expected directive AD-32 closed, got %s", s)
+	}
+}
+
+func TestDirectiveOnInstalledAssembly(t *testing.T) {
+	var cc SimpleChaincode
+	stub := &genealogyStub{shim.NewMockStub("directives", nil), time.Date(2016, 10, 1, 0, 0, 0, 0, time.UTC)}
+	stub.MockTransactionStart("tx1")
+	if _, err := cc.Init(stub, "init", []string{`{"version": "` + MYVERSION + `"}`}); err != nil {
+		t.Fatalf("init failed: %s", err)
+	}
+	for _, step := range []struct {
+		function string
+		arg      string
+	}{
+		{"createAssetAircraft", `{"common": {"assetID": "1234"}, "aircraft": {}}`},
+		{"createAssetAssembly", `{"common": {"assetID": "S150"}, "assembly": {"ataCode": "32-50"}}`},
+		{"eventMaintenance", `{"maintenance": {"assembly": "S150", "action": "commission"}}`},
+		{"eventMaintenance", `{"maintenance": {"assembly": "S150", "action": "install", "aircraft": "1234"}}`},
+		{"createDirective", `{"directive": {"directiveID": "AD-32", "ataCode": "32", "serialFrom": "S100", "serialTo": "S199", "deadline": "2017-01-01T00:00:00Z"}}`},
+	} {
+		if _, err := cc.Invoke(stub, step.function, []string{step.arg}); err != nil {
+			t.Fatalf("%s failed: %s", step.function, err)
+		}
+	}
+
+	// an installed assembly only allows uninstall and comply, comply needs the directive
+	if _, err := cc.Invoke(stub, "eventMaintenance", []string{`{"maintenance": {"assembly": "S150", "action": "comply"}}`}); err == nil {
+		t.Fatal("comply without completesDirective should be rejected")
+	}
+	if _, err := cc.Invoke(stub, "eventMaintenance", []string{`{"maintenance": {"assembly": "S150", "action": "comply", "completesDirective": "AD-32"}}`}); err != nil {
+		t.Fatalf("comply failed: %s", err)
+	}
+	assemblyID, _ := assetIDToInternal("assembly", "S150")
+	state, err := getUnmarshalledState(stub, "TestDirectiveOnInstalledAssembly", assemblyID)
+	if err != nil {
+		t.Fatalf("failed to read assembly: %s", err)
+	}
+	if s, _ := getObjectAsString(state, "directives.AD-32.status"); s != DirectiveStatusClosed {
+		t.Fatalf("expected directive AD-32 closed, got %s", s)
+	}
+	if getAssemblyStatus(state) != AssemblyStatusAircraft {
+		t.Fatalf("assembly should still be installed, status is %s", getAssemblyStatus(state))
+	}
+	if aircraft, found, _ := getPartAircraft(stub, assemblyID); !found || aircraft == "" {
+		t.Fatal("assembly should still be on its aircraft")
+	}
+}
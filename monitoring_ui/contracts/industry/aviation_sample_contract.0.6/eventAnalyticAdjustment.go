@@ -21,6 +21,7 @@ package main
 import (
 	"fmt"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/ibm-watson-iot/blockchain-samples/contracts/platform/contractlogger"
 )
 
 func eventAnalyticAdjustment(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
@@ -42,6 +43,7 @@ func handleAssemblyAnalyticAdjustmentEvent(stub shim.ChaincodeStubInterface, eve
 	if err != nil {
 		return nil, err
 	}
+	contractlogger.SetTransactionAsset(stub.GetTxID(), assetID)
 
 	state, err := getUnmarshalledState(stub, "handleAssemblyAnalyticAdjustmentEvent", assetID)
 	if err != nil {
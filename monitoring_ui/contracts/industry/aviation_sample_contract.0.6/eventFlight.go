@@ -18,11 +18,14 @@ Kim Letkeman - Initial Contribution
 
 // Flight Event
 // This event makes changes to both aircraft assets and assembly assets.
-// For the aircraft to which the flight applies, "cycles" is incremented by one.
-// For the assemblies attached to said aircraft:
+// For the aircraft to which the flight applies, "cycles" is incremented by one
+// and "flightHours" accumulates the optional flight.flightHours.
+// For the assemblies attached to said aircraft, and every assembly installed
+// below them in the part tree:
 //    "cycles" and "adjustedCycles" are incremented
 //    "aCheckCounter" and "aCheckCounterAdjusted" are incremented
 //    "bCheckCounter" and "bCheckCounterAdjusted" are incremented
+//    "flightHours", "aCheckHours" and "bCheckHours" accumulate flight.flightHours
 
 package main
 
@@ -30,6 +33,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/ibm-watson-iot/blockchain-samples/contracts/platform/contractlogger"
 )
 
 func eventFlight(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
@@ -57,25 +61,19 @@ func eventFlight(stub shim.ChaincodeStubInterface, args []string) ([]byte, error
 		return nil, err
 	}
 
-	indexes, err := getAircraftAssemblyIndexesFromLedger(stub)
+	// propagate to every assembly in the part tree below the aircraft
+	assemblies, err := getPartDescendants(stub, aircraftID)
 	if err != nil {
 		return nil, err
 	}
-
-	// propagate to assemblies
-	assemblies, found := indexes.getAircraftAssemblies(aircraftID)
 	log.Debugf("eventFlight: propagating aircraft %s to %d assemblies", aircraftID, len(assemblies))
-	if found {
-		for assetID := range assemblies {
-			_, err := handleAssemblyFlightEvent(stub, event, assetID)
-			if err != nil {
-				return nil, err
-			}
+	for _, assetID := range assemblies {
+		_, err := handleAssemblyFlightEvent(stub, event, assetID)
+		if err != nil {
+			return nil, err
 		}
 	}
 
-	// no need to put indexes to ledger since we used them in read only mode
-
 	return nil, nil
 }
 
@@ -86,6 +84,7 @@ func handleAircraftFlightEvent(stub shim.ChaincodeStubInterface, event interface
 	if err != nil {
 		return nil, err
 	}
+	contractlogger.SetTransactionAsset(stub.GetTxID(), assetID)
 
 	// the assetID should be an internal aircraft ID
 	state, err := getUnmarshalledState(stub, "handleAircraftFlightEvent", assetID)
@@ -123,6 +122,11 @@ func handleAircraftFlightEvent(stub shim.ChaincodeStubInterface, event interface
 		return nil, err
 	}
 
+	state, err = addFlightHours(state, event, []string{"flightHours"})
+	if err != nil {
+		return nil, err
+	}
+
 	state, err = addTXNTimestampToState(stub, "handleAircraftFlightEvent", state)
 	if err != nil {
 		return nil, err
@@ -237,6 +241,11 @@ func handleAssemblyFlightEvent(stub shim.ChaincodeStubInterface, event interface
 		return nil, err
 	}
 
+	state, err = addFlightHours(state, event, []string{"flightHours", "aCheckHours", "bCheckHours"})
+	if err != nil {
+		return nil, err
+	}
+
 	state, err = addTXNTimestampToState(stub, "handleAssemblyFlightEvent", state)
 	if err != nil {
 		return nil, err
@@ -255,3 +264,28 @@ func handleAssemblyFlightEvent(stub shim.ChaincodeStubInterface, event interface
 
 	return state, nil
 }
+
+// addFlightHours adds the event's flight.flightHours to each of the named counters,
+// flights without hours leave the counters untouched
+func addFlightHours(state interface{}, event interface{}, properties []string) (interface{}, error) {
+	var ok bool
+	hours, found := getObjectAsNumber(event, "flight.flightHours")
+	if !found {
+		return state, nil
+	}
+	if hours < 0 {
+		err := fmt.Errorf("addFlightHours: flightHours cannot be negative: %f", hours)
+		log.Error(err)
+		return nil, err
+	}
+	for _, p := range properties {
+		total, _ := getObjectAsNumber(state, p)
+		state, ok = putObject(state, p, total+hours)
+		if !ok {
+			err := fmt.Errorf("addFlightHours: %s property could not be written into state: %+v", p, state)
+			log.Error(err)
+			return nil, err
+		}
+	}
+	return state, nil
+}
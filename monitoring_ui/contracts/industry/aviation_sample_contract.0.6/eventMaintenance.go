@@ -28,15 +28,16 @@ Kim Letkeman - Initial Contribution
 //  MaintenanceStart       maintenance
 //  MaintenanceComplete    inventory
 //  Scrap                  scrapped
+//  comply                 unchanged, completesDirective is required
 //
 // An assembly:
 //   - can only be installed on an aircraft from inventory
 //   - can only go to maintenance from inventory
 //   - providing an aircraft serial number for maintenanceStarted is an error
 //
-// NOTE: This is not an enforced finite state machine. It is possible to go from
-// any status to any other status by executing the appropriate action. Some combinations
-// make no sense and will be errored.
+// The lifecycle is an enforced finite state machine, see assemblyLifecycle.go for
+// the transition table. An action that is not allowed from the current status is
+// rejected and the error lists the actions that are allowed.
 
 package main
 
@@ -44,6 +45,8 @@ import (
 	"fmt"
 	//"errors"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/ibm-watson-iot/blockchain-samples/contracts/platform/contractlogger"
+	"time"
 )
 
 func eventMaintenance(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
@@ -64,6 +67,7 @@ func handleAssemblyMaintenanceEvent(stub shim.ChaincodeStubInterface, event inte
 	if err != nil {
 		return nil, err
 	}
+	contractlogger.SetTransactionAsset(stub.GetTxID(), assetID)
 
 	state, err := getUnmarshalledState(stub, "handleAssemblyMaintenanceEvent", assetID)
 	if err != nil {
@@ -100,11 +104,6 @@ func handleAssemblyMaintenanceEvent(stub shim.ChaincodeStubInterface, event inte
 func processMaintenanceAction(stub shim.ChaincodeStubInterface, state interface{}, event interface{}, assetID string) (interface{}, error) {
 	var ok bool
 
-	indexes, err := getAircraftAssemblyIndexesFromLedger(stub)
-	if err != nil {
-		return nil, err
-	}
-
 	action, found := getObjectAsString(event, "maintenance.action")
 	if !found {
 		err := fmt.Errorf("processMaintenanceAction: action property missing from event: %+v", event)
@@ -115,7 +114,7 @@ func processMaintenanceAction(stub shim.ChaincodeStubInterface, state interface{
 	eventAssemblyID, found := getObjectAsString(event, "maintenance.assembly")
 	if !found {
 		// no assembly in event
-		err = fmt.Errorf("processMaintenanceAction: assembly id missing from maintenance event but is required")
+		err := fmt.Errorf("processMaintenanceAction: assembly id missing from maintenance event but is required")
 		log.Error(err)
 		return nil, err
 	}
@@ -125,50 +124,76 @@ func processMaintenanceAction(stub shim.ChaincodeStubInterface, state interface{
 	}
 
 	eventAircraftID, found := getObjectAsString(event, "maintenance.aircraft")
-	if !found {
-		// no aircraft in event, check to see if it was mandatory
-		if contains([]string{"install", "uninstall"}, action) {
-			err = fmt.Errorf("processMaintenanceAction: aircraft id missing from maintenance event but is required for action %s", action)
+	if found {
+		eventAircraftID, err = assetIDToInternal("aircraft", eventAircraftID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// a sub-assembly is installed into a parent assembly instead of onto an aircraft
+	eventParentID, found := getObjectAsString(event, "maintenance.parent")
+	if found {
+		if eventAircraftID != "" {
+			err = fmt.Errorf("processMaintenanceAction: assembly %s cannot name both an aircraft and a parent assembly", eventAssemblyID)
 			log.Error(err)
 			return nil, err
 		}
-	} else {
-		eventAircraftID, err = assetIDToInternal("aircraft", eventAircraftID)
+		eventParentID, err = assetIDToInternal("assembly", eventParentID)
 		if err != nil {
 			return nil, err
 		}
 	}
 
+	eventTarget := describeInstallTarget(eventAircraftID, eventParentID)
+	currAircraftID, _ := getObjectAsString(state, "aircraft")
+	currParentID, _ := getObjectAsString(state, "parent")
+	currTarget := ""
+	if currAircraftID != "" {
+		currTarget = "aircraft " + currAircraftID
+	} else if currParentID != "" {
+		currTarget = "assembly " + currParentID
+	}
+	transition, err := assemblyLifecycle.validateTransition(eventAssemblyID, getAssemblyStatus(state), action, eventTarget, currTarget)
+	if err != nil {
+		err = fmt.Errorf("processMaintenanceAction: %s", err.Error())
+		return nil, err
+	}
+
+	txntime, err := getTxnTime(stub)
+	if err != nil {
+		return nil, err
+	}
+
 	log.Info(fmt.Sprintf("\n\nProcess Maintenance Action: \n\nSTATE: %+v \n\nEVENT: %+v\n\n EVENT ASSEM: %s   EVENT AIRCRAFT: %s   ACTION: %s\n\n", state, event, eventAssemblyIDInternal, eventAircraftID, action))
 
 	switch action {
 	case "commission":
-		// commission always goes into inventory, error if not "new"
-		err := validateStatus(state, []string{"new"})
-		if err != nil {
-			return nil, err
-		}
-		state, ok = putObject(state, "status", "inventory")
+		state, ok = putObject(state, "status", transition.To)
 		if !ok {
 			err := fmt.Errorf("processMaintenanceAction: assembly %s failed to commmission as putObject failed", eventAssemblyIDInternal)
 			log.Error(err)
 			return nil, err
 		}
 	case "install":
-		err := validateStatus(state, []string{"inventory"})
+		currParent, found, err := getPartParent(stub, eventAssemblyIDInternal)
 		if err != nil {
-			err := fmt.Errorf("processMaintenanceAction: assembly %s failed to install to aircraft %s: %s", eventAssemblyIDInternal, eventAircraftID, err.Error())
-			log.Error(err)
 			return nil, err
 		}
-		currAircraft, found := indexes.isAssemblyOnAnyAircraft(eventAssemblyIDInternal)
 		if found {
-			err := fmt.Errorf("processMaintenanceAction: assembly %s cannot be installed on aircraft %s as it is already on aircraft %s", eventAssemblyIDInternal, eventAircraftID, currAircraft)
+			err := fmt.Errorf("processMaintenanceAction: assembly %s cannot be installed on %s as it is already installed on %s", eventAssemblyIDInternal, eventTarget, currParent)
 			log.Error(err)
 			return nil, err
 		}
-		// add to inverted index in both directions
-		err = indexes.addAssemblyToAircraft(eventAssemblyIDInternal, eventAircraftID)
+		if eventParentID != "" {
+			state, err = installAssemblyIntoParent(stub, state, eventAssemblyIDInternal, eventParentID, transition.To, txntime)
+			if err != nil {
+				return nil, err
+			}
+			break
+		}
+		// the aircraft is the root of the part tree
+		err = addPartEdge(stub, eventAircraftID, eventAssemblyIDInternal, txntime)
 		if err != nil {
 			return nil, err
 		}
@@ -180,7 +205,7 @@ func processMaintenanceAction(stub shim.ChaincodeStubInterface, state interface{
 			return nil, err
 		}
 		state, err = injectProps(state, []QualifiedPropertyNameValue{
-			{"status", "aircraft"},
+			{"status", transition.To},
 			{"aircraft", acID},
 		})
 		// add assembly into aircraft state
@@ -203,24 +228,33 @@ func processMaintenanceAction(stub shim.ChaincodeStubInterface, state interface{
 			log.Error(err)
 			return nil, err
 		}
+		err = recordSubtreeGenealogy(stub, eventAssemblyIDInternal, "install", "", eventAircraftID, txntime)
+		if err != nil {
+			return nil, err
+		}
 	case "uninstall":
-		err := validateStatus(state, []string{"aircraft"})
+		if eventParentID != "" {
+			state, err = uninstallAssemblyFromParent(stub, state, eventAssemblyIDInternal, eventParentID, transition.To, txntime)
+			if err != nil {
+				return nil, err
+			}
+			break
+		}
+		currParent, found, err := getPartParent(stub, eventAssemblyIDInternal)
 		if err != nil {
-			err := fmt.Errorf("processMaintenanceAction: assembly %s failed to uninstall from aircraft %s: %s", eventAssemblyIDInternal, eventAircraftID, err.Error())
-			log.Error(err)
 			return nil, err
 		}
-		if !indexes.isAssemblyOnThisAircraft(eventAssemblyIDInternal, eventAircraftID) {
+		if !found || currParent != eventAircraftID {
 			err := fmt.Errorf("processMaintenanceAction: assembly %s cannot be uninstalled as it is not on aircraft: %s", eventAssemblyIDInternal, eventAircraftID)
 			log.Error(err)
 			return nil, err
 		}
 		// good to uninstall
-		err = indexes.removeAssemblyFromAircraft(eventAssemblyIDInternal, eventAircraftID)
+		err = removePartEdge(stub, eventAircraftID, eventAssemblyIDInternal)
 		if err != nil {
 			return nil, err
 		}
-		state, ok = putObject(state, "status", "inventory")
+		state, ok = putObject(state, "status", transition.To)
 		if !ok {
 			err := fmt.Errorf("processMaintenanceAction: assembly %s failed to uninstall as putObject failed", eventAssemblyIDInternal)
 			log.Error(err)
@@ -252,75 +286,189 @@ func processMaintenanceAction(stub shim.ChaincodeStubInterface, state interface{
 			log.Error(err)
 			return nil, err
 		}
-	case "startMaintenance":
-		err := validateStatus(state, []string{"inventory"})
+		err = recordSubtreeGenealogy(stub, eventAssemblyIDInternal, "uninstall", "", eventAircraftID, txntime)
 		if err != nil {
-			err := fmt.Errorf("processMaintenanceAction: assembly %s failed to start maintenence: %s", eventAssemblyIDInternal, err.Error())
-			log.Error(err)
 			return nil, err
 		}
-		state, ok = putObject(state, "status", "maintenance")
+	case "startMaintenance":
+		state, ok = putObject(state, "status", transition.To)
 		if !ok {
 			err := fmt.Errorf("processMaintenanceAction: assembly %s failed to startMaintenance as putObject failed", eventAssemblyIDInternal)
 			log.Error(err)
 			return nil, err
 		}
 	case "endMaintenance":
-		err := validateStatus(state, []string{"maintenance"})
-		if err != nil {
-			err := fmt.Errorf("processMaintenanceAction: assembly %s failed to end maintenence: %s", eventAssemblyIDInternal, err.Error())
-			log.Error(err)
-			return nil, err
-		}
-		state, ok = putObject(state, "status", "inventory")
+		state, ok = putObject(state, "status", transition.To)
 		if !ok {
 			err := fmt.Errorf("processMaintenanceAction: assembly %s failed to endMaintenance as putObject failed", eventAssemblyIDInternal)
 			log.Error(err)
 			return nil, err
 		}
 	case "scrap":
-		// note that "" is included so that an assembly can be scrapped before it is commissioned
-		err := validateStatus(state, []string{"inventory", "maintenance", "new"})
+		children, err := getPartChildren(stub, eventAssemblyIDInternal)
 		if err != nil {
-			err := fmt.Errorf("processMaintenanceAction: assembly %s failed to start maintenence: %s", eventAssemblyIDInternal, err.Error())
+			return nil, err
+		}
+		if len(children) > 0 {
+			err := fmt.Errorf("processMaintenanceAction: assembly %s cannot be scrapped while assemblies %v are installed in it", eventAssemblyIDInternal, children)
 			log.Error(err)
 			return nil, err
 		}
-		state, ok = putObject(state, "status", "scrapped")
+		state, ok = putObject(state, "status", transition.To)
 		if !ok {
 			err := fmt.Errorf("processMaintenanceAction: assembly %s failed to scrap as putObject failed", eventAssemblyIDInternal)
 			log.Error(err)
 			return nil, err
 		}
+	case "comply":
+		// the directives rule closes the directive, the assembly stays where it is
+		if _, found := getObjectAsString(event, "maintenance.completesDirective"); !found {
+			err := fmt.Errorf("processMaintenanceAction: assembly %s cannot comply without completesDirective", eventAssemblyIDInternal)
+			log.Error(err)
+			return nil, err
+		}
 	default:
 		err := fmt.Errorf("processMaintenanceAction: action property unknown: %s", action)
 		log.Error(err)
 		return nil, err
 	}
 
-	err = putAircraftAssemblyIndexesToLedger(stub, indexes)
+	return state, nil
+}
+
+// describeInstallTarget names the aircraft or parent assembly in an event for error messages
+func describeInstallTarget(aircraftID string, parentID string) string {
+	if aircraftID != "" {
+		return "aircraft " + aircraftID
+	}
+	if parentID != "" {
+		return "assembly " + parentID
+	}
+	return ""
+}
+
+// installAssemblyIntoParent installs a sub-assembly into a parent assembly, adding the edge
+// to the part tree and the child to the parent's assemblies
+func installAssemblyIntoParent(stub shim.ChaincodeStubInterface, state interface{}, assemblyID string, parentID string, status string, txntime time.Time) (interface{}, error) {
+	var ok bool
+	// the parent must not be the assembly or sit below it
+	ancestors, err := getPartAncestors(stub, parentID)
 	if err != nil {
 		return nil, err
 	}
+	if parentID == assemblyID || contains(ancestors, assemblyID) {
+		err := fmt.Errorf("installAssemblyIntoParent: assembly %s cannot be installed in assembly %s as that is part of its own tree", assemblyID, parentID)
+		log.Error(err)
+		return nil, err
+	}
+	pstate, err := getUnmarshalledState(stub, "installAssemblyIntoParent", parentID)
+	if err != nil {
+		return nil, err
+	}
+	if getAssemblyStatus(pstate) == AssemblyStatusScrapped {
+		err := fmt.Errorf("installAssemblyIntoParent: assembly %s cannot be installed in scrapped assembly %s", assemblyID, parentID)
+		log.Error(err)
+		return nil, err
+	}
 
+	err = addPartEdge(stub, parentID, assemblyID, txntime)
+	if err != nil {
+		return nil, err
+	}
+	pID, err := assetIDToExternal(parentID)
+	if err != nil {
+		return nil, err
+	}
+	asID, err := assetIDToExternal(assemblyID)
+	if err != nil {
+		return nil, err
+	}
+	state, err = injectProps(state, []QualifiedPropertyNameValue{
+		{"status", status},
+		{"parent", pID},
+	})
+	if err != nil {
+		return nil, err
+	}
+	pstate, ok = addToStringArray(pstate, "assemblies", asID)
+	if !ok {
+		err := fmt.Errorf("installAssemblyIntoParent: failed to add to array in assembly state for %s", parentID)
+		log.Error(err)
+		return nil, err
+	}
+	err = putMarshalledState(stub, "installAssemblyIntoParent", "maintenance", parentID, pstate)
+	if err != nil {
+		return nil, err
+	}
+
+	aircraftID, _, err := getPartAircraft(stub, parentID)
+	if err != nil {
+		return nil, err
+	}
+	err = recordSubtreeGenealogy(stub, assemblyID, "install", parentID, aircraftID, txntime)
+	if err != nil {
+		return nil, err
+	}
 	return state, nil
 }
 
-func validateStatus(state interface{}, statuses []string) error {
-	status, ok := getObjectAsString(state, "status")
+// uninstallAssemblyFromParent removes a sub-assembly from its parent assembly, anything
+// installed in the sub-assembly comes out with it
+func uninstallAssemblyFromParent(stub shim.ChaincodeStubInterface, state interface{}, assemblyID string, parentID string, status string, txntime time.Time) (interface{}, error) {
+	var ok bool
+	currParent, found, err := getPartParent(stub, assemblyID)
+	if err != nil {
+		return nil, err
+	}
+	if !found || currParent != parentID {
+		err := fmt.Errorf("uninstallAssemblyFromParent: assembly %s cannot be uninstalled as it is not in assembly: %s", assemblyID, parentID)
+		log.Error(err)
+		return nil, err
+	}
+	// the aircraft is found through the parent before the edge is removed
+	aircraftID, _, err := getPartAircraft(stub, parentID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = removePartEdge(stub, parentID, assemblyID)
+	if err != nil {
+		return nil, err
+	}
+	state, ok = putObject(state, "status", status)
 	if !ok {
-		// no status means new, should be checking an empty array
-		if len(statuses) == 0 {
-			return nil
-		}
-		err := fmt.Errorf("validateStatus: expecting one of %+v statuses but found no status property", statuses)
+		err := fmt.Errorf("uninstallAssemblyFromParent: assembly %s failed to uninstall as putObject failed", assemblyID)
+		log.Error(err)
+		return nil, err
+	}
+	state, ok = removeObject(state, "parent")
+	if !ok {
+		err := fmt.Errorf("uninstallAssemblyFromParent: assembly %s failed to uninstall as removeObject parent failed", assemblyID)
+		log.Error(err)
+		return nil, err
+	}
+	asID, err := assetIDToExternal(assemblyID)
+	if err != nil {
+		return nil, err
+	}
+	pstate, err := getUnmarshalledState(stub, "uninstallAssemblyFromParent", parentID)
+	if err != nil {
+		return nil, err
+	}
+	pstate, ok = removeFromStringArray(pstate, "assemblies", asID)
+	if !ok {
+		err := fmt.Errorf("uninstallAssemblyFromParent: failed to remove from array in assembly state for %s", parentID)
 		log.Error(err)
-		return err
+		return nil, err
 	}
-	if contains(statuses, status) {
-		return nil
+	err = putMarshalledState(stub, "uninstallAssemblyFromParent", "maintenance", parentID, pstate)
+	if err != nil {
+		return nil, err
 	}
-	err := fmt.Errorf("validateStatus: status %s not in expected statuses %+v", status, statuses)
-	log.Error(err)
-	return err
+
+	err = recordSubtreeGenealogy(stub, assemblyID, "uninstall", parentID, aircraftID, txntime)
+	if err != nil {
+		return nil, err
+	}
+	return state, nil
 }
@@ -0,0 +1,187 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+// Inspection intervals
+// An ACHECK or BCHECK falls due on whichever of its limits is reached first:
+//    cycles   -- aCheckCounterAdjusted / bCheckCounterAdjusted, incremented per flight
+//    hours    -- aCheckHours / bCheckHours, accumulated from flight.flightHours
+//    days     -- calendar days since aCheckDate / bCheckDate, from the transaction timestamp
+//
+// The limits come from the dynamic contract config and can be overridden per assembly
+// type, keyed by the full ATA code (e.g. "32-50") or by the ATA chapter (e.g. "32").
+// A limit of zero is not checked. When the most used limit passes upcomingRatio, the
+// upcoming alert is raised as an early warning.
+
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// InspectionThresholds overrides the contract wide thresholds for an assembly type,
+// zero values fall back to the contract wide threshold
+type InspectionThresholds struct {
+	ACheckThreshold      float64 `json:"aCheckThreshold,omitempty"`
+	BCheckThreshold      float64 `json:"bCheckThreshold,omitempty"`
+	ACheckHoursThreshold float64 `json:"aCheckHoursThreshold,omitempty"`
+	BCheckHoursThreshold float64 `json:"bCheckHoursThreshold,omitempty"`
+	ACheckDaysThreshold  float64 `json:"aCheckDaysThreshold,omitempty"`
+	BCheckDaysThreshold  float64 `json:"bCheckDaysThreshold,omitempty"`
+}
+
+// inspectionInterval describes one kind of check and where its counters live in state
+type inspectionInterval struct {
+	name           string
+	alert          Alerts
+	upcomingAlert  Alerts
+	cyclesProperty string
+	hoursProperty  string
+	dateProperty   string
+	dueProperty    string
+}
+
+var aCheckInterval = inspectionInterval{"ACHECK", AlertsACHECK, AlertsACHECKUPCOMING, "aCheckCounterAdjusted", "aCheckHours", "aCheckDate", "aCheckDue"}
+var bCheckInterval = inspectionInterval{"BCHECK", AlertsBCHECK, AlertsBCHECKUPCOMING, "bCheckCounterAdjusted", "bCheckHours", "bCheckDate", "bCheckDue"}
+
+// limits returns the cycles, hours and days limits for the interval and assembly type
+func (config DynamicContractConfig) limits(interval inspectionInterval, ataCode string) (float64, float64, float64) {
+	var cycles, hours, days float64
+	if interval.name == "ACHECK" {
+		cycles, hours, days = config.ACheckThreshold, config.ACheckHoursThreshold, config.ACheckDaysThreshold
+	} else {
+		cycles, hours, days = config.BCheckThreshold, config.BCheckHoursThreshold, config.BCheckDaysThreshold
+	}
+	override, found := config.assemblyTypeThresholds(ataCode)
+	if !found {
+		return cycles, hours, days
+	}
+	var oc, oh, od float64
+	if interval.name == "ACHECK" {
+		oc, oh, od = override.ACheckThreshold, override.ACheckHoursThreshold, override.ACheckDaysThreshold
+	} else {
+		oc, oh, od = override.BCheckThreshold, override.BCheckHoursThreshold, override.BCheckDaysThreshold
+	}
+	if oc != 0 {
+		cycles = oc
+	}
+	if oh != 0 {
+		hours = oh
+	}
+	if od != 0 {
+		days = od
+	}
+	return cycles, hours, days
+}
+
+// assemblyTypeThresholds finds the override for the full ATA code first, then for its chapter
+func (config DynamicContractConfig) assemblyTypeThresholds(ataCode string) (InspectionThresholds, bool) {
+	if ataCode == "" || len(config.AssemblyTypeThresholds) == 0 {
+		return InspectionThresholds{}, false
+	}
+	if t, found := config.AssemblyTypeThresholds[ataCode]; found {
+		return t, true
+	}
+	chapter := strings.SplitN(ataCode, "-", 2)[0]
+	t, found := config.AssemblyTypeThresholds[chapter]
+	return t, found
+}
+
+// evaluateInterval computes how much of each limit is used, raises the check alert when the
+// first limit is reached and the upcoming alert inside the warning band. Only an inspection
+// clears the check alert. The calculation is written to the state under the due property.
+func (state *ArgsMap) evaluateInterval(interval inspectionInterval, config DynamicContractConfig, alerts *AlertStatusInternal, txntime time.Time) error {
+	ataCode, _ := getObjectAsString(*state, "assembly.ataCode")
+	cyclesLimit, hoursLimit, daysLimit := config.limits(interval, ataCode)
+
+	// the calendar starts on the first event that reaches the rules for this assembly
+	checkDate := txntime
+	ds, found := getObjectAsString(*state, interval.dateProperty)
+	if found {
+		d, err := time.Parse(time.RFC3339Nano, ds)
+		if err != nil {
+			err = fmt.Errorf("evaluateInterval: cannot parse %s %s: %s", interval.dateProperty, ds, err)
+			log.Error(err)
+			return err
+		}
+		checkDate = d
+	} else {
+		if _, ok := putObject(*state, interval.dateProperty, txntime.Format(time.RFC3339Nano)); !ok {
+			return fmt.Errorf("evaluateInterval: cannot put %s for state %+v", interval.dateProperty, state)
+		}
+	}
+
+	cycles, _ := getObjectAsNumber(*state, interval.cyclesProperty)
+	hours, _ := getObjectAsNumber(*state, interval.hoursProperty)
+	days := txntime.Sub(checkDate).Hours() / 24
+
+	due := map[string]interface{}{}
+	var ratio float64
+	var limit string
+	for _, l := range []struct {
+		name  string
+		used  float64
+		limit float64
+	}{
+		{"cycles", cycles, cyclesLimit},
+		{"hours", hours, hoursLimit},
+		{"days", days, daysLimit},
+	} {
+		if l.limit <= 0 {
+			continue
+		}
+		due[l.name+"Remaining"] = l.limit - l.used
+		if r := l.used / l.limit; limit == "" || r > ratio {
+			ratio = r
+			limit = l.name
+		}
+	}
+	due["limit"] = limit
+	due["ratio"] = ratio
+	if _, ok := putObject(*state, interval.dueProperty, due); !ok {
+		return fmt.Errorf("evaluateInterval: cannot put %s for state %+v", interval.dueProperty, state)
+	}
+
+	if limit == "" {
+		// no limits configured
+		return nil
+	}
+	if ratio >= 1 {
+		alerts.raiseAlert(interval.alert)
+		alerts.clearAlert(interval.upcomingAlert)
+	} else if config.UpcomingRatio > 0 && ratio >= config.UpcomingRatio {
+		alerts.raiseAlert(interval.upcomingAlert)
+	} else {
+		alerts.clearAlert(interval.upcomingAlert)
+	}
+	return nil
+}
+
+// resetInterval restarts the cycles, hours and calendar for the interval after an inspection
+func (state *ArgsMap) resetInterval(interval inspectionInterval, cyclesBaseProperty string, alerts *AlertStatusInternal, txntime time.Time) error {
+	for _, p := range []string{cyclesBaseProperty, interval.cyclesProperty, interval.hoursProperty} {
+		if _, ok := putObject(*state, p, float64(0)); !ok {
+			return fmt.Errorf("inspection rule: cannot put 0 to %s for state %+v", p, state)
+		}
+	}
+	if _, ok := putObject(*state, interval.dateProperty, txntime.Format(time.RFC3339Nano)); !ok {
+		return fmt.Errorf("inspection rule: cannot put %s for state %+v", interval.dateProperty, state)
+	}
+	alerts.clearAlert(interval.alert)
+	alerts.clearAlert(interval.upcomingAlert)
+	return nil
+}
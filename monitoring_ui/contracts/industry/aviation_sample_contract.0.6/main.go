@@ -96,6 +96,7 @@ import (
     "errors"
     "fmt"
     "github.com/hyperledger/fabric/core/chaincode/shim"
+    "github.com/ibm-watson-iot/blockchain-samples/contracts/platform/contractlogger"
     "strings"
 )
 
@@ -133,7 +134,7 @@ const TXNUUID string = "txnuuid"
 // ArgsMap is a generic map[string]interface{} to be used as a receiver
 type ArgsMap map[string]interface{}
 
-var log = NewContractLogger(DEFAULTNICKNAME, DEFAULTLOGGINGLEVEL)
+var log = contractlogger.NewContractLogger(DEFAULTNICKNAME+"-"+MYVERSION, contractlogger.DEFAULTLOGGINGLEVEL)
 
 // ************************************
 // start the message pumps
@@ -169,7 +170,7 @@ func (t *SimpleChaincode) Init(stub shim.ChaincodeStubInterface, function string
         stateArg.Nickname = DEFAULTNICKNAME
     }
 
-    (*log).setModule(stateArg.Nickname)
+    log.SetModule(stateArg.Nickname + "-" + MYVERSION)
 
     err = initializeContractState(stub, stateArg.Version, stateArg.Nickname)
     if err != nil {
@@ -181,17 +182,14 @@ func (t *SimpleChaincode) Init(stub shim.ChaincodeStubInterface, function string
         return nil, err
     }
 
-    err = initAircraftAssemblyIndexes(stub)
-    if err != nil {
-        return nil, err
-    }
-
     log.Info("Contract initialized")
     return nil, nil
 }
 
 // Invoke is called in invoke mode to delegate state changing function messages
 func (t *SimpleChaincode) Invoke(stub shim.ChaincodeStubInterface, function string, args []string) ([]byte, error) {
+    contractlogger.BeginTransaction(stub.GetTxID(), "invoke", function)
+    defer contractlogger.EndTransaction(stub.GetTxID())
     // asset CRUD API
     if function == "createAssetAirline" {
         return t.createAssetAirline(stub, args)
@@ -234,6 +232,10 @@ func (t *SimpleChaincode) Invoke(stub shim.ChaincodeStubInterface, function stri
     } else if function == "eventMaintenance" {
         return eventMaintenance(stub, args)
 
+        // airworthiness directive API
+    } else if function == "createDirective" {
+        return createDirective(stub, args)
+
         // contract dynamic config API
     } else if function == "updateContractConfig" {
         return nil, updateContractConfig(stub, args)
@@ -255,6 +257,8 @@ func (t *SimpleChaincode) Invoke(stub shim.ChaincodeStubInterface, function stri
 
 // Query is called in query mode to delegate non-state-changing queries
 func (t *SimpleChaincode) Query(stub shim.ChaincodeStubInterface, function string, args []string) ([]byte, error) {
+    contractlogger.BeginTransaction(stub.GetTxID(), "query", function)
+    defer contractlogger.EndTransaction(stub.GetTxID())
     // asset CRUD API
     if function == "readAssetAirline" {
         return t.readAssetAirline(stub, args)
@@ -276,6 +280,12 @@ func (t *SimpleChaincode) Query(stub shim.ChaincodeStubInterface, function strin
         return t.readAssetAssemblyHistory(stub, args)
     } else if function == "readAssetAircraftComplete" {
         return t.readAssetAircraftComplete(stub, args)
+    } else if function == "readAssemblyLifecycle" {
+        return readAssemblyLifecycle(stub, args)
+    } else if function == "readAssetGenealogy" {
+        return readAssetGenealogy(stub, args)
+    } else if function == "readDirectiveCompliance" {
+        return readDirectiveCompliance(stub, args)
 
         // contract dynamic config API
     } else if function == "readContractConfig" {
@@ -368,7 +378,9 @@ func (t *SimpleChaincode) readContractObjectModel(stub shim.ChaincodeStubInterfa
 // ************************************
 func (t *SimpleChaincode) setLoggingLevel(stub shim.ChaincodeStubInterface, args []string) error {
     type LogLevelArg struct {
-        Level string `json:"logLevel"`
+        Level  string   `json:"logLevel"`
+        Module string   `json:"module"`
+        Redact []string `json:"redact"`
     }
     var level LogLevelArg
     var err error
@@ -383,9 +395,16 @@ func (t *SimpleChaincode) setLoggingLevel(stub shim.ChaincodeStubInterface, args
         log.Error(err)
         return err
     }
-    for i, lev := range logLevelNames {
+    for i, lev := range contractlogger.LogLevelNames {
         if strings.ToUpper(level.Level) == lev {
-            (*log).SetLoggingLevel(LogLevel(i))
+            if level.Module != "" {
+                contractlogger.SetModuleLoggingLevel(level.Module, contractlogger.LogLevel(i))
+            } else {
+                log.SetLoggingLevel(contractlogger.LogLevel(i))
+            }
+            if level.Redact != nil {
+                contractlogger.SetRedactedPaths(level.Redact)
+            }
             return nil
         }
     }
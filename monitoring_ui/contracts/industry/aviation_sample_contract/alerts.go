@@ -22,6 +22,7 @@ Kim Letkeman - Initial Contribution
 // KL 28 Jun 2016 Remove OVERTEMP and add ACHECK and BCHECK
 // v4.4 Aviation
 // KL 29 Aug 2016 Add HARDLANDING alert and inspection action
+//                Add AD_OPEN alert for airworthiness directives
 // ************************************
 
 package main
@@ -38,9 +39,15 @@ const (
 	AlertsBCHECK Alerts = 1
 	// AlertsHARDLANDING hard landing inspection alert
 	AlertsHARDLANDING Alerts = 2
+	// AlertsACHECKUPCOMING short interval inspection is nearly due
+	AlertsACHECKUPCOMING Alerts = 3
+	// AlertsBCHECKUPCOMING long interval inspection is nearly due
+	AlertsBCHECKUPCOMING Alerts = 4
+	// AlertsADOPEN an airworthiness directive is open on the assembly
+	AlertsADOPEN Alerts = 5
 
 	// AlertsSIZE is to be maintained always as 1 greater than the last alert, giving a size
-	AlertsSIZE Alerts = 3
+	AlertsSIZE Alerts = 6
 )
 
 // AlertsName is a map of ID to name
@@ -48,13 +55,19 @@ var AlertsName = map[int]string{
 	0: "ACHECK",
 	1: "BCHECK",
 	2: "HARDLANDING",
+	3: "ACHECKUPCOMING",
+	4: "BCHECKUPCOMING",
+	5: "AD_OPEN",
 }
 
 // AlertsValue is a map of name to ID
 var AlertsValue = map[string]int32{
-	"ACHECK":      0,
-	"BCHECK":      1,
-	"HARDLANDING": 2,
+	"ACHECK":         0,
+	"BCHECK":         1,
+	"HARDLANDING":    2,
+	"ACHECKUPCOMING": 3,
+	"BCHECKUPCOMING": 4,
+	"AD_OPEN":        5,
 }
 
 func (x Alerts) String() string {
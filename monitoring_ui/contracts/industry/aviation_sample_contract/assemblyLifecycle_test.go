@@ -0,0 +1,88 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAssemblyLifecycle(t *testing.T) {
+	var lc = assemblyLifecycle
+
+	// the happy path through the lifecycle
+	var steps = []struct {
+		action string
+		target string
+		to     string
+	}{
+		{"commission", "", AssemblyStatusInventory},
+		{"install", "aircraft 5678", AssemblyStatusAircraft},
+		{"uninstall", "aircraft 5678", AssemblyStatusInventory},
+		{"startMaintenance", "", AssemblyStatusMaintenance},
+		{"endMaintenance", "", AssemblyStatusInventory},
+		{"scrap", "", AssemblyStatusScrapped},
+	}
+	var status = AssemblyStatusNew
+	for _, s := range steps {
+		tr, err := lc.validateTransition("abc", status, s.action, s.target, "")
+		if err != nil {
+			t.Fatalf("%s from %s should be allowed: %s", s.action, status, err)
+		}
+		if tr.To != s.to {
+			t.Fatalf("%s from %s should go to %s but went to %s", s.action, status, s.to, tr.To)
+		}
+		status = tr.To
+	}
+
+	// scrapped is terminal
+	for _, action := range []string{"commission", "install", "uninstall", "startMaintenance", "endMaintenance", "scrap"} {
+		_, err := lc.validateTransition("abc", AssemblyStatusScrapped, action, "", "")
+		if err == nil {
+			t.Fatalf("%s from scrapped should be rejected", action)
+		}
+	}
+
+	// rejected transition reports status, allowed actions and aircraft
+	_, err := lc.validateTransition("abc", AssemblyStatusAircraft, "startMaintenance", "", "aircraft 5678")
+	if err == nil {
+		t.Fatal("startMaintenance while installed should be rejected")
+	}
+	for _, want := range []string{"status aircraft", "[uninstall comply]", "aircraft 5678"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("error %q should contain %q", err.Error(), want)
+		}
+	}
+
+	// comply leaves an installed assembly where it is
+	if tr, err := lc.validateTransition("abc", AssemblyStatusAircraft, "comply", "", "aircraft 5678"); err != nil || tr.To != AssemblyStatusAircraft {
+		t.Fatalf("comply while installed should stay installed, got %+v, %v", tr, err)
+	}
+
+	// aircraft preconditions
+	if _, err := lc.validateTransition("abc", AssemblyStatusInventory, "install", "", ""); err == nil {
+		t.Fatal("install without aircraft should be rejected")
+	}
+	if _, err := lc.validateTransition("abc", AssemblyStatusInventory, "startMaintenance", "assembly ENG1", ""); err == nil {
+		t.Fatal("startMaintenance with a parent assembly should be rejected")
+	}
+
+	// missing status means new
+	if getAssemblyStatus(map[string]interface{}{}) != AssemblyStatusNew {
+		t.Fatal("assembly without status should be new")
+	}
+}
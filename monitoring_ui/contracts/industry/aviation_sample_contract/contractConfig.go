@@ -43,11 +43,27 @@ var schema = initSchema()
 // DynamicContractConfig is a struct that holds contract configurations that can be
 // via application.
 type DynamicContractConfig struct {
-	ACheckThreshold float64 `json:"aCheckThreshold"`
-	BCheckThreshold float64 `json:"bCheckThreshold"`
+	ACheckThreshold      float64 `json:"aCheckThreshold"`
+	BCheckThreshold      float64 `json:"bCheckThreshold"`
+	ACheckHoursThreshold float64 `json:"aCheckHoursThreshold"`
+	BCheckHoursThreshold float64 `json:"bCheckHoursThreshold"`
+	ACheckDaysThreshold  float64 `json:"aCheckDaysThreshold"`
+	BCheckDaysThreshold  float64 `json:"bCheckDaysThreshold"`
+	// fraction of the first limit reached at which the upcoming alerts are raised
+	UpcomingRatio float64 `json:"upcomingRatio"`
+	// per assembly type overrides keyed by ATA code or ATA chapter
+	AssemblyTypeThresholds map[string]InspectionThresholds `json:"assemblyTypeThresholds,omitempty"`
 }
 
-var defaultDynamicConfig = DynamicContractConfig{2, 4}
+var defaultDynamicConfig = DynamicContractConfig{
+	ACheckThreshold:      2,
+	BCheckThreshold:      4,
+	ACheckHoursThreshold: 500,
+	BCheckHoursThreshold: 1500,
+	ACheckDaysThreshold:  60,
+	BCheckDaysThreshold:  180,
+	UpcomingRatio:        0.9,
+}
 
 // Translation table for event names and prefixes. Includes isAsset property for
 // convenience and performance.
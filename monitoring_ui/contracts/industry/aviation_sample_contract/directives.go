@@ -0,0 +1,439 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+// Airworthiness directives
+// A directive is registered with createDirective and targets assemblies by any
+// combination of:
+//    ataCode       -- the assembly type, a chapter such as "32" also covers "32-50"
+//    manufacturer  -- the assembly manufacturer
+//    serialFrom / serialTo -- an inclusive range of serial numbers, serials that share
+//                             a prefix are ordered by their numeric suffix, so S99 < S100
+//
+// Every assembly that matches tracks the directive under directives.<directiveID>
+// in its state and carries the AD_OPEN alert until an eventMaintenance names the
+// directive in completesDirective, an installed assembly uses the comply action for
+// this so that it is not removed from the aircraft. Assemblies created after the
+// directive pick it up on creation. Scrapped assemblies are not tracked.
+//
+// readDirectiveCompliance reports open and closed assemblies per aircraft.
+//
+// Directives are stored as one world state key each:
+//    AirworthinessDirective (directiveID)
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DIRECTIVE is the world state object type for airworthiness directives
+const DIRECTIVE = "AirworthinessDirective"
+
+// directive compliance statuses
+const (
+	DirectiveStatusOpen   = "open"
+	DirectiveStatusClosed = "closed"
+)
+
+// Directive is an airworthiness directive, at least one of the targeting properties is required
+type Directive struct {
+	DirectiveID  string `json:"directiveID"`
+	Title        string `json:"title,omitempty"`
+	Description  string `json:"description,omitempty"`
+	ATACode      string `json:"ataCode,omitempty"`
+	Manufacturer string `json:"manufacturer,omitempty"`
+	SerialFrom   string `json:"serialFrom,omitempty"`
+	SerialTo     string `json:"serialTo,omitempty"`
+	// compliance deadline, RFC3339
+	Deadline string `json:"deadline"`
+	Issued   string `json:"issued"`
+	TxnUUID  string `json:"txnuuid"`
+}
+
+// AircraftDirectiveCompliance lists the matching assemblies on one aircraft, IDs are external
+type AircraftDirectiveCompliance struct {
+	Aircraft string   `json:"aircraft,omitempty"`
+	Status   string   `json:"status"`
+	Open     []string `json:"open"`
+	Closed   []string `json:"closed"`
+}
+
+// DirectiveCompliance is returned by readDirectiveCompliance for each directive
+type DirectiveCompliance struct {
+	Directive Directive                     `json:"directive"`
+	Status    string                        `json:"status"`
+	Aircraft  []AircraftDirectiveCompliance `json:"aircraft"`
+	// matching assemblies that are not on an aircraft
+	NotInstalled AircraftDirectiveCompliance `json:"notInstalled"`
+}
+
+// validate checks the directive as submitted to createDirective
+func (d Directive) validate() error {
+	if d.DirectiveID == "" {
+		return errors.New("directiveID is required")
+	}
+	if strings.ContainsAny(d.DirectiveID, "."+compositeKeySeparator) {
+		return fmt.Errorf("directiveID %s must not contain '.'", d.DirectiveID)
+	}
+	if d.ATACode == "" && d.Manufacturer == "" && d.SerialFrom == "" && d.SerialTo == "" {
+		return fmt.Errorf("directive %s must target an ataCode, manufacturer or serial range", d.DirectiveID)
+	}
+	if d.SerialFrom != "" && d.SerialTo != "" && compareSerials(d.SerialFrom, d.SerialTo) > 0 {
+		return fmt.Errorf("directive %s serialFrom %s is after serialTo %s", d.DirectiveID, d.SerialFrom, d.SerialTo)
+	}
+	if _, err := time.Parse(time.RFC3339Nano, d.Deadline); err != nil {
+		return fmt.Errorf("directive %s deadline %s is not an RFC3339 timestamp: %s", d.DirectiveID, d.Deadline, err)
+	}
+	return nil
+}
+
+// appliesTo matches the directive against an assembly state, every targeting property that
+// is set must match
+func (d Directive) appliesTo(state interface{}) bool {
+	if _, found := getObject(state, "assembly"); !found {
+		return false
+	}
+	if d.ATACode != "" {
+		ataCode, _ := getObjectAsString(state, "assembly.ataCode")
+		if ataCode != d.ATACode && !strings.HasPrefix(ataCode, d.ATACode+"-") {
+			return false
+		}
+	}
+	if d.Manufacturer != "" {
+		manufacturer, _ := getObjectAsString(state, "assembly.manufacturer")
+		if !strings.EqualFold(manufacturer, d.Manufacturer) {
+			return false
+		}
+	}
+	if d.SerialFrom != "" || d.SerialTo != "" {
+		serial, found := getObjectAsString(state, "assembly.serialNumber")
+		if !found {
+			serial, _ = getObjectAsString(state, "common.assetID")
+		}
+		if serial == "" || (d.SerialFrom != "" && compareSerials(serial, d.SerialFrom) < 0) || (d.SerialTo != "" && compareSerials(serial, d.SerialTo) > 0) {
+			return false
+		}
+	}
+	return true
+}
+
+// compareSerials orders serial numbers, returning -1, 0 or 1. Serials with the same
+// non-numeric prefix compare by the value of their numeric suffix, anything else
+// compares as strings.
+func compareSerials(a string, b string) int {
+	aprefix, adigits := splitSerial(a)
+	bprefix, bdigits := splitSerial(b)
+	if aprefix == bprefix && adigits != "" && bdigits != "" {
+		// compare without parsing so that long suffixes cannot overflow
+		adigits = strings.TrimLeft(adigits, "0")
+		bdigits = strings.TrimLeft(bdigits, "0")
+		if len(adigits) != len(bdigits) {
+			if len(adigits) < len(bdigits) {
+				return -1
+			}
+			return 1
+		}
+		a, b = adigits, bdigits
+	}
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// splitSerial splits a serial number into its prefix and trailing digits
+func splitSerial(serial string) (string, string) {
+	i := len(serial)
+	for i > 0 && serial[i-1] >= '0' && serial[i-1] <= '9' {
+		i--
+	}
+	return serial[:i], serial[i:]
+}
+
+func getDirective(stub *shim.ChaincodeStub, directiveID string) (Directive, bool, error) {
+	var d Directive
+	dbytes, err := stub.GetState(createCompositeKey(DIRECTIVE, []string{directiveID}))
+	if err != nil {
+		err = fmt.Errorf("getDirective failed to get directive %s: %s", directiveID, err)
+		log.Error(err)
+		return d, false, err
+	}
+	if len(dbytes) == 0 {
+		return d, false, nil
+	}
+	err = json.Unmarshal(dbytes, &d)
+	if err != nil {
+		err = fmt.Errorf("getDirective failed to unmarshal directive %s: %s", directiveID, err)
+		log.Error(err)
+		return d, false, err
+	}
+	return d, true, nil
+}
+
+// getDirectivesFromLedger returns all directives in directiveID order
+func getDirectivesFromLedger(stub *shim.ChaincodeStub) ([]Directive, error) {
+	values, err := rangeCompositeKey(stubLedger{stub}, DIRECTIVE, []string{})
+	if err != nil {
+		return nil, err
+	}
+	var directives = make([]Directive, 0, len(values))
+	for _, v := range values {
+		var d Directive
+		err = json.Unmarshal(v, &d)
+		if err != nil {
+			err = fmt.Errorf("getDirectivesFromLedger failed to unmarshal directive: %s", err)
+			log.Error(err)
+			return nil, err
+		}
+		directives = append(directives, d)
+	}
+	return directives, nil
+}
+
+// getAssemblyStates returns the internal IDs and states of all assemblies in key order
+func getAssemblyStates(stub *shim.ChaincodeStub, caller string) ([]string, []interface{}, error) {
+	prefix, err := eventNameToAssetPrefix("assembly")
+	if err != nil {
+		return nil, nil, err
+	}
+	iter, err := stub.RangeQueryState(prefix, prefix+"}")
+	if err != nil {
+		err = fmt.Errorf("%s failed to get a range query iterator: %s", caller, err)
+		log.Error(err)
+		return nil, nil, err
+	}
+	defer iter.Close()
+	var ids = make([]string, 0)
+	var states = make([]interface{}, 0)
+	for iter.HasNext() {
+		assetID, assetBytes, err := iter.Next()
+		if err != nil {
+			err = fmt.Errorf("%s iter.Next() failed: %s", caller, err)
+			log.Error(err)
+			return nil, nil, err
+		}
+		var state interface{}
+		err = json.Unmarshal(assetBytes, &state)
+		if err != nil {
+			err = fmt.Errorf("%s unmarshal failed for %s: %s", caller, assetID, err)
+			log.Error(err)
+			return nil, nil, err
+		}
+		ids = append(ids, assetID)
+		states = append(states, state)
+	}
+	return ids, states, nil
+}
+
+// ************************************
+// createDirective
+// ************************************
+func createDirective(stub *shim.ChaincodeStub, args []string) ([]byte, error) {
+	event, err := getUnmarshalledArgument(stub, "createDirective", args)
+	if err != nil {
+		return nil, err
+	}
+	var arg struct {
+		Directive Directive `json:"directive"`
+	}
+	err = json.Unmarshal([]byte(args[0]), &arg)
+	if err != nil {
+		err = fmt.Errorf("createDirective failed to unmarshal directive: %s", err)
+		log.Error(err)
+		return nil, err
+	}
+	directive := arg.Directive
+	err = directive.validate()
+	if err != nil {
+		err = fmt.Errorf("createDirective: %s", err)
+		log.Error(err)
+		return nil, err
+	}
+	_, found, err := getDirective(stub, directive.DirectiveID)
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		err = fmt.Errorf("createDirective: directive %s already exists", directive.DirectiveID)
+		log.Error(err)
+		return nil, err
+	}
+
+	txntime, err := getTxnTime(stub)
+	if err != nil {
+		return nil, err
+	}
+	directive.Issued = txntime.Format(time.RFC3339Nano)
+	directive.TxnUUID = stub.UUID
+	dbytes, err := json.Marshal(&directive)
+	if err != nil {
+		err = fmt.Errorf("createDirective failed to marshal directive %s: %s", directive.DirectiveID, err)
+		log.Error(err)
+		return nil, err
+	}
+	err = stub.PutState(createCompositeKey(DIRECTIVE, []string{directive.DirectiveID}), dbytes)
+	if err != nil {
+		err = fmt.Errorf("createDirective failed to put directive %s: %s", directive.DirectiveID, err)
+		log.Error(err)
+		return nil, err
+	}
+
+	// run the rules on every matching assembly so that the directive opens immediately
+	ids, states, err := getAssemblyStates(stub, "createDirective")
+	if err != nil {
+		return nil, err
+	}
+	for i, state := range states {
+		if !directive.appliesTo(state) || getAssemblyStatus(state) == AssemblyStatusScrapped {
+			continue
+		}
+		state, err = addTXNTimestampToState(stub, "createDirective", state)
+		if err != nil {
+			return nil, err
+		}
+		state = addLastEventToState(stub, "createDirective", event, state, "")
+		state, err = handleAlertsAndRules(stub, "createDirective", "directive", ids[i], event, state)
+		if err != nil {
+			return nil, err
+		}
+		err = putMarshalledState(stub, "createDirective", "directive", ids[i], state)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+// ************************************
+// readDirectiveCompliance
+// ************************************
+// Accepts no arguments for all directives, or a directiveID property for one
+func readDirectiveCompliance(stub *shim.ChaincodeStub, args []string) ([]byte, error) {
+	var directives []Directive
+	var err error
+	if len(args) == 0 {
+		directives, err = getDirectivesFromLedger(stub)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		argsMap, err := getUnmarshalledArgument(stub, "readDirectiveCompliance", args)
+		if err != nil {
+			return nil, err
+		}
+		directiveID, found := getObjectAsString(argsMap, "directiveID")
+		if !found {
+			err = errors.New("readDirectiveCompliance: directiveID property missing from argument")
+			log.Error(err)
+			return nil, err
+		}
+		d, found, err := getDirective(stub, directiveID)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			err = fmt.Errorf("readDirectiveCompliance: directive %s does not exist", directiveID)
+			log.Error(err)
+			return nil, err
+		}
+		directives = []Directive{d}
+	}
+
+	ids, states, err := getAssemblyStates(stub, "readDirectiveCompliance")
+	if err != nil {
+		return nil, err
+	}
+
+	var results = make([]DirectiveCompliance, 0, len(directives))
+	for _, d := range directives {
+		var byAircraft = make(map[string]*AircraftDirectiveCompliance)
+		var result = DirectiveCompliance{
+			Directive:    d,
+			Status:       DirectiveStatusClosed,
+			Aircraft:     make([]AircraftDirectiveCompliance, 0),
+			NotInstalled: newAircraftDirectiveCompliance(""),
+		}
+		for i, state := range states {
+			if !d.appliesTo(state) || getAssemblyStatus(state) == AssemblyStatusScrapped {
+				continue
+			}
+			assemblyID, err := assetIDToExternal(ids[i])
+			if err != nil {
+				return nil, err
+			}
+			entry := &result.NotInstalled
+			aircraftID, found, err := getPartAircraft(stubLedger{stub}, ids[i])
+			if err != nil {
+				return nil, err
+			}
+			if found {
+				aircraftID, err = assetIDToExternal(aircraftID)
+				if err != nil {
+					return nil, err
+				}
+				if _, found := byAircraft[aircraftID]; !found {
+					a := newAircraftDirectiveCompliance(aircraftID)
+					byAircraft[aircraftID] = &a
+				}
+				entry = byAircraft[aircraftID]
+			}
+			// an assembly that the rules have not yet seen is still open
+			status, _ := getObjectAsString(state, "directives."+d.DirectiveID+".status")
+			if status == DirectiveStatusClosed {
+				entry.Closed = append(entry.Closed, assemblyID)
+			} else {
+				entry.Open = append(entry.Open, assemblyID)
+				entry.Status = DirectiveStatusOpen
+				result.Status = DirectiveStatusOpen
+			}
+		}
+		var aircraft = make([]string, 0, len(byAircraft))
+		for a := range byAircraft {
+			aircraft = append(aircraft, a)
+		}
+		sort.Strings(aircraft)
+		for _, a := range aircraft {
+			result.Aircraft = append(result.Aircraft, *byAircraft[a])
+		}
+		results = append(results, result)
+	}
+
+	resultsBytes, err := json.Marshal(&results)
+	if err != nil {
+		err = errors.New("readDirectiveCompliance failed to marshal results: " + err.Error())
+		log.Error(err)
+		return nil, err
+	}
+	return resultsBytes, nil
+}
+
+func newAircraftDirectiveCompliance(aircraftID string) AircraftDirectiveCompliance {
+	return AircraftDirectiveCompliance{
+		Aircraft: aircraftID,
+		Status:   DirectiveStatusClosed,
+		Open:     make([]string, 0),
+		Closed:   make([]string, 0),
+	}
+}
@@ -0,0 +1,139 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDirectives(t *testing.T) {
+	var directives = []Directive{
+		{DirectiveID: "AD-32", ATACode: "32", SerialFrom: "S100", SerialTo: "S199", Deadline: "2017-01-01T00:00:00Z"},
+		{DirectiveID: "AD-ACME", Manufacturer: "acme", Deadline: "2017-01-01T00:00:00Z"},
+	}
+	for _, d := range directives {
+		if err := d.validate(); err != nil {
+			t.Fatalf("directive %s failed validation: %s", d.DirectiveID, err)
+		}
+	}
+	if err := (Directive{DirectiveID: "AD-X", Deadline: "2017-01-01T00:00:00Z"}).validate(); err == nil {
+		t.Fatal("directive without targeting passed validation")
+	}
+	if err := (Directive{DirectiveID: "AD-X", SerialFrom: "S100", SerialTo: "S99", Deadline: "2017-01-01T00:00:00Z"}).validate(); err == nil {
+		t.Fatal("directive with serialFrom S100 after serialTo S99 passed validation")
+	}
+
+	// serials with a shared prefix are ordered by their numeric suffix
+	for _, tt := range []struct {
+		serial  string
+		applies bool
+	}{{"S99", false}, {"S100", true}, {"S0150", true}, {"S199", true}, {"S200", false}, {"S1000", false}, {"T150", false}} {
+		var state = ArgsMap{"assembly": map[string]interface{}{"ataCode": "32", "serialNumber": tt.serial}}
+		if directives[0].appliesTo(state) != tt.applies {
+			t.Errorf("AD-32 for S100..S199 applies to %s should be %v", tt.serial, tt.applies)
+		}
+	}
+	var now = time.Date(2016, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	// steering is in chapter 32 and the serial range, but not made by acme
+	var state = ArgsMap{
+		"assembly": map[string]interface{}{"ataCode": "32-50", "serialNumber": "S150", "manufacturer": "Other"},
+	}
+	if !directives[0].appliesTo(state) || directives[1].appliesTo(state) {
+		t.Fatalf("directives matched incorrectly against %+v", state)
+	}
+	var alerts AlertStatusInternal
+	if err := state.directivesRule(directives, &alerts, ArgsMap{}, now); err != nil {
+		t.Fatalf("directivesRule failed: %s", err)
+	}
+	if !alerts.Active[AlertsADOPEN] {
+		t.Fatal("AD_OPEN not raised for open directive")
+	}
+	if s, _ := getObjectAsString(state, "directives.AD-32.status"); s != DirectiveStatusOpen {
+		t.Fatalf("expected directive AD-32 open, got %s", s)
+	}
+
+	// a directive that does not apply cannot be completed
+	var event = ArgsMap{"maintenance": map[string]interface{}{"completesDirective": "AD-ACME"}}
+	if err := state.directivesRule(directives, &alerts, event, now); err == nil {
+		t.Fatal("completed a directive that does not apply")
+	}
+
+	event = ArgsMap{"maintenance": map[string]interface{}{"completesDirective": "AD-32"}}
+	if err := state.directivesRule(directives, &alerts, event, now); err != nil {
+		t.Fatalf("directivesRule failed: %s", err)
+	}
+	if alerts.Active[AlertsADOPEN] {
+		t.Fatal("AD_OPEN still active after the directive was completed")
+	}
+	if s, _ := getObjectAsString(state, "directives.AD-32.status"); s != DirectiveStatusClosed {
+		t.Fatalf("expected directive AD-32 closed, got %s", s)
+	}
+}
+
+// TestDirectiveOnInstalledAssembly follows a comply event on an installed assembly through
+// the lifecycle, the directives rule and the part tree, as the 0.5 stub cannot run Invoke
+func TestDirectiveOnInstalledAssembly(t *testing.T) {
+	var directives = []Directive{
+		{DirectiveID: "AD-32", ATACode: "32", SerialFrom: "S100", SerialTo: "S199", Deadline: "2017-01-01T00:00:00Z"},
+	}
+	var now = time.Date(2016, 10, 1, 0, 0, 0, 0, time.UTC)
+	assemblyID, _ := assetIDToInternal("assembly", "S150")
+	aircraftID, _ := assetIDToInternal("aircraft", "1234")
+	ledger := newMapLedger("tx1")
+	if err := addPartEdge(ledger, aircraftID, assemblyID, now); err != nil {
+		t.Fatalf("addPartEdge failed: %s", err)
+	}
+	var state = ArgsMap{
+		"status":   AssemblyStatusAircraft,
+		"assembly": map[string]interface{}{"ataCode": "32-50", "serialNumber": "S150"},
+	}
+	var alerts AlertStatusInternal
+	if err := state.directivesRule(directives, &alerts, ArgsMap{}, now); err != nil {
+		t.Fatalf("directivesRule failed: %s", err)
+	}
+	if !alerts.Active[AlertsADOPEN] {
+		t.Fatal("AD_OPEN not raised for the installed assembly")
+	}
+
+	// an installed assembly only allows uninstall and comply, and comply leaves it installed
+	if _, err := assemblyLifecycle.validateTransition(assemblyID, getAssemblyStatus(state), "startMaintenance", "", "aircraft 1234"); err == nil {
+		t.Fatal("startMaintenance should be rejected while installed")
+	}
+	transition, err := assemblyLifecycle.validateTransition(assemblyID, getAssemblyStatus(state), "comply", "", "aircraft 1234")
+	if err != nil || transition.To != AssemblyStatusAircraft {
+		t.Fatalf("comply while installed should stay installed, got %+v, %v", transition, err)
+	}
+
+	var event = ArgsMap{"maintenance": map[string]interface{}{"assembly": "S150", "action": "comply", "completesDirective": "AD-32"}}
+	if err := state.directivesRule(directives, &alerts, event, now); err != nil {
+		t.Fatalf("directivesRule failed: %s", err)
+	}
+	if s, _ := getObjectAsString(state, "directives.AD-32.status"); s != DirectiveStatusClosed {
+		t.Fatalf("expected directive AD-32 closed, got %s", s)
+	}
+	if alerts.Active[AlertsADOPEN] {
+		t.Fatal("AD_OPEN still active after the directive was completed")
+	}
+	if getAssemblyStatus(state) != AssemblyStatusAircraft {
+		t.Fatalf("assembly should still be installed, status is %s", getAssemblyStatus(state))
+	}
+	if aircraft, found, _ := getPartAircraft(ledger, assemblyID); !found || aircraft != aircraftID {
+		t.Fatalf("assembly should still be on aircraft %s, found %s", aircraftID, aircraft)
+	}
+}
@@ -27,6 +27,7 @@ package main
 import (
 	//"fmt"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/ibm-watson-iot/blockchain-samples/contracts/platform/contractlogger"
 )
 
 func eventInspection(stub *shim.ChaincodeStub, args []string) ([]byte, error) {
@@ -46,6 +47,7 @@ func handleAssemblyInspectionEvent(stub *shim.ChaincodeStub, event interface{})
 	if err != nil {
 		return nil, err
 	}
+	contractlogger.SetTransactionAsset(stub.UUID, assetID)
 
 	state, err := getUnmarshalledState(stub, "handleAssemblyInspectionEvent", assetID)
 	if err != nil {
@@ -0,0 +1,81 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestInspectionIntervals(t *testing.T) {
+	var config = defaultDynamicConfig
+	config.AssemblyTypeThresholds = map[string]InspectionThresholds{
+		"32": {ACheckThreshold: 100, ACheckHoursThreshold: 10},
+	}
+	var start = time.Date(2016, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	// landing gear uses the chapter 32 override, cycles far from the limit
+	var state = ArgsMap{
+		"assembly":              map[string]interface{}{"ataCode": "32-50"},
+		"aCheckCounterAdjusted": float64(1),
+		"aCheckHours":           float64(9.5),
+	}
+	var alerts AlertStatusInternal
+
+	// first evaluation starts the calendar, hours are inside the upcoming band
+	if err := state.evaluateInterval(aCheckInterval, config, &alerts, start); err != nil {
+		t.Fatal(err)
+	}
+	if _, found := getObjectAsString(state, "aCheckDate"); !found {
+		t.Fatal("aCheckDate should be set on first evaluation")
+	}
+	if !alerts.Active[AlertsACHECKUPCOMING] || alerts.Active[AlertsACHECK] {
+		t.Fatalf("expected ACHECKUPCOMING only, got %+v", alerts.Active)
+	}
+	if limit, _ := getObjectAsString(state, "aCheckDue.limit"); limit != "hours" {
+		t.Fatalf("expected hours to be the first limit, got %s", limit)
+	}
+
+	// calendar days limit reached before the hours limit
+	if err := state.evaluateInterval(aCheckInterval, config, &alerts, start.Add(61*24*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if !alerts.Active[AlertsACHECK] || alerts.Active[AlertsACHECKUPCOMING] {
+		t.Fatalf("expected ACHECK only, got %+v", alerts.Active)
+	}
+	if limit, _ := getObjectAsString(state, "aCheckDue.limit"); limit != "days" {
+		t.Fatalf("expected days to be the first limit, got %s", limit)
+	}
+
+	// inspection restarts the interval and clears both alerts
+	var now = start.Add(62 * 24 * time.Hour)
+	if err := state.resetInterval(aCheckInterval, "aCheckCounter", &alerts, now); err != nil {
+		t.Fatal(err)
+	}
+	if err := state.evaluateInterval(aCheckInterval, config, &alerts, now); err != nil {
+		t.Fatal(err)
+	}
+	if !alerts.NoAlertsActive() {
+		t.Fatalf("expected no active alerts after inspection, got %+v", alerts.Active)
+	}
+
+	// assemblies without an override use the contract thresholds
+	cycles, hours, days := config.limits(bCheckInterval, "21")
+	if cycles != config.BCheckThreshold || hours != config.BCheckHoursThreshold || days != config.BCheckDaysThreshold {
+		t.Fatalf("expected contract thresholds, got %f %f %f", cycles, hours, days)
+	}
+}
@@ -0,0 +1,487 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+// Part genealogy
+// Aircraft and assemblies form one tree. Assemblies are installed onto an aircraft, which
+// is always the root, or into other assemblies (engine -> module -> part) to any depth.
+// Every level is stored as one world state key per edge so that an install or uninstall
+// only touches the edges involved:
+//    PartChild     (parent, child)             edge, ranged to find the children of a part
+//    PartParent    (child)                     edge, the one parent of a part
+//    PartGenealogy (part, timestamp, txnuuid)  installation history of a part
+//
+// An install or uninstall that moves a part on or off an aircraft also records a
+// genealogy entry for every part below it, so the history of any part covers every
+// aircraft it has flown on.
+//
+// Fabric 0.6 has no composite key support, so keys are built in the same shape as
+// the later shim: objectType and attributes, each terminated by U+0000.
+//
+// The 0.5 stub is a struct that only works against a peer, so the tree reads and writes
+// the world state through partLedger. stubLedger is the ledger of a transaction, and the
+// tests back the interface with a map.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"strings"
+	"time"
+)
+
+// world state object types for the part tree
+const (
+	PARTCHILDEDGE  = "PartChild"
+	PARTPARENTEDGE = "PartParent"
+	PARTGENEALOGY  = "PartGenealogy"
+)
+
+const compositeKeySeparator = "\x00"
+const compositeKeyMaxRune = "\U0010FFFF"
+
+// PartEdge connects a parent assembly to a child assembly, IDs are internal
+type PartEdge struct {
+	Parent    string `json:"parent"`
+	Child     string `json:"child"`
+	Timestamp string `json:"timestamp"`
+	TxnUUID   string `json:"txnuuid"`
+}
+
+// GenealogyRecord is one entry in the installation history of a part, IDs are external
+type GenealogyRecord struct {
+	AssetID  string `json:"assetID"`
+	Action   string `json:"action"`
+	Parent   string `json:"parent,omitempty"`
+	Aircraft string `json:"aircraft,omitempty"`
+	// the ancestor that was installed or uninstalled, blank when it was this part
+	Via       string `json:"via,omitempty"`
+	Timestamp string `json:"timestamp"`
+	TxnUUID   string `json:"txnuuid"`
+}
+
+// GenealogyNode is a part and the parts installed into it
+type GenealogyNode struct {
+	AssetID  string          `json:"assetID"`
+	Children []GenealogyNode `json:"children"`
+}
+
+// AssetGenealogy is returned by readAssetGenealogy
+type AssetGenealogy struct {
+	AssetID  string `json:"assetID"`
+	Parent   string `json:"parent,omitempty"`
+	Aircraft string `json:"aircraft,omitempty"`
+	// ancestors from the top assembly down to the parent
+	Path []string `json:"path"`
+	// every aircraft the part has been installed on, in order of first installation
+	AircraftHistory []string          `json:"aircraftHistory"`
+	Children        []GenealogyNode   `json:"children"`
+	History         []GenealogyRecord `json:"history"`
+}
+
+// partLedger is the part of the stub that the part tree uses
+type partLedger interface {
+	GetState(key string) ([]byte, error)
+	PutState(key string, value []byte) error
+	DelState(key string) error
+	// rangeValues returns the values of the keys from startKey up to endKey, in key order
+	rangeValues(startKey string, endKey string) ([][]byte, error)
+	txnUUID() string
+}
+
+// stubLedger is the partLedger of the transaction that the stub runs
+type stubLedger struct {
+	*shim.ChaincodeStub
+}
+
+func (stub stubLedger) rangeValues(startKey string, endKey string) ([][]byte, error) {
+	iter, err := stub.RangeQueryState(startKey, endKey)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+	var results = make([][]byte, 0)
+	for iter.HasNext() {
+		_, valueBytes, err := iter.Next()
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, valueBytes)
+	}
+	return results, nil
+}
+
+func (stub stubLedger) txnUUID() string {
+	return stub.UUID
+}
+
+// createCompositeKey builds a key in the same layout as the fabric 1.x composite keys
+func createCompositeKey(objectType string, attributes []string) string {
+	key := compositeKeySeparator + objectType + compositeKeySeparator
+	for _, a := range attributes {
+		key += a + compositeKeySeparator
+	}
+	return key
+}
+
+// splitCompositeKey returns the object type and attributes of a composite key
+func splitCompositeKey(key string) (string, []string, error) {
+	if !strings.HasPrefix(key, compositeKeySeparator) || !strings.HasSuffix(key, compositeKeySeparator) {
+		err := fmt.Errorf("splitCompositeKey: %q is not a composite key", key)
+		log.Error(err)
+		return "", nil, err
+	}
+	parts := strings.Split(key[1:len(key)-1], compositeKeySeparator)
+	return parts[0], parts[1:], nil
+}
+
+// rangeCompositeKey returns the values of all keys that start with the partial key, in key order
+func rangeCompositeKey(ledger partLedger, objectType string, attributes []string) ([][]byte, error) {
+	prefix := createCompositeKey(objectType, attributes)
+	results, err := ledger.rangeValues(prefix, prefix+compositeKeyMaxRune)
+	if err != nil {
+		err = fmt.Errorf("rangeCompositeKey failed to range over %s: %s", objectType, err)
+		log.Error(err)
+		return nil, err
+	}
+	return results, nil
+}
+
+func getTxnTime(stub *shim.ChaincodeStub) (time.Time, error) {
+	txnunixtime, err := stub.GetTxTimestamp()
+	if err != nil {
+		err = fmt.Errorf("getTxnTime: error getting transaction timestamp: %s", err)
+		log.Error(err)
+		return time.Time{}, err
+	}
+	return time.Unix(txnunixtime.Seconds, int64(txnunixtime.Nanos)).UTC(), nil
+}
+
+// getPartParent returns the internal ID of the assembly the part is installed into
+func getPartParent(ledger partLedger, partID string) (string, bool, error) {
+	edgeBytes, err := ledger.GetState(createCompositeKey(PARTPARENTEDGE, []string{partID}))
+	if err != nil {
+		err = fmt.Errorf("getPartParent failed to get parent edge for %s: %s", partID, err)
+		log.Error(err)
+		return "", false, err
+	}
+	if len(edgeBytes) == 0 {
+		return "", false, nil
+	}
+	var edge PartEdge
+	err = json.Unmarshal(edgeBytes, &edge)
+	if err != nil {
+		err = fmt.Errorf("getPartParent failed to unmarshal parent edge for %s: %s", partID, err)
+		log.Error(err)
+		return "", false, err
+	}
+	return edge.Parent, true, nil
+}
+
+// getPartChildren returns the internal IDs of the parts installed directly into the part
+func getPartChildren(ledger partLedger, partID string) ([]string, error) {
+	edges, err := rangeCompositeKey(ledger, PARTCHILDEDGE, []string{partID})
+	if err != nil {
+		return nil, err
+	}
+	var children = make([]string, 0, len(edges))
+	for _, edgeBytes := range edges {
+		var edge PartEdge
+		err = json.Unmarshal(edgeBytes, &edge)
+		if err != nil {
+			err = fmt.Errorf("getPartChildren failed to unmarshal child edge for %s: %s", partID, err)
+			log.Error(err)
+			return nil, err
+		}
+		children = append(children, edge.Child)
+	}
+	return children, nil
+}
+
+// getPartDescendants returns every part below the part, depth first
+func getPartDescendants(ledger partLedger, partID string) ([]string, error) {
+	children, err := getPartChildren(ledger, partID)
+	if err != nil {
+		return nil, err
+	}
+	var descendants = make([]string, 0)
+	for _, child := range children {
+		descendants = append(descendants, child)
+		below, err := getPartDescendants(ledger, child)
+		if err != nil {
+			return nil, err
+		}
+		descendants = append(descendants, below...)
+	}
+	return descendants, nil
+}
+
+// getPartAncestors returns the parents of the part, nearest first
+func getPartAncestors(ledger partLedger, partID string) ([]string, error) {
+	var ancestors = make([]string, 0)
+	current := partID
+	for {
+		parent, found, err := getPartParent(ledger, current)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			return ancestors, nil
+		}
+		if parent == partID || contains(ancestors, parent) {
+			err = fmt.Errorf("getPartAncestors: part tree has a cycle at %s", parent)
+			log.Error(err)
+			return nil, err
+		}
+		ancestors = append(ancestors, parent)
+		current = parent
+	}
+}
+
+// isAircraftID is true when the internal ID is an aircraft, which can only be the root of a part tree
+func isAircraftID(id string) bool {
+	prefix, err := assetIDToInternal("aircraft", "")
+	return err == nil && strings.HasPrefix(id, prefix)
+}
+
+// getPartAircraft returns the internal ID of the aircraft the part flies on, directly or
+// through its top assembly
+func getPartAircraft(ledger partLedger, partID string) (string, bool, error) {
+	ancestors, err := getPartAncestors(ledger, partID)
+	if err != nil {
+		return "", false, err
+	}
+	if len(ancestors) == 0 || !isAircraftID(ancestors[len(ancestors)-1]) {
+		return "", false, nil
+	}
+	return ancestors[len(ancestors)-1], true, nil
+}
+
+func addPartEdge(ledger partLedger, parentID string, childID string, txntime time.Time) error {
+	edge := PartEdge{parentID, childID, txntime.Format(time.RFC3339Nano), ledger.txnUUID()}
+	edgeBytes, err := json.Marshal(&edge)
+	if err != nil {
+		err = fmt.Errorf("addPartEdge failed to marshal edge %s -> %s: %s", parentID, childID, err)
+		log.Error(err)
+		return err
+	}
+	err = ledger.PutState(createCompositeKey(PARTCHILDEDGE, []string{parentID, childID}), edgeBytes)
+	if err != nil {
+		err = fmt.Errorf("addPartEdge failed to put child edge %s -> %s: %s", parentID, childID, err)
+		log.Error(err)
+		return err
+	}
+	err = ledger.PutState(createCompositeKey(PARTPARENTEDGE, []string{childID}), edgeBytes)
+	if err != nil {
+		err = fmt.Errorf("addPartEdge failed to put parent edge %s -> %s: %s", parentID, childID, err)
+		log.Error(err)
+		return err
+	}
+	return nil
+}
+
+func removePartEdge(ledger partLedger, parentID string, childID string) error {
+	err := ledger.DelState(createCompositeKey(PARTCHILDEDGE, []string{parentID, childID}))
+	if err != nil {
+		err = fmt.Errorf("removePartEdge failed to delete child edge %s -> %s: %s", parentID, childID, err)
+		log.Error(err)
+		return err
+	}
+	err = ledger.DelState(createCompositeKey(PARTPARENTEDGE, []string{childID}))
+	if err != nil {
+		err = fmt.Errorf("removePartEdge failed to delete parent edge %s -> %s: %s", parentID, childID, err)
+		log.Error(err)
+		return err
+	}
+	return nil
+}
+
+// recordGenealogy appends an installation history entry for the part, IDs are internal
+func recordGenealogy(ledger partLedger, partID string, action string, parentID string, aircraftID string, viaID string, txntime time.Time) error {
+	var err error
+	record := GenealogyRecord{Action: action, Timestamp: txntime.Format(time.RFC3339Nano), TxnUUID: ledger.txnUUID()}
+	for _, id := range []struct {
+		internal string
+		external *string
+	}{
+		{partID, &record.AssetID},
+		{parentID, &record.Parent},
+		{aircraftID, &record.Aircraft},
+		{viaID, &record.Via},
+	} {
+		if id.internal == "" {
+			continue
+		}
+		*id.external, err = assetIDToExternal(id.internal)
+		if err != nil {
+			return err
+		}
+	}
+	recordBytes, err := json.Marshal(&record)
+	if err != nil {
+		err = fmt.Errorf("recordGenealogy failed to marshal record for %s: %s", partID, err)
+		log.Error(err)
+		return err
+	}
+	// zero padded nanoseconds keep the history in time order
+	key := createCompositeKey(PARTGENEALOGY, []string{partID, fmt.Sprintf("%020d", txntime.UnixNano()), record.TxnUUID})
+	err = ledger.PutState(key, recordBytes)
+	if err != nil {
+		err = fmt.Errorf("recordGenealogy failed to put record for %s: %s", partID, err)
+		log.Error(err)
+		return err
+	}
+	return nil
+}
+
+// recordSubtreeGenealogy records the install or uninstall of a part and, when the part moves
+// on or off an aircraft, the same for every part below it
+func recordSubtreeGenealogy(ledger partLedger, partID string, action string, parentID string, aircraftID string, txntime time.Time) error {
+	err := recordGenealogy(ledger, partID, action, parentID, aircraftID, "", txntime)
+	if err != nil {
+		return err
+	}
+	if aircraftID == "" {
+		return nil
+	}
+	descendants, err := getPartDescendants(ledger, partID)
+	if err != nil {
+		return err
+	}
+	for _, d := range descendants {
+		dparent, _, err := getPartParent(ledger, d)
+		if err != nil {
+			return err
+		}
+		err = recordGenealogy(ledger, d, action, dparent, aircraftID, partID, txntime)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readGenealogyHistory(ledger partLedger, partID string) ([]GenealogyRecord, error) {
+	recordsBytes, err := rangeCompositeKey(ledger, PARTGENEALOGY, []string{partID})
+	if err != nil {
+		return nil, err
+	}
+	var records = make([]GenealogyRecord, 0, len(recordsBytes))
+	for _, recordBytes := range recordsBytes {
+		var record GenealogyRecord
+		err = json.Unmarshal(recordBytes, &record)
+		if err != nil {
+			err = fmt.Errorf("readGenealogyHistory failed to unmarshal record for %s: %s", partID, err)
+			log.Error(err)
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func readGenealogyChildren(ledger partLedger, partID string) ([]GenealogyNode, error) {
+	children, err := getPartChildren(ledger, partID)
+	if err != nil {
+		return nil, err
+	}
+	var nodes = make([]GenealogyNode, 0, len(children))
+	for _, child := range children {
+		below, err := readGenealogyChildren(ledger, child)
+		if err != nil {
+			return nil, err
+		}
+		childID, err := assetIDToExternal(child)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, GenealogyNode{childID, below})
+	}
+	return nodes, nil
+}
+
+// ************************************
+// readAssetGenealogy
+// ************************************
+func readAssetGenealogy(stub *shim.ChaincodeStub, args []string) ([]byte, error) {
+	argsMap, err := getUnmarshalledArgument(stub, "readAssetGenealogy", args)
+	if err != nil {
+		return nil, err
+	}
+	partID, err := validateAssetID("readAssetGenealogy", "assembly", argsMap)
+	if err != nil {
+		return nil, err
+	}
+	_, err = getUnmarshalledState(stub, "readAssetGenealogy", partID)
+	if err != nil {
+		return nil, err
+	}
+
+	var result = AssetGenealogy{Path: make([]string, 0), AircraftHistory: make([]string, 0)}
+	result.AssetID, err = assetIDToExternal(partID)
+	if err != nil {
+		return nil, err
+	}
+
+	// the aircraft, when there is one, is the root of the tree and is not part of the path
+	ledger := stubLedger{stub}
+	ancestors, err := getPartAncestors(ledger, partID)
+	if err != nil {
+		return nil, err
+	}
+	if len(ancestors) > 0 && isAircraftID(ancestors[len(ancestors)-1]) {
+		result.Aircraft, err = assetIDToExternal(ancestors[len(ancestors)-1])
+		if err != nil {
+			return nil, err
+		}
+		ancestors = ancestors[:len(ancestors)-1]
+	}
+	for i := len(ancestors) - 1; i >= 0; i-- {
+		a, err := assetIDToExternal(ancestors[i])
+		if err != nil {
+			return nil, err
+		}
+		result.Path = append(result.Path, a)
+	}
+	if len(result.Path) > 0 {
+		result.Parent = result.Path[len(result.Path)-1]
+	}
+
+	result.Children, err = readGenealogyChildren(ledger, partID)
+	if err != nil {
+		return nil, err
+	}
+
+	result.History, err = readGenealogyHistory(ledger, partID)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range result.History {
+		if r.Aircraft != "" && !contains(result.AircraftHistory, r.Aircraft) {
+			result.AircraftHistory = append(result.AircraftHistory, r.Aircraft)
+		}
+	}
+
+	resultBytes, err := json.Marshal(&result)
+	if err != nil {
+		err = errors.New("readAssetGenealogy failed to marshal genealogy: " + err.Error())
+		log.Error(err)
+		return nil, err
+	}
+	return resultBytes, nil
+}
@@ -0,0 +1,162 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+package main
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPartCompositeKey(t *testing.T) {
+	key := createCompositeKey(PARTCHILDEDGE, []string{"ASENG", "ASMOD"})
+	objectType, attributes, err := splitCompositeKey(key)
+	if err != nil {
+		t.Fatalf("splitCompositeKey failed: %s", err)
+	}
+	if objectType != PARTCHILDEDGE || len(attributes) != 2 || attributes[0] != "ASENG" || attributes[1] != "ASMOD" {
+		t.Fatalf("splitCompositeKey returned %s %v", objectType, attributes)
+	}
+
+	// a partial key is a prefix of the full key so that it can start a range query
+	partial := createCompositeKey(PARTCHILDEDGE, []string{"ASENG"})
+	if !strings.HasPrefix(key, partial) {
+		t.Fatalf("partial key %q is not a prefix of %q", partial, key)
+	}
+	// but not of a sibling whose id merely starts with the same characters
+	if strings.HasPrefix(createCompositeKey(PARTCHILDEDGE, []string{"ASENGINE", "ASMOD"}), partial) {
+		t.Fatalf("partial key %q matches a different parent", partial)
+	}
+
+	if _, _, err := splitCompositeKey("ASENG"); err == nil {
+		t.Fatal("splitCompositeKey accepted a key that is not composite")
+	}
+}
+
+// mapLedger stands in for the fabric 0.5 stub, which is a struct with no mock, so the
+// part tree runs against a map
+type mapLedger struct {
+	State map[string][]byte
+	uuid  string
+}
+
+func newMapLedger(uuid string) *mapLedger {
+	return &mapLedger{make(map[string][]byte), uuid}
+}
+
+func (ledger *mapLedger) GetState(key string) ([]byte, error) {
+	return ledger.State[key], nil
+}
+
+func (ledger *mapLedger) PutState(key string, value []byte) error {
+	ledger.State[key] = value
+	return nil
+}
+
+func (ledger *mapLedger) DelState(key string) error {
+	delete(ledger.State, key)
+	return nil
+}
+
+func (ledger *mapLedger) rangeValues(startKey string, endKey string) ([][]byte, error) {
+	var keys = make([]string, 0)
+	for k := range ledger.State {
+		if k >= startKey && k < endKey {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	var values = make([][]byte, 0, len(keys))
+	for _, k := range keys {
+		values = append(values, ledger.State[k])
+	}
+	return values, nil
+}
+
+func (ledger *mapLedger) txnUUID() string { return ledger.uuid }
+
+func TestPartTree(t *testing.T) {
+	ledger := newMapLedger("tx1")
+	now := time.Date(2016, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	// an engine with a module and blade below it, and an APU, on one aircraft
+	for _, e := range []struct{ parent, child string }{
+		{"AC1234", "ASENG"},
+		{"ASENG", "ASMOD"},
+		{"ASMOD", "ASBLADE"},
+		{"AC1234", "ASAPU"},
+		{"ASENGINE", "ASSPARE"},
+	} {
+		if err := addPartEdge(ledger, e.parent, e.child, now); err != nil {
+			t.Fatalf("addPartEdge %s -> %s failed: %s", e.parent, e.child, err)
+		}
+	}
+
+	descendants, err := getPartDescendants(ledger, "AC1234")
+	if err != nil || !reflect.DeepEqual(descendants, []string{"ASAPU", "ASENG", "ASMOD", "ASBLADE"}) {
+		t.Fatalf("aircraft descendants are %v (err %v)", descendants, err)
+	}
+	// a part whose ID starts with the parent's ID is not its child
+	children, err := getPartChildren(ledger, "ASENG")
+	if err != nil || !reflect.DeepEqual(children, []string{"ASMOD"}) {
+		t.Fatalf("engine children are %v (err %v)", children, err)
+	}
+	ancestors, err := getPartAncestors(ledger, "ASBLADE")
+	if err != nil || !reflect.DeepEqual(ancestors, []string{"ASMOD", "ASENG", "AC1234"}) {
+		t.Fatalf("blade ancestors are %v (err %v)", ancestors, err)
+	}
+	if aircraft, found, err := getPartAircraft(ledger, "ASBLADE"); err != nil || !found || aircraft != "AC1234" {
+		t.Fatalf("blade flies on %s, %v (err %v)", aircraft, found, err)
+	}
+	if aircraft, found, err := getPartAircraft(ledger, "ASSPARE"); err != nil || found {
+		t.Fatalf("spare should not be on an aircraft, found %s (err %v)", aircraft, err)
+	}
+
+	nodes, err := readGenealogyChildren(ledger, "AC1234")
+	var want = []GenealogyNode{
+		{"APU", []GenealogyNode{}},
+		{"ENG", []GenealogyNode{{"MOD", []GenealogyNode{{"BLADE", []GenealogyNode{}}}}}},
+	}
+	if err != nil || !reflect.DeepEqual(nodes, want) {
+		t.Fatalf("aircraft tree is %+v (err %v)", nodes, err)
+	}
+
+	// taking the engine off the aircraft takes everything below it along
+	ledger.uuid = "tx2"
+	err = recordSubtreeGenealogy(ledger, "ASENG", "uninstall", "", "AC1234", now)
+	if err != nil {
+		t.Fatalf("recordSubtreeGenealogy failed: %s", err)
+	}
+	if err = removePartEdge(ledger, "AC1234", "ASENG"); err != nil {
+		t.Fatalf("removePartEdge failed: %s", err)
+	}
+	history, err := readGenealogyHistory(ledger, "ASBLADE")
+	if err != nil || len(history) != 1 {
+		t.Fatalf("blade history is %+v (err %v)", history, err)
+	}
+	if h := history[0]; h.Action != "uninstall" || h.Parent != "MOD" || h.Aircraft != "1234" || h.Via != "ENG" || h.TxnUUID != "tx2" {
+		t.Fatalf("blade history record is %+v", h)
+	}
+	if _, found, _ := getPartAircraft(ledger, "ASBLADE"); found {
+		t.Fatal("blade should no longer be on an aircraft")
+	}
+	if descendants, _ = getPartDescendants(ledger, "AC1234"); !reflect.DeepEqual(descendants, []string{"ASAPU"}) {
+		t.Fatalf("aircraft descendants after uninstall are %v", descendants)
+	}
+}
@@ -25,6 +25,7 @@ Kim Letkeman - Initial Contribution
 // KL 28 Jun 2016 Remove OVERTEMP and add ACHECK and BCHECK rules for simple
 //                aviation contract v4.2sa
 // KL 29 Aug 2016 Add HARDLANDING rule for aviation v4.4
+//                Add AD_OPEN rule for airworthiness directives
 // ************************************
 
 package main
@@ -33,6 +34,7 @@ import (
 	//"errors"
 	"fmt"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"time"
 )
 
 func (state *ArgsMap) executeRules(stub *shim.ChaincodeStub, eventName string, alerts *AlertStatus, event ArgsMap) (bool, error) {
@@ -47,21 +49,34 @@ func (state *ArgsMap) executeRules(stub *shim.ChaincodeStub, eventName string, a
 		return true, err
 	}
 
+	txnunixtime, err := stub.GetTxTimestamp()
+	if err != nil {
+		err = fmt.Errorf("executeRules: error getting transaction timestamp: %s", err)
+		log.Error(err)
+		return true, err
+	}
+	txntime := time.Unix(txnunixtime.Seconds, int64(txnunixtime.Nanos)).UTC()
+
+	directives, err := getDirectivesFromLedger(stub)
+	if err != nil {
+		return true, err
+	}
+
 	// ------ validation and state machine rules
 
 	// ------ alert rules
 	// rule 1 -- inspections to clear alerts acheck, bcheck, hardlanding
-	err = state.inspectionsRule(dynamicConfig, &internal, event)
+	err = state.inspectionsRule(dynamicConfig, &internal, event, txntime)
 	if err != nil {
 		return true, err
 	}
-	// rule 2 -- short cycle count acheck
-	err = state.acheckRule(dynamicConfig, &internal, event)
+	// rule 2 -- short interval acheck, first of cycles, flight hours or calendar days
+	err = state.acheckRule(dynamicConfig, &internal, event, txntime)
 	if err != nil {
 		return true, err
 	}
-	// rule 3 -- long cycle count bcheck
-	err = state.bcheckRule(dynamicConfig, &internal, event)
+	// rule 3 -- long interval bcheck, first of cycles, flight hours or calendar days
+	err = state.bcheckRule(dynamicConfig, &internal, event, txntime)
 	if err != nil {
 		return true, err
 	}
@@ -70,6 +85,11 @@ func (state *ArgsMap) executeRules(stub *shim.ChaincodeStub, eventName string, a
 	if err != nil {
 		return true, err
 	}
+	// rule 5 -- airworthiness directives open on this assembly
+	err = state.directivesRule(directives, &internal, event, txntime)
+	if err != nil {
+		return true, err
+	}
 
 	// transform for external consumption
 	*alerts = internal.asAlertStatus()
@@ -94,10 +114,10 @@ func (state *ArgsMap) executeRules(stub *shim.ChaincodeStub, eventName string, a
 //***********************************
 
 // Inspection actions are processed here:
-// ACHECK -- clears the ACHECK (short cycle count) alert
-// BCHECK -- clears the BCHECK (long cycle count) alert, also clears ACHECK
+// ACHECK -- clears the ACHECK and ACHECKUPCOMING alerts and restarts the interval
+// BCHECK -- clears the BCHECK and BCHECKUPCOMING alerts, also clears ACHECK
 // HARDLANDING -- clears the hardlanding inspection alert
-func (state *ArgsMap) inspectionsRule(config DynamicContractConfig, alerts *AlertStatusInternal, event ArgsMap) error {
+func (state *ArgsMap) inspectionsRule(config DynamicContractConfig, alerts *AlertStatusInternal, event ArgsMap, txntime time.Time) error {
 	if _, found := getObject(event, "inspection"); !found {
 		// this is an inspections rule, and this is not an inspection event
 		return nil
@@ -110,35 +130,20 @@ func (state *ArgsMap) inspectionsRule(config DynamicContractConfig, alerts *Aler
 	if found {
 		if insp == "ACHECK" {
 			// clears acheck and resets
-			_, ok := putObject(*state, "aCheckCounter", 0)
-			if !ok {
-				return fmt.Errorf("inspection rule: cannot put 0 to aCheckCounter for state %+v", state)
-			}
-			_, ok = putObject(*state, "aCheckCounterAdjusted", 0)
-			if !ok {
-				return fmt.Errorf("inspection rule: cannot put 0 to aCheckCounterAdjusted for state %+v", state)
+			err := state.resetInterval(aCheckInterval, "aCheckCounter", alerts, txntime)
+			if err != nil {
+				return err
 			}
-			alerts.clearAlert(AlertsACHECK)
 		} else if insp == "BCHECK" {
 			// clears acheck and bcheck and resets both
-			_, ok := putObject(*state, "bCheckCounter", 0)
-			if !ok {
-				return fmt.Errorf("inspection rule: cannot put 0 to bCheckCounter for state %+v", state)
-			}
-			_, ok = putObject(*state, "bCheckCounterAdjusted", 0)
-			if !ok {
-				return fmt.Errorf("inspection rule: cannot put 0 to bCheckCounterAdjusted for state %+v", state)
+			err := state.resetInterval(bCheckInterval, "bCheckCounter", alerts, txntime)
+			if err != nil {
+				return err
 			}
-			alerts.clearAlert(AlertsBCHECK)
-			_, ok = putObject(*state, "aCheckCounter", 0)
-			if !ok {
-				return fmt.Errorf("inspection rule: cannot put 0 to aCheckCounter for state %+v", state)
+			err = state.resetInterval(aCheckInterval, "aCheckCounter", alerts, txntime)
+			if err != nil {
+				return err
 			}
-			_, ok = putObject(*state, "aCheckCounterAdjusted", 0)
-			if !ok {
-				return fmt.Errorf("inspection rule: cannot put 0 to aCheckCounterAdjusted for state %+v", state)
-			}
-			alerts.clearAlert(AlertsACHECK)
 		} else if insp == "HARDLANDING" {
 			// clears hardlanding, nothing to reset
 			alerts.clearAlert(AlertsHARDLANDING)
@@ -147,55 +152,23 @@ func (state *ArgsMap) inspectionsRule(config DynamicContractConfig, alerts *Aler
 	return nil
 }
 
-// ACHECK alert handled by this rule.
-func (state *ArgsMap) acheckRule(config DynamicContractConfig, alerts *AlertStatusInternal, event ArgsMap) error {
-	//log.Debugf("\n\n**** ACHECKRULE\n\nEVENT: %T || %+v\n\nSTATE: %T || %+v", event, event, state, state)
-
-	_, flightFound := getObject(event, "flight")
-	_, analyticAdjustmentFound := getObject(event, "analyticAdjustment")
-
-	if !flightFound && !analyticAdjustmentFound {
-		// neither flight nor analyticAdjustment event
-		return nil
-	}
-
+// ACHECK and ACHECKUPCOMING alerts handled by this rule. Calendar days pass without
+// flights, so the interval is evaluated on every assembly event.
+func (state *ArgsMap) acheckRule(config DynamicContractConfig, alerts *AlertStatusInternal, event ArgsMap, txntime time.Time) error {
 	if _, found := getObject(*state, "assembly"); !found {
 		// acheck on assemblies only
 		return nil
 	}
-
-	// use the adjusted counter for threshold check
-	accadjusted, accadjustedfound := getObjectAsNumber(*state, "aCheckCounterAdjusted")
-	if accadjustedfound && accadjusted >= config.ACheckThreshold {
-		alerts.raiseAlert(AlertsACHECK)
-	}
-
-	return nil
+	return state.evaluateInterval(aCheckInterval, config, alerts, txntime)
 }
 
-// BCHECK alert handled by this rule.
-func (state *ArgsMap) bcheckRule(config DynamicContractConfig, alerts *AlertStatusInternal, event ArgsMap) error {
-
-	_, flightFound := getObject(event, "flight")
-	_, analyticAdjustmentFound := getObject(event, "analyticAdjustment")
-
-	if !flightFound && !analyticAdjustmentFound {
-		// neither flight nor analyticAdjustment event
-		return nil
-	}
-
+// BCHECK and BCHECKUPCOMING alerts handled by this rule.
+func (state *ArgsMap) bcheckRule(config DynamicContractConfig, alerts *AlertStatusInternal, event ArgsMap, txntime time.Time) error {
 	if _, found := getObject(*state, "assembly"); !found {
-		// acheck on assemblies only
+		// bcheck on assemblies only
 		return nil
 	}
-
-	// use the adjusted counter for threshold check
-	bccadjusted, bccadjustedfound := getObjectAsNumber(*state, "bCheckCounterAdjusted")
-	if bccadjustedfound && bccadjusted >= config.BCheckThreshold {
-		alerts.raiseAlert(AlertsBCHECK)
-	}
-
-	return nil
+	return state.evaluateInterval(bCheckInterval, config, alerts, txntime)
 }
 
 // HARDLANDING alert handled by this rule:
@@ -217,6 +190,63 @@ func (state *ArgsMap) hardlandingRule(config DynamicContractConfig, alerts *Aler
 	return nil
 }
 
+// AD_OPEN alert handled by this rule. Every directive that applies to the assembly is
+// tracked under directives.<directiveID> in the state, a maintenance event that names the
+// directive in completesDirective closes it. The alert is active while any directive is open.
+func (state *ArgsMap) directivesRule(directives []Directive, alerts *AlertStatusInternal, event ArgsMap, txntime time.Time) error {
+	if _, found := getObject(*state, "assembly"); !found {
+		// directives on assemblies only
+		return nil
+	}
+	if getAssemblyStatus(*state) == AssemblyStatusScrapped {
+		// scrapped assemblies no longer fly
+		alerts.clearAlert(AlertsADOPEN)
+		return nil
+	}
+	completes, completing := getObjectAsString(event, "maintenance.completesDirective")
+	var completed, open bool
+	for _, d := range directives {
+		if !d.appliesTo(*state) {
+			continue
+		}
+		qname := "directives." + d.DirectiveID
+		status, found := getObjectAsString(*state, qname+".status")
+		if !found {
+			// first event for this assembly since the directive was issued
+			status = DirectiveStatusOpen
+			if _, ok := putObject(*state, qname+".deadline", d.Deadline); !ok {
+				return fmt.Errorf("directives rule: cannot put %s.deadline for state %+v", qname, state)
+			}
+		}
+		if completing && completes == d.DirectiveID {
+			completed = true
+			if status != DirectiveStatusClosed {
+				status = DirectiveStatusClosed
+				if _, ok := putObject(*state, qname+".completed", txntime.Format(time.RFC3339Nano)); !ok {
+					return fmt.Errorf("directives rule: cannot put %s.completed for state %+v", qname, state)
+				}
+			}
+		}
+		if _, ok := putObject(*state, qname+".status", status); !ok {
+			return fmt.Errorf("directives rule: cannot put %s.status for state %+v", qname, state)
+		}
+		if status != DirectiveStatusClosed {
+			open = true
+		}
+	}
+	if completing && !completed {
+		err := fmt.Errorf("directives rule: directive %s does not exist or does not apply to this assembly", completes)
+		log.Error(err)
+		return err
+	}
+	if open {
+		alerts.raiseAlert(AlertsADOPEN)
+	} else {
+		alerts.clearAlert(AlertsADOPEN)
+	}
+	return nil
+}
+
 //***********************************
 //**         COMPLIANCE            **
 //***********************************
@@ -62,6 +62,7 @@ var samples = `
             "arlsZone": "tbd",
             "ataCode": "The ATA code defining the assembly type, e.g. 32=landing gear, 32-50=steering.",
             "lifeLimitInitial": 789,
+            "manufacturer": "The assembly manufacturer. Used to target airworthiness directives.",
             "name": "The assembly name.",
             "serialNumber": "Assembly identifier assigned by manufacturer"
         },
@@ -80,11 +81,26 @@ var samples = `
             "timestamp": "2016-10-05T05:35:44.578334201Z"
         }
     },
+    "directiveEvent": {
+        "directive": {
+            "ataCode": "Targets assemblies of this type. A chapter such as 32 also targets 32-50.",
+            "deadline": "Compliance deadline.",
+            "description": "The required action.",
+            "directiveID": "The directive identifier, must not contain '.'",
+            "issued": "Transaction timestamp of createDirective, set by the contract.",
+            "manufacturer": "Targets assemblies from this manufacturer.",
+            "serialFrom": "Targets assemblies with serial numbers from this one, inclusive.",
+            "serialTo": "Targets assemblies with serial numbers up to this one, inclusive.",
+            "title": "The directive title.",
+            "txnuuid": "Transaction UUID of createDirective, set by the contract."
+        }
+    },
     "flightEvent": {
         "flight": {
             "aircraft": "Aircraft tail or serial number (tbd)",
             "analyticHardlanding": true,
             "atd": "actual time departure",
+            "flightHours": 123.456,
             "flightnumber": "A flight number",
             "from": "3 letter code of originating airport",
             "gForce": 123.456,
@@ -110,7 +126,9 @@ var samples = `
             "action": "install",
             "aircraft": "The serial number of the aircraft to / from which the assembly has been installed / uninstalled.",
             "assembly": "This assembly's serial number",
-            "note": "Maintenance note for this action. Overwritten whenever a new note property is inserted into the maintenance sub-event."
+            "completesDirective": "The directiveID of an airworthiness directive that this maintenance action complies with. Closes the directive on the assembly. Required for the comply action, which leaves an installed assembly on its aircraft.",
+            "note": "Maintenance note for this action. Overwritten whenever a new note property is inserted into the maintenance sub-event.",
+            "parent": "The serial number of the parent assembly into / from which the assembly has been installed / uninstalled. Not allowed together with aircraft."
         }
     },
     "state": {
@@ -118,17 +136,26 @@ var samples = `
             "active": [
                 "ACHECK",
                 "BCHECK",
-                "HARDLANDING"
+                "HARDLANDING",
+                "ACHECKUPCOMING",
+                "BCHECKUPCOMING",
+                "AD_OPEN"
             ],
             "cleared": [
                 "ACHECK",
                 "BCHECK",
-                "HARDLANDING"
+                "HARDLANDING",
+                "ACHECKUPCOMING",
+                "BCHECKUPCOMING",
+                "AD_OPEN"
             ],
             "raised": [
                 "ACHECK",
                 "BCHECK",
-                "HARDLANDING"
+                "HARDLANDING",
+                "ACHECKUPCOMING",
+                "BCHECKUPCOMING",
+                "AD_OPEN"
             ]
         },
         "compliant": true,
@@ -369,6 +396,10 @@ var samples = `
                                         "description": "Initial assembly life limit.",
                                         "type": "integer"
                                     },
+                                    "manufacturer": {
+                                        "description": "The assembly manufacturer. Used to target airworthiness directives.",
+                                        "type": "string"
+                                    },
                                     "name": {
                                         "description": "The assembly name.",
                                         "type": "string"
@@ -452,6 +483,10 @@ var samples = `
                                         "description": "actual time departure",
                                         "type": "string"
                                     },
+                                    "flightHours": {
+                                        "description": "flight duration in hours, accumulated against the inspection hours limits",
+                                        "type": "number"
+                                    },
                                     "flightnumber": {
                                         "description": "A flight number",
                                         "type": "string"
@@ -528,7 +563,8 @@ var samples = `
                                             "uninstall",
                                             "startMaintenance",
                                             "endMaintenance",
-                                            "scrap"
+                                            "scrap",
+                                            "comply"
                                         ],
                                         "type": "string"
                                     },
@@ -540,9 +576,17 @@ var samples = `
                                         "description": "This assembly's serial number",
                                         "type": "string"
                                     },
+                                    "completesDirective": {
+                                        "description": "The directiveID of an airworthiness directive that this maintenance action complies with. Closes the directive on the assembly. Required for the comply action, which leaves an installed assembly on its aircraft.",
+                                        "type": "string"
+                                    },
                                     "note": {
                                         "description": "Maintenance note for this action. Overwritten whenever a new note property is inserted into the maintenance sub-event.",
                                         "type": "string"
+                                    },
+                                    "parent": {
+                                        "description": "The serial number of the parent assembly into / from which the assembly has been installed / uninstalled. Not allowed together with aircraft.",
+                                        "type": "string"
                                     }
                                 },
                                 "required": [
@@ -631,6 +675,10 @@ var samples = `
                         "description": "Initial assembly life limit.",
                         "type": "integer"
                     },
+                    "manufacturer": {
+                        "description": "The assembly manufacturer. Used to target airworthiness directives.",
+                        "type": "string"
+                    },
                     "name": {
                         "description": "The assembly name.",
                         "type": "string"
@@ -226,6 +226,10 @@ var schemas = `
                                         "description": "Initial assembly life limit.",
                                         "type": "integer"
                                     },
+                                    "manufacturer": {
+                                        "description": "The assembly manufacturer. Used to target airworthiness directives.",
+                                        "type": "string"
+                                    },
                                     "name": {
                                         "description": "The assembly name.",
                                         "type": "string"
@@ -306,6 +310,84 @@ var schemas = `
             },
             "type": "object"
         },
+        "createDirective": {
+            "description": "Register an airworthiness directive. One argument, a JSON encoded event. The directive targets assemblies by ataCode, manufacturer and serial range, every targeting property that is set must match. All matching assemblies raise the AD_OPEN alert until a maintenance event completes the directive.",
+            "properties": {
+                "args": {
+                    "description": "args are JSON encoded strings",
+                    "items": {
+                        "description": "directive event",
+                        "properties": {
+                            "directive": {
+                                "description": "An airworthiness directive. At least one of ataCode, manufacturer, serialFrom or serialTo is required and every one that is set must match an assembly.",
+                                "properties": {
+                                    "ataCode": {
+                                        "description": "Targets assemblies of this type. A chapter such as 32 also targets 32-50.",
+                                        "type": "string"
+                                    },
+                                    "deadline": {
+                                        "description": "Compliance deadline.",
+                                        "format": "date-time",
+                                        "type": "string"
+                                    },
+                                    "description": {
+                                        "description": "The required action.",
+                                        "type": "string"
+                                    },
+                                    "directiveID": {
+                                        "description": "The directive identifier, must not contain '.'",
+                                        "type": "string"
+                                    },
+                                    "issued": {
+                                        "description": "Transaction timestamp of createDirective, set by the contract.",
+                                        "format": "date-time",
+                                        "type": "string"
+                                    },
+                                    "manufacturer": {
+                                        "description": "Targets assemblies from this manufacturer.",
+                                        "type": "string"
+                                    },
+                                    "serialFrom": {
+                                        "description": "Targets assemblies with serial numbers from this one, inclusive.",
+                                        "type": "string"
+                                    },
+                                    "serialTo": {
+                                        "description": "Targets assemblies with serial numbers up to this one, inclusive.",
+                                        "type": "string"
+                                    },
+                                    "title": {
+                                        "description": "The directive title.",
+                                        "type": "string"
+                                    },
+                                    "txnuuid": {
+                                        "description": "Transaction UUID of createDirective, set by the contract.",
+                                        "type": "string"
+                                    }
+                                },
+                                "required": [
+                                    "directiveID",
+                                    "deadline"
+                                ],
+                                "type": "object"
+                            }
+                        },
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
+                    "type": "array"
+                },
+                "function": {
+                    "description": "createDirective function",
+                    "enum": [
+                        "createDirective"
+                    ],
+                    "type": "string"
+                },
+                "method": "invoke"
+            },
+            "type": "object"
+        },
         "deleteAllAssetsAircraft": {
             "description": "Delete the state of all assets. No arguments are accepted. For each managed asset, the state and history are erased, and the asset is removed if necessary from recent states.",
             "properties": {
@@ -683,6 +765,10 @@ var schemas = `
                                         "description": "actual time departure",
                                         "type": "string"
                                     },
+                                    "flightHours": {
+                                        "description": "flight duration in hours, accumulated against the inspection hours limits",
+                                        "type": "number"
+                                    },
                                     "flightnumber": {
                                         "description": "A flight number",
                                         "type": "string"
@@ -799,7 +885,8 @@ var schemas = `
                                             "uninstall",
                                             "startMaintenance",
                                             "endMaintenance",
-                                            "scrap"
+                                            "scrap",
+                                            "comply"
                                         ],
                                         "type": "string"
                                     },
@@ -811,9 +898,17 @@ var schemas = `
                                         "description": "This assembly's serial number",
                                         "type": "string"
                                     },
+                                    "completesDirective": {
+                                        "description": "The directiveID of an airworthiness directive that this maintenance action complies with. Closes the directive on the assembly. Required for the comply action, which leaves an installed assembly on its aircraft.",
+                                        "type": "string"
+                                    },
                                     "note": {
                                         "description": "Maintenance note for this action. Overwritten whenever a new note property is inserted into the maintenance sub-event.",
                                         "type": "string"
+                                    },
+                                    "parent": {
+                                        "description": "The serial number of the parent assembly into / from which the assembly has been installed / uninstalled. Not allowed together with aircraft.",
+                                        "type": "string"
                                     }
                                 },
                                 "required": [
@@ -937,11 +1032,14 @@ var schemas = `
                                 "properties": {
                                     "active": {
                                         "items": {
-                                            "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts.",
+                                            "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts. The UPCOMING alerts warn that an ACHECK or BCHECK is nearly due and are cleared by the same inspection. AD_OPEN signals an airworthiness directive that the assembly has not yet complied with and is cleared by a maintenance event that completes the directive.",
                                             "enum": [
                                                 "ACHECK",
                                                 "BCHECK",
-                                                "HARDLANDING"
+                                                "HARDLANDING",
+                                                "ACHECKUPCOMING",
+                                                "BCHECKUPCOMING",
+                                                "AD_OPEN"
                                             ],
                                             "type": "string"
                                         },
@@ -950,11 +1048,14 @@ var schemas = `
                                     },
                                     "cleared": {
                                         "items": {
-                                            "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts.",
+                                            "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts. The UPCOMING alerts warn that an ACHECK or BCHECK is nearly due and are cleared by the same inspection. AD_OPEN signals an airworthiness directive that the assembly has not yet complied with and is cleared by a maintenance event that completes the directive.",
                                             "enum": [
                                                 "ACHECK",
                                                 "BCHECK",
-                                                "HARDLANDING"
+                                                "HARDLANDING",
+                                                "ACHECKUPCOMING",
+                                                "BCHECKUPCOMING",
+                                                "AD_OPEN"
                                             ],
                                             "type": "string"
                                         },
@@ -963,11 +1064,14 @@ var schemas = `
                                     },
                                     "raised": {
                                         "items": {
-                                            "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts.",
+                                            "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts. The UPCOMING alerts warn that an ACHECK or BCHECK is nearly due and are cleared by the same inspection. AD_OPEN signals an airworthiness directive that the assembly has not yet complied with and is cleared by a maintenance event that completes the directive.",
                                             "enum": [
                                                 "ACHECK",
                                                 "BCHECK",
-                                                "HARDLANDING"
+                                                "HARDLANDING",
+                                                "ACHECKUPCOMING",
+                                                "BCHECKUPCOMING",
+                                                "AD_OPEN"
                                             ],
                                             "type": "string"
                                         },
@@ -1286,6 +1390,10 @@ var schemas = `
                                                                     "description": "Initial assembly life limit.",
                                                                     "type": "integer"
                                                                 },
+                                                                "manufacturer": {
+                                                                    "description": "The assembly manufacturer. Used to target airworthiness directives.",
+                                                                    "type": "string"
+                                                                },
                                                                 "name": {
                                                                     "description": "The assembly name.",
                                                                     "type": "string"
@@ -1369,6 +1477,10 @@ var schemas = `
                                                                     "description": "actual time departure",
                                                                     "type": "string"
                                                                 },
+                                                                "flightHours": {
+                                                                    "description": "flight duration in hours, accumulated against the inspection hours limits",
+                                                                    "type": "number"
+                                                                },
                                                                 "flightnumber": {
                                                                     "description": "A flight number",
                                                                     "type": "string"
@@ -1445,7 +1557,8 @@ var schemas = `
                                                                         "uninstall",
                                                                         "startMaintenance",
                                                                         "endMaintenance",
-                                                                        "scrap"
+                                                                        "scrap",
+                                                                        "comply"
                                                                     ],
                                                                     "type": "string"
                                                                 },
@@ -1457,9 +1570,17 @@ var schemas = `
                                                                     "description": "This assembly's serial number",
                                                                     "type": "string"
                                                                 },
+                                                                "completesDirective": {
+                                                                    "description": "The directiveID of an airworthiness directive that this maintenance action complies with. Closes the directive on the assembly. Required for the comply action, which leaves an installed assembly on its aircraft.",
+                                                                    "type": "string"
+                                                                },
                                                                 "note": {
                                                                     "description": "Maintenance note for this action. Overwritten whenever a new note property is inserted into the maintenance sub-event.",
                                                                     "type": "string"
+                                                                },
+                                                                "parent": {
+                                                                    "description": "The serial number of the parent assembly into / from which the assembly has been installed / uninstalled. Not allowed together with aircraft.",
+                                                                    "type": "string"
                                                                 }
                                                             },
                                                             "required": [
@@ -1558,6 +1679,10 @@ var schemas = `
                                             "description": "Initial assembly life limit.",
                                             "type": "integer"
                                         },
+                                        "manufacturer": {
+                                            "description": "The assembly manufacturer. Used to target airworthiness directives.",
+                                            "type": "string"
+                                        },
                                         "name": {
                                             "description": "The assembly name.",
                                             "type": "string"
@@ -1651,11 +1776,14 @@ var schemas = `
                                 "properties": {
                                     "active": {
                                         "items": {
-                                            "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts.",
+                                            "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts. The UPCOMING alerts warn that an ACHECK or BCHECK is nearly due and are cleared by the same inspection. AD_OPEN signals an airworthiness directive that the assembly has not yet complied with and is cleared by a maintenance event that completes the directive.",
                                             "enum": [
                                                 "ACHECK",
                                                 "BCHECK",
-                                                "HARDLANDING"
+                                                "HARDLANDING",
+                                                "ACHECKUPCOMING",
+                                                "BCHECKUPCOMING",
+                                                "AD_OPEN"
                                             ],
                                             "type": "string"
                                         },
@@ -1664,11 +1792,14 @@ var schemas = `
                                     },
                                     "cleared": {
                                         "items": {
-                                            "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts.",
+                                            "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts. The UPCOMING alerts warn that an ACHECK or BCHECK is nearly due and are cleared by the same inspection. AD_OPEN signals an airworthiness directive that the assembly has not yet complied with and is cleared by a maintenance event that completes the directive.",
                                             "enum": [
                                                 "ACHECK",
                                                 "BCHECK",
-                                                "HARDLANDING"
+                                                "HARDLANDING",
+                                                "ACHECKUPCOMING",
+                                                "BCHECKUPCOMING",
+                                                "AD_OPEN"
                                             ],
                                             "type": "string"
                                         },
@@ -1677,11 +1808,14 @@ var schemas = `
                                     },
                                     "raised": {
                                         "items": {
-                                            "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts.",
+                                            "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts. The UPCOMING alerts warn that an ACHECK or BCHECK is nearly due and are cleared by the same inspection. AD_OPEN signals an airworthiness directive that the assembly has not yet complied with and is cleared by a maintenance event that completes the directive.",
                                             "enum": [
                                                 "ACHECK",
                                                 "BCHECK",
-                                                "HARDLANDING"
+                                                "HARDLANDING",
+                                                "ACHECKUPCOMING",
+                                                "BCHECKUPCOMING",
+                                                "AD_OPEN"
                                             ],
                                             "type": "string"
                                         },
@@ -2000,6 +2134,10 @@ var schemas = `
                                                                     "description": "Initial assembly life limit.",
                                                                     "type": "integer"
                                                                 },
+                                                                "manufacturer": {
+                                                                    "description": "The assembly manufacturer. Used to target airworthiness directives.",
+                                                                    "type": "string"
+                                                                },
                                                                 "name": {
                                                                     "description": "The assembly name.",
                                                                     "type": "string"
@@ -2083,6 +2221,10 @@ var schemas = `
                                                                     "description": "actual time departure",
                                                                     "type": "string"
                                                                 },
+                                                                "flightHours": {
+                                                                    "description": "flight duration in hours, accumulated against the inspection hours limits",
+                                                                    "type": "number"
+                                                                },
                                                                 "flightnumber": {
                                                                     "description": "A flight number",
                                                                     "type": "string"
@@ -2159,7 +2301,8 @@ var schemas = `
                                                                         "uninstall",
                                                                         "startMaintenance",
                                                                         "endMaintenance",
-                                                                        "scrap"
+                                                                        "scrap",
+                                                                        "comply"
                                                                     ],
                                                                     "type": "string"
                                                                 },
@@ -2171,9 +2314,17 @@ var schemas = `
                                                                     "description": "This assembly's serial number",
                                                                     "type": "string"
                                                                 },
+                                                                "completesDirective": {
+                                                                    "description": "The directiveID of an airworthiness directive that this maintenance action complies with. Closes the directive on the assembly. Required for the comply action, which leaves an installed assembly on its aircraft.",
+                                                                    "type": "string"
+                                                                },
                                                                 "note": {
                                                                     "description": "Maintenance note for this action. Overwritten whenever a new note property is inserted into the maintenance sub-event.",
                                                                     "type": "string"
+                                                                },
+                                                                "parent": {
+                                                                    "description": "The serial number of the parent assembly into / from which the assembly has been installed / uninstalled. Not allowed together with aircraft.",
+                                                                    "type": "string"
                                                                 }
                                                             },
                                                             "required": [
@@ -2272,6 +2423,10 @@ var schemas = `
                                             "description": "Initial assembly life limit.",
                                             "type": "integer"
                                         },
+                                        "manufacturer": {
+                                            "description": "The assembly manufacturer. Used to target airworthiness directives.",
+                                            "type": "string"
+                                        },
                                         "name": {
                                             "description": "The assembly name.",
                                             "type": "string"
@@ -2365,11 +2520,14 @@ var schemas = `
                                 "properties": {
                                     "active": {
                                         "items": {
-                                            "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts.",
+                                            "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts. The UPCOMING alerts warn that an ACHECK or BCHECK is nearly due and are cleared by the same inspection. AD_OPEN signals an airworthiness directive that the assembly has not yet complied with and is cleared by a maintenance event that completes the directive.",
                                             "enum": [
                                                 "ACHECK",
                                                 "BCHECK",
-                                                "HARDLANDING"
+                                                "HARDLANDING",
+                                                "ACHECKUPCOMING",
+                                                "BCHECKUPCOMING",
+                                                "AD_OPEN"
                                             ],
                                             "type": "string"
                                         },
@@ -2378,11 +2536,14 @@ var schemas = `
                                     },
                                     "cleared": {
                                         "items": {
-                                            "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts.",
+                                            "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts. The UPCOMING alerts warn that an ACHECK or BCHECK is nearly due and are cleared by the same inspection. AD_OPEN signals an airworthiness directive that the assembly has not yet complied with and is cleared by a maintenance event that completes the directive.",
                                             "enum": [
                                                 "ACHECK",
                                                 "BCHECK",
-                                                "HARDLANDING"
+                                                "HARDLANDING",
+                                                "ACHECKUPCOMING",
+                                                "BCHECKUPCOMING",
+                                                "AD_OPEN"
                                             ],
                                             "type": "string"
                                         },
@@ -2391,11 +2552,14 @@ var schemas = `
                                     },
                                     "raised": {
                                         "items": {
-                                            "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts.",
+                                            "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts. The UPCOMING alerts warn that an ACHECK or BCHECK is nearly due and are cleared by the same inspection. AD_OPEN signals an airworthiness directive that the assembly has not yet complied with and is cleared by a maintenance event that completes the directive.",
                                             "enum": [
                                                 "ACHECK",
                                                 "BCHECK",
-                                                "HARDLANDING"
+                                                "HARDLANDING",
+                                                "ACHECKUPCOMING",
+                                                "BCHECKUPCOMING",
+                                                "AD_OPEN"
                                             ],
                                             "type": "string"
                                         },
@@ -2714,6 +2878,10 @@ var schemas = `
                                                                     "description": "Initial assembly life limit.",
                                                                     "type": "integer"
                                                                 },
+                                                                "manufacturer": {
+                                                                    "description": "The assembly manufacturer. Used to target airworthiness directives.",
+                                                                    "type": "string"
+                                                                },
                                                                 "name": {
                                                                     "description": "The assembly name.",
                                                                     "type": "string"
@@ -2797,6 +2965,10 @@ var schemas = `
                                                                     "description": "actual time departure",
                                                                     "type": "string"
                                                                 },
+                                                                "flightHours": {
+                                                                    "description": "flight duration in hours, accumulated against the inspection hours limits",
+                                                                    "type": "number"
+                                                                },
                                                                 "flightnumber": {
                                                                     "description": "A flight number",
                                                                     "type": "string"
@@ -2873,7 +3045,8 @@ var schemas = `
                                                                         "uninstall",
                                                                         "startMaintenance",
                                                                         "endMaintenance",
-                                                                        "scrap"
+                                                                        "scrap",
+                                                                        "comply"
                                                                     ],
                                                                     "type": "string"
                                                                 },
@@ -2885,9 +3058,17 @@ var schemas = `
                                                                     "description": "This assembly's serial number",
                                                                     "type": "string"
                                                                 },
+                                                                "completesDirective": {
+                                                                    "description": "The directiveID of an airworthiness directive that this maintenance action complies with. Closes the directive on the assembly. Required for the comply action, which leaves an installed assembly on its aircraft.",
+                                                                    "type": "string"
+                                                                },
                                                                 "note": {
                                                                     "description": "Maintenance note for this action. Overwritten whenever a new note property is inserted into the maintenance sub-event.",
                                                                     "type": "string"
+                                                                },
+                                                                "parent": {
+                                                                    "description": "The serial number of the parent assembly into / from which the assembly has been installed / uninstalled. Not allowed together with aircraft.",
+                                                                    "type": "string"
                                                                 }
                                                             },
                                                             "required": [
@@ -2986,6 +3167,10 @@ var schemas = `
                                             "description": "Initial assembly life limit.",
                                             "type": "integer"
                                         },
+                                        "manufacturer": {
+                                            "description": "The assembly manufacturer. Used to target airworthiness directives.",
+                                            "type": "string"
+                                        },
                                         "name": {
                                             "description": "The assembly name.",
                                             "type": "string"
@@ -3020,6 +3205,76 @@ var schemas = `
             },
             "type": "object"
         },
+        "readAssemblyLifecycle": {
+            "description": "Returns the assembly lifecycle state machine: the statuses, the terminal statuses and the table of allowed transitions. The maintenance event rejects any action that is not in the table for the assembly's current status.",
+            "properties": {
+                "args": {
+                    "description": "accepts no arguments",
+                    "items": {},
+                    "maxItems": 0,
+                    "minItems": 0,
+                    "type": "array"
+                },
+                "function": {
+                    "description": "readAssemblyLifecycle function",
+                    "enum": [
+                        "readAssemblyLifecycle"
+                    ],
+                    "type": "string"
+                },
+                "method": "query",
+                "result": {
+                    "description": "The assembly lifecycle state machine.",
+                    "properties": {
+                        "statuses": {
+                            "description": "All assembly statuses.",
+                            "items": {
+                                "type": "string"
+                            },
+                            "type": "array"
+                        },
+                        "terminal": {
+                            "description": "Statuses that no action leaves.",
+                            "items": {
+                                "type": "string"
+                            },
+                            "type": "array"
+                        },
+                        "transitions": {
+                            "description": "Allowed transitions.",
+                            "items": {
+                                "properties": {
+                                    "action": {
+                                        "description": "Maintenance event action.",
+                                        "type": "string"
+                                    },
+                                    "forbidsAircraft": {
+                                        "description": "The maintenance event must not name an aircraft or parent assembly.",
+                                        "type": "boolean"
+                                    },
+                                    "from": {
+                                        "description": "Status before the action.",
+                                        "type": "string"
+                                    },
+                                    "requiresAircraft": {
+                                        "description": "The maintenance event must name an aircraft or parent assembly.",
+                                        "type": "boolean"
+                                    },
+                                    "to": {
+                                        "description": "Status after the action.",
+                                        "type": "string"
+                                    }
+                                },
+                                "type": "object"
+                            },
+                            "type": "array"
+                        }
+                    },
+                    "type": "object"
+                }
+            },
+            "type": "object"
+        },
         "readAssetAircraft": {
             "description": "Returns the state of an aircraft asset. Argument is a JSON encoded string. The arg is an 'assetID' property.",
             "properties": {
@@ -3058,11 +3313,14 @@ var schemas = `
                             "properties": {
                                 "active": {
                                     "items": {
-                                        "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts.",
+                                        "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts. The UPCOMING alerts warn that an ACHECK or BCHECK is nearly due and are cleared by the same inspection. AD_OPEN signals an airworthiness directive that the assembly has not yet complied with and is cleared by a maintenance event that completes the directive.",
                                         "enum": [
                                             "ACHECK",
                                             "BCHECK",
-                                            "HARDLANDING"
+                                            "HARDLANDING",
+                                            "ACHECKUPCOMING",
+                                            "BCHECKUPCOMING",
+                                            "AD_OPEN"
                                         ],
                                         "type": "string"
                                     },
@@ -3071,11 +3329,14 @@ var schemas = `
                                 },
                                 "cleared": {
                                     "items": {
-                                        "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts.",
+                                        "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts. The UPCOMING alerts warn that an ACHECK or BCHECK is nearly due and are cleared by the same inspection. AD_OPEN signals an airworthiness directive that the assembly has not yet complied with and is cleared by a maintenance event that completes the directive.",
                                         "enum": [
                                             "ACHECK",
                                             "BCHECK",
-                                            "HARDLANDING"
+                                            "HARDLANDING",
+                                            "ACHECKUPCOMING",
+                                            "BCHECKUPCOMING",
+                                            "AD_OPEN"
                                         ],
                                         "type": "string"
                                     },
@@ -3084,11 +3345,14 @@ var schemas = `
                                 },
                                 "raised": {
                                     "items": {
-                                        "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts.",
+                                        "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts. The UPCOMING alerts warn that an ACHECK or BCHECK is nearly due and are cleared by the same inspection. AD_OPEN signals an airworthiness directive that the assembly has not yet complied with and is cleared by a maintenance event that completes the directive.",
                                         "enum": [
                                             "ACHECK",
                                             "BCHECK",
-                                            "HARDLANDING"
+                                            "HARDLANDING",
+                                            "ACHECKUPCOMING",
+                                            "BCHECKUPCOMING",
+                                            "AD_OPEN"
                                         ],
                                         "type": "string"
                                     },
@@ -3407,6 +3671,10 @@ var schemas = `
                                                                 "description": "Initial assembly life limit.",
                                                                 "type": "integer"
                                                             },
+                                                            "manufacturer": {
+                                                                "description": "The assembly manufacturer. Used to target airworthiness directives.",
+                                                                "type": "string"
+                                                            },
                                                             "name": {
                                                                 "description": "The assembly name.",
                                                                 "type": "string"
@@ -3490,6 +3758,10 @@ var schemas = `
                                                                 "description": "actual time departure",
                                                                 "type": "string"
                                                             },
+                                                            "flightHours": {
+                                                                "description": "flight duration in hours, accumulated against the inspection hours limits",
+                                                                "type": "number"
+                                                            },
                                                             "flightnumber": {
                                                                 "description": "A flight number",
                                                                 "type": "string"
@@ -3566,7 +3838,8 @@ var schemas = `
                                                                     "uninstall",
                                                                     "startMaintenance",
                                                                     "endMaintenance",
-                                                                    "scrap"
+                                                                    "scrap",
+                                                                    "comply"
                                                                 ],
                                                                 "type": "string"
                                                             },
@@ -3578,9 +3851,17 @@ var schemas = `
                                                                 "description": "This assembly's serial number",
                                                                 "type": "string"
                                                             },
+                                                            "completesDirective": {
+                                                                "description": "The directiveID of an airworthiness directive that this maintenance action complies with. Closes the directive on the assembly. Required for the comply action, which leaves an installed assembly on its aircraft.",
+                                                                "type": "string"
+                                                            },
                                                             "note": {
                                                                 "description": "Maintenance note for this action. Overwritten whenever a new note property is inserted into the maintenance sub-event.",
                                                                 "type": "string"
+                                                            },
+                                                            "parent": {
+                                                                "description": "The serial number of the parent assembly into / from which the assembly has been installed / uninstalled. Not allowed together with aircraft.",
+                                                                "type": "string"
                                                             }
                                                         },
                                                         "required": [
@@ -3679,6 +3960,10 @@ var schemas = `
                                         "description": "Initial assembly life limit.",
                                         "type": "integer"
                                     },
+                                    "manufacturer": {
+                                        "description": "The assembly manufacturer. Used to target airworthiness directives.",
+                                        "type": "string"
+                                    },
                                     "name": {
                                         "description": "The assembly name.",
                                         "type": "string"
@@ -3748,11 +4033,14 @@ var schemas = `
                             "properties": {
                                 "active": {
                                     "items": {
-                                        "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts.",
+                                        "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts. The UPCOMING alerts warn that an ACHECK or BCHECK is nearly due and are cleared by the same inspection. AD_OPEN signals an airworthiness directive that the assembly has not yet complied with and is cleared by a maintenance event that completes the directive.",
                                         "enum": [
                                             "ACHECK",
                                             "BCHECK",
-                                            "HARDLANDING"
+                                            "HARDLANDING",
+                                            "ACHECKUPCOMING",
+                                            "BCHECKUPCOMING",
+                                            "AD_OPEN"
                                         ],
                                         "type": "string"
                                     },
@@ -3761,11 +4049,14 @@ var schemas = `
                                 },
                                 "cleared": {
                                     "items": {
-                                        "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts.",
+                                        "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts. The UPCOMING alerts warn that an ACHECK or BCHECK is nearly due and are cleared by the same inspection. AD_OPEN signals an airworthiness directive that the assembly has not yet complied with and is cleared by a maintenance event that completes the directive.",
                                         "enum": [
                                             "ACHECK",
                                             "BCHECK",
-                                            "HARDLANDING"
+                                            "HARDLANDING",
+                                            "ACHECKUPCOMING",
+                                            "BCHECKUPCOMING",
+                                            "AD_OPEN"
                                         ],
                                         "type": "string"
                                     },
@@ -3774,11 +4065,14 @@ var schemas = `
                                 },
                                 "raised": {
                                     "items": {
-                                        "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts.",
+                                        "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts. The UPCOMING alerts warn that an ACHECK or BCHECK is nearly due and are cleared by the same inspection. AD_OPEN signals an airworthiness directive that the assembly has not yet complied with and is cleared by a maintenance event that completes the directive.",
                                         "enum": [
                                             "ACHECK",
                                             "BCHECK",
-                                            "HARDLANDING"
+                                            "HARDLANDING",
+                                            "ACHECKUPCOMING",
+                                            "BCHECKUPCOMING",
+                                            "AD_OPEN"
                                         ],
                                         "type": "string"
                                     },
@@ -4097,6 +4391,10 @@ var schemas = `
                                                                 "description": "Initial assembly life limit.",
                                                                 "type": "integer"
                                                             },
+                                                            "manufacturer": {
+                                                                "description": "The assembly manufacturer. Used to target airworthiness directives.",
+                                                                "type": "string"
+                                                            },
                                                             "name": {
                                                                 "description": "The assembly name.",
                                                                 "type": "string"
@@ -4180,6 +4478,10 @@ var schemas = `
                                                                 "description": "actual time departure",
                                                                 "type": "string"
                                                             },
+                                                            "flightHours": {
+                                                                "description": "flight duration in hours, accumulated against the inspection hours limits",
+                                                                "type": "number"
+                                                            },
                                                             "flightnumber": {
                                                                 "description": "A flight number",
                                                                 "type": "string"
@@ -4256,7 +4558,8 @@ var schemas = `
                                                                     "uninstall",
                                                                     "startMaintenance",
                                                                     "endMaintenance",
-                                                                    "scrap"
+                                                                    "scrap",
+                                                                    "comply"
                                                                 ],
                                                                 "type": "string"
                                                             },
@@ -4268,9 +4571,17 @@ var schemas = `
                                                                 "description": "This assembly's serial number",
                                                                 "type": "string"
                                                             },
+                                                            "completesDirective": {
+                                                                "description": "The directiveID of an airworthiness directive that this maintenance action complies with. Closes the directive on the assembly. Required for the comply action, which leaves an installed assembly on its aircraft.",
+                                                                "type": "string"
+                                                            },
                                                             "note": {
                                                                 "description": "Maintenance note for this action. Overwritten whenever a new note property is inserted into the maintenance sub-event.",
                                                                 "type": "string"
+                                                            },
+                                                            "parent": {
+                                                                "description": "The serial number of the parent assembly into / from which the assembly has been installed / uninstalled. Not allowed together with aircraft.",
+                                                                "type": "string"
                                                             }
                                                         },
                                                         "required": [
@@ -4369,6 +4680,10 @@ var schemas = `
                                         "description": "Initial assembly life limit.",
                                         "type": "integer"
                                     },
+                                    "manufacturer": {
+                                        "description": "The assembly manufacturer. Used to target airworthiness directives.",
+                                        "type": "string"
+                                    },
                                     "name": {
                                         "description": "The assembly name.",
                                         "type": "string"
@@ -4443,11 +4758,14 @@ var schemas = `
                                 "properties": {
                                     "active": {
                                         "items": {
-                                            "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts.",
+                                            "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts. The UPCOMING alerts warn that an ACHECK or BCHECK is nearly due and are cleared by the same inspection. AD_OPEN signals an airworthiness directive that the assembly has not yet complied with and is cleared by a maintenance event that completes the directive.",
                                             "enum": [
                                                 "ACHECK",
                                                 "BCHECK",
-                                                "HARDLANDING"
+                                                "HARDLANDING",
+                                                "ACHECKUPCOMING",
+                                                "BCHECKUPCOMING",
+                                                "AD_OPEN"
                                             ],
                                             "type": "string"
                                         },
@@ -4456,11 +4774,14 @@ var schemas = `
                                     },
                                     "cleared": {
                                         "items": {
-                                            "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts.",
+                                            "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts. The UPCOMING alerts warn that an ACHECK or BCHECK is nearly due and are cleared by the same inspection. AD_OPEN signals an airworthiness directive that the assembly has not yet complied with and is cleared by a maintenance event that completes the directive.",
                                             "enum": [
                                                 "ACHECK",
                                                 "BCHECK",
-                                                "HARDLANDING"
+                                                "HARDLANDING",
+                                                "ACHECKUPCOMING",
+                                                "BCHECKUPCOMING",
+                                                "AD_OPEN"
                                             ],
                                             "type": "string"
                                         },
@@ -4469,11 +4790,14 @@ var schemas = `
                                     },
                                     "raised": {
                                         "items": {
-                                            "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts.",
+                                            "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts. The UPCOMING alerts warn that an ACHECK or BCHECK is nearly due and are cleared by the same inspection. AD_OPEN signals an airworthiness directive that the assembly has not yet complied with and is cleared by a maintenance event that completes the directive.",
                                             "enum": [
                                                 "ACHECK",
                                                 "BCHECK",
-                                                "HARDLANDING"
+                                                "HARDLANDING",
+                                                "ACHECKUPCOMING",
+                                                "BCHECKUPCOMING",
+                                                "AD_OPEN"
                                             ],
                                             "type": "string"
                                         },
@@ -4792,6 +5116,10 @@ var schemas = `
                                                                     "description": "Initial assembly life limit.",
                                                                     "type": "integer"
                                                                 },
+                                                                "manufacturer": {
+                                                                    "description": "The assembly manufacturer. Used to target airworthiness directives.",
+                                                                    "type": "string"
+                                                                },
                                                                 "name": {
                                                                     "description": "The assembly name.",
                                                                     "type": "string"
@@ -4875,6 +5203,10 @@ var schemas = `
                                                                     "description": "actual time departure",
                                                                     "type": "string"
                                                                 },
+                                                                "flightHours": {
+                                                                    "description": "flight duration in hours, accumulated against the inspection hours limits",
+                                                                    "type": "number"
+                                                                },
                                                                 "flightnumber": {
                                                                     "description": "A flight number",
                                                                     "type": "string"
@@ -4951,7 +5283,8 @@ var schemas = `
                                                                         "uninstall",
                                                                         "startMaintenance",
                                                                         "endMaintenance",
-                                                                        "scrap"
+                                                                        "scrap",
+                                                                        "comply"
                                                                     ],
                                                                     "type": "string"
                                                                 },
@@ -4963,9 +5296,17 @@ var schemas = `
                                                                     "description": "This assembly's serial number",
                                                                     "type": "string"
                                                                 },
+                                                                "completesDirective": {
+                                                                    "description": "The directiveID of an airworthiness directive that this maintenance action complies with. Closes the directive on the assembly. Required for the comply action, which leaves an installed assembly on its aircraft.",
+                                                                    "type": "string"
+                                                                },
                                                                 "note": {
                                                                     "description": "Maintenance note for this action. Overwritten whenever a new note property is inserted into the maintenance sub-event.",
                                                                     "type": "string"
+                                                                },
+                                                                "parent": {
+                                                                    "description": "The serial number of the parent assembly into / from which the assembly has been installed / uninstalled. Not allowed together with aircraft.",
+                                                                    "type": "string"
                                                                 }
                                                             },
                                                             "required": [
@@ -5064,6 +5405,10 @@ var schemas = `
                                             "description": "Initial assembly life limit.",
                                             "type": "integer"
                                         },
+                                        "manufacturer": {
+                                            "description": "The assembly manufacturer. Used to target airworthiness directives.",
+                                            "type": "string"
+                                        },
                                         "name": {
                                             "description": "The assembly name.",
                                             "type": "string"
@@ -5136,11 +5481,14 @@ var schemas = `
                             "properties": {
                                 "active": {
                                     "items": {
-                                        "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts.",
+                                        "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts. The UPCOMING alerts warn that an ACHECK or BCHECK is nearly due and are cleared by the same inspection. AD_OPEN signals an airworthiness directive that the assembly has not yet complied with and is cleared by a maintenance event that completes the directive.",
                                         "enum": [
                                             "ACHECK",
                                             "BCHECK",
-                                            "HARDLANDING"
+                                            "HARDLANDING",
+                                            "ACHECKUPCOMING",
+                                            "BCHECKUPCOMING",
+                                            "AD_OPEN"
                                         ],
                                         "type": "string"
                                     },
@@ -5149,11 +5497,14 @@ var schemas = `
                                 },
                                 "cleared": {
                                     "items": {
-                                        "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts.",
+                                        "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts. The UPCOMING alerts warn that an ACHECK or BCHECK is nearly due and are cleared by the same inspection. AD_OPEN signals an airworthiness directive that the assembly has not yet complied with and is cleared by a maintenance event that completes the directive.",
                                         "enum": [
                                             "ACHECK",
                                             "BCHECK",
-                                            "HARDLANDING"
+                                            "HARDLANDING",
+                                            "ACHECKUPCOMING",
+                                            "BCHECKUPCOMING",
+                                            "AD_OPEN"
                                         ],
                                         "type": "string"
                                     },
@@ -5162,11 +5513,14 @@ var schemas = `
                                 },
                                 "raised": {
                                     "items": {
-                                        "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts.",
+                                        "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts. The UPCOMING alerts warn that an ACHECK or BCHECK is nearly due and are cleared by the same inspection. AD_OPEN signals an airworthiness directive that the assembly has not yet complied with and is cleared by a maintenance event that completes the directive.",
                                         "enum": [
                                             "ACHECK",
                                             "BCHECK",
-                                            "HARDLANDING"
+                                            "HARDLANDING",
+                                            "ACHECKUPCOMING",
+                                            "BCHECKUPCOMING",
+                                            "AD_OPEN"
                                         ],
                                         "type": "string"
                                     },
@@ -5485,6 +5839,10 @@ var schemas = `
                                                                 "description": "Initial assembly life limit.",
                                                                 "type": "integer"
                                                             },
+                                                            "manufacturer": {
+                                                                "description": "The assembly manufacturer. Used to target airworthiness directives.",
+                                                                "type": "string"
+                                                            },
                                                             "name": {
                                                                 "description": "The assembly name.",
                                                                 "type": "string"
@@ -5568,6 +5926,10 @@ var schemas = `
                                                                 "description": "actual time departure",
                                                                 "type": "string"
                                                             },
+                                                            "flightHours": {
+                                                                "description": "flight duration in hours, accumulated against the inspection hours limits",
+                                                                "type": "number"
+                                                            },
                                                             "flightnumber": {
                                                                 "description": "A flight number",
                                                                 "type": "string"
@@ -5644,7 +6006,8 @@ var schemas = `
                                                                     "uninstall",
                                                                     "startMaintenance",
                                                                     "endMaintenance",
-                                                                    "scrap"
+                                                                    "scrap",
+                                                                    "comply"
                                                                 ],
                                                                 "type": "string"
                                                             },
@@ -5656,9 +6019,17 @@ var schemas = `
                                                                 "description": "This assembly's serial number",
                                                                 "type": "string"
                                                             },
+                                                            "completesDirective": {
+                                                                "description": "The directiveID of an airworthiness directive that this maintenance action complies with. Closes the directive on the assembly. Required for the comply action, which leaves an installed assembly on its aircraft.",
+                                                                "type": "string"
+                                                            },
                                                             "note": {
                                                                 "description": "Maintenance note for this action. Overwritten whenever a new note property is inserted into the maintenance sub-event.",
                                                                 "type": "string"
+                                                            },
+                                                            "parent": {
+                                                                "description": "The serial number of the parent assembly into / from which the assembly has been installed / uninstalled. Not allowed together with aircraft.",
+                                                                "type": "string"
                                                             }
                                                         },
                                                         "required": [
@@ -5757,6 +6128,10 @@ var schemas = `
                                         "description": "Initial assembly life limit.",
                                         "type": "integer"
                                     },
+                                    "manufacturer": {
+                                        "description": "The assembly manufacturer. Used to target airworthiness directives.",
+                                        "type": "string"
+                                    },
                                     "name": {
                                         "description": "The assembly name.",
                                         "type": "string"
@@ -5831,11 +6206,14 @@ var schemas = `
                                 "properties": {
                                     "active": {
                                         "items": {
-                                            "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts.",
+                                            "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts. The UPCOMING alerts warn that an ACHECK or BCHECK is nearly due and are cleared by the same inspection. AD_OPEN signals an airworthiness directive that the assembly has not yet complied with and is cleared by a maintenance event that completes the directive.",
                                             "enum": [
                                                 "ACHECK",
                                                 "BCHECK",
-                                                "HARDLANDING"
+                                                "HARDLANDING",
+                                                "ACHECKUPCOMING",
+                                                "BCHECKUPCOMING",
+                                                "AD_OPEN"
                                             ],
                                             "type": "string"
                                         },
@@ -5844,11 +6222,14 @@ var schemas = `
                                     },
                                     "cleared": {
                                         "items": {
-                                            "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts.",
+                                            "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts. The UPCOMING alerts warn that an ACHECK or BCHECK is nearly due and are cleared by the same inspection. AD_OPEN signals an airworthiness directive that the assembly has not yet complied with and is cleared by a maintenance event that completes the directive.",
                                             "enum": [
                                                 "ACHECK",
                                                 "BCHECK",
-                                                "HARDLANDING"
+                                                "HARDLANDING",
+                                                "ACHECKUPCOMING",
+                                                "BCHECKUPCOMING",
+                                                "AD_OPEN"
                                             ],
                                             "type": "string"
                                         },
@@ -5857,11 +6238,14 @@ var schemas = `
                                     },
                                     "raised": {
                                         "items": {
-                                            "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts.",
+                                            "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts. The UPCOMING alerts warn that an ACHECK or BCHECK is nearly due and are cleared by the same inspection. AD_OPEN signals an airworthiness directive that the assembly has not yet complied with and is cleared by a maintenance event that completes the directive.",
                                             "enum": [
                                                 "ACHECK",
                                                 "BCHECK",
-                                                "HARDLANDING"
+                                                "HARDLANDING",
+                                                "ACHECKUPCOMING",
+                                                "BCHECKUPCOMING",
+                                                "AD_OPEN"
                                             ],
                                             "type": "string"
                                         },
@@ -6180,6 +6564,10 @@ var schemas = `
                                                                     "description": "Initial assembly life limit.",
                                                                     "type": "integer"
                                                                 },
+                                                                "manufacturer": {
+                                                                    "description": "The assembly manufacturer. Used to target airworthiness directives.",
+                                                                    "type": "string"
+                                                                },
                                                                 "name": {
                                                                     "description": "The assembly name.",
                                                                     "type": "string"
@@ -6263,6 +6651,10 @@ var schemas = `
                                                                     "description": "actual time departure",
                                                                     "type": "string"
                                                                 },
+                                                                "flightHours": {
+                                                                    "description": "flight duration in hours, accumulated against the inspection hours limits",
+                                                                    "type": "number"
+                                                                },
                                                                 "flightnumber": {
                                                                     "description": "A flight number",
                                                                     "type": "string"
@@ -6339,7 +6731,8 @@ var schemas = `
                                                                         "uninstall",
                                                                         "startMaintenance",
                                                                         "endMaintenance",
-                                                                        "scrap"
+                                                                        "scrap",
+                                                                        "comply"
                                                                     ],
                                                                     "type": "string"
                                                                 },
@@ -6351,9 +6744,17 @@ var schemas = `
                                                                     "description": "This assembly's serial number",
                                                                     "type": "string"
                                                                 },
+                                                                "completesDirective": {
+                                                                    "description": "The directiveID of an airworthiness directive that this maintenance action complies with. Closes the directive on the assembly. Required for the comply action, which leaves an installed assembly on its aircraft.",
+                                                                    "type": "string"
+                                                                },
                                                                 "note": {
                                                                     "description": "Maintenance note for this action. Overwritten whenever a new note property is inserted into the maintenance sub-event.",
                                                                     "type": "string"
+                                                                },
+                                                                "parent": {
+                                                                    "description": "The serial number of the parent assembly into / from which the assembly has been installed / uninstalled. Not allowed together with aircraft.",
+                                                                    "type": "string"
                                                                 }
                                                             },
                                                             "required": [
@@ -6452,6 +6853,10 @@ var schemas = `
                                             "description": "Initial assembly life limit.",
                                             "type": "integer"
                                         },
+                                        "manufacturer": {
+                                            "description": "The assembly manufacturer. Used to target airworthiness directives.",
+                                            "type": "string"
+                                        },
                                         "name": {
                                             "description": "The assembly name.",
                                             "type": "string"
@@ -6524,11 +6929,14 @@ var schemas = `
                             "properties": {
                                 "active": {
                                     "items": {
-                                        "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts.",
+                                        "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts. The UPCOMING alerts warn that an ACHECK or BCHECK is nearly due and are cleared by the same inspection. AD_OPEN signals an airworthiness directive that the assembly has not yet complied with and is cleared by a maintenance event that completes the directive.",
                                         "enum": [
                                             "ACHECK",
                                             "BCHECK",
-                                            "HARDLANDING"
+                                            "HARDLANDING",
+                                            "ACHECKUPCOMING",
+                                            "BCHECKUPCOMING",
+                                            "AD_OPEN"
                                         ],
                                         "type": "string"
                                     },
@@ -6537,11 +6945,14 @@ var schemas = `
                                 },
                                 "cleared": {
                                     "items": {
-                                        "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts.",
+                                        "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts. The UPCOMING alerts warn that an ACHECK or BCHECK is nearly due and are cleared by the same inspection. AD_OPEN signals an airworthiness directive that the assembly has not yet complied with and is cleared by a maintenance event that completes the directive.",
                                         "enum": [
                                             "ACHECK",
                                             "BCHECK",
-                                            "HARDLANDING"
+                                            "HARDLANDING",
+                                            "ACHECKUPCOMING",
+                                            "BCHECKUPCOMING",
+                                            "AD_OPEN"
                                         ],
                                         "type": "string"
                                     },
@@ -6550,11 +6961,14 @@ var schemas = `
                                 },
                                 "raised": {
                                     "items": {
-                                        "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts.",
+                                        "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts. The UPCOMING alerts warn that an ACHECK or BCHECK is nearly due and are cleared by the same inspection. AD_OPEN signals an airworthiness directive that the assembly has not yet complied with and is cleared by a maintenance event that completes the directive.",
                                         "enum": [
                                             "ACHECK",
                                             "BCHECK",
-                                            "HARDLANDING"
+                                            "HARDLANDING",
+                                            "ACHECKUPCOMING",
+                                            "BCHECKUPCOMING",
+                                            "AD_OPEN"
                                         ],
                                         "type": "string"
                                     },
@@ -6873,6 +7287,10 @@ var schemas = `
                                                                 "description": "Initial assembly life limit.",
                                                                 "type": "integer"
                                                             },
+                                                            "manufacturer": {
+                                                                "description": "The assembly manufacturer. Used to target airworthiness directives.",
+                                                                "type": "string"
+                                                            },
                                                             "name": {
                                                                 "description": "The assembly name.",
                                                                 "type": "string"
@@ -6956,6 +7374,10 @@ var schemas = `
                                                                 "description": "actual time departure",
                                                                 "type": "string"
                                                             },
+                                                            "flightHours": {
+                                                                "description": "flight duration in hours, accumulated against the inspection hours limits",
+                                                                "type": "number"
+                                                            },
                                                             "flightnumber": {
                                                                 "description": "A flight number",
                                                                 "type": "string"
@@ -7032,7 +7454,8 @@ var schemas = `
                                                                     "uninstall",
                                                                     "startMaintenance",
                                                                     "endMaintenance",
-                                                                    "scrap"
+                                                                    "scrap",
+                                                                    "comply"
                                                                 ],
                                                                 "type": "string"
                                                             },
@@ -7044,9 +7467,17 @@ var schemas = `
                                                                 "description": "This assembly's serial number",
                                                                 "type": "string"
                                                             },
+                                                            "completesDirective": {
+                                                                "description": "The directiveID of an airworthiness directive that this maintenance action complies with. Closes the directive on the assembly. Required for the comply action, which leaves an installed assembly on its aircraft.",
+                                                                "type": "string"
+                                                            },
                                                             "note": {
                                                                 "description": "Maintenance note for this action. Overwritten whenever a new note property is inserted into the maintenance sub-event.",
                                                                 "type": "string"
+                                                            },
+                                                            "parent": {
+                                                                "description": "The serial number of the parent assembly into / from which the assembly has been installed / uninstalled. Not allowed together with aircraft.",
+                                                                "type": "string"
                                                             }
                                                         },
                                                         "required": [
@@ -7145,6 +7576,10 @@ var schemas = `
                                         "description": "Initial assembly life limit.",
                                         "type": "integer"
                                     },
+                                    "manufacturer": {
+                                        "description": "The assembly manufacturer. Used to target airworthiness directives.",
+                                        "type": "string"
+                                    },
                                     "name": {
                                         "description": "The assembly name.",
                                         "type": "string"
@@ -7219,11 +7654,14 @@ var schemas = `
                                 "properties": {
                                     "active": {
                                         "items": {
-                                            "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts.",
+                                            "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts. The UPCOMING alerts warn that an ACHECK or BCHECK is nearly due and are cleared by the same inspection. AD_OPEN signals an airworthiness directive that the assembly has not yet complied with and is cleared by a maintenance event that completes the directive.",
                                             "enum": [
                                                 "ACHECK",
                                                 "BCHECK",
-                                                "HARDLANDING"
+                                                "HARDLANDING",
+                                                "ACHECKUPCOMING",
+                                                "BCHECKUPCOMING",
+                                                "AD_OPEN"
                                             ],
                                             "type": "string"
                                         },
@@ -7232,11 +7670,14 @@ var schemas = `
                                     },
                                     "cleared": {
                                         "items": {
-                                            "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts.",
+                                            "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts. The UPCOMING alerts warn that an ACHECK or BCHECK is nearly due and are cleared by the same inspection. AD_OPEN signals an airworthiness directive that the assembly has not yet complied with and is cleared by a maintenance event that completes the directive.",
                                             "enum": [
                                                 "ACHECK",
                                                 "BCHECK",
-                                                "HARDLANDING"
+                                                "HARDLANDING",
+                                                "ACHECKUPCOMING",
+                                                "BCHECKUPCOMING",
+                                                "AD_OPEN"
                                             ],
                                             "type": "string"
                                         },
@@ -7245,11 +7686,14 @@ var schemas = `
                                     },
                                     "raised": {
                                         "items": {
-                                            "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts.",
+                                            "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts. The UPCOMING alerts warn that an ACHECK or BCHECK is nearly due and are cleared by the same inspection. AD_OPEN signals an airworthiness directive that the assembly has not yet complied with and is cleared by a maintenance event that completes the directive.",
                                             "enum": [
                                                 "ACHECK",
                                                 "BCHECK",
-                                                "HARDLANDING"
+                                                "HARDLANDING",
+                                                "ACHECKUPCOMING",
+                                                "BCHECKUPCOMING",
+                                                "AD_OPEN"
                                             ],
                                             "type": "string"
                                         },
@@ -7568,6 +8012,10 @@ var schemas = `
                                                                     "description": "Initial assembly life limit.",
                                                                     "type": "integer"
                                                                 },
+                                                                "manufacturer": {
+                                                                    "description": "The assembly manufacturer. Used to target airworthiness directives.",
+                                                                    "type": "string"
+                                                                },
                                                                 "name": {
                                                                     "description": "The assembly name.",
                                                                     "type": "string"
@@ -7651,6 +8099,10 @@ var schemas = `
                                                                     "description": "actual time departure",
                                                                     "type": "string"
                                                                 },
+                                                                "flightHours": {
+                                                                    "description": "flight duration in hours, accumulated against the inspection hours limits",
+                                                                    "type": "number"
+                                                                },
                                                                 "flightnumber": {
                                                                     "description": "A flight number",
                                                                     "type": "string"
@@ -7727,7 +8179,8 @@ var schemas = `
                                                                         "uninstall",
                                                                         "startMaintenance",
                                                                         "endMaintenance",
-                                                                        "scrap"
+                                                                        "scrap",
+                                                                        "comply"
                                                                     ],
                                                                     "type": "string"
                                                                 },
@@ -7739,9 +8192,17 @@ var schemas = `
                                                                     "description": "This assembly's serial number",
                                                                     "type": "string"
                                                                 },
+                                                                "completesDirective": {
+                                                                    "description": "The directiveID of an airworthiness directive that this maintenance action complies with. Closes the directive on the assembly. Required for the comply action, which leaves an installed assembly on its aircraft.",
+                                                                    "type": "string"
+                                                                },
                                                                 "note": {
                                                                     "description": "Maintenance note for this action. Overwritten whenever a new note property is inserted into the maintenance sub-event.",
                                                                     "type": "string"
+                                                                },
+                                                                "parent": {
+                                                                    "description": "The serial number of the parent assembly into / from which the assembly has been installed / uninstalled. Not allowed together with aircraft.",
+                                                                    "type": "string"
                                                                 }
                                                             },
                                                             "required": [
@@ -7840,6 +8301,10 @@ var schemas = `
                                             "description": "Initial assembly life limit.",
                                             "type": "integer"
                                         },
+                                        "manufacturer": {
+                                            "description": "The assembly manufacturer. Used to target airworthiness directives.",
+                                            "type": "string"
+                                        },
                                         "name": {
                                             "description": "The assembly name.",
                                             "type": "string"
@@ -7874,37 +8339,154 @@ var schemas = `
             },
             "type": "object"
         },
-        "readAssetSamples": {
-            "description": "Returns a string generated from the schema that contains sample Objects as specified in the file 'generate.json' in the /scripts folder.",
+        "readAssetGenealogy": {
+            "description": "Returns the genealogy of an assembly: its parent, the path of ancestors up to the top assembly, the aircraft that the top assembly is installed on, the tree of installed sub-assemblies, every aircraft that the assembly has flown on and its install / uninstall history. Argument is a JSON encoded string. The arg is an 'assetID' property.",
             "properties": {
                 "args": {
-                    "description": "accepts no arguments",
-                    "items": {},
-                    "maxItems": 0,
-                    "minItems": 0,
+                    "description": "args are JSON encoded strings",
+                    "items": {
+                        "description": "Requested 'assetID' in an object.",
+                        "properties": {
+                            "assetID": {
+                                "description": "The ID of a managed asset. The resource focal point for a smart contract.",
+                                "type": "string"
+                            }
+                        },
+                        "required": [
+                            "assetID"
+                        ],
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
                     "type": "array"
                 },
                 "function": {
-                    "description": "readAssetSamples function",
+                    "description": "readAssetGenealogy function",
                     "enum": [
-                        "readAssetSamples"
+                        "readAssetGenealogy"
                     ],
                     "type": "string"
                 },
                 "method": "query",
                 "result": {
-                    "description": "JSON encoded object containing selected sample data",
-                    "type": "string"
-                }
-            },
-            "type": "object"
-        },
-        "readAssetSchemas": {
-            "description": "Returns a string generated from the schema that contains APIs and Objects as specified in the file 'generate.json' in the /scripts folder.",
-            "properties": {
-                "args": {
-                    "description": "accepts no arguments",
-                    "items": {},
+                    "description": "The genealogy of the assembly.",
+                    "properties": {
+                        "aircraft": {
+                            "description": "The assetID of the aircraft that the top assembly is installed on. Blank when not on an aircraft.",
+                            "type": "string"
+                        },
+                        "aircraftHistory": {
+                            "description": "Every aircraft the assembly has been installed on, directly or through a parent, in order of first install.",
+                            "items": {
+                                "type": "string"
+                            },
+                            "type": "array"
+                        },
+                        "assetID": {
+                            "description": "The assembly's assetID.",
+                            "type": "string"
+                        },
+                        "children": {
+                            "description": "The tree of installed sub-assemblies.",
+                            "items": {
+                                "properties": {
+                                    "assetID": {
+                                        "description": "The sub-assembly's assetID.",
+                                        "type": "string"
+                                    },
+                                    "children": {
+                                        "description": "The sub-assembly's own installed sub-assemblies.",
+                                        "items": {
+                                            "type": "object"
+                                        },
+                                        "type": "array"
+                                    }
+                                },
+                                "type": "object"
+                            },
+                            "type": "array"
+                        },
+                        "history": {
+                            "description": "Install and uninstall history, oldest first.",
+                            "items": {
+                                "properties": {
+                                    "action": {
+                                        "description": "install or uninstall",
+                                        "type": "string"
+                                    },
+                                    "aircraft": {
+                                        "type": "string"
+                                    },
+                                    "assetID": {
+                                        "type": "string"
+                                    },
+                                    "parent": {
+                                        "type": "string"
+                                    },
+                                    "timestamp": {
+                                        "type": "string"
+                                    },
+                                    "txnuuid": {
+                                        "type": "string"
+                                    },
+                                    "via": {
+                                        "description": "The ancestor assembly that was installed or uninstalled, blank when it was this assembly.",
+                                        "type": "string"
+                                    }
+                                },
+                                "type": "object"
+                            },
+                            "type": "array"
+                        },
+                        "parent": {
+                            "description": "The assetID of the assembly this one is installed in. Blank when not installed in an assembly.",
+                            "type": "string"
+                        },
+                        "path": {
+                            "description": "Ancestor assemblies from the top assembly down to the parent.",
+                            "items": {
+                                "type": "string"
+                            },
+                            "type": "array"
+                        }
+                    },
+                    "type": "object"
+                }
+            },
+            "type": "object"
+        },
+        "readAssetSamples": {
+            "description": "Returns a string generated from the schema that contains sample Objects as specified in the file 'generate.json' in the /scripts folder.",
+            "properties": {
+                "args": {
+                    "description": "accepts no arguments",
+                    "items": {},
+                    "maxItems": 0,
+                    "minItems": 0,
+                    "type": "array"
+                },
+                "function": {
+                    "description": "readAssetSamples function",
+                    "enum": [
+                        "readAssetSamples"
+                    ],
+                    "type": "string"
+                },
+                "method": "query",
+                "result": {
+                    "description": "JSON encoded object containing selected sample data",
+                    "type": "string"
+                }
+            },
+            "type": "object"
+        },
+        "readAssetSchemas": {
+            "description": "Returns a string generated from the schema that contains APIs and Objects as specified in the file 'generate.json' in the /scripts folder.",
+            "properties": {
+                "args": {
+                    "description": "accepts no arguments",
+                    "items": {},
                     "maxItems": 0,
                     "minItems": 0,
                     "type": "array"
@@ -7945,13 +8527,60 @@ var schemas = `
                 "result": {
                     "description": "Dynamic configuration for this contract, consisting of thresholds for alerts.",
                     "properties": {
+                        "aCheckDaysThreshold": {
+                            "description": "Calendar days threshold for the aCheck inspection alert. Zero means no days limit.",
+                            "type": "number"
+                        },
+                        "aCheckHoursThreshold": {
+                            "description": "Flight hours threshold for the aCheck inspection alert. Zero means no hours limit.",
+                            "type": "number"
+                        },
                         "aCheckThreshold": {
                             "description": "Cycles threshold for the aCheck inspection alert.",
                             "type": "number"
                         },
+                        "assemblyTypeThresholds": {
+                            "additionalProperties": {
+                                "properties": {
+                                    "aCheckDaysThreshold": {
+                                        "type": "number"
+                                    },
+                                    "aCheckHoursThreshold": {
+                                        "type": "number"
+                                    },
+                                    "aCheckThreshold": {
+                                        "type": "number"
+                                    },
+                                    "bCheckDaysThreshold": {
+                                        "type": "number"
+                                    },
+                                    "bCheckHoursThreshold": {
+                                        "type": "number"
+                                    },
+                                    "bCheckThreshold": {
+                                        "type": "number"
+                                    }
+                                },
+                                "type": "object"
+                            },
+                            "description": "Thresholds per assembly type, keyed by ATA code (e.g. 32-50) or ATA chapter (e.g. 32). The full ATA code is matched first. Missing or zero thresholds fall back to the contract thresholds.",
+                            "type": "object"
+                        },
+                        "bCheckDaysThreshold": {
+                            "description": "Calendar days threshold for the bCheck inspection alert. Zero means no days limit.",
+                            "type": "number"
+                        },
+                        "bCheckHoursThreshold": {
+                            "description": "Flight hours threshold for the bCheck inspection alert. Zero means no hours limit.",
+                            "type": "number"
+                        },
                         "bCheckThreshold": {
                             "description": "Cycles threshold for the bCheck inspection alert.",
                             "type": "number"
+                        },
+                        "upcomingRatio": {
+                            "description": "Fraction of the first limit at which the ACHECKUPCOMING and BCHECKUPCOMING alerts are raised. Zero disables the upcoming alerts.",
+                            "type": "number"
                         }
                     },
                     "type": "object"
@@ -7995,6 +8624,170 @@ var schemas = `
             },
             "type": "object"
         },
+        "readDirectiveCompliance": {
+            "description": "Returns the compliance status of airworthiness directives, listing the open and closed assemblies per aircraft. Accepts no arguments for all directives, or a JSON encoded string with a 'directiveID' property for one directive.",
+            "properties": {
+                "args": {
+                    "description": "args are JSON encoded strings",
+                    "items": {
+                        "properties": {
+                            "directiveID": {
+                                "description": "The directive to report on.",
+                                "type": "string"
+                            }
+                        },
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 0,
+                    "type": "array"
+                },
+                "function": {
+                    "description": "readDirectiveCompliance function",
+                    "enum": [
+                        "readDirectiveCompliance"
+                    ],
+                    "type": "string"
+                },
+                "method": "query",
+                "result": {
+                    "description": "One entry per directive, sorted by directiveID.",
+                    "items": {
+                        "properties": {
+                            "aircraft": {
+                                "description": "Matching assemblies per aircraft, sorted by aircraft.",
+                                "items": {
+                                    "description": "The assemblies that a directive applies to on one aircraft.",
+                                    "properties": {
+                                        "aircraft": {
+                                            "description": "The aircraft, blank for assemblies that are not installed.",
+                                            "type": "string"
+                                        },
+                                        "closed": {
+                                            "description": "Assemblies that have complied.",
+                                            "items": {
+                                                "type": "string"
+                                            },
+                                            "type": "array"
+                                        },
+                                        "open": {
+                                            "description": "Assemblies that have not complied.",
+                                            "items": {
+                                                "type": "string"
+                                            },
+                                            "type": "array"
+                                        },
+                                        "status": {
+                                            "enum": [
+                                                "open",
+                                                "closed"
+                                            ],
+                                            "type": "string"
+                                        }
+                                    },
+                                    "type": "object"
+                                },
+                                "type": "array"
+                            },
+                            "directive": {
+                                "description": "An airworthiness directive. At least one of ataCode, manufacturer, serialFrom or serialTo is required and every one that is set must match an assembly.",
+                                "properties": {
+                                    "ataCode": {
+                                        "description": "Targets assemblies of this type. A chapter such as 32 also targets 32-50.",
+                                        "type": "string"
+                                    },
+                                    "deadline": {
+                                        "description": "Compliance deadline.",
+                                        "format": "date-time",
+                                        "type": "string"
+                                    },
+                                    "description": {
+                                        "description": "The required action.",
+                                        "type": "string"
+                                    },
+                                    "directiveID": {
+                                        "description": "The directive identifier, must not contain '.'",
+                                        "type": "string"
+                                    },
+                                    "issued": {
+                                        "description": "Transaction timestamp of createDirective, set by the contract.",
+                                        "format": "date-time",
+                                        "type": "string"
+                                    },
+                                    "manufacturer": {
+                                        "description": "Targets assemblies from this manufacturer.",
+                                        "type": "string"
+                                    },
+                                    "serialFrom": {
+                                        "description": "Targets assemblies with serial numbers from this one, inclusive.",
+                                        "type": "string"
+                                    },
+                                    "serialTo": {
+                                        "description": "Targets assemblies with serial numbers up to this one, inclusive.",
+                                        "type": "string"
+                                    },
+                                    "title": {
+                                        "description": "The directive title.",
+                                        "type": "string"
+                                    },
+                                    "txnuuid": {
+                                        "description": "Transaction UUID of createDirective, set by the contract.",
+                                        "type": "string"
+                                    }
+                                },
+                                "required": [
+                                    "directiveID",
+                                    "deadline"
+                                ],
+                                "type": "object"
+                            },
+                            "notInstalled": {
+                                "description": "The assemblies that a directive applies to on one aircraft.",
+                                "properties": {
+                                    "aircraft": {
+                                        "description": "The aircraft, blank for assemblies that are not installed.",
+                                        "type": "string"
+                                    },
+                                    "closed": {
+                                        "description": "Assemblies that have complied.",
+                                        "items": {
+                                            "type": "string"
+                                        },
+                                        "type": "array"
+                                    },
+                                    "open": {
+                                        "description": "Assemblies that have not complied.",
+                                        "items": {
+                                            "type": "string"
+                                        },
+                                        "type": "array"
+                                    },
+                                    "status": {
+                                        "enum": [
+                                            "open",
+                                            "closed"
+                                        ],
+                                        "type": "string"
+                                    }
+                                },
+                                "type": "object"
+                            },
+                            "status": {
+                                "description": "Open while any matching assembly has not completed the directive.",
+                                "enum": [
+                                    "open",
+                                    "closed"
+                                ],
+                                "type": "string"
+                            }
+                        },
+                        "type": "object"
+                    },
+                    "type": "array"
+                }
+            },
+            "type": "object"
+        },
         "readRecentStates": {
             "description": "Returns the state of recently updated assets as an array of objects sorted with the most recently updated asset first. Each asset appears exactly once up to a maxmum of 20 in this version of the contract.",
             "properties": {
@@ -8023,11 +8816,14 @@ var schemas = `
                                 "properties": {
                                     "active": {
                                         "items": {
-                                            "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts.",
+                                            "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts. The UPCOMING alerts warn that an ACHECK or BCHECK is nearly due and are cleared by the same inspection. AD_OPEN signals an airworthiness directive that the assembly has not yet complied with and is cleared by a maintenance event that completes the directive.",
                                             "enum": [
                                                 "ACHECK",
                                                 "BCHECK",
-                                                "HARDLANDING"
+                                                "HARDLANDING",
+                                                "ACHECKUPCOMING",
+                                                "BCHECKUPCOMING",
+                                                "AD_OPEN"
                                             ],
                                             "type": "string"
                                         },
@@ -8036,11 +8832,14 @@ var schemas = `
                                     },
                                     "cleared": {
                                         "items": {
-                                            "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts.",
+                                            "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts. The UPCOMING alerts warn that an ACHECK or BCHECK is nearly due and are cleared by the same inspection. AD_OPEN signals an airworthiness directive that the assembly has not yet complied with and is cleared by a maintenance event that completes the directive.",
                                             "enum": [
                                                 "ACHECK",
                                                 "BCHECK",
-                                                "HARDLANDING"
+                                                "HARDLANDING",
+                                                "ACHECKUPCOMING",
+                                                "BCHECKUPCOMING",
+                                                "AD_OPEN"
                                             ],
                                             "type": "string"
                                         },
@@ -8049,11 +8848,14 @@ var schemas = `
                                     },
                                     "raised": {
                                         "items": {
-                                            "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts.",
+                                            "description": "These alerts signal a required inspection. Only the inspection event with an action of the same name can clear these alewrts. The UPCOMING alerts warn that an ACHECK or BCHECK is nearly due and are cleared by the same inspection. AD_OPEN signals an airworthiness directive that the assembly has not yet complied with and is cleared by a maintenance event that completes the directive.",
                                             "enum": [
                                                 "ACHECK",
                                                 "BCHECK",
-                                                "HARDLANDING"
+                                                "HARDLANDING",
+                                                "ACHECKUPCOMING",
+                                                "BCHECKUPCOMING",
+                                                "AD_OPEN"
                                             ],
                                             "type": "string"
                                         },
@@ -8372,6 +9174,10 @@ var schemas = `
                                                                     "description": "Initial assembly life limit.",
                                                                     "type": "integer"
                                                                 },
+                                                                "manufacturer": {
+                                                                    "description": "The assembly manufacturer. Used to target airworthiness directives.",
+                                                                    "type": "string"
+                                                                },
                                                                 "name": {
                                                                     "description": "The assembly name.",
                                                                     "type": "string"
@@ -8455,6 +9261,10 @@ var schemas = `
                                                                     "description": "actual time departure",
                                                                     "type": "string"
                                                                 },
+                                                                "flightHours": {
+                                                                    "description": "flight duration in hours, accumulated against the inspection hours limits",
+                                                                    "type": "number"
+                                                                },
                                                                 "flightnumber": {
                                                                     "description": "A flight number",
                                                                     "type": "string"
@@ -8531,7 +9341,8 @@ var schemas = `
                                                                         "uninstall",
                                                                         "startMaintenance",
                                                                         "endMaintenance",
-                                                                        "scrap"
+                                                                        "scrap",
+                                                                        "comply"
                                                                     ],
                                                                     "type": "string"
                                                                 },
@@ -8543,9 +9354,17 @@ var schemas = `
                                                                     "description": "This assembly's serial number",
                                                                     "type": "string"
                                                                 },
+                                                                "completesDirective": {
+                                                                    "description": "The directiveID of an airworthiness directive that this maintenance action complies with. Closes the directive on the assembly. Required for the comply action, which leaves an installed assembly on its aircraft.",
+                                                                    "type": "string"
+                                                                },
                                                                 "note": {
                                                                     "description": "Maintenance note for this action. Overwritten whenever a new note property is inserted into the maintenance sub-event.",
                                                                     "type": "string"
+                                                                },
+                                                                "parent": {
+                                                                    "description": "The serial number of the parent assembly into / from which the assembly has been installed / uninstalled. Not allowed together with aircraft.",
+                                                                    "type": "string"
                                                                 }
                                                             },
                                                             "required": [
@@ -8644,6 +9463,10 @@ var schemas = `
                                             "description": "Initial assembly life limit.",
                                             "type": "integer"
                                         },
+                                        "manufacturer": {
+                                            "description": "The assembly manufacturer. Used to target airworthiness directives.",
+                                            "type": "string"
+                                        },
                                         "name": {
                                             "description": "The assembly name.",
                                             "type": "string"
@@ -8990,6 +9813,10 @@ var schemas = `
                                         "description": "Initial assembly life limit.",
                                         "type": "integer"
                                     },
+                                    "manufacturer": {
+                                        "description": "The assembly manufacturer. Used to target airworthiness directives.",
+                                        "type": "string"
+                                    },
                                     "name": {
                                         "description": "The assembly name.",
                                         "type": "string"
@@ -9077,13 +9904,60 @@ var schemas = `
                     "items": {
                         "description": "Dynamic configuration for this contract, consisting of thresholds for alerts.",
                         "properties": {
+                            "aCheckDaysThreshold": {
+                                "description": "Calendar days threshold for the aCheck inspection alert. Zero means no days limit.",
+                                "type": "number"
+                            },
+                            "aCheckHoursThreshold": {
+                                "description": "Flight hours threshold for the aCheck inspection alert. Zero means no hours limit.",
+                                "type": "number"
+                            },
                             "aCheckThreshold": {
                                 "description": "Cycles threshold for the aCheck inspection alert.",
                                 "type": "number"
                             },
+                            "assemblyTypeThresholds": {
+                                "additionalProperties": {
+                                    "properties": {
+                                        "aCheckDaysThreshold": {
+                                            "type": "number"
+                                        },
+                                        "aCheckHoursThreshold": {
+                                            "type": "number"
+                                        },
+                                        "aCheckThreshold": {
+                                            "type": "number"
+                                        },
+                                        "bCheckDaysThreshold": {
+                                            "type": "number"
+                                        },
+                                        "bCheckHoursThreshold": {
+                                            "type": "number"
+                                        },
+                                        "bCheckThreshold": {
+                                            "type": "number"
+                                        }
+                                    },
+                                    "type": "object"
+                                },
+                                "description": "Thresholds per assembly type, keyed by ATA code (e.g. 32-50) or ATA chapter (e.g. 32). The full ATA code is matched first. Missing or zero thresholds fall back to the contract thresholds.",
+                                "type": "object"
+                            },
+                            "bCheckDaysThreshold": {
+                                "description": "Calendar days threshold for the bCheck inspection alert. Zero means no days limit.",
+                                "type": "number"
+                            },
+                            "bCheckHoursThreshold": {
+                                "description": "Flight hours threshold for the bCheck inspection alert. Zero means no hours limit.",
+                                "type": "number"
+                            },
                             "bCheckThreshold": {
                                 "description": "Cycles threshold for the bCheck inspection alert.",
                                 "type": "number"
+                            },
+                            "upcomingRatio": {
+                                "description": "Fraction of the first limit at which the ACHECKUPCOMING and BCHECKUPCOMING alerts are raised. Zero disables the upcoming alerts.",
+                                "type": "number"
                             }
                         },
                         "type": "object"
@@ -9309,6 +10183,10 @@ var schemas = `
                 "cycles": {
                     "description": "Total number of cycles for this aircraft",
                     "type": "number"
+                },
+                "flightHours": {
+                    "description": "Total flight hours for this aircraft.",
+                    "type": "number"
                 }
             },
             "type": "object"
@@ -9491,6 +10369,10 @@ var schemas = `
                             "description": "Initial assembly life limit.",
                             "type": "integer"
                         },
+                        "manufacturer": {
+                            "description": "The assembly manufacturer. Used to target airworthiness directives.",
+                            "type": "string"
+                        },
                         "name": {
                             "description": "The assembly name.",
                             "type": "string"
@@ -9567,6 +10449,45 @@ var schemas = `
                     "description": "ACheckCounter plus analytic adjustments. Can be larger or smaller than aCheckCounter. Used for rule calculations.",
                     "type": "number"
                 },
+                "aCheckDate": {
+                    "description": "Transaction timestamp of the last ACHECK or BCHECK, or of the first event for this assembly. Starts the calendar days limit.",
+                    "type": "string"
+                },
+                "aCheckDue": {
+                    "description": "How close an assembly is to its next inspection. The inspection falls due on whichever limit is reached first.",
+                    "properties": {
+                        "cyclesRemaining": {
+                            "description": "Cycles left before the cycles limit. Absent when there is no cycles limit.",
+                            "type": "number"
+                        },
+                        "daysRemaining": {
+                            "description": "Calendar days left before the days limit. Absent when there is no days limit.",
+                            "type": "number"
+                        },
+                        "hoursRemaining": {
+                            "description": "Flight hours left before the hours limit. Absent when there is no hours limit.",
+                            "type": "number"
+                        },
+                        "limit": {
+                            "description": "The limit that is closest to being reached.",
+                            "enum": [
+                                "cycles",
+                                "hours",
+                                "days"
+                            ],
+                            "type": "string"
+                        },
+                        "ratio": {
+                            "description": "Used fraction of the limit that is closest to being reached. The inspection is due at 1.",
+                            "type": "number"
+                        }
+                    },
+                    "type": "object"
+                },
+                "aCheckHours": {
+                    "description": "Flight hours since the last ACHECK or BCHECK.",
+                    "type": "number"
+                },
                 "adjustedCycles": {
                     "description": "Cycles plus analytic adjustments for this assembly.",
                     "type": "integer"
@@ -9575,6 +10496,13 @@ var schemas = `
                     "description": "The assetID of the aircraft on which this assembly is mounted. Blank if removed for maintenance.",
                     "type": "string"
                 },
+                "assemblies": {
+                    "description": "The assetIDs of the sub-assemblies installed in this assembly.",
+                    "items": {
+                        "type": "string"
+                    },
+                    "type": "array"
+                },
                 "assembly": {
                     "description": "The set of writable properties that define an assembly. Note that assetID is the assembly serial number",
                     "properties": {
@@ -9590,6 +10518,10 @@ var schemas = `
                             "description": "Initial assembly life limit.",
                             "type": "integer"
                         },
+                        "manufacturer": {
+                            "description": "The assembly manufacturer. Used to target airworthiness directives.",
+                            "type": "string"
+                        },
                         "name": {
                             "description": "The assembly name.",
                             "type": "string"
@@ -9614,6 +10546,45 @@ var schemas = `
                     "description": "BCheckCounter plus analytic adjustments. Can be larger or smaller than aCheckCounter. Used for rule calculations.",
                     "type": "number"
                 },
+                "bCheckDate": {
+                    "description": "Transaction timestamp of the last BCHECK, or of the first event for this assembly. Starts the calendar days limit.",
+                    "type": "string"
+                },
+                "bCheckDue": {
+                    "description": "How close an assembly is to its next inspection. The inspection falls due on whichever limit is reached first.",
+                    "properties": {
+                        "cyclesRemaining": {
+                            "description": "Cycles left before the cycles limit. Absent when there is no cycles limit.",
+                            "type": "number"
+                        },
+                        "daysRemaining": {
+                            "description": "Calendar days left before the days limit. Absent when there is no days limit.",
+                            "type": "number"
+                        },
+                        "hoursRemaining": {
+                            "description": "Flight hours left before the hours limit. Absent when there is no hours limit.",
+                            "type": "number"
+                        },
+                        "limit": {
+                            "description": "The limit that is closest to being reached.",
+                            "enum": [
+                                "cycles",
+                                "hours",
+                                "days"
+                            ],
+                            "type": "string"
+                        },
+                        "ratio": {
+                            "description": "Used fraction of the limit that is closest to being reached. The inspection is due at 1.",
+                            "type": "number"
+                        }
+                    },
+                    "type": "object"
+                },
+                "bCheckHours": {
+                    "description": "Flight hours since the last BCHECK.",
+                    "type": "number"
+                },
                 "common": {
                     "description": "The set of common properties for any event to a contract that adheres to the IoT contract pattern 'partial state as event' for assets and that may have pure events that are *about* these assets.",
                     "properties": {
@@ -9664,6 +10635,36 @@ var schemas = `
                     "description": "Lifetime cycle count for this assembly.",
                     "type": "integer"
                 },
+                "directives": {
+                    "additionalProperties": {
+                        "properties": {
+                            "completed": {
+                                "description": "Transaction timestamp of the maintenance event that completed the directive.",
+                                "format": "date-time",
+                                "type": "string"
+                            },
+                            "deadline": {
+                                "description": "Compliance deadline.",
+                                "format": "date-time",
+                                "type": "string"
+                            },
+                            "status": {
+                                "enum": [
+                                    "open",
+                                    "closed"
+                                ],
+                                "type": "string"
+                            }
+                        },
+                        "type": "object"
+                    },
+                    "description": "The airworthiness directives that apply to this assembly, keyed by directiveID.",
+                    "type": "object"
+                },
+                "flightHours": {
+                    "description": "Lifetime flight hours for this assembly.",
+                    "type": "number"
+                },
                 "maintenance": {
                     "description": "Maintenance consists of installation of an assembly onto an aircraft or uninstallation of same. When an assembly is not installed on an aircraft, it is said to be in inventory or in maintenance. Thus, there is a status on assemblies showing that.",
                     "properties": {
@@ -9674,7 +10675,8 @@ var schemas = `
                                 "uninstall",
                                 "startMaintenance",
                                 "endMaintenance",
-                                "scrap"
+                                "scrap",
+                                "comply"
                             ],
                             "type": "string"
                         },
@@ -9686,9 +10688,17 @@ var schemas = `
                             "description": "This assembly's serial number",
                             "type": "string"
                         },
+                        "completesDirective": {
+                            "description": "The directiveID of an airworthiness directive that this maintenance action complies with. Closes the directive on the assembly. Required for the comply action, which leaves an installed assembly on its aircraft.",
+                            "type": "string"
+                        },
                         "note": {
                             "description": "Maintenance note for this action. Overwritten whenever a new note property is inserted into the maintenance sub-event.",
                             "type": "string"
+                        },
+                        "parent": {
+                            "description": "The serial number of the parent assembly into / from which the assembly has been installed / uninstalled. Not allowed together with aircraft.",
+                            "type": "string"
                         }
                     },
                     "required": [
@@ -9697,6 +10707,10 @@ var schemas = `
                     ],
                     "type": "object"
                 },
+                "parent": {
+                    "description": "The assetID of the parent assembly in which this assembly is installed. Blank if not installed in an assembly.",
+                    "type": "string"
+                },
                 "status": {
                     "enum": [
                         "new",
@@ -9726,13 +10740,118 @@ var schemas = `
         "contractConfig": {
             "description": "Dynamic configuration for this contract, consisting of thresholds for alerts.",
             "properties": {
+                "aCheckDaysThreshold": {
+                    "description": "Calendar days threshold for the aCheck inspection alert. Zero means no days limit.",
+                    "type": "number"
+                },
+                "aCheckHoursThreshold": {
+                    "description": "Flight hours threshold for the aCheck inspection alert. Zero means no hours limit.",
+                    "type": "number"
+                },
                 "aCheckThreshold": {
                     "description": "Cycles threshold for the aCheck inspection alert.",
                     "type": "number"
                 },
+                "assemblyTypeThresholds": {
+                    "additionalProperties": {
+                        "properties": {
+                            "aCheckDaysThreshold": {
+                                "type": "number"
+                            },
+                            "aCheckHoursThreshold": {
+                                "type": "number"
+                            },
+                            "aCheckThreshold": {
+                                "type": "number"
+                            },
+                            "bCheckDaysThreshold": {
+                                "type": "number"
+                            },
+                            "bCheckHoursThreshold": {
+                                "type": "number"
+                            },
+                            "bCheckThreshold": {
+                                "type": "number"
+                            }
+                        },
+                        "type": "object"
+                    },
+                    "description": "Thresholds per assembly type, keyed by ATA code (e.g. 32-50) or ATA chapter (e.g. 32). The full ATA code is matched first. Missing or zero thresholds fall back to the contract thresholds.",
+                    "type": "object"
+                },
+                "bCheckDaysThreshold": {
+                    "description": "Calendar days threshold for the bCheck inspection alert. Zero means no days limit.",
+                    "type": "number"
+                },
+                "bCheckHoursThreshold": {
+                    "description": "Flight hours threshold for the bCheck inspection alert. Zero means no hours limit.",
+                    "type": "number"
+                },
                 "bCheckThreshold": {
                     "description": "Cycles threshold for the bCheck inspection alert.",
                     "type": "number"
+                },
+                "upcomingRatio": {
+                    "description": "Fraction of the first limit at which the ACHECKUPCOMING and BCHECKUPCOMING alerts are raised. Zero disables the upcoming alerts.",
+                    "type": "number"
+                }
+            },
+            "type": "object"
+        },
+        "directiveEvent": {
+            "description": "directive event",
+            "properties": {
+                "directive": {
+                    "description": "An airworthiness directive. At least one of ataCode, manufacturer, serialFrom or serialTo is required and every one that is set must match an assembly.",
+                    "properties": {
+                        "ataCode": {
+                            "description": "Targets assemblies of this type. A chapter such as 32 also targets 32-50.",
+                            "type": "string"
+                        },
+                        "deadline": {
+                            "description": "Compliance deadline.",
+                            "format": "date-time",
+                            "type": "string"
+                        },
+                        "description": {
+                            "description": "The required action.",
+                            "type": "string"
+                        },
+                        "directiveID": {
+                            "description": "The directive identifier, must not contain '.'",
+                            "type": "string"
+                        },
+                        "issued": {
+                            "description": "Transaction timestamp of createDirective, set by the contract.",
+                            "format": "date-time",
+                            "type": "string"
+                        },
+                        "manufacturer": {
+                            "description": "Targets assemblies from this manufacturer.",
+                            "type": "string"
+                        },
+                        "serialFrom": {
+                            "description": "Targets assemblies with serial numbers from this one, inclusive.",
+                            "type": "string"
+                        },
+                        "serialTo": {
+                            "description": "Targets assemblies with serial numbers up to this one, inclusive.",
+                            "type": "string"
+                        },
+                        "title": {
+                            "description": "The directive title.",
+                            "type": "string"
+                        },
+                        "txnuuid": {
+                            "description": "Transaction UUID of createDirective, set by the contract.",
+                            "type": "string"
+                        }
+                    },
+                    "required": [
+                        "directiveID",
+                        "deadline"
+                    ],
+                    "type": "object"
                 }
             },
             "type": "object"
@@ -9764,6 +10883,10 @@ var schemas = `
                             "description": "actual time departure",
                             "type": "string"
                         },
+                        "flightHours": {
+                            "description": "flight duration in hours, accumulated against the inspection hours limits",
+                            "type": "number"
+                        },
                         "flightnumber": {
                             "description": "A flight number",
                             "type": "string"
@@ -9858,7 +10981,8 @@ var schemas = `
                                 "uninstall",
                                 "startMaintenance",
                                 "endMaintenance",
-                                "scrap"
+                                "scrap",
+                                "comply"
                             ],
                             "type": "string"
                         },
@@ -9870,9 +10994,17 @@ var schemas = `
                             "description": "This assembly's serial number",
                             "type": "string"
                         },
+                        "completesDirective": {
+                            "description": "The directiveID of an airworthiness directive that this maintenance action complies with. Closes the directive on the assembly. Required for the comply action, which leaves an installed assembly on its aircraft.",
+                            "type": "string"
+                        },
                         "note": {
                             "description": "Maintenance note for this action. Overwritten whenever a new note property is inserted into the maintenance sub-event.",
                             "type": "string"
+                        },
+                        "parent": {
+                            "description": "The serial number of the parent assembly into / from which the assembly has been installed / uninstalled. Not allowed together with aircraft.",
+                            "type": "string"
                         }
                     },
                     "required": [
@@ -30,11 +30,17 @@ const (
     // AlertsOVERTEMP the over temperature alert
     // AlertsINVALIDCRTIME     Alerts = 0
     //AlertsINVALIDMDTIME     Alerts = 1
-    AlertsTIMEERROR Alerts = 0
-    AlertsRPMERROR  Alerts = 1
+    AlertsTIMEERROR      Alerts = 0
+    AlertsRPMERROR       Alerts = 1
+    AlertsOVERTEMP       Alerts = 2
+    AlertsVIBRATION      Alerts = 3
+    AlertsMAINTENANCE    Alerts = 4
+    AlertsHVACOVERHEAT   Alerts = 5
+    AlertsHVACOVERCOOL   Alerts = 6
+    AlertsHVACNOTRUNNING Alerts = 7
 
     // AlertsSIZE is to be maintained always as 1 greater than the last alert, giving a size
-    AlertsSIZE Alerts = 2
+    AlertsSIZE Alerts = 8
 )
 
 // AlertsName is a map of ID to name
@@ -43,6 +49,12 @@ var AlertsName = map[int]string{
     // 1: "INVALID_MODIFY_TIME",
     0: "CREATE_TIME_GREATER_THAN_MODIFY_TIME",
     1: "RPM_LESS_THAN_20PERCENT",
+    2: "OVER_TEMPERATURE",
+    3: "HIGH_VIBRATION",
+    4: "MAINTENANCE_DUE",
+    5: "HVAC_OVERHEAT",
+    6: "HVAC_OVERCOOL",
+    7: "HVAC_NOT_RUNNING",
 }
 
 // AlertsValue is a map of name to ID
@@ -51,6 +63,12 @@ var AlertsValue = map[string]int32{
     //"INVALID_MODIFY_TIME": 1,
     "CREATE_TIME_GREATER_THAN_MODIFY_TIME": 0,
     "RPM_LESS_THAN_20PERCENT":              1,
+    "OVER_TEMPERATURE":                     2,
+    "HIGH_VIBRATION":                       3,
+    "MAINTENANCE_DUE":                      4,
+    "HVAC_OVERHEAT":                        5,
+    "HVAC_OVERCOOL":                        6,
+    "HVAC_NOT_RUNNING":                     7,
 }
 
 func (x Alerts) String() string {
@@ -0,0 +1,209 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+// ************************************
+// Rule profiles by equipment type
+// ************************************
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+)
+
+// RULEPROFILESKEY is used to store the rule profiles that override the defaults
+const RULEPROFILESKEY string = "RuleProfiles"
+
+// DEFAULTPROFILE applies to assets whose equipment type has no profile of its own
+const DEFAULTPROFILE string = "default"
+
+// RuleProfile holds the thresholds applied to one type of equipment. A missing
+// threshold disables its rule.
+type RuleProfile struct {
+	MinRPMPercent    *float64 `json:"minRPMPercent,omitempty"`
+	MaxTemperatureC  *float64 `json:"maxTemperatureC,omitempty"`
+	MaxVibration     *float64 `json:"maxVibration,omitempty"`
+	MaintenanceHours *float64 `json:"maintenanceHours,omitempty"`
+	HVACCheck        bool     `json:"hvacCheck"`
+	HVACToleranceC   float64  `json:"hvacToleranceC"`
+}
+
+// RuleProfiles maps an equipment type to its profile
+type RuleProfiles map[string]RuleProfile
+
+func threshold(v float64) *float64 {
+	return &v
+}
+
+// the default profile keeps the original fixed 30% RPM floor, vibration is
+// RMS velocity in mm/s and runtime is in hours since the last maintenance
+var defaultRuleProfiles = RuleProfiles{
+	DEFAULTPROFILE: {MinRPMPercent: threshold(30)},
+	"motor": {
+		MinRPMPercent:    threshold(30),
+		MaxTemperatureC:  threshold(90),
+		MaxVibration:     threshold(7.1),
+		MaintenanceHours: threshold(20000),
+	},
+	"pump": {
+		MinRPMPercent:    threshold(25),
+		MaxTemperatureC:  threshold(80),
+		MaxVibration:     threshold(4.5),
+		MaintenanceHours: threshold(10000),
+	},
+	"chiller": {
+		MaxTemperatureC:  threshold(12),
+		MaxVibration:     threshold(4.5),
+		MaintenanceHours: threshold(8000),
+		HVACCheck:        true,
+		HVACToleranceC:   1,
+	},
+	"air_handler": {
+		MinRPMPercent:    threshold(20),
+		MaxVibration:     threshold(4.5),
+		MaintenanceHours: threshold(4000),
+		HVACCheck:        true,
+		HVACToleranceC:   1,
+	},
+}
+
+// profileFor selects the profile for the asset's assettype, matching the whole type
+// first and then the word after its last underscore (so variable_speed_motor uses motor)
+func (profiles RuleProfiles) profileFor(a *ArgsMap) RuleProfile {
+	assetType := ""
+	if t, found := getObject(*a, ASSETTYPE); found {
+		assetType, _ = t.(string)
+	}
+	assetType = strings.ToLower(strings.TrimSpace(assetType))
+	if assetType == "" {
+		return profiles[DEFAULTPROFILE]
+	}
+	if p, found := profiles[assetType]; found {
+		return p
+	}
+	if i := strings.LastIndex(assetType, "_"); i >= 0 {
+		if p, found := profiles[assetType[i+1:]]; found {
+			return p
+		}
+	}
+	return profiles[DEFAULTPROFILE]
+}
+
+// getRuleProfiles returns the default profiles overridden by those stored in the ledger
+func getRuleProfiles(stub shim.ChaincodeStubInterface) (RuleProfiles, error) {
+	var stored RuleProfiles
+	var profiles = make(RuleProfiles, len(defaultRuleProfiles))
+	for name, p := range defaultRuleProfiles {
+		profiles[name] = p
+	}
+	profilesBytes, err := stub.GetState(RULEPROFILESKEY)
+	if err != nil {
+		err = fmt.Errorf("GETSTATE for rule profiles failed: %s", err)
+		log.Error(err)
+		return nil, err
+	}
+	if len(profilesBytes) == 0 {
+		return profiles, nil
+	}
+	err = json.Unmarshal(profilesBytes, &stored)
+	if err != nil {
+		err = fmt.Errorf("getRuleProfiles failed to unmarshal: %s", err)
+		log.Error(err)
+		return nil, err
+	}
+	for name, p := range stored {
+		profiles[name] = p
+	}
+	return profiles, nil
+}
+
+// ************************************
+// setRuleProfiles
+// ************************************
+
+// setRuleProfiles stores one or more profiles, each replacing the profile of its
+// equipment type in full
+func (t *SimpleChaincode) setRuleProfiles(stub shim.ChaincodeStubInterface, args []string) error {
+	var arg struct {
+		Profiles RuleProfiles `json:"profiles"`
+	}
+	var stored = make(RuleProfiles)
+	var err error
+	if len(args) != 1 {
+		err = errors.New("setRuleProfiles expects a single parameter")
+		log.Error(err)
+		return err
+	}
+	err = json.Unmarshal([]byte(args[0]), &arg)
+	if err != nil {
+		err = fmt.Errorf("setRuleProfiles failed to unmarshal arg: %s", err)
+		log.Error(err)
+		return err
+	}
+	if len(arg.Profiles) == 0 {
+		err = errors.New("setRuleProfiles expects at least one profile")
+		log.Error(err)
+		return err
+	}
+	profilesBytes, err := stub.GetState(RULEPROFILESKEY)
+	if err == nil && len(profilesBytes) > 0 {
+		err = json.Unmarshal(profilesBytes, &stored)
+	}
+	if err != nil {
+		err = fmt.Errorf("setRuleProfiles failed to read stored profiles: %s", err)
+		log.Error(err)
+		return err
+	}
+	for name, p := range arg.Profiles {
+		if p.HVACToleranceC < 0 {
+			err = fmt.Errorf("setRuleProfiles profile %s has a negative HVAC tolerance", name)
+			log.Error(err)
+			return err
+		}
+		stored[strings.ToLower(name)] = p
+	}
+	profilesBytes, err = json.Marshal(stored)
+	if err != nil {
+		err = errors.New("setRuleProfiles failed to marshal")
+		log.Error(err)
+		return err
+	}
+	err = stub.PutState(RULEPROFILESKEY, profilesBytes)
+	if err != nil {
+		err = fmt.Errorf("PUTSTATE rule profiles failed: %s", err)
+		log.Error(err)
+		return err
+	}
+	return nil
+}
+
+// ************************************
+// readRuleProfiles
+// ************************************
+
+// readRuleProfiles returns the profiles in effect, defaults included
+func (t *SimpleChaincode) readRuleProfiles(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	profiles, err := getRuleProfiles(stub)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(profiles)
+}
@@ -27,6 +27,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/ibm-watson-iot/blockchain-samples/contracts/platform/contractlogger"
 	"reflect"
 	"strings"
 	"time"
@@ -70,7 +71,7 @@ const TIMESTAMP string = "timestamp"
 // ArgsMap is a generic map[string]interface{} to be used as a receiver
 type ArgsMap map[string]interface{}
 
-var log = NewContractLogger(DEFAULTNICKNAME, DEFAULTLOGGINGLEVEL)
+var log = contractlogger.NewContractLogger(DEFAULTNICKNAME+"-"+MYVERSION, contractlogger.DEFAULTLOGGINGLEVEL)
 
 // ************************************
 // start the message pumps
@@ -106,7 +107,7 @@ func (t *SimpleChaincode) Init(stub *shim.ChaincodeStub, function string, args [
 		stateArg.Nickname = DEFAULTNICKNAME
 	}
 
-	(*log).setModule(stateArg.Nickname)
+	log.SetModule(stateArg.Nickname + "-" + MYVERSION)
 
 	err = initializeContractState(stub, stateArg.Version, stateArg.Nickname)
 	if err != nil {
@@ -119,6 +120,8 @@ func (t *SimpleChaincode) Init(stub *shim.ChaincodeStub, function string, args [
 
 // Invoke is called in invoke mode to delegate state changing function messages
 func (t *SimpleChaincode) Invoke(stub *shim.ChaincodeStub, function string, args []string) ([]byte, error) {
+	contractlogger.BeginTransaction(stub.UUID, "invoke", function)
+	defer contractlogger.EndTransaction(stub.UUID)
 	if function == "createAsset" {
 		return t.createAsset(stub, args)
 	} else if function == "updateAsset" {
@@ -133,6 +136,8 @@ func (t *SimpleChaincode) Invoke(stub *shim.ChaincodeStub, function string, args
 		return nil, t.setLoggingLevel(stub, args)
 	} else if function == "setCreateOnUpdate" {
 		return nil, t.setCreateOnUpdate(stub, args)
+	} else if function == "setRuleProfiles" {
+		return nil, t.setRuleProfiles(stub, args)
 	}
 	err := fmt.Errorf("Invoke received unknown invocation: %s", function)
 	log.Warning(err)
@@ -141,6 +146,8 @@ func (t *SimpleChaincode) Invoke(stub *shim.ChaincodeStub, function string, args
 
 // Query is called in query mode to delegate non-state-changing queries
 func (t *SimpleChaincode) Query(stub *shim.ChaincodeStub, function string, args []string) ([]byte, error) {
+	contractlogger.BeginTransaction(stub.UUID, "query", function)
+	defer contractlogger.EndTransaction(stub.UUID)
 	if function == "readAsset" {
 		return t.readAsset(stub, args)
 	} else if function == "readAllAssets" {
@@ -157,6 +164,8 @@ func (t *SimpleChaincode) Query(stub *shim.ChaincodeStub, function string, args
 		return t.readContractObjectModel(stub, args)
 	} else if function == "readContractState" {
 		return t.readContractState(stub, args)
+	} else if function == "readRuleProfiles" {
+		return t.readRuleProfiles(stub, args)
 	}
 	// To be added
 	/*   else if function == "readAllAssetsOfType" {
@@ -229,6 +238,7 @@ func (t *SimpleChaincode) createAsset(stub *shim.ChaincodeStub, args []string) (
 			log.Error(err)
 			return nil, err
 		}
+		contractlogger.SetTransactionAsset(stub.UUID, assetID)
 	}
 	// Is asset name present?
 	assetTypeBytes, found := getObject(argsMap, ASSETNAME)
@@ -259,9 +269,17 @@ func (t *SimpleChaincode) createAsset(stub *shim.ChaincodeStub, args []string) (
 	// Once the BlueMix instance supports GetTxnTimestamp, we will incorporate the
 	// changes to the contract
 
+	// start counting runtime from this event, serviced only applies to the event
+	argsMap[MAINTENANCE] = accumulateRuntime(nil, argsMap)
+	delete(argsMap, "serviced")
+
 	// run the rules and raise or clear alerts
 	alerts := newAlertStatus()
-	if argsMap.executeRules(&alerts) {
+	profiles, err := getRuleProfiles(stub)
+	if err != nil {
+		return nil, err
+	}
+	if argsMap.executeRules(&alerts, profiles) {
 		// NOT compliant!
 		log.Noticef("createAsset assetID %s of type %s is noncompliant", assetID, assetType)
 		argsMap["alerts"] = alerts
@@ -394,6 +412,7 @@ func (t *SimpleChaincode) updateAsset(stub *shim.ChaincodeStub, args []string) (
 			log.Error(err)
 			return nil, err
 		}
+		contractlogger.SetTransactionAsset(stub.UUID, assetID)
 	}
 	log.Noticef("updateAsset found assetID %s", assetID)
 
@@ -455,6 +474,10 @@ func (t *SimpleChaincode) updateAsset(stub *shim.ChaincodeStub, args []string) (
 		return nil, err
 	}
 
+	// add the time the asset ran since its previous event, from the running state stored in
+	// the asset, before the event's own running state is merged over it
+	maintenance := accumulateRuntime(ledgerMap, argsMap)
+
 	// now add incoming map values to existing state to merge them
 	// this contract respects the fact that updateAsset can accept a partial state
 	// as the moral equivalent of one or more discrete events
@@ -463,6 +486,8 @@ func (t *SimpleChaincode) updateAsset(stub *shim.ChaincodeStub, args []string) (
 	// ledger has to have common section
 	stateOut := deepMerge(map[string]interface{}(argsMap),
 		map[string]interface{}(ledgerMap))
+	stateOut[MAINTENANCE] = maintenance
+	delete(stateOut, "serviced")
 	log.Debugf("updateAsset assetID %s merged state: %s of type %s", assetID, assetType, stateOut)
 
 	// handle compliance section
@@ -477,7 +502,11 @@ func (t *SimpleChaincode) updateAsset(stub *shim.ChaincodeStub, args []string) (
 		alerts.alertStatusFromMap(a.(map[string]interface{}))
 	}
 	// important: rules need access to the entire calculated state
-	if ledgerMap.executeRules(&alerts) {
+	profiles, err := getRuleProfiles(stub)
+	if err != nil {
+		return nil, err
+	}
+	if ledgerMap.executeRules(&alerts, profiles) {
 		// true means noncompliant
 		log.Noticef("updateAsset assetID %s of type %s is noncompliant", assetID, assetType)
 		// update ledger with new state, if all clear then delete
@@ -579,6 +608,7 @@ func (t *SimpleChaincode) deleteAsset(stub *shim.ChaincodeStub, args []string) (
 			log.Error(err)
 			return nil, err
 		}
+		contractlogger.SetTransactionAsset(stub.UUID, assetID)
 	}
 
 	// Is asset name present?
@@ -627,7 +657,7 @@ func (t *SimpleChaincode) deleteAsset(stub *shim.ChaincodeStub, args []string) (
 	// push the recent state
 	err = removeAssetFromRecentState(stub, sAssetKey)
 	if err != nil {
-		err := fmt.Errorf("deleteAsset asset %s recent state removal failed: %s", assetID, assetType, err)
+		err := fmt.Errorf("deleteAsset asset %s of type %s recent state removal failed: %s", assetID, assetType, err)
 		log.Critical(err)
 		return nil, err
 	}
@@ -680,6 +710,7 @@ func (t *SimpleChaincode) deletePropertiesFromAsset(stub *shim.ChaincodeStub, ar
 			log.Error(err)
 			return nil, err
 		}
+		contractlogger.SetTransactionAsset(stub.UUID, assetID)
 	}
 	// Is asset name present?
 	assetTypeBytes, found := getObject(argsMap, ASSETNAME)
@@ -757,7 +788,7 @@ OUTERDELETELOOP:
 			(!CASESENSITIVEMODE && strings.HasSuffix(strings.ToLower(prop), strings.ToLower(ASSETID)) ||
 				CASESENSITIVEMODE && strings.HasSuffix(prop, ASSETTYPE)) ||
 			(!CASESENSITIVEMODE && strings.HasSuffix(strings.ToLower(prop), strings.ToLower(ASSETTYPE))) {
-			log.Warningf("deletePropertiesFromAsset AssetID %s of type %s cannot delete protected qualified property: %s or type %s", assetID, assetType, prop)
+			log.Warningf("deletePropertiesFromAsset AssetID %s of type %s cannot delete protected qualified property: %s", assetID, assetType, prop)
 		} else {
 			levels := strings.Split(prop, ".")
 			lm := (map[string]interface{})(ledgerMap)
@@ -806,7 +837,11 @@ OUTERDELETELOOP:
 		alerts.alertStatusFromMap(a.(map[string]interface{}))
 	}
 	// important: rules need access to the entire calculated state
-	if ledgerMap.executeRules(&alerts) {
+	profiles, err := getRuleProfiles(stub)
+	if err != nil {
+		return nil, err
+	}
+	if ledgerMap.executeRules(&alerts, profiles) {
 		// true means noncompliant
 		log.Noticef("deletePropertiesFromAsset assetID %s of type %s is noncompliant", assetID, assetType)
 		// update ledger with new state, if all clear then delete
@@ -957,6 +992,7 @@ func (t *SimpleChaincode) readAsset(stub *shim.ChaincodeStub, args []string) ([]
 			log.Error(err)
 			return nil, err
 		}
+		contractlogger.SetTransactionAsset(stub.UUID, assetID)
 	}
 	// Is asset name present?
 	assetTypeBytes, found := getObject(argsMap, ASSETNAME)
@@ -1091,6 +1127,7 @@ func (t *SimpleChaincode) readAssetHistory(stub *shim.ChaincodeStub, args []stri
 			log.Error(err)
 			return nil, err
 		}
+		contractlogger.SetTransactionAsset(stub.UUID, assetID)
 	}
 	// Is asset name present?
 	assetTypeBytes, found := getObject(argsMap, ASSETNAME)
@@ -1217,7 +1254,9 @@ func (t *SimpleChaincode) readContractObjectModel(stub *shim.ChaincodeStub, args
 // ************************************
 func (t *SimpleChaincode) setLoggingLevel(stub *shim.ChaincodeStub, args []string) error {
 	type LogLevelArg struct {
-		Level string `json:"logLevel"`
+		Level  string   `json:"logLevel"`
+		Module string   `json:"module"`
+		Redact []string `json:"redact"`
 	}
 	var level LogLevelArg
 	var err error
@@ -1232,9 +1271,16 @@ func (t *SimpleChaincode) setLoggingLevel(stub *shim.ChaincodeStub, args []strin
 		log.Error(err)
 		return err
 	}
-	for i, lev := range logLevelNames {
+	for i, lev := range contractlogger.LogLevelNames {
 		if strings.ToUpper(level.Level) == lev {
-			(*log).SetLoggingLevel(LogLevel(i))
+			if level.Module != "" {
+				contractlogger.SetModuleLoggingLevel(level.Module, contractlogger.LogLevel(i))
+			} else {
+				log.SetLoggingLevel(contractlogger.LogLevel(i))
+			}
+			if level.Redact != nil {
+				contractlogger.SetRedactedPaths(level.Redact)
+			}
 			return nil
 		}
 	}
@@ -27,21 +27,32 @@ Kim Letkeman - Initial Contribution
 
 package main
 
-import (	
-   //"time"
-   // "strconv"
+import (
+    "math"
+    "strconv"
+    "strings"
+    "time"
     )
 
-func (a *ArgsMap) executeRules(alerts *AlertStatus) (bool) {
+func (a *ArgsMap) executeRules(alerts *AlertStatus, profiles RuleProfiles) (bool) {
     log.Debugf("Executing rules input: %v", *alerts)
     var internal = (*alerts).asAlertStatusInternal()
+    // thresholds depend on the type of equipment
+    var profile = profiles.profileFor(a)
 
     // rule 1 -- Create and mod time check
     internal.timeCheck(a)
     // rule 2 --RPM check : if motor is running at 20% or below, it will likely overheat
-    internal.rpmCheck(a)
-    // rule 3 -- HVAC Check. If the HVAC is not running, that is an alert scenario
-    //internal.hvacCheck(a)
+    internal.rpmCheck(a, profile)
+    // rule 3 -- temperature check against the profile's limit
+    internal.temperatureCheck(a, profile)
+    // rule 4 -- vibration check against the profile's limit
+    internal.vibrationCheck(a, profile)
+    // rule 5 -- maintenance is due once the runtime the contract accumulated since the last service
+    //           reaches the profile's interval
+    internal.maintenanceCheck(a, profile)
+    // rule 6 -- HVAC Check. If the HVAC is not running, that is an alert scenario
+    internal.hvacCheck(a, profile)
     // now transform internal back to external in order to give the contract the
     // appropriate JSON to send externally
     *alerts = internal.asAlertStatus()
@@ -53,69 +64,228 @@ func (a *ArgsMap) executeRules(alerts *AlertStatus) (bool) {
     return !compliant
 }
 
+// getReading finds a numeric property at the top level of the state or inside
+// its indicators, as devices report either way
+func getReading(a *ArgsMap, prop string) (float64, bool) {
+    for _, qprop := range []string{prop, "indicators." + prop} {
+        v, found := getObject(*a, qprop)
+        if !found {
+            continue
+        }
+        switch t := v.(type) {
+        case float64:
+            return t, true
+        case string:
+            f, err := strconv.ParseFloat(strings.TrimSpace(t), 64)
+            if err == nil {
+                return f, true
+            }
+        }
+    }
+    return 0, false
+}
+
+// MAINTENANCE is the contract's record of the asset's runtime since its last service, kept
+// in the asset state. Devices do not send it, the contract replaces whatever they do send
+const MAINTENANCE string = "maintenance"
+
+// accumulateRuntime returns the maintenance record for the state after the event. The asset
+// counts as running from an event that reports running, or an rpm above zero, until one that
+// reports otherwise, and the time between event timestamps while it runs is added to the
+// runtime. An event with serviced true starts the runtime again from zero. Events without a
+// timestamp, or older than the last one counted, leave the record as it was
+func accumulateRuntime(prior ArgsMap, event ArgsMap) map[string]interface{} {
+    var record = map[string]interface{}{"running": false, "runtime_hours": 0.0}
+    if prior != nil {
+        m, _ := getObject(prior, MAINTENANCE)
+        if m, found := m.(map[string]interface{}); found {
+            for k, v := range m {
+                record[k] = v
+            }
+        }
+    }
+    tEvent, found := getTimestamp(&event, TIMESTAMP)
+    if !found {
+        log.Warningf("event has no %s, runtime is not accumulated", TIMESTAMP)
+        return record
+    }
+    var since = ArgsMap(record)
+    tSince, found := getTimestamp(&since, "since")
+    if found && tEvent.Before(tSince) {
+        log.Warningf("event at %s is older than the runtime counted to %s", tEvent, tSince)
+        return record
+    }
+    runtime, _ := record["runtime_hours"].(float64)
+    running, _ := record["running"].(bool)
+    if found && running {
+        runtime += tEvent.Sub(tSince).Hours()
+    }
+    if serviced, _ := getObject(event, "serviced"); serviced == true {
+        runtime = 0
+        record["serviced_at"] = tEvent.UTC().Format(time.RFC3339Nano)
+    }
+    if r, found := isRunning(&event); found {
+        running = r
+    }
+    record["running"] = running
+    record["runtime_hours"] = runtime
+    record["since"] = tEvent.UTC().Format(time.RFC3339Nano)
+    return record
+}
+
+// isRunning reads whether the event reports the asset running, from its running flag or
+// else from its rpm
+func isRunning(a *ArgsMap) (bool, bool) {
+    for _, qprop := range []string{"running", "indicators.running"} {
+        v, _ := getObject(*a, qprop)
+        if b, found := v.(bool); found {
+            return b, true
+        }
+    }
+    rpm, found := getReading(a, "rpm")
+    if found {
+        return rpm > 0, true
+    }
+    return false, false
+}
+
+// getTimestamp accepts epoch milliseconds or RFC3339 strings
+func getTimestamp(a *ArgsMap, prop string) (time.Time, bool) {
+    v, found := getObject(*a, prop)
+    if !found {
+        return time.Time{}, false
+    }
+    switch t := v.(type) {
+    case float64:
+        return time.Unix(0, int64(t)*int64(time.Millisecond)), true
+    case string:
+        ts, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(t))
+        if err == nil {
+            return ts, true
+        }
+        ms, err := strconv.ParseFloat(strings.TrimSpace(t), 64)
+        if err == nil {
+            return time.Unix(0, int64(ms)*int64(time.Millisecond)), true
+        }
+    }
+    log.Warningf("timestamp %s has an unrecognized format: %v", prop, v)
+    return time.Time{}, false
+}
+
 //***********************************
 //**           RULES               **
 //***********************************
 
 func (alerts *AlertStatusInternal) timeCheck (a *ArgsMap) {
-//var createTime time.Time
-//var modTime time.Time
-    /*
-    now := time.Now()
-    unixNano := now.UnixNano()                                                                      
-    umillisec := unixNano / 1000000  */
-    crTime, found := getObject(*a, "create_date")
-    mdTime, found2 := getObject(*a, "last_mod_date")
+    crTime, found := getTimestamp(a, "create_date")
+    mdTime, found2 := getTimestamp(a, "last_mod_date")
     if found && found2 {
-        //modTime= time.Unix(0, msInt*int64(time.Millisecond))
-        if crTime.(float64) > mdTime.(float64) {
+        if crTime.After(mdTime) {
             alerts.raiseAlert(AlertsTIMEERROR)
-        return
+            return
         }
         alerts.clearAlert(AlertsTIMEERROR)
     }
 }
 // Need to modify so that for motor, this ic called first 
-func (alerts *AlertStatusInternal) rpmCheck (a *ArgsMap) {
+func (alerts *AlertStatusInternal) rpmCheck (a *ArgsMap, profile RuleProfile) {
 //Reference : http://www.vfds.in/be-aware-of-vfd-running-in-low-speed-frequency-655982.html
-    maxRPM, found := getObject(*a, "max_rpm")
-    if found {
-        curRPM, found2 := getObject(*a, "rpm")
-        if found2 {
-            percRPM := (curRPM.(float64)/maxRPM.(float64))*100
-            if percRPM <=30 {
-                alerts.raiseAlert(AlertsRPMERROR)
-                return
+    if profile.MinRPMPercent != nil {
+        maxRPM, found := getReading(a, "max_rpm")
+        if found && maxRPM > 0 {
+            curRPM, found2 := getReading(a, "rpm")
+            if found2 {
+                percRPM := (curRPM/maxRPM)*100
+                if percRPM <= *profile.MinRPMPercent {
+                    alerts.raiseAlert(AlertsRPMERROR)
+                    return
+                }
             }
         }
     }
     alerts.clearAlert(AlertsRPMERROR)
 }
-/*
-func (alerts *AlertStatusInternal) hvacCheck (a *ArgsMap) {
-    hvacMode, found := getObject(*a, "hvac_mode")
-    if found {
-        tgtTemp, found2 := getObject(*a, "target_temperature_c")
-        if found2 {
-            ambTemp, found3 := getObject(*a, "ambient_temperature_c")
-            if found3 {
-                if (ambTemp.(float64) >tgtTemp.(float64) && hvacMode =="heat") {
-                    alerts.raiseAlert(AlertsHVACOVERHEAT)
-                    return
-                }
+
+func (alerts *AlertStatusInternal) temperatureCheck (a *ArgsMap, profile RuleProfile) {
+    if profile.MaxTemperatureC != nil {
+        temp, found := getReading(a, "temperature_c")
+        if found && temp > *profile.MaxTemperatureC {
+            alerts.raiseAlert(AlertsOVERTEMP)
+            return
+        }
+    }
+    alerts.clearAlert(AlertsOVERTEMP)
+}
+
+func (alerts *AlertStatusInternal) vibrationCheck (a *ArgsMap, profile RuleProfile) {
+    if profile.MaxVibration != nil {
+        vib, found := getReading(a, "vibration")
+        if found && vib > *profile.MaxVibration {
+            alerts.raiseAlert(AlertsVIBRATION)
+            return
+        }
+    }
+    alerts.clearAlert(AlertsVIBRATION)
+}
+
+func (alerts *AlertStatusInternal) maintenanceCheck (a *ArgsMap, profile RuleProfile) {
+    if profile.MaintenanceHours != nil {
+        // the runtime accumulated by the contract, not one reported by the device
+        runtime, _ := getObject(*a, MAINTENANCE+".runtime_hours")
+        if hours, found := runtime.(float64); found && hours >= *profile.MaintenanceHours {
+            alerts.raiseAlert(AlertsMAINTENANCE)
+            return
+        }
+    }
+    alerts.clearAlert(AlertsMAINTENANCE)
+}
+
+func (alerts *AlertStatusInternal) hvacCheck (a *ArgsMap, profile RuleProfile) {
+    if profile.HVACCheck {
+        hvacMode, found := getObject(*a, "hvac_mode")
+        if !found {
+            hvacMode, found = getObject(*a, "indicators.hvac_mode")
+        }
+        mode, _ := hvacMode.(string)
+        tgtTemp, found2 := getReading(a, "target_temperature_c")
+        ambTemp, found3 := getReading(a, "ambient_temperature_c")
+        if found && found2 && found3 {
+            tol := profile.HVACToleranceC
+            switch strings.ToLower(mode) {
+            case "heat":
+                alerts.setAlert(AlertsHVACOVERHEAT, ambTemp > tgtTemp+tol)
+                alerts.clearAlert(AlertsHVACOVERCOOL)
+                alerts.clearAlert(AlertsHVACNOTRUNNING)
+            case "cool":
+                alerts.clearAlert(AlertsHVACOVERHEAT)
+                alerts.setAlert(AlertsHVACOVERCOOL, ambTemp < tgtTemp-tol)
+                alerts.clearAlert(AlertsHVACNOTRUNNING)
+            case "off":
+                // switched off while the space has drifted away from its target
                 alerts.clearAlert(AlertsHVACOVERHEAT)
-                if (ambTemp.(float64) <tgtTemp.(float64) && hvacMode =="cool") {
-                    alerts.raiseAlert(AlertsHVACOVERCOOL)
-                    return
-                }
                 alerts.clearAlert(AlertsHVACOVERCOOL)
+                alerts.setAlert(AlertsHVACNOTRUNNING, math.Abs(ambTemp-tgtTemp) > tol)
+            default:
+                // auto and other modes regulate both ways
+                alerts.clearAlert(AlertsHVACOVERHEAT)
+                alerts.clearAlert(AlertsHVACOVERCOOL)
+                alerts.clearAlert(AlertsHVACNOTRUNNING)
             }
+            return
         }
     }
     alerts.clearAlert(AlertsHVACOVERHEAT)
     alerts.clearAlert(AlertsHVACOVERCOOL)
+    alerts.clearAlert(AlertsHVACNOTRUNNING)
+}
+
+func (alerts *AlertStatusInternal) setAlert (alert Alerts, active bool) {
+    if active {
+        alerts.raiseAlert(alert)
+        return
+    }
+    alerts.clearAlert(alert)
 }
-*/
 //***********************************
 //**         COMPLIANCE            **
 //***********************************
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func testEvent(t *testing.T, s string) ArgsMap {
+	var event map[string]interface{}
+	if err := json.Unmarshal([]byte(s), &event); err != nil {
+		t.Fatalf("bad event %s: %s", s, err)
+	}
+	return ArgsMap(event)
+}
+
+// applyEvents runs the events through the runtime accumulation the way createAsset and
+// updateAsset do, with the state round tripped through JSON as it is on the ledger
+func applyEvents(t *testing.T, events ...string) ArgsMap {
+	var state ArgsMap
+	for _, s := range events {
+		event := testEvent(t, s)
+		maintenance := accumulateRuntime(state, event)
+		if state == nil {
+			state = event
+		} else {
+			state = ArgsMap(deepMerge(map[string]interface{}(event), map[string]interface{}(state)))
+		}
+		state[MAINTENANCE] = maintenance
+		delete(state, "serviced")
+		stateJSON, err := json.Marshal(state)
+		if err != nil {
+			t.Fatalf("state did not marshal: %s", err)
+		}
+		state = testEvent(t, string(stateJSON))
+	}
+	return state
+}
+
+func runtimeHours(state ArgsMap) float64 {
+	hours, _ := getObject(state, MAINTENANCE+".runtime_hours")
+	h, _ := hours.(float64)
+	return h
+}
+
+func TestRuntimeAccumulates(t *testing.T) {
+	tests := []struct {
+		name   string
+		events []string
+		hours  float64
+	}{
+		{"never running", []string{
+			`{"timestamp": "2016-04-27T00:00:00Z"}`,
+			`{"timestamp": "2016-04-27T10:00:00Z"}`,
+		}, 0},
+		{"running by rpm", []string{
+			`{"timestamp": "2016-04-27T00:00:00Z", "rpm": 1500}`,
+			`{"timestamp": "2016-04-27T10:00:00Z"}`,
+		}, 10},
+		{"stopped by rpm", []string{
+			`{"timestamp": "2016-04-27T00:00:00Z", "rpm": 1500}`,
+			`{"timestamp": "2016-04-27T04:00:00Z", "rpm": 0}`,
+			`{"timestamp": "2016-04-27T10:00:00Z"}`,
+		}, 4},
+		{"running flag over rpm", []string{
+			`{"timestamp": "2016-04-27T00:00:00Z", "running": true, "rpm": 0}`,
+			`{"timestamp": "2016-04-27T02:00:00Z", "indicators": {"running": false}}`,
+			`{"timestamp": "2016-04-27T03:00:00Z", "indicators": {"running": true}}`,
+			`{"timestamp": "2016-04-27T04:30:00Z"}`,
+		}, 3.5},
+		{"local time stamps", []string{
+			`{"timestamp": "2016-04-27T13:00:00-04:00", "rpm": 1500}`,
+			`{"timestamp": "2016-04-27T19:00:00Z"}`,
+		}, 2},
+		{"epoch milliseconds", []string{
+			`{"timestamp": 1461715200000, "rpm": 1500}`,
+			`{"timestamp": 1461722400000}`,
+		}, 2},
+		{"event without a timestamp", []string{
+			`{"timestamp": "2016-04-27T00:00:00Z", "rpm": 1500}`,
+			`{"rpm": 0}`,
+			`{"timestamp": "2016-04-27T10:00:00Z"}`,
+		}, 10},
+		{"event older than the last one", []string{
+			`{"timestamp": "2016-04-27T00:00:00Z", "rpm": 1500}`,
+			`{"timestamp": "2016-04-27T05:00:00Z"}`,
+			`{"timestamp": "2016-04-27T01:00:00Z", "rpm": 0}`,
+			`{"timestamp": "2016-04-27T10:00:00Z"}`,
+		}, 10},
+		{"reported runtime ignored", []string{
+			`{"timestamp": "2016-04-27T00:00:00Z", "rpm": 1500, "maintenance": {"runtime_hours": 50000}}`,
+			`{"timestamp": "2016-04-27T01:00:00Z", "maintenance": {"runtime_hours": 50000}}`,
+		}, 1},
+	}
+	for _, test := range tests {
+		state := applyEvents(t, test.events...)
+		if math.Abs(runtimeHours(state)-test.hours) > 1e-9 {
+			t.Errorf("%s: expected %v runtime hours, got %v", test.name, test.hours, runtimeHours(state))
+		}
+	}
+}
+
+func TestMaintenanceThreshold(t *testing.T) {
+	profile := RuleProfile{MaintenanceHours: threshold(100)}
+	tests := []struct {
+		name    string
+		state   string
+		profile RuleProfile
+		due     bool
+	}{
+		{"below the interval", `{"maintenance": {"runtime_hours": 99.9}}`, profile, false},
+		{"at the interval", `{"maintenance": {"runtime_hours": 100}}`, profile, true},
+		{"past the interval", `{"maintenance": {"runtime_hours": 250}}`, profile, true},
+		{"no runtime counted", `{}`, profile, false},
+		{"device counter only", `{"runtime_hours": 250}`, profile, false},
+		{"no interval in the profile", `{"maintenance": {"runtime_hours": 250}}`, RuleProfile{}, false},
+	}
+	for _, test := range tests {
+		state := testEvent(t, test.state)
+		var alerts AlertStatusInternal
+		alerts.maintenanceCheck(&state, test.profile)
+		if alerts.Active[AlertsMAINTENANCE] != test.due {
+			t.Errorf("%s: expected maintenance due %v, got %v", test.name, test.due, alerts.Active[AlertsMAINTENANCE])
+		}
+	}
+}
+
+func TestMaintenanceReset(t *testing.T) {
+	profile := RuleProfile{MaintenanceHours: threshold(100)}
+	events := []string{
+		`{"timestamp": "2016-04-01T00:00:00Z", "rpm": 1500}`,
+		`{"timestamp": "2016-04-06T00:00:00Z"}`,
+		`{"timestamp": "2016-04-06T01:00:00Z", "serviced": true}`,
+		`{"timestamp": "2016-04-06T03:00:00Z"}`,
+	}
+	var alerts AlertStatusInternal
+
+	// 120 hours since the asset started running
+	state := applyEvents(t, events[:2]...)
+	alerts.maintenanceCheck(&state, profile)
+	if !alerts.Active[AlertsMAINTENANCE] || !alerts.Raised[AlertsMAINTENANCE] {
+		t.Fatalf("expected maintenance due after %v hours", runtimeHours(state))
+	}
+
+	// serviced while running, the count starts again from the service
+	state = applyEvents(t, events[:3]...)
+	alerts.maintenanceCheck(&state, profile)
+	if alerts.Active[AlertsMAINTENANCE] || !alerts.Cleared[AlertsMAINTENANCE] {
+		t.Errorf("expected maintenance cleared by the service, runtime %v hours", runtimeHours(state))
+	}
+	if _, found := state["serviced"]; found {
+		t.Errorf("expected serviced not to be kept in the state")
+	}
+	serviced, _ := getObject(state, MAINTENANCE+".serviced_at")
+	if serviced != "2016-04-06T01:00:00Z" {
+		t.Errorf("expected the service time to be kept, got %v", serviced)
+	}
+
+	state = applyEvents(t, events...)
+	if runtimeHours(state) != 2 {
+		t.Errorf("expected 2 runtime hours since the service, got %v", runtimeHours(state))
+	}
+}
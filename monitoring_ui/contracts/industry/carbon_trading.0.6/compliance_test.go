@@ -0,0 +1,57 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Risham Chokshi - Initial Contribution
+*/
+
+package main
+
+import (
+    "testing"
+)
+
+func TestComplianceSettle(t *testing.T) {
+    var limit = 0.1
+    var period = CompliancePeriod{PeriodID: "2016", BankingLimit: &limit, PenaltyPerCredit: 100}
+
+    // 100 allotted + 5 banked + 20 bought - 10 sold = 115 held, 80 emitted
+    surplus := ArgsMap{"assetID": "A", "allottedCredits": 100.0, "bankedCredits": 5.0, "boughtCredits": 20.0, "soldCredits": 10.0, "reading": 70.0}
+    report := period.settle(surplus, 80, true)
+    if report.RetiredCredits != 80 || report.Shortfall != 0 || !report.InCompliance || report.Penalty != nil {
+        t.Errorf("surplus company should retire 80 and be compliant: %+v", report)
+    }
+    if report.BankedOut != 10 || report.ForfeitedCredits != 25 {
+        t.Errorf("35 surplus should bank 10 (10%% of 100 allotted) and forfeit 25: %+v", report)
+    }
+    surplus.startNextPeriod(report)
+    if surplus["reading"] != 0.0 || surplus["soldCredits"] != 0.0 || surplus["boughtCredits"] != 0.0 || surplus["bankedCredits"] != 10.0 || surplus["retiredCredits"] != 80.0 {
+        t.Errorf("period totals not reset: %+v", surplus)
+    }
+
+    // without a banking limit the whole surplus is banked
+    period.BankingLimit = nil
+    report = period.settle(ArgsMap{"assetID": "B", "allottedCredits": 50.0, "reading": 20.0}, 20, false)
+    if report.BankedOut != 30 || report.ForfeitedCredits != 0 || report.Verified {
+        t.Errorf("unlimited banking should carry over 30: %+v", report)
+    }
+
+    // 40 held, 55 emitted
+    short := ArgsMap{"assetID": "C", "allottedCredits": 50.0, "soldCredits": 10.0, "reading": 55.0}
+    report = period.settle(short, 55, false)
+    if report.RetiredCredits != 40 || report.Shortfall != 15 || report.InCompliance || report.BankedOut != 0 {
+        t.Errorf("short company should retire 40 and be 15 short: %+v", report)
+    }
+    if report.Penalty == nil || report.Penalty.Amount != 1500 || report.Penalty.PenaltyID != "2016.C" {
+        t.Errorf("shortfall should raise a penalty of 1500: %+v", report.Penalty)
+    }
+}
@@ -37,6 +37,7 @@ import (
     
     "reflect"
     "github.com/hyperledger/fabric/core/chaincode/shim"
+    "github.com/ibm-watson-iot/blockchain-samples/contracts/platform/contractlogger"
     
 )
 
@@ -64,7 +65,7 @@ const TIMESTAMP string = "timestamp"
 // ArgsMap is a generic map[string]interface{} to be used as a receiver 
 type ArgsMap map[string]interface{} 
 
-var log = NewContractLogger(DEFAULTNICKNAME, DEFAULTLOGGINGLEVEL)
+var log = contractlogger.NewContractLogger(DEFAULTNICKNAME+"-"+MYVERSION, contractlogger.DEFAULTLOGGINGLEVEL)
 
 // ************************************
 // start the message pumps 
@@ -100,7 +101,7 @@ func (t *SimpleChaincode) Init(stub shim.ChaincodeStubInterface, function string
         stateArg.Nickname = DEFAULTNICKNAME
     } 
 
-    (*log).setModule(stateArg.Nickname)
+    log.SetModule(stateArg.Nickname + "-" + MYVERSION)
     
     err = initializeContractState(stub, stateArg.Version, stateArg.Nickname)
     if err != nil {
@@ -113,6 +114,8 @@ func (t *SimpleChaincode) Init(stub shim.ChaincodeStubInterface, function string
 
 // Invoke is called in invoke mode to delegate state changing function messages 
 func (t *SimpleChaincode) Invoke(stub shim.ChaincodeStubInterface, function string, args []string) ([]byte, error) {
+    contractlogger.BeginTransaction(stub.GetTxID(), "invoke", function)
+    defer contractlogger.EndTransaction(stub.GetTxID())
     if function == "createAsset" {
         return t.createAsset(stub, args)
     } else if function == "updateAsset" {
@@ -127,6 +130,14 @@ func (t *SimpleChaincode) Invoke(stub shim.ChaincodeStubInterface, function stri
         return nil, t.setLoggingLevel(stub, args)
     } else if function == "setCreateOnUpdate" {
         return nil, t.setCreateOnUpdate(stub, args)
+    } else if function == "placeOrder" {
+        return t.placeOrder(stub, args)
+    } else if function == "cancelOrder" {
+        return t.cancelOrder(stub, args)
+    } else if function == "openCompliancePeriod" {
+        return t.openCompliancePeriod(stub, args)
+    } else if function == "closeCompliancePeriod" {
+        return t.closeCompliancePeriod(stub, args)
     }
     err := fmt.Errorf("Invoke received unknown invocation: %s", function)
     log.Warning(err)
@@ -135,6 +146,8 @@ func (t *SimpleChaincode) Invoke(stub shim.ChaincodeStubInterface, function stri
 
 // Query is called in query mode to delegate non-state-changing queries
 func (t *SimpleChaincode) Query(stub shim.ChaincodeStubInterface, function string, args []string) ([]byte, error) {
+    contractlogger.BeginTransaction(stub.GetTxID(), "query", function)
+    defer contractlogger.EndTransaction(stub.GetTxID())
     if function == "readAsset" {
         return t.readAsset(stub, args)
     } else if function == "readAllAssets" {
@@ -151,6 +164,12 @@ func (t *SimpleChaincode) Query(stub shim.ChaincodeStubInterface, function strin
         return t.readContractObjectModel(stub, args)
     } else if function == "readContractState" {
         return t.readContractState(stub, args)
+    } else if function == "readOrderBook" {
+        return t.readOrderBook(stub, args)
+    } else if function == "readCompliancePeriod" {
+        return t.readCompliancePeriod(stub, args)
+    } else if function == "readComplianceReport" {
+        return t.readComplianceReport(stub, args)
     }
     err := fmt.Errorf("Query received unknown invocation: %s", function)
     log.Warning(err)
@@ -217,6 +236,7 @@ func (t *SimpleChaincode) createAsset(stub shim.ChaincodeStubInterface, args []s
             log.Error(err)
             return nil, err
         }
+        contractlogger.SetTransactionAsset(stub.GetTxID(), assetID)
     }
     //checking if allottedCredits is present or not? 
     //check if asset created is not trade, if it is, then all the field do not need to be present
@@ -249,14 +269,14 @@ func (t *SimpleChaincode) createAsset(stub shim.ChaincodeStubInterface, args []s
         if argsMap["reading"] == nil{
             argsMap["reading"] = 0.0
         }
-        //initialize credits sold for the trade
-        if argsMap["soldCredits"] == nil{
-            argsMap["soldCredits"] = 0.0
-        }
-        //initializing boughtCredits attribute to 0
-        if argsMap["boughtCredits"] == nil{
-            argsMap["boughtCredits"] = 0.0
+        //soldCredits and boughtCredits are kept by the order book and start at 0
+        if argsMap["soldCredits"] != nil || argsMap["boughtCredits"] != nil {
+            err := errors.New("createAsset cannot set soldCredits or boughtCredits, they are updated by trades in the order book")
+            log.Error(err)
+            return nil, err
         }
+        argsMap["soldCredits"] = 0.0
+        argsMap["boughtCredits"] = 0.0
         //check if value of pricePerCredit given to update is not negative
         if argsMap["pricePerCredit"]!=nil && argsMap["pricePerCredit"].(float64) < 0.0 {
             err := errors.New("updateAsset arg pricePerCredit needs be a positive value")
@@ -443,6 +463,7 @@ func (t *SimpleChaincode) updateAsset(stub shim.ChaincodeStubInterface, args []s
             log.Error(err)
             return nil, err
         }
+        contractlogger.SetTransactionAsset(stub.GetTxID(), assetID)
     }
     log.Noticef("updateAsset found assetID %s", assetID)
 
@@ -557,14 +578,11 @@ func (t *SimpleChaincode) updateAsset(stub shim.ChaincodeStubInterface, args []s
             }
             argsMap["contactInformation"] = contactInfo
         }
-        //argument to change number of credits which was sold
-        if argsMap["soldCredits"] != nil{
-            argsMap["soldCredits"] = argsMap["soldCredits"].(float64) + ledgerMap["soldCredits"].(float64)
-        }
-
-        //argument to change number of credits which was bought
-        if argsMap["boughtCredits"] != nil{
-            argsMap["boughtCredits"] = argsMap["boughtCredits"].(float64) + ledgerMap["boughtCredits"].(float64)
+        //soldCredits and boughtCredits are kept by the order book, see placeOrder
+        if argsMap["soldCredits"] != nil || argsMap["boughtCredits"] != nil {
+            err := errors.New("updateAsset cannot set soldCredits or boughtCredits, they are updated by trades in the order book")
+            log.Error(err)
+            return nil, err
         }
         //check if pricePerCredit or creditsForSale values was updated
         //checking error cases with pricePerCredit and creditsForSale
@@ -600,7 +618,15 @@ func (t *SimpleChaincode) updateAsset(stub shim.ChaincodeStubInterface, args []s
             //calculate all the credits put on sale
             totalCred = addValuesInArray(ledgerMap["creditsSellList"].([]interface{}))
         }
-        if argsMap["creditsForSale"] != nil && (argsMap["creditsForSale"].(float64) > (ledgerMap["allottedCredits"].(float64) - ledgerMap["reading"].(float64) - ledgerMap["soldCredits"].(float64) + ledgerMap["boughtCredits"].(float64) - totalCred)){
+        //credits offered in open sell orders are not available either
+        if argsMap["creditsForSale"] != nil {
+            book, err := getOrderBook(stub)
+            if err != nil {
+                return nil, err
+            }
+            totalCred = totalCred + book.openSellCredits(assetID)
+        }
+        if argsMap["creditsForSale"] != nil && (argsMap["creditsForSale"].(float64) > (ledgerMap["allottedCredits"].(float64) + floatValue(ledgerMap, "bankedCredits") - ledgerMap["reading"].(float64) - ledgerMap["soldCredits"].(float64) + ledgerMap["boughtCredits"].(float64) - totalCred)){
             err := errors.New("updateAsset arg creditsForSale needs be less and or equal to remaining credits" + strconv.FormatFloat(ledgerMap["allottedCredits"].(float64) - ledgerMap["reading"].(float64), 'f', -1, 64))
             log.Error(err)
             return nil, err
@@ -724,14 +750,24 @@ func (t *SimpleChaincode) updateAsset(stub shim.ChaincodeStubInterface, args []s
     //checking for all the attributes
      //checking for all the attributes
     if assetID == "trade" && argsMap["tradeCredits"] != nil && argsMap["tradePrice"] != nil && argsMap["tradeTimestamp"] != nil {
-        ledgerMap["tradeHistory"] = ledgerMap.updateTradeBlock(false, argsMap["tradeCredits"].(string), argsMap["tradePrice"].(string), argsMap["tradeTimestamp"].(string), "", "")
+        ledgerMap["tradeHistory"], err = ledgerMap.updateTradeBlock(false, argsMap["tradeCredits"].(string), argsMap["tradePrice"].(string), argsMap["tradeTimestamp"].(string), "", "")
+        if err != nil {
+            err = fmt.Errorf("updateAsset trade history: %s", err)
+            log.Error(err)
+            return nil, err
+        }
     } else if assetID == "trade" && (argsMap["tradeCredits"] != nil || argsMap["tradePrice"] != nil || argsMap["tradeTimestamp"] != nil) {
         //all the fields should be present, throw an error
         err := errors.New("updateAsset some of the attributes are missing for 'trade' asset in trade history: tradeCredits, tradePrice, tradeTimestamp - should be all present")
         log.Error(err)
         return nil, err
     } else if argsMap["tradeCredits"] != nil && argsMap["tradePrice"] != nil && argsMap["tradeTimestamp"] != nil && argsMap["tradeCompany"] != nil && argsMap["tradeBuySell"] != nil {
-        ledgerMap["tradeHistory"] = ledgerMap.updateTradeBlock(true, argsMap["tradeCredits"].(string), argsMap["tradePrice"].(string), argsMap["tradeTimestamp"].(string), argsMap["tradeCompany"].(string), argsMap["tradeBuySell"].(string))
+        ledgerMap["tradeHistory"], err = ledgerMap.updateTradeBlock(true, argsMap["tradeCredits"].(string), argsMap["tradePrice"].(string), argsMap["tradeTimestamp"].(string), argsMap["tradeCompany"].(string), argsMap["tradeBuySell"].(string))
+        if err != nil {
+            err = fmt.Errorf("updateAsset trade history: %s", err)
+            log.Error(err)
+            return nil, err
+        }
     } else if argsMap["tradeCredits"] != nil || argsMap["tradePrice"] != nil || argsMap["tradeTimestamp"] != nil || argsMap["tradeCompany"] != nil || argsMap["tradeBuySell"] != nil {
         //all the fields should be present, throw an error
         err := errors.New("updateAsset some of the attributes are missing for trade history: tradeCredits, tradeCompany, tradePrice, tradeTimestamp, tradeBuySell - should be all present")
@@ -856,6 +892,7 @@ func (t *SimpleChaincode) deleteAsset(stub shim.ChaincodeStubInterface, args []s
             log.Error(err)
             return nil, err
         }
+        contractlogger.SetTransactionAsset(stub.GetTxID(), assetID)
     }
 
     found = assetIsActive(stub, assetID)
@@ -939,6 +976,7 @@ func (t *SimpleChaincode) deletePropertiesFromAsset(stub shim.ChaincodeStubInter
             log.Error(err)
             return nil, err
         }
+        contractlogger.SetTransactionAsset(stub.GetTxID(), assetID)
     }
 
     found = assetIsActive(stub, assetID)
@@ -1205,6 +1243,7 @@ func (t *SimpleChaincode) readAsset(stub shim.ChaincodeStubInterface, args []str
             log.Error(err)
             return nil, err
         }
+        contractlogger.SetTransactionAsset(stub.GetTxID(), assetID)
     }
     
     found = assetIsActive(stub, assetID)
@@ -1318,6 +1357,7 @@ func (t *SimpleChaincode) readAssetHistory(stub shim.ChaincodeStubInterface, arg
             log.Error(err)
             return nil, err
         }
+        contractlogger.SetTransactionAsset(stub.GetTxID(), assetID)
     }
     
     found = assetIsActive(stub, assetID)
@@ -1430,7 +1470,9 @@ func (t *SimpleChaincode) readContractObjectModel(stub shim.ChaincodeStubInterfa
 // ************************************
 func (t *SimpleChaincode) setLoggingLevel(stub shim.ChaincodeStubInterface, args []string) (error) {
     type LogLevelArg struct {
-        Level string `json:"logLevel"`
+        Level  string   `json:"logLevel"`
+        Module string   `json:"module"`
+        Redact []string `json:"redact"`
     }
     var level LogLevelArg
     var err error
@@ -1445,9 +1487,16 @@ func (t *SimpleChaincode) setLoggingLevel(stub shim.ChaincodeStubInterface, args
         log.Error(err)
         return err
     }
-    for i, lev := range logLevelNames {
+    for i, lev := range contractlogger.LogLevelNames {
         if strings.ToUpper(level.Level) == lev {
-            (*log).SetLoggingLevel(LogLevel(i))
+            if level.Module != "" {
+                contractlogger.SetModuleLoggingLevel(level.Module, contractlogger.LogLevel(i))
+            } else {
+                log.SetLoggingLevel(contractlogger.LogLevel(i))
+            }
+            if level.Redact != nil {
+                contractlogger.SetRedactedPaths(level.Redact)
+            }
             return nil
         } 
     }
@@ -1547,7 +1596,7 @@ func checkandConvertType(args interface{})(string){
     case string:
         return strings.Trim(args.(string), " ")
     default:
-        fmt.Printf("Other:%v\n", v)
+        log.Debugf("checkandConvertType received unexpected type %T: %v", v, v)
         return "Type mismatched"
     }
 }
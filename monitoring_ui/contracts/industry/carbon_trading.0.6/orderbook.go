@@ -0,0 +1,542 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Risham Chokshi - Initial Contribution
+*/
+
+// ************************************
+// Order book
+// Companies post buy and sell orders for credits with placeOrder. An incoming order
+// is matched against the other side of the book by price-time priority: best price
+// first, and the earliest order at that price. Each fill trades at the price of the
+// resting order, adds to the seller's soldCredits and the buyer's boughtCredits, and
+// is recorded in the tradeHistory of both companies and of the "trade" asset, all in
+// the same transaction. Whatever is left of the incoming order rests in the book
+// until it is filled or cancelled with cancelOrder.
+//
+// A sell order cannot exceed the credits the company has left, net of credits
+// already offered in open sell orders and in creditsSellList. A company's orders
+// never match each other. The seller's credits are checked again when an order
+// fills, as the company may have used or listed credits since it placed the order:
+// each fill is capped at what the seller still has, and an order whose seller has
+// nothing left stays in the book without filling.
+//
+// The open orders are stored under one world state key:
+//    OrderBookKey
+// ************************************
+
+package main
+
+import (
+    "encoding/json"
+    "errors"
+    "fmt"
+    "sort"
+    "time"
+
+    "github.com/hyperledger/fabric/core/chaincode/shim"
+)
+
+// ORDERBOOKKEY is used to store the open buy and sell orders
+const ORDERBOOKKEY string = "OrderBookKey"
+
+// BUY and SELL are the sides of an order
+const BUY string = "buy"
+const SELL string = "sell"
+
+// Order is a limit order for credits
+type Order struct {
+    OrderID     string      `json:"orderID"`            // transaction id of placeOrder
+    AssetID     string      `json:"assetID"`            // company placing the order
+    BuySell     string      `json:"buysell"`
+    Credits     float64     `json:"credits"`
+    Remaining   float64     `json:"remaining"`          // credits not yet filled
+    Price       float64     `json:"price"`              // limit price per credit
+    Sequence    int64       `json:"sequence"`           // arrival order, for time priority
+    Timestamp   string      `json:"timestamp"`
+}
+
+// OrderBook holds the open orders, each side kept best first
+type OrderBook struct {
+    Sequence    int64       `json:"sequence"`
+    Buy         []Order     `json:"buy"`                // highest price first
+    Sell        []Order     `json:"sell"`               // lowest price first
+}
+
+// ************************************
+// placeOrder
+// ************************************
+func (t *SimpleChaincode) placeOrder(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+    var order Order
+    var err error
+
+    if len(args) != 1 {
+        err = errors.New("placeOrder expects one JSON object with assetID, buysell, credits and price")
+        log.Error(err)
+        return nil, err
+    }
+    err = json.Unmarshal([]byte(args[0]), &order)
+    if err != nil {
+        err = fmt.Errorf("placeOrder failed to unmarshal arg: %s", err)
+        log.Error(err)
+        return nil, err
+    }
+    if order.AssetID == "" || order.AssetID == "trade" || !assetIsActive(stub, order.AssetID) {
+        err = fmt.Errorf("placeOrder company %s does not exist", order.AssetID)
+        log.Error(err)
+        return nil, err
+    }
+    if order.BuySell != BUY && order.BuySell != SELL {
+        err = fmt.Errorf("placeOrder buysell must be %s or %s", BUY, SELL)
+        log.Error(err)
+        return nil, err
+    }
+    if order.Credits <= 0 || order.Price < 0 {
+        err = errors.New("placeOrder credits must be more than 0 and price cannot be negative")
+        log.Error(err)
+        return nil, err
+    }
+    book, err := getOrderBook(stub)
+    if err != nil {
+        return nil, err
+    }
+    if order.BuySell == SELL {
+        company, err := getCompanyState(stub, order.AssetID)
+        if err != nil {
+            return nil, err
+        }
+        available := company.availableCredits() - book.openSellCredits(order.AssetID)
+        if order.Credits > available {
+            err = fmt.Errorf("placeOrder company %s has %s credits available to sell", order.AssetID, formatCredits(available))
+            log.Error(err)
+            return nil, err
+        }
+    }
+    txntimestamp, err := txnTimestamp(stub)
+    if err != nil {
+        return nil, err
+    }
+    order.OrderID = stub.GetTxID()
+    order.Remaining = order.Credits
+    order.Timestamp = txntimestamp.Format(time.RFC3339Nano)
+    book.Sequence++
+    order.Sequence = book.Sequence
+
+    available, err := book.sellerCredits(stub, order)
+    if err != nil {
+        return nil, err
+    }
+    trades := book.match(&order, available)
+    if order.Remaining > 0 {
+        book.add(order)
+    }
+    err = putOrderBook(stub, book)
+    if err != nil {
+        return nil, err
+    }
+    log.Infof("placeOrder %s %s %s credits for %s, %d fills", order.OrderID, order.BuySell, formatCredits(order.Credits), order.AssetID, len(trades))
+    return nil, applyTrades(stub, trades, txntimestamp, "placeOrder", args[0])
+}
+
+// ************************************
+// cancelOrder
+// ************************************
+func (t *SimpleChaincode) cancelOrder(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+    var order Order
+    var err error
+
+    if len(args) != 1 {
+        err = errors.New("cancelOrder expects one JSON object with assetID and orderID")
+        log.Error(err)
+        return nil, err
+    }
+    err = json.Unmarshal([]byte(args[0]), &order)
+    if err != nil {
+        err = fmt.Errorf("cancelOrder failed to unmarshal arg: %s", err)
+        log.Error(err)
+        return nil, err
+    }
+    book, err := getOrderBook(stub)
+    if err != nil {
+        return nil, err
+    }
+    if !book.remove(order.OrderID, order.AssetID) {
+        err = fmt.Errorf("cancelOrder order %s is not open for company %s", order.OrderID, order.AssetID)
+        log.Error(err)
+        return nil, err
+    }
+    return nil, putOrderBook(stub, book)
+}
+
+// ************************************
+// readOrderBook
+// ************************************
+// Returns the open orders, or with an assetID only the open orders of that company
+func (t *SimpleChaincode) readOrderBook(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+    var filter Order
+    var err error
+
+    if len(args) > 1 {
+        err = errors.New("readOrderBook expects no arguments or one JSON object with assetID")
+        log.Error(err)
+        return nil, err
+    }
+    book, err := getOrderBook(stub)
+    if err != nil {
+        return nil, err
+    }
+    if len(args) == 1 {
+        err = json.Unmarshal([]byte(args[0]), &filter)
+        if err != nil {
+            err = fmt.Errorf("readOrderBook failed to unmarshal arg: %s", err)
+            log.Error(err)
+            return nil, err
+        }
+        if filter.AssetID != "" {
+            book.Buy = ordersOf(book.Buy, filter.AssetID)
+            book.Sell = ordersOf(book.Sell, filter.AssetID)
+        }
+    }
+    return json.Marshal(book)
+}
+
+// sellerCredits returns the credits available now to each company that can sell in
+// a match of the order: the company placing a sell order, or the companies with
+// resting sell orders that cross a buy order
+func (book *OrderBook) sellerCredits(stub shim.ChaincodeStubInterface, order Order) (map[string]float64, error) {
+    var available = make(map[string]float64)
+    var sellers = []string{order.AssetID}
+    if order.BuySell == BUY {
+        sellers = sellers[:0]
+        for _, r := range book.Sell {
+            if r.Price <= order.Price && r.AssetID != order.AssetID {
+                sellers = append(sellers, r.AssetID)
+            }
+        }
+    }
+    for _, assetID := range sellers {
+        if _, found := available[assetID]; found {
+            continue
+        }
+        company, err := getCompanyState(stub, assetID)
+        if err != nil {
+            return nil, err
+        }
+        available[assetID] = company.availableCredits()
+    }
+    return available, nil
+}
+
+// match fills the order against the other side of the book by price-time priority.
+// Fully filled resting orders leave the book, the order's Remaining is reduced by
+// what was filled. No fill exceeds the seller's available credits, which are reduced
+// as the seller's orders fill
+func (book *OrderBook) match(order *Order, available map[string]float64) ([]TradeRecord) {
+    var trades []TradeRecord
+    resting := &book.Sell
+    if order.BuySell == SELL {
+        resting = &book.Buy
+    }
+    kept := make([]Order, 0, len(*resting))
+    for _, r := range *resting {
+        crosses := (order.BuySell == BUY && r.Price <= order.Price) || (order.BuySell == SELL && r.Price >= order.Price)
+        if order.Remaining <= 0 || !crosses || r.AssetID == order.AssetID {
+            kept = append(kept, r)
+            continue
+        }
+        seller := r.AssetID
+        if order.BuySell == SELL {
+            seller = order.AssetID
+        }
+        credits := r.Remaining
+        if order.Remaining < credits {
+            credits = order.Remaining
+        }
+        if available[seller] < credits {
+            credits = available[seller]
+        }
+        if credits <= 0 {
+            kept = append(kept, r)
+            continue
+        }
+        available[seller] -= credits
+        trade := TradeRecord{
+            TradeID:   fmt.Sprintf("%s.%d", order.OrderID, len(trades)+1),
+            Credits:   credits,
+            Price:     r.Price,
+            Timestamp: order.Timestamp,
+        }
+        if order.BuySell == BUY {
+            trade.Buyer, trade.BuyOrderID = order.AssetID, order.OrderID
+            trade.Seller, trade.SellOrderID = r.AssetID, r.OrderID
+        } else {
+            trade.Buyer, trade.BuyOrderID = r.AssetID, r.OrderID
+            trade.Seller, trade.SellOrderID = order.AssetID, order.OrderID
+        }
+        trades = append(trades, trade)
+        order.Remaining -= credits
+        r.Remaining -= credits
+        if r.Remaining > 0 {
+            kept = append(kept, r)
+        }
+    }
+    *resting = kept
+    return trades
+}
+
+// add inserts an order behind every order on its side with the same or a better price
+func (book *OrderBook) add(order Order) {
+    side := &book.Buy
+    if order.BuySell == SELL {
+        side = &book.Sell
+    }
+    i := 0
+    for ; i < len(*side); i++ {
+        p := (*side)[i].Price
+        if (order.BuySell == BUY && order.Price > p) || (order.BuySell == SELL && order.Price < p) {
+            break
+        }
+    }
+    *side = append(*side, Order{})
+    copy((*side)[i+1:], (*side)[i:])
+    (*side)[i] = order
+}
+
+// remove takes an open order of the company out of the book
+func (book *OrderBook) remove(orderID string, assetID string) (bool) {
+    for _, side := range []*[]Order{&book.Buy, &book.Sell} {
+        for i, o := range *side {
+            if o.OrderID == orderID && o.AssetID == assetID {
+                *side = append((*side)[:i], (*side)[i+1:]...)
+                return true
+            }
+        }
+    }
+    return false
+}
+
+// openSellCredits is what the company has offered and not yet sold in the book
+func (book *OrderBook) openSellCredits(assetID string) (float64) {
+    var total float64
+    for _, o := range book.Sell {
+        if o.AssetID == assetID {
+            total += o.Remaining
+        }
+    }
+    return total
+}
+
+func ordersOf(orders []Order, assetID string) ([]Order) {
+    var out = make([]Order, 0)
+    for _, o := range orders {
+        if o.AssetID == assetID {
+            out = append(out, o)
+        }
+    }
+    return out
+}
+
+// applyTrades moves the traded credits between the companies and records the trades.
+// Each company is written once, in assetID order
+func applyTrades(stub shim.ChaincodeStubInterface, trades []TradeRecord, txntimestamp time.Time, function string, args string) (error) {
+    if len(trades) == 0 {
+        return nil
+    }
+    var companies = make(map[string]ArgsMap)
+    for _, trade := range trades {
+        for _, assetID := range []string{trade.Seller, trade.Buyer} {
+            if _, found := companies[assetID]; !found {
+                company, err := getCompanyState(stub, assetID)
+                if err != nil {
+                    return err
+                }
+                companies[assetID] = company
+            }
+        }
+        seller, buyer := companies[trade.Seller], companies[trade.Buyer]
+        if seller.availableCredits() < trade.Credits {
+            err := fmt.Errorf("trade %s sells %s credits but company %s has %s available", trade.TradeID, formatCredits(trade.Credits), trade.Seller, formatCredits(seller.availableCredits()))
+            log.Error(err)
+            return err
+        }
+        seller["soldCredits"] = floatValue(seller, "soldCredits") + trade.Credits
+        buyer["boughtCredits"] = floatValue(buyer, "boughtCredits") + trade.Credits
+        sold, bought := trade, trade
+        sold.Company, sold.BuySell = trade.Buyer, SELL
+        bought.Company, bought.BuySell = trade.Seller, BUY
+        seller["tradeHistory"] = seller.appendTradeRecord(sold)
+        buyer["tradeHistory"] = buyer.appendTradeRecord(bought)
+    }
+    // the "trade" asset keeps the history of the whole market
+    if assetIsActive(stub, "trade") {
+        market, err := getCompanyState(stub, "trade")
+        if err != nil {
+            return err
+        }
+        for _, trade := range trades {
+            market["tradeHistory"] = market.appendTradeRecord(trade)
+        }
+        companies["trade"] = market
+    }
+    var ids = make([]string, 0, len(companies))
+    for assetID := range companies {
+        ids = append(ids, assetID)
+    }
+    sort.Strings(ids)
+    for _, assetID := range ids {
+        err := putCompanyState(stub, assetID, companies[assetID], txntimestamp, function, args)
+        if err != nil {
+            return err
+        }
+    }
+    return nil
+}
+
+// putCompanyState writes a company state changed by trading or by a compliance period,
+// re-running the rules as updateAsset does since credits count towards the carbon threshold
+func putCompanyState(stub shim.ChaincodeStubInterface, assetID string, state ArgsMap, txntimestamp time.Time, function string, args string) (error) {
+    state[TIMESTAMP] = txntimestamp
+    if assetID != "trade" {
+        alerts := newAlertStatus()
+        if a, found := state["alerts"]; found {
+            if aMap, ok := a.(map[string]interface{}); ok {
+                alerts.alertStatusFromMap(aMap)
+            }
+        }
+        if state.executeRules(&alerts) {
+            log.Noticef("%s assetID %s is noncompliant", function, assetID)
+            state["alerts"] = alerts
+            delete(state, "incompliance")
+        } else {
+            if alerts.AllClear() {
+                delete(state, "alerts")
+            } else {
+                state["alerts"] = alerts
+            }
+            state["incompliance"] = true
+        }
+    }
+    state["lastEvent"] = map[string]interface{}{"function": function, "args": args}
+    stateJSON, err := json.Marshal(state)
+    if err != nil {
+        err = fmt.Errorf("%s AssetID %s marshal failed: %s", function, assetID, err)
+        log.Error(err)
+        return err
+    }
+    err = stub.PutState(assetID, stateJSON)
+    if err != nil {
+        err = fmt.Errorf("%s AssetID %s PUTSTATE failed: %s", function, assetID, err)
+        log.Error(err)
+        return err
+    }
+    err = pushRecentState(stub, string(stateJSON))
+    if err != nil {
+        err = fmt.Errorf("%s AssetID %s push to recentstates failed: %s", function, assetID, err)
+        log.Error(err)
+        return err
+    }
+    err = updateStateHistory(stub, assetID, string(stateJSON))
+    if err != nil {
+        err = fmt.Errorf("%s AssetID %s push to history failed: %s", function, assetID, err)
+        log.Error(err)
+        return err
+    }
+    return nil
+}
+
+// availableCredits is what the company has left to sell: allotted and banked less used
+// and sold, plus bought, less what is listed in creditsSellList
+func (a ArgsMap) availableCredits() (float64) {
+    var listed float64
+    if list, found := a["creditsSellList"].([]interface{}); found {
+        listed = addValuesInArray(list)
+    }
+    return floatValue(a, "allottedCredits") + floatValue(a, "bankedCredits") - floatValue(a, "reading") - floatValue(a, "soldCredits") + floatValue(a, "boughtCredits") - listed
+}
+
+func floatValue(a ArgsMap, qname string) (float64) {
+    tbytes, found := getObject(a, qname)
+    if found {
+        if f, found := tbytes.(float64); found {
+            return f
+        }
+    }
+    return 0
+}
+
+func formatCredits(credits float64) (string) {
+    return fmt.Sprintf("%g", credits)
+}
+
+func getCompanyState(stub shim.ChaincodeStubInterface, assetID string) (ArgsMap, error) {
+    var state interface{}
+    assetBytes, err := stub.GetState(assetID)
+    if err != nil || len(assetBytes) == 0 {
+        err = fmt.Errorf("asset %s GETSTATE failed: %s", assetID, err)
+        log.Error(err)
+        return nil, err
+    }
+    err = json.Unmarshal(assetBytes, &state)
+    if err != nil {
+        err = fmt.Errorf("asset %s unmarshal failed: %s", assetID, err)
+        log.Error(err)
+        return nil, err
+    }
+    stateMap, found := state.(map[string]interface{})
+    if !found {
+        err = fmt.Errorf("asset %s LEDGER state is not a map shape", assetID)
+        log.Error(err)
+        return nil, err
+    }
+    return ArgsMap(stateMap), nil
+}
+
+func getOrderBook(stub shim.ChaincodeStubInterface) (OrderBook, error) {
+    var book = OrderBook{Buy: make([]Order, 0), Sell: make([]Order, 0)}
+    bookBytes, err := stub.GetState(ORDERBOOKKEY)
+    if err != nil || len(bookBytes) == 0 {
+        return book, nil
+    }
+    err = json.Unmarshal(bookBytes, &book)
+    if err != nil {
+        err = fmt.Errorf("Unmarshal failed for order book: %s", err)
+        log.Error(err)
+    }
+    return book, err
+}
+
+func putOrderBook(stub shim.ChaincodeStubInterface, book OrderBook) (error) {
+    bookJSON, err := json.Marshal(book)
+    if err != nil {
+        err = fmt.Errorf("Failed to marshal order book: %s", err)
+        log.Error(err)
+        return err
+    }
+    err = stub.PutState(ORDERBOOKKEY, bookJSON)
+    if err != nil {
+        err = fmt.Errorf("Failed to PUTSTATE order book: %s", err)
+        log.Error(err)
+        return err
+    }
+    return nil
+}
+
+func txnTimestamp(stub shim.ChaincodeStubInterface) (time.Time, error) {
+    txnunixtime, err := stub.GetTxTimestamp()
+    if err != nil || txnunixtime == nil {
+        err = fmt.Errorf("Error getting transaction timestamp: %s", err)
+        log.Error(err)
+        return time.Time{}, err
+    }
+    return time.Unix(txnunixtime.Seconds, int64(txnunixtime.Nanos)), nil
+}
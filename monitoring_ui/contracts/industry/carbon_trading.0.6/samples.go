@@ -12,7 +12,6 @@ var samples = `
     "event": {
         "allottedCredits": 123.456,
         "assetID": "The ID of a managed asset. The resource focal point for a smart contract.",
-        "boughtCredits": 123.456,
         "creditsForSale": 123.456,
         "creditsRequestBuy": 123.456,
         "email": "Contact information of the company will be stored here",
@@ -33,7 +32,6 @@ var samples = `
             "latitude": 123.456,
             "longitude": 123.456
         },
-        "soldCredits": 123.456,
         "temperatureCelsius": "Sensor and weather value will be stored in a string. So sensorWeatherData object could refer to this definition to store its value",
         "temperatureFahrenheit": "Sensor and weather value will be stored in a string. So sensorWeatherData object could refer to this definition to store its value",
         "threshold": 123.456,
@@ -69,6 +67,7 @@ var samples = `
         },
         "allottedCredits": 123.456,
         "assetID": "The ID of a managed asset. The resource focal point for a smart contract.",
+        "bankedCredits": 123.456,
         "boughtCredits": 123.456,
         "compliant": true,
         "contactInformation": {
@@ -109,6 +108,7 @@ var samples = `
             "carpe noctem"
         ],
         "reading": 123.456,
+        "retiredCredits": 123.456,
         "sensorID": 123.456,
         "sensorWeatherHistory": {
             "iconUrl": "carpe noctem",
@@ -125,7 +125,7 @@ var samples = `
                 "Sensor and weather value will be stored in a string. So sensorWeatherData object could refer to this definition to store its value"
             ],
             "timestamp": [
-                "2016-08-09T15:49:18.022023884-05:00"
+                "2026-10-19T12:03:12.088898092Z"
             ],
             "windDegrees": [
                 "Sensor and weather value will be stored in a string. So sensorWeatherData object could refer to this definition to store its value"
@@ -149,23 +149,20 @@ var samples = `
         "tradeBuySell": "Trade values are triggered for every trade which is processed. This contract stores every trade which will be made between two companies",
         "tradeCompany": "Trade values are triggered for every trade which is processed. This contract stores every trade which will be made between two companies",
         "tradeCredits": "Trade values are triggered for every trade which is processed. This contract stores every trade which will be made between two companies",
-        "tradeHistory": {
-            "buysell": [
-                "Trade values are triggered for every trade which is processed. This contract stores every trade which will be made between two companies"
-            ],
-            "company": [
-                "Trade values are triggered for every trade which is processed. This contract stores every trade which will be made between two companies"
-            ],
-            "credits": [
-                "Trade values are triggered for every trade which is processed. This contract stores every trade which will be made between two companies"
-            ],
-            "price": [
-                "Trade values are triggered for every trade which is processed. This contract stores every trade which will be made between two companies"
-            ],
-            "timestamp": [
-                "2016-08-09T15:49:18.022017853-05:00"
-            ]
-        },
+        "tradeHistory": [
+            {
+                "buyOrderID": "carpe noctem",
+                "buyer": "The ID of a managed asset. The resource focal point for a smart contract.",
+                "buysell": "sell",
+                "company": "carpe noctem",
+                "credits": 123.456,
+                "price": 123.456,
+                "sellOrderID": "carpe noctem",
+                "seller": "The ID of a managed asset. The resource focal point for a smart contract.",
+                "timestamp": "2016-08-09T15:49:18.022017853-05:00",
+                "tradeID": "Order id of the incoming order and the number of the fill"
+            }
+        ],
         "tradePrice": "Trade values are triggered for every trade which is processed. This contract stores every trade which will be made between two companies",
         "tradeTimestamp": "Trade values are triggered for every trade which is processed. This contract stores every trade which will be made between two companies",
         "txntimestamp": "Transaction timestamp matching that in the blockchain.",
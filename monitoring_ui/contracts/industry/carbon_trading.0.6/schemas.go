@@ -3,6 +3,83 @@ package main
 var schemas = `
 {
     "API": {
+        "cancelOrder": {
+            "description": "Cancel an open order. Argument is a JSON encoded string with the assetID of the company that placed the order and the orderID.",
+            "properties": {
+                "args": {
+                    "description": "args are JSON encoded strings",
+                    "items": {
+                        "description": "An order of a company.",
+                        "properties": {
+                            "assetID": {
+                                "description": "The ID of a managed asset. The resource focal point for a smart contract.",
+                                "type": "string"
+                            },
+                            "orderID": {
+                                "type": "string"
+                            }
+                        },
+                        "required": [
+                            "assetID",
+                            "orderID"
+                        ],
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
+                    "type": "array"
+                },
+                "function": {
+                    "description": "cancelOrder function",
+                    "enum": [
+                        "cancelOrder"
+                    ],
+                    "type": "string"
+                },
+                "method": "invoke"
+            },
+            "type": "object"
+        },
+        "closeCompliancePeriod": {
+            "description": "Close the open compliance period. For every company credits equal to its emissions are retired, verified emissions if sent in and the reading otherwise. The surplus is banked up to the banking limit and the rest forfeited, a shortfall is recorded as a penalty. reading, soldCredits and boughtCredits start again from 0, open orders and creditsSellList are withdrawn.",
+            "properties": {
+                "args": {
+                    "description": "args are JSON encoded strings",
+                    "items": {
+                        "description": "The compliance period to close.",
+                        "properties": {
+                            "periodID": {
+                                "description": "The open period",
+                                "type": "string"
+                            },
+                            "verifiedEmissions": {
+                                "additionalProperties": {
+                                    "type": "number"
+                                },
+                                "description": "Verified emissions for the period by assetID, the reading is used for companies not listed",
+                                "type": "object"
+                            }
+                        },
+                        "required": [
+                            "periodID"
+                        ],
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
+                    "type": "array"
+                },
+                "function": {
+                    "description": "closeCompliancePeriod function",
+                    "enum": [
+                        "closeCompliancePeriod"
+                    ],
+                    "type": "string"
+                },
+                "method": "invoke"
+            },
+            "type": "object"
+        },
         "createAsset": {
             "description": "Create an asset. One argument, a JSON encoded event. AssetID is required with zero or more writable properties. Establishes an initial asset state.",
             "properties": {
@@ -19,10 +96,6 @@ var schemas = `
                                 "description": "The ID of a managed asset. The resource focal point for a smart contract.",
                                 "type": "string"
                             },
-                            "boughtCredits": {
-                                "description": "Total number of credits bought from other companies",
-                                "type": "number"
-                            },
                             "creditsForSale": {
                                 "description": "Total credits which are going to be put on sale by a company",
                                 "type": "number"
@@ -96,10 +169,6 @@ var schemas = `
                                 },
                                 "type": "object"
                             },
-                            "soldCredits": {
-                                "description": "Total number of credits sold to other companies",
-                                "type": "number"
-                            },
                             "temperatureCelsius": {
                                 "description": "Sensor and weather value will be stored in a string. So sensorWeatherData object could refer to this definition to store its value",
                                 "type": "string"
@@ -315,6 +384,105 @@ var schemas = `
             },
             "type": "object"
         },
+        "openCompliancePeriod": {
+            "description": "Open a compliance period. Only one period can be open. bankingLimit is the fraction of allottedCredits a surplus may carry over at close, all of it if absent. penaltyPerCredit is charged per credit of shortfall. allocations sets allottedCredits for the period by assetID.",
+            "properties": {
+                "args": {
+                    "description": "args are JSON encoded strings",
+                    "items": {
+                        "description": "A compliance period to open.",
+                        "properties": {
+                            "allocations": {
+                                "additionalProperties": {
+                                    "type": "number"
+                                },
+                                "description": "allottedCredits for the period by assetID",
+                                "type": "object"
+                            },
+                            "bankingLimit": {
+                                "description": "Fraction of allottedCredits that may be banked for the next period, 0 for no banking",
+                                "type": "number"
+                            },
+                            "penaltyPerCredit": {
+                                "description": "Penalty per credit of shortfall",
+                                "type": "number"
+                            },
+                            "periodID": {
+                                "description": "Compliance period, e.g. the year",
+                                "type": "string"
+                            }
+                        },
+                        "required": [
+                            "periodID"
+                        ],
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
+                    "type": "array"
+                },
+                "function": {
+                    "description": "openCompliancePeriod function",
+                    "enum": [
+                        "openCompliancePeriod"
+                    ],
+                    "type": "string"
+                },
+                "method": "invoke"
+            },
+            "type": "object"
+        },
+        "placeOrder": {
+            "description": "Post a buy or sell order for credits. The order is matched against the other side of the order book by price-time priority, each fill trades at the price of the resting order and updates soldCredits, boughtCredits and tradeHistory of both companies. What is not filled rests in the book. A sell order cannot exceed the credits the company has left.",
+            "properties": {
+                "args": {
+                    "description": "args are JSON encoded strings",
+                    "items": {
+                        "description": "A limit order for credits.",
+                        "properties": {
+                            "assetID": {
+                                "description": "The ID of a managed asset. The resource focal point for a smart contract.",
+                                "type": "string"
+                            },
+                            "buysell": {
+                                "enum": [
+                                    "buy",
+                                    "sell"
+                                ],
+                                "type": "string"
+                            },
+                            "credits": {
+                                "description": "Credits to buy or sell",
+                                "type": "number"
+                            },
+                            "price": {
+                                "description": "Highest price per credit for a buy, lowest for a sell",
+                                "type": "number"
+                            }
+                        },
+                        "required": [
+                            "assetID",
+                            "buysell",
+                            "credits",
+                            "price"
+                        ],
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
+                    "type": "array"
+                },
+                "function": {
+                    "description": "placeOrder function",
+                    "enum": [
+                        "placeOrder"
+                    ],
+                    "type": "string"
+                },
+                "method": "invoke"
+            },
+            "type": "object"
+        },
         "readAllAssets": {
             "description": "Returns the state of all assets as an array of JSON encoded strings. Accepts no arguments. For each managed asset, the state is read from the ledger and added to the returned array. Array is sorted by assetID.",
             "properties": {
@@ -385,8 +553,12 @@ var schemas = `
                                 "description": "The ID of a managed asset. The resource focal point for a smart contract.",
                                 "type": "string"
                             },
+                            "bankedCredits": {
+                                "description": "Credits carried over from the last compliance period",
+                                "type": "number"
+                            },
                             "boughtCredits": {
-                                "description": "Total number of credits bought from other companies",
+                                "description": "Total number of credits bought from other companies, set by trades in the order book",
                                 "type": "number"
                             },
                             "compliant": {
@@ -517,6 +689,10 @@ var schemas = `
                                 "description": "defines one reading for a sensor",
                                 "type": "number"
                             },
+                            "retiredCredits": {
+                                "description": "Total number of credits retired against emissions at the close of compliance periods",
+                                "type": "number"
+                            },
                             "sensorID": {
                                 "description": "defines one sensor in a company",
                                 "type": "number"
@@ -607,7 +783,7 @@ var schemas = `
                                 "type": "object"
                             },
                             "soldCredits": {
-                                "description": "Total number of credits sold to other companies",
+                                "description": "Total number of credits sold to other companies, set by trades in the order book",
                                 "type": "number"
                             },
                             "temperatureCelsius": {
@@ -639,50 +815,52 @@ var schemas = `
                                 "type": "string"
                             },
                             "tradeHistory": {
-                                "description": "Sold means how many credits were traded and Price refers for how much per credit. Company means institution trade was made to. Timestamp means what time trade occured. BuySell attribute is to indicate if it was a buy or sell ",
-                                "properties": {
-                                    "buysell": {
-                                        "items": {
-                                            "description": "Trade values are triggered for every trade which is processed. This contract stores every trade which will be made between two companies",
+                                "description": "Every trade made by the company, or on the 'trade' asset every trade in the market, oldest first",
+                                "items": {
+                                    "description": "One trade. Credits is how many credits were traded and price is per credit. On a company's trade history, company is the counterparty and buysell the side the company was on. Trades from the order book also carry the buyer, seller and both order ids.",
+                                    "properties": {
+                                        "buyOrderID": {
                                             "type": "string"
                                         },
-                                        "minItems": 0,
-                                        "type": "array"
-                                    },
-                                    "company": {
-                                        "items": {
-                                            "description": "Trade values are triggered for every trade which is processed. This contract stores every trade which will be made between two companies",
+                                        "buyer": {
+                                            "description": "The ID of a managed asset. The resource focal point for a smart contract.",
                                             "type": "string"
                                         },
-                                        "minItems": 0,
-                                        "type": "array"
-                                    },
-                                    "credits": {
-                                        "items": {
-                                            "description": "Trade values are triggered for every trade which is processed. This contract stores every trade which will be made between two companies",
+                                        "buysell": {
+                                            "enum": [
+                                                "buy",
+                                                "sell"
+                                            ],
                                             "type": "string"
                                         },
-                                        "minItems": 0,
-                                        "type": "array"
-                                    },
-                                    "price": {
-                                        "items": {
-                                            "description": "Trade values are triggered for every trade which is processed. This contract stores every trade which will be made between two companies",
+                                        "company": {
                                             "type": "string"
                                         },
-                                        "minItems": 0,
-                                        "type": "array"
-                                    },
-                                    "timestamp": {
-                                        "items": {
-                                            "description": "Trade values are triggered for every trade which is processed. This contract stores every trade which will be made between two companies",
+                                        "credits": {
+                                            "type": "number"
+                                        },
+                                        "price": {
+                                            "type": "number"
+                                        },
+                                        "sellOrderID": {
                                             "type": "string"
                                         },
-                                        "minItems": 0,
-                                        "type": "array"
-                                    }
+                                        "seller": {
+                                            "description": "The ID of a managed asset. The resource focal point for a smart contract.",
+                                            "type": "string"
+                                        },
+                                        "timestamp": {
+                                            "type": "string"
+                                        },
+                                        "tradeID": {
+                                            "description": "Order id of the incoming order and the number of the fill",
+                                            "type": "string"
+                                        }
+                                    },
+                                    "type": "object"
                                 },
-                                "type": "object"
+                                "minItems": 0,
+                                "type": "array"
                             },
                             "tradePrice": {
                                 "description": "Trade values are triggered for every trade which is processed. This contract stores every trade which will be made between two companies",
@@ -814,8 +992,12 @@ var schemas = `
                             "description": "The ID of a managed asset. The resource focal point for a smart contract.",
                             "type": "string"
                         },
+                        "bankedCredits": {
+                            "description": "Credits carried over from the last compliance period",
+                            "type": "number"
+                        },
                         "boughtCredits": {
-                            "description": "Total number of credits bought from other companies",
+                            "description": "Total number of credits bought from other companies, set by trades in the order book",
                             "type": "number"
                         },
                         "compliant": {
@@ -946,6 +1128,10 @@ var schemas = `
                             "description": "defines one reading for a sensor",
                             "type": "number"
                         },
+                        "retiredCredits": {
+                            "description": "Total number of credits retired against emissions at the close of compliance periods",
+                            "type": "number"
+                        },
                         "sensorID": {
                             "description": "defines one sensor in a company",
                             "type": "number"
@@ -1036,7 +1222,7 @@ var schemas = `
                             "type": "object"
                         },
                         "soldCredits": {
-                            "description": "Total number of credits sold to other companies",
+                            "description": "Total number of credits sold to other companies, set by trades in the order book",
                             "type": "number"
                         },
                         "temperatureCelsius": {
@@ -1068,50 +1254,52 @@ var schemas = `
                             "type": "string"
                         },
                         "tradeHistory": {
-                            "description": "Sold means how many credits were traded and Price refers for how much per credit. Company means institution trade was made to. Timestamp means what time trade occured. BuySell attribute is to indicate if it was a buy or sell ",
-                            "properties": {
-                                "buysell": {
-                                    "items": {
-                                        "description": "Trade values are triggered for every trade which is processed. This contract stores every trade which will be made between two companies",
+                            "description": "Every trade made by the company, or on the 'trade' asset every trade in the market, oldest first",
+                            "items": {
+                                "description": "One trade. Credits is how many credits were traded and price is per credit. On a company's trade history, company is the counterparty and buysell the side the company was on. Trades from the order book also carry the buyer, seller and both order ids.",
+                                "properties": {
+                                    "buyOrderID": {
                                         "type": "string"
                                     },
-                                    "minItems": 0,
-                                    "type": "array"
-                                },
-                                "company": {
-                                    "items": {
-                                        "description": "Trade values are triggered for every trade which is processed. This contract stores every trade which will be made between two companies",
+                                    "buyer": {
+                                        "description": "The ID of a managed asset. The resource focal point for a smart contract.",
                                         "type": "string"
                                     },
-                                    "minItems": 0,
-                                    "type": "array"
-                                },
-                                "credits": {
-                                    "items": {
-                                        "description": "Trade values are triggered for every trade which is processed. This contract stores every trade which will be made between two companies",
+                                    "buysell": {
+                                        "enum": [
+                                            "buy",
+                                            "sell"
+                                        ],
                                         "type": "string"
                                     },
-                                    "minItems": 0,
-                                    "type": "array"
-                                },
-                                "price": {
-                                    "items": {
-                                        "description": "Trade values are triggered for every trade which is processed. This contract stores every trade which will be made between two companies",
+                                    "company": {
                                         "type": "string"
                                     },
-                                    "minItems": 0,
-                                    "type": "array"
-                                },
-                                "timestamp": {
-                                    "items": {
-                                        "description": "Trade values are triggered for every trade which is processed. This contract stores every trade which will be made between two companies",
+                                    "credits": {
+                                        "type": "number"
+                                    },
+                                    "price": {
+                                        "type": "number"
+                                    },
+                                    "sellOrderID": {
                                         "type": "string"
                                     },
-                                    "minItems": 0,
-                                    "type": "array"
-                                }
+                                    "seller": {
+                                        "description": "The ID of a managed asset. The resource focal point for a smart contract.",
+                                        "type": "string"
+                                    },
+                                    "timestamp": {
+                                        "type": "string"
+                                    },
+                                    "tradeID": {
+                                        "description": "Order id of the incoming order and the number of the fill",
+                                        "type": "string"
+                                    }
+                                },
+                                "type": "object"
                             },
-                            "type": "object"
+                            "minItems": 0,
+                            "type": "array"
                         },
                         "tradePrice": {
                             "description": "Trade values are triggered for every trade which is processed. This contract stores every trade which will be made between two companies",
@@ -1248,8 +1436,12 @@ var schemas = `
                                 "description": "The ID of a managed asset. The resource focal point for a smart contract.",
                                 "type": "string"
                             },
+                            "bankedCredits": {
+                                "description": "Credits carried over from the last compliance period",
+                                "type": "number"
+                            },
                             "boughtCredits": {
-                                "description": "Total number of credits bought from other companies",
+                                "description": "Total number of credits bought from other companies, set by trades in the order book",
                                 "type": "number"
                             },
                             "compliant": {
@@ -1380,6 +1572,10 @@ var schemas = `
                                 "description": "defines one reading for a sensor",
                                 "type": "number"
                             },
+                            "retiredCredits": {
+                                "description": "Total number of credits retired against emissions at the close of compliance periods",
+                                "type": "number"
+                            },
                             "sensorID": {
                                 "description": "defines one sensor in a company",
                                 "type": "number"
@@ -1470,7 +1666,7 @@ var schemas = `
                                 "type": "object"
                             },
                             "soldCredits": {
-                                "description": "Total number of credits sold to other companies",
+                                "description": "Total number of credits sold to other companies, set by trades in the order book",
                                 "type": "number"
                             },
                             "temperatureCelsius": {
@@ -1502,50 +1698,52 @@ var schemas = `
                                 "type": "string"
                             },
                             "tradeHistory": {
-                                "description": "Sold means how many credits were traded and Price refers for how much per credit. Company means institution trade was made to. Timestamp means what time trade occured. BuySell attribute is to indicate if it was a buy or sell ",
-                                "properties": {
-                                    "buysell": {
-                                        "items": {
-                                            "description": "Trade values are triggered for every trade which is processed. This contract stores every trade which will be made between two companies",
+                                "description": "Every trade made by the company, or on the 'trade' asset every trade in the market, oldest first",
+                                "items": {
+                                    "description": "One trade. Credits is how many credits were traded and price is per credit. On a company's trade history, company is the counterparty and buysell the side the company was on. Trades from the order book also carry the buyer, seller and both order ids.",
+                                    "properties": {
+                                        "buyOrderID": {
                                             "type": "string"
                                         },
-                                        "minItems": 0,
-                                        "type": "array"
-                                    },
-                                    "company": {
-                                        "items": {
-                                            "description": "Trade values are triggered for every trade which is processed. This contract stores every trade which will be made between two companies",
+                                        "buyer": {
+                                            "description": "The ID of a managed asset. The resource focal point for a smart contract.",
                                             "type": "string"
                                         },
-                                        "minItems": 0,
-                                        "type": "array"
-                                    },
-                                    "credits": {
-                                        "items": {
-                                            "description": "Trade values are triggered for every trade which is processed. This contract stores every trade which will be made between two companies",
+                                        "buysell": {
+                                            "enum": [
+                                                "buy",
+                                                "sell"
+                                            ],
                                             "type": "string"
                                         },
-                                        "minItems": 0,
-                                        "type": "array"
-                                    },
-                                    "price": {
-                                        "items": {
-                                            "description": "Trade values are triggered for every trade which is processed. This contract stores every trade which will be made between two companies",
+                                        "company": {
                                             "type": "string"
                                         },
-                                        "minItems": 0,
-                                        "type": "array"
-                                    },
-                                    "timestamp": {
-                                        "items": {
-                                            "description": "Trade values are triggered for every trade which is processed. This contract stores every trade which will be made between two companies",
+                                        "credits": {
+                                            "type": "number"
+                                        },
+                                        "price": {
+                                            "type": "number"
+                                        },
+                                        "sellOrderID": {
                                             "type": "string"
                                         },
-                                        "minItems": 0,
-                                        "type": "array"
-                                    }
+                                        "seller": {
+                                            "description": "The ID of a managed asset. The resource focal point for a smart contract.",
+                                            "type": "string"
+                                        },
+                                        "timestamp": {
+                                            "type": "string"
+                                        },
+                                        "tradeID": {
+                                            "description": "Order id of the incoming order and the number of the fill",
+                                            "type": "string"
+                                        }
+                                    },
+                                    "type": "object"
                                 },
-                                "type": "object"
+                                "minItems": 0,
+                                "type": "array"
                             },
                             "tradePrice": {
                                 "description": "Trade values are triggered for every trade which is processed. This contract stores every trade which will be made between two companies",
@@ -1600,6 +1798,376 @@ var schemas = `
             },
             "type": "object"
         },
+        "readCompliancePeriod": {
+            "description": "Returns a compliance period with the reports of all companies once it is closed. Without a periodID, the open period.",
+            "properties": {
+                "args": {
+                    "description": "args are JSON encoded strings",
+                    "items": {
+                        "description": "A compliance period.",
+                        "properties": {
+                            "periodID": {
+                                "type": "string"
+                            }
+                        },
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 0,
+                    "type": "array"
+                },
+                "function": {
+                    "description": "readCompliancePeriod function",
+                    "enum": [
+                        "readCompliancePeriod"
+                    ],
+                    "type": "string"
+                },
+                "method": "query",
+                "result": {
+                    "description": "A compliance period.",
+                    "properties": {
+                        "allocations": {
+                            "additionalProperties": {
+                                "type": "number"
+                            },
+                            "type": "object"
+                        },
+                        "bankingLimit": {
+                            "type": "number"
+                        },
+                        "closed": {
+                            "type": "string"
+                        },
+                        "opened": {
+                            "type": "string"
+                        },
+                        "penaltyPerCredit": {
+                            "type": "number"
+                        },
+                        "periodID": {
+                            "type": "string"
+                        },
+                        "reports": {
+                            "description": "Compliance reports, oldest period first",
+                            "items": {
+                                "description": "The outcome of a compliance period for a company. Holdings are allottedCredits + bankedIn + boughtCredits - soldCredits, retiredCredits covers emissions as far as holdings go, the rest of the holdings are bankedOut or forfeited.",
+                                "properties": {
+                                    "allottedCredits": {
+                                        "type": "number"
+                                    },
+                                    "assetID": {
+                                        "description": "The ID of a managed asset. The resource focal point for a smart contract.",
+                                        "type": "string"
+                                    },
+                                    "bankedIn": {
+                                        "description": "Credits carried over from the previous period",
+                                        "type": "number"
+                                    },
+                                    "bankedOut": {
+                                        "description": "Credits carried over to the next period",
+                                        "type": "number"
+                                    },
+                                    "boughtCredits": {
+                                        "type": "number"
+                                    },
+                                    "emissions": {
+                                        "type": "number"
+                                    },
+                                    "forfeitedCredits": {
+                                        "type": "number"
+                                    },
+                                    "incompliance": {
+                                        "type": "boolean"
+                                    },
+                                    "penalty": {
+                                        "description": "Penalty for a shortfall at the close of a period. The penaltyID is periodID.assetID",
+                                        "properties": {
+                                            "amount": {
+                                                "type": "number"
+                                            },
+                                            "credits": {
+                                                "description": "Shortfall in credits",
+                                                "type": "number"
+                                            },
+                                            "penaltyID": {
+                                                "type": "string"
+                                            },
+                                            "penaltyPerCredit": {
+                                                "type": "number"
+                                            },
+                                            "timestamp": {
+                                                "type": "string"
+                                            }
+                                        },
+                                        "type": "object"
+                                    },
+                                    "periodID": {
+                                        "type": "string"
+                                    },
+                                    "retiredCredits": {
+                                        "type": "number"
+                                    },
+                                    "shortfall": {
+                                        "type": "number"
+                                    },
+                                    "soldCredits": {
+                                        "type": "number"
+                                    },
+                                    "status": {
+                                        "enum": [
+                                            "open",
+                                            "closed"
+                                        ],
+                                        "type": "string"
+                                    },
+                                    "verified": {
+                                        "description": "Emissions were verified at close rather than taken from the reading",
+                                        "type": "boolean"
+                                    }
+                                },
+                                "type": "object"
+                            },
+                            "type": "array"
+                        },
+                        "status": {
+                            "enum": [
+                                "open",
+                                "closed"
+                            ],
+                            "type": "string"
+                        }
+                    },
+                    "type": "object"
+                }
+            },
+            "type": "object"
+        },
+        "readComplianceReport": {
+            "description": "Returns the compliance reports of a company, for one period or for every period. The report of the open period is a projection from the current state.",
+            "properties": {
+                "args": {
+                    "description": "args are JSON encoded strings",
+                    "items": {
+                        "description": "A company and optionally a compliance period.",
+                        "properties": {
+                            "assetID": {
+                                "description": "The ID of a managed asset. The resource focal point for a smart contract.",
+                                "type": "string"
+                            },
+                            "periodID": {
+                                "type": "string"
+                            }
+                        },
+                        "required": [
+                            "assetID"
+                        ],
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
+                    "type": "array"
+                },
+                "function": {
+                    "description": "readComplianceReport function",
+                    "enum": [
+                        "readComplianceReport"
+                    ],
+                    "type": "string"
+                },
+                "method": "query",
+                "result": {
+                    "description": "Compliance reports, oldest period first",
+                    "items": {
+                        "description": "The outcome of a compliance period for a company. Holdings are allottedCredits + bankedIn + boughtCredits - soldCredits, retiredCredits covers emissions as far as holdings go, the rest of the holdings are bankedOut or forfeited.",
+                        "properties": {
+                            "allottedCredits": {
+                                "type": "number"
+                            },
+                            "assetID": {
+                                "description": "The ID of a managed asset. The resource focal point for a smart contract.",
+                                "type": "string"
+                            },
+                            "bankedIn": {
+                                "description": "Credits carried over from the previous period",
+                                "type": "number"
+                            },
+                            "bankedOut": {
+                                "description": "Credits carried over to the next period",
+                                "type": "number"
+                            },
+                            "boughtCredits": {
+                                "type": "number"
+                            },
+                            "emissions": {
+                                "type": "number"
+                            },
+                            "forfeitedCredits": {
+                                "type": "number"
+                            },
+                            "incompliance": {
+                                "type": "boolean"
+                            },
+                            "penalty": {
+                                "description": "Penalty for a shortfall at the close of a period. The penaltyID is periodID.assetID",
+                                "properties": {
+                                    "amount": {
+                                        "type": "number"
+                                    },
+                                    "credits": {
+                                        "description": "Shortfall in credits",
+                                        "type": "number"
+                                    },
+                                    "penaltyID": {
+                                        "type": "string"
+                                    },
+                                    "penaltyPerCredit": {
+                                        "type": "number"
+                                    },
+                                    "timestamp": {
+                                        "type": "string"
+                                    }
+                                },
+                                "type": "object"
+                            },
+                            "periodID": {
+                                "type": "string"
+                            },
+                            "retiredCredits": {
+                                "type": "number"
+                            },
+                            "shortfall": {
+                                "type": "number"
+                            },
+                            "soldCredits": {
+                                "type": "number"
+                            },
+                            "status": {
+                                "enum": [
+                                    "open",
+                                    "closed"
+                                ],
+                                "type": "string"
+                            },
+                            "verified": {
+                                "description": "Emissions were verified at close rather than taken from the reading",
+                                "type": "boolean"
+                            }
+                        },
+                        "type": "object"
+                    },
+                    "type": "array"
+                }
+            },
+            "type": "object"
+        },
+        "readOrderBook": {
+            "description": "Returns the open buy orders, highest price first, and sell orders, lowest price first. With an assetID, only the open orders of that company.",
+            "properties": {
+                "args": {
+                    "description": "args are JSON encoded strings",
+                    "items": {
+                        "description": "An object containing only an assetID for use as an argument to read or delete.",
+                        "properties": {
+                            "assetID": {
+                                "description": "The ID of a managed asset. The resource focal point for a smart contract.",
+                                "type": "string"
+                            }
+                        },
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 0,
+                    "type": "array"
+                },
+                "function": {
+                    "description": "readOrderBook function",
+                    "enum": [
+                        "readOrderBook"
+                    ],
+                    "type": "string"
+                },
+                "method": "query",
+                "result": {
+                    "description": "The open orders, each side best price first.",
+                    "properties": {
+                        "buy": {
+                            "items": {
+                                "description": "An open order. The orderID is the transaction id of placeOrder, remaining is what is not yet filled and sequence gives time priority.",
+                                "properties": {
+                                    "assetID": {
+                                        "description": "The ID of a managed asset. The resource focal point for a smart contract.",
+                                        "type": "string"
+                                    },
+                                    "buysell": {
+                                        "type": "string"
+                                    },
+                                    "credits": {
+                                        "type": "number"
+                                    },
+                                    "orderID": {
+                                        "type": "string"
+                                    },
+                                    "price": {
+                                        "type": "number"
+                                    },
+                                    "remaining": {
+                                        "type": "number"
+                                    },
+                                    "sequence": {
+                                        "type": "integer"
+                                    },
+                                    "timestamp": {
+                                        "type": "string"
+                                    }
+                                },
+                                "type": "object"
+                            },
+                            "type": "array"
+                        },
+                        "sell": {
+                            "items": {
+                                "description": "An open order. The orderID is the transaction id of placeOrder, remaining is what is not yet filled and sequence gives time priority.",
+                                "properties": {
+                                    "assetID": {
+                                        "description": "The ID of a managed asset. The resource focal point for a smart contract.",
+                                        "type": "string"
+                                    },
+                                    "buysell": {
+                                        "type": "string"
+                                    },
+                                    "credits": {
+                                        "type": "number"
+                                    },
+                                    "orderID": {
+                                        "type": "string"
+                                    },
+                                    "price": {
+                                        "type": "number"
+                                    },
+                                    "remaining": {
+                                        "type": "number"
+                                    },
+                                    "sequence": {
+                                        "type": "integer"
+                                    },
+                                    "timestamp": {
+                                        "type": "string"
+                                    }
+                                },
+                                "type": "object"
+                            },
+                            "type": "array"
+                        },
+                        "sequence": {
+                            "type": "integer"
+                        }
+                    },
+                    "type": "object"
+                }
+            },
+            "type": "object"
+        },
         "readRecentStates": {
             "description": "Returns the state of recently updated assets as an array of objects sorted with the most recently updated asset first. Each asset appears exactly once up to a maxmum of 20 in this version of the contract.",
             "properties": {
@@ -1670,8 +2238,12 @@ var schemas = `
                                 "description": "The ID of a managed asset. The resource focal point for a smart contract.",
                                 "type": "string"
                             },
+                            "bankedCredits": {
+                                "description": "Credits carried over from the last compliance period",
+                                "type": "number"
+                            },
                             "boughtCredits": {
-                                "description": "Total number of credits bought from other companies",
+                                "description": "Total number of credits bought from other companies, set by trades in the order book",
                                 "type": "number"
                             },
                             "compliant": {
@@ -1802,6 +2374,10 @@ var schemas = `
                                 "description": "defines one reading for a sensor",
                                 "type": "number"
                             },
+                            "retiredCredits": {
+                                "description": "Total number of credits retired against emissions at the close of compliance periods",
+                                "type": "number"
+                            },
                             "sensorID": {
                                 "description": "defines one sensor in a company",
                                 "type": "number"
@@ -1892,7 +2468,7 @@ var schemas = `
                                 "type": "object"
                             },
                             "soldCredits": {
-                                "description": "Total number of credits sold to other companies",
+                                "description": "Total number of credits sold to other companies, set by trades in the order book",
                                 "type": "number"
                             },
                             "temperatureCelsius": {
@@ -1924,50 +2500,52 @@ var schemas = `
                                 "type": "string"
                             },
                             "tradeHistory": {
-                                "description": "Sold means how many credits were traded and Price refers for how much per credit. Company means institution trade was made to. Timestamp means what time trade occured. BuySell attribute is to indicate if it was a buy or sell ",
-                                "properties": {
-                                    "buysell": {
-                                        "items": {
-                                            "description": "Trade values are triggered for every trade which is processed. This contract stores every trade which will be made between two companies",
+                                "description": "Every trade made by the company, or on the 'trade' asset every trade in the market, oldest first",
+                                "items": {
+                                    "description": "One trade. Credits is how many credits were traded and price is per credit. On a company's trade history, company is the counterparty and buysell the side the company was on. Trades from the order book also carry the buyer, seller and both order ids.",
+                                    "properties": {
+                                        "buyOrderID": {
                                             "type": "string"
                                         },
-                                        "minItems": 0,
-                                        "type": "array"
-                                    },
-                                    "company": {
-                                        "items": {
-                                            "description": "Trade values are triggered for every trade which is processed. This contract stores every trade which will be made between two companies",
+                                        "buyer": {
+                                            "description": "The ID of a managed asset. The resource focal point for a smart contract.",
                                             "type": "string"
                                         },
-                                        "minItems": 0,
-                                        "type": "array"
-                                    },
-                                    "credits": {
-                                        "items": {
-                                            "description": "Trade values are triggered for every trade which is processed. This contract stores every trade which will be made between two companies",
+                                        "buysell": {
+                                            "enum": [
+                                                "buy",
+                                                "sell"
+                                            ],
                                             "type": "string"
                                         },
-                                        "minItems": 0,
-                                        "type": "array"
-                                    },
-                                    "price": {
-                                        "items": {
-                                            "description": "Trade values are triggered for every trade which is processed. This contract stores every trade which will be made between two companies",
+                                        "company": {
                                             "type": "string"
                                         },
-                                        "minItems": 0,
-                                        "type": "array"
-                                    },
-                                    "timestamp": {
-                                        "items": {
-                                            "description": "Trade values are triggered for every trade which is processed. This contract stores every trade which will be made between two companies",
+                                        "credits": {
+                                            "type": "number"
+                                        },
+                                        "price": {
+                                            "type": "number"
+                                        },
+                                        "sellOrderID": {
                                             "type": "string"
                                         },
-                                        "minItems": 0,
-                                        "type": "array"
-                                    }
+                                        "seller": {
+                                            "description": "The ID of a managed asset. The resource focal point for a smart contract.",
+                                            "type": "string"
+                                        },
+                                        "timestamp": {
+                                            "type": "string"
+                                        },
+                                        "tradeID": {
+                                            "description": "Order id of the incoming order and the number of the fill",
+                                            "type": "string"
+                                        }
+                                    },
+                                    "type": "object"
                                 },
-                                "type": "object"
+                                "minItems": 0,
+                                "type": "array"
                             },
                             "tradePrice": {
                                 "description": "Trade values are triggered for every trade which is processed. This contract stores every trade which will be made between two companies",
@@ -2096,10 +2674,6 @@ var schemas = `
                                 "description": "The ID of a managed asset. The resource focal point for a smart contract.",
                                 "type": "string"
                             },
-                            "boughtCredits": {
-                                "description": "Total number of credits bought from other companies",
-                                "type": "number"
-                            },
                             "creditsForSale": {
                                 "description": "Total credits which are going to be put on sale by a company",
                                 "type": "number"
@@ -2173,10 +2747,6 @@ var schemas = `
                                 },
                                 "type": "object"
                             },
-                            "soldCredits": {
-                                "description": "Total number of credits sold to other companies",
-                                "type": "number"
-                            },
                             "temperatureCelsius": {
                                 "description": "Sensor and weather value will be stored in a string. So sensorWeatherData object could refer to this definition to store its value",
                                 "type": "string"
@@ -2274,20 +2844,214 @@ var schemas = `
             },
             "type": "object"
         },
-        "assetIDandCount": {
-            "description": "Requested assetID with item count.",
+        "assetIDandCount": {
+            "description": "Requested assetID with item count.",
+            "properties": {
+                "assetID": {
+                    "description": "The ID of a managed asset. The resource focal point for a smart contract.",
+                    "type": "string"
+                },
+                "count": {
+                    "type": "integer"
+                }
+            },
+            "required": [
+                "assetID"
+            ],
+            "type": "object"
+        },
+        "compliancePeriod": {
+            "description": "A compliance period.",
+            "properties": {
+                "allocations": {
+                    "additionalProperties": {
+                        "type": "number"
+                    },
+                    "type": "object"
+                },
+                "bankingLimit": {
+                    "type": "number"
+                },
+                "closed": {
+                    "type": "string"
+                },
+                "opened": {
+                    "type": "string"
+                },
+                "penaltyPerCredit": {
+                    "type": "number"
+                },
+                "periodID": {
+                    "type": "string"
+                },
+                "reports": {
+                    "description": "Compliance reports, oldest period first",
+                    "items": {
+                        "description": "The outcome of a compliance period for a company. Holdings are allottedCredits + bankedIn + boughtCredits - soldCredits, retiredCredits covers emissions as far as holdings go, the rest of the holdings are bankedOut or forfeited.",
+                        "properties": {
+                            "allottedCredits": {
+                                "type": "number"
+                            },
+                            "assetID": {
+                                "description": "The ID of a managed asset. The resource focal point for a smart contract.",
+                                "type": "string"
+                            },
+                            "bankedIn": {
+                                "description": "Credits carried over from the previous period",
+                                "type": "number"
+                            },
+                            "bankedOut": {
+                                "description": "Credits carried over to the next period",
+                                "type": "number"
+                            },
+                            "boughtCredits": {
+                                "type": "number"
+                            },
+                            "emissions": {
+                                "type": "number"
+                            },
+                            "forfeitedCredits": {
+                                "type": "number"
+                            },
+                            "incompliance": {
+                                "type": "boolean"
+                            },
+                            "penalty": {
+                                "description": "Penalty for a shortfall at the close of a period. The penaltyID is periodID.assetID",
+                                "properties": {
+                                    "amount": {
+                                        "type": "number"
+                                    },
+                                    "credits": {
+                                        "description": "Shortfall in credits",
+                                        "type": "number"
+                                    },
+                                    "penaltyID": {
+                                        "type": "string"
+                                    },
+                                    "penaltyPerCredit": {
+                                        "type": "number"
+                                    },
+                                    "timestamp": {
+                                        "type": "string"
+                                    }
+                                },
+                                "type": "object"
+                            },
+                            "periodID": {
+                                "type": "string"
+                            },
+                            "retiredCredits": {
+                                "type": "number"
+                            },
+                            "shortfall": {
+                                "type": "number"
+                            },
+                            "soldCredits": {
+                                "type": "number"
+                            },
+                            "status": {
+                                "enum": [
+                                    "open",
+                                    "closed"
+                                ],
+                                "type": "string"
+                            },
+                            "verified": {
+                                "description": "Emissions were verified at close rather than taken from the reading",
+                                "type": "boolean"
+                            }
+                        },
+                        "type": "object"
+                    },
+                    "type": "array"
+                },
+                "status": {
+                    "enum": [
+                        "open",
+                        "closed"
+                    ],
+                    "type": "string"
+                }
+            },
+            "type": "object"
+        },
+        "complianceReport": {
+            "description": "The outcome of a compliance period for a company. Holdings are allottedCredits + bankedIn + boughtCredits - soldCredits, retiredCredits covers emissions as far as holdings go, the rest of the holdings are bankedOut or forfeited.",
             "properties": {
+                "allottedCredits": {
+                    "type": "number"
+                },
                 "assetID": {
                     "description": "The ID of a managed asset. The resource focal point for a smart contract.",
                     "type": "string"
                 },
-                "count": {
-                    "type": "integer"
+                "bankedIn": {
+                    "description": "Credits carried over from the previous period",
+                    "type": "number"
+                },
+                "bankedOut": {
+                    "description": "Credits carried over to the next period",
+                    "type": "number"
+                },
+                "boughtCredits": {
+                    "type": "number"
+                },
+                "emissions": {
+                    "type": "number"
+                },
+                "forfeitedCredits": {
+                    "type": "number"
+                },
+                "incompliance": {
+                    "type": "boolean"
+                },
+                "penalty": {
+                    "description": "Penalty for a shortfall at the close of a period. The penaltyID is periodID.assetID",
+                    "properties": {
+                        "amount": {
+                            "type": "number"
+                        },
+                        "credits": {
+                            "description": "Shortfall in credits",
+                            "type": "number"
+                        },
+                        "penaltyID": {
+                            "type": "string"
+                        },
+                        "penaltyPerCredit": {
+                            "type": "number"
+                        },
+                        "timestamp": {
+                            "type": "string"
+                        }
+                    },
+                    "type": "object"
+                },
+                "periodID": {
+                    "type": "string"
+                },
+                "retiredCredits": {
+                    "type": "number"
+                },
+                "shortfall": {
+                    "type": "number"
+                },
+                "soldCredits": {
+                    "type": "number"
+                },
+                "status": {
+                    "enum": [
+                        "open",
+                        "closed"
+                    ],
+                    "type": "string"
+                },
+                "verified": {
+                    "description": "Emissions were verified at close rather than taken from the reading",
+                    "type": "boolean"
                 }
             },
-            "required": [
-                "assetID"
-            ],
             "type": "object"
         },
         "event": {
@@ -2301,10 +3065,6 @@ var schemas = `
                     "description": "The ID of a managed asset. The resource focal point for a smart contract.",
                     "type": "string"
                 },
-                "boughtCredits": {
-                    "description": "Total number of credits bought from other companies",
-                    "type": "number"
-                },
                 "creditsForSale": {
                     "description": "Total credits which are going to be put on sale by a company",
                     "type": "number"
@@ -2378,10 +3138,6 @@ var schemas = `
                     },
                     "type": "object"
                 },
-                "soldCredits": {
-                    "description": "Total number of credits sold to other companies",
-                    "type": "number"
-                },
                 "temperatureCelsius": {
                     "description": "Sensor and weather value will be stored in a string. So sensorWeatherData object could refer to this definition to store its value",
                     "type": "string"
@@ -2470,6 +3226,204 @@ var schemas = `
             ],
             "type": "object"
         },
+        "orderBook": {
+            "description": "The open orders, each side best price first.",
+            "properties": {
+                "buy": {
+                    "items": {
+                        "description": "An open order. The orderID is the transaction id of placeOrder, remaining is what is not yet filled and sequence gives time priority.",
+                        "properties": {
+                            "assetID": {
+                                "description": "The ID of a managed asset. The resource focal point for a smart contract.",
+                                "type": "string"
+                            },
+                            "buysell": {
+                                "type": "string"
+                            },
+                            "credits": {
+                                "type": "number"
+                            },
+                            "orderID": {
+                                "type": "string"
+                            },
+                            "price": {
+                                "type": "number"
+                            },
+                            "remaining": {
+                                "type": "number"
+                            },
+                            "sequence": {
+                                "type": "integer"
+                            },
+                            "timestamp": {
+                                "type": "string"
+                            }
+                        },
+                        "type": "object"
+                    },
+                    "type": "array"
+                },
+                "sell": {
+                    "items": {
+                        "description": "An open order. The orderID is the transaction id of placeOrder, remaining is what is not yet filled and sequence gives time priority.",
+                        "properties": {
+                            "assetID": {
+                                "description": "The ID of a managed asset. The resource focal point for a smart contract.",
+                                "type": "string"
+                            },
+                            "buysell": {
+                                "type": "string"
+                            },
+                            "credits": {
+                                "type": "number"
+                            },
+                            "orderID": {
+                                "type": "string"
+                            },
+                            "price": {
+                                "type": "number"
+                            },
+                            "remaining": {
+                                "type": "number"
+                            },
+                            "sequence": {
+                                "type": "integer"
+                            },
+                            "timestamp": {
+                                "type": "string"
+                            }
+                        },
+                        "type": "object"
+                    },
+                    "type": "array"
+                },
+                "sequence": {
+                    "type": "integer"
+                }
+            },
+            "type": "object"
+        },
+        "orderKey": {
+            "description": "An order of a company.",
+            "properties": {
+                "assetID": {
+                    "description": "The ID of a managed asset. The resource focal point for a smart contract.",
+                    "type": "string"
+                },
+                "orderID": {
+                    "type": "string"
+                }
+            },
+            "required": [
+                "assetID",
+                "orderID"
+            ],
+            "type": "object"
+        },
+        "orderRequest": {
+            "description": "A limit order for credits.",
+            "properties": {
+                "assetID": {
+                    "description": "The ID of a managed asset. The resource focal point for a smart contract.",
+                    "type": "string"
+                },
+                "buysell": {
+                    "enum": [
+                        "buy",
+                        "sell"
+                    ],
+                    "type": "string"
+                },
+                "credits": {
+                    "description": "Credits to buy or sell",
+                    "type": "number"
+                },
+                "price": {
+                    "description": "Highest price per credit for a buy, lowest for a sell",
+                    "type": "number"
+                }
+            },
+            "required": [
+                "assetID",
+                "buysell",
+                "credits",
+                "price"
+            ],
+            "type": "object"
+        },
+        "periodClose": {
+            "description": "The compliance period to close.",
+            "properties": {
+                "periodID": {
+                    "description": "The open period",
+                    "type": "string"
+                },
+                "verifiedEmissions": {
+                    "additionalProperties": {
+                        "type": "number"
+                    },
+                    "description": "Verified emissions for the period by assetID, the reading is used for companies not listed",
+                    "type": "object"
+                }
+            },
+            "required": [
+                "periodID"
+            ],
+            "type": "object"
+        },
+        "periodKey": {
+            "description": "A compliance period.",
+            "properties": {
+                "periodID": {
+                    "type": "string"
+                }
+            },
+            "type": "object"
+        },
+        "periodRequest": {
+            "description": "A compliance period to open.",
+            "properties": {
+                "allocations": {
+                    "additionalProperties": {
+                        "type": "number"
+                    },
+                    "description": "allottedCredits for the period by assetID",
+                    "type": "object"
+                },
+                "bankingLimit": {
+                    "description": "Fraction of allottedCredits that may be banked for the next period, 0 for no banking",
+                    "type": "number"
+                },
+                "penaltyPerCredit": {
+                    "description": "Penalty per credit of shortfall",
+                    "type": "number"
+                },
+                "periodID": {
+                    "description": "Compliance period, e.g. the year",
+                    "type": "string"
+                }
+            },
+            "required": [
+                "periodID"
+            ],
+            "type": "object"
+        },
+        "reportKey": {
+            "description": "A company and optionally a compliance period.",
+            "properties": {
+                "assetID": {
+                    "description": "The ID of a managed asset. The resource focal point for a smart contract.",
+                    "type": "string"
+                },
+                "periodID": {
+                    "type": "string"
+                }
+            },
+            "required": [
+                "assetID"
+            ],
+            "type": "object"
+        },
         "state": {
             "description": "A set of properties that constitute a complete asset state. Includes event properties and any other calculated properties such as compliance related alerts.",
             "properties": {
@@ -2520,8 +3474,12 @@ var schemas = `
                     "description": "The ID of a managed asset. The resource focal point for a smart contract.",
                     "type": "string"
                 },
+                "bankedCredits": {
+                    "description": "Credits carried over from the last compliance period",
+                    "type": "number"
+                },
                 "boughtCredits": {
-                    "description": "Total number of credits bought from other companies",
+                    "description": "Total number of credits bought from other companies, set by trades in the order book",
                     "type": "number"
                 },
                 "compliant": {
@@ -2652,6 +3610,10 @@ var schemas = `
                     "description": "defines one reading for a sensor",
                     "type": "number"
                 },
+                "retiredCredits": {
+                    "description": "Total number of credits retired against emissions at the close of compliance periods",
+                    "type": "number"
+                },
                 "sensorID": {
                     "description": "defines one sensor in a company",
                     "type": "number"
@@ -2742,7 +3704,7 @@ var schemas = `
                     "type": "object"
                 },
                 "soldCredits": {
-                    "description": "Total number of credits sold to other companies",
+                    "description": "Total number of credits sold to other companies, set by trades in the order book",
                     "type": "number"
                 },
                 "temperatureCelsius": {
@@ -2774,50 +3736,52 @@ var schemas = `
                     "type": "string"
                 },
                 "tradeHistory": {
-                    "description": "Sold means how many credits were traded and Price refers for how much per credit. Company means institution trade was made to. Timestamp means what time trade occured. BuySell attribute is to indicate if it was a buy or sell ",
-                    "properties": {
-                        "buysell": {
-                            "items": {
-                                "description": "Trade values are triggered for every trade which is processed. This contract stores every trade which will be made between two companies",
+                    "description": "Every trade made by the company, or on the 'trade' asset every trade in the market, oldest first",
+                    "items": {
+                        "description": "One trade. Credits is how many credits were traded and price is per credit. On a company's trade history, company is the counterparty and buysell the side the company was on. Trades from the order book also carry the buyer, seller and both order ids.",
+                        "properties": {
+                            "buyOrderID": {
                                 "type": "string"
                             },
-                            "minItems": 0,
-                            "type": "array"
-                        },
-                        "company": {
-                            "items": {
-                                "description": "Trade values are triggered for every trade which is processed. This contract stores every trade which will be made between two companies",
+                            "buyer": {
+                                "description": "The ID of a managed asset. The resource focal point for a smart contract.",
                                 "type": "string"
                             },
-                            "minItems": 0,
-                            "type": "array"
-                        },
-                        "credits": {
-                            "items": {
-                                "description": "Trade values are triggered for every trade which is processed. This contract stores every trade which will be made between two companies",
+                            "buysell": {
+                                "enum": [
+                                    "buy",
+                                    "sell"
+                                ],
                                 "type": "string"
                             },
-                            "minItems": 0,
-                            "type": "array"
-                        },
-                        "price": {
-                            "items": {
-                                "description": "Trade values are triggered for every trade which is processed. This contract stores every trade which will be made between two companies",
+                            "company": {
                                 "type": "string"
                             },
-                            "minItems": 0,
-                            "type": "array"
-                        },
-                        "timestamp": {
-                            "items": {
-                                "description": "Trade values are triggered for every trade which is processed. This contract stores every trade which will be made between two companies",
+                            "credits": {
+                                "type": "number"
+                            },
+                            "price": {
+                                "type": "number"
+                            },
+                            "sellOrderID": {
                                 "type": "string"
                             },
-                            "minItems": 0,
-                            "type": "array"
-                        }
+                            "seller": {
+                                "description": "The ID of a managed asset. The resource focal point for a smart contract.",
+                                "type": "string"
+                            },
+                            "timestamp": {
+                                "type": "string"
+                            },
+                            "tradeID": {
+                                "description": "Order id of the incoming order and the number of the fill",
+                                "type": "string"
+                            }
+                        },
+                        "type": "object"
                     },
-                    "type": "object"
+                    "minItems": 0,
+                    "type": "array"
                 },
                 "tradePrice": {
                     "description": "Trade values are triggered for every trade which is processed. This contract stores every trade which will be made between two companies",
@@ -2867,4 +3831,4 @@ var schemas = `
             "type": "object"
         }
     }
-}`
+}`
\ No newline at end of file
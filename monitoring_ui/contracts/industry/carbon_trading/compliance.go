@@ -0,0 +1,502 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Risham Chokshi - Initial Contribution
+*/
+
+// ************************************
+// Compliance periods
+// A regulator opens a compliance period with openCompliancePeriod, optionally with new
+// allottedCredits for some companies, and closes it with closeCompliancePeriod. At close,
+// for every company:
+//    emissions  -- the verified emissions sent in for the company, or its reading
+//    holdings   -- allottedCredits + bankedCredits + boughtCredits - soldCredits
+//    retired    -- credits equal to the emissions are retired, as far as holdings go
+//    banked     -- the surplus carries over as bankedCredits, up to bankingLimit times
+//                  allottedCredits, the rest is forfeited
+//    penalty    -- a shortfall is recorded as a penalty of penaltyPerCredit per credit
+// reading, soldCredits and boughtCredits then start again from 0 for the next period.
+// Open orders and credits listed in creditsSellList are withdrawn, as they were offered
+// against the closed period's credits.
+//
+// readComplianceReport returns a company's report for each period, with a projection
+// for the open period.
+//
+// Periods are stored under:
+//    CompliancePeriodsKey          -- the list of periods and the open period
+//    CompliancePeriod.<periodID>   -- a period with the reports of all companies
+// ************************************
+
+package main
+
+import (
+    "encoding/json"
+    "errors"
+    "fmt"
+    "math"
+    "sort"
+    "time"
+
+    "github.com/hyperledger/fabric/core/chaincode/shim"
+)
+
+// PERIODSKEY is used to store the list of compliance periods
+const PERIODSKEY string = "CompliancePeriodsKey"
+
+// PERIODPREFIX is the key prefix of a compliance period
+const PERIODPREFIX string = "CompliancePeriod."
+
+// Period status
+const PERIODOPEN string = "open"
+const PERIODCLOSED string = "closed"
+
+// CompliancePeriods lists the periods in the order they were opened
+type CompliancePeriods struct {
+    Current     string      `json:"current"`            // open period, empty when none is open
+    Periods     []string    `json:"periods"`
+}
+
+// CompliancePeriod is one compliance period, usually a year
+type CompliancePeriod struct {
+    PeriodID            string                  `json:"periodID"`
+    Status              string                  `json:"status"`
+    Opened              string                  `json:"opened"`
+    Closed              string                  `json:"closed,omitempty"`
+    BankingLimit        *float64                `json:"bankingLimit,omitempty"`     // fraction of allottedCredits that may be banked, no limit if absent
+    PenaltyPerCredit    float64                 `json:"penaltyPerCredit"`
+    Allocations         map[string]float64      `json:"allocations,omitempty"`      // allottedCredits set when the period opened
+    Reports             []ComplianceReport      `json:"reports,omitempty"`          // one per company, set at close
+}
+
+// ComplianceReport is the outcome of a period for one company
+type ComplianceReport struct {
+    AssetID             string          `json:"assetID"`
+    PeriodID            string          `json:"periodID"`
+    Status              string          `json:"status"`                 // open is a projection from the current state
+    Emissions           float64         `json:"emissions"`
+    Verified            bool            `json:"verified"`               // emissions were verified, not taken from reading
+    AllottedCredits     float64         `json:"allottedCredits"`
+    BankedIn            float64         `json:"bankedIn"`               // carried over from the previous period
+    BoughtCredits       float64         `json:"boughtCredits"`
+    SoldCredits         float64         `json:"soldCredits"`
+    RetiredCredits      float64         `json:"retiredCredits"`
+    BankedOut           float64         `json:"bankedOut"`              // carried over to the next period
+    ForfeitedCredits    float64         `json:"forfeitedCredits"`
+    Shortfall           float64         `json:"shortfall"`
+    Penalty             *PenaltyRecord  `json:"penalty,omitempty"`
+    InCompliance        bool            `json:"incompliance"`
+}
+
+// PenaltyRecord is raised for a shortfall at the close of a period
+type PenaltyRecord struct {
+    PenaltyID           string          `json:"penaltyID"`              // <periodID>.<assetID>
+    Credits             float64         `json:"credits"`
+    PenaltyPerCredit    float64         `json:"penaltyPerCredit"`
+    Amount              float64         `json:"amount"`
+    Timestamp           string          `json:"timestamp"`
+}
+
+type periodClose struct {
+    PeriodID            string                  `json:"periodID"`
+    VerifiedEmissions   map[string]float64      `json:"verifiedEmissions"`
+}
+
+type reportRequest struct {
+    AssetID             string          `json:"assetID"`
+    PeriodID            string          `json:"periodID"`
+}
+
+// ************************************
+// openCompliancePeriod
+// ************************************
+func (t *SimpleChaincode) openCompliancePeriod(stub *shim.ChaincodeStub, args []string) ([]byte, error) {
+    var period CompliancePeriod
+    var err error
+
+    if len(args) != 1 {
+        err = errors.New("openCompliancePeriod expects one JSON object with periodID and optional bankingLimit, penaltyPerCredit and allocations")
+        log.Error(err)
+        return nil, err
+    }
+    err = json.Unmarshal([]byte(args[0]), &period)
+    if err != nil {
+        err = fmt.Errorf("openCompliancePeriod failed to unmarshal arg: %s", err)
+        log.Error(err)
+        return nil, err
+    }
+    if period.PeriodID == "" {
+        err = errors.New("openCompliancePeriod arg does not include periodID")
+        log.Error(err)
+        return nil, err
+    }
+    if period.PenaltyPerCredit < 0 || (period.BankingLimit != nil && *period.BankingLimit < 0) {
+        err = errors.New("openCompliancePeriod penaltyPerCredit and bankingLimit cannot be negative")
+        log.Error(err)
+        return nil, err
+    }
+    periods, err := getCompliancePeriods(stub)
+    if err != nil {
+        return nil, err
+    }
+    if periods.Current != "" {
+        err = fmt.Errorf("openCompliancePeriod period %s is still open", periods.Current)
+        log.Error(err)
+        return nil, err
+    }
+    if contains(periods.Periods, period.PeriodID) {
+        err = fmt.Errorf("openCompliancePeriod period %s already exists", period.PeriodID)
+        log.Error(err)
+        return nil, err
+    }
+    txntimestamp, err := txnTimestamp(stub)
+    if err != nil {
+        return nil, err
+    }
+    for assetID, credits := range period.Allocations {
+        if credits < 0 || assetID == "trade" || !assetIsActive(stub, assetID) {
+            err = fmt.Errorf("openCompliancePeriod allocation of %g credits to %s is not valid", credits, assetID)
+            log.Error(err)
+            return nil, err
+        }
+    }
+    // new allocations are written in assetID order
+    for _, assetID := range sortedKeys(period.Allocations) {
+        company, err := getCompanyState(stub, assetID)
+        if err != nil {
+            return nil, err
+        }
+        company["allottedCredits"] = period.Allocations[assetID]
+        err = putCompanyState(stub, assetID, company, txntimestamp, "openCompliancePeriod", args[0])
+        if err != nil {
+            return nil, err
+        }
+    }
+    period.Status = PERIODOPEN
+    period.Opened = txntimestamp.Format(time.RFC3339Nano)
+    period.Closed = ""
+    period.Reports = nil
+    periods.Current = period.PeriodID
+    periods.Periods = append(periods.Periods, period.PeriodID)
+    err = putCompliancePeriod(stub, period)
+    if err != nil {
+        return nil, err
+    }
+    return nil, putCompliancePeriods(stub, periods)
+}
+
+// ************************************
+// closeCompliancePeriod
+// ************************************
+func (t *SimpleChaincode) closeCompliancePeriod(stub *shim.ChaincodeStub, args []string) ([]byte, error) {
+    var request periodClose
+    var err error
+
+    if len(args) != 1 {
+        err = errors.New("closeCompliancePeriod expects one JSON object with periodID and optional verifiedEmissions")
+        log.Error(err)
+        return nil, err
+    }
+    err = json.Unmarshal([]byte(args[0]), &request)
+    if err != nil {
+        err = fmt.Errorf("closeCompliancePeriod failed to unmarshal arg: %s", err)
+        log.Error(err)
+        return nil, err
+    }
+    periods, err := getCompliancePeriods(stub)
+    if err != nil {
+        return nil, err
+    }
+    if periods.Current == "" || request.PeriodID != periods.Current {
+        err = fmt.Errorf("closeCompliancePeriod period %s is not the open period", request.PeriodID)
+        log.Error(err)
+        return nil, err
+    }
+    for assetID, emissions := range request.VerifiedEmissions {
+        if emissions < 0 || assetID == "trade" || !assetIsActive(stub, assetID) {
+            err = fmt.Errorf("closeCompliancePeriod verified emissions of %g for %s are not valid", emissions, assetID)
+            log.Error(err)
+            return nil, err
+        }
+    }
+    period, err := getCompliancePeriod(stub, request.PeriodID)
+    if err != nil {
+        return nil, err
+    }
+    txntimestamp, err := txnTimestamp(stub)
+    if err != nil {
+        return nil, err
+    }
+    period.Status = PERIODCLOSED
+    period.Closed = txntimestamp.Format(time.RFC3339Nano)
+    period.Reports = make([]ComplianceReport, 0)
+
+    aa, err := getActiveAssets(stub)
+    if err != nil {
+        return nil, err
+    }
+    for _, assetID := range aa {
+        if assetID == "trade" {
+            continue
+        }
+        company, err := getCompanyState(stub, assetID)
+        if err != nil {
+            return nil, err
+        }
+        emissions, verified := request.VerifiedEmissions[assetID]
+        if !verified {
+            emissions = floatValue(company, "reading")
+        }
+        report := period.settle(company, emissions, verified)
+        if report.Penalty != nil {
+            report.Penalty.Timestamp = period.Closed
+        }
+        period.Reports = append(period.Reports, report)
+        company.startNextPeriod(report)
+        err = putCompanyState(stub, assetID, company, txntimestamp, "closeCompliancePeriod", args[0])
+        if err != nil {
+            return nil, err
+        }
+    }
+    // open orders were offered against the credits of the closed period
+    book, err := getOrderBook(stub)
+    if err != nil {
+        return nil, err
+    }
+    err = putOrderBook(stub, OrderBook{Sequence: book.Sequence, Buy: make([]Order, 0), Sell: make([]Order, 0)})
+    if err != nil {
+        return nil, err
+    }
+    err = putCompliancePeriod(stub, period)
+    if err != nil {
+        return nil, err
+    }
+    periods.Current = ""
+    return nil, putCompliancePeriods(stub, periods)
+}
+
+// ************************************
+// readCompliancePeriod
+// ************************************
+// Returns a period, the open period if no periodID is sent in
+func (t *SimpleChaincode) readCompliancePeriod(stub *shim.ChaincodeStub, args []string) ([]byte, error) {
+    var request reportRequest
+    var err error
+
+    if len(args) > 1 {
+        err = errors.New("readCompliancePeriod expects no arguments or one JSON object with periodID")
+        log.Error(err)
+        return nil, err
+    }
+    if len(args) == 1 {
+        err = json.Unmarshal([]byte(args[0]), &request)
+        if err != nil {
+            err = fmt.Errorf("readCompliancePeriod failed to unmarshal arg: %s", err)
+            log.Error(err)
+            return nil, err
+        }
+    }
+    if request.PeriodID == "" {
+        periods, err := getCompliancePeriods(stub)
+        if err != nil {
+            return nil, err
+        }
+        if periods.Current == "" {
+            err = errors.New("readCompliancePeriod no period is open")
+            log.Error(err)
+            return nil, err
+        }
+        request.PeriodID = periods.Current
+    }
+    period, err := getCompliancePeriod(stub, request.PeriodID)
+    if err != nil {
+        return nil, err
+    }
+    return json.Marshal(period)
+}
+
+// ************************************
+// readComplianceReport
+// ************************************
+// Returns the company's report for one period, or for every period if no periodID is
+// sent in. The report of the open period is projected from the current state
+func (t *SimpleChaincode) readComplianceReport(stub *shim.ChaincodeStub, args []string) ([]byte, error) {
+    var request reportRequest
+    var err error
+
+    if len(args) != 1 {
+        err = errors.New("readComplianceReport expects one JSON object with assetID and optional periodID")
+        log.Error(err)
+        return nil, err
+    }
+    err = json.Unmarshal([]byte(args[0]), &request)
+    if err != nil {
+        err = fmt.Errorf("readComplianceReport failed to unmarshal arg: %s", err)
+        log.Error(err)
+        return nil, err
+    }
+    if request.AssetID == "" || request.AssetID == "trade" || !assetIsActive(stub, request.AssetID) {
+        err = fmt.Errorf("readComplianceReport company %s does not exist", request.AssetID)
+        log.Error(err)
+        return nil, err
+    }
+    periods, err := getCompliancePeriods(stub)
+    if err != nil {
+        return nil, err
+    }
+    var reports = make([]ComplianceReport, 0)
+    for _, periodID := range periods.Periods {
+        if request.PeriodID != "" && periodID != request.PeriodID {
+            continue
+        }
+        period, err := getCompliancePeriod(stub, periodID)
+        if err != nil {
+            return nil, err
+        }
+        if period.Status == PERIODOPEN {
+            company, err := getCompanyState(stub, request.AssetID)
+            if err != nil {
+                return nil, err
+            }
+            report := period.settle(company, floatValue(company, "reading"), false)
+            report.Status = PERIODOPEN
+            reports = append(reports, report)
+            continue
+        }
+        for _, report := range period.Reports {
+            if report.AssetID == request.AssetID {
+                reports = append(reports, report)
+            }
+        }
+    }
+    if request.PeriodID != "" && len(reports) == 0 {
+        err = fmt.Errorf("readComplianceReport no report for %s in period %s", request.AssetID, request.PeriodID)
+        log.Error(err)
+        return nil, err
+    }
+    return json.Marshal(reports)
+}
+
+// settle retires credits for the emissions and works out what is banked, forfeited or
+// short for a company at the end of the period
+func (period *CompliancePeriod) settle(company ArgsMap, emissions float64, verified bool) (ComplianceReport) {
+    report := ComplianceReport{
+        PeriodID:        period.PeriodID,
+        Status:          PERIODCLOSED,
+        Emissions:       emissions,
+        Verified:        verified,
+        AllottedCredits: floatValue(company, "allottedCredits"),
+        BankedIn:        floatValue(company, "bankedCredits"),
+        BoughtCredits:   floatValue(company, "boughtCredits"),
+        SoldCredits:     floatValue(company, "soldCredits"),
+    }
+    report.AssetID, _ = company[ASSETID].(string)
+    holdings := math.Max(report.AllottedCredits + report.BankedIn + report.BoughtCredits - report.SoldCredits, 0)
+    report.RetiredCredits = math.Min(emissions, holdings)
+    report.Shortfall = emissions - report.RetiredCredits
+    surplus := holdings - report.RetiredCredits
+    report.BankedOut = surplus
+    if period.BankingLimit != nil {
+        report.BankedOut = math.Min(surplus, *period.BankingLimit * report.AllottedCredits)
+    }
+    report.ForfeitedCredits = surplus - report.BankedOut
+    report.InCompliance = report.Shortfall <= 0
+    if !report.InCompliance {
+        report.Penalty = &PenaltyRecord{
+            PenaltyID:        period.PeriodID + "." + report.AssetID,
+            Credits:          report.Shortfall,
+            PenaltyPerCredit: period.PenaltyPerCredit,
+            Amount:           report.Shortfall * period.PenaltyPerCredit,
+        }
+    }
+    return report
+}
+
+// startNextPeriod resets the period totals of a company after its report
+func (a ArgsMap) startNextPeriod(report ComplianceReport) {
+    a["reading"] = 0.0
+    a["soldCredits"] = 0.0
+    a["boughtCredits"] = 0.0
+    a["bankedCredits"] = report.BankedOut
+    a["retiredCredits"] = floatValue(a, "retiredCredits") + report.RetiredCredits
+    delete(a, "creditsSellList")
+    delete(a, "priceSellList")
+}
+
+func sortedKeys(m map[string]float64) ([]string) {
+    var keys = make([]string, 0, len(m))
+    for k := range m {
+        keys = append(keys, k)
+    }
+    sort.Strings(keys)
+    return keys
+}
+
+func getCompliancePeriods(stub *shim.ChaincodeStub) (CompliancePeriods, error) {
+    var periods = CompliancePeriods{Periods: make([]string, 0)}
+    periodsBytes, err := stub.GetState(PERIODSKEY)
+    if err != nil || len(periodsBytes) == 0 {
+        return periods, nil
+    }
+    err = json.Unmarshal(periodsBytes, &periods)
+    if err != nil {
+        err = fmt.Errorf("Unmarshal failed for compliance periods: %s", err)
+        log.Error(err)
+    }
+    return periods, err
+}
+
+func putCompliancePeriods(stub *shim.ChaincodeStub, periods CompliancePeriods) (error) {
+    periodsJSON, err := json.Marshal(periods)
+    if err != nil {
+        err = fmt.Errorf("Failed to marshal compliance periods: %s", err)
+        log.Error(err)
+        return err
+    }
+    err = stub.PutState(PERIODSKEY, periodsJSON)
+    if err != nil {
+        err = fmt.Errorf("Failed to PUTSTATE compliance periods: %s", err)
+        log.Error(err)
+    }
+    return err
+}
+
+func getCompliancePeriod(stub *shim.ChaincodeStub, periodID string) (CompliancePeriod, error) {
+    var period CompliancePeriod
+    periodBytes, err := stub.GetState(PERIODPREFIX + periodID)
+    if err != nil || len(periodBytes) == 0 {
+        err = fmt.Errorf("compliance period %s does not exist", periodID)
+        log.Error(err)
+        return period, err
+    }
+    err = json.Unmarshal(periodBytes, &period)
+    if err != nil {
+        err = fmt.Errorf("Unmarshal failed for compliance period %s: %s", periodID, err)
+        log.Error(err)
+    }
+    return period, err
+}
+
+func putCompliancePeriod(stub *shim.ChaincodeStub, period CompliancePeriod) (error) {
+    periodJSON, err := json.Marshal(period)
+    if err != nil {
+        err = fmt.Errorf("Failed to marshal compliance period %s: %s", period.PeriodID, err)
+        log.Error(err)
+        return err
+    }
+    err = stub.PutState(PERIODPREFIX + period.PeriodID, periodJSON)
+    if err != nil {
+        err = fmt.Errorf("Failed to PUTSTATE compliance period %s: %s", period.PeriodID, err)
+        log.Error(err)
+    }
+    return err
+}
@@ -0,0 +1,144 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Risham Chokshi - Initial Contribution
+*/
+
+package main
+
+import (
+    "encoding/json"
+    "testing"
+)
+
+func TestOrderBookMatch(t *testing.T) {
+    var book OrderBook
+    var sells = []Order{
+        {OrderID: "S1", AssetID: "A", BuySell: SELL, Remaining: 10, Price: 5, Sequence: 1},
+        {OrderID: "S2", AssetID: "B", BuySell: SELL, Remaining: 10, Price: 4, Sequence: 2},
+        {OrderID: "S3", AssetID: "C", BuySell: SELL, Remaining: 10, Price: 4, Sequence: 3},
+        {OrderID: "S4", AssetID: "D", BuySell: SELL, Remaining: 10, Price: 6, Sequence: 4},
+    }
+    for _, o := range sells {
+        book.add(o)
+    }
+    var ids string
+    for _, o := range book.Sell {
+        ids += o.OrderID
+    }
+    if ids != "S2S3S1S4" {
+        t.Fatalf("sell side not in price-time order: %s", ids)
+    }
+
+    // buyer C does not trade with itself, so S3 is skipped
+    buy := Order{OrderID: "B1", AssetID: "C", BuySell: BUY, Remaining: 25, Price: 5}
+    trades := book.match(&buy, map[string]float64{"A": 100, "B": 100, "D": 100})
+    if len(trades) != 2 {
+        t.Fatalf("expected 2 fills, got %d: %+v", len(trades), trades)
+    }
+    if trades[0].SellOrderID != "S2" || trades[0].Credits != 10 || trades[0].Price != 4 {
+        t.Errorf("first fill should be S2 for 10 at 4: %+v", trades[0])
+    }
+    if trades[1].SellOrderID != "S1" || trades[1].Credits != 10 || trades[1].Price != 5 {
+        t.Errorf("second fill should be S1 for 10 at 5: %+v", trades[1])
+    }
+    if trades[1].Buyer != "C" || trades[1].Seller != "A" || trades[1].BuyOrderID != "B1" {
+        t.Errorf("fill has wrong counterparties: %+v", trades[1])
+    }
+    if buy.Remaining != 5 {
+        t.Errorf("buy order should have 5 remaining, has %g", buy.Remaining)
+    }
+    if len(book.Sell) != 2 || book.Sell[0].OrderID != "S3" || book.Sell[1].OrderID != "S4" {
+        t.Errorf("filled orders should leave the book: %+v", book.Sell)
+    }
+
+    // a partial fill leaves the rest of the resting order in the book
+    sell := Order{OrderID: "S5", AssetID: "A", BuySell: SELL, Remaining: 3, Price: 4}
+    book.add(Order{OrderID: "B2", AssetID: "B", BuySell: BUY, Remaining: 8, Price: 4.5})
+    trades = book.match(&sell, map[string]float64{"A": 3})
+    if len(trades) != 1 || trades[0].Price != 4.5 || sell.Remaining != 0 || book.Buy[0].Remaining != 5 {
+        t.Errorf("partial fill of B2 went wrong: %+v %+v", trades, book.Buy)
+    }
+    if book.openSellCredits("C") != 10 {
+        t.Errorf("C should have 10 credits offered, has %g", book.openSellCredits("C"))
+    }
+    if !book.remove("S3", "C") || book.remove("S4", "C") {
+        t.Error("only the owner can cancel an order")
+    }
+}
+
+func TestOrderBookMatchCapsFillsAtSellerCredits(t *testing.T) {
+    var book OrderBook
+    for _, o := range []Order{
+        {OrderID: "S1", AssetID: "A", BuySell: SELL, Remaining: 10, Price: 4, Sequence: 1},
+        {OrderID: "S2", AssetID: "B", BuySell: SELL, Remaining: 10, Price: 4, Sequence: 2},
+        {OrderID: "S3", AssetID: "A", BuySell: SELL, Remaining: 10, Price: 5, Sequence: 3},
+        {OrderID: "S4", AssetID: "C", BuySell: SELL, Remaining: 10, Price: 5, Sequence: 4},
+    } {
+        book.add(o)
+    }
+
+    // since placing its orders A has used all but 4 credits, and B has none left
+    available := map[string]float64{"A": 4, "B": 0, "C": 10}
+    buy := Order{OrderID: "B1", AssetID: "D", BuySell: BUY, Remaining: 20, Price: 5}
+    trades := book.match(&buy, available)
+    if len(trades) != 2 {
+        t.Fatalf("expected 2 fills, got %d: %+v", len(trades), trades)
+    }
+    if trades[0].SellOrderID != "S1" || trades[0].Credits != 4 {
+        t.Errorf("S1 should fill only the 4 credits A has left: %+v", trades[0])
+    }
+    if trades[1].SellOrderID != "S4" || trades[1].Credits != 10 {
+        t.Errorf("S2 and S3 cannot fill, so S4 should fill for 10: %+v", trades[1])
+    }
+    if buy.Remaining != 6 || available["A"] != 0 || available["C"] != 0 {
+        t.Errorf("wrong remainder %g or seller credits %v", buy.Remaining, available)
+    }
+    if len(book.Sell) != 3 || book.Sell[0].OrderID != "S1" || book.Sell[0].Remaining != 6 || book.Sell[1].OrderID != "S2" || book.Sell[2].OrderID != "S3" {
+        t.Errorf("uncovered orders should stay in the book unfilled: %+v", book.Sell)
+    }
+
+    // an incoming sell is capped the same way
+    book.add(Order{OrderID: "B2", AssetID: "D", BuySell: BUY, Remaining: 10, Price: 6})
+    sell := Order{OrderID: "S5", AssetID: "B", BuySell: SELL, Remaining: 5, Price: 6}
+    if trades = book.match(&sell, map[string]float64{"B": 2}); len(trades) != 1 || trades[0].Credits != 2 || sell.Remaining != 3 {
+        t.Errorf("sell should fill only the 2 credits B has: %+v %+v", trades, sell)
+    }
+}
+
+func TestTradeHistoryRecords(t *testing.T) {
+    var a ArgsMap
+    var old = `{"tradeHistory": {"credits": ["10", "5"], "price": ["2", "3"], "timestamp": ["t1", "t2"],
+        "company": ["2", "3", "B"], "buysell": ["buy", "sell"]}}`
+    if err := json.Unmarshal([]byte(old), &a); err != nil {
+        t.Fatal(err)
+    }
+    records, err := a.updateTradeBlock(true, "1.5", "4", "t3", "C", "buy")
+    if err != nil {
+        t.Fatal(err)
+    }
+    if len(records) != 3 {
+        t.Fatalf("expected 3 records, got %d", len(records))
+    }
+    first := records[0].(TradeRecord)
+    if first.Credits != 10 || first.Price != 2 || first.Timestamp != "t1" || first.BuySell != "buy" || first.Company != "" {
+        t.Errorf("old history converted wrongly: %+v", first)
+    }
+    last := records[2].(TradeRecord)
+    if last.Credits != 1.5 || last.Company != "C" {
+        t.Errorf("new record wrong: %+v", last)
+    }
+    if _, err = a.updateTradeBlock(false, "ten", "4", "t3", "", ""); err == nil {
+        t.Error("credits that are not a number should be rejected")
+    }
+}
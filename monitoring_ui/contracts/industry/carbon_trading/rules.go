@@ -20,6 +20,7 @@ Risham Chokshi - Initial Contribution
 // Rules for Contract
 // KL 23 June 2016 Initial rules package for giving alerts based on threshold
 // RC 6 July 2016  Added all rules dealing with bought, sold, allotted and used credits
+// Readings and credits are per compliance period, banked credits count towards the threshold
 // ************************************
 
 package main
@@ -54,6 +55,7 @@ func (alerts *AlertStatusInternal) overCarbRule (a *ArgsMap) {
     var allot float64 = 0 //value of alloted
     var soldCred float64 = 0 //value for soldCredits from the ledger
     var boughtCred float64 = 0 //value for boughtCredits from the ledger
+    var bankedCred float64 = 0 //value for bankedCredits carried over from the last compliance period
     tbytes, found := getObject(*a, "reading")
     if found {
         carbonUsed, found := tbytes.(float64)
@@ -70,10 +72,12 @@ func (alerts *AlertStatusInternal) overCarbRule (a *ArgsMap) {
                     soldCred, found = tbytes.(float64)
                     tbytes, found = getObject(*a, "boughtCredits")
                     boughtCred, found = tbytes.(float64)
+                    tbytes, found = getObject(*a, "bankedCredits")
+                    bankedCred, found = tbytes.(float64)
                     //calculating total number of credits used
                     carbonUsed = carbonUsed + soldCred
                     //carbon threshold calculated
-                    carbonThreshold = (carbonThreshold/100.0) * (allot + boughtCred)
+                    carbonThreshold = (carbonThreshold/100.0) * (allot + bankedCred + boughtCred)
                     if carbonUsed >= carbonThreshold {
                         alerts.raiseAlert(AlertsOVERCARBON)
                         return 
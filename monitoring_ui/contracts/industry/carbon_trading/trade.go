@@ -18,40 +18,99 @@ Risham Chokshi - Initial Contribution
 // Trade package
 // RC 29 Jun 2016 Initial trade package
 // RC 14 Jul 2016 Trade history attributes set up
+// Trade history holds one record per trade, order book fills and trades sent in with updateAsset
 // ************************************
 
 package main
 
 import (
+    "fmt"
+    "strconv"
+    "strings"
 )
 
-//updating tradeBlock once it is passed in, creating new TradeBlock if one does not exist
-//adding on the contract value if it does exist
-//input is ledger map and returning an updated tradeblock or ledger
-func (a *ArgsMap) updateTradeBlock(regCompany bool, tradeCredits string, tradePrice string, tradetimestamp string, tradeCompany string, tradeType string) (map[string]interface {}){
-    var tradeBlockMap map[string]interface{} 
-    //get the object from ledger if tradeHistory already exists
-    tbytes, found := getObject(*a, "tradeHistory")
-    //if found is false, then a tradeHistory does not exists and new struct needs to be created
-    if found == false {
-        tradeBlockMap = make(map[string]interface{})
-        tradeBlockMap["credits"] = []string{tradeCredits}
-        tradeBlockMap["price"] = []string{tradePrice}
-        tradeBlockMap["timestamp"] = []string{tradetimestamp}
-        if regCompany {
-            tradeBlockMap["company"] = []string{tradeCompany}
-            tradeBlockMap["buysell"] = []string{tradeType}
+// TradeRecord is one trade in a tradeHistory. On a company's history, company is the
+// counterparty and buysell is the side the company was on
+type TradeRecord struct {
+    TradeID     string      `json:"tradeID,omitempty"`
+    Credits     float64     `json:"credits"`
+    Price       float64     `json:"price"`
+    Buyer       string      `json:"buyer,omitempty"`
+    Seller      string      `json:"seller,omitempty"`
+    BuyOrderID  string      `json:"buyOrderID,omitempty"`
+    SellOrderID string      `json:"sellOrderID,omitempty"`
+    Company     string      `json:"company,omitempty"`
+    BuySell     string      `json:"buysell,omitempty"`
+    Timestamp   string      `json:"timestamp"`
+}
+
+//updating tradeBlock once it is passed in, for a trade sent in with updateAsset
+//credits and price are sent in as strings
+//input is ledger map and returning the updated trade history
+func (a *ArgsMap) updateTradeBlock(regCompany bool, tradeCredits string, tradePrice string, tradetimestamp string, tradeCompany string, tradeType string) ([]interface{}, error) {
+    credits, err := strconv.ParseFloat(strings.TrimSpace(tradeCredits), 64)
+    if err != nil {
+        return nil, fmt.Errorf("tradeCredits is not a number: %s", tradeCredits)
+    }
+    price, err := strconv.ParseFloat(strings.TrimSpace(tradePrice), 64)
+    if err != nil {
+        return nil, fmt.Errorf("tradePrice is not a number: %s", tradePrice)
+    }
+    record := TradeRecord{Credits: credits, Price: price, Timestamp: tradetimestamp}
+    if regCompany {
+        record.Company = tradeCompany
+        record.BuySell = tradeType
+    }
+    return a.appendTradeRecord(record), nil
+}
+
+//appending a record to the tradeHistory, converting a history in the old format first
+func (a *ArgsMap) appendTradeRecord(record TradeRecord) ([]interface{}) {
+    return append(tradeHistoryRecords(*a), record)
+}
+
+//tradeHistory used to be parallel credits, price, timestamp, company and buysell arrays.
+//Returns the history as a list of records, whichever format it is stored in
+func tradeHistoryRecords(a ArgsMap) ([]interface{}) {
+    tbytes, found := getObject(a, "tradeHistory")
+    if !found {
+        return make([]interface{}, 0)
+    }
+    if records, found := tbytes.([]interface{}); found {
+        return records
+    }
+    old, found := tbytes.(map[string]interface{})
+    if !found {
+        return make([]interface{}, 0)
+    }
+    column := func(name string) ([]interface{}) {
+        values, _ := old[name].([]interface{})
+        return values
+    }
+    credits, price, timestamp := column("credits"), column("price"), column("timestamp")
+    company, buysell := column("company"), column("buysell")
+    // company was appended onto price by mistake, so unless it lines up with the
+    // other arrays it cannot be trusted
+    if len(company) != len(credits) {
+        company = nil
+    }
+    records := make([]interface{}, 0, len(credits))
+    for i := range credits {
+        record := TradeRecord{}
+        record.Credits, _ = strconv.ParseFloat(fmt.Sprint(credits[i]), 64)
+        if i < len(price) {
+            record.Price, _ = strconv.ParseFloat(fmt.Sprint(price[i]), 64)
+        }
+        if i < len(timestamp) {
+            record.Timestamp = fmt.Sprint(timestamp[i])
+        }
+        if i < len(company) {
+            record.Company = fmt.Sprint(company[i])
         }
-    } else {
-        tradeBlockMap = tbytes.(map[string]interface{})
-        //appending all the new attributes
-        tradeBlockMap["credits"] = append(tradeBlockMap["credits"].([]interface{}), tradeCredits)
-        tradeBlockMap["price"] = append(tradeBlockMap["price"].([]interface{}), tradePrice)
-        tradeBlockMap["timestamp"] = append(tradeBlockMap["timestamp"].([]interface{}), tradetimestamp)
-        if regCompany {
-            tradeBlockMap["company"] = append(tradeBlockMap["price"].([]interface{}), tradeCompany)
-            tradeBlockMap["buysell"] = append(tradeBlockMap["buysell"].([]interface{}), tradeType)
+        if i < len(buysell) {
+            record.BuySell = fmt.Sprint(buysell[i])
         }
+        records = append(records, record)
     }
-    return tradeBlockMap
-}
\ No newline at end of file
+    return records
+}
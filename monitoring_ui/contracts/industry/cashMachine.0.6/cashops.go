@@ -0,0 +1,440 @@
+/*******************************************************************************
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+
+Contributors:
+
+Sumabala Nair - Modified SimpleContract for CashMachine use case
+
+******************************************************************************/
+
+// Cassettes, daily withdrawal limits, alerts, end of day reconciliation and
+// per transaction history for the cash machine
+
+package main
+
+import (
+    "encoding/json"
+    "errors"
+    "fmt"
+    "math"
+    "sort"
+    "time"
+
+    "github.com/hyperledger/fabric/core/chaincode/shim"
+)
+
+// alerts raised on the cash machine state, recalculated after every transaction
+const (
+    ALERTLOWCASH         string = "LOWCASH"
+    ALERTNEGATIVEBALANCE string = "NEGATIVEBALANCE"
+    ALERTDISCREPANCY     string = "DISCREPANCY"
+)
+
+// Cassette holds the notes of one denomination
+type Cassette struct {
+    CassetteID   string  `json:"cassetteid"`
+    Denomination float64 `json:"denomination,omitempty"`
+    Count        int     `json:"count"`
+}
+
+// CassetteDiscrepancy compares the ledger count of a cassette with its physical count
+type CassetteDiscrepancy struct {
+    CassetteID   string  `json:"cassetteid"`
+    Denomination float64 `json:"denomination"`
+    LedgerCount  int     `json:"ledgercount"`
+    CountedCount int     `json:"countedcount"`
+    Difference   int     `json:"difference"`
+}
+
+// Reconciliation records an end of day physical count against the ledger
+type Reconciliation struct {
+    Timestamp      string                `json:"timestamp"`
+    TxnID          string                `json:"txnid"`
+    LedgerBalance  float64               `json:"ledgerbalance"`
+    CountedBalance float64               `json:"countedbalance"`
+    Discrepancy    float64               `json:"discrepancy"`
+    Cassettes      []CassetteDiscrepancy `json:"cassettes,omitempty"`
+    Balanced       bool                  `json:"balanced"`
+}
+
+// ReconcileEvent is the reported physical count, either per cassette or as a total
+type ReconcileEvent struct {
+    AssetID        string     `json:"assetid"`
+    Cassettes      []Cassette `json:"cassettes,omitempty"`
+    CountedBalance *float64   `json:"countedbalance,omitempty"`
+    Timestamp      string     `json:"timestamp,omitempty"`
+}
+
+// amounts are compared in cents to stay clear of floating point drift
+func toCents(amount float64) int64 {
+    return int64(math.Floor(amount*100 + 0.5))
+}
+
+func cassetteTotal(cassettes []Cassette) float64 {
+    var cents int64
+    for _, c := range cassettes {
+        cents += toCents(c.Denomination) * int64(c.Count)
+    }
+    return float64(cents) / 100
+}
+
+func findCassette(cassettes []Cassette, cassetteID string) int {
+    for i, c := range cassettes {
+        if c.CassetteID == cassetteID {
+            return i
+        }
+    }
+    return -1
+}
+
+// loadCassettes adds notes to the machine's cassettes, a cassette that is not yet
+// known is installed with the denomination given in the event
+func (s *CashMachineState) loadCassettes(in []Cassette) error {
+    for _, c := range in {
+        if c.Count < 0 {
+            return errors.New("Cassette " + c.CassetteID + " count cannot be negative")
+        }
+        i := findCassette(s.Cassettes, c.CassetteID)
+        if i < 0 {
+            if c.CassetteID == "" || c.Denomination <= 0 {
+                return errors.New("A new cassette needs a cassetteid and a positive denomination")
+            }
+            s.Cassettes = append(s.Cassettes, c)
+            continue
+        }
+        if c.Denomination != 0 && c.Denomination != s.Cassettes[i].Denomination {
+            return fmt.Errorf("Cassette %s holds denomination %.2f, not %.2f", c.CassetteID, s.Cassettes[i].Denomination, c.Denomination)
+        }
+        s.Cassettes[i].Count += c.Count
+    }
+    return nil
+}
+
+// unloadCassettes removes the notes that were dispensed from each cassette
+func (s *CashMachineState) unloadCassettes(out []Cassette) error {
+    for _, c := range out {
+        i := findCassette(s.Cassettes, c.CassetteID)
+        if i < 0 {
+            return errors.New("Unknown cassette " + c.CassetteID)
+        }
+        if c.Count < 0 || c.Count > s.Cassettes[i].Count {
+            return fmt.Errorf("Cassette %s holds %d notes, cannot dispense %d", c.CassetteID, s.Cassettes[i].Count, c.Count)
+        }
+        s.Cassettes[i].Count -= c.Count
+    }
+    return nil
+}
+
+// MAXDISPENSEUNITS bounds the search for the notes of a withdrawal, counted in
+// units of the greatest common divisor of the denominations in the machine
+const MAXDISPENSEUNITS int64 = 100000
+
+// byDenomination orders cassette indexes from the largest denomination down
+type byDenomination struct {
+    order     []int
+    cassettes []Cassette
+}
+
+func (b byDenomination) Len() int      { return len(b.order) }
+func (b byDenomination) Swap(i, j int) { b.order[i], b.order[j] = b.order[j], b.order[i] }
+func (b byDenomination) Less(i, j int) bool {
+    return b.cassettes[b.order[i]].Denomination > b.cassettes[b.order[j]].Denomination
+}
+
+func gcd(a, b int64) int64 {
+    for b != 0 {
+        a, b = b, a%b
+    }
+    return a
+}
+
+// dispense picks the fewest notes that make up the amount exactly. Taking the largest
+// notes first cannot pay e.g. 60 from 50s and 20s, so the notes are chosen by
+// dynamic programming over the cassette counts, one cassette at a time from the
+// largest denomination down, which prefers larger notes when two choices tie.
+func (s *CashMachineState) dispense(amount float64) ([]Cassette, error) {
+    var order = make([]int, 0, len(s.Cassettes))
+    var unit int64
+    for i, c := range s.Cassettes {
+        if d := toCents(c.Denomination); d > 0 && c.Count > 0 {
+            order = append(order, i)
+            unit = gcd(d, unit)
+        }
+    }
+    sort.Stable(byDenomination{order, s.Cassettes})
+    cents := toCents(amount)
+    if cents == 0 {
+        return make([]Cassette, 0), nil
+    }
+    if unit == 0 || cents%unit != 0 {
+        return nil, fmt.Errorf("Cannot dispense %.2f from the notes in the cassettes", amount)
+    }
+    units := cents / unit
+    if units > MAXDISPENSEUNITS {
+        return nil, fmt.Errorf("Cannot dispense %.2f, it needs more than %d of the smallest notes", amount, MAXDISPENSEUNITS)
+    }
+    // fewest[v] is the fewest notes that make up v units from the cassettes so far,
+    // and take[k][v] the notes from the k-th cassette in that choice
+    const none = math.MaxInt32
+    var fewest = make([]int, units+1)
+    for v := range fewest {
+        fewest[v] = none
+    }
+    fewest[0] = 0
+    var take = make([][]int, len(order))
+    for k, i := range order {
+        d := toCents(s.Cassettes[i].Denomination) / unit
+        next := make([]int, units+1)
+        take[k] = make([]int, units+1)
+        for v := int64(0); v <= units; v++ {
+            next[v] = none
+            for n := int64(0); n <= int64(s.Cassettes[i].Count) && n*d <= v; n++ {
+                if prev := fewest[v-n*d]; prev != none && prev+int(n) < next[v] {
+                    next[v] = prev + int(n)
+                    take[k][v] = int(n)
+                }
+            }
+        }
+        fewest = next
+    }
+    if fewest[units] == none {
+        return nil, fmt.Errorf("Cannot dispense %.2f from the notes in the cassettes", amount)
+    }
+    var notes = make([]Cassette, 0)
+    v := units
+    for k := len(order) - 1; k >= 0; k-- {
+        c := s.Cassettes[order[k]]
+        if n := take[k][v]; n > 0 {
+            notes = append([]Cassette{{c.CassetteID, c.Denomination, n}}, notes...)
+            v -= int64(n) * (toCents(c.Denomination) / unit)
+        }
+    }
+    return notes, nil
+}
+
+// applyCash moves money in or out of the machine. Cassette counts, when sent in,
+// must agree with the amount, and a withdrawal without them is dispensed from the
+// cassettes if the machine has any.
+func (s *CashMachineState) applyCash(actionType string, amount float64, cassettes []Cassette) error {
+    if len(cassettes) > 0 {
+        // installed cassettes count at their own denomination, new ones at the one sent in
+        var priced = make([]Cassette, len(cassettes))
+        copy(priced, cassettes)
+        for i, c := range priced {
+            if j := findCassette(s.Cassettes, c.CassetteID); j >= 0 {
+                priced[i].Denomination = s.Cassettes[j].Denomination
+            }
+        }
+        notes := cassetteTotal(priced)
+        if amount == 0 {
+            amount = notes
+        } else if toCents(amount) != toCents(notes) {
+            return fmt.Errorf("Amount %.2f does not match the %.2f in the cassette counts", amount, notes)
+        }
+    }
+    if amount < 0 {
+        return errors.New("Amount cannot be negative")
+    }
+    switch actionType {
+    case "Deposit":
+        if err := s.loadCassettes(cassettes); err != nil {
+            return err
+        }
+        s.Balance += amount
+    case "Withdraw":
+        if s.DailyLimit != nil && toCents(s.WithdrawnToday+amount) > toCents(*s.DailyLimit) {
+            return fmt.Errorf("Withdrawal of %.2f exceeds the daily limit of %.2f, %.2f already withdrawn on %s", amount, *s.DailyLimit, s.WithdrawnToday, s.BusinessDay)
+        }
+        if len(cassettes) == 0 && len(s.Cassettes) > 0 {
+            var err error
+            if cassettes, err = s.dispense(amount); err != nil {
+                return err
+            }
+        }
+        if err := s.unloadCassettes(cassettes); err != nil {
+            return err
+        }
+        s.Balance -= amount
+        s.WithdrawnToday += amount
+    default:
+        return errors.New("ActionType must be Deposit or Withdraw, received: " + actionType)
+    }
+    s.Amount = amount
+    return nil
+}
+
+// startBusinessDay resets the daily withdrawals when a transaction falls on a new day.
+// The day comes from the transaction time only, as a client could otherwise reset
+// its daily limit by sending a timestamp on another day.
+func (s *CashMachineState) startBusinessDay(txnTime time.Time) error {
+    if txnTime.IsZero() {
+        return errors.New("Unable to get transaction time for the business day")
+    }
+    day := txnTime.UTC().Format("2006-01-02")
+    if day != s.BusinessDay {
+        s.BusinessDay = day
+        s.WithdrawnToday = 0
+    }
+    return nil
+}
+
+// setAlerts recalculates the alerts from the balance and the last reconciliation
+func (s *CashMachineState) setAlerts() {
+    s.Alerts = make([]string, 0)
+    if s.LowCashThreshold != nil && toCents(s.Balance) < toCents(*s.LowCashThreshold) {
+        s.Alerts = append(s.Alerts, ALERTLOWCASH)
+    }
+    if toCents(s.Balance) < 0 {
+        s.Alerts = append(s.Alerts, ALERTNEGATIVEBALANCE)
+    }
+    if s.LastReconciliation != nil && !s.LastReconciliation.Balanced {
+        s.Alerts = append(s.Alerts, ALERTDISCREPANCY)
+    }
+}
+
+// reconcile compares a reported physical count with the ledger and records the result,
+// the ledger is left as is so that the discrepancy can be investigated
+func (s *CashMachineState) reconcile(count ReconcileEvent, timestamp string, txnID string) (Reconciliation, error) {
+    var r = Reconciliation{Timestamp: timestamp, TxnID: txnID, LedgerBalance: s.Balance}
+    if len(count.Cassettes) > 0 {
+        var counted int64
+        for _, c := range s.Cassettes {
+            r.Cassettes = append(r.Cassettes, CassetteDiscrepancy{CassetteID: c.CassetteID, Denomination: c.Denomination, LedgerCount: c.Count})
+        }
+        for _, c := range count.Cassettes {
+            i := findCassette(s.Cassettes, c.CassetteID)
+            if i < 0 {
+                return r, errors.New("Reconciliation counted unknown cassette " + c.CassetteID)
+            }
+            if c.Count < 0 {
+                return r, errors.New("Cassette " + c.CassetteID + " count cannot be negative")
+            }
+            r.Cassettes[i].CountedCount += c.Count
+        }
+        for i := range r.Cassettes {
+            r.Cassettes[i].Difference = r.Cassettes[i].CountedCount - r.Cassettes[i].LedgerCount
+            counted += toCents(r.Cassettes[i].Denomination) * int64(r.Cassettes[i].CountedCount)
+        }
+        r.CountedBalance = float64(counted) / 100
+        if count.CountedBalance != nil && toCents(*count.CountedBalance) != counted {
+            return r, fmt.Errorf("Counted balance %.2f does not match the %.2f in the cassette counts", *count.CountedBalance, r.CountedBalance)
+        }
+    } else if count.CountedBalance != nil {
+        r.CountedBalance = *count.CountedBalance
+    } else {
+        return r, errors.New("Reconciliation needs cassette counts or a countedbalance")
+    }
+    r.Discrepancy = float64(toCents(r.CountedBalance)-toCents(r.LedgerBalance)) / 100
+    r.Balanced = r.Discrepancy == 0
+    for _, c := range r.Cassettes {
+        if c.Difference != 0 {
+            r.Balanced = false
+        }
+    }
+    s.LastReconciliation = &r
+    return r, nil
+}
+
+// ************************************
+// history, one key per transaction
+// ************************************
+
+func historyPrefix(assetID string) string {
+    return assetID + HISTKEY + "."
+}
+
+func historyKey(assetID string, seq int) string {
+    return fmt.Sprintf("%s%010d", historyPrefix(assetID), seq)
+}
+
+// putHistory writes the state after a transaction under the next history key
+func putHistory(stub shim.ChaincodeStubInterface, state *CashMachineState) error {
+    state.HistorySeq++
+    stateJSON, err := json.Marshal(state)
+    if err != nil {
+        return errors.New("Marshal failed for cash machine history" + fmt.Sprint(err))
+    }
+    return stub.PutState(historyKey(state.AssetID, state.HistorySeq), stateJSON)
+}
+
+// historyKeys returns the history keys of an asset, oldest first
+func historyKeys(stub shim.ChaincodeStubInterface, assetID string) ([]string, error) {
+    var keys = make([]string, 0)
+    prefix := historyPrefix(assetID)
+    iter, err := stub.RangeQueryState(prefix, prefix+"~")
+    if err != nil {
+        return nil, errors.New("Unable to start the history range query: " + fmt.Sprint(err))
+    }
+    defer iter.Close()
+    for iter.HasNext() {
+        key, _, err := iter.Next()
+        if err != nil {
+            return nil, errors.New("History range query failed: " + fmt.Sprint(err))
+        }
+        keys = append(keys, key)
+    }
+    sort.Strings(keys)
+    return keys, nil
+}
+
+// readHistory returns up to count states, most recent first, with any history
+// left in the array format of earlier versions of the contract at the end
+func readHistory(stub shim.ChaincodeStubInterface, assetID string, count int) ([]CashMachineState, error) {
+    var history = make([]CashMachineState, 0)
+    keys, err := historyKeys(stub, assetID)
+    if err != nil {
+        return nil, err
+    }
+    for i := len(keys) - 1; i >= 0; i-- {
+        stateBytes, err := stub.GetState(keys[i])
+        if err != nil {
+            return nil, errors.New("Unable to get asset history from ledger: " + fmt.Sprint(err))
+        }
+        var state CashMachineState
+        if err = json.Unmarshal(stateBytes, &state); err != nil {
+            return nil, errors.New("Unable to unmarshal asset history data obtained from ledger")
+        }
+        history = append(history, state)
+    }
+    legacyBytes, err := stub.GetState(assetID + HISTKEY)
+    if err == nil && len(legacyBytes) > 0 {
+        var legacy CashMachineHistory
+        if err = json.Unmarshal(legacyBytes, &legacy); err == nil {
+            for _, entry := range legacy.CashHistory {
+                var state CashMachineState
+                if json.Unmarshal([]byte(entry), &state) == nil {
+                    history = append(history, state)
+                }
+            }
+        }
+    }
+    if count > 0 && count < len(history) {
+        history = history[:count]
+    }
+    return history, nil
+}
+
+// deleteHistory removes every history key of an asset
+func deleteHistory(stub shim.ChaincodeStubInterface, assetID string) error {
+    keys, err := historyKeys(stub, assetID)
+    if err != nil {
+        return err
+    }
+    for _, key := range keys {
+        if err = stub.DelState(key); err != nil {
+            return errors.New("Asset History delete failed! : " + fmt.Sprint(err))
+        }
+    }
+    return stub.DelState(assetID + HISTKEY)
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+    "reflect"
+    "testing"
+    "time"
+)
+
+func TestDispense(t *testing.T) {
+    var tests = []struct {
+        cassettes []Cassette
+        amount    float64
+        want      []Cassette
+    }{
+        // largest note first would take a 50 and be left with 10
+        {[]Cassette{{"C20", 20, 10}, {"C50", 50, 10}}, 60, []Cassette{{"C20", 20, 3}}},
+        {[]Cassette{{"C20", 20, 10}, {"C50", 50, 10}}, 110, []Cassette{{"C50", 50, 1}, {"C20", 20, 3}}},
+        // fewest notes, larger notes when they tie
+        {[]Cassette{{"C20", 20, 10}, {"C50", 50, 10}}, 100, []Cassette{{"C50", 50, 2}}},
+        {[]Cassette{{"C10", 10, 10}, {"C20", 20, 10}, {"C50", 50, 1}}, 80, []Cassette{{"C50", 50, 1}, {"C20", 20, 1}, {"C10", 10, 1}}},
+        // an empty cassette cannot be used
+        {[]Cassette{{"C20", 20, 10}, {"C50", 50, 0}}, 100, []Cassette{{"C20", 20, 5}}},
+        {[]Cassette{{"C5", 5.5, 4}}, 16.5, []Cassette{{"C5", 5.5, 3}}},
+    }
+    for _, tt := range tests {
+        s := CashMachineState{Cassettes: tt.cassettes}
+        notes, err := s.dispense(tt.amount)
+        if err != nil {
+            t.Errorf("dispense %.2f from %+v failed: %s", tt.amount, tt.cassettes, err)
+            continue
+        }
+        if !reflect.DeepEqual(notes, tt.want) {
+            t.Errorf("dispense %.2f from %+v gave %+v, want %+v", tt.amount, tt.cassettes, notes, tt.want)
+        }
+    }
+
+    var fails = []struct {
+        cassettes []Cassette
+        amount    float64
+    }{
+        {[]Cassette{{"C20", 20, 2}, {"C50", 50, 10}}, 60},
+        {[]Cassette{{"C20", 20, 10}, {"C50", 50, 10}}, 30},
+        {[]Cassette{{"C20", 20, 10}}, 15},
+        {[]Cassette{{"C1", 0.01, 10}}, 10000},
+    }
+    for _, tt := range fails {
+        s := CashMachineState{Cassettes: tt.cassettes}
+        if notes, err := s.dispense(tt.amount); err == nil {
+            t.Errorf("dispense %.2f from %+v should fail, gave %+v", tt.amount, tt.cassettes, notes)
+        }
+    }
+}
+
+func TestStartBusinessDay(t *testing.T) {
+    s := CashMachineState{BusinessDay: "2016-10-18", WithdrawnToday: 200}
+    if err := s.startBusinessDay(time.Time{}); err == nil {
+        t.Fatal("a business day needs the transaction time")
+    }
+    if err := s.startBusinessDay(time.Date(2016, 10, 18, 23, 0, 0, 0, time.UTC)); err != nil || s.WithdrawnToday != 200 {
+        t.Fatalf("same day should keep the withdrawals: %+v %v", s, err)
+    }
+    // 01:00 in Paris is still the 18th in UTC
+    paris := time.FixedZone("CEST", 2*60*60)
+    if err := s.startBusinessDay(time.Date(2016, 10, 19, 1, 0, 0, 0, paris)); err != nil || s.BusinessDay != "2016-10-18" {
+        t.Fatalf("the business day is taken in UTC: %+v %v", s, err)
+    }
+    if err := s.startBusinessDay(time.Date(2016, 10, 19, 0, 0, 1, 0, time.UTC)); err != nil || s.BusinessDay != "2016-10-19" || s.WithdrawnToday != 0 {
+        t.Fatalf("a new day should reset the withdrawals: %+v %v", s, err)
+    }
+}
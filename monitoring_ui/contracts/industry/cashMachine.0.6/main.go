@@ -61,8 +61,20 @@ type CashMachineState struct {
     Amount     float64 `json:"amount,omitempty"`
     Balance    float64 `json:"balance,omitempty"`
     Timestamp  string  `json:"timestamp,omitempty"`
+
+    Cassettes          []Cassette      `json:"cassettes,omitempty"`
+    DailyLimit         *float64        `json:"dailylimit,omitempty"`
+    LowCashThreshold   *float64        `json:"lowcashthreshold,omitempty"`
+    BusinessDay        string          `json:"businessday,omitempty"`
+    WithdrawnToday     float64         `json:"withdrawntoday,omitempty"`
+    Alerts             []string        `json:"alerts,omitempty"`
+    LastReconciliation *Reconciliation `json:"lastreconciliation,omitempty"`
+    TxnID              string          `json:"txnid,omitempty"`
+    HistorySeq         int             `json:"historyseq,omitempty"`
 }
 
+// CashMachineHistory is the single history array written by earlier versions
+// of the contract, history is now stored under one key per transaction
 type CashMachineHistory struct {
     CashHistory []string `json:"cashhistory"`
 }
@@ -110,6 +122,9 @@ func (t *SimpleChaincode) Invoke(stub shim.ChaincodeStubInterface, function stri
     } else if function == "deleteAsset" {
         // Deletes an asset by ID from the ledger
         return t.deleteAsset(stub, args)
+    } else if function == "reconcileAsset" {
+        // Records an end of day physical count against the ledger
+        return t.reconcileAsset(stub, args)
     }
     return nil, errors.New("Received unknown invocation: " + function)
 }
@@ -180,10 +195,8 @@ func (t *SimpleChaincode) deleteAsset(stub shim.ChaincodeStubInterface, args []s
         err = errors.New("Asset record delete failed! : " + fmt.Sprint(err))
         return nil, err
     }
-    cmHistKey := stateIn.AssetID + HISTKEY
-    err = stub.DelState(cmHistKey)
+    err = deleteHistory(stub, assetID)
     if err != nil {
-        err = errors.New("Asset History delete failed! : " + fmt.Sprint(err))
         return nil, err
     }
     return nil, nil
@@ -221,28 +234,26 @@ func (t *SimpleChaincode) readAsset(stub shim.ChaincodeStubInterface, args []str
 //*************readCashMachineObjectModel*****************/
 
 func (t *SimpleChaincode) readAssetHistory(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-    var cmHistKey string // asset ID history key
-    var err error
-    var state CashMachineState
+    var countArg struct {
+        Count int `json:"count"`
+    }
 
     // validate input data for number of args, Unmarshaling to asset state and obtain asset id
     stateIn, err := t.validateInput(args)
     if err != nil {
         return nil, errors.New("Asset does not exist!")
     }
-    cmHistKey = stateIn.AssetID + HISTKEY
-    // Get the state from the ledger
-    assetBytes, err := stub.GetState(cmHistKey)
-    if err != nil || len(assetBytes) == 0 {
-        err = errors.New("Unable to get asset history from ledger")
+    // count is optional, zero returns all history states
+    _ = json.Unmarshal([]byte(args[0]), &countArg)
+    history, err := readHistory(stub, stateIn.AssetID, countArg.Count)
+    if err != nil {
         return nil, err
     }
-    err = json.Unmarshal(assetBytes, &state)
-    if err != nil {
-        err = errors.New("Unable to unmarshal asset history data obtained from ledger")
+    if len(history) == 0 {
+        err = errors.New("Unable to get asset history from ledger")
         return nil, err
     }
-    return assetBytes, nil
+    return json.Marshal(history)
 }
 
 //*************readCashMachineSamples*******************
@@ -296,12 +307,10 @@ func (t *SimpleChaincode) validateInput(args []string) (stateIn CashMachineState
 //******************** createOrupdateCashMachine ********************/
 
 func (t *SimpleChaincode) createOrupdateCashMachine(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-    var assetID string // asset ID                    // used when looking in map
+    var assetID string // asset ID
     var err error
     var stateIn CashMachineState
     var stateStub CashMachineState
-    var cashMachineHistory CashMachineHistory
-    var cmHistoryState []byte
 
     // validate input data for number of args, Unmarshaling to asset state and obtain asset id
 
@@ -309,109 +318,137 @@ func (t *SimpleChaincode) createOrupdateCashMachine(stub shim.ChaincodeStubInter
     if err != nil {
         return nil, err
     }
-    // fmt.Println("after validate input ")
     assetID = stateIn.AssetID
-    stimeStamp := strings.TrimSpace(stateIn.Timestamp)
-    if stimeStamp == "" {
-        // Obtain timestamp from the stub
-        txnTime, err := stub.GetTxTimestamp()
-        if err != nil {
-            err = errors.New("Unable to get transaction time")
-            return nil, err
-        }
-        txntimestamp := time.Unix(txnTime.Seconds, int64(txnTime.Nanos))
-        sTime := txntimestamp.String()
-        stateIn.Timestamp = sTime
-    } else {
-        stateIn.Timestamp = stimeStamp
+    stimeStamp, txnTime, err := eventTimestamp(stub, stateIn.Timestamp)
+    if err != nil {
+        return nil, err
     }
-    // fmt.Println("Time: ", stateIn.Timestamp)
-    cmHistKey := stateIn.AssetID + HISTKEY
     // Check if asset record existed in stub
     assetBytes, err := stub.GetState(assetID)
-    // fmt.Println ("error is ", err)
     if err != nil || len(assetBytes) == 0 {
-        // This implies that this is a 'create' scenario
-        stateIn.Balance = stateIn.Amount
-        stateIn.ActionType = "InitialBalance"
-        stateStub = stateIn // The record that goes into the stub is the one that comes in, since this is a create scenario
-        // fmt.Println("assetBytes ", stateStub.AssetID)
-        // If its a create scenario, history cannot exist
-        // Do We want to store the raw data in history? Right now it stores processed data
-        stateInJSON, err := json.Marshal(stateIn)
+        // This implies that this is a 'create' scenario, the opening balance is the
+        // amount or else the value of the notes loaded into the cassettes
+        stateStub = CashMachineState{AssetID: assetID, DailyLimit: stateIn.DailyLimit, LowCashThreshold: stateIn.LowCashThreshold}
+        err = stateStub.loadCassettes(stateIn.Cassettes)
         if err != nil {
-            return nil, errors.New("Marshal failed for incoming contract state" + fmt.Sprint(err))
+            return nil, err
         }
-        var cmHistory = CashMachineHistory{make([]string, 1)}
-        cmHistory.CashHistory[0] = string(stateInJSON)
-        cmState, err := json.Marshal(cmHistory)
-        if err != nil {
+        stateStub.Amount = stateIn.Amount
+        if stateStub.Amount == 0 {
+            stateStub.Amount = cassetteTotal(stateStub.Cassettes)
+        }
+        stateStub.Balance = stateStub.Amount
+        stateStub.ActionType = "InitialBalance"
+        if err = stateStub.startBusinessDay(txnTime); err != nil {
             return nil, err
         }
-        cmHistoryState = []byte(cmState)
     } else {
         // This is an update scenario
-        // fmt.Println("Update Scenario")
-        // fmt.Println("Data in stub was :", string(assetBytes))
         err = json.Unmarshal(assetBytes, &stateStub)
         if err != nil {
             err = errors.New("Unable to unmarshal JSON data from stub")
             return nil, err
             // state is an empty instance of asset state
         }
-        // fmt.Println("stateIn.ActionType is ", stateIn.ActionType)
-        stateStub.Amount = stateIn.Amount
-        if stateIn.ActionType == "Deposit" {
-            stateStub.Balance = stateStub.Balance + stateIn.Amount
-        } else {
-            stateStub.Balance = stateStub.Balance - stateIn.Amount
-            // Assuming stateStub.ActionType is "Withdraw"
-        }
-        stateIn.Balance = stateStub.Balance     // assuming history needs banace too
-        stateStub.Timestamp = stateIn.Timestamp // updating the stub record
-        stateStub.ActionType = stateIn.ActionType
-        // get the histoty of the machine
-        // Do We want to store the raw data in history? Right now it stores processed data
-        stateInJSON, err := json.Marshal(stateIn)
-        if err != nil {
-            return nil, errors.New("Marshal failed for incoming contract state" + fmt.Sprint(err))
+        // limits can be changed with any event and apply to its own withdrawal
+        if stateIn.DailyLimit != nil {
+            stateStub.DailyLimit = stateIn.DailyLimit
         }
-        cmHistory, err := stub.GetState(cmHistKey)
-        if err != nil {
-            return nil, err
+        if stateIn.LowCashThreshold != nil {
+            stateStub.LowCashThreshold = stateIn.LowCashThreshold
         }
-
-        err = json.Unmarshal(cmHistory, &cashMachineHistory)
-        if err != nil {
+        if err = stateStub.startBusinessDay(txnTime); err != nil {
             return nil, err
         }
-
-        var cmSlice = make([]string, 0)
-        cmSlice = append(cmSlice, string(stateInJSON))
-        cmSlice = append(cmSlice, cashMachineHistory.CashHistory...)
-        cashMachineHistory.CashHistory = cmSlice
-        cmState, err := json.Marshal(cashMachineHistory)
-        if err != nil {
-            return nil, err
+        if stateIn.ActionType == "" && stateIn.Amount == 0 && len(stateIn.Cassettes) == 0 {
+            stateStub.ActionType = "Configure"
+            stateStub.Amount = 0
+        } else {
+            err = stateStub.applyCash(stateIn.ActionType, stateIn.Amount, stateIn.Cassettes)
+            if err != nil {
+                return nil, err
+            }
+            stateStub.ActionType = stateIn.ActionType
         }
-        cmHistoryState = []byte(cmState)
     }
+    stateStub.Timestamp = stimeStamp
+    stateStub.TxnID = stub.GetTxID()
+    stateStub.setAlerts()
+    return nil, putCashMachine(stub, &stateStub)
+}
 
-    // Now that the statestub record has the updated data, we can put it in the stub
-    stateJSON, err := json.Marshal(stateStub)
+//******************** reconcileCashMachine ********************/
+
+func (t *SimpleChaincode) reconcileAsset(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+    var count ReconcileEvent
+    var stateStub CashMachineState
+
+    if len(args) != 1 {
+        return nil, errors.New("Incorrect number of arguments. Expecting a JSON string with mandatory assetID and the counted cassettes or balance")
+    }
+    err := json.Unmarshal([]byte(args[0]), &count)
     if err != nil {
-        return nil, errors.New("Marshal failed for contract state" + fmt.Sprint(err))
+        return nil, errors.New("Unable to unmarshal input JSON data")
     }
-
-    // Write the new state to the ledger
-    err = stub.PutState(assetID, stateJSON)
+    assetID := strings.TrimSpace(count.AssetID)
+    if assetID == "" {
+        return nil, errors.New("AssetID not passed")
+    }
+    assetBytes, err := stub.GetState(assetID)
+    if err != nil || len(assetBytes) == 0 {
+        return nil, errors.New("Asset does not exist!")
+    }
+    err = json.Unmarshal(assetBytes, &stateStub)
+    if err != nil {
+        return nil, errors.New("Unable to unmarshal JSON data from stub")
+    }
+    stimeStamp, _, err := eventTimestamp(stub, count.Timestamp)
     if err != nil {
-        err = errors.New("PUT ledger state failed: " + fmt.Sprint(err))
         return nil, err
     }
-    err = stub.PutState(cmHistKey, cmHistoryState)
+    _, err = stateStub.reconcile(count, stimeStamp, stub.GetTxID())
     if err != nil {
-        return nil, errors.New("Cash machine transaction history failed PUT to ledger: " + fmt.Sprint(err))
+        return nil, err
     }
-    return nil, nil
+    stateStub.ActionType = "Reconcile"
+    stateStub.Amount = 0
+    stateStub.Timestamp = stimeStamp
+    stateStub.TxnID = stub.GetTxID()
+    stateStub.setAlerts()
+    return nil, putCashMachine(stub, &stateStub)
+}
+
+// eventTimestamp returns the event's timestamp, or the transaction time when it
+// was not sent in, along with the transaction time when the fabric provides it
+func eventTimestamp(stub shim.ChaincodeStubInterface, timestamp string) (string, time.Time, error) {
+    var txntimestamp time.Time
+    txnTime, err := stub.GetTxTimestamp()
+    if err == nil && txnTime != nil {
+        txntimestamp = time.Unix(txnTime.Seconds, int64(txnTime.Nanos))
+    }
+    stimeStamp := strings.TrimSpace(timestamp)
+    if stimeStamp != "" {
+        return stimeStamp, txntimestamp, nil
+    }
+    if txntimestamp.IsZero() {
+        return "", txntimestamp, errors.New("Unable to get transaction time")
+    }
+    return txntimestamp.String(), txntimestamp, nil
+}
+
+// putCashMachine writes the state and its history entry to the ledger
+func putCashMachine(stub shim.ChaincodeStubInterface, state *CashMachineState) error {
+    err := putHistory(stub, state)
+    if err != nil {
+        return errors.New("Cash machine transaction history failed PUT to ledger: " + fmt.Sprint(err))
+    }
+    stateJSON, err := json.Marshal(state)
+    if err != nil {
+        return errors.New("Marshal failed for contract state" + fmt.Sprint(err))
+    }
+    err = stub.PutState(state.AssetID, stateJSON)
+    if err != nil {
+        return errors.New("PUT ledger state failed: " + fmt.Sprint(err))
+    }
+    return nil
 }
@@ -6,6 +6,15 @@ var samples = `
         "assetID": "The ID of a managed asset. In this case, the cash machine's unique id wrt monetary transactions.For query operations, only assetID needs to be sent in.",
         "ActionType": "One of three actions are expected: InitialBalance, Deposit or Withdraw",
         "Amount": "The amount that needs to be transacted. eg. 123.05"
+        "Cassettes": [{"cassetteid": "A", "denomination": 20, "count": 50}],
+        "DailyLimit": "The most that can be withdrawn per business day. eg. 600",
+        "LowCashThreshold": "The balance below which the LOWCASH alert is raised. eg. 800",
+        "Timestamp": "A string with timestamp. If not sent in, it is set to the transaction time in the fabric"
+    },
+    "reconcileEvent": {
+        "assetID": "The ID of the cash machine that was counted",
+        "Cassettes": [{"cassetteid": "A", "count": 48}],
+        "CountedBalance": "The value of the cash counted, optional when cassettes are sent in. eg. 960",
         "Timestamp": "A string with timestamp. If not sent in, it is set to the transaction time in the fabric"
     },
     "initEvent": {
@@ -16,6 +25,10 @@ var samples = `
         "ActionType": "A String with one of three values is expected: InitialBalance, Deposit or Withdraw",
         "Amount": "The amount that needs to be transacted. eg. 123.05"
         "Balance": "This is a computed field. Don't send it in, it will be overwritten. eg. 234.56"
+        "Cassettes": [{"cassetteid": "A", "denomination": 20, "count": 50}],
+        "WithdrawnToday": "Computed, the amount withdrawn during the business day. eg. 170",
+        "Alerts": ["LOWCASH", "NEGATIVEBALANCE", "DISCREPANCY"],
+        "LastReconciliation": {"ledgerbalance": 1000, "countedbalance": 960, "discrepancy": -40, "balanced": false},
         "Timestamp": "A string with timestamp. If not sent in, it is set to the transaction time in the fabric"
     }
 }`
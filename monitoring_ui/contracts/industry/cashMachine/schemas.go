@@ -16,7 +16,7 @@ var schemas = `
                                 "type": "string"
                             },
                             "actiontype": {
-                                "description": "A String with one of three values is expected: InitialBalance, Deposit or Withdraw",
+                                "description": "Deposit or Withdraw on update, an update with no actiontype, amount or cassettes only changes the limits. Create always sets InitialBalance.",
                                 "type": "string"
                             },
                             "amount": {
@@ -26,6 +26,39 @@ var schemas = `
                             "timestamp": {
                                 "description": "Current timestamp. If not sent in, the transaction time is set",
                                 "type": "string"
+                            },
+                            "cassettes": {
+                                "description": "Notes loaded into (create and Deposit) or dispensed from (Withdraw) each cassette. When sent in, the amount may be omitted and must otherwise match the notes. A Withdraw without cassettes is dispensed with the fewest notes that make up the amount, preferring larger denominations.",
+                                "items": {
+                                    "description": "A cassette and its count of notes",
+                                    "properties": {
+                                        "cassetteid": {
+                                            "description": "The ID of the cassette within the machine.",
+                                            "type": "string"
+                                        },
+                                        "denomination": {
+                                            "description": "The value of one note in the cassette. Required when a cassette is first loaded.",
+                                            "type": "number"
+                                        },
+                                        "count": {
+                                            "description": "The number of notes.",
+                                            "type": "integer"
+                                        }
+                                    },
+                                    "required": [
+                                        "cassetteid"
+                                    ],
+                                    "type": "object"
+                                },
+                                "type": "array"
+                            },
+                            "dailylimit": {
+                                "description": "The most that can be withdrawn per business day. Can be set with any event.",
+                                "type": "number"
+                            },
+                            "lowcashthreshold": {
+                                "description": "The balance below which the LOWCASH alert is raised. Can be set with any event.",
+                                "type": "number"
                             }
                         },
                         "required": [
@@ -146,7 +179,7 @@ var schemas = `
                             "type": "string"
                         },
                         "actiontype": {
-                            "description": "The last transaction: InitialBalance, Deposit or Withdraw",
+                            "description": "The last transaction: InitialBalance, Deposit, Withdraw, Configure or Reconcile",
                             "type": "string"
                         },
                         "amount": {
@@ -160,6 +193,115 @@ var schemas = `
                         "timestamp": {
                             "description": "Current timestamp. If not sent in, the transaction time is set",
                             "type": "string"
+                        },
+                        "cassettes": {
+                            "description": "The cash cassettes of the machine, one per denomination.",
+                            "items": {
+                                "description": "A cassette and its count of notes",
+                                "properties": {
+                                    "cassetteid": {
+                                        "description": "The ID of the cassette within the machine.",
+                                        "type": "string"
+                                    },
+                                    "denomination": {
+                                        "description": "The value of one note in the cassette. Required when a cassette is first loaded.",
+                                        "type": "number"
+                                    },
+                                    "count": {
+                                        "description": "The number of notes.",
+                                        "type": "integer"
+                                    }
+                                },
+                                "required": [
+                                    "cassetteid"
+                                ],
+                                "type": "object"
+                            },
+                            "type": "array"
+                        },
+                        "dailylimit": {
+                            "description": "The most that can be withdrawn per business day. Can be set with any event.",
+                            "type": "number"
+                        },
+                        "lowcashthreshold": {
+                            "description": "The balance below which the LOWCASH alert is raised. Can be set with any event.",
+                            "type": "number"
+                        },
+                        "businessday": {
+                            "description": "The date (YYYY-MM-DD, UTC) of the last transaction, taken from the transaction time and never from the event timestamp.",
+                            "type": "string"
+                        },
+                        "withdrawntoday": {
+                            "description": "The amount withdrawn during the business day.",
+                            "type": "number"
+                        },
+                        "alerts": {
+                            "description": "Active alerts: LOWCASH, NEGATIVEBALANCE and DISCREPANCY (the last reconciliation did not balance).",
+                            "items": {
+                                "type": "string"
+                            },
+                            "type": "array"
+                        },
+                        "lastreconciliation": {
+                            "description": "The last end of day reconciliation of the physical count against the ledger.",
+                            "properties": {
+                                "timestamp": {
+                                    "type": "string"
+                                },
+                                "txnid": {
+                                    "type": "string"
+                                },
+                                "ledgerbalance": {
+                                    "description": "The balance on the ledger when counted.",
+                                    "type": "number"
+                                },
+                                "countedbalance": {
+                                    "description": "The value of the notes counted.",
+                                    "type": "number"
+                                },
+                                "discrepancy": {
+                                    "description": "Counted minus ledger balance.",
+                                    "type": "number"
+                                },
+                                "cassettes": {
+                                    "description": "Ledger and counted notes per cassette.",
+                                    "items": {
+                                        "properties": {
+                                            "cassetteid": {
+                                                "type": "string"
+                                            },
+                                            "denomination": {
+                                                "type": "number"
+                                            },
+                                            "ledgercount": {
+                                                "type": "integer"
+                                            },
+                                            "countedcount": {
+                                                "type": "integer"
+                                            },
+                                            "difference": {
+                                                "description": "Counted minus ledger notes.",
+                                                "type": "integer"
+                                            }
+                                        },
+                                        "type": "object"
+                                    },
+                                    "type": "array"
+                                },
+                                "balanced": {
+                                    "description": "True when the balance and every cassette count agree.",
+                                    "type": "boolean"
+                                }
+                            },
+                            "type": "object"
+                        },
+                        "txnid": {
+                            "description": "The ID of the transaction that last changed the state.",
+                            "type": "string"
+                        },
+                        "historyseq": {
+                            "description": "The sequence number of the latest history entry.",
+                            "type": "integer"
                         }
                     },
                     "type": "object"
@@ -173,11 +315,15 @@ var schemas = `
                 "args": {
                     "description": "args are JSON encoded strings",
                     "items": {
-                        "description": "Requested assetID",
+                        "description": "Requested assetID with item count.",
                         "properties": {
                             "assetID": {
                                 "description": "The ID of a managed asset. The resource focal point for a smart contract.",
                                 "type": "string"
+                            },
+                            "count": {
+                                "description": "The number of history states to return, most recent first. Zero or missing returns all.",
+                                "type": "integer"
                             }
                         },
                         "required": [
@@ -203,11 +349,11 @@ var schemas = `
                         "description": "A set of fields that constitute the complete asset state.",
                         "properties": {
                             "assetID": {
-                            "description": "The ID of a managed asset.In this case, the uniqie ID of the case machine.",
-                            "type": "string"
+                                "description": "The ID of a managed asset.In this case, the uniqie ID of the case machine.",
+                                "type": "string"
                             },
                             "actiontype": {
-                                "description": "The last transaction: InitialBalance, Deposit or Withdraw",
+                                "description": "The last transaction: InitialBalance, Deposit, Withdraw, Configure or Reconcile",
                                 "type": "string"
                             },
                             "amount": {
@@ -221,6 +367,115 @@ var schemas = `
                             "timestamp": {
                                 "description": "Current timestamp. If not sent in, the transaction time is set",
                                 "type": "string"
+                            },
+                            "cassettes": {
+                                "description": "The cash cassettes of the machine, one per denomination.",
+                                "items": {
+                                    "description": "A cassette and its count of notes",
+                                    "properties": {
+                                        "cassetteid": {
+                                            "description": "The ID of the cassette within the machine.",
+                                            "type": "string"
+                                        },
+                                        "denomination": {
+                                            "description": "The value of one note in the cassette. Required when a cassette is first loaded.",
+                                            "type": "number"
+                                        },
+                                        "count": {
+                                            "description": "The number of notes.",
+                                            "type": "integer"
+                                        }
+                                    },
+                                    "required": [
+                                        "cassetteid"
+                                    ],
+                                    "type": "object"
+                                },
+                                "type": "array"
+                            },
+                            "dailylimit": {
+                                "description": "The most that can be withdrawn per business day. Can be set with any event.",
+                                "type": "number"
+                            },
+                            "lowcashthreshold": {
+                                "description": "The balance below which the LOWCASH alert is raised. Can be set with any event.",
+                                "type": "number"
+                            },
+                            "businessday": {
+                                "description": "The date (YYYY-MM-DD, UTC) of the last transaction, taken from the transaction time and never from the event timestamp.",
+                                "type": "string"
+                            },
+                            "withdrawntoday": {
+                                "description": "The amount withdrawn during the business day.",
+                                "type": "number"
+                            },
+                            "alerts": {
+                                "description": "Active alerts: LOWCASH, NEGATIVEBALANCE and DISCREPANCY (the last reconciliation did not balance).",
+                                "items": {
+                                    "type": "string"
+                                },
+                                "type": "array"
+                            },
+                            "lastreconciliation": {
+                                "description": "The last end of day reconciliation of the physical count against the ledger.",
+                                "properties": {
+                                    "timestamp": {
+                                        "type": "string"
+                                    },
+                                    "txnid": {
+                                        "type": "string"
+                                    },
+                                    "ledgerbalance": {
+                                        "description": "The balance on the ledger when counted.",
+                                        "type": "number"
+                                    },
+                                    "countedbalance": {
+                                        "description": "The value of the notes counted.",
+                                        "type": "number"
+                                    },
+                                    "discrepancy": {
+                                        "description": "Counted minus ledger balance.",
+                                        "type": "number"
+                                    },
+                                    "cassettes": {
+                                        "description": "Ledger and counted notes per cassette.",
+                                        "items": {
+                                            "properties": {
+                                                "cassetteid": {
+                                                    "type": "string"
+                                                },
+                                                "denomination": {
+                                                    "type": "number"
+                                                },
+                                                "ledgercount": {
+                                                    "type": "integer"
+                                                },
+                                                "countedcount": {
+                                                    "type": "integer"
+                                                },
+                                                "difference": {
+                                                    "description": "Counted minus ledger notes.",
+                                                    "type": "integer"
+                                                }
+                                            },
+                                            "type": "object"
+                                        },
+                                        "type": "array"
+                                    },
+                                    "balanced": {
+                                        "description": "True when the balance and every cassette count agree.",
+                                        "type": "boolean"
+                                    }
+                                },
+                                "type": "object"
+                            },
+                            "txnid": {
+                                "description": "The ID of the transaction that last changed the state.",
+                                "type": "string"
+                            },
+                            "historyseq": {
+                                "description": "The sequence number of the latest history entry.",
+                                "type": "integer"
                             }
                         },
                         "type": "object"
@@ -231,6 +486,66 @@ var schemas = `
             },
             "type": "object"
         },
+        "reconcileAsset": {
+            "description": "Reconciles the ledger with a reported physical count. Records the discrepancy per cassette and in total, and raises the DISCREPANCY alert until a later count balances.",
+            "properties": {
+                "args": {
+                    "description": "args are JSON encoded strings",
+                    "items": {
+                        "description": "An end of day physical count, either per cassette or as a total. The result is recorded in lastreconciliation and the ledger balance is left unchanged.",
+                        "properties": {
+                            "assetID": {
+                                "description": "The ID of the cash machine.",
+                                "type": "string"
+                            },
+                            "cassettes": {
+                                "description": "The notes counted in each cassette, cassettes that are not listed count as empty.",
+                                "items": {
+                                    "properties": {
+                                        "cassetteid": {
+                                            "type": "string"
+                                        },
+                                        "count": {
+                                            "type": "integer"
+                                        }
+                                    },
+                                    "required": [
+                                        "cassetteid",
+                                        "count"
+                                    ],
+                                    "type": "object"
+                                },
+                                "type": "array"
+                            },
+                            "countedbalance": {
+                                "description": "The value of the cash counted. Must match the cassettes when both are sent in.",
+                                "type": "number"
+                            },
+                            "timestamp": {
+                                "description": "Current timestamp. If not sent in, the transaction time is set",
+                                "type": "string"
+                            }
+                        },
+                        "required": [
+                            "assetID"
+                        ],
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
+                    "type": "array"
+                },
+                "function": {
+                    "description": "reconcileAsset function",
+                    "enum": [
+                        "reconcileAsset"
+                    ],
+                    "type": "string"
+                },
+                "method": "invoke"
+            },
+            "type": "object"
+        },
         "updateAsset": {
             "description": "Update the state of an asset. The one argument is a JSON encoded event. AssetID is required along with one or more writable properties. Establishes the next asset state. ",
             "properties": {
@@ -239,12 +554,12 @@ var schemas = `
                     "items": {
                         "description": "A set of fields that constitute the writable fields in an asset's state. AssetID is mandatory along with at least one writable field. In this contract pattern, a partial state is used as an event.",
                         "properties": {
-                           "assetID": {
+                            "assetID": {
                                 "description": "The ID of a managed asset.In this case, the uniqie ID of the case machine.",
                                 "type": "string"
                             },
                             "actiontype": {
-                                "description": "A String with one of three values is expected: InitialBalance, Deposit or Withdraw",
+                                "description": "Deposit or Withdraw on update, an update with no actiontype, amount or cassettes only changes the limits. Create always sets InitialBalance.",
                                 "type": "string"
                             },
                             "amount": {
@@ -254,6 +569,39 @@ var schemas = `
                             "timestamp": {
                                 "description": "Current timestamp. If not sent in, the transaction time is set",
                                 "type": "string"
+                            },
+                            "cassettes": {
+                                "description": "Notes loaded into (create and Deposit) or dispensed from (Withdraw) each cassette. When sent in, the amount may be omitted and must otherwise match the notes. A Withdraw without cassettes is dispensed with the fewest notes that make up the amount, preferring larger denominations.",
+                                "items": {
+                                    "description": "A cassette and its count of notes",
+                                    "properties": {
+                                        "cassetteid": {
+                                            "description": "The ID of the cassette within the machine.",
+                                            "type": "string"
+                                        },
+                                        "denomination": {
+                                            "description": "The value of one note in the cassette. Required when a cassette is first loaded.",
+                                            "type": "number"
+                                        },
+                                        "count": {
+                                            "description": "The number of notes.",
+                                            "type": "integer"
+                                        }
+                                    },
+                                    "required": [
+                                        "cassetteid"
+                                    ],
+                                    "type": "object"
+                                },
+                                "type": "array"
+                            },
+                            "dailylimit": {
+                                "description": "The most that can be withdrawn per business day. Can be set with any event.",
+                                "type": "number"
+                            },
+                            "lowcashthreshold": {
+                                "description": "The balance below which the LOWCASH alert is raised. Can be set with any event.",
+                                "type": "number"
                             }
                         },
                         "required": [
@@ -312,7 +660,7 @@ var schemas = `
                     "type": "string"
                 },
                 "actiontype": {
-                    "description": "A String with one of three values is expected: InitialBalance, Deposit or Withdraw",
+                    "description": "Deposit or Withdraw on update, an update with no actiontype, amount or cassettes only changes the limits. Create always sets InitialBalance.",
                     "type": "string"
                 },
                 "amount": {
@@ -322,6 +670,39 @@ var schemas = `
                 "timestamp": {
                     "description": "Current timestamp. If not sent in, the transaction time is set",
                     "type": "string"
+                },
+                "cassettes": {
+                    "description": "Notes loaded into (create and Deposit) or dispensed from (Withdraw) each cassette. When sent in, the amount may be omitted and must otherwise match the notes. A Withdraw without cassettes is dispensed with the fewest notes that make up the amount, preferring larger denominations.",
+                    "items": {
+                        "description": "A cassette and its count of notes",
+                        "properties": {
+                            "cassetteid": {
+                                "description": "The ID of the cassette within the machine.",
+                                "type": "string"
+                            },
+                            "denomination": {
+                                "description": "The value of one note in the cassette. Required when a cassette is first loaded.",
+                                "type": "number"
+                            },
+                            "count": {
+                                "description": "The number of notes.",
+                                "type": "integer"
+                            }
+                        },
+                        "required": [
+                            "cassetteid"
+                        ],
+                        "type": "object"
+                    },
+                    "type": "array"
+                },
+                "dailylimit": {
+                    "description": "The most that can be withdrawn per business day. Can be set with any event.",
+                    "type": "number"
+                },
+                "lowcashthreshold": {
+                    "description": "The balance below which the LOWCASH alert is raised. Can be set with any event.",
+                    "type": "number"
                 }
             },
             "required": [
@@ -342,6 +723,46 @@ var schemas = `
             ],
             "type": "object"
         },
+        "reconcileEvent": {
+            "description": "An end of day physical count, either per cassette or as a total. The result is recorded in lastreconciliation and the ledger balance is left unchanged.",
+            "properties": {
+                "assetID": {
+                    "description": "The ID of the cash machine.",
+                    "type": "string"
+                },
+                "cassettes": {
+                    "description": "The notes counted in each cassette, cassettes that are not listed count as empty.",
+                    "items": {
+                        "properties": {
+                            "cassetteid": {
+                                "type": "string"
+                            },
+                            "count": {
+                                "type": "integer"
+                            }
+                        },
+                        "required": [
+                            "cassetteid",
+                            "count"
+                        ],
+                        "type": "object"
+                    },
+                    "type": "array"
+                },
+                "countedbalance": {
+                    "description": "The value of the cash counted. Must match the cassettes when both are sent in.",
+                    "type": "number"
+                },
+                "timestamp": {
+                    "description": "Current timestamp. If not sent in, the transaction time is set",
+                    "type": "string"
+                }
+            },
+            "required": [
+                "assetID"
+            ],
+            "type": "object"
+        },
         "state": {
             "description": "A set of fields that constitute the complete asset state.",
             "properties": {
@@ -350,7 +771,7 @@ var schemas = `
                     "type": "string"
                 },
                 "actiontype": {
-                    "description": "A String with one of three values is expected: InitialBalance, Deposit or Withdraw",
+                    "description": "The last transaction: InitialBalance, Deposit, Withdraw, Configure or Reconcile",
                     "type": "string"
                 },
                 "amount": {
@@ -364,6 +785,115 @@ var schemas = `
                 "timestamp": {
                     "description": "Current timestamp. If not sent in, the transaction time is set",
                     "type": "string"
+                },
+                "cassettes": {
+                    "description": "The cash cassettes of the machine, one per denomination.",
+                    "items": {
+                        "description": "A cassette and its count of notes",
+                        "properties": {
+                            "cassetteid": {
+                                "description": "The ID of the cassette within the machine.",
+                                "type": "string"
+                            },
+                            "denomination": {
+                                "description": "The value of one note in the cassette. Required when a cassette is first loaded.",
+                                "type": "number"
+                            },
+                            "count": {
+                                "description": "The number of notes.",
+                                "type": "integer"
+                            }
+                        },
+                        "required": [
+                            "cassetteid"
+                        ],
+                        "type": "object"
+                    },
+                    "type": "array"
+                },
+                "dailylimit": {
+                    "description": "The most that can be withdrawn per business day. Can be set with any event.",
+                    "type": "number"
+                },
+                "lowcashthreshold": {
+                    "description": "The balance below which the LOWCASH alert is raised. Can be set with any event.",
+                    "type": "number"
+                },
+                "businessday": {
+                    "description": "The date (YYYY-MM-DD, UTC) of the last transaction, taken from the transaction time and never from the event timestamp.",
+                    "type": "string"
+                },
+                "withdrawntoday": {
+                    "description": "The amount withdrawn during the business day.",
+                    "type": "number"
+                },
+                "alerts": {
+                    "description": "Active alerts: LOWCASH, NEGATIVEBALANCE and DISCREPANCY (the last reconciliation did not balance).",
+                    "items": {
+                        "type": "string"
+                    },
+                    "type": "array"
+                },
+                "lastreconciliation": {
+                    "description": "The last end of day reconciliation of the physical count against the ledger.",
+                    "properties": {
+                        "timestamp": {
+                            "type": "string"
+                        },
+                        "txnid": {
+                            "type": "string"
+                        },
+                        "ledgerbalance": {
+                            "description": "The balance on the ledger when counted.",
+                            "type": "number"
+                        },
+                        "countedbalance": {
+                            "description": "The value of the notes counted.",
+                            "type": "number"
+                        },
+                        "discrepancy": {
+                            "description": "Counted minus ledger balance.",
+                            "type": "number"
+                        },
+                        "cassettes": {
+                            "description": "Ledger and counted notes per cassette.",
+                            "items": {
+                                "properties": {
+                                    "cassetteid": {
+                                        "type": "string"
+                                    },
+                                    "denomination": {
+                                        "type": "number"
+                                    },
+                                    "ledgercount": {
+                                        "type": "integer"
+                                    },
+                                    "countedcount": {
+                                        "type": "integer"
+                                    },
+                                    "difference": {
+                                        "description": "Counted minus ledger notes.",
+                                        "type": "integer"
+                                    }
+                                },
+                                "type": "object"
+                            },
+                            "type": "array"
+                        },
+                        "balanced": {
+                            "description": "True when the balance and every cassette count agree.",
+                            "type": "boolean"
+                        }
+                    },
+                    "type": "object"
+                },
+                "txnid": {
+                    "description": "The ID of the transaction that last changed the state.",
+                    "type": "string"
+                },
+                "historyseq": {
+                    "description": "The sequence number of the latest history entry.",
+                    "type": "integer"
                 }
             },
             "type": "object"
@@ -1,5 +1,7 @@
 package main
 
+import "time"
+
 type Device struct {
     DeviceID                    *string                    `json:"deviceid,omitempty"`    
     MinimumUsageCost            *float64                   `json:"minimumusagecost,omitempty"`    
@@ -9,6 +11,10 @@ type Device struct {
     Latitude                    *float64                    `json:"latitude,omitempty"`  
     Longitude                   *float64                    `json:"longitude,omitempty"` 
     Address                     *string                    `json:"address,omitempty"`    
+    ArrivalWindow               *int64                     `json:"arrivalwindow,omitempty"`    // seconds after a reservation starts to confirm arrival
+    NoShowFee                   *float64                   `json:"noshowfee,omitempty"`    
+    Zone                        *string                    `json:"zone,omitempty"`    
+    TariffID                    *string                    `json:"tariffid,omitempty"`    // overrides the tariff of the zone
     Available                   *bool                      `json:"available,omitempty"`        
 }
 
@@ -21,6 +27,130 @@ type Usage struct {
     ActualEndtime               string                        `json:"actualendtime,omitempty"`    // celcius
     OvertimeCost                float64                        `json:"overtimecost,omitempty"` // percent
     TotalCost                   float64                        `json:"totalcost,omitempty"` // percent
+    ReservationID               string                         `json:"reservationid,omitempty"` // set if the usage started from a reservation
+    PaymentID                   string                         `json:"paymentid,omitempty"`
+    PermitID                    string                         `json:"permitid,omitempty"`
+    TariffID                    string                         `json:"tariffid,omitempty"`     // tariff the usage was priced with
+}
+
+// A parking meter booked ahead of time. Arrival has to be confirmed within the arrival
+// window after the start time, or the reservation is released as a no-show
+type Reservation struct {
+    ReservationID               string                         `json:"reservationid,omitempty"`
+    DeviceID                    string                         `json:"deviceid,omitempty"`    
+    StartTime                   string                         `json:"starttime,omitempty"`    
+    Duration                    int64                          `json:"duration,omitempty"`    
+    ArrivalDeadline             string                         `json:"arrivaldeadline,omitempty"`    
+    Status                      ReservationStatus              `json:"status,omitempty"`
+    PaymentID                   string                         `json:"paymentid,omitempty"`    // usage or no-show payment
+}
+
+//Reservations of a device
+type ReservationList struct {
+    Reservations []Reservation `json:"reservations"`
+}
+
+// Pricing of a device. Periods set time-of-day and day-of-week rates, the first period
+// matching the time applies and the device rates apply if none does. Surge multiplies
+// the rate by the occupancy of the zone, and permit holders get a discount by permit type
+type Tariff struct {
+    TariffID                    string                         `json:"tariffid"`
+    UTCOffset                   string                         `json:"utcoffset,omitempty"`  // standard time of the periods, such as +01:00. UTC if empty
+    DST                         *DSTRule                       `json:"dst,omitempty"`        // daylight saving time of the periods, none if empty
+    Periods                     []TariffPeriod                 `json:"periods,omitempty"`
+    Surge                       []SurgeStep                    `json:"surge,omitempty"`
+    PermitDiscounts             map[string]float64             `json:"permitdiscounts,omitempty"`  // permit type to discount, 0 to 1
+}
+
+type TariffPeriod struct {
+    Days                        []time.Weekday                 `json:"days,omitempty"`   // 0 Sunday to 6 Saturday, every day if empty
+    From                        string                         `json:"from"`             // 15:04
+    To                          string                         `json:"to"`               // 15:04, exclusive. Before From runs past midnight
+    UsageCost                   float64                        `json:"usagecost"`        // per second, as minimumusagecost
+    OvertimeCost                float64                        `json:"overtimecost"`     // per second, as overtimeusagecost
+}
+
+// Daylight saving time of a tariff, in force every year from Start until End. End before
+// Start in the year is a southern hemisphere rule. The rules are kept in the tariff rather
+// than looked up by zone name, so that every peer prices a stay alike
+type DSTRule struct {
+    UTCOffset                   string                         `json:"utcoffset"`        // while in force, such as +02:00
+    Start                       DSTChange                      `json:"start"`
+    End                         DSTChange                      `json:"end"`
+}
+
+// The clock changes on the week'th day of the month, at the time in local standard time
+type DSTChange struct {
+    Month                       time.Month                     `json:"month"`            // 1 January to 12 December
+    Week                        int                            `json:"week"`             // 1 to 4, 5 for the last
+    Day                         time.Weekday                   `json:"day"`              // 0 Sunday to 6 Saturday
+    At                          string                         `json:"at"`               // 15:04 standard time
+}
+
+// The highest step at or below the occupancy of the zone applies
+type SurgeStep struct {
+    Occupancy                   float64                        `json:"occupancy"`        // share of the zone's devices in use, 0 to 1
+    Multiplier                  float64                        `json:"multiplier"`
+}
+
+// Attaches a tariff to a device, or to every device in a zone
+type TariffAssignment struct {
+    TariffID                    string                         `json:"tariffid"`
+    DeviceID                    string                         `json:"deviceid,omitempty"`
+    Zone                        string                         `json:"zone,omitempty"`
+}
+
+type Permit struct {
+    PermitID                    string                         `json:"permitid"`
+    PermitType                  string                         `json:"permittype"`
+    ValidUntil                  string                         `json:"validuntil,omitempty"`   // TimeLayout, no expiry if empty
+}
+
+// Price of a stay on a device. A stay on a tariff is split into segments where the period
+// in force changes, and cost is the sum of rate * duration of the segments * surge * (1 - discount)
+type Quote struct {
+    DeviceID                    string                         `json:"deviceid"`
+    TariffID                    string                         `json:"tariffid,omitempty"`
+    StartTime                   string                         `json:"starttime"`
+    Duration                    int64                          `json:"duration"`
+    Overtime                    bool                           `json:"overtime,omitempty"`
+    Rate                        float64                        `json:"rate"`                 // at the start of the stay
+    Segments                    []QuoteSegment                 `json:"segments,omitempty"`
+    Occupancy                   float64                        `json:"occupancy"`
+    Surge                       float64                        `json:"surge"`
+    Discount                    float64                        `json:"discount"`
+    Cost                        float64                        `json:"cost"`
+}
+
+// Part of a stay priced at one rate, times are UTC in TimeLayout
+type QuoteSegment struct {
+    StartTime                   string                         `json:"starttime"`
+    EndTime                     string                         `json:"endtime"`
+    Duration                    int64                          `json:"duration"`
+    Rate                        float64                        `json:"rate"`
+}
+
+// One extendUsage call, as billed on the payment
+type Extension struct {
+    TxID                        string                         `json:"txid"`
+    Duration                    int64                          `json:"duration"`
+    Cost                        float64                        `json:"cost"`
+    EndTime                     string                         `json:"endtime"`
+}
+
+// Payment record of a usage or a no-show. AmountDue reconciles with the usage:
+// UsageCost + OvertimeCost, and OvertimeCost is the sum of the extensions
+type Payment struct {
+    PaymentID                   string                         `json:"paymentid"`
+    DeviceID                    string                         `json:"deviceid"`
+    ReservationID               string                         `json:"reservationid,omitempty"`
+    UsageCost                   float64                        `json:"usagecost"`
+    OvertimeCost                float64                        `json:"overtimecost"`
+    NoShowFee                   float64                        `json:"noshowfee,omitempty"`
+    Extensions                  []Extension                    `json:"extensions,omitempty"`
+    AmountDue                   float64                        `json:"amountdue"`
+    AmountPaid                  float64                        `json:"amountpaid"`
+    Status                      PaymentStatus                  `json:"status"`
 }
 
 
@@ -31,11 +161,25 @@ const USAGEKEY string = "USAGE"
 const USAGEHIST string = "USAGEHIST"
 const ALERTKEY string = "ALERT"
 const LISTKEY  string = "DEVLIST"
+const RESERVATIONKEY string = "RESERVATION"
+const PAYMENTKEY string = "PAYMENT"
+const TARIFFKEY string = "TARIFF"
+const ZONEKEY string = "ZONE"
+const PERMITKEY string = "PERMIT"
+
+// Certificate attribute role that a caller of createTariff, assignTariff and createPermit must hold
+const PERMITISSUERROLE string = "parkingauthority"
 
 const MAXHIST int = 10
 
 const BufferTime int = 2
 
+// Layout of usage and reservation start times
+const TimeLayout string = "2006-01-02 15:04:05"
+
+// Arrival window, in seconds, for devices that don't define one
+const DefaultArrivalWindow int64 = 900
+
 type AlertLevels string
 
 const (
@@ -45,6 +189,22 @@ const (
     Warning ="warning"
     Overtime = "overtime" 
 ) 
+
+type ReservationStatus string
+
+const (
+    Reserved ReservationStatus = "reserved"
+    Arrived ReservationStatus = "arrived"
+    NoShow ReservationStatus = "noshow"
+    Cancelled ReservationStatus = "cancelled"
+)
+
+type PaymentStatus string
+
+const (
+    PaymentDue PaymentStatus = "due"
+    PaymentSettled PaymentStatus = "settled"
+)
   
 
 const MYVERSION string = "1.0.0"
@@ -66,6 +66,20 @@ func (t *DeviceUsageChaincode) Invoke(stub shim.ChaincodeStubInterface, function
         return t.updateDeviceAsAvailable(stub, args)
     }else if function =="deleteDevice" {
         return t.deleteDevice(stub, args)
+    }else if function =="createReservation" {
+        return t.createReservation(stub, args)
+    }else if function =="confirmArrival" {
+        return t.confirmArrival(stub, args)
+    }else if function =="releaseNoShowReservations" {
+        return t.releaseNoShowReservations(stub, args)
+    }else if function =="settlePayment" {
+        return t.settlePayment(stub, args)
+    }else if function =="createTariff" {
+        return t.createTariff(stub, args)
+    }else if function =="assignTariff" {
+        return t.assignTariff(stub, args)
+    }else if function =="createPermit" {
+        return t.createPermit(stub, args)
     } 
 	//fmt.Println("Unknown invocation function: ", function)
 	return nil, errors.New("Received unknown invocation: " + function)
@@ -86,6 +100,14 @@ func (t *DeviceUsageChaincode) Query(stub shim.ChaincodeStubInterface, function
             return t.readDeviceList(stub, args)
     } else if function =="readAssetSchemas" {
             return t.readAssetSchemas(stub, args)
+    } else if function =="readReservations" {
+            return t.readReservations(stub, args)
+    } else if function =="readPayment" {
+            return t.readPayment(stub, args)
+    } else if function =="readTariff" {
+            return t.readTariff(stub, args)
+    } else if function =="quotePrice" {
+            return t.quotePrice(stub, args)
     } 
     
 	return nil, errors.New("Received unknown invocation: " + function)
@@ -182,6 +204,10 @@ func (t *DeviceUsageChaincode) createDevice(stub shim.ChaincodeStubInterface, ar
             if devicesIn.OvertimeUsageTime !=nil {
                 *device.OvertimeUsageTime=*devicesIn.OvertimeUsageTime
             }
+            // The tariff is attached with assignTariff, keep it
+            if devicesIn.TariffID == nil {
+                devicesIn.TariffID = device.TariffID
+            }
             sStubUpdate, err = json.Marshal(&devicesIn)
             if err !=nil {
                 return nil, err
@@ -292,6 +318,10 @@ func (t *DeviceUsageChaincode) createBulkDevice(stub shim.ChaincodeStubInterface
                 if devicesIn[l].OvertimeUsageTime !=nil {
                     *device.OvertimeUsageTime=*devicesIn[l].OvertimeUsageTime
                 }
+                // The tariff is attached with assignTariff, keep it
+                if devicesIn[l].TariffID == nil {
+                    devicesIn[l].TariffID = device.TariffID
+                }
                 sStubUpdate, err = json.Marshal(&devicesIn[l])
                 if err !=nil {
                     return nil, err
@@ -442,6 +472,34 @@ func (t *DeviceUsageChaincode) createUsage(stub shim.ChaincodeStubInterface, arg
         //fmt.Println(err)
         return nil, err
     }
+    /////////////////////////////////////////////////
+    // The usage can't take a slot reserved by someone else. Reservations past their
+    // arrival window are released first
+    if device.DeviceID == nil {
+        device.DeviceID = &sDeviceId
+    }
+    txntime, err := t.txnTime(stub)
+    if err != nil {
+        return nil, err
+    }
+    resList, err := t.releaseNoShows(stub, device, txntime)
+    if err != nil {
+        return nil, err
+    }
+    tStartTime, err := time.Parse(TimeLayout, usage.StartTime)
+    if err != nil {
+        return nil, err
+    }
+    err = t.checkReservationConflict(resList, usage.ReservationID, tStartTime, usage.Duration)
+    if err != nil {
+        return nil, err
+    }
+    /////////////////////////////////////////////////
+    // Price the usage with the tariff in force at the transaction time
+    quote, err := t.priceStay(stub, device, txntime, usage.Duration, usage.PermitID, false)
+    if err != nil {
+        return nil, err
+    }
     *device.Available = false
      sStubUpdate, err := json.Marshal(&device)
     if err !=nil {
@@ -469,8 +527,17 @@ func (t *DeviceUsageChaincode) createUsage(stub shim.ChaincodeStubInterface, arg
     dEndTime:= tEndTime.Add(iDuration)
     usage.EndTime = dEndTime.String()
     //Computing usage cost
-    usage.UsageCost = *device.MinimumUsageCost* float64(usage.Duration)
+    usage.UsageCost = quote.Cost
+    usage.TariffID = quote.TariffID
+    usage.OvertimeCost = 0
     usage.TotalCost = usage.UsageCost
+    /////////////////////////////////////////////////
+    // Open the payment record of the usage
+    usage.PaymentID = ""
+    err = t.recordPayment(stub, &usage, nil)
+    if err != nil {
+        return nil, err
+    }
     // Put usage record to state
     sStubUpdate, err = json.Marshal(&usage)
     if err !=nil {
@@ -756,7 +823,18 @@ func (t *DeviceUsageChaincode) extendUsage(stub shim.ChaincodeStubInterface, arg
     }
     /////////////////////////////////////////////////
     // Assuming start time and duration are provided by the invoke call, calculate end time
-    fExtension :=*device.OvertimeUsageCost*float64(usage.Duration)
+    if device.DeviceID == nil {
+        device.DeviceID = &sDeviceId
+    }
+    txntime, err := t.txnTime(stub)
+    if err != nil {
+        return nil, err
+    }
+    quote, err := t.priceStay(stub, device, txntime, usage.Duration, usageStub.PermitID, true)
+    if err != nil {
+        return nil, err
+    }
+    fExtension :=quote.Cost
     //fmt.Println("calculating time")
     sOldEndTime := usageStub.EndTime[0:19]
     //fmt.Println("The old end time is ", sOldEndTime)
@@ -769,9 +847,16 @@ func (t *DeviceUsageChaincode) extendUsage(stub shim.ChaincodeStubInterface, arg
     iDuration := time.Duration(usage.Duration)*time.Second
     dEndTime:= tEndTime.Add(iDuration)
     usageStub.EndTime = dEndTime.String()
-    //Computing usage cost
-    usageStub.OvertimeCost = fExtension
-    usageStub.TotalCost = usageStub.UsageCost+fExtension
+    //Computing usage cost. Overtime adds up over the extensions
+    usageStub.OvertimeCost += fExtension
+    usageStub.TotalCost = usageStub.UsageCost+usageStub.OvertimeCost
+    /////////////////////////////////////////////////
+    // Link the extension to the payment of the usage
+    ext := Extension{TxID: stub.GetTxID(), Duration: usage.Duration, Cost: fExtension, EndTime: usageStub.EndTime}
+    err = t.recordPayment(stub, &usageStub, &ext)
+    if err != nil {
+        return nil, err
+    }
     // Put usage record to state
     sStubUpdate, err = json.Marshal(&usageStub)
     if err !=nil {
@@ -0,0 +1,126 @@
+package main
+import (
+    "encoding/json"
+    "errors"
+    "fmt"
+    "math"
+
+"github.com/hyperledger/fabric/core/chaincode/shim"
+)
+
+// Every usage has a payment record, under PAYMENTKEY_<paymentid>. The payment id of a usage
+// is the transaction id that created the usage, the payment id of a no-show is the
+// reservation id.
+
+// Amounts closer than this are taken as equal when reconciling
+const PaymentTolerance float64 = 0.005
+
+/************************ settlePayment ********************/
+// Pays an amount, in amountpaid, against the payment. The payment is settled once the
+// amount due has been paid
+func (t *DeviceUsageChaincode) settlePayment(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+    var paymentIn Payment
+    if len(args) != 1 {
+        return nil, errors.New("Expects one argument, a JSON string with payment id and amount paid")
+    }
+    err := json.Unmarshal([]byte(args[0]), &paymentIn)
+    if err != nil {
+        return nil, errors.New("Unable to unmarshal payment data " + fmt.Sprint(err))
+    }
+    if paymentIn.AmountPaid <= 0 {
+        return nil, errors.New("Amount paid must be more than 0")
+    }
+    payment, err := t.getPayment(stub, paymentIn.PaymentID)
+    if err != nil {
+        return nil, err
+    }
+    if payment.Status == PaymentSettled {
+        return nil, errors.New("Payment is already settled: " + payment.PaymentID)
+    }
+    payment.AmountPaid += paymentIn.AmountPaid
+    return nil, t.putPayment(stub, payment)
+}
+
+/************************ readPayment ********************/
+
+func (t *DeviceUsageChaincode) readPayment(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+    var paymentIn Payment
+    if len(args) != 1 {
+        return nil, errors.New("readPayment expects one argument, a JSON string with payment id")
+    }
+    err := json.Unmarshal([]byte(args[0]), &paymentIn)
+    if err != nil {
+        return nil, errors.New("Unable to unmarshal input payment!")
+    }
+    payment, err := t.getPayment(stub, paymentIn.PaymentID)
+    if err != nil {
+        return nil, err
+    }
+    return json.Marshal(&payment)
+}
+
+/************************ internal: recordPayment ********************/
+// Brings the payment of a usage in line with the usage, adding the extension if there is one.
+// A usage from before payment records gets its payment here, with the overtime it already
+// had as a single extension
+func (t *DeviceUsageChaincode) recordPayment(stub shim.ChaincodeStubInterface, usage *Usage, ext *Extension) error {
+    payment, err := t.getPayment(stub, usage.PaymentID)
+    if err != nil {
+        usage.PaymentID = stub.GetTxID()
+        payment = Payment{PaymentID: usage.PaymentID, DeviceID: usage.DeviceID, ReservationID: usage.ReservationID}
+        prevOvertime := usage.OvertimeCost
+        if ext != nil {
+            prevOvertime -= ext.Cost
+        }
+        if prevOvertime > PaymentTolerance {
+            payment.Extensions = append(payment.Extensions, Extension{Cost: prevOvertime})
+        }
+    }
+    if ext != nil {
+        payment.Extensions = append(payment.Extensions, *ext)
+    }
+    payment.UsageCost = usage.UsageCost
+    payment.OvertimeCost = 0
+    for _, e := range payment.Extensions {
+        payment.OvertimeCost += e.Cost
+    }
+    if math.Abs(payment.OvertimeCost - usage.OvertimeCost) > PaymentTolerance {
+        return errors.New("Overtime cost of usage does not reconcile with its extensions: " + payment.PaymentID)
+    }
+    return t.putPayment(stub, payment)
+}
+
+/************************ internal: putPayment ********************/
+// Recomputes the amount due and the status before the payment is put to the ledger
+
+func (t *DeviceUsageChaincode) putPayment(stub shim.ChaincodeStubInterface, payment Payment) error {
+    payment.AmountDue = payment.UsageCost + payment.OvertimeCost + payment.NoShowFee
+    payment.Status = PaymentDue
+    if payment.AmountPaid >= payment.AmountDue - PaymentTolerance {
+        payment.Status = PaymentSettled
+    }
+    sStubUpdate, err := json.Marshal(&payment)
+    if err != nil {
+        return err
+    }
+    err = stub.PutState(PAYMENTKEY+"_"+payment.PaymentID, sStubUpdate)
+    if err != nil {
+        return errors.New("Payment record failed PUT to ledger: " + fmt.Sprint(err))
+    }
+    return nil
+}
+
+/************************ internal: getPayment ********************/
+
+func (t *DeviceUsageChaincode) getPayment(stub shim.ChaincodeStubInterface, sPaymentID string) (Payment, error) {
+    var payment Payment
+    if len(sPaymentID) == 0 {
+        return payment, errors.New("Payment id is mandatory !")
+    }
+    stubData, err := stub.GetState(PAYMENTKEY+"_"+sPaymentID)
+    if err != nil || len(stubData) == 0 {
+        return payment, errors.New("Payment does not exist in stub: " + sPaymentID)
+    }
+    err = json.Unmarshal(stubData, &payment)
+    return payment, err
+}
@@ -0,0 +1,322 @@
+package main
+import (
+    "encoding/json"
+    "errors"
+    "fmt"
+    "time"
+
+"github.com/hyperledger/fabric/core/chaincode/shim"
+)
+
+// Reservations book a device ahead of time. They are kept per device, under
+// RESERVATIONKEY_<deviceid>. There are no timers in chaincode, so a reservation whose
+// arrival window has passed is released as a no-show on the next transaction that
+// touches the device, or by releaseNoShowReservations. The same pass drops no-shows, and
+// arrivals whose booked slot has ended, from the list so that it does not grow with every
+// reservation. Their payment records, keyed by reservation id, keep the history.
+
+/************************ createReservation ********************/
+// The reservation id is the transaction id of the createReservation call
+func (t *DeviceUsageChaincode) createReservation(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+    var resIn Reservation
+    if len(args) != 1 {
+        return nil, errors.New("Expects one argument, a JSON string with device id, start time and duration")
+    }
+    err := json.Unmarshal([]byte(args[0]), &resIn)
+    if err != nil {
+        return nil, errors.New("Unable to unmarshal reservation data " + fmt.Sprint(err))
+    }
+    if resIn.Duration <= 0 {
+        return nil, errors.New("Reservation duration must be a positive number of seconds")
+    }
+    device, err := t.getDevice(stub, resIn.DeviceID)
+    if err != nil {
+        return nil, err
+    }
+    txntime, err := t.txnTime(stub)
+    if err != nil {
+        return nil, err
+    }
+    tStartTime, err := time.Parse(TimeLayout, resIn.StartTime)
+    if err != nil {
+        return nil, err
+    }
+    if tStartTime.Before(txntime) {
+        return nil, errors.New("Reservation start time must be in the future")
+    }
+    resList, err := t.releaseNoShows(stub, device, txntime)
+    if err != nil {
+        return nil, err
+    }
+    err = t.checkReservationConflict(resList, "", tStartTime, resIn.Duration)
+    if err != nil {
+        return nil, err
+    }
+    window := DefaultArrivalWindow
+    if device.ArrivalWindow != nil {
+        window = *device.ArrivalWindow
+    }
+    resIn.ReservationID = stub.GetTxID()
+    resIn.ArrivalDeadline = tStartTime.Add(time.Duration(window)*time.Second).Format(TimeLayout)
+    resIn.Status = Reserved
+    resIn.PaymentID = ""
+    resList.Reservations = append(resList.Reservations, resIn)
+    return nil, t.putReservations(stub, resIn.DeviceID, resList)
+}
+
+/************************ confirmArrival ********************/
+// Confirms the driver has arrived, any time up to the arrival deadline. The reservation
+// becomes a usage for the booked start time and duration
+func (t *DeviceUsageChaincode) confirmArrival(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+    var resIn Reservation
+    if len(args) != 1 {
+        return nil, errors.New("Expects one argument, a JSON string with device id and reservation id")
+    }
+    err := json.Unmarshal([]byte(args[0]), &resIn)
+    if err != nil {
+        return nil, errors.New("Unable to unmarshal reservation data " + fmt.Sprint(err))
+    }
+    device, err := t.getDevice(stub, resIn.DeviceID)
+    if err != nil {
+        return nil, err
+    }
+    txntime, err := t.txnTime(stub)
+    if err != nil {
+        return nil, err
+    }
+    resList, err := t.releaseNoShows(stub, device, txntime)
+    if err != nil {
+        return nil, err
+    }
+    pos := -1
+    for i, res := range resList.Reservations {
+        if res.ReservationID == resIn.ReservationID {
+            pos = i
+        }
+    }
+    if pos < 0 {
+        // a released no-show is no longer listed, but its fee is recorded under the reservation id
+        if _, err := t.getPayment(stub, resIn.ReservationID); err == nil {
+            return nil, errors.New("Arrival window closed, reservation " + resIn.ReservationID + " released as no-show")
+        }
+        return nil, errors.New("Reservation does not exist for device: " + resIn.ReservationID)
+    }
+    res := resList.Reservations[pos]
+    if res.Status != Reserved {
+        return nil, errors.New("Reservation is " + string(res.Status))
+    }
+    if device.Available != nil && !*device.Available {
+        return nil, errors.New("Device is in use")
+    }
+    usage := Usage{DeviceID: res.DeviceID, StartTime: res.StartTime, Duration: res.Duration, ReservationID: res.ReservationID}
+    usageJSON, err := json.Marshal(&usage)
+    if err != nil {
+        return nil, err
+    }
+    _, err = t.createUsage(stub, []string{string(usageJSON)})
+    if err != nil {
+        return nil, err
+    }
+    resList.Reservations[pos].Status = Arrived
+    resList.Reservations[pos].PaymentID = stub.GetTxID()
+    return nil, t.putReservations(stub, res.DeviceID, resList)
+}
+
+/************************ releaseNoShowReservations ********************/
+// Releases reservations whose arrival window has passed, for one device or, with no
+// arguments, for every device in the device list
+func (t *DeviceUsageChaincode) releaseNoShowReservations(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+    var devList DevList
+    var device Device
+    txntime, err := t.txnTime(stub)
+    if err != nil {
+        return nil, err
+    }
+    if len(args) == 1 {
+        err = json.Unmarshal([]byte(args[0]), &device)
+        if err != nil || device.DeviceID == nil {
+            return nil, errors.New("Device id is mandatory !")
+        }
+        devList.Devices = []string{*device.DeviceID}
+    } else {
+        sListData, err := stub.GetState(LISTKEY)
+        if err == nil && len(sListData) > 0 {
+            err = json.Unmarshal(sListData, &devList)
+            if err != nil {
+                return nil, err
+            }
+        }
+    }
+    for _, sDeviceId := range devList.Devices {
+        device, err = t.getDevice(stub, sDeviceId)
+        if err != nil {
+            return nil, err
+        }
+        _, err = t.releaseNoShows(stub, device, txntime)
+        if err != nil {
+            return nil, err
+        }
+    }
+    return nil, nil
+}
+
+/************************ readReservations ********************/
+
+func (t *DeviceUsageChaincode) readReservations(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+    var resIn Reservation
+    if len(args) != 1 {
+        return nil, errors.New("readReservations expects one argument, a JSON string with device id")
+    }
+    err := json.Unmarshal([]byte(args[0]), &resIn)
+    if err != nil {
+        return nil, errors.New("Unable to unmarshal input device!")
+    }
+    if len(resIn.DeviceID) == 0 {
+        return nil, errors.New("Device id is mandatory !")
+    }
+    resList, err := t.getReservations(stub, resIn.DeviceID)
+    if err != nil {
+        return nil, err
+    }
+    return json.Marshal(&resList)
+}
+
+/************************ internal: releaseNoShows ********************/
+// Marks reservations whose arrival deadline has passed as no-shows, releasing the slot,
+// and raises the no-show fee as a payment. No-shows and arrivals whose slot has ended are
+// then pruned. Returns the remaining reservations of the device
+func (t *DeviceUsageChaincode) releaseNoShows(stub shim.ChaincodeStubInterface, device Device, txntime time.Time) (ReservationList, error) {
+    sDeviceId := *device.DeviceID
+    resList, err := t.getReservations(stub, sDeviceId)
+    if err != nil {
+        return resList, err
+    }
+    bChanged := false
+    kept := make([]Reservation, 0, len(resList.Reservations))
+    for _, res := range resList.Reservations {
+        if res.Status == Arrived {
+            tResStart, err := time.Parse(TimeLayout, res.StartTime)
+            if err != nil {
+                return resList, err
+            }
+            if txntime.Before(tResStart.Add(time.Duration(res.Duration)*time.Second)) {
+                // the slot is still in use and blocks other reservations
+                kept = append(kept, res)
+            } else {
+                bChanged = true
+            }
+            continue
+        }
+        if res.Status != Reserved {
+            bChanged = true
+            continue
+        }
+        tDeadline, err := time.Parse(TimeLayout, res.ArrivalDeadline)
+        if err != nil {
+            return resList, err
+        }
+        if !txntime.After(tDeadline) {
+            kept = append(kept, res)
+            continue
+        }
+        // The no-show fee defaults to the cost of holding the device for the arrival window
+        fee := 0.0
+        if device.NoShowFee != nil {
+            fee = *device.NoShowFee
+        } else if device.MinimumUsageCost != nil {
+            window := DefaultArrivalWindow
+            if device.ArrivalWindow != nil {
+                window = *device.ArrivalWindow
+            }
+            fee = *device.MinimumUsageCost * float64(window)
+        }
+        payment := Payment{PaymentID: res.ReservationID, DeviceID: sDeviceId, ReservationID: res.ReservationID,
+            NoShowFee: fee, AmountDue: fee, Status: PaymentDue}
+        err = t.putPayment(stub, payment)
+        if err != nil {
+            return resList, err
+        }
+        bChanged = true
+    }
+    resList.Reservations = kept
+    if bChanged {
+        err = t.putReservations(stub, sDeviceId, resList)
+    }
+    return resList, err
+}
+
+/************************ internal: checkReservationConflict ********************/
+// A usage or reservation can't overlap a slot that is reserved, or in use from a reservation.
+// sReservationID is left out of the check, it is the reservation being turned into a usage
+func (t *DeviceUsageChaincode) checkReservationConflict(resList ReservationList, sReservationID string, tStartTime time.Time, duration int64) error {
+    tEndTime := tStartTime.Add(time.Duration(duration)*time.Second)
+    for _, res := range resList.Reservations {
+        if res.ReservationID == sReservationID || (res.Status != Reserved && res.Status != Arrived) {
+            continue
+        }
+        tResStart, err := time.Parse(TimeLayout, res.StartTime)
+        if err != nil {
+            return err
+        }
+        tResEnd := tResStart.Add(time.Duration(res.Duration)*time.Second)
+        if tStartTime.Before(tResEnd) && tResStart.Before(tEndTime) {
+            return errors.New("Device is reserved from " + res.StartTime + " by reservation " + res.ReservationID)
+        }
+    }
+    return nil
+}
+
+/************************ internal: getReservations ********************/
+
+func (t *DeviceUsageChaincode) getReservations(stub shim.ChaincodeStubInterface, sDeviceId string) (ReservationList, error) {
+    var resList ReservationList
+    resList.Reservations = make([]Reservation, 0)
+    stubData, err := stub.GetState(RESERVATIONKEY+"_"+sDeviceId)
+    if err != nil || len(stubData) == 0 {
+        return resList, nil
+    }
+    err = json.Unmarshal(stubData, &resList)
+    return resList, err
+}
+
+/************************ internal: putReservations ********************/
+
+func (t *DeviceUsageChaincode) putReservations(stub shim.ChaincodeStubInterface, sDeviceId string, resList ReservationList) error {
+    sStubUpdate, err := json.Marshal(&resList)
+    if err != nil {
+        return err
+    }
+    err = stub.PutState(RESERVATIONKEY+"_"+sDeviceId, sStubUpdate)
+    if err != nil {
+        return errors.New("Reservation record failed PUT to ledger: " + fmt.Sprint(err))
+    }
+    return nil
+}
+
+/************************ internal: getDevice ********************/
+
+func (t *DeviceUsageChaincode) getDevice(stub shim.ChaincodeStubInterface, sDeviceId string) (Device, error) {
+    var device Device
+    stubData, err := stub.GetState(DEVICESKEY+"_"+sDeviceId)
+    if err != nil || len(stubData) == 0 {
+        return device, errors.New("Device does not exist in stub!")
+    }
+    err = json.Unmarshal(stubData, &device)
+    if err != nil {
+        return device, err
+    }
+    if device.DeviceID == nil {
+        device.DeviceID = &sDeviceId
+    }
+    return device, nil
+}
+
+/************************ internal: txnTime ********************/
+
+func (t *DeviceUsageChaincode) txnTime(stub shim.ChaincodeStubInterface) (time.Time, error) {
+    txnTime, err := stub.GetTxTimestamp()
+    if err != nil || txnTime == nil {
+        return time.Time{}, errors.New("Unable to get transction time")
+    }
+    return time.Unix(txnTime.Seconds, int64(txnTime.Nanos)).UTC(), nil
+}
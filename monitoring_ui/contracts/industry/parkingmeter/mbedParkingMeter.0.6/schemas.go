@@ -43,6 +43,22 @@ var schemas = `
                                 "description": "Abbreviated street address",
                                 "type": "string"
                             },
+                            "arrivalwindow": {
+                                "description": "Seconds after a reservation starts within which arrival must be confirmed. Defaults to 900",
+                                "type": "integer"
+                            },
+                            "noshowfee": {
+                                "description": "Fee charged when arrival is not confirmed in time. Defaults to the minimum usage cost for the arrival window",
+                                "type": "number"
+                            },
+                            "zone": {
+                                "description": "Pricing zone of the meter. Occupancy of the zone drives surge pricing",
+                                "type": "string"
+                            },
+                            "tariffid": {
+                                "description": "Tariff assigned to the meter with assignTariff. Takes precedence over the zone tariff",
+                                "type": "string"
+                            },
                             "available": {
                                 "description": "Light in candela.",
                                 "type": "boolean"
@@ -103,6 +119,10 @@ var schemas = `
                                 "description": "Usage cost. Based on duration and rates defined for device.",
                                 "type": "number"
                             },
+                            "permitid": {
+                                "description": "Permit of the driver, for a permit discount on the tariff",
+                                "type": "string"
+                            },
                             "actualendtime": {
                                 "description": "actual end time. Provision for overtime scenario",
                                 "type": "string"
@@ -273,6 +293,22 @@ var schemas = `
                                 "description": "Abbreviated street address",
                                 "type": "string"
                             },
+                            "arrivalwindow": {
+                                "description": "Seconds after a reservation starts within which arrival must be confirmed. Defaults to 900",
+                                "type": "integer"
+                            },
+                            "noshowfee": {
+                                "description": "Fee charged when arrival is not confirmed in time. Defaults to the minimum usage cost for the arrival window",
+                                "type": "number"
+                            },
+                            "zone": {
+                                "description": "Pricing zone of the meter. Occupancy of the zone drives surge pricing",
+                                "type": "string"
+                            },
+                            "tariffid": {
+                                "description": "Tariff assigned to the meter with assignTariff. Takes precedence over the zone tariff",
+                                "type": "string"
+                            },
                             "available": {
                                 "description": "Light in candela.",
                                 "type": "boolean"
@@ -370,6 +406,22 @@ var schemas = `
                                 "description": "Abbreviated street address",
                                 "type": "string"
                             },
+                            "arrivalwindow": {
+                                "description": "Seconds after a reservation starts within which arrival must be confirmed. Defaults to 900",
+                                "type": "integer"
+                            },
+                            "noshowfee": {
+                                "description": "Fee charged when arrival is not confirmed in time. Defaults to the minimum usage cost for the arrival window",
+                                "type": "number"
+                            },
+                            "zone": {
+                                "description": "Pricing zone of the meter. Occupancy of the zone drives surge pricing",
+                                "type": "string"
+                            },
+                            "tariffid": {
+                                "description": "Tariff assigned to the meter with assignTariff. Takes precedence over the zone tariff",
+                                "type": "string"
+                            },
                             "available": {
                                 "description": "Light in candela.",
                                 "type": "boolean"
@@ -442,6 +494,22 @@ var schemas = `
                                 "description": "Abbreviated street address",
                                 "type": "string"
                             },
+                            "arrivalwindow": {
+                                "description": "Seconds after a reservation starts within which arrival must be confirmed. Defaults to 900",
+                                "type": "integer"
+                            },
+                            "noshowfee": {
+                                "description": "Fee charged when arrival is not confirmed in time. Defaults to the minimum usage cost for the arrival window",
+                                "type": "number"
+                            },
+                            "zone": {
+                                "description": "Pricing zone of the meter. Occupancy of the zone drives surge pricing",
+                                "type": "string"
+                            },
+                            "tariffid": {
+                                "description": "Tariff assigned to the meter with assignTariff. Takes precedence over the zone tariff",
+                                "type": "string"
+                            },
                             "available": {
                                 "description": "Light in candela.",
                                 "type": "boolean"
@@ -505,6 +573,22 @@ var schemas = `
                                 "description": "Abbreviated street address",
                                 "type": "string"
                             },
+                            "arrivalwindow": {
+                                "description": "Seconds after a reservation starts within which arrival must be confirmed. Defaults to 900",
+                                "type": "integer"
+                            },
+                            "noshowfee": {
+                                "description": "Fee charged when arrival is not confirmed in time. Defaults to the minimum usage cost for the arrival window",
+                                "type": "number"
+                            },
+                            "zone": {
+                                "description": "Pricing zone of the meter. Occupancy of the zone drives surge pricing",
+                                "type": "string"
+                            },
+                            "tariffid": {
+                                "description": "Tariff assigned to the meter with assignTariff. Takes precedence over the zone tariff",
+                                "type": "string"
+                            },
                             "available": {
                                 "description": "Light in candela.",
                                 "type": "boolean"
@@ -515,6 +599,437 @@ var schemas = `
             },
             "type": "object"
         },
+        "createReservation": {
+            "description": "Reserve a parking meter ahead of time. The reservation id is the transaction id. Arrival has to be confirmed within the arrival window of the device, or the reservation is released as a no-show and the no-show fee is charged.",
+            "properties": {
+                "args": {
+                    "description": "args are JSON encoded strings",
+                    "items": {
+                        "properties": {
+                            "deviceid": {
+                                "description": "The ID of a meter.",
+                                "type": "string"
+                            },
+                            "starttime": {
+                                "description": "Reserved start time, 2006-01-02 15:04:05",
+                                "type": "string"
+                            },
+                            "duration": {
+                                "description": "Reserved duration in seconds",
+                                "type": "integer"
+                            }
+                        },
+                        "required": [
+                            "deviceid",
+                            "starttime",
+                            "duration"
+                        ],
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
+                    "type": "array"
+                },
+                "function": {
+                    "description": "createReservation function",
+                    "enum": [
+                        "createReservation"
+                    ],
+                    "type": "string"
+                },
+                "method": "invoke"
+            },
+            "type": "object"
+        },
+        "confirmArrival": {
+            "description": "Confirm arrival for a reservation, up to its arrival deadline. Creates the usage and its payment record for the reserved start time and duration.",
+            "properties": {
+                "args": {
+                    "description": "args are JSON encoded strings",
+                    "items": {
+                        "properties": {
+                            "deviceid": {
+                                "description": "The ID of a meter.",
+                                "type": "string"
+                            },
+                            "reservationid": {
+                                "description": "The reservation id",
+                                "type": "string"
+                            }
+                        },
+                        "required": [
+                            "deviceid",
+                            "reservationid"
+                        ],
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
+                    "type": "array"
+                },
+                "function": {
+                    "description": "confirmArrival function",
+                    "enum": [
+                        "confirmArrival"
+                    ],
+                    "type": "string"
+                },
+                "method": "invoke"
+            },
+            "type": "object"
+        },
+        "releaseNoShowReservations": {
+            "description": "Release reservations whose arrival window has passed as no-shows, for one device or, without arguments, for all devices. Reservations are also released by any transaction on the device.",
+            "properties": {
+                "args": {
+                    "description": "args are JSON encoded strings",
+                    "items": {
+                        "properties": {
+                            "deviceid": {
+                                "description": "The ID of a meter.",
+                                "type": "string"
+                            }
+                        },
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 0,
+                    "type": "array"
+                },
+                "function": {
+                    "description": "releaseNoShowReservations function",
+                    "enum": [
+                        "releaseNoShowReservations"
+                    ],
+                    "type": "string"
+                },
+                "method": "invoke"
+            },
+            "type": "object"
+        },
+        "settlePayment": {
+            "description": "Pay an amount against the payment record of a usage or a no-show. The payment is settled once the amount due is paid.",
+            "properties": {
+                "args": {
+                    "description": "args are JSON encoded strings",
+                    "items": {
+                        "properties": {
+                            "paymentid": {
+                                "description": "The payment id. Transaction id of the usage, or reservation id of a no-show",
+                                "type": "string"
+                            },
+                            "amountpaid": {
+                                "description": "Amount paid",
+                                "type": "number"
+                            }
+                        },
+                        "required": [
+                            "paymentid",
+                            "amountpaid"
+                        ],
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
+                    "type": "array"
+                },
+                "function": {
+                    "description": "settlePayment function",
+                    "enum": [
+                        "settlePayment"
+                    ],
+                    "type": "string"
+                },
+                "method": "invoke"
+            },
+            "type": "object"
+        },
+        "readReservations": {
+            "description": "Returns the pending reservations of a device, and arrivals whose slot has not ended, with their status: reserved or arrived. Released no-shows are dropped, their fee is read with readPayment and the reservation id.",
+            "properties": {
+                "args": {
+                    "description": "args are JSON encoded strings",
+                    "items": {
+                        "properties": {
+                            "deviceid": {
+                                "description": "The ID of a meter.",
+                                "type": "string"
+                            }
+                        },
+                        "required": [
+                            "deviceid"
+                        ],
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
+                    "type": "array"
+                },
+                "function": {
+                    "description": "readReservations function",
+                    "enum": [
+                        "readReservations"
+                    ],
+                    "type": "string"
+                },
+                "method": "query",
+                "result": {
+                    "description": "Reservations of the device",
+                    "type": "object"
+                }
+            },
+            "type": "object"
+        },
+        "readPayment": {
+            "description": "Returns a payment record. amountdue is usagecost + overtimecost + noshowfee, overtimecost is the sum of the extendUsage calls in extensions.",
+            "properties": {
+                "args": {
+                    "description": "args are JSON encoded strings",
+                    "items": {
+                        "properties": {
+                            "paymentid": {
+                                "description": "The payment id",
+                                "type": "string"
+                            }
+                        },
+                        "required": [
+                            "paymentid"
+                        ],
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
+                    "type": "array"
+                },
+                "function": {
+                    "description": "readPayment function",
+                    "enum": [
+                        "readPayment"
+                    ],
+                    "type": "string"
+                },
+                "method": "query",
+                "result": {
+                    "description": "The payment record",
+                    "type": "object"
+                }
+            },
+            "type": "object"
+        },
+        "createTariff": {
+            "description": "Create or replace a tariff. Periods set the usage and overtime cost per second by day of week and time of day in the tariff's time zone, a period may wrap past midnight. Outside all periods the meter's own rates apply. A stay is split where the period in force changes. Surge steps multiply the cost once zone occupancy reaches the step, permit discounts are by permit type.",
+            "properties": {
+                "args": {
+                    "description": "args are JSON encoded strings",
+                    "items": {
+                        "properties": {
+                            "tariffid": {
+                                "description": "The tariff id",
+                                "type": "string"
+                            },
+                            "utcoffset": {
+                                "description": "Standard time offset of the periods from UTC, such as +01:00. UTC if empty",
+                                "type": "string"
+                            },
+                            "dst": {
+                                "description": "Daylight saving time of the periods: utcoffset while in force, and start and end changes, each a month (1..12), week (1..4, 5 for the last), day (0 Sunday .. 6 Saturday) and at as HH:MM in standard time. None if empty",
+                                "type": "object"
+                            },
+                            "periods": {
+                                "description": "List of periods: days (0 Sunday .. 6 Saturday, all days if empty), from and to as HH:MM, usagecost, overtimecost",
+                                "type": "array"
+                            },
+                            "surge": {
+                                "description": "List of surge steps: occupancy (0..1) and multiplier",
+                                "type": "array"
+                            },
+                            "permitdiscounts": {
+                                "description": "Discount by permit type, as a fraction between 0 and 1",
+                                "type": "object"
+                            }
+                        },
+                        "required": [
+                            "tariffid"
+                        ],
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
+                    "type": "array"
+                },
+                "function": {
+                    "description": "createTariff function",
+                    "enum": [
+                        "createTariff"
+                    ],
+                    "type": "string"
+                },
+                "method": "invoke"
+            },
+            "type": "object"
+        },
+        "assignTariff": {
+            "description": "Assign a tariff to a meter or to a zone. The meter's tariff takes precedence over its zone's. An empty tariffid removes the assignment.",
+            "properties": {
+                "args": {
+                    "description": "args are JSON encoded strings",
+                    "items": {
+                        "properties": {
+                            "tariffid": {
+                                "description": "The tariff id",
+                                "type": "string"
+                            },
+                            "deviceid": {
+                                "description": "The ID of a meter.",
+                                "type": "string"
+                            },
+                            "zone": {
+                                "description": "Pricing zone",
+                                "type": "string"
+                            }
+                        },
+                        "required": [
+                            "tariffid"
+                        ],
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
+                    "type": "array"
+                },
+                "function": {
+                    "description": "assignTariff function",
+                    "enum": [
+                        "assignTariff"
+                    ],
+                    "type": "string"
+                },
+                "method": "invoke"
+            },
+            "type": "object"
+        },
+        "createPermit": {
+            "description": "Create or replace a parking permit. Permits past validuntil get no discount. The caller's certificate must have the role attribute parkingauthority.",
+            "properties": {
+                "args": {
+                    "description": "args are JSON encoded strings",
+                    "items": {
+                        "properties": {
+                            "permitid": {
+                                "description": "The permit id",
+                                "type": "string"
+                            },
+                            "permittype": {
+                                "description": "Permit type, matched against the tariff permitdiscounts",
+                                "type": "string"
+                            },
+                            "validuntil": {
+                                "description": "Expiry, YYYY-MM-DD HH:MM:SS",
+                                "type": "string"
+                            }
+                        },
+                        "required": [
+                            "permitid",
+                            "permittype"
+                        ],
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
+                    "type": "array"
+                },
+                "function": {
+                    "description": "createPermit function",
+                    "enum": [
+                        "createPermit"
+                    ],
+                    "type": "string"
+                },
+                "method": "invoke"
+            },
+            "type": "object"
+        },
+        "readTariff": {
+            "description": "Returns a tariff.",
+            "properties": {
+                "args": {
+                    "description": "args are JSON encoded strings",
+                    "items": {
+                        "properties": {
+                            "tariffid": {
+                                "description": "The tariff id",
+                                "type": "string"
+                            }
+                        },
+                        "required": [
+                            "tariffid"
+                        ],
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
+                    "type": "array"
+                },
+                "function": {
+                    "description": "readTariff function",
+                    "enum": [
+                        "readTariff"
+                    ],
+                    "type": "string"
+                },
+                "method": "query",
+                "result": {
+                    "description": "The tariff",
+                    "type": "object"
+                }
+            },
+            "type": "object"
+        },
+        "quotePrice": {
+            "description": "Price a stay of the duration on a meter from the transaction time, as createUsage would, without creating a usage. Cost is the sum of rate x duration of each segment, x surge x (1 - discount), at current zone occupancy.",
+            "properties": {
+                "args": {
+                    "description": "args are JSON encoded strings",
+                    "items": {
+                        "properties": {
+                            "deviceid": {
+                                "description": "The ID of a meter.",
+                                "type": "string"
+                            },
+                            "duration": {
+                                "description": "Duration in seconds",
+                                "type": "number"
+                            },
+                            "permitid": {
+                                "description": "Permit of the driver",
+                                "type": "string"
+                            }
+                        },
+                        "required": [
+                            "deviceid",
+                            "duration"
+                        ],
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
+                    "type": "array"
+                },
+                "function": {
+                    "description": "quotePrice function",
+                    "enum": [
+                        "quotePrice"
+                    ],
+                    "type": "string"
+                },
+                "method": "query",
+                "result": {
+                    "description": "The quote: rate, segments of the stay by rate, occupancy, surge, discount and cost",
+                    "type": "object"
+                }
+            },
+            "type": "object"
+        },
         "deleteDevice": {
             "description": "Delete a parking meter device. One argument, a JSON encoded event.",
             "properties": {
@@ -595,6 +1110,22 @@ var schemas = `
                     "description": "Abbreviated street address",
                     "type": "string"
                 },
+                "arrivalwindow": {
+                    "description": "Seconds after a reservation starts within which arrival must be confirmed. Defaults to 900",
+                    "type": "integer"
+                },
+                "noshowfee": {
+                    "description": "Fee charged when arrival is not confirmed in time. Defaults to the minimum usage cost for the arrival window",
+                    "type": "number"
+                },
+                "zone": {
+                    "description": "Pricing zone of the meter. Occupancy of the zone drives surge pricing",
+                    "type": "string"
+                },
+                "tariffid": {
+                    "description": "Tariff assigned to the meter with assignTariff. Takes precedence over the zone tariff",
+                    "type": "string"
+                },
                 "available": {
                     "description": "Light in candela.",
                     "type": "boolean"
@@ -658,6 +1189,22 @@ var schemas = `
                     "description": "Abbreviated street address",
                     "type": "string"
                 },
+                "arrivalwindow": {
+                    "description": "Seconds after a reservation starts within which arrival must be confirmed. Defaults to 900",
+                    "type": "integer"
+                },
+                "noshowfee": {
+                    "description": "Fee charged when arrival is not confirmed in time. Defaults to the minimum usage cost for the arrival window",
+                    "type": "number"
+                },
+                "zone": {
+                    "description": "Pricing zone of the meter. Occupancy of the zone drives surge pricing",
+                    "type": "string"
+                },
+                "tariffid": {
+                    "description": "Tariff assigned to the meter with assignTariff. Takes precedence over the zone tariff",
+                    "type": "string"
+                },
                 "available": {
                     "description": "Light in candela.",
                     "type": "boolean"
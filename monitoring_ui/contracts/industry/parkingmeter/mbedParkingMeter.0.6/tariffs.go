@@ -0,0 +1,564 @@
+package main
+import (
+    "encoding/json"
+    "errors"
+    "fmt"
+    "strings"
+    "time"
+
+"github.com/hyperledger/fabric/core/chaincode/shim"
+)
+
+// Tariffs are kept under TARIFFKEY_<tariffid>. A tariff is attached to a device through the
+// device's tariffid, or to a zone under ZONEKEY_<zone>. The device's tariff wins over the
+// zone's, and a device without either is priced with its own minimum and overtime costs.
+// Stays are priced from the transaction time, by createUsage, extendUsage and quotePrice
+// alike, and split where the period in force changes. Tariffs, their assignment and permits
+// are set by the parking authority only.
+
+// Layout of the from and to times of a tariff period
+const ClockLayout string = "15:04"
+
+/************************ createTariff ********************/
+// Creates or replaces a tariff. Only a caller with the role attribute PERMITISSUERROLE can
+func (t *DeviceUsageChaincode) createTariff(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+    var tariff Tariff
+    if len(args) != 1 {
+        return nil, errors.New("Expects one argument, a JSON string with tariff details")
+    }
+    err := t.verifyAuthority(stub, "Tariffs can only be created")
+    if err != nil {
+        return nil, err
+    }
+    err = json.Unmarshal([]byte(args[0]), &tariff)
+    if err != nil {
+        return nil, errors.New("Unable to unmarshal tariff data " + fmt.Sprint(err))
+    }
+    tariff.TariffID = strings.TrimSpace(tariff.TariffID)
+    if tariff.TariffID == "" {
+        return nil, errors.New("Tariff id is mandatory !")
+    }
+    err = tariff.validateZone()
+    if err != nil {
+        return nil, err
+    }
+    for _, period := range tariff.Periods {
+        _, errFrom := time.Parse(ClockLayout, period.From)
+        _, errTo := time.Parse(ClockLayout, period.To)
+        if errFrom != nil || errTo != nil {
+            return nil, errors.New("Tariff period from and to must be in 15:04 format")
+        }
+        if period.UsageCost < 0 || period.OvertimeCost < 0 {
+            return nil, errors.New("Tariff period costs cannot be negative")
+        }
+        for _, day := range period.Days {
+            if day < time.Sunday || day > time.Saturday {
+                return nil, errors.New("Tariff period days must be 0 (Sunday) to 6 (Saturday)")
+            }
+        }
+    }
+    for _, step := range tariff.Surge {
+        if step.Occupancy < 0 || step.Occupancy > 1 || step.Multiplier <= 0 {
+            return nil, errors.New("Surge occupancy must be 0 to 1 and multiplier more than 0")
+        }
+    }
+    for permitType, discount := range tariff.PermitDiscounts {
+        if discount < 0 || discount > 1 {
+            return nil, errors.New("Permit discount must be 0 to 1: " + permitType)
+        }
+    }
+    sStubUpdate, err := json.Marshal(&tariff)
+    if err != nil {
+        return nil, err
+    }
+    err = stub.PutState(TARIFFKEY+"_"+tariff.TariffID, sStubUpdate)
+    if err != nil {
+        return nil, errors.New("Tariff record failed PUT to ledger: " + fmt.Sprint(err))
+    }
+    return nil, nil
+}
+
+/************************ assignTariff ********************/
+// Attaches a tariff to a device or to a zone. An empty tariff id detaches it. Only a caller
+// with the role attribute PERMITISSUERROLE can
+func (t *DeviceUsageChaincode) assignTariff(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+    var assignment TariffAssignment
+    if len(args) != 1 {
+        return nil, errors.New("Expects one argument, a JSON string with tariff id and a device id or zone")
+    }
+    err := t.verifyAuthority(stub, "Tariffs can only be assigned")
+    if err != nil {
+        return nil, err
+    }
+    err = json.Unmarshal([]byte(args[0]), &assignment)
+    if err != nil {
+        return nil, errors.New("Unable to unmarshal tariff assignment " + fmt.Sprint(err))
+    }
+    if (assignment.DeviceID == "") == (assignment.Zone == "") {
+        return nil, errors.New("Either a device id or a zone is mandatory !")
+    }
+    if assignment.TariffID != "" {
+        _, err = t.getTariff(stub, assignment.TariffID)
+        if err != nil {
+            return nil, err
+        }
+    }
+    if assignment.Zone != "" {
+        if assignment.TariffID == "" {
+            err = stub.DelState(ZONEKEY+"_"+assignment.Zone)
+        } else {
+            err = stub.PutState(ZONEKEY+"_"+assignment.Zone, []byte(assignment.TariffID))
+        }
+        if err != nil {
+            return nil, errors.New("Zone tariff failed PUT to ledger: " + fmt.Sprint(err))
+        }
+        return nil, nil
+    }
+    device, err := t.getDevice(stub, assignment.DeviceID)
+    if err != nil {
+        return nil, err
+    }
+    device.TariffID = nil
+    if assignment.TariffID != "" {
+        device.TariffID = &assignment.TariffID
+    }
+    sStubUpdate, err := json.Marshal(&device)
+    if err != nil {
+        return nil, err
+    }
+    err = stub.PutState(DEVICESKEY+"_"+assignment.DeviceID, sStubUpdate)
+    if err != nil {
+        return nil, errors.New("Device record failed PUT to ledger: " + fmt.Sprint(err))
+    }
+    return nil, nil
+}
+
+/************************ createPermit ********************/
+// Creates or replaces a parking permit. Usage with the permit id gets the discount of
+// the tariff for the permit type. Only the parking authority, a caller whose certificate
+// has the role attribute PERMITISSUERROLE, can issue permits
+func (t *DeviceUsageChaincode) createPermit(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+    var permit Permit
+    if len(args) != 1 {
+        return nil, errors.New("Expects one argument, a JSON string with permit id and permit type")
+    }
+    err := t.verifyAuthority(stub, "Permits can only be issued")
+    if err != nil {
+        return nil, err
+    }
+    err = json.Unmarshal([]byte(args[0]), &permit)
+    if err != nil {
+        return nil, errors.New("Unable to unmarshal permit data " + fmt.Sprint(err))
+    }
+    if strings.TrimSpace(permit.PermitID) == "" || strings.TrimSpace(permit.PermitType) == "" {
+        return nil, errors.New("Permit id and permit type are mandatory !")
+    }
+    if permit.ValidUntil != "" {
+        _, err = time.Parse(TimeLayout, permit.ValidUntil)
+        if err != nil {
+            return nil, err
+        }
+    }
+    sStubUpdate, err := json.Marshal(&permit)
+    if err != nil {
+        return nil, err
+    }
+    err = stub.PutState(PERMITKEY+"_"+permit.PermitID, sStubUpdate)
+    if err != nil {
+        return nil, errors.New("Permit record failed PUT to ledger: " + fmt.Sprint(err))
+    }
+    return nil, nil
+}
+
+/************************ readTariff ********************/
+
+func (t *DeviceUsageChaincode) readTariff(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+    var tariffIn Tariff
+    if len(args) != 1 {
+        return nil, errors.New("readTariff expects one argument, a JSON string with tariff id")
+    }
+    err := json.Unmarshal([]byte(args[0]), &tariffIn)
+    if err != nil {
+        return nil, errors.New("Unable to unmarshal input tariff!")
+    }
+    tariff, err := t.getTariff(stub, tariffIn.TariffID)
+    if err != nil {
+        return nil, err
+    }
+    return json.Marshal(&tariff)
+}
+
+/************************ quotePrice ********************/
+// Returns the price of a stay of the duration, as createUsage would charge it in this
+// transaction. createUsage prices from the transaction time, so the quote does as well
+func (t *DeviceUsageChaincode) quotePrice(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+    var usage Usage
+    if len(args) != 1 {
+        return nil, errors.New("quotePrice expects one argument, a JSON string with device id and duration")
+    }
+    err := json.Unmarshal([]byte(args[0]), &usage)
+    if err != nil {
+        return nil, errors.New("Unable to unmarshal device usage data " + fmt.Sprint(err))
+    }
+    if usage.Duration <= 0 {
+        return nil, errors.New("Quote duration must be a positive number of seconds")
+    }
+    device, err := t.getDevice(stub, usage.DeviceID)
+    if err != nil {
+        return nil, err
+    }
+    txntime, err := t.txnTime(stub)
+    if err != nil {
+        return nil, err
+    }
+    quote, err := t.priceStay(stub, device, txntime, usage.Duration, usage.PermitID, false)
+    if err != nil {
+        return nil, err
+    }
+    return json.Marshal(&quote)
+}
+
+/************************ internal: priceStay ********************/
+// Prices a stay, or an overtime extension, on the device for duration seconds from
+// tPriceTime. With a tariff each segment of the stay is priced by the period in force
+
+func (t *DeviceUsageChaincode) priceStay(stub shim.ChaincodeStubInterface, device Device, tPriceTime time.Time, duration int64, sPermitID string, bOvertime bool) (Quote, error) {
+    tPriceTime = tPriceTime.Truncate(time.Second)
+    quote := Quote{DeviceID: *device.DeviceID, StartTime: tPriceTime.UTC().Format(TimeLayout), Duration: duration, Overtime: bOvertime, Surge: 1}
+    if bOvertime && device.OvertimeUsageCost != nil {
+        quote.Rate = *device.OvertimeUsageCost
+    } else if !bOvertime && device.MinimumUsageCost != nil {
+        quote.Rate = *device.MinimumUsageCost
+    }
+    fBaseCost := quote.Rate * float64(duration)
+    tariff, found, err := t.getDeviceTariff(stub, device)
+    if err != nil {
+        return quote, err
+    }
+    if found {
+        quote.TariffID = tariff.TariffID
+        quote.Segments, err = tariff.segments(tPriceTime, duration, bOvertime, quote.Rate)
+        if err != nil {
+            return quote, err
+        }
+        fBaseCost = 0
+        for _, segment := range quote.Segments {
+            fBaseCost += segment.Rate * float64(segment.Duration)
+        }
+        if len(quote.Segments) > 0 {
+            quote.Rate = quote.Segments[0].Rate
+        }
+        if len(tariff.Surge) > 0 && device.Zone != nil {
+            quote.Occupancy, err = t.zoneOccupancy(stub, *device.Zone)
+            if err != nil {
+                return quote, err
+            }
+            threshold := -1.0
+            for _, step := range tariff.Surge {
+                if step.Occupancy <= quote.Occupancy && step.Occupancy > threshold {
+                    threshold = step.Occupancy
+                    quote.Surge = step.Multiplier
+                }
+            }
+        }
+        if sPermitID != "" {
+            permit, err := t.getPermit(stub, sPermitID)
+            if err != nil {
+                return quote, err
+            }
+            if permit.ValidUntil != "" {
+                tValidUntil, _ := time.Parse(TimeLayout, permit.ValidUntil)
+                if tPriceTime.After(tValidUntil) {
+                    return quote, errors.New("Permit expired at " + permit.ValidUntil)
+                }
+            }
+            quote.Discount = tariff.PermitDiscounts[permit.PermitType]
+        }
+    }
+    quote.Cost = fBaseCost * quote.Surge * (1 - quote.Discount)
+    return quote, nil
+}
+
+/************************ internal: segments ********************/
+// Splits a stay into segments at the period boundaries of the tariff, and at midnight as
+// periods can be limited to days, in the tariff's local time. The local time changes with
+// daylight saving time, so a stay is split at the clock change as well. Each segment is
+// priced at the rate of the period in force, or at the device rate outside all periods.
+// Neighbouring segments at the same rate are merged
+
+func (tariff Tariff) segments(tStart time.Time, duration int64, bOvertime bool, deviceRate float64) ([]QuoteSegment, error) {
+    segments := make([]QuoteSegment, 0)
+    tEnd := tStart.Add(time.Duration(duration)*time.Second)
+    for tFrom := tStart; tFrom.Before(tEnd); {
+        loc, tChange, err := tariff.zoneAt(tFrom)
+        if err != nil {
+            return nil, err
+        }
+        tFrom = tFrom.In(loc)
+        tTo := tariff.nextBoundary(tFrom)
+        if !tChange.IsZero() && tChange.Before(tTo) {
+            tTo = tChange
+        }
+        if tTo.After(tEnd) {
+            tTo = tEnd
+        }
+        rate := deviceRate
+        period, ok := tariff.periodAt(tFrom)
+        if ok && bOvertime {
+            rate = period.OvertimeCost
+        } else if ok {
+            rate = period.UsageCost
+        }
+        seconds := int64(tTo.Sub(tFrom)/time.Second)
+        if n := len(segments); n > 0 && segments[n-1].Rate == rate {
+            segments[n-1].EndTime = tTo.UTC().Format(TimeLayout)
+            segments[n-1].Duration += seconds
+        } else {
+            segments = append(segments, QuoteSegment{StartTime: tFrom.UTC().Format(TimeLayout), EndTime: tTo.UTC().Format(TimeLayout), Duration: seconds, Rate: rate})
+        }
+        tFrom = tTo
+    }
+    return segments, nil
+}
+
+/************************ internal: nextBoundary ********************/
+// Returns the first period start or end, or midnight, after the local time
+
+func (tariff Tariff) nextBoundary(tTime time.Time) time.Time {
+    y, m, d := tTime.Date()
+    tNext := time.Date(y, m, d+1, 0, 0, 0, 0, tTime.Location())
+    for _, period := range tariff.Periods {
+        for _, sClock := range []string{period.From, period.To} {
+            tClock, err := time.Parse(ClockLayout, sClock)
+            if err != nil {
+                continue
+            }
+            tBoundary := time.Date(y, m, d, tClock.Hour(), tClock.Minute(), 0, 0, tTime.Location())
+            if tBoundary.After(tTime) && tBoundary.Before(tNext) {
+                tNext = tBoundary
+            }
+        }
+    }
+    return tNext
+}
+
+/************************ internal: validateZone ********************/
+// Checks the UTC offsets and daylight saving time rule of the tariff
+
+func (tariff Tariff) validateZone() error {
+    _, err := parseUTCOffset(tariff.UTCOffset)
+    if err != nil || tariff.DST == nil {
+        return err
+    }
+    if tariff.DST.UTCOffset == "" {
+        return errors.New("Daylight saving time UTC offset is mandatory !")
+    }
+    _, err = parseUTCOffset(tariff.DST.UTCOffset)
+    if err != nil {
+        return err
+    }
+    for _, change := range []DSTChange{tariff.DST.Start, tariff.DST.End} {
+        _, err = time.Parse(ClockLayout, change.At)
+        if err != nil {
+            return errors.New("Daylight saving time change must be at a 15:04 standard time")
+        }
+        if change.Month < time.January || change.Month > time.December {
+            return errors.New("Daylight saving time month must be 1 (January) to 12 (December)")
+        }
+        if change.Week < 1 || change.Week > 5 {
+            return errors.New("Daylight saving time week must be 1 to 4, or 5 for the last")
+        }
+        if change.Day < time.Sunday || change.Day > time.Saturday {
+            return errors.New("Daylight saving time day must be 0 (Sunday) to 6 (Saturday)")
+        }
+    }
+    return nil
+}
+
+/************************ internal: zoneAt ********************/
+// Returns the local time zone of the tariff periods at the time, as a fixed offset, and the
+// next change of the offset. The change is zero without daylight saving time
+
+func (tariff Tariff) zoneAt(tTime time.Time) (*time.Location, time.Time, error) {
+    stdOffset, err := parseUTCOffset(tariff.UTCOffset)
+    if err != nil {
+        return nil, time.Time{}, err
+    }
+    if tariff.DST == nil {
+        return time.FixedZone("", stdOffset), time.Time{}, nil
+    }
+    dstOffset, err := parseUTCOffset(tariff.DST.UTCOffset)
+    if err != nil {
+        return nil, time.Time{}, err
+    }
+    // Of the changes in the years around the time, the last one before it is in force
+    offset := stdOffset
+    var tLast, tNext time.Time
+    year := tTime.UTC().Year()
+    for y := year - 1; y <= year+1; y++ {
+        for _, change := range []struct {
+            at     time.Time
+            offset int
+        }{{tariff.DST.Start.at(y, stdOffset), dstOffset}, {tariff.DST.End.at(y, stdOffset), stdOffset}} {
+            if change.at.After(tTime) {
+                if tNext.IsZero() || change.at.Before(tNext) {
+                    tNext = change.at
+                }
+            } else if tLast.IsZero() || change.at.After(tLast) {
+                tLast, offset = change.at, change.offset
+            }
+        }
+    }
+    return time.FixedZone("", offset), tNext, nil
+}
+
+/************************ internal: at ********************/
+// Returns the time of the daylight saving time change in the year, in a zone of the
+// standard offset
+
+func (change DSTChange) at(year int, stdOffset int) time.Time {
+    loc := time.FixedZone("", stdOffset)
+    tClock, _ := time.Parse(ClockLayout, change.At)
+    var tDay time.Time
+    if change.Week >= 5 {
+        // Back from the last day of the month
+        tDay = time.Date(year, change.Month+1, 0, 0, 0, 0, 0, loc)
+        tDay = tDay.AddDate(0, 0, -((int(tDay.Weekday()) - int(change.Day) + 7) % 7))
+    } else {
+        tDay = time.Date(year, change.Month, 1, 0, 0, 0, 0, loc)
+        tDay = tDay.AddDate(0, 0, (int(change.Day)-int(tDay.Weekday())+7)%7 + 7*(change.Week-1))
+    }
+    return tDay.Add(time.Duration(tClock.Hour())*time.Hour + time.Duration(tClock.Minute())*time.Minute)
+}
+
+/************************ internal: parseUTCOffset ********************/
+// Returns the seconds east of UTC of an offset such as +01:00 or -05:00, 0 if empty
+
+func parseUTCOffset(sOffset string) (int, error) {
+    if sOffset == "" {
+        return 0, nil
+    }
+    tOffset, err := time.Parse("-07:00", sOffset)
+    if err != nil {
+        return 0, errors.New("Tariff UTC offset must be as +01:00 or -05:00: " + sOffset)
+    }
+    _, offset := tOffset.Zone()
+    return offset, nil
+}
+
+/************************ internal: periodAt ********************/
+// Returns the first period of the tariff in force at the time, which is local to the tariff
+
+func (tariff Tariff) periodAt(tTime time.Time) (TariffPeriod, bool) {
+    clock := tTime.Format(ClockLayout)
+    for _, period := range tariff.Periods {
+        // A period running past midnight started on the day before
+        day := tTime.Weekday()
+        var inPeriod bool
+        if period.From < period.To {
+            inPeriod = clock >= period.From && clock < period.To
+        } else if clock >= period.From {
+            inPeriod = true
+        } else if clock < period.To {
+            inPeriod = true
+            day = (day + 6) % 7
+        }
+        if !inPeriod {
+            continue
+        }
+        if len(period.Days) == 0 {
+            return period, true
+        }
+        for _, d := range period.Days {
+            if d == day {
+                return period, true
+            }
+        }
+    }
+    return TariffPeriod{}, false
+}
+
+/************************ internal: zoneOccupancy ********************/
+// Share of the devices in the zone that are in use
+
+func (t *DeviceUsageChaincode) zoneOccupancy(stub shim.ChaincodeStubInterface, sZone string) (float64, error) {
+    var devList DevList
+    sListData, err := stub.GetState(LISTKEY)
+    if err != nil || len(sListData) == 0 {
+        return 0, nil
+    }
+    err = json.Unmarshal(sListData, &devList)
+    if err != nil {
+        return 0, err
+    }
+    iTotal, iInUse := 0, 0
+    for _, sDeviceId := range devList.Devices {
+        device, err := t.getDevice(stub, sDeviceId)
+        if err != nil || device.Zone == nil || *device.Zone != sZone {
+            continue
+        }
+        iTotal++
+        if device.Available != nil && !*device.Available {
+            iInUse++
+        }
+    }
+    if iTotal == 0 {
+        return 0, nil
+    }
+    return float64(iInUse) / float64(iTotal), nil
+}
+
+/************************ internal: getDeviceTariff ********************/
+// Returns the tariff of the device, else the tariff of its zone
+
+func (t *DeviceUsageChaincode) getDeviceTariff(stub shim.ChaincodeStubInterface, device Device) (Tariff, bool, error) {
+    sTariffID := ""
+    if device.TariffID != nil {
+        sTariffID = *device.TariffID
+    } else if device.Zone != nil {
+        zoneData, err := stub.GetState(ZONEKEY+"_"+*device.Zone)
+        if err == nil {
+            sTariffID = string(zoneData)
+        }
+    }
+    if sTariffID == "" {
+        return Tariff{}, false, nil
+    }
+    tariff, err := t.getTariff(stub, sTariffID)
+    return tariff, err == nil, err
+}
+
+/************************ internal: getTariff ********************/
+
+func (t *DeviceUsageChaincode) getTariff(stub shim.ChaincodeStubInterface, sTariffID string) (Tariff, error) {
+    var tariff Tariff
+    stubData, err := stub.GetState(TARIFFKEY+"_"+sTariffID)
+    if err != nil || len(stubData) == 0 {
+        return tariff, errors.New("Tariff does not exist in stub: " + sTariffID)
+    }
+    err = json.Unmarshal(stubData, &tariff)
+    return tariff, err
+}
+
+/************************ internal: getPermit ********************/
+
+func (t *DeviceUsageChaincode) getPermit(stub shim.ChaincodeStubInterface, sPermitID string) (Permit, error) {
+    var permit Permit
+    stubData, err := stub.GetState(PERMITKEY+"_"+sPermitID)
+    if err != nil || len(stubData) == 0 {
+        return permit, errors.New("Permit does not exist in stub: " + sPermitID)
+    }
+    err = json.Unmarshal(stubData, &permit)
+    return permit, err
+}
+
+/************************ internal: verifyAuthority ********************/
+// Fails with the message unless the caller's certificate has the role attribute PERMITISSUERROLE
+
+func (t *DeviceUsageChaincode) verifyAuthority(stub shim.ChaincodeStubInterface, sMessage string) error {
+    bAuthority, err := stub.VerifyAttribute("role", []byte(PERMITISSUERROLE))
+    if err != nil || !bAuthority {
+        return errors.New(sMessage + " by the " + PERMITISSUERROLE + " role")
+    }
+    return nil
+}
@@ -0,0 +1,241 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+)
+
+// The central European rule, +01:00 and +02:00 from the last Sunday of March to the last
+// Sunday of October, both at 02:00 standard time
+var cetTariff = Tariff{
+	TariffID:  "cet",
+	UTCOffset: "+01:00",
+	DST: &DSTRule{
+		UTCOffset: "+02:00",
+		Start:     DSTChange{Month: time.March, Week: 5, Day: time.Sunday, At: "02:00"},
+		End:       DSTChange{Month: time.October, Week: 5, Day: time.Sunday, At: "02:00"},
+	},
+	Periods: []TariffPeriod{{From: "07:00", To: "09:00", UsageCost: 2, OvertimeCost: 4}},
+}
+
+func utc(s string) time.Time {
+	t, err := time.Parse(TimeLayout, s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestTariffPeriodAt(t *testing.T) {
+	tariff := Tariff{Periods: []TariffPeriod{
+		{Days: []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}, From: "08:00", To: "18:00", UsageCost: 2},
+		{Days: []time.Weekday{time.Friday}, From: "22:00", To: "02:00", UsageCost: 3},
+		{From: "08:00", To: "12:00", UsageCost: 1},
+	}}
+	tests := []struct {
+		time  string
+		found bool
+		cost  float64
+	}{
+		{"2024-07-01 07:59:59", false, 0}, // Monday before any period
+		{"2024-07-01 08:00:00", true, 2},  // Monday, the first matching period wins
+		{"2024-07-01 17:59:59", true, 2},  // To is exclusive
+		{"2024-07-01 18:00:00", false, 0}, // Monday evening
+		{"2024-07-06 09:00:00", true, 1},  // Saturday, every day period
+		{"2024-07-05 23:00:00", true, 3},  // Friday night
+		{"2024-07-06 01:00:00", true, 3},  // past midnight, started on Friday
+		{"2024-07-07 01:00:00", false, 0}, // past midnight, started on Saturday
+		{"2024-07-06 02:00:00", false, 0}, // end of the night period
+	}
+	for _, test := range tests {
+		period, found := tariff.periodAt(utc(test.time))
+		if found != test.found || period.UsageCost != test.cost {
+			t.Errorf("%s: expected found %v at %v, got found %v at %v", test.time, test.found, test.cost, found, period.UsageCost)
+		}
+	}
+}
+
+func TestTariffSegments(t *testing.T) {
+	dayTariff := Tariff{UTCOffset: "-05:00", Periods: []TariffPeriod{
+		{From: "08:00", To: "18:00", UsageCost: 2, OvertimeCost: 5},
+		{From: "18:00", To: "20:00", UsageCost: 2, OvertimeCost: 6},
+	}}
+	tests := []struct {
+		name     string
+		tariff   Tariff
+		start    string
+		duration int64
+		overtime bool
+		segments []QuoteSegment
+	}{
+		{"inside one period", dayTariff, "2024-07-01 14:00:00", 3600, false, []QuoteSegment{
+			{StartTime: "2024-07-01 14:00:00", EndTime: "2024-07-01 15:00:00", Duration: 3600, Rate: 2},
+		}},
+		{"into a period, in local time", dayTariff, "2024-07-01 12:30:00", 3600, false, []QuoteSegment{
+			{StartTime: "2024-07-01 12:30:00", EndTime: "2024-07-01 13:00:00", Duration: 1800, Rate: 1},
+			{StartTime: "2024-07-01 13:00:00", EndTime: "2024-07-01 13:30:00", Duration: 1800, Rate: 2},
+		}},
+		{"same rate periods merged", dayTariff, "2024-07-01 22:00:00", 3 * 3600, false, []QuoteSegment{
+			{StartTime: "2024-07-01 22:00:00", EndTime: "2024-07-02 01:00:00", Duration: 3 * 3600, Rate: 2},
+		}},
+		{"overtime rates not merged", dayTariff, "2024-07-01 22:00:00", 3 * 3600, true, []QuoteSegment{
+			{StartTime: "2024-07-01 22:00:00", EndTime: "2024-07-01 23:00:00", Duration: 3600, Rate: 5},
+			{StartTime: "2024-07-01 23:00:00", EndTime: "2024-07-02 01:00:00", Duration: 2 * 3600, Rate: 6},
+		}},
+		{"out of the periods", dayTariff, "2024-07-02 00:30:00", 3600, false, []QuoteSegment{
+			{StartTime: "2024-07-02 00:30:00", EndTime: "2024-07-02 01:00:00", Duration: 1800, Rate: 2},
+			{StartTime: "2024-07-02 01:00:00", EndTime: "2024-07-02 01:30:00", Duration: 1800, Rate: 1},
+		}},
+		{"standard time", cetTariff, "2024-03-30 05:00:00", 2 * 3600, false, []QuoteSegment{
+			{StartTime: "2024-03-30 05:00:00", EndTime: "2024-03-30 06:00:00", Duration: 3600, Rate: 1},
+			{StartTime: "2024-03-30 06:00:00", EndTime: "2024-03-30 07:00:00", Duration: 3600, Rate: 2},
+		}},
+		{"summer time", cetTariff, "2024-03-31 05:00:00", 2 * 3600, false, []QuoteSegment{
+			{StartTime: "2024-03-31 05:00:00", EndTime: "2024-03-31 07:00:00", Duration: 2 * 3600, Rate: 2},
+		}},
+		{"across the clock change", cetTariff, "2024-03-31 00:00:00", 6 * 3600, false, []QuoteSegment{
+			{StartTime: "2024-03-31 00:00:00", EndTime: "2024-03-31 05:00:00", Duration: 5 * 3600, Rate: 1},
+			{StartTime: "2024-03-31 05:00:00", EndTime: "2024-03-31 06:00:00", Duration: 3600, Rate: 2},
+		}},
+		{"across the change back", cetTariff, "2024-10-27 00:00:00", 7 * 3600, false, []QuoteSegment{
+			{StartTime: "2024-10-27 00:00:00", EndTime: "2024-10-27 06:00:00", Duration: 6 * 3600, Rate: 1},
+			{StartTime: "2024-10-27 06:00:00", EndTime: "2024-10-27 07:00:00", Duration: 3600, Rate: 2},
+		}},
+	}
+	for _, test := range tests {
+		segments, err := test.tariff.segments(utc(test.start), test.duration, test.overtime, 1)
+		if err != nil {
+			t.Errorf("%s: %s", test.name, err)
+			continue
+		}
+		if len(segments) != len(test.segments) {
+			t.Errorf("%s: expected segments %+v, got %+v", test.name, test.segments, segments)
+			continue
+		}
+		for i := range segments {
+			if segments[i] != test.segments[i] {
+				t.Errorf("%s: expected segment %+v, got %+v", test.name, test.segments[i], segments[i])
+			}
+		}
+	}
+}
+
+func TestTariffDSTChanges(t *testing.T) {
+	tests := []struct {
+		change DSTChange
+		year   int
+		offset int
+		at     string
+	}{
+		{DSTChange{Month: time.March, Week: 5, Day: time.Sunday, At: "02:00"}, 2024, 3600, "2024-03-31 01:00:00"},
+		{DSTChange{Month: time.October, Week: 5, Day: time.Sunday, At: "02:00"}, 2024, 3600, "2024-10-27 01:00:00"},
+		{DSTChange{Month: time.March, Week: 2, Day: time.Sunday, At: "02:00"}, 2024, -5 * 3600, "2024-03-10 07:00:00"},
+		{DSTChange{Month: time.November, Week: 1, Day: time.Sunday, At: "01:00"}, 2024, -5 * 3600, "2024-11-03 06:00:00"},
+		// The first Sunday of October 2023 is the 1st, a day after the change in UTC
+		{DSTChange{Month: time.October, Week: 1, Day: time.Sunday, At: "02:00"}, 2023, 10 * 3600, "2023-09-30 16:00:00"},
+	}
+	for _, test := range tests {
+		at := test.change.at(test.year, test.offset).UTC().Format(TimeLayout)
+		if at != test.at {
+			t.Errorf("%+v in %d: expected %s, got %s", test.change, test.year, test.at, at)
+		}
+	}
+}
+
+func TestTariffZoneAt(t *testing.T) {
+	sydney := Tariff{UTCOffset: "+10:00", DST: &DSTRule{
+		UTCOffset: "+11:00",
+		Start:     DSTChange{Month: time.October, Week: 1, Day: time.Sunday, At: "02:00"},
+		End:       DSTChange{Month: time.April, Week: 1, Day: time.Sunday, At: "02:00"},
+	}}
+	tests := []struct {
+		name   string
+		tariff Tariff
+		time   string
+		offset int
+		change string
+	}{
+		{"winter", cetTariff, "2024-01-15 12:00:00", 3600, "2024-03-31 01:00:00"},
+		{"just before the change", cetTariff, "2024-03-31 00:59:59", 3600, "2024-03-31 01:00:00"},
+		{"at the change", cetTariff, "2024-03-31 01:00:00", 2 * 3600, "2024-10-27 01:00:00"},
+		{"after the change back", cetTariff, "2024-10-27 01:00:00", 3600, "2025-03-30 01:00:00"},
+		{"southern summer", sydney, "2024-01-15 12:00:00", 11 * 3600, "2024-04-06 16:00:00"},
+		{"southern winter", sydney, "2024-07-15 12:00:00", 10 * 3600, "2024-10-05 16:00:00"},
+		{"no dst", Tariff{UTCOffset: "-05:00"}, "2024-07-15 12:00:00", -5 * 3600, ""},
+	}
+	for _, test := range tests {
+		loc, tChange, err := test.tariff.zoneAt(utc(test.time))
+		if err != nil {
+			t.Errorf("%s: %s", test.name, err)
+			continue
+		}
+		_, offset := utc(test.time).In(loc).Zone()
+		change := ""
+		if !tChange.IsZero() {
+			change = tChange.UTC().Format(TimeLayout)
+		}
+		if offset != test.offset || change != test.change {
+			t.Errorf("%s: expected offset %d and change %q, got %d and %q", test.name, test.offset, test.change, offset, change)
+		}
+	}
+}
+
+func TestTariffValidateZone(t *testing.T) {
+	bad := []Tariff{
+		{UTCOffset: "Europe/Paris"},
+		{UTCOffset: "+1"},
+		{DST: &DSTRule{UTCOffset: "+02:00", Start: DSTChange{Month: 13, Week: 1, At: "02:00"}, End: DSTChange{Month: 10, Week: 5, At: "02:00"}}},
+		{DST: &DSTRule{UTCOffset: "+02:00", Start: DSTChange{Month: 3, Week: 6, At: "02:00"}, End: DSTChange{Month: 10, Week: 5, At: "02:00"}}},
+		{DST: &DSTRule{UTCOffset: "+02:00", Start: DSTChange{Month: 3, Week: 5, At: "2am"}, End: DSTChange{Month: 10, Week: 5, At: "02:00"}}},
+		{DST: &DSTRule{Start: DSTChange{Month: 3, Week: 5, At: "02:00"}, End: DSTChange{Month: 10, Week: 5, At: "02:00"}}},
+	}
+	for _, tariff := range bad {
+		if tariff.validateZone() == nil {
+			t.Errorf("expected %+v to be rejected", tariff)
+		}
+	}
+	if err := cetTariff.validateZone(); err != nil {
+		t.Errorf("expected the central European tariff to be valid: %s", err)
+	}
+}
+
+// A stub whose caller may hold the parking authority role
+type roleStub struct {
+	*shim.MockStub
+	role string
+}
+
+func (stub *roleStub) VerifyAttribute(attributeName string, attributeValue []byte) (bool, error) {
+	return attributeName == "role" && string(attributeValue) == stub.role, nil
+}
+
+func TestTariffsNeedParkingAuthority(t *testing.T) {
+	cc := new(DeviceUsageChaincode)
+	tariffJSON, _ := json.Marshal(cetTariff)
+	assignmentJSON := `{"tariffid":"cet","zone":"center"}`
+	for _, role := range []string{"", "driver", PERMITISSUERROLE} {
+		stub := &roleStub{shim.NewMockStub("parking", cc), role}
+		stub.MockTransactionStart("tx1")
+		_, errCreate := cc.createTariff(stub, []string{string(tariffJSON)})
+		_, errAssign := cc.assignTariff(stub, []string{assignmentJSON})
+		stub.MockTransactionEnd("tx1")
+		if role == PERMITISSUERROLE {
+			if errCreate != nil || errAssign != nil {
+				t.Errorf("expected the parking authority to create and assign tariffs, got %v and %v", errCreate, errAssign)
+			}
+			if string(stub.State[ZONEKEY+"_center"]) != "cet" {
+				t.Errorf("expected zone center to have tariff cet")
+			}
+			continue
+		}
+		if errCreate == nil || errAssign == nil {
+			t.Errorf("expected role %q to be refused, got %v and %v", role, errCreate, errAssign)
+		}
+		if len(stub.State) != 0 {
+			t.Errorf("expected role %q to write nothing, got %d keys", role, len(stub.State))
+		}
+	}
+}
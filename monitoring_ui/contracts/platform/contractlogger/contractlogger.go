@@ -0,0 +1,457 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+// v1 KL 15 Mar 2016 Created to allow us to insulate from logger versions
+//                   and to provide an efficient method of changing levels
+//                   from outside and to allow the level switching to actually work
+// v2 KL 19 Oct 2016 JSON lines carrying the transaction, per module levels
+//                   and redaction of sensitive paths in logged fields
+// v3 KL 24 Oct 2016 Moved out of the individual contracts into one package. It does
+//                   not import the shim, so contracts for fabric 0.5 and 0.6 share it.
+
+// Package contractlogger writes one JSON line per message for the standalone IoT
+// contracts (iot_sample_contract, pingpong, aviation, building, carbon trading and
+// trade lane). Contracts built on the iot contract platform use its own logger.
+package contractlogger
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LogLevel mirrors the levels of the go logger used by the shim
+type LogLevel int
+
+const (
+	// CRITICAL means cannot function
+	CRITICAL LogLevel = iota
+	// ERROR means something is wrong
+	ERROR
+	// WARNING means something might be wrong
+	WARNING
+	// NOTICE means take note, this should be investigated
+	NOTICE
+	// INFO means this happened and might be of interest
+	INFO
+	// DEBUG allows for a peek into the guts of the app for debugging
+	DEBUG
+)
+
+// LogLevelNames are indexed by LogLevel
+var LogLevelNames = []string{
+	"CRITICAL",
+	"ERROR",
+	"WARNING",
+	"NOTICE",
+	"INFO",
+	"DEBUG",
+}
+
+// DEFAULTLOGGINGLEVEL is normally INFO in test and WARNING in production
+const DEFAULTLOGGINGLEVEL = DEBUG
+
+// REDACTED replaces the value of every configured sensitive path in logged fields
+const REDACTED string = "***REDACTED***"
+
+// ContractLogger is our version of goLogger, writing one JSON line per message.
+// The contract's root logger is created with NewContractLogger, and sub-modules
+// (e.g. "alerts" or "rules.maintenance") with NewModuleLogger. A sub-module uses
+// its own level when one has been set, else its parent's, else the root's.
+type ContractLogger struct {
+	module    string
+	submodule string
+	level     LogLevel
+	txID      string
+}
+
+// ILogger the goLogger interface to which we are 100% compatible
+type ILogger interface {
+	Critical(args ...interface{})
+	Criticalf(format string, args ...interface{})
+	Error(args ...interface{})
+	Errorf(format string, args ...interface{})
+	Warning(args ...interface{})
+	Warningf(format string, args ...interface{})
+	Notice(args ...interface{})
+	Noticef(format string, args ...interface{})
+	Info(args ...interface{})
+	Infof(format string, args ...interface{})
+	Debug(args ...interface{})
+	Debugf(format string, args ...interface{})
+}
+
+// LogEntry is one JSON line. The transaction properties are filled in between
+// BeginTransaction and EndTransaction so that lines can be correlated on the peer.
+type LogEntry struct {
+	Timestamp  string                 `json:"ts"`
+	Level      string                 `json:"level"`
+	Module     string                 `json:"module"`
+	Submodule  string                 `json:"submodule,omitempty"`
+	TxID       string                 `json:"txid,omitempty"`
+	Method     string                 `json:"method,omitempty"`
+	Function   string                 `json:"function,omitempty"`
+	Class      string                 `json:"class,omitempty"`
+	AssetKey   string                 `json:"assetkey,omitempty"`
+	DurationMS *float64               `json:"durationms,omitempty"`
+	Message    string                 `json:"msg"`
+	Fields     map[string]interface{} `json:"fields,omitempty"`
+}
+
+type logContext struct {
+	txID     string
+	method   string
+	function string
+	class    string
+	assetKey string
+	start    time.Time
+}
+
+// Queries run in their own goroutine while an invoke may be executing, so the
+// context of every open transaction is kept under its ID.
+var logState = struct {
+	sync.Mutex
+	out    io.Writer
+	root   *ContractLogger
+	levels map[string]LogLevel
+	redact [][]string
+	txns   map[string]*logContext
+}{
+	out:    os.Stdout,
+	levels: make(map[string]LogLevel),
+	txns:   make(map[string]*logContext),
+}
+
+// NewContractLogger creates the root logger for the contract to use, the module
+// is normally the contract's nickname and version
+func NewContractLogger(module string, level LogLevel) *ContractLogger {
+	l := &ContractLogger{module: module, level: level}
+	l.SetLoggingLevel(level)
+	logState.Lock()
+	logState.root = l
+	logState.Unlock()
+	return l
+}
+
+// NewModuleLogger creates a logger for a dot separated sub-module of the contract
+func NewModuleLogger(submodule string) *ContractLogger {
+	return &ContractLogger{submodule: submodule, level: -1}
+}
+
+// ForTransaction returns a logger whose lines always carry the given transaction,
+// for code that has the transaction ID at hand while other transactions are open
+func (cl *ContractLogger) ForTransaction(txID string) *ContractLogger {
+	l := *cl
+	l.txID = txID
+	return &l
+}
+
+//SetLoggingLevel is used to change the logging level while the smart contract is running
+func (cl *ContractLogger) SetLoggingLevel(level LogLevel) {
+	if level < CRITICAL || level > DEBUG {
+		level = DEFAULTLOGGINGLEVEL
+	}
+	if cl.submodule != "" {
+		SetModuleLoggingLevel(cl.submodule, level)
+		return
+	}
+	logState.Lock()
+	cl.level = level
+	logState.Unlock()
+}
+
+// SetModule renames the root module, e.g. when the contract is deployed with a nickname
+func (cl *ContractLogger) SetModule(module string) {
+	logState.Lock()
+	cl.module = module
+	logState.Unlock()
+}
+
+// SetModuleLoggingLevel sets the level for a sub-module and all of its children
+func SetModuleLoggingLevel(submodule string, level LogLevel) {
+	logState.Lock()
+	defer logState.Unlock()
+	logState.levels[submodule] = level
+}
+
+// SetRedactedPaths replaces the list of qualified property paths (e.g. "owner.name")
+// whose values are masked wherever they appear inside logged fields
+func SetRedactedPaths(paths []string) {
+	logState.Lock()
+	defer logState.Unlock()
+	logState.redact = make([][]string, 0, len(paths))
+	for _, p := range paths {
+		if p != "" {
+			logState.redact = append(logState.redact, strings.Split(p, "."))
+		}
+	}
+}
+
+// SetLogOutput redirects the JSON lines for all loggers, which are written to stdout by default
+func SetLogOutput(w io.Writer) {
+	logState.Lock()
+	defer logState.Unlock()
+	logState.out = w
+}
+
+// levelFor walks up the sub-module hierarchy to the root, caller holds the lock
+func (cl *ContractLogger) levelFor() LogLevel {
+	for m := cl.submodule; m != ""; {
+		if l, found := logState.levels[m]; found {
+			return l
+		}
+		i := strings.LastIndex(m, ".")
+		if i < 0 {
+			break
+		}
+		m = m[:i]
+	}
+	if cl.level >= CRITICAL {
+		return cl.level
+	}
+	if logState.root != nil {
+		return logState.root.level
+	}
+	return DEFAULTLOGGINGLEVEL
+}
+
+// contextFor finds the transaction for a line, caller holds the lock. A logger that
+// is not bound to a transaction can only be attributed while exactly one is open.
+func (cl *ContractLogger) contextFor() *logContext {
+	if cl.txID != "" {
+		return logState.txns[cl.txID]
+	}
+	if len(logState.txns) == 1 {
+		for _, t := range logState.txns {
+			return t
+		}
+	}
+	return nil
+}
+
+// BeginTransaction is called as Invoke or Query starts executing. The class is
+// taken from the function name (e.g. createAssetAircraft).
+func BeginTransaction(txID string, method string, function string) {
+	t := &logContext{
+		txID:     txID,
+		method:   method,
+		function: function,
+		start:    time.Now(),
+	}
+	if i := strings.Index(function, "Asset"); i >= 0 {
+		class := strings.TrimPrefix(function[i+len("Asset"):], "s")
+		if class != "" && strings.ToUpper(class[:1]) == class[:1] {
+			t.class = strings.ToLower(class[:1]) + class[1:]
+		}
+	}
+	logState.Lock()
+	logState.txns[txID] = t
+	logState.Unlock()
+}
+
+// SetTransactionAsset records the asset on which a transaction is operating, called
+// once the contract has found the assetID in its argument
+func SetTransactionAsset(txID string, assetKey string) {
+	logState.Lock()
+	defer logState.Unlock()
+	if t, found := logState.txns[txID]; found {
+		t.assetKey = assetKey
+	}
+}
+
+// EndTransaction writes the closing line for the transaction with its duration
+func EndTransaction(txID string) {
+	logState.Lock()
+	t, root := logState.txns[txID], logState.root
+	logState.Unlock()
+	if t == nil {
+		return
+	}
+	if root != nil {
+		d := time.Since(t.start)
+		root.ForTransaction(txID).write(INFO, t.method+" complete", nil, &d)
+	}
+	logState.Lock()
+	delete(logState.txns, txID)
+	logState.Unlock()
+}
+
+//*************
+// JSON logger
+//*************
+
+func (cl *ContractLogger) write(level LogLevel, msg string, fields map[string]interface{}, duration *time.Duration) {
+	logState.Lock()
+	defer logState.Unlock()
+	if level > cl.levelFor() {
+		return
+	}
+	e := LogEntry{
+		Timestamp: time.Now().UTC().Format(time.RFC3339Nano),
+		Level:     LogLevelNames[level],
+		Module:    cl.module,
+		Submodule: cl.submodule,
+		Message:   strings.TrimSuffix(msg, "\n"),
+	}
+	if e.Module == "" && logState.root != nil {
+		e.Module = logState.root.module
+	}
+	if t := cl.contextFor(); t != nil {
+		e.TxID, e.Method, e.Function, e.Class, e.AssetKey = t.txID, t.method, t.function, t.class, t.assetKey
+	}
+	if duration != nil {
+		ms := float64(*duration) / float64(time.Millisecond)
+		e.DurationMS = &ms
+	}
+	if len(fields) > 0 {
+		e.Fields = make(map[string]interface{}, len(fields))
+		for k, v := range fields {
+			e.Fields[k] = redactValue(v)
+		}
+	}
+	line, err := json.Marshal(e)
+	if err != nil {
+		line, _ = json.Marshal(LogEntry{Timestamp: e.Timestamp, Level: e.Level, Module: e.Module, TxID: e.TxID,
+			Message: fmt.Sprintf("%s (fields could not be marshaled: %s)", e.Message, err)})
+	}
+	logState.out.Write(append(line, '\n'))
+}
+
+// redactValue normalizes a field value through JSON so that structs and maps are
+// treated alike, and masks every configured path found at any depth
+func redactValue(v interface{}) interface{} {
+	if len(logState.redact) == 0 {
+		return v
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	var generic interface{}
+	if err = json.Unmarshal(b, &generic); err != nil {
+		return v
+	}
+	return redactWalk(generic)
+}
+
+func redactWalk(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for _, path := range logState.redact {
+			redactPath(t, path)
+		}
+		for k, child := range t {
+			t[k] = redactWalk(child)
+		}
+	case []interface{}:
+		for i, child := range t {
+			t[i] = redactWalk(child)
+		}
+	}
+	return v
+}
+
+func redactPath(m map[string]interface{}, path []string) {
+	for i, segment := range path {
+		child, found := m[segment]
+		if !found {
+			return
+		}
+		if i == len(path)-1 {
+			m[segment] = REDACTED
+			return
+		}
+		next, ok := child.(map[string]interface{})
+		if !ok {
+			return
+		}
+		m = next
+	}
+}
+
+// LogFields writes a line with structured fields, which are redacted before marshaling
+func (cl *ContractLogger) LogFields(level LogLevel, msg string, fields map[string]interface{}) {
+	cl.write(level, msg, fields, nil)
+}
+
+// DebugFields writes a line with structured fields at DEBUG level
+func (cl *ContractLogger) DebugFields(msg string, fields map[string]interface{}) {
+	cl.write(DEBUG, msg, fields, nil)
+}
+
+// Critical logs a message using CRITICAL as log level.
+func (cl *ContractLogger) Critical(args ...interface{}) {
+	cl.write(CRITICAL, fmt.Sprint(args...), nil, nil)
+}
+
+// Criticalf logs a message using CRITICAL as log level.
+func (cl *ContractLogger) Criticalf(format string, args ...interface{}) {
+	cl.write(CRITICAL, fmt.Sprintf(format, args...), nil, nil)
+}
+
+// Error logs a message using ERROR as log level.
+func (cl *ContractLogger) Error(args ...interface{}) {
+	cl.write(ERROR, fmt.Sprint(args...), nil, nil)
+}
+
+// Errorf logs a message using ERROR as log level.
+func (cl *ContractLogger) Errorf(format string, args ...interface{}) {
+	cl.write(ERROR, fmt.Sprintf(format, args...), nil, nil)
+}
+
+// Warning logs a message using WARNING as log level.
+func (cl *ContractLogger) Warning(args ...interface{}) {
+	cl.write(WARNING, fmt.Sprint(args...), nil, nil)
+}
+
+// Warningf logs a message using WARNING as log level.
+func (cl *ContractLogger) Warningf(format string, args ...interface{}) {
+	cl.write(WARNING, fmt.Sprintf(format, args...), nil, nil)
+}
+
+// Notice logs a message using NOTICE as log level.
+func (cl *ContractLogger) Notice(args ...interface{}) {
+	cl.write(NOTICE, fmt.Sprint(args...), nil, nil)
+}
+
+// Noticef logs a message using NOTICE as log level.
+func (cl *ContractLogger) Noticef(format string, args ...interface{}) {
+	cl.write(NOTICE, fmt.Sprintf(format, args...), nil, nil)
+}
+
+// Info logs a message using INFO as log level.
+func (cl *ContractLogger) Info(args ...interface{}) {
+	cl.write(INFO, fmt.Sprint(args...), nil, nil)
+}
+
+// Infof logs a message using INFO as log level.
+func (cl *ContractLogger) Infof(format string, args ...interface{}) {
+	cl.write(INFO, fmt.Sprintf(format, args...), nil, nil)
+}
+
+// Debug logs a message using DEBUG as log level.
+func (cl *ContractLogger) Debug(args ...interface{}) {
+	cl.write(DEBUG, fmt.Sprint(args...), nil, nil)
+}
+
+// Debugf logs a message using DEBUG as log level.
+func (cl *ContractLogger) Debugf(format string, args ...interface{}) {
+	cl.write(DEBUG, fmt.Sprintf(format, args...), nil, nil)
+}
@@ -0,0 +1,141 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+package contractlogger
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+)
+
+var log = NewContractLogger("test-1.0", DEFAULTLOGGINGLEVEL)
+
+func captureLog(t *testing.T, fn func()) []LogEntry {
+	var buf bytes.Buffer
+	logState.Lock()
+	logState.out = &buf
+	logState.Unlock()
+	defer func() {
+		logState.Lock()
+		logState.out = os.Stdout
+		logState.levels = make(map[string]LogLevel)
+		logState.redact = nil
+		logState.txns = make(map[string]*logContext)
+		logState.Unlock()
+		log.SetLoggingLevel(DEFAULTLOGGINGLEVEL)
+	}()
+	fn()
+	var entries []LogEntry
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var e LogEntry
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatalf("log line is not JSON: %s", line)
+		}
+		entries = append(entries, e)
+	}
+	return entries
+}
+
+func TestLoggerTransactionContext(t *testing.T) {
+	entries := captureLog(t, func() {
+		BeginTransaction("tx1", "invoke", "updateAsset")
+		SetTransactionAsset("tx1", "A1")
+		log.Infof("updating %s", "A1")
+		EndTransaction("tx1")
+		log.Info("outside")
+	})
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 lines, got %+v", entries)
+	}
+	e := entries[0]
+	if e.TxID != "tx1" || e.Method != "invoke" || e.Function != "updateAsset" || e.AssetKey != "A1" || e.Level != "INFO" || e.Message != "updating A1" {
+		t.Fatalf("unexpected context on first line: %+v", e)
+	}
+	if entries[1].DurationMS == nil || entries[1].Message != "invoke complete" {
+		t.Fatalf("closing line should carry the duration: %+v", entries[1])
+	}
+	if entries[2].TxID != "" {
+		t.Fatalf("context should end with the transaction: %+v", entries[2])
+	}
+}
+
+func TestLoggerOverlappingTransactions(t *testing.T) {
+	entries := captureLog(t, func() {
+		BeginTransaction("tx1", "invoke", "createAssetAircraft")
+		SetTransactionAsset("tx1", "A1")
+		BeginTransaction("tx2", "query", "readAssetAircraft")
+		log.Info("ambiguous")
+		log.ForTransaction("tx2").Info("reading")
+		EndTransaction("tx2")
+		log.Info("only tx1 is open")
+		EndTransaction("tx1")
+	})
+	if len(entries) != 5 {
+		t.Fatalf("expected 5 lines, got %+v", entries)
+	}
+	if entries[0].TxID != "" {
+		t.Fatalf("a line written while two transactions are open must not guess: %+v", entries[0])
+	}
+	if e := entries[1]; e.TxID != "tx2" || e.Method != "query" || e.Class != "aircraft" || e.AssetKey != "" {
+		t.Fatalf("bound line should carry the query: %+v", e)
+	}
+	if e := entries[2]; e.TxID != "tx2" || e.Message != "query complete" || e.DurationMS == nil {
+		t.Fatalf("closing line should belong to the query: %+v", e)
+	}
+	if e := entries[3]; e.TxID != "tx1" || e.AssetKey != "A1" {
+		t.Fatalf("ending the query must leave the invoke open: %+v", e)
+	}
+	if e := entries[4]; e.TxID != "tx1" || e.Message != "invoke complete" {
+		t.Fatalf("closing line should belong to the invoke: %+v", e)
+	}
+}
+
+func TestLoggerModuleLevels(t *testing.T) {
+	rules := NewModuleLogger("rules")
+	threshold := NewModuleLogger("rules.threshold")
+	entries := captureLog(t, func() {
+		log.SetLoggingLevel(WARNING)
+		SetModuleLoggingLevel("rules", DEBUG)
+		log.Info("root suppressed")
+		rules.Debug("rules written")
+		threshold.Debug("inherits rules")
+		SetModuleLoggingLevel("rules.threshold", ERROR)
+		threshold.Warning("threshold suppressed")
+	})
+	if len(entries) != 2 || entries[0].Submodule != "rules" || entries[1].Submodule != "rules.threshold" {
+		t.Fatalf("unexpected lines for module levels: %+v", entries)
+	}
+}
+
+func TestLoggerRedaction(t *testing.T) {
+	entries := captureLog(t, func() {
+		SetRedactedPaths([]string{"owner.name"})
+		log.DebugFields("asset", map[string]interface{}{
+			"asset": map[string]interface{}{"assetID": "A1", "owner": map[string]interface{}{"name": "Jane", "id": 7}},
+		})
+	})
+	asset := entries[0].Fields["asset"].(map[string]interface{})
+	owner := asset["owner"].(map[string]interface{})
+	if owner["name"] != REDACTED || owner["id"] != float64(7) || asset["assetID"] != "A1" {
+		t.Fatalf("expected only owner.name to be redacted, got %+v", asset)
+	}
+}
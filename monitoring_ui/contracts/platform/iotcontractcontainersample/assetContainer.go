@@ -23,70 +23,9 @@ import (
 	iot "github.com/ibm-watson-iot/blockchain-samples/contracts/platform/iotcontractplatform"
 )
 
-// ContainerClass acts as the class of all containers
-var ContainerClass = iot.AssetClass{
-	Name:        "container",
-	Prefix:      "CON",
-	AssetIDPath: "container.barcode",
-}
-
-func newContainer() iot.Asset {
-	return iot.Asset{
-		Class:        ContainerClass,
-		AssetKey:     "",
-		State:        nil,
-		EventIn:      nil,
-		TXNID:        "",
-		TXNTS:        nil,
-		EventOut:     nil,
-		AlertsActive: nil,
-		Compliant:    true,
-	}
-}
-
-var createAssetContainer iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return ContainerClass.CreateAsset(stub, args, "createAssetContainer", []iot.QPropNV{})
-}
-
-var replaceAssetContainer iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return ContainerClass.ReplaceAsset(stub, args, "replaceAssetContainer", []iot.QPropNV{})
-}
-
-var updateAssetContainer iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return ContainerClass.UpdateAsset(stub, args, "updateAssetContainer", []iot.QPropNV{})
-}
-
-var deleteAssetContainer iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return ContainerClass.DeleteAsset(stub, args)
-}
-
-var deleteAssetStateHistoryContainer iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return ContainerClass.DeleteAssetStateHistory(stub, args)
-}
-
-var deleteAllAssetsContainer iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return ContainerClass.DeleteAllAssets(stub, args)
-}
-
-var deletePropertiesFromAssetContainer iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return ContainerClass.DeletePropertiesFromAsset(stub, args, "deletePropertiesFromAssetContainer", []iot.QPropNV{})
-}
-
-var readAssetContainer iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return ContainerClass.ReadAsset(stub, args)
-}
-
-var readAllAssetsContainer iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return ContainerClass.ReadAllAssets(stub, args)
-}
-
-var readAssetStateHistoryContainer = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return ContainerClass.ReadAssetStateHistory(stub, args)
-}
-
 var overtempAlert iot.AlertName = "OVERTEMP"
 var overtempRule iot.RuleFunc = func(stub shim.ChaincodeStubInterface, container *iot.Asset) error {
-	temp, found := iot.GetObjectAsNumber(container.State, "container.temperature")
+	temp, found := getContainerTemperature(container)
 	if found {
 		if temp > 0 {
 			iot.RaiseAlert(container, overtempAlert)
@@ -99,14 +38,4 @@ var overtempRule iot.RuleFunc = func(stub shim.ChaincodeStubInterface, container
 
 func init() {
 	iot.AddRule("Over Temperature Alert", ContainerClass, []iot.AlertName{overtempAlert}, overtempRule)
-	iot.AddRoute("createAssetContainer", "invoke", ContainerClass, createAssetContainer)
-	iot.AddRoute("replaceAssetContainer", "invoke", ContainerClass, replaceAssetContainer)
-	iot.AddRoute("updateAssetContainer", "invoke", ContainerClass, updateAssetContainer)
-	iot.AddRoute("deleteAssetContainer", "invoke", ContainerClass, deleteAssetContainer)
-	iot.AddRoute("deleteAssetStateHistoryContainer", "invoke", ContainerClass, deleteAssetStateHistoryContainer)
-	iot.AddRoute("deleteAllAssetsContainer", "invoke", ContainerClass, deleteAllAssetsContainer)
-	iot.AddRoute("deletePropertiesFromAssetContainer", "invoke", ContainerClass, deletePropertiesFromAssetContainer)
-	iot.AddRoute("readAssetContainer", "query", ContainerClass, readAssetContainer)
-	iot.AddRoute("readAssetStateHistoryContainer", "query", ContainerClass, readAssetStateHistoryContainer)
-	iot.AddRoute("readAllAssetsContainer", "query", ContainerClass, readAllAssetsContainer)
 }
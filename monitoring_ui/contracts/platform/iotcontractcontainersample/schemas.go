@@ -364,7 +364,252 @@ var schemas = `
                     "items": {
                         "patternProperties": {
                             "^CON": {
-                                "description": "The external state of one container asset, named by its world state ID",
+                                "description": "A container's complete state",
+                                "properties": {
+                                    "AssetKey": {
+                                        "description": "This container's world state container ID",
+                                        "type": "string"
+                                    },
+                                    "alerts": {
+                                        "description": "An array of alert names",
+                                        "items": {
+                                            "description": "An alert name",
+                                            "type": "string"
+                                        },
+                                        "type": "array"
+                                    },
+                                    "assetIDpath": {
+                                        "description": "Qualified property path to the container's ID, declared in the contract code",
+                                        "type": "string"
+                                    },
+                                    "class": {
+                                        "description": "The container's asset class",
+                                        "type": "string"
+                                    },
+                                    "compliant": {
+                                        "description": "This container has no active alerts",
+                                        "type": "boolean"
+                                    },
+                                    "eventin": {
+                                        "description": "The contract event that created this state, for example updateAssetContainer",
+                                        "properties": {
+                                            "container": {
+                                                "description": "The changeable properties for a container, also considered its 'event' as a partial state",
+                                                "properties": {
+                                                    "barcode": {
+                                                        "description": "A container's ID",
+                                                        "type": "string"
+                                                    },
+                                                    "carrier": {
+                                                        "description": "The carrier in possession of this container",
+                                                        "type": "string"
+                                                    },
+                                                    "common": {
+                                                        "description": "Common properties for all assets",
+                                                        "properties": {
+                                                            "appdata": {
+                                                                "description": "Application managed information as an array of key:value pairs",
+                                                                "items": {
+                                                                    "properties": {
+                                                                        "K": {
+                                                                            "type": "string"
+                                                                        },
+                                                                        "V": {
+                                                                            "type": "string"
+                                                                        }
+                                                                    },
+                                                                    "type": "object"
+                                                                },
+                                                                "minItems": 0,
+                                                                "type": "array"
+                                                            },
+                                                            "deviceID": {
+                                                                "description": "A unique identifier for the device that sent the current event",
+                                                                "type": "string"
+                                                            },
+                                                            "devicetimestamp": {
+                                                                "description": "A timestamp recoded by the device that sent the current event",
+                                                                "type": "string"
+                                                            },
+                                                            "location": {
+                                                                "description": "A geographical coordinate",
+                                                                "properties": {
+                                                                    "latitude": {
+                                                                        "type": "number"
+                                                                    },
+                                                                    "longitude": {
+                                                                        "type": "number"
+                                                                    }
+                                                                },
+                                                                "type": "object"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    },
+                                                    "temperature": {
+                                                        "description": "Temperature of a container's contents in degrees Celsuis",
+                                                        "type": "number"
+                                                    }
+                                                },
+                                                "required": [
+                                                    "barcode"
+                                                ],
+                                                "type": "object"
+                                            }
+                                        },
+                                        "type": "object"
+                                    },
+                                    "eventout": {
+                                        "description": "The chaincode event emitted on invoke exit, if any",
+                                        "properties": {
+                                            "container": {
+                                                "description": "A chaincode event defining the standard platform-generated result event for a contract invoke, contains an array of contributed results",
+                                                "properties": {
+                                                    "name": {
+                                                        "default": "EVT.IOTCP.INVOKE.RESULT",
+                                                        "enum": [
+                                                            "EVT.IOTCP.INVOKE.RESULT"
+                                                        ],
+                                                        "type": "string"
+                                                    },
+                                                    "payload": {
+                                                        "description": "A map of contributed results",
+                                                        "properties": {
+                                                            "description": "the overall status of the invoke result, defined by err",
+                                                            "properties": {
+                                                                "activeAlerts": {
+                                                                    "description": "An array of alert names",
+                                                                    "items": {
+                                                                        "description": "An alert name",
+                                                                        "type": "string"
+                                                                    },
+                                                                    "type": "array"
+                                                                },
+                                                                "alertsCleared": {
+                                                                    "description": "An array of alert names",
+                                                                    "items": {
+                                                                        "description": "An alert name",
+                                                                        "type": "string"
+                                                                    },
+                                                                    "type": "array"
+                                                                },
+                                                                "alertsRaised": {
+                                                                    "description": "An array of alert names",
+                                                                    "items": {
+                                                                        "description": "An alert name",
+                                                                        "type": "string"
+                                                                    },
+                                                                    "type": "array"
+                                                                },
+                                                                "invokeresult": {
+                                                                    "description": "status: OK==txn succeeded, ERROR==txn failed",
+                                                                    "properties": {
+                                                                        "message": {
+                                                                            "type": "string"
+                                                                        },
+                                                                        "status": {
+                                                                            "enum": [
+                                                                                "OK",
+                                                                                "ERROR"
+                                                                            ],
+                                                                            "type": "string"
+                                                                        }
+                                                                    },
+                                                                    "type": "object"
+                                                                }
+                                                            },
+                                                            "type": "object"
+                                                        },
+                                                        "type": "object"
+                                                    }
+                                                },
+                                                "type": "object"
+                                            }
+                                        },
+                                        "type": "object"
+                                    },
+                                    "prefix": {
+                                        "description": "The container's asset class prefix in world state",
+                                        "type": "string"
+                                    },
+                                    "state": {
+                                        "description": "Properties that have been received or calculated for this container",
+                                        "properties": {
+                                            "container": {
+                                                "description": "The changeable properties for a container, also considered its 'event' as a partial state",
+                                                "properties": {
+                                                    "barcode": {
+                                                        "description": "A container's ID",
+                                                        "type": "string"
+                                                    },
+                                                    "carrier": {
+                                                        "description": "The carrier in possession of this container",
+                                                        "type": "string"
+                                                    },
+                                                    "common": {
+                                                        "description": "Common properties for all assets",
+                                                        "properties": {
+                                                            "appdata": {
+                                                                "description": "Application managed information as an array of key:value pairs",
+                                                                "items": {
+                                                                    "properties": {
+                                                                        "K": {
+                                                                            "type": "string"
+                                                                        },
+                                                                        "V": {
+                                                                            "type": "string"
+                                                                        }
+                                                                    },
+                                                                    "type": "object"
+                                                                },
+                                                                "minItems": 0,
+                                                                "type": "array"
+                                                            },
+                                                            "deviceID": {
+                                                                "description": "A unique identifier for the device that sent the current event",
+                                                                "type": "string"
+                                                            },
+                                                            "devicetimestamp": {
+                                                                "description": "A timestamp recoded by the device that sent the current event",
+                                                                "type": "string"
+                                                            },
+                                                            "location": {
+                                                                "description": "A geographical coordinate",
+                                                                "properties": {
+                                                                    "latitude": {
+                                                                        "type": "number"
+                                                                    },
+                                                                    "longitude": {
+                                                                        "type": "number"
+                                                                    }
+                                                                },
+                                                                "type": "object"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    },
+                                                    "temperature": {
+                                                        "description": "Temperature of a container's contents in degrees Celsuis",
+                                                        "type": "number"
+                                                    }
+                                                },
+                                                "required": [
+                                                    "barcode"
+                                                ],
+                                                "type": "object"
+                                            }
+                                        },
+                                        "type": "object"
+                                    },
+                                    "txnid": {
+                                        "description": "Transaction UUID matching the blockchain",
+                                        "type": "string"
+                                    },
+                                    "txnts": {
+                                        "description": "Transaction timestamp matching the blockchain",
+                                        "type": "string"
+                                    }
+                                },
                                 "type": "object"
                             }
                         },
@@ -409,13 +654,304 @@ var schemas = `
                             "functionname": {
                                 "type": "string"
                             },
-                            "method": {
-                                "type": "string"
-                            }
+                            "method": {
+                                "type": "string"
+                            }
+                        },
+                        "type": "object"
+                    },
+                    "minItems": 0,
+                    "type": "object"
+                }
+            },
+            "type": "object"
+        },
+        "readAssetAsOfContainer": {
+            "description": "Returns a container as it was at a point in time, rebuilt from its history",
+            "properties": {
+                "args": {
+                    "items": {
+                        "properties": {
+                            "asof": {
+                                "description": "the point in time, the latest state written at or before it is returned",
+                                "format": "date-time",
+                                "sample": "yyyy-mm-ddThh:mm:ssZ",
+                                "type": "string"
+                            },
+                            "container": {
+                                "properties": {
+                                    "barcode": {
+                                        "description": "A container's ID",
+                                        "type": "string"
+                                    }
+                                },
+                                "type": "object"
+                            }
+                        },
+                        "required": [
+                            "container",
+                            "asof"
+                        ],
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
+                    "type": "array"
+                },
+                "function": {
+                    "enum": [
+                        "readAssetAsOfContainer"
+                    ],
+                    "type": "string"
+                },
+                "method": "query",
+                "result": {
+                    "description": "A container's complete state",
+                    "properties": {
+                        "AssetKey": {
+                            "description": "This container's world state container ID",
+                            "type": "string"
+                        },
+                        "alerts": {
+                            "description": "An array of alert names",
+                            "items": {
+                                "description": "An alert name",
+                                "type": "string"
+                            },
+                            "type": "array"
+                        },
+                        "assetIDpath": {
+                            "description": "Qualified property path to the container's ID, declared in the contract code",
+                            "type": "string"
+                        },
+                        "class": {
+                            "description": "The container's asset class",
+                            "type": "string"
+                        },
+                        "compliant": {
+                            "description": "This container has no active alerts",
+                            "type": "boolean"
+                        },
+                        "eventin": {
+                            "description": "The contract event that created this state, for example updateAssetContainer",
+                            "properties": {
+                                "container": {
+                                    "description": "The changeable properties for a container, also considered its 'event' as a partial state",
+                                    "properties": {
+                                        "barcode": {
+                                            "description": "A container's ID",
+                                            "type": "string"
+                                        },
+                                        "carrier": {
+                                            "description": "The carrier in possession of this container",
+                                            "type": "string"
+                                        },
+                                        "common": {
+                                            "description": "Common properties for all assets",
+                                            "properties": {
+                                                "appdata": {
+                                                    "description": "Application managed information as an array of key:value pairs",
+                                                    "items": {
+                                                        "properties": {
+                                                            "K": {
+                                                                "type": "string"
+                                                            },
+                                                            "V": {
+                                                                "type": "string"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    },
+                                                    "minItems": 0,
+                                                    "type": "array"
+                                                },
+                                                "deviceID": {
+                                                    "description": "A unique identifier for the device that sent the current event",
+                                                    "type": "string"
+                                                },
+                                                "devicetimestamp": {
+                                                    "description": "A timestamp recoded by the device that sent the current event",
+                                                    "type": "string"
+                                                },
+                                                "location": {
+                                                    "description": "A geographical coordinate",
+                                                    "properties": {
+                                                        "latitude": {
+                                                            "type": "number"
+                                                        },
+                                                        "longitude": {
+                                                            "type": "number"
+                                                        }
+                                                    },
+                                                    "type": "object"
+                                                }
+                                            },
+                                            "type": "object"
+                                        },
+                                        "temperature": {
+                                            "description": "Temperature of a container's contents in degrees Celsuis",
+                                            "type": "number"
+                                        }
+                                    },
+                                    "required": [
+                                        "barcode"
+                                    ],
+                                    "type": "object"
+                                }
+                            },
+                            "type": "object"
+                        },
+                        "eventout": {
+                            "description": "The chaincode event emitted on invoke exit, if any",
+                            "properties": {
+                                "container": {
+                                    "description": "A chaincode event defining the standard platform-generated result event for a contract invoke, contains an array of contributed results",
+                                    "properties": {
+                                        "name": {
+                                            "default": "EVT.IOTCP.INVOKE.RESULT",
+                                            "enum": [
+                                                "EVT.IOTCP.INVOKE.RESULT"
+                                            ],
+                                            "type": "string"
+                                        },
+                                        "payload": {
+                                            "description": "A map of contributed results",
+                                            "properties": {
+                                                "description": "the overall status of the invoke result, defined by err",
+                                                "properties": {
+                                                    "activeAlerts": {
+                                                        "description": "An array of alert names",
+                                                        "items": {
+                                                            "description": "An alert name",
+                                                            "type": "string"
+                                                        },
+                                                        "type": "array"
+                                                    },
+                                                    "alertsCleared": {
+                                                        "description": "An array of alert names",
+                                                        "items": {
+                                                            "description": "An alert name",
+                                                            "type": "string"
+                                                        },
+                                                        "type": "array"
+                                                    },
+                                                    "alertsRaised": {
+                                                        "description": "An array of alert names",
+                                                        "items": {
+                                                            "description": "An alert name",
+                                                            "type": "string"
+                                                        },
+                                                        "type": "array"
+                                                    },
+                                                    "invokeresult": {
+                                                        "description": "status: OK==txn succeeded, ERROR==txn failed",
+                                                        "properties": {
+                                                            "message": {
+                                                                "type": "string"
+                                                            },
+                                                            "status": {
+                                                                "enum": [
+                                                                    "OK",
+                                                                    "ERROR"
+                                                                ],
+                                                                "type": "string"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    }
+                                                },
+                                                "type": "object"
+                                            },
+                                            "type": "object"
+                                        }
+                                    },
+                                    "type": "object"
+                                }
+                            },
+                            "type": "object"
+                        },
+                        "prefix": {
+                            "description": "The container's asset class prefix in world state",
+                            "type": "string"
+                        },
+                        "state": {
+                            "description": "Properties that have been received or calculated for this container",
+                            "properties": {
+                                "container": {
+                                    "description": "The changeable properties for a container, also considered its 'event' as a partial state",
+                                    "properties": {
+                                        "barcode": {
+                                            "description": "A container's ID",
+                                            "type": "string"
+                                        },
+                                        "carrier": {
+                                            "description": "The carrier in possession of this container",
+                                            "type": "string"
+                                        },
+                                        "common": {
+                                            "description": "Common properties for all assets",
+                                            "properties": {
+                                                "appdata": {
+                                                    "description": "Application managed information as an array of key:value pairs",
+                                                    "items": {
+                                                        "properties": {
+                                                            "K": {
+                                                                "type": "string"
+                                                            },
+                                                            "V": {
+                                                                "type": "string"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    },
+                                                    "minItems": 0,
+                                                    "type": "array"
+                                                },
+                                                "deviceID": {
+                                                    "description": "A unique identifier for the device that sent the current event",
+                                                    "type": "string"
+                                                },
+                                                "devicetimestamp": {
+                                                    "description": "A timestamp recoded by the device that sent the current event",
+                                                    "type": "string"
+                                                },
+                                                "location": {
+                                                    "description": "A geographical coordinate",
+                                                    "properties": {
+                                                        "latitude": {
+                                                            "type": "number"
+                                                        },
+                                                        "longitude": {
+                                                            "type": "number"
+                                                        }
+                                                    },
+                                                    "type": "object"
+                                                }
+                                            },
+                                            "type": "object"
+                                        },
+                                        "temperature": {
+                                            "description": "Temperature of a container's contents in degrees Celsuis",
+                                            "type": "number"
+                                        }
+                                    },
+                                    "required": [
+                                        "barcode"
+                                    ],
+                                    "type": "object"
+                                }
+                            },
+                            "type": "object"
                         },
-                        "type": "object"
+                        "txnid": {
+                            "description": "Transaction UUID matching the blockchain",
+                            "type": "string"
+                        },
+                        "txnts": {
+                            "description": "Transaction timestamp matching the blockchain",
+                            "type": "string"
+                        }
                     },
-                    "minItems": 0,
                     "type": "object"
                 }
             },
@@ -702,6 +1238,134 @@ var schemas = `
             },
             "type": "object"
         },
+        "readAssetStateAggregatesContainer": {
+            "description": "Returns min, max, mean, count, first and last of a numeric container property per time bucket, with the percentage of time spent in each requested alert",
+            "properties": {
+                "args": {
+                    "items": {
+                        "properties": {
+                            "alerts": {
+                                "description": "alert names for which to return the percentage of time active",
+                                "items": {
+                                    "type": "string"
+                                },
+                                "type": "array"
+                            },
+                            "bucket": {
+                                "description": "size of the time buckets, aligned to UTC",
+                                "enum": [
+                                    "minute",
+                                    "hour",
+                                    "day"
+                                ],
+                                "type": "string"
+                            },
+                            "container": {
+                                "properties": {
+                                    "barcode": {
+                                        "description": "A container's ID",
+                                        "type": "string"
+                                    }
+                                },
+                                "type": "object"
+                            },
+                            "daterange": {
+                                "description": "if specified, dates must fall in between these values, inclusive",
+                                "properties": {
+                                    "begin": {
+                                        "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                        "format": "date-time",
+                                        "sample": "yyyy-mm-dd hh:mm:ss",
+                                        "type": "string"
+                                    },
+                                    "end": {
+                                        "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                        "format": "date-time",
+                                        "sample": "yyyy-mm-dd hh:mm:ss",
+                                        "type": "string"
+                                    }
+                                },
+                                "type": "object"
+                            },
+                            "qprop": {
+                                "description": "qualified property name of a numeric property in the asset state",
+                                "sample": "asset.temperature",
+                                "type": "string"
+                            }
+                        },
+                        "required": [
+                            "container",
+                            "bucket"
+                        ],
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
+                    "type": "array"
+                },
+                "function": {
+                    "enum": [
+                        "readAssetStateAggregatesContainer"
+                    ],
+                    "type": "string"
+                },
+                "method": "query",
+                "result": {
+                    "properties": {
+                        "assetkey": {
+                            "type": "string"
+                        },
+                        "bucket": {
+                            "type": "string"
+                        },
+                        "buckets": {
+                            "description": "buckets that hold readings or covered time, oldest first",
+                            "items": {
+                                "properties": {
+                                    "alertPercent": {
+                                        "description": "percentage of the bucket's covered time for which each alert was active",
+                                        "type": "object"
+                                    },
+                                    "begin": {
+                                        "format": "date-time",
+                                        "type": "string"
+                                    },
+                                    "count": {
+                                        "type": "integer"
+                                    },
+                                    "end": {
+                                        "format": "date-time",
+                                        "type": "string"
+                                    },
+                                    "first": {
+                                        "type": "number"
+                                    },
+                                    "last": {
+                                        "type": "number"
+                                    },
+                                    "max": {
+                                        "type": "number"
+                                    },
+                                    "mean": {
+                                        "type": "number"
+                                    },
+                                    "min": {
+                                        "type": "number"
+                                    }
+                                },
+                                "type": "object"
+                            },
+                            "type": "array"
+                        },
+                        "qprop": {
+                            "type": "string"
+                        }
+                    },
+                    "type": "object"
+                }
+            },
+            "type": "object"
+        },
         "readAssetStateHistoryContainer": {
             "description": "Returns history states for a container",
             "properties": {
@@ -790,7 +1454,252 @@ var schemas = `
                     "items": {
                         "patternProperties": {
                             "^CON": {
-                                "description": "The external state of one container asset, named by its world state ID",
+                                "description": "A container's complete state",
+                                "properties": {
+                                    "AssetKey": {
+                                        "description": "This container's world state container ID",
+                                        "type": "string"
+                                    },
+                                    "alerts": {
+                                        "description": "An array of alert names",
+                                        "items": {
+                                            "description": "An alert name",
+                                            "type": "string"
+                                        },
+                                        "type": "array"
+                                    },
+                                    "assetIDpath": {
+                                        "description": "Qualified property path to the container's ID, declared in the contract code",
+                                        "type": "string"
+                                    },
+                                    "class": {
+                                        "description": "The container's asset class",
+                                        "type": "string"
+                                    },
+                                    "compliant": {
+                                        "description": "This container has no active alerts",
+                                        "type": "boolean"
+                                    },
+                                    "eventin": {
+                                        "description": "The contract event that created this state, for example updateAssetContainer",
+                                        "properties": {
+                                            "container": {
+                                                "description": "The changeable properties for a container, also considered its 'event' as a partial state",
+                                                "properties": {
+                                                    "barcode": {
+                                                        "description": "A container's ID",
+                                                        "type": "string"
+                                                    },
+                                                    "carrier": {
+                                                        "description": "The carrier in possession of this container",
+                                                        "type": "string"
+                                                    },
+                                                    "common": {
+                                                        "description": "Common properties for all assets",
+                                                        "properties": {
+                                                            "appdata": {
+                                                                "description": "Application managed information as an array of key:value pairs",
+                                                                "items": {
+                                                                    "properties": {
+                                                                        "K": {
+                                                                            "type": "string"
+                                                                        },
+                                                                        "V": {
+                                                                            "type": "string"
+                                                                        }
+                                                                    },
+                                                                    "type": "object"
+                                                                },
+                                                                "minItems": 0,
+                                                                "type": "array"
+                                                            },
+                                                            "deviceID": {
+                                                                "description": "A unique identifier for the device that sent the current event",
+                                                                "type": "string"
+                                                            },
+                                                            "devicetimestamp": {
+                                                                "description": "A timestamp recoded by the device that sent the current event",
+                                                                "type": "string"
+                                                            },
+                                                            "location": {
+                                                                "description": "A geographical coordinate",
+                                                                "properties": {
+                                                                    "latitude": {
+                                                                        "type": "number"
+                                                                    },
+                                                                    "longitude": {
+                                                                        "type": "number"
+                                                                    }
+                                                                },
+                                                                "type": "object"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    },
+                                                    "temperature": {
+                                                        "description": "Temperature of a container's contents in degrees Celsuis",
+                                                        "type": "number"
+                                                    }
+                                                },
+                                                "required": [
+                                                    "barcode"
+                                                ],
+                                                "type": "object"
+                                            }
+                                        },
+                                        "type": "object"
+                                    },
+                                    "eventout": {
+                                        "description": "The chaincode event emitted on invoke exit, if any",
+                                        "properties": {
+                                            "container": {
+                                                "description": "A chaincode event defining the standard platform-generated result event for a contract invoke, contains an array of contributed results",
+                                                "properties": {
+                                                    "name": {
+                                                        "default": "EVT.IOTCP.INVOKE.RESULT",
+                                                        "enum": [
+                                                            "EVT.IOTCP.INVOKE.RESULT"
+                                                        ],
+                                                        "type": "string"
+                                                    },
+                                                    "payload": {
+                                                        "description": "A map of contributed results",
+                                                        "properties": {
+                                                            "description": "the overall status of the invoke result, defined by err",
+                                                            "properties": {
+                                                                "activeAlerts": {
+                                                                    "description": "An array of alert names",
+                                                                    "items": {
+                                                                        "description": "An alert name",
+                                                                        "type": "string"
+                                                                    },
+                                                                    "type": "array"
+                                                                },
+                                                                "alertsCleared": {
+                                                                    "description": "An array of alert names",
+                                                                    "items": {
+                                                                        "description": "An alert name",
+                                                                        "type": "string"
+                                                                    },
+                                                                    "type": "array"
+                                                                },
+                                                                "alertsRaised": {
+                                                                    "description": "An array of alert names",
+                                                                    "items": {
+                                                                        "description": "An alert name",
+                                                                        "type": "string"
+                                                                    },
+                                                                    "type": "array"
+                                                                },
+                                                                "invokeresult": {
+                                                                    "description": "status: OK==txn succeeded, ERROR==txn failed",
+                                                                    "properties": {
+                                                                        "message": {
+                                                                            "type": "string"
+                                                                        },
+                                                                        "status": {
+                                                                            "enum": [
+                                                                                "OK",
+                                                                                "ERROR"
+                                                                            ],
+                                                                            "type": "string"
+                                                                        }
+                                                                    },
+                                                                    "type": "object"
+                                                                }
+                                                            },
+                                                            "type": "object"
+                                                        },
+                                                        "type": "object"
+                                                    }
+                                                },
+                                                "type": "object"
+                                            }
+                                        },
+                                        "type": "object"
+                                    },
+                                    "prefix": {
+                                        "description": "The container's asset class prefix in world state",
+                                        "type": "string"
+                                    },
+                                    "state": {
+                                        "description": "Properties that have been received or calculated for this container",
+                                        "properties": {
+                                            "container": {
+                                                "description": "The changeable properties for a container, also considered its 'event' as a partial state",
+                                                "properties": {
+                                                    "barcode": {
+                                                        "description": "A container's ID",
+                                                        "type": "string"
+                                                    },
+                                                    "carrier": {
+                                                        "description": "The carrier in possession of this container",
+                                                        "type": "string"
+                                                    },
+                                                    "common": {
+                                                        "description": "Common properties for all assets",
+                                                        "properties": {
+                                                            "appdata": {
+                                                                "description": "Application managed information as an array of key:value pairs",
+                                                                "items": {
+                                                                    "properties": {
+                                                                        "K": {
+                                                                            "type": "string"
+                                                                        },
+                                                                        "V": {
+                                                                            "type": "string"
+                                                                        }
+                                                                    },
+                                                                    "type": "object"
+                                                                },
+                                                                "minItems": 0,
+                                                                "type": "array"
+                                                            },
+                                                            "deviceID": {
+                                                                "description": "A unique identifier for the device that sent the current event",
+                                                                "type": "string"
+                                                            },
+                                                            "devicetimestamp": {
+                                                                "description": "A timestamp recoded by the device that sent the current event",
+                                                                "type": "string"
+                                                            },
+                                                            "location": {
+                                                                "description": "A geographical coordinate",
+                                                                "properties": {
+                                                                    "latitude": {
+                                                                        "type": "number"
+                                                                    },
+                                                                    "longitude": {
+                                                                        "type": "number"
+                                                                    }
+                                                                },
+                                                                "type": "object"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    },
+                                                    "temperature": {
+                                                        "description": "Temperature of a container's contents in degrees Celsuis",
+                                                        "type": "number"
+                                                    }
+                                                },
+                                                "required": [
+                                                    "barcode"
+                                                ],
+                                                "type": "object"
+                                            }
+                                        },
+                                        "type": "object"
+                                    },
+                                    "txnid": {
+                                        "description": "Transaction UUID matching the blockchain",
+                                        "type": "string"
+                                    },
+                                    "txnts": {
+                                        "description": "Transaction timestamp matching the blockchain",
+                                        "type": "string"
+                                    }
+                                },
                                 "type": "object"
                             }
                         },
@@ -1220,6 +2129,45 @@ var schemas = `
             },
             "type": "object"
         },
+        "setHistoryMode": {
+            "description": "Chooses whether asset history is stored as full snapshots or as deltas with periodic snapshots",
+            "properties": {
+                "args": {
+                    "items": {
+                        "properties": {
+                            "mode": {
+                                "description": "snapshots stores the whole asset on every write, events stores only the change",
+                                "enum": [
+                                    "snapshots",
+                                    "events"
+                                ],
+                                "type": "string"
+                            },
+                            "snapshotInterval": {
+                                "description": "in events mode, the number of deltas written between full snapshots, defaults to 10",
+                                "minimum": 0,
+                                "type": "integer"
+                            }
+                        },
+                        "required": [
+                            "mode"
+                        ],
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
+                    "type": "array"
+                },
+                "function": {
+                    "enum": [
+                        "setHistoryMode"
+                    ],
+                    "type": "string"
+                },
+                "method": "invoke"
+            },
+            "type": "object"
+        },
         "setLoggingLevel": {
             "description": "Sets the logging level for the contract",
             "properties": {
@@ -1236,6 +2184,17 @@ var schemas = `
                                     "DEBUG"
                                 ],
                                 "type": "string"
+                            },
+                            "module": {
+                                "description": "Sets the level for this module and its sub-modules only, e.g. iotcontractplatform.filters, all modules are set when omitted",
+                                "type": "string"
+                            },
+                            "redact": {
+                                "description": "Qualified property paths whose values are masked wherever they appear in logged fields, replaces the current list",
+                                "items": {
+                                    "type": "string"
+                                },
+                                "type": "array"
                             }
                         },
                         "type": "object"
@@ -0,0 +1,317 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+// v0.1 KL -- time series aggregates over asset state history, so that charts do not
+//            have to read every state
+
+package iotcontractplatform
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+)
+
+// MAXAGGREGATEBUCKETS is the most buckets that one request can span, about a day of minutes
+const MAXAGGREGATEBUCKETS int = 1500
+
+// bucketSizes maps the supported bucket names to their durations, buckets are aligned to UTC
+var bucketSizes = map[string]time.Duration{
+	"minute": time.Minute,
+	"hour":   time.Hour,
+	"day":    24 * time.Hour,
+}
+
+// AggregatesRequest selects the property, bucket size and alerts to aggregate, it is
+// accompanied by the asset's key and an optional daterange
+type AggregatesRequest struct {
+	QProp  string      `json:"qprop"`
+	Bucket string      `json:"bucket"`
+	Alerts []AlertName `json:"alerts,omitempty"`
+}
+
+// AggregateBucket holds the statistics for the readings taken within one bucket, and the
+// percentage of the bucket's covered time for which each requested alert was active. When
+// alerts are requested, time is covered from the asset's first state until the end of the
+// daterange, or until its last state when the range is open, and never past the time of the
+// transaction.
+type AggregateBucket struct {
+	Begin        time.Time             `json:"begin"`
+	End          time.Time             `json:"end"`
+	Count        int                   `json:"count"`
+	Min          *float64              `json:"min,omitempty"`
+	Max          *float64              `json:"max,omitempty"`
+	Mean         *float64              `json:"mean,omitempty"`
+	First        *float64              `json:"first,omitempty"`
+	Last         *float64              `json:"last,omitempty"`
+	AlertPercent map[AlertName]float64 `json:"alertPercent,omitempty"`
+	sum          float64
+	covered      time.Duration
+	inAlert      map[AlertName]time.Duration
+}
+
+// AssetStateAggregates is the result of readAssetStateAggregates, buckets are oldest first
+type AssetStateAggregates struct {
+	AssetKey string            `json:"assetkey"`
+	QProp    string            `json:"qprop,omitempty"`
+	Bucket   string            `json:"bucket"`
+	Buckets  []AggregateBucket `json:"buckets"`
+}
+
+type aggregateBuckets struct {
+	size    time.Duration
+	alerts  []AlertName
+	buckets map[int64]*AggregateBucket
+}
+
+func (ab *aggregateBuckets) bucket(t time.Time) *AggregateBucket {
+	begin := t.Truncate(ab.size).UTC()
+	b, found := ab.buckets[begin.UnixNano()]
+	if !found {
+		b = &AggregateBucket{
+			Begin:   begin,
+			End:     begin.Add(ab.size),
+			inAlert: make(map[AlertName]time.Duration),
+		}
+		ab.buckets[begin.UnixNano()] = b
+	}
+	return b
+}
+
+func (ab *aggregateBuckets) addReading(t time.Time, v float64) {
+	b := ab.bucket(t)
+	if b.Count == 0 {
+		b.Min, b.Max, b.First = float64Ptr(v), float64Ptr(v), float64Ptr(v)
+	}
+	if v < *b.Min {
+		b.Min = float64Ptr(v)
+	}
+	if v > *b.Max {
+		b.Max = float64Ptr(v)
+	}
+	b.Last = float64Ptr(v)
+	b.sum += v
+	b.Count++
+}
+
+// addInterval spreads the time from begin to end across the buckets it spans, which is
+// only needed for the alert percentages
+func (ab *aggregateBuckets) addInterval(begin time.Time, end time.Time, alerts AlertNameArray) {
+	if len(ab.alerts) == 0 {
+		return
+	}
+	for t := begin; t.Before(end); {
+		b := ab.bucket(t)
+		next := b.End
+		if end.Before(next) {
+			next = end
+		}
+		b.covered += next.Sub(t)
+		for _, alert := range ab.alerts {
+			if alerts.contains(alert) {
+				b.inAlert[alert] += next.Sub(t)
+			}
+		}
+		t = next
+	}
+}
+
+func (ab *aggregateBuckets) sorted() []AggregateBucket {
+	var out = make([]AggregateBucket, 0, len(ab.buckets))
+	for _, b := range ab.buckets {
+		if b.Count > 0 {
+			b.Mean = float64Ptr(b.sum / float64(b.Count))
+		}
+		if len(ab.alerts) > 0 && b.covered > 0 {
+			b.AlertPercent = make(map[AlertName]float64, len(ab.alerts))
+			for _, alert := range ab.alerts {
+				b.AlertPercent[alert] = 100 * float64(b.inAlert[alert]) / float64(b.covered)
+			}
+		}
+		out = append(out, *b)
+	}
+	sort.Sort(byBucketBegin(out))
+	return out
+}
+
+type byBucketBegin []AggregateBucket
+
+func (bb byBucketBegin) Len() int           { return len(bb) }
+func (bb byBucketBegin) Swap(i, j int)      { bb[i], bb[j] = bb[j], bb[i] }
+func (bb byBucketBegin) Less(i, j int) bool { return bb[i].Begin.Before(bb[j].Begin) }
+
+// span returns the number of buckets from the one holding begin to the one holding end
+func (ab *aggregateBuckets) span(begin time.Time, end time.Time) int {
+	return int(end.Truncate(ab.size).Sub(begin.Truncate(ab.size))/ab.size) + 1
+}
+
+func float64Ptr(v float64) *float64 {
+	return &v
+}
+
+func (aa AlertNameArray) contains(alert AlertName) bool {
+	for _, a := range aa {
+		if a == alert {
+			return true
+		}
+	}
+	return false
+}
+
+// parseDateRangeTime parses one end of a daterange for aggregation, which needs real times
+func parseDateRangeTime(s string) (time.Time, bool, error) {
+	if s == "" {
+		return time.Time{}, false, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("daterange %s is not an RFC3339 timestamp: %s", s, err)
+	}
+	return t, true, nil
+}
+
+// ReadAssetStateAggregates returns min, max, mean, count, first and last of a numeric
+// property per minute, hour or day bucket, with the percentage of time spent in each
+// requested alert, computed from the asset's state history
+func (c *AssetClass) ReadAssetStateAggregates(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	var arg = c.NewAsset()
+	var req AggregatesRequest
+
+	if err := arg.unmarshallEventIn(stub, args); err != nil {
+		err := fmt.Errorf("ReadAssetStateAggregates for class %s could not unmarshall, err is %s", c.Name, err)
+		log.Error(err)
+		return nil, err
+	}
+	assetKey, err := arg.getAssetKey(stub)
+	if err != nil {
+		err = fmt.Errorf("ReadAssetStateAggregates for class %s could not find id at %s, err is %s", c.Name, c.AssetIDPath, err)
+		log.Error(err)
+		return nil, err
+	}
+	err = json.Unmarshal([]byte(args[0]), &req)
+	if err != nil {
+		err = fmt.Errorf("ReadAssetStateAggregates failed to unmarshal arg: %s", err)
+		log.Error(err)
+		return nil, err
+	}
+	size, found := bucketSizes[req.Bucket]
+	if !found {
+		err = fmt.Errorf("ReadAssetStateAggregates bucket must be minute, hour or day, got %s", req.Bucket)
+		log.Error(err)
+		return nil, err
+	}
+	if req.QProp == "" && len(req.Alerts) == 0 {
+		err = errors.New("ReadAssetStateAggregates expects a qprop, alerts or both")
+		log.Error(err)
+		return nil, err
+	}
+
+	dr, err := getUnmarshalledDateRange(stub, args)
+	if err != nil {
+		err = fmt.Errorf("ReadAssetStateAggregates failed while getting daterange for %s %s, err is %s", c.Name, assetKey, err)
+		log.Error(err)
+		return nil, err
+	}
+	begin, hasBegin, err := parseDateRangeTime(dr.DateRange.Begin)
+	if err != nil {
+		err = fmt.Errorf("ReadAssetStateAggregates for %s %s: %s", c.Name, assetKey, err)
+		log.Error(err)
+		return nil, err
+	}
+	end, hasEnd, err := parseDateRangeTime(dr.DateRange.End)
+	if err != nil {
+		err = fmt.Errorf("ReadAssetStateAggregates for %s %s: %s", c.Name, assetKey, err)
+		log.Error(err)
+		return nil, err
+	}
+
+	return aggregateAssetStates(stub, assetKey, req, size, begin, hasBegin, end, hasEnd)
+}
+
+func aggregateAssetStates(stub shim.ChaincodeStubInterface, assetKey string, req AggregatesRequest, size time.Duration, begin time.Time, hasBegin bool, end time.Time, hasEnd bool) ([]byte, error) {
+	entries, err := readHistoryEntries(stub, assetKey)
+	if err != nil {
+		err = fmt.Errorf("ReadAssetStateAggregates failed to read history for %s: %s", assetKey, err)
+		log.Error(err)
+		return nil, err
+	}
+	if hasEnd {
+		entries = entries[:sort.Search(len(entries), func(i int) bool { return entries[i].txnts.After(end) })]
+	}
+
+	// replay from the last snapshot at or before the range so that the state in effect
+	// when the range begins is known
+	base := func(e historyEntry) bool { return hasBegin && !e.txnts.After(begin) }
+	all := func(historyEntry) bool { return true }
+	states, _, err := entries.replay(base, all)
+	if err != nil {
+		err = fmt.Errorf("ReadAssetStateAggregates failed to replay history for %s: %s", assetKey, err)
+		log.Error(err)
+		return nil, err
+	}
+
+	var ab = aggregateBuckets{size, req.Alerts, make(map[int64]*AggregateBucket)}
+	if len(states) > 0 {
+		// an open range ends at the last state, and a range cannot end after the transaction,
+		// or after the last state when the transaction has no timestamp
+		var last = *states[len(states)-1].TXNTS
+		if hasEnd {
+			if txnunixtime, err := stub.GetTxTimestamp(); err == nil && txnunixtime != nil {
+				last = time.Unix(txnunixtime.Seconds, int64(txnunixtime.Nanos))
+			}
+		}
+		if !hasEnd || last.Before(end) {
+			end = last
+		}
+		var first = *states[0].TXNTS
+		if hasBegin && first.Before(begin) {
+			first = begin
+		}
+		if n := ab.span(first, end); n > MAXAGGREGATEBUCKETS {
+			err = fmt.Errorf("ReadAssetStateAggregates for %s spans %d %s buckets, the most is %d", assetKey, n, req.Bucket, MAXAGGREGATEBUCKETS)
+			log.Error(err)
+			return nil, err
+		}
+	}
+	for i, state := range states {
+		from := *state.TXNTS
+		to := end
+		if i+1 < len(states) {
+			to = *states[i+1].TXNTS
+		}
+		if hasBegin {
+			if to.Before(begin) {
+				continue
+			}
+			if from.Before(begin) {
+				from = begin
+			}
+		}
+		ab.addInterval(from, to, state.AlertsActive)
+		if req.QProp == "" || (hasBegin && state.TXNTS.Before(begin)) {
+			continue
+		}
+		if v, found := GetObjectAsNumber(state.State, req.QProp); found {
+			ab.addReading(*state.TXNTS, v)
+		}
+	}
+
+	return json.Marshal(AssetStateAggregates{assetKey, req.QProp, req.Bucket, ab.sorted()})
+}
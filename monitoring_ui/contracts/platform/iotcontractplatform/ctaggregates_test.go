@@ -0,0 +1,156 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+package iotcontractplatform
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+)
+
+type wantBucket struct {
+	begin    string
+	count    int
+	min      float64
+	max      float64
+	mean     float64
+	first    float64
+	last     float64
+	overtemp float64
+}
+
+func TestAssetStateAggregates(t *testing.T) {
+	var tests = []struct {
+		name string
+		arg  string
+		want []wantBucket
+	}{
+		{
+			name: "hours",
+			arg:  `"bucket": "hour", "alerts": ["OVERTEMP"], "daterange": {"begin": "2016-11-01T00:00:00Z", "end": "2016-11-01T02:00:00Z"}`,
+			want: []wantBucket{
+				{"2016-11-01T00:00:00Z", 3, -5, 4, 1.0 / 3, -5, 4, 200.0 / 3},
+				{"2016-11-01T01:00:00Z", 2, -3, -1, -2, -1, -3, 0},
+			},
+		},
+		{
+			name: "range begins between states",
+			arg:  `"bucket": "hour", "alerts": ["OVERTEMP"], "daterange": {"begin": "2016-11-01T00:30:00Z", "end": "2016-11-01T01:00:00Z"}`,
+			want: []wantBucket{
+				{"2016-11-01T00:00:00Z", 1, 4, 4, 4, 4, 4, 100},
+				{"2016-11-01T01:00:00Z", 1, -1, -1, -1, -1, -1, 0},
+			},
+		},
+		{
+			name: "open range",
+			arg:  `"bucket": "day", "alerts": ["OVERTEMP"]`,
+			want: []wantBucket{
+				{"2016-11-01T00:00:00Z", 5, -5, 4, -0.6, -5, -3, 50},
+			},
+		},
+	}
+
+	for _, mode := range []string{HISTORYSNAPSHOTS, HISTORYEVENTS} {
+		s := newDeployedStub(t)
+		if _, err := s.Invoke("setHistoryMode", `{"mode": "`+mode+`", "snapshotInterval": 2}`); err != nil {
+			t.Fatalf("setHistoryMode failed: %s", err)
+		}
+		s.Now = time.Date(2016, time.November, 1, 0, 0, 0, 0, time.UTC)
+		s.Step = 20 * time.Minute
+		for _, step := range []crudStep{
+			{function: "createAsset", arg: `{"asset": {"assetID": "A1", "temperature": -5}}`},
+			{function: "updateAsset", arg: `{"asset": {"assetID": "A1", "temperature": 2}}`},
+			{function: "updateAsset", arg: `{"asset": {"assetID": "A1", "temperature": 4}}`},
+			{function: "updateAsset", arg: `{"asset": {"assetID": "A1", "temperature": -1}}`},
+			{function: "updateAsset", arg: `{"asset": {"assetID": "A1", "temperature": -3}}`},
+		} {
+			if _, err := s.Invoke(step.function, step.arg); err != nil {
+				t.Fatalf("%s failed: %s", step.function, err)
+			}
+		}
+
+		for _, tt := range tests {
+			var got AssetStateAggregates
+			result, err := s.Query("readAssetStateAggregates", `{"asset": {"assetID": "A1"}, "qprop": "asset.temperature", `+tt.arg+`}`)
+			if err == nil {
+				err = json.Unmarshal(result, &got)
+			}
+			if err != nil {
+				t.Fatalf("%s %s: readAssetStateAggregates failed: %s", mode, tt.name, err)
+			}
+			if len(got.Buckets) != len(tt.want) {
+				t.Errorf("%s %s: got %d buckets, want %d: %+v", mode, tt.name, len(got.Buckets), len(tt.want), got.Buckets)
+				continue
+			}
+			for i, w := range tt.want {
+				b := got.Buckets[i]
+				if b.Begin.Format(time.RFC3339) != w.begin || b.Count != w.count || !near(b.Min, w.min) || !near(b.Max, w.max) ||
+					!near(b.Mean, w.mean) || !near(b.First, w.first) || !near(b.Last, w.last) {
+					t.Errorf("%s %s: bucket %d is %s count %d min %v max %v mean %v first %v last %v, want %+v", mode, tt.name, i,
+						b.Begin, b.Count, *b.Min, *b.Max, *b.Mean, *b.First, *b.Last, w)
+				}
+				if p := b.AlertPercent[overtempAlert]; math.Abs(p-w.overtemp) > 1e-9 {
+					t.Errorf("%s %s: bucket %d is %v%% in OVERTEMP, want %v%%", mode, tt.name, i, p, w.overtemp)
+				}
+			}
+		}
+
+		if _, err := s.Query("readAssetStateAggregates", `{"asset": {"assetID": "A1"}, "qprop": "asset.temperature", "bucket": "week"}`); err == nil {
+			t.Errorf("%s: readAssetStateAggregates should reject a week bucket", mode)
+		}
+
+		// a range cannot reach past the transaction
+		var got AssetStateAggregates
+		result, err := s.Query("readAssetStateAggregates", `{"asset": {"assetID": "A1"}, "bucket": "minute", "alerts": ["OVERTEMP"], "daterange": {"begin": "2016-11-01T01:00:00Z", "end": "2017-11-01T00:00:00Z"}}`)
+		if err == nil {
+			err = json.Unmarshal(result, &got)
+		}
+		if err != nil {
+			t.Fatalf("%s: readAssetStateAggregates until next year failed: %s", mode, err)
+		}
+		if last := got.Buckets[len(got.Buckets)-1].Begin; last.After(s.Now) {
+			t.Errorf("%s: the last bucket begins at %s, after the transaction at %s", mode, last, s.Now)
+		}
+
+		// without alerts there is no time to cover, so buckets hold readings
+		var readings AssetStateAggregates
+		result, err = s.Query("readAssetStateAggregates", `{"asset": {"assetID": "A1"}, "qprop": "asset.temperature", "bucket": "minute", "daterange": {"begin": "2016-11-01T00:00:00Z", "end": "2016-11-01T02:00:00Z"}}`)
+		if err == nil {
+			err = json.Unmarshal(result, &readings)
+		}
+		if err != nil || len(readings.Buckets) != 5 || readings.Buckets[0].AlertPercent != nil {
+			t.Errorf("%s: expected 5 minute buckets with readings and no alert percentages, got %+v (err %v)", mode, readings.Buckets, err)
+		}
+
+		// two days of minutes is more than MAXAGGREGATEBUCKETS
+		s.Now = s.Now.Add(48 * time.Hour)
+		if _, err := s.Invoke("updateAsset", `{"asset": {"assetID": "A1", "temperature": 1}}`); err != nil {
+			t.Fatalf("updateAsset failed: %s", err)
+		}
+		if _, err := s.Query("readAssetStateAggregates", `{"asset": {"assetID": "A1"}, "qprop": "asset.temperature", "bucket": "minute"}`); err == nil {
+			t.Errorf("%s: readAssetStateAggregates should reject two days of minutes", mode)
+		}
+		if _, err := s.Query("readAssetStateAggregates", `{"asset": {"assetID": "A1"}, "qprop": "asset.temperature", "bucket": "hour"}`); err != nil {
+			t.Errorf("%s: readAssetStateAggregates over two days of hours failed: %s", mode, err)
+		}
+	}
+}
+
+func near(got *float64, want float64) bool {
+	return got != nil && math.Abs(*got-want) < 1e-9
+}
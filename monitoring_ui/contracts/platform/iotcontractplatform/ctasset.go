@@ -104,29 +104,42 @@ func (a *Asset) PUTAsset(stub shim.ChaincodeStubInterface, caller string, inject
 		err := a.injectProps(inject)
 		if err != nil {
 			err = fmt.Errorf("PUTAsset for class %s failed to inject properties %+v for %s, err is %s", a.Class.Name, inject, a.AssetKey, err)
-			log.Errorf(err.Error())
+			log.Error(err)
 			return nil, err
 		}
 	}
 
 	if err := a.ExecuteRules(stub); err != nil {
 		err = fmt.Errorf("PUTAsset for class %s failed in rules engine for %s, err is %s", a.Class.Name, a.AssetKey, err)
-		log.Errorf(err.Error())
+		log.Error(err)
 		return nil, err
 	}
 
-	alertsDeltas := GetAlertsAndDeltas(alertsIn, a.AlertsActive)
-	alertsDeltasBytes, err := json.Marshal(alertsDeltas)
+	_, err := a.putMarshalledState(stub)
 	if err != nil {
-		err = fmt.Errorf("PUTAsset for class %s failed to marshall alert deltas for %s[%+v], err is %s", a.Class.Name, a.AssetKey, alertsDeltas, err)
+		err = fmt.Errorf("PUTAsset for class %s failed to marshall for %s, err is %s", a.Class.Name, a.AssetKey, err)
 		log.Error(err)
 		return nil, err
 	}
 
-	_, err = a.putMarshalledState(stub)
+	propagated, err := a.propagate(stub)
 	if err != nil {
-		err = fmt.Errorf("PUTAsset for class %s failed to marshall for %s, err is %s", a.Class.Name, a.AssetKey, err)
-		log.Errorf(err.Error())
+		err = fmt.Errorf("PUTAsset for class %s failed to propagate %s, err is %s", a.Class.Name, a.AssetKey, err)
+		log.Error(err)
+		return nil, err
+	}
+
+	alertsDeltas := GetAlertsAndDeltas(alertsIn, a.AlertsActive)
+	if len(propagated) > 0 {
+		if alertsDeltas == nil {
+			alertsDeltas = make(map[string]interface{})
+		}
+		alertsDeltas["propagated"] = propagated
+	}
+	alertsDeltasBytes, err := json.Marshal(alertsDeltas)
+	if err != nil {
+		err = fmt.Errorf("PUTAsset for class %s failed to marshall alert deltas for %s[%+v], err is %s", a.Class.Name, a.AssetKey, alertsDeltas, err)
+		log.Error(err)
 		return nil, err
 	}
 	return alertsDeltasBytes, nil
@@ -139,24 +152,24 @@ func (c *AssetClass) CreateAsset(stub shim.ChaincodeStubInterface, args []string
 
 	if err := a.unmarshallEventIn(stub, args); err != nil {
 		err = fmt.Errorf("CreateAsset for class %s could not unmarshall, err is %s", c.Name, err)
-		log.Errorf(err.Error())
+		log.Error(err)
 		return nil, err
 	}
-	assetKey, err := a.getAssetKey()
+	assetKey, err := a.getAssetKey(stub)
 	if err != nil {
 		err = fmt.Errorf("CreateAsset for class %s could not find id at %s, err is %s", c.Name, c.AssetIDPath, err)
-		log.Errorf(err.Error())
+		log.Error(err)
 		return nil, err
 	}
 	_, exists, err := c.getAssetFromWorldState(stub, assetKey)
 	if err != nil {
 		err := fmt.Errorf("CreateAsset for class %s asset %s read from world state returned error %s", c.Name, a.AssetKey, err)
-		log.Errorf(err.Error())
+		log.Error(err)
 		return nil, err
 	}
 	if exists {
 		err := fmt.Errorf("CreateAsset for class %s asset %s asset already exists", c.Name, a.AssetKey)
-		log.Errorf(err.Error())
+		log.Error(err)
 		return nil, err
 	}
 
@@ -165,7 +178,7 @@ func (c *AssetClass) CreateAsset(stub shim.ChaincodeStubInterface, args []string
 	a.State = &astate
 	if err := a.addTXNTimestampToState(stub); err != nil {
 		err = fmt.Errorf("CreateAsset for class %s failed to add txn timestamp for %s, err is %s", c.Name, a.AssetKey, err)
-		log.Errorf(err.Error())
+		log.Error(err)
 		return nil, err
 	}
 
@@ -179,24 +192,24 @@ func (c *AssetClass) ReplaceAsset(stub shim.ChaincodeStubInterface, args []strin
 
 	if err := a.unmarshallEventIn(stub, args); err != nil {
 		err = fmt.Errorf("ReplaceAsset for class %s could not unmarshall, err is %s", c.Name, err)
-		log.Errorf(err.Error())
+		log.Error(err)
 		return nil, err
 	}
-	assetKey, err := a.getAssetKey()
+	assetKey, err := a.getAssetKey(stub)
 	if err != nil {
 		err = fmt.Errorf("ReplaceAsset for class %s could not find id at %s, err is %s", c.Name, c.AssetIDPath, err)
-		log.Errorf(err.Error())
+		log.Error(err)
 		return nil, err
 	}
 	_, exists, err := c.getAssetFromWorldState(stub, assetKey)
 	if err != nil {
 		err := fmt.Errorf("ReplaceAsset for class %s asset %s read from world state returned error %s", c.Name, a.AssetKey, err)
-		log.Errorf(err.Error())
+		log.Error(err)
 		return nil, err
 	}
 	if !exists {
 		err := fmt.Errorf("ReplaceAsset for class %s asset %s asset does not exist", c.Name, a.AssetKey)
-		log.Errorf(err.Error())
+		log.Error(err)
 		return nil, err
 	}
 
@@ -205,7 +218,7 @@ func (c *AssetClass) ReplaceAsset(stub shim.ChaincodeStubInterface, args []strin
 	a.State = &astate
 	if err := a.addTXNTimestampToState(stub); err != nil {
 		err = fmt.Errorf("CreateAsset for class %s failed to add txn timestamp for %s, err is %s", c.Name, a.AssetKey, err)
-		log.Errorf(err.Error())
+		log.Error(err)
 		return nil, err
 	}
 
@@ -220,19 +233,19 @@ func (c *AssetClass) UpdateAsset(stub shim.ChaincodeStubInterface, args []string
 
 	if err := arg.unmarshallEventIn(stub, args); err != nil {
 		err = fmt.Errorf("UpdateAsset for class %s could not unmarshall, err is %s", c.Name, err)
-		log.Errorf(err.Error())
+		log.Error(err)
 		return nil, err
 	}
-	assetKey, err := arg.getAssetKey()
+	assetKey, err := arg.getAssetKey(stub)
 	if err != nil {
 		err = fmt.Errorf("UpdateAsset for class %s could not find id at %s, err is %s", c.Name, c.AssetIDPath, err)
-		log.Errorf(err.Error())
+		log.Error(err)
 		return nil, err
 	}
 	assetBytes, exists, err := c.getAssetFromWorldState(stub, assetKey)
 	if err != nil {
 		err := fmt.Errorf("UpdateAsset for class %s asset %s read from world state returned error %s", c.Name, assetKey, err)
-		log.Errorf(err.Error())
+		log.Error(err)
 		return nil, err
 	}
 	if !exists {
@@ -240,13 +253,13 @@ func (c *AssetClass) UpdateAsset(stub shim.ChaincodeStubInterface, args []string
 			return c.CreateAsset(stub, args, caller, inject)
 		}
 		err := fmt.Errorf("UpdateAsset for class %s asset %s asset does not exist", c.Name, assetKey)
-		log.Errorf(err.Error())
+		log.Error(err)
 		return nil, err
 	}
 	err = json.Unmarshal(assetBytes, &a)
 	if err != nil {
 		err := fmt.Errorf("UpdateAsset for class %s asset %s Unmarshal failed with err %s", c.Name, assetKey, err)
-		log.Errorf(err.Error())
+		log.Error(err)
 		return nil, err
 	}
 	// save the incoming EventIn
@@ -259,7 +272,7 @@ func (c *AssetClass) UpdateAsset(stub shim.ChaincodeStubInterface, args []string
 
 	if err := a.addTXNTimestampToState(stub); err != nil {
 		err = fmt.Errorf("UpdateAsset for class %s failed to add txn timestamp for %s, err is %s", c.Name, a.AssetKey, err)
-		log.Errorf(err.Error())
+		log.Error(err)
 		return nil, err
 	}
 
@@ -272,19 +285,19 @@ func (c *AssetClass) DeleteAsset(stub shim.ChaincodeStubInterface, args []string
 
 	if err := arg.unmarshallEventIn(stub, args); err != nil {
 		err := fmt.Errorf("DeleteAsset for class %s could not unmarshall, err is %s", c.Name, err)
-		log.Errorf(err.Error())
+		log.Error(err)
 		return nil, err
 	}
-	assetKey, err := arg.getAssetKey()
+	assetKey, err := arg.getAssetKey(stub)
 	if err != nil {
 		err = fmt.Errorf("DeleteAsset for class %s could not find id at %s, err is %s", c.Name, c.AssetIDPath, err)
-		log.Errorf(err.Error())
+		log.Error(err)
 		return nil, err
 	}
 	err = arg.removeOneAssetFromWorldState(stub)
 	if err != nil {
 		err := fmt.Errorf("DeleteAsset: removeOneAssetFromWorldState class %s, asset %s, returned error: %s", c.Name, assetKey, err)
-		log.Errorf(err.Error())
+		log.Error(err)
 		return nil, err
 	}
 	return nil, nil
@@ -303,7 +316,7 @@ func (c *AssetClass) DeleteAllAssets(stub shim.ChaincodeStubInterface, args []st
 	iter, err := stub.RangeQueryState(c.Prefix, c.Prefix+"}")
 	if err != nil {
 		err = fmt.Errorf("DeleteAllAssets failed to get a range query iterator: %s", err)
-		log.Errorf(err.Error())
+		log.Error(err)
 		return nil, err
 	}
 	defer iter.Close()
@@ -311,21 +324,21 @@ func (c *AssetClass) DeleteAllAssets(stub shim.ChaincodeStubInterface, args []st
 		key, stateBytes, err := iter.Next()
 		if err != nil {
 			err = fmt.Errorf("DeleteAllAssets iter.Next() failed: %s", err)
-			log.Errorf(err.Error())
+			log.Error(err)
 			return nil, err
 		}
 		var state Asset
 		err = json.Unmarshal(stateBytes, &state)
 		if err != nil {
 			err = fmt.Errorf("DeleteAllAssets state unmarshal failed: %s", err)
-			log.Errorf(err.Error())
+			log.Error(err)
 			return nil, err
 		}
 		if state.Filter(filter) {
 			err = state.removeOneAssetFromWorldState(stub)
 			if err != nil {
 				err = fmt.Errorf("DeleteAllAssets removeOneAssetFromWorldState for asset %s failed: %s", key, err)
-				log.Errorf(err.Error())
+				log.Error(err)
 				return nil, err
 			}
 		}
@@ -342,30 +355,30 @@ func (c *AssetClass) DeletePropertiesFromAsset(stub shim.ChaincodeStubInterface,
 
 	if err := arg.unmarshallEventIn(stub, args); err != nil {
 		err = fmt.Errorf("DeletePropertiesFromAsset for class %s could not unmarshall, err is %s", c.Name, err)
-		log.Errorf(err.Error())
+		log.Error(err)
 		return nil, err
 	}
-	assetKey, err := arg.getAssetKey()
+	assetKey, err := arg.getAssetKey(stub)
 	if err != nil {
 		err = fmt.Errorf("DeletePropertiesFromAsset for class %s could not find id at %s, err is %s", c.Name, c.AssetIDPath, err)
-		log.Errorf(err.Error())
+		log.Error(err)
 		return nil, err
 	}
 	assetBytes, exists, err := c.getAssetFromWorldState(stub, assetKey)
 	if err != nil {
 		err := fmt.Errorf("DeletePropertiesFromAsset for class %s asset %s read from world state returned error %s", c.Name, a.AssetKey, err)
-		log.Errorf(err.Error())
+		log.Error(err)
 		return nil, err
 	}
 	if !exists {
 		err := fmt.Errorf("DeletePropertiesFromAsset for class %s asset %s asset does not exist", c.Name, a.AssetKey)
-		log.Errorf(err.Error())
+		log.Error(err)
 		return nil, err
 	}
 	err = json.Unmarshal(assetBytes, &a)
 	if err != nil {
 		err := fmt.Errorf("DeletePropertiesFromAsset for class %s asset %s Unmarshal failed with err %s", c.Name, a.AssetKey, err)
-		log.Errorf(err.Error())
+		log.Error(err)
 		return nil, err
 	}
 	// save the incoming EventIn
@@ -378,7 +391,7 @@ func (c *AssetClass) DeletePropertiesFromAsset(stub shim.ChaincodeStubInterface,
 		qpropsm, found := GetObjectAsMap(arg.EventIn, "qprops")
 		if !found {
 			err = fmt.Errorf("deletePropertiesFromAsset asset %s has no qprops argument or qprops not a string array", assetKey)
-			log.Errorf(err.Error())
+			log.Error(err)
 			return nil, err
 		}
 		for _, v := range qpropsm {
@@ -393,7 +406,7 @@ func (c *AssetClass) DeletePropertiesFromAsset(stub shim.ChaincodeStubInterface,
 
 	if err := a.addTXNTimestampToState(stub); err != nil {
 		err = fmt.Errorf("deletePropertiesFromAsset for class %s failed to add txn timestamp for %s, err is %s", c.Name, a.AssetKey, err)
-		log.Errorf(err.Error())
+		log.Error(err)
 		return nil, err
 	}
 
@@ -404,19 +417,24 @@ func (c *AssetClass) DeletePropertiesFromAsset(stub shim.ChaincodeStubInterface,
 		err := a.injectProps(inject)
 		if err != nil {
 			err = fmt.Errorf("deletePropertiesFromAsset for class %s failed to inject properties %+v for %s, err is %s", c.Name, inject, a.AssetKey, err)
-			log.Errorf(err.Error())
+			log.Error(err)
 			return nil, err
 		}
 	}
 	if err := a.ExecuteRules(stub); err != nil {
 		err = fmt.Errorf("CreateAsset for class %s failed in rules engine for %s, err is %s", c.Name, a.AssetKey, err)
-		log.Errorf(err.Error())
+		log.Error(err)
 		return nil, err
 	}
 	jsonBytes, err := a.putMarshalledState(stub)
 	if err != nil {
 		err = fmt.Errorf("CreateAsset for class %s failed to marshall for %s, err is %s", c.Name, a.AssetKey, err)
-		log.Errorf(err.Error())
+		log.Error(err)
+		return nil, err
+	}
+	if _, err := a.propagate(stub); err != nil {
+		err = fmt.Errorf("deletePropertiesFromAsset for class %s failed to propagate %s, err is %s", c.Name, a.AssetKey, err)
+		log.Error(err)
 		return nil, err
 	}
 
@@ -429,24 +447,24 @@ func (c *AssetClass) ReadAsset(stub shim.ChaincodeStubInterface, args []string)
 
 	if err := arg.unmarshallEventIn(stub, args); err != nil {
 		err := fmt.Errorf("ReadAsset for class %s could not unmarshall, err is %s", c.Name, err)
-		log.Errorf(err.Error())
+		log.Error(err)
 		return nil, err
 	}
-	assetKey, err := arg.getAssetKey()
+	assetKey, err := arg.getAssetKey(stub)
 	if err != nil {
 		err = fmt.Errorf("ReadAsset for class %s could not find id at %s, err is %s", c.Name, c.AssetIDPath, err)
-		log.Errorf(err.Error())
+		log.Error(err)
 		return nil, err
 	}
 	assetBytes, exists, err := c.getAssetFromWorldState(stub, assetKey)
 	if err != nil {
 		err := fmt.Errorf("ReadAsset for class %s, asset %s returned error: %s", c.Name, assetKey, err)
-		log.Errorf(err.Error())
+		log.Error(err)
 		return nil, err
 	}
 	if !exists {
 		err := fmt.Errorf("ReadAsset for class %s, asset %s does not exist", c.Name, assetKey)
-		log.Errorf(err.Error())
+		log.Error(err)
 		return nil, err
 	}
 	return assetBytes, nil
@@ -461,7 +479,7 @@ func (c AssetClass) ReadAllAssets(stub shim.ChaincodeStubInterface, args []strin
 	resultsBytes, err := json.Marshal(&results)
 	if err != nil {
 		err = fmt.Errorf("readAllAssets failed to marshal assets structure: %s", err)
-		log.Errorf(err.Error())
+		log.Error(err)
 		return nil, err
 	}
 	return resultsBytes, nil
@@ -476,14 +494,14 @@ func (c AssetClass) ReadAllAssetsUnmarshalled(stub shim.ChaincodeStubInterface,
 	filter, err = getUnmarshalledStateFilter(args)
 	if err != nil {
 		err = fmt.Errorf("readAllAssetsUnmarshalled failed to get a filter: %s", err)
-		log.Errorf(err.Error())
+		log.Error(err)
 		return nil, err
 	}
 
 	iter, err := stub.RangeQueryState(c.Prefix, c.Prefix+"}")
 	if err != nil {
 		err = fmt.Errorf("readAllAssetsUnmarshalled failed to get a range query iterator: %s", err)
-		log.Errorf(err.Error())
+		log.Error(err)
 		return nil, err
 	}
 	defer iter.Close()
@@ -491,14 +509,14 @@ func (c AssetClass) ReadAllAssetsUnmarshalled(stub shim.ChaincodeStubInterface,
 		key, assetBytes, err := iter.Next()
 		if err != nil {
 			err = fmt.Errorf("readAllAssetsUnmarshalled iter.Next() failed: %s", err)
-			log.Errorf(err.Error())
+			log.Error(err)
 			return nil, err
 		}
 		var state = new(Asset)
 		err = json.Unmarshal(assetBytes, state)
 		if err != nil {
 			err = fmt.Errorf("readAllAssetsUnmarshalled unmarshal %s failed: %s", key, err)
-			log.Errorf(err.Error())
+			log.Error(err)
 			return nil, err
 		}
 		if state.Filter(filter) {
@@ -564,6 +582,14 @@ var readAssetStateHistoryDefault = func(stub shim.ChaincodeStubInterface, args [
 	return DefaultClass.ReadAssetStateHistory(stub, args)
 }
 
+var readAssetAsOfDefault ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	return DefaultClass.ReadAssetAsOf(stub, args)
+}
+
+var readAssetStateAggregatesDefault ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	return DefaultClass.ReadAssetStateAggregates(stub, args)
+}
+
 // RegisterDefaultRoutes registers the basic crud API for the simplest possible contract
 func RegisterDefaultRoutes() {
 	AddRoute("createAsset", "invoke", DefaultClass, createAssetDefault)
@@ -575,6 +601,8 @@ func RegisterDefaultRoutes() {
 	AddRoute("deletePropertiesFromAsset", "invoke", DefaultClass, deletePropertiesFromAssetDefault)
 	AddRoute("readAsset", "query", DefaultClass, readAssetDefault)
 	AddRoute("readAssetStateHistory", "query", DefaultClass, readAssetStateHistoryDefault)
+	AddRoute("readAssetAsOf", "query", DefaultClass, readAssetAsOfDefault)
+	AddRoute("readAssetStateAggregates", "query", DefaultClass, readAssetStateAggregatesDefault)
 	AddRoute("readAllAssets", "query", DefaultClass, readAllAssetsDefault)
 
 	AddRule("Over Temperature Alert", DefaultClass, []AlertName{overtempAlert}, overtempRule)
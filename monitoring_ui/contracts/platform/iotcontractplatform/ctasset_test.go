@@ -0,0 +1,277 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+package iotcontractplatform
+
+import (
+	"testing"
+	"time"
+)
+
+func init() {
+	RegisterDefaultRoutes()
+}
+
+func newDeployedStub(t *testing.T) *TestStub {
+	s := NewTestStub("1.0")
+	if _, err := s.Init(`{"version": "1.0"}`); err != nil {
+		t.Fatalf("Init failed: %s", err)
+	}
+	return s
+}
+
+type crudStep struct {
+	function string
+	arg      string
+	wantErr  bool
+	raised   []AlertName
+	cleared  []AlertName
+}
+
+func TestAssetCRUD(t *testing.T) {
+	var tests = []struct {
+		name      string
+		steps     []crudStep
+		exists    bool
+		state     map[string]interface{} // qualified property and expected value, nil for absent
+		compliant bool
+		active    []AlertName
+		history   int
+	}{
+		{
+			name:      "create",
+			steps:     []crudStep{{"createAsset", `{"asset": {"assetID": "A1", "temperature": -5}}`, false, nil, nil}},
+			exists:    true,
+			state:     map[string]interface{}{"asset.temperature": -5.0},
+			compliant: true,
+			history:   1,
+		},
+		{
+			name:    "create raises alert",
+			steps:   []crudStep{{"createAsset", `{"asset": {"assetID": "A1", "temperature": 5}}`, false, []AlertName{overtempAlert}, nil}},
+			exists:  true,
+			active:  []AlertName{overtempAlert},
+			history: 1,
+		},
+		{
+			name: "create existing asset fails",
+			steps: []crudStep{
+				{"createAsset", `{"asset": {"assetID": "A1", "temperature": -5}}`, false, nil, nil},
+				{"createAsset", `{"asset": {"assetID": "A1", "temperature": 5}}`, true, nil, nil},
+			},
+			exists:    true,
+			state:     map[string]interface{}{"asset.temperature": -5.0},
+			compliant: true,
+			history:   1,
+		},
+		{
+			name: "create without asset ID fails",
+			steps: []crudStep{
+				{"createAsset", `{"asset": {"temperature": -5}}`, true, nil, nil},
+			},
+		},
+		{
+			name: "update merges into state",
+			steps: []crudStep{
+				{"createAsset", `{"asset": {"assetID": "A1", "temperature": -5, "carrier": "UPS"}}`, false, nil, nil},
+				{"updateAsset", `{"asset": {"assetID": "A1", "temperature": 3}}`, false, []AlertName{overtempAlert}, nil},
+			},
+			exists:  true,
+			state:   map[string]interface{}{"asset.temperature": 3.0, "asset.carrier": "UPS"},
+			active:  []AlertName{overtempAlert},
+			history: 2,
+		},
+		{
+			name: "update clears alert",
+			steps: []crudStep{
+				{"createAsset", `{"asset": {"assetID": "A1", "temperature": 5}}`, false, []AlertName{overtempAlert}, nil},
+				{"updateAsset", `{"asset": {"assetID": "A1", "temperature": -1}}`, false, nil, []AlertName{overtempAlert}},
+			},
+			exists:    true,
+			compliant: true,
+			history:   2,
+		},
+		{
+			name:    "update missing asset creates it by default",
+			steps:   []crudStep{{"updateAsset", `{"asset": {"assetID": "A1", "temperature": 1}}`, false, []AlertName{overtempAlert}, nil}},
+			exists:  true,
+			active:  []AlertName{overtempAlert},
+			history: 1,
+		},
+		{
+			name: "update missing asset fails without create on first update",
+			steps: []crudStep{
+				{"setCreateOnFirstUpdate", `{"setCreateOnFirstUpdate": false}`, false, nil, nil},
+				{"updateAsset", `{"asset": {"assetID": "A1", "temperature": 1}}`, true, nil, nil},
+			},
+		},
+		{
+			name: "update with bad payload leaves state alone",
+			steps: []crudStep{
+				{"createAsset", `{"asset": {"assetID": "A1", "temperature": -5}}`, false, nil, nil},
+				{"updateAsset", `{"asset": {"assetID": "A1", "temperature": 1`, true, nil, nil},
+			},
+			exists:    true,
+			state:     map[string]interface{}{"asset.temperature": -5.0},
+			compliant: true,
+			history:   1,
+		},
+		{
+			name: "replace drops properties",
+			steps: []crudStep{
+				{"createAsset", `{"asset": {"assetID": "A1", "temperature": 5, "carrier": "UPS"}}`, false, []AlertName{overtempAlert}, nil},
+				// replace starts from a new asset, so the earlier alert is not reported as cleared
+				{"replaceAsset", `{"asset": {"assetID": "A1", "temperature": -6}}`, false, nil, nil},
+			},
+			exists:    true,
+			state:     map[string]interface{}{"asset.temperature": -6.0, "asset.carrier": nil},
+			compliant: true,
+			history:   2,
+		},
+		{
+			name:  "replace missing asset fails",
+			steps: []crudStep{{"replaceAsset", `{"asset": {"assetID": "A1", "temperature": 1}}`, true, nil, nil}},
+		},
+	}
+
+	for _, tc := range tests {
+		s := newDeployedStub(t)
+		for i, step := range tc.steps {
+			_, err := s.Invoke(step.function, step.arg)
+			if (err != nil) != step.wantErr {
+				t.Errorf("%s: step %d %s returned err %v, want error %t", tc.name, i, step.function, err, step.wantErr)
+				continue
+			}
+			if err == nil {
+				s.AssertAlertDeltas(t, step.raised, step.cleared)
+			}
+		}
+		a, exists := s.ReadAsset(DefaultClass, "A1")
+		if exists != tc.exists {
+			t.Errorf("%s: asset exists is %t, want %t", tc.name, exists, tc.exists)
+			continue
+		}
+		if !exists {
+			continue
+		}
+		for qprop, want := range tc.state {
+			got, found := GetObject(a.State, qprop)
+			if want == nil && found {
+				t.Errorf("%s: %s is %v, want absent", tc.name, qprop, got)
+			} else if want != nil && got != want {
+				t.Errorf("%s: %s is %v, want %v", tc.name, qprop, got, want)
+			}
+		}
+		s.AssertCompliance(t, DefaultClass, "A1", tc.compliant, tc.active...)
+		history, err := s.ReadHistory("readAssetStateHistory", DefaultClass, "A1")
+		if err != nil || len(history) != tc.history {
+			t.Errorf("%s: history has %d states (err %v), want %d", tc.name, len(history), err, tc.history)
+		}
+	}
+}
+
+func TestAssetHistoryAndRecentStates(t *testing.T) {
+	s := newDeployedStub(t)
+	s.Step = time.Minute
+	for _, step := range []crudStep{
+		{function: "createAsset", arg: `{"asset": {"assetID": "A1", "temperature": -5}}`},
+		{function: "createAsset", arg: `{"asset": {"assetID": "A2", "temperature": -5}}`},
+		{function: "updateAsset", arg: `{"asset": {"assetID": "A1", "temperature": 2}}`},
+	} {
+		if _, err := s.Invoke(step.function, step.arg); err != nil {
+			t.Fatalf("%s failed: %s", step.function, err)
+		}
+	}
+
+	history, err := s.ReadHistory("readAssetStateHistory", DefaultClass, "A1")
+	if err != nil || len(history) != 2 {
+		t.Fatalf("A1 history has %d states (err %v), want 2", len(history), err)
+	}
+	if history[0].TXNID != "TXN0004" || history[1].TXNID != "TXN0002" {
+		t.Errorf("history should be newest first, got %s then %s", history[0].TXNID, history[1].TXNID)
+	}
+	if history[0].TXNTS.Sub(*history[1].TXNTS) != 2*time.Minute {
+		t.Errorf("history timestamps should be two steps apart, got %s and %s", history[0].TXNTS, history[1].TXNTS)
+	}
+
+	recent, err := s.ReadRecentStates()
+	if err != nil || len(recent) != 2 {
+		t.Fatalf("recent states has %d states (err %v), want 2", len(recent), err)
+	}
+	if recent[0].AssetKey != "DEFA1" || recent[1].AssetKey != "DEFA2" {
+		t.Errorf("recent states should be DEFA1, DEFA2, got %s, %s", recent[0].AssetKey, recent[1].AssetKey)
+	}
+
+	if _, err = s.Invoke("deleteAssetStateHistory", `{"asset": {"assetID": "A1"}}`); err != nil {
+		t.Fatalf("deleteAssetStateHistory failed: %s", err)
+	}
+	if history, _ = s.ReadHistory("readAssetStateHistory", DefaultClass, "A1"); len(history) != 0 {
+		t.Errorf("A1 history should be empty after delete, has %d states", len(history))
+	}
+	if history, _ = s.ReadHistory("readAssetStateHistory", DefaultClass, "A2"); len(history) != 1 {
+		t.Errorf("A2 history should be untouched, has %d states", len(history))
+	}
+
+	if _, err = s.Invoke("deleteAsset", `{"asset": {"assetID": "A2"}}`); err != nil {
+		t.Fatalf("deleteAsset failed: %s", err)
+	}
+	if recent, _ = s.ReadRecentStates(); len(recent) != 1 || recent[0].AssetKey != "DEFA1" {
+		t.Errorf("recent states should only hold DEFA1 after deleting A2, got %+v", recent)
+	}
+}
+
+func TestTestStub(t *testing.T) {
+	s := newDeployedStub(t)
+	if err := s.PutState("K", []byte("V")); err == nil {
+		t.Error("PutState outside a transaction should fail")
+	}
+
+	s.TxID = "T"
+	for _, k := range []string{"A", "B.1", "B.2", "B.3", "C"} {
+		_ = s.PutState(k, []byte(k))
+	}
+	s.TxID = ""
+	iter, _ := s.RangeQueryState("B.", "B.}")
+	var keys string
+	for iter.HasNext() {
+		k, v, _ := iter.Next()
+		if k != string(v) {
+			t.Errorf("key %s has value %s", k, v)
+		}
+		keys += k + " "
+	}
+	if keys != "B.1 B.2 B.3 " {
+		t.Errorf("range query should return the B keys only, got %s", keys)
+	}
+
+	var samples = `{"API": {
+		"createAsset": {"function": "createAsset", "args": [{"asset": {"assetID": "S1", "temperature": 1}}]},
+		"readAsset": {"function": "readAsset", "args": [{"asset": {"assetID": "S1"}}]}}}`
+	results, err := s.ReplaySamples(samples, "createAsset", "readAsset")
+	if err != nil {
+		t.Fatalf("ReplaySamples failed: %s", err)
+	}
+	if len(results["readAsset"]) == 0 {
+		t.Error("readAsset sample should return the asset")
+	}
+	s.AssertCompliance(t, DefaultClass, "S1", false, overtempAlert)
+	if _, err = s.ReplaySamples(samples, "createAsset"); err == nil {
+		t.Error("replaying createAsset twice should fail")
+	}
+	if payload, _ := s.LastEvent(); payload["status"] != "ERROR" {
+		t.Errorf("failed invoke should set an error event, got %+v", payload)
+	}
+}
@@ -27,12 +27,22 @@ import (
 	"github.com/hyperledger/fabric/core/chaincode/shim"
 )
 
-var log = shim.NewLogger("iotcontractplatform")
+var log = NewContractLogger("iotcontractplatform")
 
-// SetContractLogger allows the whole package to be loaded at startup and to share a
-// single chaincode logger
+// SetContractLogger shares the contract's chaincode logger with the platform, which
+// adopts its level and keeps it in step when setLoggingLevel is called without a module
 func SetContractLogger(logger *shim.ChaincodeLogger) {
-	log = logger
+	level := shim.LogCritical
+	for l := shim.LogDebug; l > shim.LogCritical; l-- {
+		if logger.IsEnabledFor(l) {
+			level = l
+			break
+		}
+	}
+	SetLoggingLevel("", level)
+	logState.Lock()
+	logState.shared = logger
+	logState.Unlock()
 }
 
 // CREATEONFIRSTUPDATEKEY is used to store can create on update status, which if true by default
@@ -47,7 +57,7 @@ var readWorldState ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args [
 	iter, err := stub.RangeQueryState("", "")
 	if err != nil {
 		err = fmt.Errorf("readWorldState failed to get a range query iterator: %s", err)
-		log.Errorf(err.Error())
+		log.Error(err)
 		return nil, err
 	}
 	defer iter.Close()
@@ -56,13 +66,13 @@ var readWorldState ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args [
 		assetID, assetBytes, err := iter.Next()
 		if err != nil {
 			err = fmt.Errorf("readWorldState iter.Next() failed: %s", err)
-			log.Errorf(err.Error())
+			log.Error(err)
 			return nil, err
 		}
 		err = json.Unmarshal(assetBytes, &state)
 		if err != nil {
 			err = fmt.Errorf("readWorldState unmarshal failed: %s", err)
-			log.Errorf(err.Error())
+			log.Error(err)
 			return nil, err
 		}
 		results[assetID] = state
@@ -71,11 +81,11 @@ var readWorldState ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args [
 	resultsBytes, err := json.MarshalIndent(&results, "", "    ")
 	if err != nil {
 		err = fmt.Errorf("readWorldState failed to marshal results: %s", err)
-		log.Errorf(err.Error())
+		log.Error(err)
 		return nil, err
 	}
 
-	log.Debugf(string(resultsBytes))
+	log.Debug(string(resultsBytes))
 
 	return resultsBytes, nil
 }
@@ -89,7 +99,7 @@ var deleteWorldState ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args
 	iter, err := stub.RangeQueryState("", "")
 	if err != nil {
 		err = fmt.Errorf("clearWorldState failed to get a range query iterator: %s", err)
-		log.Errorf(err.Error())
+		log.Error(err)
 		return nil, err
 	}
 	defer iter.Close()
@@ -97,7 +107,7 @@ var deleteWorldState ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args
 		assetID, _, err := iter.Next()
 		if err != nil {
 			err = fmt.Errorf("clearWorldState iter.Next() failed: %s", err)
-			log.Errorf(err.Error())
+			log.Error(err)
 			return nil, err
 		}
 		// Delete the key / asset from the ledger
@@ -116,42 +126,38 @@ var deleteWorldState ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args
 	return nil, nil
 }
 
+// setLoggingLevel sets the level for all modules, or for one module and its
+// sub-modules, and optionally replaces the list of redacted paths
 var setLoggingLevel ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
 	type LogLevelArg struct {
-		Level string `json:"logLevel"`
+		Level  string    `json:"logLevel"`
+		Module string    `json:"module"`
+		Redact *[]string `json:"redact"`
 	}
 	var level LogLevelArg
 	var err error
 	if len(args) != 1 {
 		err = errors.New("Incorrect number of arguments. Expecting a JSON encoded LogLevel.")
-		log.Errorf(err.Error())
+		log.Error(err)
 		return nil, err
 	}
 	err = json.Unmarshal([]byte(args[0]), &level)
 	if err != nil {
 		err = fmt.Errorf("setLoggingLevel failed to unmarshal arg: %s", err)
-		log.Errorf(err.Error())
+		log.Error(err)
 		return nil, err
 	}
 
-	switch level.Level {
-	case "DEBUG":
-		log.SetLevel(shim.LogDebug)
-	case "INFO":
-		log.SetLevel(shim.LogInfo)
-	case "NOTICE":
-		log.SetLevel(shim.LogNotice)
-	case "WARNING":
-		log.SetLevel(shim.LogWarning)
-	case "ERROR":
-		log.SetLevel(shim.LogError)
-	case "CRITICAL":
-		log.SetLevel(shim.LogCritical)
-	default:
+	l, err := GetLoggingLevelFromString(level.Level)
+	if err != nil {
 		err = fmt.Errorf("setLoggingLevel failed with unknown arg: %s", level.Level)
-		log.Errorf(err.Error())
+		log.Error(err)
 		return nil, err
 	}
+	SetLoggingLevel(level.Module, l)
+	if level.Redact != nil {
+		SetRedactedPaths(*level.Redact...)
+	}
 
 	return nil, nil
 }
@@ -170,19 +176,19 @@ var setCreateOnFirstUpdate ChaincodeFunc = func(stub shim.ChaincodeStubInterface
 	var err error
 	if len(args) != 1 {
 		err = errors.New("setCreateOnFirstUpdate expects a single parameter")
-		log.Errorf(err.Error())
+		log.Error(err)
 		return nil, err
 	}
 	err = json.Unmarshal([]byte(args[0]), &createOnFirstUpdate)
 	if err != nil {
 		err = fmt.Errorf("setCreateOnFirstUpdate failed to unmarshal arg: %s", err)
-		log.Errorf(err.Error())
+		log.Error(err)
 		return nil, err
 	}
 	err = PUTcreateOnFirstUpdate(stub, createOnFirstUpdate)
 	if err != nil {
 		err = fmt.Errorf("setCreateOnFirstUpdate failed to PUT setting: %s", err)
-		log.Errorf(err.Error())
+		log.Error(err)
 		return nil, err
 	}
 	return nil, nil
@@ -193,13 +199,13 @@ func PUTcreateOnFirstUpdate(stub shim.ChaincodeStubInterface, createOnFirstUpdat
 	createOnFirstUpdateBytes, err := json.Marshal(createOnFirstUpdate)
 	if err != nil {
 		err = errors.New("PUTcreateOnFirstUpdate failed to marshal")
-		log.Errorf(err.Error())
+		log.Error(err)
 		return err
 	}
 	err = stub.PutState(CREATEONFIRSTUPDATEKEY, createOnFirstUpdateBytes)
 	if err != nil {
 		err = fmt.Errorf("PUTSTATE createOnFirstUpdate failed: %s", err)
-		log.Errorf(err.Error())
+		log.Error(err)
 		return err
 	}
 	return nil
@@ -211,21 +217,119 @@ func CanCreateOnFirstUpdate(stub shim.ChaincodeStubInterface) bool {
 	createOnFirstUpdateBytes, err := stub.GetState(CREATEONFIRSTUPDATEKEY)
 	if err != nil {
 		err = fmt.Errorf("GETSTATE for canCreateOnFirstUpdate failed: %s", err)
-		log.Errorf(err.Error())
+		log.Error(err)
 		return true // true is the default
 	}
 	err = json.Unmarshal(createOnFirstUpdateBytes, &createOnFirstUpdate)
 	if err != nil {
 		err = fmt.Errorf("canCreateOnFirstUpdate failed to marshal: %s", err)
-		log.Errorf(err.Error())
+		log.Error(err)
 		return true // true is the default
 	}
 	return createOnFirstUpdate.SetCreateOnFirstUpdate
 }
 
+// HISTORYMODEKEY is used to store the way asset state history is written
+const HISTORYMODEKEY string = "IOTCP:HistoryMode"
+
+// HISTORYSNAPSHOTS stores a full copy of the asset on every write, which is the default
+const HISTORYSNAPSHOTS string = "snapshots"
+
+// HISTORYEVENTS stores the change to the asset on every write, with a full snapshot
+// after every SnapshotInterval changes
+const HISTORYEVENTS string = "events"
+
+// DEFAULTSNAPSHOTINTERVAL is the number of deltas written between snapshots when
+// no interval is set
+const DEFAULTSNAPSHOTINTERVAL int = 10
+
+// HistoryMode is a shared parameter structure for the use of the history mode feature
+type HistoryMode struct {
+	Mode             string `json:"mode"`
+	SnapshotInterval int    `json:"snapshotInterval,omitempty"`
+}
+
+// ************************************
+// setHistoryMode
+// ************************************
+var setHistoryMode ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	var historyMode HistoryMode
+	var err error
+	if len(args) != 1 {
+		err = errors.New("setHistoryMode expects a single parameter")
+		log.Error(err)
+		return nil, err
+	}
+	err = json.Unmarshal([]byte(args[0]), &historyMode)
+	if err != nil {
+		err = fmt.Errorf("setHistoryMode failed to unmarshal arg: %s", err)
+		log.Error(err)
+		return nil, err
+	}
+	if historyMode.Mode != HISTORYSNAPSHOTS && historyMode.Mode != HISTORYEVENTS {
+		err = fmt.Errorf("setHistoryMode mode must be %s or %s, got %s", HISTORYSNAPSHOTS, HISTORYEVENTS, historyMode.Mode)
+		log.Error(err)
+		return nil, err
+	}
+	if historyMode.SnapshotInterval < 0 {
+		err = fmt.Errorf("setHistoryMode snapshot interval cannot be negative, got %d", historyMode.SnapshotInterval)
+		log.Error(err)
+		return nil, err
+	}
+	if historyMode.SnapshotInterval == 0 {
+		historyMode.SnapshotInterval = DEFAULTSNAPSHOTINTERVAL
+	}
+	err = PUThistoryMode(stub, historyMode)
+	if err != nil {
+		err = fmt.Errorf("setHistoryMode failed to PUT setting: %s", err)
+		log.Error(err)
+		return nil, err
+	}
+	return nil, nil
+}
+
+// PUThistoryMode marshals the new setting and writes it to the ledger
+func PUThistoryMode(stub shim.ChaincodeStubInterface, historyMode HistoryMode) (err error) {
+	historyModeBytes, err := json.Marshal(historyMode)
+	if err != nil {
+		err = errors.New("PUThistoryMode failed to marshal")
+		log.Error(err)
+		return err
+	}
+	err = stub.PutState(HISTORYMODEKEY, historyModeBytes)
+	if err != nil {
+		err = fmt.Errorf("PUTSTATE historyMode failed: %s", err)
+		log.Error(err)
+		return err
+	}
+	return nil
+}
+
+// GetHistoryMode retrieves the setting from the ledger, defaulting to snapshots
+func GetHistoryMode(stub shim.ChaincodeStubInterface) HistoryMode {
+	var historyMode = HistoryMode{HISTORYSNAPSHOTS, DEFAULTSNAPSHOTINTERVAL}
+	historyModeBytes, err := stub.GetState(HISTORYMODEKEY)
+	if err != nil {
+		err = fmt.Errorf("GETSTATE for historyMode failed: %s", err)
+		log.Error(err)
+		return historyMode
+	}
+	if len(historyModeBytes) == 0 {
+		return historyMode
+	}
+	err = json.Unmarshal(historyModeBytes, &historyMode)
+	if err != nil {
+		err = fmt.Errorf("GetHistoryMode failed to unmarshal: %s", err)
+		log.Error(err)
+		return HistoryMode{HISTORYSNAPSHOTS, DEFAULTSNAPSHOTINTERVAL}
+	}
+	return historyMode
+}
+
 func init() {
 	AddRoute("deleteWorldState", "invoke", SystemClass, deleteWorldState)
 	AddRoute("readWorldState", "query", SystemClass, readWorldState)
 	AddRoute("setLoggingLevel", "invoke", SystemClass, setLoggingLevel)
 	AddRoute("setCreateOnFirstUpdate", "invoke", SystemClass, setCreateOnFirstUpdate)
+	AddRoute("setHistoryMode", "invoke", SystemClass, setHistoryMode)
 }
@@ -109,7 +109,7 @@ var readContractState = func(stub shim.ChaincodeStubInterface, args []string) ([
 
 	if len(args) != 0 {
 		err = errors.New("Too many arguments. Expecting none.")
-		log.Errorf(err.Error())
+		log.Error(err)
 		return nil, err
 	}
 
@@ -117,7 +117,7 @@ var readContractState = func(stub shim.ChaincodeStubInterface, args []string) ([
 	chaincodeBytes, err := stub.GetState(CONTRACTSTATEKEY)
 	if err != nil {
 		err = fmt.Errorf("readContractState failed GETSTATE: %s", err)
-		log.Errorf(err.Error())
+		log.Error(err)
 		return nil, err
 	}
 
@@ -132,14 +132,14 @@ var initContract = func(stub shim.ChaincodeStubInterface, args []string) ([]byte
 
 	if len(args) != 2 {
 		err = errors.New("initContract expects two arguments, a JSON object with version and nickname, and the contract version")
-		log.Criticalf(err.Error())
+		log.Critical(err)
 		return nil, err
 	}
 
 	err = json.Unmarshal([]byte(args[0]), &stateArg)
 	if err != nil {
 		err = fmt.Errorf("initContract argument unmarshal failed: %s", err)
-		log.Criticalf(err.Error())
+		log.Critical(err)
 		return nil, err
 	}
 
@@ -43,21 +43,22 @@ var SystemClass = AssetClass{
 // Incoming asset CRUD events must have an assetID, which must be where the asset
 // definition says it is. This function creates the world state representation by
 // prepending the Prefix to it.
-func (a *Asset) getAssetKey() (string, error) {
+func (a *Asset) getAssetKey(stub shim.ChaincodeStubInterface) (string, error) {
 	assetID, found := GetObjectAsString(a.EventIn, a.Class.AssetIDPath)
 	if !found {
 		err := fmt.Errorf("getAssetID: %s not found", a.Class.AssetIDPath)
-		log.Errorf(err.Error())
+		log.Error(err)
 		return "", err
 	}
 
 	if assetID == "" {
 		err := fmt.Errorf("getAssetID: %s is blank", a.Class.AssetIDPath)
-		log.Errorf(err.Error())
+		log.Error(err)
 		return "", err
 	}
 	// bit of a side-effect, sorry
 	a.AssetKey = a.Class.Prefix + assetID
+	setLogContextAsset(stub, a.Class.Name, a.AssetKey)
 	return a.AssetKey, nil
 }
 
@@ -73,7 +74,7 @@ func (c AssetClass) getAssetFromWorldState(stub shim.ChaincodeStubInterface, ass
 	assetBytes, err = stub.GetState(assetKey)
 	if err != nil {
 		err := fmt.Errorf("getAssetFromWorldState: GetState of %s returned error %s", assetKey, err)
-		log.Errorf(err.Error())
+		log.Error(err)
 		return nil, false, err
 	}
 
@@ -94,7 +95,7 @@ func GetAssetFromLedger(stub shim.ChaincodeStubInterface, assetKey string) (asse
 	assetBytes, err := stub.GetState(assetKey)
 	if err != nil {
 		err := fmt.Errorf("GetAssetFromLedger: GetState of %s returned error %s", assetKey, err)
-		log.Errorf(err.Error())
+		log.Error(err)
 		return Asset{}, false, err
 	}
 
@@ -110,7 +111,7 @@ func GetAssetFromLedger(stub shim.ChaincodeStubInterface, assetKey string) (asse
 	err = json.Unmarshal(assetBytes, &a)
 	if err != nil {
 		err := fmt.Errorf("GetAssetFromLedger for asset %s Unmarshal failed with err %s", assetKey, err)
-		log.Errorf(err.Error())
+		log.Error(err)
 		return Asset{}, true, err
 	}
 
@@ -126,7 +127,7 @@ func (a *Asset) unmarshallEventIn(stub shim.ChaincodeStubInterface, args []strin
 
 	if len(args) != 1 && len(args) != 2 {
 		err = errors.New("Expecting a JSON event [and optional redirect function name]")
-		log.Errorf(err.Error())
+		log.Error(err)
 		return err
 	}
 
@@ -134,20 +135,20 @@ func (a *Asset) unmarshallEventIn(stub shim.ChaincodeStubInterface, args []strin
 	err = json.Unmarshal(eventBytes, &event)
 	if err != nil {
 		err = fmt.Errorf("%s failed to unmarshal arg: %s", a.Class, err)
-		log.Errorf(err.Error())
+		log.Error(err)
 		return err
 	}
 
 	if event == nil {
 		err = fmt.Errorf("%s unmarshal arg created nil event", a.Class)
-		log.Errorf(err.Error())
+		log.Error(err)
 		return err
 	}
 
 	amap, found := AsMap(event)
 	if !found {
 		err := fmt.Errorf("%s arg is not a map shape", a.Class)
-		log.Errorf(err.Error())
+		log.Error(err)
 		return err
 	}
 	a.EventIn = &amap
@@ -163,12 +164,12 @@ func (a *Asset) unmarshallEventIn(stub shim.ChaincodeStubInterface, args []strin
 //     stateBytes, exists, err := c.getAssetFromWorldState(stub, assetID)
 //     if err != nil {
 //         err := fmt.Errorf("getUnmarshalledState for class %s asset %s read from world state returned error %s", c.Name, assetID, err)
-//         log.Errorf(err.Error())
+//         log.Error(err)
 //         return nil, err
 //     }
 //     if !exists {
 //         err := fmt.Errorf("getUnmarshalledState for class %s asset %s asset does not exist", c.Name, assetID)
-//         log.Errorf(err.Error())
+//         log.Error(err)
 //         return nil, err
 //     }
 
@@ -189,27 +190,28 @@ func (a *Asset) putMarshalledState(stub shim.ChaincodeStubInterface) ([]byte, er
 	stateJSON, err := json.Marshal(a)
 	if err != nil {
 		err = fmt.Errorf("putMarshalledState: assetID %s marshal failed: %s", a.AssetKey, err)
-		log.Errorf(err.Error())
+		log.Error(err)
 		return nil, err
 	}
 
-	err = stub.PutState(a.AssetKey, []byte(stateJSON))
+	// history goes first as a delta is taken from the prior state
+	err = a.PUTAssetStateHistory(stub)
 	if err != nil {
-		err = fmt.Errorf("putMarshalledState: PUTSTATE for assetID %s failed: %s", a.AssetKey, err)
-		log.Errorf(err.Error())
+		err = fmt.Errorf("putMarshalledState failed to put asset %s history: %s", a.AssetKey, err)
+		log.Error(err)
 		return nil, err
 	}
 
-	err = a.PushRecentState(stub)
+	err = stub.PutState(a.AssetKey, []byte(stateJSON))
 	if err != nil {
-		err = fmt.Errorf("%s: assetID %s push recent states failed: %s", a.Class.Name, a.AssetKey, err)
-		log.Errorf(err.Error())
+		err = fmt.Errorf("putMarshalledState: PUTSTATE for assetID %s failed: %s", a.AssetKey, err)
+		log.Error(err)
 		return nil, err
 	}
 
-	err = a.PUTAssetStateHistory(stub)
+	err = a.PushRecentState(stub)
 	if err != nil {
-		err = fmt.Errorf("putMarshalledState failed to put asset %s history: %s", a.AssetKey, err)
+		err = fmt.Errorf("%s: assetID %s push recent states failed: %s", a.Class.Name, a.AssetKey, err)
 		log.Error(err)
 		return nil, err
 	}
@@ -230,6 +232,12 @@ func (a *Asset) removeOneAssetFromWorldState(stub shim.ChaincodeStubInterface) e
 		log.Error(err)
 		return err
 	}
+	err = removeAssetEdges(stub, a.AssetKey)
+	if err != nil {
+		err = fmt.Errorf("removeOneAssetFromWorldState: asset %s relationships could not be removed: %s", a.AssetKey, err)
+		log.Error(err)
+		return err
+	}
 	// delete history must be executed separately
 	return nil
 }
@@ -241,7 +249,7 @@ func (a *Asset) addTXNTimestampToState(stub shim.ChaincodeStubInterface) error {
 	txnunixtime, err := stub.GetTxTimestamp()
 	if err != nil {
 		err = fmt.Errorf("error getting transaction timestamp, err is %s", err)
-		log.Errorf(err.Error())
+		log.Error(err)
 		return err
 	}
 	txntimestamp := time.Unix(txnunixtime.Seconds, int64(txnunixtime.Nanos))
@@ -256,7 +264,7 @@ func (a *Asset) injectProps(qprops []QPropNV) error {
 		ok = PutObject(a.State, qp.QProp, qp.Value)
 		if !ok {
 			err := fmt.Errorf("injectProps->putObject failed to put %s:%s to state %#v", qp.QProp, qp.Value, a)
-			log.Errorf(err.Error())
+			log.Error(err)
 			return err
 		}
 	}
@@ -24,8 +24,13 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
 )
 
+// filters run for every asset in a read, so they log under their own module
+var filterLog = NewContractLogger("iotcontractplatform.filters")
+
 // MatchType denotes how a filter should operate.
 type MatchType int32
 
@@ -137,7 +142,7 @@ func (a *Asset) matchNone(filter StateFilter) bool {
 }
 
 func findJSONPropInStruct(p string, v reflect.Value) (reflect.Value, interface{}, reflect.Kind, bool) {
-	dump("findJSONPropInStruct", p, nil, nil, nil)
+	filterLog.DebugFields("findJSONPropInStruct", map[string]interface{}{"prop": p})
 	if v.Kind() != reflect.Struct {
 		return reflect.Value{}, nil, reflect.Invalid, false
 	}
@@ -152,12 +157,10 @@ func findJSONPropInStruct(p string, v reflect.Value) (reflect.Value, interface{}
 	return reflect.Value{}, nil, reflect.Invalid, false
 }
 
-func dump(s string, i interface{}, j interface{}, k interface{}, l interface{}) {
-	fmt.Printf("%s: first: %+v || second: %+v || third: %+v || fourth: %+v\n", s, i, j, k, l)
-}
-
 func (a *Asset) performOneMatch(prop QPropNV) bool {
-	dump("performOneMatch", a, prop, nil, nil)
+	if filterLog.IsEnabledFor(shim.LogDebug) {
+		filterLog.DebugFields("performOneMatch", map[string]interface{}{"asset": a, "filter": prop})
+	}
 	var levels []string
 	var found = false
 	var kind reflect.Kind
@@ -169,7 +172,7 @@ func (a *Asset) performOneMatch(prop QPropNV) bool {
 	levels = strings.SplitAfterN(prop.QProp, ".", 2)
 	ar := reflect.ValueOf(a).Elem()
 	v, o, kind, found = findJSONPropInStruct(strings.TrimSuffix(levels[0], ","), ar)
-	dump("JSON prop in struct returned", v, o, kind, found)
+	filterLog.DebugFields("JSON prop in struct returned", map[string]interface{}{"kind": kind.String(), "found": found})
 
 	if found {
 		if len(levels) == 2 {
@@ -201,7 +204,7 @@ func (a *Asset) performOneMatch(prop QPropNV) bool {
 			if err == nil {
 				return o.(float64) == f
 			}
-			err = fmt.Errorf("Cannot convert %s to float64 in filter when comparing to object %s %+v", prop.Value, prop.QProp, a)
+			err = fmt.Errorf("Cannot convert %s to float64 in filter when comparing to object %s of %s", prop.Value, prop.QProp, a.AssetKey)
 			log.Error(err)
 			return false
 		case int:
@@ -209,14 +212,14 @@ func (a *Asset) performOneMatch(prop QPropNV) bool {
 			if err == nil {
 				return o.(int) == i
 			}
-			err = fmt.Errorf("Cannot convert %s to int in filter when comparing to object %s %+v", prop.Value, prop.QProp, a)
+			err = fmt.Errorf("Cannot convert %s to int in filter when comparing to object %s of %s", prop.Value, prop.QProp, a.AssetKey)
 			log.Error(err)
 			return false
 		case bool:
 			if b, err := strconv.ParseBool(prop.Value); err == nil {
 				return b == o.(bool)
 			}
-			err := fmt.Errorf("Cannot convert %s to bool in filter when comparing to object %s %+v", prop.Value, prop.QProp, a)
+			err := fmt.Errorf("Cannot convert %s to bool in filter when comparing to object %s of %s", prop.Value, prop.QProp, a.AssetKey)
 			log.Error(err)
 			return false
 		default:
@@ -19,6 +19,8 @@ Kim Letkeman - Initial Contribution
 // v0.2 KL -- complete rewrite, history will be stored one state at a time so that
 //            it poses minimal additional burden on state writes, read will use an iterator,
 //            read will allow start and end time range, all, or last <n>
+// v0.3 KL -- history can be stored as deltas with periodic snapshots, see setHistoryMode,
+//            and states are rebuilt from the nearest snapshot when read
 
 package iotcontractplatform
 
@@ -57,8 +59,16 @@ type DateRange struct {
 	} `json:"daterange"`
 }
 
-// PUTAssetStateHistory write an Asset state with history key
+// PUTAssetStateHistory write an Asset state with history key, or only its delta from the
+// prior state when the history mode is events. Must be called before the new state is
+// written to world state.
 func (a *Asset) PUTAssetStateHistory(stub shim.ChaincodeStubInterface) error {
+	if mode := GetHistoryMode(stub); mode.Mode == HISTORYEVENTS {
+		written, err := a.putAssetStateDelta(stub, mode.SnapshotInterval)
+		if err != nil || written {
+			return err
+		}
+	}
 	historyKey := STATEHISTORYKEY + a.AssetKey + "." + a.TXNTS.Format(time.RFC3339Nano)
 	assetBytes, err := json.Marshal(a)
 	if err != nil {
@@ -85,36 +95,37 @@ func (c *AssetClass) DeleteAssetStateHistory(stub shim.ChaincodeStubInterface, a
 		log.Error(err)
 		return nil, err
 	}
-	assetKey, err := arg.getAssetKey()
+	assetKey, err := arg.getAssetKey(stub)
 	if err != nil {
 		err = fmt.Errorf("DeleteAssetStateHistory for class %s could not find id at %s, err is %s", c.Name, c.AssetIDPath, err)
 		log.Error(err)
 		return nil, err
 	}
 
-	var historyKey = STATEHISTORYKEY + assetKey + "."
-
-	iter, err := stub.RangeQueryState(historyKey, historyKey+"}")
+	entries, err := readHistoryEntries(stub, assetKey)
 	if err != nil {
-		err = fmt.Errorf("DeleteAssetStateHistory failed to get a range query iterator: %s", err)
-		log.Errorf(err.Error())
+		err = fmt.Errorf("DeleteAssetStateHistory failed to read history for %s: %s", assetKey, err)
+		log.Error(err)
 		return nil, err
 	}
-	defer iter.Close()
-	for iter.HasNext() {
-		key, _, err := iter.Next()
-		if err != nil {
-			err = fmt.Errorf("DeleteAssetStateHistory iter.Next() failed: %s", err)
-			log.Errorf(err.Error())
-			return nil, err
+	for _, e := range entries {
+		var key = STATEDELTAKEY + assetKey + "." + e.ts
+		if e.snapshot {
+			key = STATEHISTORYKEY + assetKey + "." + e.ts
 		}
 		err = stub.DelState(key)
 		if err != nil {
 			err = fmt.Errorf("DeleteAssetStateHistory DelState for asset %s failed: %s ", key, err)
-			log.Errorf(err.Error())
+			log.Error(err)
 			return nil, err
 		}
 	}
+	err = stub.DelState(DELTACOUNTKEY + assetKey)
+	if err != nil {
+		err = fmt.Errorf("DeleteAssetStateHistory DelState of delta count for asset %s failed: %s ", assetKey, err)
+		log.Error(err)
+		return nil, err
+	}
 
 	return nil, nil
 }
@@ -134,7 +145,7 @@ func (c *AssetClass) ReadAssetStateHistory(stub shim.ChaincodeStubInterface, arg
 		log.Error(err)
 		return nil, err
 	}
-	assetKey, err := arg.getAssetKey()
+	assetKey, err := arg.getAssetKey(stub)
 	if err != nil {
 		err = fmt.Errorf("ReadAssetStateHistory for class %s could not find id at %s, err is %s", c.Name, c.AssetIDPath, err)
 		log.Error(err)
@@ -155,39 +166,35 @@ func (c *AssetClass) ReadAssetStateHistory(stub shim.ChaincodeStubInterface, arg
 		return nil, err
 	}
 
+	var entries historyEntries
 	if dr == EmptyDateRange {
 		begin = ""
 		end = "}"
+		entries, err = readHistoryEntries(stub, assetKey)
 	} else {
 		begin = dr.DateRange.Begin
 		end = dr.DateRange.End + "}"
+		entries, err = readHistoryFrom(stub, assetKey, begin, end)
+	}
+	if err != nil {
+		err = fmt.Errorf("ReadAssetStateHistory failed to read history for %s: %s", assetKey, err)
+		log.Error(err)
+		return nil, err
 	}
 
-	var historyKey = STATEHISTORYKEY + assetKey + "."
-
-	iter, err := stub.RangeQueryState(historyKey+begin, historyKey+end)
+	// the range compares timestamps as strings, as a range query on the keys would, and
+	// states stored as deltas are rebuilt from the last snapshot before the range
+	before := func(e historyEntry) bool { return e.ts < begin }
+	inRange := func(e historyEntry) bool { return e.ts >= begin && e.ts <= end }
+	states, _, err := entries.replay(before, inRange)
 	if err != nil {
-		err = fmt.Errorf("ReadAssetStateHistory failed to get a range query iterator: %s", err)
-		log.Errorf(err.Error())
+		err = fmt.Errorf("ReadAssetStateHistory failed to replay history for %s: %s", assetKey, err)
+		log.Error(err)
 		return nil, err
 	}
-	defer iter.Close()
-	for iter.HasNext() {
-		key, assetBytes, err := iter.Next()
-		if err != nil {
-			err = fmt.Errorf("ReadAssetStateHistory iter.Next() failed: %s", err)
-			log.Errorf(err.Error())
-			return nil, err
-		}
-		var state = new(Asset)
-		err = json.Unmarshal(assetBytes, state)
-		if err != nil {
-			err = fmt.Errorf("ReadAssetStateHistory unmarshal %s failed: %s", key, err)
-			log.Errorf(err.Error())
-			return nil, err
-		}
+	for _, state := range states {
 		if state.Filter(filter) {
-			assets = append(assets, *state)
+			assets = append(assets, state)
 		}
 	}
 
@@ -197,6 +204,60 @@ func (c *AssetClass) ReadAssetStateHistory(stub shim.ChaincodeStubInterface, arg
 	return json.Marshal(assets)
 }
 
+// readHistoryFrom returns the snapshots with key timestamps from begin to end, and the
+// deltas from the last snapshot before begin to end, oldest first
+func readHistoryFrom(stub shim.ChaincodeStubInterface, assetKey string, begin string, end string) (historyEntries, error) {
+	entries, err := readHistoryRange(stub, STATEHISTORYKEY, assetKey, begin, end)
+	if err != nil {
+		return nil, err
+	}
+	var base *historyEntry
+	if when, ok := parseKeyTime(begin); ok {
+		base, err = lastSnapshotAtOrBefore(stub, assetKey, when)
+	} else {
+		var before historyEntries
+		before, err = readHistoryRange(stub, STATEHISTORYKEY, assetKey, "", begin)
+		if len(before) > 0 {
+			base = &before[len(before)-1]
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var from = begin
+	if base != nil {
+		from = keyTime(base.txnts)
+		if base.ts < begin {
+			entries = append(entries, *base)
+		}
+	}
+	deltas, err := readHistoryRange(stub, STATEDELTAKEY, assetKey, from, end)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range deltas {
+		if base == nil || d.txnts.After(base.txnts) {
+			entries = append(entries, d)
+		}
+	}
+	sort.Stable(entries)
+	return entries, nil
+}
+
+// parseKeyTime parses the begin of a date range, which may be a timestamp, a time to the
+// second or a date in the zone in which history keys are written
+func parseKeyTime(begin string) (time.Time, bool) {
+	if t, err := time.Parse(time.RFC3339Nano, begin); err == nil {
+		return t, true
+	}
+	for _, layout := range []string{"2006-01-02T15:04:05", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, begin, time.Local); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
 // Returns a date range found in the json object in args[0]
 func getUnmarshalledDateRange(stub shim.ChaincodeStubInterface, args []string) (DateRange, error) {
 	var dr DateRange
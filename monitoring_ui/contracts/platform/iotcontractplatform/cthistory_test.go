@@ -0,0 +1,190 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+package iotcontractplatform
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+var historySteps = []crudStep{
+	{function: "createAsset", arg: `{"asset": {"assetID": "A1", "temperature": -5, "location": {"latitude": 1, "longitude": 2}}}`},
+	{function: "updateAsset", arg: `{"asset": {"assetID": "A1", "temperature": 2}}`},
+	{function: "updateAsset", arg: `{"asset": {"assetID": "A1", "location": {"latitude": 3}, "carrier": "UPS"}}`},
+	{function: "deletePropertiesFromAsset", arg: `{"asset": {"assetID": "A1"}, "qprops": ["asset.location.longitude", "asset.carrier"]}`},
+	{function: "replaceAsset", arg: `{"asset": {"assetID": "A1", "temperature": -1}}`},
+	{function: "updateAsset", arg: `{"asset": {"assetID": "A1", "temperature": 4}}`},
+	{function: "updateAsset", arg: `{"asset": {"assetID": "A1", "location": "dock 4"}}`},
+}
+
+// runHistorySteps runs the steps against a new stub in the given history mode and returns
+// the stub and the asset as it was after each step
+func runHistorySteps(t *testing.T, mode string) (*TestStub, AssetArray) {
+	s := newDeployedStub(t)
+	if _, err := s.Invoke("setHistoryMode", `{"mode": "`+mode+`", "snapshotInterval": 2}`); err != nil {
+		t.Fatalf("setHistoryMode failed: %s", err)
+	}
+	var states = make(AssetArray, 0)
+	for _, step := range historySteps {
+		if _, err := s.Invoke(step.function, step.arg); err != nil {
+			t.Fatalf("%s failed: %s", step.function, err)
+		}
+		a, _ := s.ReadAsset(DefaultClass, "A1")
+		states = append(states, a)
+	}
+	return s, states
+}
+
+func readAsOf(s *TestStub, when time.Time) (Asset, error) {
+	var a Asset
+	result, err := s.Query("readAssetAsOf", `{"asset": {"assetID": "A1"}, "asof": "`+when.Format(time.RFC3339Nano)+`"}`)
+	if err == nil {
+		err = json.Unmarshal(result, &a)
+	}
+	return a, err
+}
+
+func countKeys(s *TestStub, prefix string) int {
+	var n int
+	for k := range s.State {
+		if strings.HasPrefix(k, prefix) {
+			n++
+		}
+	}
+	return n
+}
+
+func TestAssetHistoryEvents(t *testing.T) {
+	s, states := runHistorySteps(t, HISTORYEVENTS)
+	snapshots, deltas := countKeys(s, STATEHISTORYKEY), countKeys(s, STATEDELTAKEY)
+	if snapshots != 3 || deltas != 4 {
+		t.Errorf("events history should hold 3 snapshots and 4 deltas, has %d and %d", snapshots, deltas)
+	}
+
+	for i, want := range states {
+		for _, when := range []time.Time{*want.TXNTS, want.TXNTS.Add(500 * time.Millisecond)} {
+			got, err := readAsOf(s, when)
+			if err != nil {
+				t.Fatalf("readAssetAsOf %s failed: %s", when, err)
+			}
+			if !reflect.DeepEqual(got.State, want.State) || got.TXNID != want.TXNID || !sameAlerts(got.AlertsActive, want.AlertsActive) || got.Compliant != want.Compliant {
+				t.Errorf("step %d as of %s: got %s, want %s", i, when, got, want)
+			}
+		}
+	}
+	if _, err := readAsOf(s, states[0].TXNTS.Add(-time.Millisecond)); err == nil {
+		t.Error("readAssetAsOf before the asset was created should fail")
+	}
+
+	history, err := s.ReadHistory("readAssetStateHistory", DefaultClass, "A1")
+	if err != nil || len(history) != len(states) {
+		t.Fatalf("A1 history has %d states (err %v), want %d", len(history), err, len(states))
+	}
+	for i, h := range history {
+		want := states[len(states)-1-i]
+		if h.TXNID != want.TXNID || !reflect.DeepEqual(h.State, want.State) {
+			t.Errorf("history state %d: got %s, want %s", i, h, want)
+		}
+	}
+
+	if _, err = s.Invoke("deleteAssetStateHistory", `{"asset": {"assetID": "A1"}}`); err != nil {
+		t.Fatalf("deleteAssetStateHistory failed: %s", err)
+	}
+	if n := countKeys(s, "IOTCP.HIST"); n != 0 {
+		t.Errorf("history should be empty after delete, has %d keys", n)
+	}
+	if _, err = s.Invoke("updateAsset", `{"asset": {"assetID": "A1", "temperature": 6}}`); err != nil {
+		t.Fatalf("updateAsset failed: %s", err)
+	}
+	if snapshots = countKeys(s, STATEHISTORYKEY); snapshots != 1 {
+		t.Errorf("the first write after deleting history should be a snapshot, have %d snapshots", snapshots)
+	}
+}
+
+func TestAssetHistoryBoundedReads(t *testing.T) {
+	s, states := runHistorySteps(t, HISTORYEVENTS)
+	// keys older than the snapshot nearest a read cannot be parsed, so reading them fails
+	assetKey := states[0].AssetKey
+	s.State[STATEHISTORYKEY+assetKey+".2000-01-01Tbad"] = []byte("{}")
+	s.State[STATEDELTAKEY+assetKey+".2000-01-01Tbad"] = []byte("{}")
+
+	for i, want := range states {
+		got, err := readAsOf(s, *want.TXNTS)
+		if err != nil {
+			t.Fatalf("readAssetAsOf step %d read history older than its snapshot: %s", i, err)
+		}
+		if !reflect.DeepEqual(got.State, want.State) || got.TXNID != want.TXNID {
+			t.Errorf("step %d: got %s, want %s", i, got, want)
+		}
+	}
+
+	// deltas are replayed from the last snapshot before the range
+	begin, end := states[3].TXNTS.Format(time.RFC3339Nano), states[5].TXNTS.Format(time.RFC3339Nano)
+	result, err := s.Query("readAssetStateHistory", `{"asset": {"assetID": "A1"}, "daterange": {"begin": "`+begin+`", "end": "`+end+`"}}`)
+	if err != nil {
+		t.Fatalf("readAssetStateHistory in a date range read history older than its snapshot: %s", err)
+	}
+	var history AssetArray
+	if err = json.Unmarshal(result, &history); err != nil || len(history) != 3 {
+		t.Fatalf("expected 3 states in the date range, got %s (err %v)", result, err)
+	}
+	for i, h := range history {
+		want := states[5-i]
+		if h.TXNID != want.TXNID || !reflect.DeepEqual(h.State, want.State) {
+			t.Errorf("history state %d: got %s, want %s", i, h, want)
+		}
+	}
+
+	// the nearest snapshot is found however long before the asof it was written
+	last := states[len(states)-1]
+	got, err := readAsOf(s, last.TXNTS.Add(30*24*time.Hour))
+	if err != nil || got.TXNID != last.TXNID || !reflect.DeepEqual(got.State, last.State) {
+		t.Errorf("a month after the last step: got %s (err %v), want %s", got, err, last)
+	}
+}
+
+func TestAssetHistoryModeChange(t *testing.T) {
+	s, states := runHistorySteps(t, HISTORYSNAPSHOTS)
+	if n := countKeys(s, STATEDELTAKEY); n != 0 {
+		t.Errorf("snapshot history should hold no deltas, has %d", n)
+	}
+	got, err := readAsOf(s, *states[2].TXNTS)
+	if err != nil || !reflect.DeepEqual(got.State, states[2].State) {
+		t.Errorf("readAssetAsOf over snapshots: got %s (err %v), want %s", got, err, states[2])
+	}
+
+	if _, err = s.Invoke("setHistoryMode", `{"mode": "events"}`); err != nil {
+		t.Fatalf("setHistoryMode failed: %s", err)
+	}
+	if _, err = s.Invoke("updateAsset", `{"asset": {"assetID": "A1", "temperature": 9}}`); err != nil {
+		t.Fatalf("updateAsset failed: %s", err)
+	}
+	last, _ := s.ReadAsset(DefaultClass, "A1")
+	if got, err = readAsOf(s, *last.TXNTS); err != nil || !reflect.DeepEqual(got.State, last.State) {
+		t.Errorf("readAssetAsOf after switching to events: got %s (err %v), want %s", got, err, last)
+	}
+	if got, err = readAsOf(s, *states[5].TXNTS); err != nil || !reflect.DeepEqual(got.State, states[5].State) {
+		t.Errorf("readAssetAsOf of an earlier snapshot: got %s (err %v), want %s", got, err, states[5])
+	}
+
+	if _, err = s.Invoke("setHistoryMode", `{"mode": "deltas"}`); err == nil {
+		t.Error("setHistoryMode should reject an unknown mode")
+	}
+}
@@ -0,0 +1,451 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+// v0.1 KL -- history stored as state deltas with periodic snapshots, states are rebuilt
+//            by replaying the deltas written since the nearest snapshot
+
+package iotcontractplatform
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+)
+
+// STATEDELTAKEY separates an asset's history deltas from its snapshots, and is prepended
+// to the assetKey
+const STATEDELTAKEY string = "IOTCP.HISTDELTA." // + assetKey + '.' + txnts
+
+// DELTACOUNTKEY holds the number of deltas written since an asset's last snapshot
+const DELTACOUNTKEY string = "IOTCP.HISTCOUNT." // + assetKey
+
+// AssetStateDelta is the change made to an asset by one transaction. Set holds the
+// properties that were added or changed, as a partial state, and Removed holds the
+// qualified names of properties that were removed.
+type AssetStateDelta struct {
+	AssetKey     string                  `json:"assetkey"`
+	Set          *map[string]interface{} `json:"set,omitempty"`
+	Removed      []string                `json:"removed,omitempty"`
+	EventIn      *map[string]interface{} `json:"eventpayload"`
+	FunctionIn   string                  `json:"eventfunction"`
+	TXNID        string                  `json:"txnid"`
+	TXNTS        *time.Time              `json:"txnts,omitempty"`
+	AlertsActive AlertNameArray          `json:"alerts,omitempty"`
+	Compliant    bool                    `json:"compliant"`
+}
+
+// AsOf is the point in time for which readAssetAsOf rebuilds an asset
+type AsOf struct {
+	AsOf string `json:"asof"`
+}
+
+// putAssetStateDelta writes the change from the asset's prior state as a delta, returning
+// false without writing when a snapshot is due instead. A snapshot is due for a new asset,
+// when the count of deltas is missing (e.g. history was deleted) and every interval deltas.
+func (a *Asset) putAssetStateDelta(stub shim.ChaincodeStubInterface, interval int) (bool, error) {
+	countKey := DELTACOUNTKEY + a.AssetKey
+	countBytes, err := stub.GetState(countKey)
+	if err != nil {
+		err = fmt.Errorf("putAssetStateDelta GETSTATE for %s failed: %s", countKey, err)
+		log.Error(err)
+		return false, err
+	}
+	count, err := strconv.Atoi(string(countBytes))
+	if err != nil || count >= interval {
+		return false, a.putDeltaCount(stub, 0)
+	}
+	prior, exists, err := GetAssetFromLedger(stub, a.AssetKey)
+	if err != nil {
+		return false, err
+	}
+	if !exists || prior.State == nil || a.State == nil {
+		return false, a.putDeltaCount(stub, 0)
+	}
+
+	var removed = make([]string, 0)
+	set := stateDelta(*prior.State, *a.State, "", &removed)
+	var d = AssetStateDelta{
+		AssetKey:     a.AssetKey,
+		Set:          &set,
+		Removed:      removed,
+		EventIn:      a.EventIn,
+		FunctionIn:   a.FunctionIn,
+		TXNID:        a.TXNID,
+		TXNTS:        a.TXNTS,
+		AlertsActive: a.AlertsActive,
+		Compliant:    a.Compliant,
+	}
+	deltaBytes, err := json.Marshal(d)
+	if err != nil {
+		err = fmt.Errorf("Failed to marshal Asset delta for history: %s", err)
+		log.Error(err)
+		return false, err
+	}
+	err = stub.PutState(STATEDELTAKEY+a.AssetKey+"."+a.TXNTS.Format(time.RFC3339Nano), deltaBytes)
+	if err != nil {
+		err = fmt.Errorf("Failed to PUT Asset history delta: %s", err)
+		log.Error(err)
+		return false, err
+	}
+	return true, a.putDeltaCount(stub, count+1)
+}
+
+func (a *Asset) putDeltaCount(stub shim.ChaincodeStubInterface, count int) error {
+	err := stub.PutState(DELTACOUNTKEY+a.AssetKey, []byte(strconv.Itoa(count)))
+	if err != nil {
+		err = fmt.Errorf("Failed to PUT history delta count for %s: %s", a.AssetKey, err)
+		log.Error(err)
+		return err
+	}
+	return nil
+}
+
+// stateDelta returns the properties of to that are new or differ from those in from,
+// descending into objects that are present in both, and appends the qualified names
+// of properties that are missing from to into removed
+func stateDelta(from map[string]interface{}, to map[string]interface{}, prefix string, removed *[]string) map[string]interface{} {
+	var set = make(map[string]interface{})
+	for k, tv := range to {
+		fv, found := from[k]
+		if !found {
+			set[k] = tv
+			continue
+		}
+		fm, fIsMap := fv.(map[string]interface{})
+		tm, tIsMap := tv.(map[string]interface{})
+		if fIsMap && tIsMap {
+			if sub := stateDelta(fm, tm, prefix+k+".", removed); len(sub) > 0 {
+				set[k] = sub
+			}
+			continue
+		}
+		if !reflect.DeepEqual(fv, tv) {
+			set[k] = tv
+		}
+	}
+	for k := range from {
+		if _, found := to[k]; !found {
+			*removed = append(*removed, prefix+k)
+		}
+	}
+	return set
+}
+
+// applyStateDelta is the inverse of stateDelta, objects are merged where the state
+// already holds an object and all other values are replaced
+func applyStateDelta(set map[string]interface{}, state map[string]interface{}) {
+	for k, v := range set {
+		vm, vIsMap := v.(map[string]interface{})
+		sm, sIsMap := state[k].(map[string]interface{})
+		if vIsMap && sIsMap {
+			applyStateDelta(vm, sm)
+			continue
+		}
+		state[k] = v
+	}
+}
+
+// apply replays the delta onto an asset rebuilt from history
+func (d AssetStateDelta) apply(a *Asset) {
+	if a.State == nil {
+		var state = make(map[string]interface{})
+		a.State = &state
+	}
+	for _, qprop := range d.Removed {
+		_ = RemoveObject(a.State, qprop)
+	}
+	if d.Set != nil {
+		applyStateDelta(*d.Set, *a.State)
+	}
+	a.EventIn = d.EventIn
+	a.FunctionIn = d.FunctionIn
+	a.TXNID = d.TXNID
+	a.TXNTS = d.TXNTS
+	a.EventOut = nil
+	a.AlertsActive = d.AlertsActive
+	a.Compliant = d.Compliant
+}
+
+// historyEntry is a snapshot or a delta read from an asset's history
+type historyEntry struct {
+	ts       string // timestamp part of the key, as written
+	txnts    time.Time
+	snapshot bool
+	value    []byte
+}
+
+type historyEntries []historyEntry
+
+func (he historyEntries) Len() int           { return len(he) }
+func (he historyEntries) Swap(i, j int)      { he[i], he[j] = he[j], he[i] }
+func (he historyEntries) Less(i, j int) bool { return he[i].txnts.Before(he[j].txnts) }
+
+// readHistoryEntries returns all snapshots and deltas for an asset, oldest first
+func readHistoryEntries(stub shim.ChaincodeStubInterface, assetKey string) (historyEntries, error) {
+	var entries = make(historyEntries, 0)
+	for _, prefix := range []string{STATEHISTORYKEY, STATEDELTAKEY} {
+		found, err := readHistoryRange(stub, prefix, assetKey, "", "}")
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, found...)
+	}
+	sort.Stable(entries)
+	return entries, nil
+}
+
+// readHistoryRange returns the snapshots or deltas (by prefix) for an asset with key
+// timestamps from from up to but not including to, oldest first
+func readHistoryRange(stub shim.ChaincodeStubInterface, prefix string, assetKey string, from string, to string) (historyEntries, error) {
+	var entries = make(historyEntries, 0)
+	var historyKey = prefix + assetKey + "."
+	iter, err := stub.RangeQueryState(historyKey+from, historyKey+to)
+	if err != nil {
+		err = fmt.Errorf("readHistoryRange failed to get a range query iterator: %s", err)
+		log.Error(err)
+		return nil, err
+	}
+	defer iter.Close()
+	for iter.HasNext() {
+		key, value, err := iter.Next()
+		if err != nil {
+			err = fmt.Errorf("readHistoryRange iter.Next() failed: %s", err)
+			log.Error(err)
+			return nil, err
+		}
+		// range queries include the end key
+		if key >= historyKey+to {
+			continue
+		}
+		ts := strings.TrimPrefix(key, historyKey)
+		txnts, err := time.Parse(time.RFC3339Nano, ts)
+		if err != nil {
+			err = fmt.Errorf("readHistoryRange history key %s has a bad timestamp: %s", key, err)
+			log.Error(err)
+			return nil, err
+		}
+		entries = append(entries, historyEntry{ts, txnts, prefix == STATEHISTORYKEY, value})
+	}
+	sort.Stable(entries)
+	return entries, nil
+}
+
+// maxSnapshotWindow bounds the backwards search for a snapshot well short of the range of
+// a time.Duration
+const maxSnapshotWindow = 100 * 365 * 24 * time.Hour
+
+// keyTime formats a time to the second as history keys are written, so that it can bound a
+// range query on history keys whatever their fraction of a second
+func keyTime(t time.Time) string {
+	return t.In(time.Local).Format("2006-01-02T15:04:05")
+}
+
+// hasHistoryBefore returns true when the asset has a snapshot or delta (by prefix) with a key
+// timestamp before to, reading no more than one key
+func hasHistoryBefore(stub shim.ChaincodeStubInterface, prefix string, assetKey string, to string) (bool, error) {
+	var historyKey = prefix + assetKey + "."
+	iter, err := stub.RangeQueryState(historyKey, historyKey+to)
+	if err != nil {
+		err = fmt.Errorf("hasHistoryBefore failed to get a range query iterator: %s", err)
+		log.Error(err)
+		return false, err
+	}
+	defer iter.Close()
+	if !iter.HasNext() {
+		return false, nil
+	}
+	key, _, err := iter.Next()
+	if err != nil {
+		err = fmt.Errorf("hasHistoryBefore iter.Next() failed: %s", err)
+		log.Error(err)
+		return false, err
+	}
+	return key < historyKey+to, nil
+}
+
+// lastSnapshotAtOrBefore returns the newest snapshot written at or before when, or nil when
+// there is none. Range queries only run forwards, so the keys are searched backwards from
+// when in windows that double in length until a snapshot is found or no keys remain, with
+// the last window reaching back to the oldest key.
+func lastSnapshotAtOrBefore(stub shim.ChaincodeStubInterface, assetKey string, when time.Time) (*historyEntry, error) {
+	var to = keyTime(when.Add(time.Second))
+	for window := time.Hour; ; window *= 2 {
+		var from string
+		if window < maxSnapshotWindow {
+			from = keyTime(when.Add(-window))
+		}
+		snapshots, err := readHistoryRange(stub, STATEHISTORYKEY, assetKey, from, to)
+		if err != nil {
+			return nil, err
+		}
+		for i := len(snapshots) - 1; i >= 0; i-- {
+			if !snapshots[i].txnts.After(when) {
+				return &snapshots[i], nil
+			}
+		}
+		if from == "" {
+			return nil, nil
+		}
+		more, err := hasHistoryBefore(stub, STATEHISTORYKEY, assetKey, from)
+		if err != nil || !more {
+			return nil, err
+		}
+		to = from
+	}
+}
+
+// replay rebuilds states from the last snapshot for which base returns true (or from the
+// oldest entry when there is none), returning copies of the states for which emit returns
+// true and the final state, which is nil when there was no snapshot to start from
+func (he historyEntries) replay(base func(historyEntry) bool, emit func(historyEntry) bool) (AssetArray, *Asset, error) {
+	var states = make(AssetArray, 0)
+	var current *Asset
+	var start int
+	for i, e := range he {
+		if e.snapshot && base(e) {
+			start = i
+		}
+	}
+	for _, e := range he[start:] {
+		if e.snapshot {
+			var state = new(Asset)
+			if err := json.Unmarshal(e.value, state); err != nil {
+				err = fmt.Errorf("replay unmarshal of snapshot at %s failed: %s", e.ts, err)
+				log.Error(err)
+				return nil, nil, err
+			}
+			current = state
+		} else {
+			if current == nil {
+				log.Warningf("replay skipped the delta at %s, which has no snapshot before it", e.ts)
+				continue
+			}
+			var d AssetStateDelta
+			if err := json.Unmarshal(e.value, &d); err != nil {
+				err = fmt.Errorf("replay unmarshal of delta at %s failed: %s", e.ts, err)
+				log.Error(err)
+				return nil, nil, err
+			}
+			d.apply(current)
+		}
+		if emit(e) {
+			states = append(states, current.copyState())
+		}
+	}
+	return states, current, nil
+}
+
+// copyState returns a copy of the asset that shares no state with it, so that replay
+// can continue to change the original
+func (a *Asset) copyState() Asset {
+	var c = *a
+	if a.State != nil {
+		state := copyValue(*a.State).(map[string]interface{})
+		c.State = &state
+	}
+	return c
+}
+
+func copyValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		var m = make(map[string]interface{}, len(t))
+		for k, mv := range t {
+			m[k] = copyValue(mv)
+		}
+		return m
+	case []interface{}:
+		var arr = make([]interface{}, len(t))
+		for i, av := range t {
+			arr[i] = copyValue(av)
+		}
+		return arr
+	}
+	return v
+}
+
+// ReadAssetAsOf rebuilds an asset as it was at a point in time by replaying the deltas
+// written since the nearest snapshot at or before that time, reading no older history
+func (c *AssetClass) ReadAssetAsOf(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	var arg = c.NewAsset()
+	var asOf AsOf
+
+	if err := arg.unmarshallEventIn(stub, args); err != nil {
+		err := fmt.Errorf("ReadAssetAsOf for class %s could not unmarshall, err is %s", c.Name, err)
+		log.Error(err)
+		return nil, err
+	}
+	assetKey, err := arg.getAssetKey(stub)
+	if err != nil {
+		err = fmt.Errorf("ReadAssetAsOf for class %s could not find id at %s, err is %s", c.Name, c.AssetIDPath, err)
+		log.Error(err)
+		return nil, err
+	}
+	err = json.Unmarshal([]byte(args[0]), &asOf)
+	if err != nil || asOf.AsOf == "" {
+		err = errors.New("ReadAssetAsOf expects an asof timestamp")
+		log.Error(err)
+		return nil, err
+	}
+	when, err := time.Parse(time.RFC3339Nano, asOf.AsOf)
+	if err != nil {
+		err = fmt.Errorf("ReadAssetAsOf asof %s is not an RFC3339 timestamp: %s", asOf.AsOf, err)
+		log.Error(err)
+		return nil, err
+	}
+
+	base, err := lastSnapshotAtOrBefore(stub, assetKey, when)
+	if err != nil {
+		return nil, err
+	}
+	if base == nil {
+		err = fmt.Errorf("ReadAssetAsOf for class %s asset %s has no history at or before %s", c.Name, assetKey, asOf.AsOf)
+		log.Error(err)
+		return nil, err
+	}
+	deltas, err := readHistoryRange(stub, STATEDELTAKEY, assetKey, keyTime(base.txnts), keyTime(when.Add(time.Second)))
+	if err != nil {
+		return nil, err
+	}
+	var entries = historyEntries{*base}
+	for _, d := range deltas {
+		if d.txnts.After(base.txnts) && !d.txnts.After(when) {
+			entries = append(entries, d)
+		}
+	}
+	sort.Stable(entries)
+	all := func(historyEntry) bool { return true }
+	none := func(historyEntry) bool { return false }
+	_, state, err := entries.replay(all, none)
+	if err != nil {
+		err = fmt.Errorf("ReadAssetAsOf for class %s asset %s failed to replay history: %s", c.Name, assetKey, err)
+		log.Error(err)
+		return nil, err
+	}
+	if state == nil {
+		err = fmt.Errorf("ReadAssetAsOf for class %s asset %s has no history at or before %s", c.Name, assetKey, asOf.AsOf)
+		log.Error(err)
+		return nil, err
+	}
+	return json.Marshal(state)
+}
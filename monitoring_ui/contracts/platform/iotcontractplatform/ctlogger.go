@@ -0,0 +1,405 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+// v0.1 KL -- structured logging for the iot chaincode platform
+
+package iotcontractplatform
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+)
+
+// REDACTED replaces the value of every configured sensitive path in logged fields
+const REDACTED string = "***REDACTED***"
+
+// DEFAULTLOGGINGLEVEL applies to every module that has not been given its own level
+const DEFAULTLOGGINGLEVEL = shim.LogInfo
+
+// names are indexed by shim.LoggingLevel, which runs from CRITICAL (0) to DEBUG (5)
+var logLevelNames = []string{
+	"CRITICAL",
+	"ERROR",
+	"WARNING",
+	"NOTICE",
+	"INFO",
+	"DEBUG",
+}
+
+// LogEntry is one JSON line as written by the contract logger. The transaction
+// properties are filled in by the router so that every line written while a
+// transaction is executing can be correlated with it on the peer.
+type LogEntry struct {
+	Timestamp  string                 `json:"ts"`
+	Level      string                 `json:"level"`
+	Module     string                 `json:"module"`
+	TxID       string                 `json:"txid,omitempty"`
+	Channel    string                 `json:"channel,omitempty"`
+	Method     string                 `json:"method,omitempty"`
+	Function   string                 `json:"function,omitempty"`
+	Class      string                 `json:"class,omitempty"`
+	AssetKey   string                 `json:"assetkey,omitempty"`
+	DurationMS *float64               `json:"durationms,omitempty"`
+	Message    string                 `json:"msg"`
+	Fields     map[string]interface{} `json:"fields,omitempty"`
+}
+
+// logContext holds the properties of a transaction that is executing
+type logContext struct {
+	txID     string
+	channel  string
+	method   string
+	function string
+	class    string
+	assetKey string
+	start    time.Time
+}
+
+// queries run in their own goroutine alongside invokes, so the context of every
+// transaction in flight is kept under its ID
+var logState = struct {
+	sync.Mutex
+	out          io.Writer
+	defaultLevel shim.LoggingLevel
+	levels       map[string]shim.LoggingLevel
+	redact       [][]string
+	txns         map[string]*logContext
+	shared       *shim.ChaincodeLogger
+}{
+	out:          os.Stdout,
+	defaultLevel: DEFAULTLOGGINGLEVEL,
+	levels:       make(map[string]shim.LoggingLevel),
+	txns:         make(map[string]*logContext),
+}
+
+// ContractLogger writes JSON lines for a named module. Modules are dot separated
+// (e.g. iotcontractplatform.filters) and inherit the level of their parent module
+// unless they have been given their own.
+type ContractLogger struct {
+	module string
+	txID   string
+}
+
+// NewContractLogger creates a structured logger for a module
+func NewContractLogger(module string) *ContractLogger {
+	return &ContractLogger{module: module}
+}
+
+// ForTransaction returns a logger whose lines always carry the stub's transaction.
+// Lines from other loggers are only correlated while a single transaction is in flight.
+func (cl *ContractLogger) ForTransaction(stub shim.ChaincodeStubInterface) *ContractLogger {
+	return &ContractLogger{module: cl.module, txID: stub.GetTxID()}
+}
+
+// SetLogOutput redirects the JSON lines for all modules, which are written to stdout by default
+func SetLogOutput(w io.Writer) {
+	logState.Lock()
+	defer logState.Unlock()
+	logState.out = w
+}
+
+// SetLoggingLevel sets the level for a module and its sub-modules. A blank module
+// sets the default level and removes all module specific levels.
+func SetLoggingLevel(module string, level shim.LoggingLevel) {
+	logState.Lock()
+	defer logState.Unlock()
+	if module == "" {
+		logState.defaultLevel = level
+		logState.levels = make(map[string]shim.LoggingLevel)
+		if logState.shared != nil {
+			logState.shared.SetLevel(level)
+		}
+		return
+	}
+	logState.levels[module] = level
+}
+
+// SetRedactedPaths replaces the list of qualified property paths (e.g. "asset.owner.name")
+// whose values are masked wherever they appear inside logged fields
+func SetRedactedPaths(paths ...string) {
+	logState.Lock()
+	defer logState.Unlock()
+	logState.redact = make([][]string, 0, len(paths))
+	for _, p := range paths {
+		if p != "" {
+			logState.redact = append(logState.redact, strings.Split(p, "."))
+		}
+	}
+}
+
+// GetLoggingLevelFromString converts one of CRITICAL, ERROR, WARNING, NOTICE, INFO
+// or DEBUG into a shim logging level
+func GetLoggingLevelFromString(level string) (shim.LoggingLevel, error) {
+	for i, name := range logLevelNames {
+		if name == level {
+			return shim.LoggingLevel(i), nil
+		}
+	}
+	return DEFAULTLOGGINGLEVEL, fmt.Errorf("unknown logging level: %s", level)
+}
+
+// levelFor walks up the module hierarchy, caller holds the lock
+func levelFor(module string) shim.LoggingLevel {
+	for m := module; m != ""; {
+		if l, found := logState.levels[m]; found {
+			return l
+		}
+		i := strings.LastIndex(m, ".")
+		if i < 0 {
+			break
+		}
+		m = m[:i]
+	}
+	return logState.defaultLevel
+}
+
+// SetLevel sets the level for this logger's module
+func (cl *ContractLogger) SetLevel(level shim.LoggingLevel) {
+	SetLoggingLevel(cl.module, level)
+}
+
+// IsEnabledFor returns true if the logger writes lines at the given level
+func (cl *ContractLogger) IsEnabledFor(level shim.LoggingLevel) bool {
+	logState.Lock()
+	defer logState.Unlock()
+	return level <= levelFor(cl.module)
+}
+
+// LogFields writes a line with structured fields, which are redacted before marshaling
+func (cl *ContractLogger) LogFields(level shim.LoggingLevel, msg string, fields map[string]interface{}) {
+	cl.write(level, msg, fields, nil)
+}
+
+// DebugFields writes a line with structured fields at DEBUG level
+func (cl *ContractLogger) DebugFields(msg string, fields map[string]interface{}) {
+	cl.write(shim.LogDebug, msg, fields, nil)
+}
+
+func (cl *ContractLogger) write(level shim.LoggingLevel, msg string, fields map[string]interface{}, duration *time.Duration) {
+	logState.Lock()
+	defer logState.Unlock()
+	if level > levelFor(cl.module) {
+		return
+	}
+	e := LogEntry{
+		Timestamp: time.Now().UTC().Format(time.RFC3339Nano),
+		Level:     logLevelNames[level],
+		Module:    cl.module,
+		Message:   strings.TrimSuffix(msg, "\n"),
+	}
+	if t := contextFor(cl.txID); t != nil {
+		e.TxID, e.Channel, e.Method, e.Function, e.Class, e.AssetKey = t.txID, t.channel, t.method, t.function, t.class, t.assetKey
+	}
+	if duration != nil {
+		ms := float64(*duration) / float64(time.Millisecond)
+		e.DurationMS = &ms
+	}
+	if len(fields) > 0 {
+		e.Fields = make(map[string]interface{}, len(fields))
+		for k, v := range fields {
+			e.Fields[k] = redactValue(v)
+		}
+	}
+	line, err := json.Marshal(e)
+	if err != nil {
+		line, _ = json.Marshal(LogEntry{Timestamp: e.Timestamp, Level: e.Level, Module: e.Module, TxID: e.TxID,
+			Message: fmt.Sprintf("%s (fields could not be marshaled: %s)", e.Message, err)})
+	}
+	logState.out.Write(append(line, '\n'))
+}
+
+// contextFor finds the transaction for a line, caller holds the lock
+func contextFor(txID string) *logContext {
+	if txID != "" {
+		return logState.txns[txID]
+	}
+	if len(logState.txns) == 1 {
+		for _, t := range logState.txns {
+			return t
+		}
+	}
+	return nil
+}
+
+// redactValue normalizes a field value through JSON so that structs and maps are
+// treated alike, and masks every configured path found at any depth
+func redactValue(v interface{}) interface{} {
+	if len(logState.redact) == 0 {
+		return v
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	var generic interface{}
+	if err = json.Unmarshal(b, &generic); err != nil {
+		return v
+	}
+	return redactWalk(generic)
+}
+
+func redactWalk(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for _, path := range logState.redact {
+			redactPath(t, path)
+		}
+		for k, child := range t {
+			t[k] = redactWalk(child)
+		}
+	case []interface{}:
+		for i, child := range t {
+			t[i] = redactWalk(child)
+		}
+	}
+	return v
+}
+
+func redactPath(m map[string]interface{}, path []string) {
+	for i, segment := range path {
+		child, found := m[segment]
+		if !found {
+			return
+		}
+		if i == len(path)-1 {
+			m[segment] = REDACTED
+			return
+		}
+		next, ok := child.(map[string]interface{})
+		if !ok {
+			return
+		}
+		m = next
+	}
+}
+
+// beginLogContext is called by the router as a transaction starts executing
+func beginLogContext(stub shim.ChaincodeStubInterface, method string, function string, class string) {
+	t := &logContext{
+		txID:     stub.GetTxID(),
+		method:   method,
+		function: function,
+		class:    class,
+		start:    time.Now(),
+	}
+	// channels arrive with fabric 1.0, so pick the ID up if the stub knows about it
+	if c, ok := stub.(interface {
+		GetChannelID() string
+	}); ok {
+		t.channel = c.GetChannelID()
+	}
+	logState.Lock()
+	logState.txns[t.txID] = t
+	logState.Unlock()
+}
+
+// setLogContextAsset records the asset on which the stub's transaction is operating
+func setLogContextAsset(stub shim.ChaincodeStubInterface, class string, assetKey string) {
+	logState.Lock()
+	defer logState.Unlock()
+	if t, found := logState.txns[stub.GetTxID()]; found {
+		t.class = class
+		t.assetKey = assetKey
+	}
+}
+
+// endLogContext writes the closing line for the stub's transaction with its duration
+func endLogContext(stub shim.ChaincodeStubInterface, err error) {
+	txID := stub.GetTxID()
+	logState.Lock()
+	t := logState.txns[txID]
+	logState.Unlock()
+	if t == nil {
+		return
+	}
+	d := time.Since(t.start)
+	txlog := log.ForTransaction(stub)
+	if err != nil {
+		txlog.write(shim.LogInfo, t.method+" failed", map[string]interface{}{"status": "ERROR", "error": err.Error()}, &d)
+	} else {
+		txlog.write(shim.LogInfo, t.method+" complete", map[string]interface{}{"status": "OK"}, &d)
+	}
+	logState.Lock()
+	delete(logState.txns, txID)
+	logState.Unlock()
+}
+
+// Critical logs a message using CRITICAL as log level.
+func (cl *ContractLogger) Critical(args ...interface{}) {
+	cl.write(shim.LogCritical, fmt.Sprint(args...), nil, nil)
+}
+
+// Criticalf logs a message using CRITICAL as log level.
+func (cl *ContractLogger) Criticalf(format string, args ...interface{}) {
+	cl.write(shim.LogCritical, fmt.Sprintf(format, args...), nil, nil)
+}
+
+// Error logs a message using ERROR as log level.
+func (cl *ContractLogger) Error(args ...interface{}) {
+	cl.write(shim.LogError, fmt.Sprint(args...), nil, nil)
+}
+
+// Errorf logs a message using ERROR as log level.
+func (cl *ContractLogger) Errorf(format string, args ...interface{}) {
+	cl.write(shim.LogError, fmt.Sprintf(format, args...), nil, nil)
+}
+
+// Warning logs a message using WARNING as log level.
+func (cl *ContractLogger) Warning(args ...interface{}) {
+	cl.write(shim.LogWarning, fmt.Sprint(args...), nil, nil)
+}
+
+// Warningf logs a message using WARNING as log level.
+func (cl *ContractLogger) Warningf(format string, args ...interface{}) {
+	cl.write(shim.LogWarning, fmt.Sprintf(format, args...), nil, nil)
+}
+
+// Notice logs a message using NOTICE as log level.
+func (cl *ContractLogger) Notice(args ...interface{}) {
+	cl.write(shim.LogNotice, fmt.Sprint(args...), nil, nil)
+}
+
+// Noticef logs a message using NOTICE as log level.
+func (cl *ContractLogger) Noticef(format string, args ...interface{}) {
+	cl.write(shim.LogNotice, fmt.Sprintf(format, args...), nil, nil)
+}
+
+// Info logs a message using INFO as log level.
+func (cl *ContractLogger) Info(args ...interface{}) {
+	cl.write(shim.LogInfo, fmt.Sprint(args...), nil, nil)
+}
+
+// Infof logs a message using INFO as log level.
+func (cl *ContractLogger) Infof(format string, args ...interface{}) {
+	cl.write(shim.LogInfo, fmt.Sprintf(format, args...), nil, nil)
+}
+
+// Debug logs a message using DEBUG as log level.
+func (cl *ContractLogger) Debug(args ...interface{}) {
+	cl.write(shim.LogDebug, fmt.Sprint(args...), nil, nil)
+}
+
+// Debugf logs a message using DEBUG as log level.
+func (cl *ContractLogger) Debugf(format string, args ...interface{}) {
+	cl.write(shim.LogDebug, fmt.Sprintf(format, args...), nil, nil)
+}
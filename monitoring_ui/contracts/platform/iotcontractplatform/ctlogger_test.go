@@ -0,0 +1,175 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+package iotcontractplatform
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+)
+
+func captureLog() *bytes.Buffer {
+	var buf bytes.Buffer
+	SetLogOutput(&buf)
+	return &buf
+}
+
+func resetLog() {
+	SetLogOutput(os.Stdout)
+	SetLoggingLevel("", DEFAULTLOGGINGLEVEL)
+	SetRedactedPaths()
+}
+
+func readLogEntries(t *testing.T, buf *bytes.Buffer) []LogEntry {
+	var entries []LogEntry
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var e LogEntry
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatalf("log line is not JSON: %s", line)
+		}
+		entries = append(entries, e)
+	}
+	buf.Reset()
+	return entries
+}
+
+func TestLoggerTransactionCorrelation(t *testing.T) {
+	defer resetLog()
+	s := newDeployedStub(t)
+	buf := captureLog()
+
+	if _, err := s.Invoke("createAsset", `{"asset": {"assetID": "A1", "temperature": -5}}`); err != nil {
+		t.Fatalf("createAsset failed: %s", err)
+	}
+	entries := readLogEntries(t, buf)
+	for _, e := range entries {
+		if e.TxID != "TXN0002" || e.AssetKey != "DEFA1" {
+			t.Errorf("every line should be correlated with the transaction: %+v", e)
+		}
+	}
+	e := entries[len(entries)-1]
+	if e.TxID != "TXN0002" || e.Method != "invoke" || e.Function != "createAsset" || e.Class != DefaultClass.Name || e.AssetKey != "DEFA1" {
+		t.Errorf("closing line is not correlated with the transaction: %+v", e)
+	}
+	if e.DurationMS == nil || e.Fields["status"] != "OK" {
+		t.Errorf("closing line should carry the duration and status: %+v", e)
+	}
+
+	if _, err := s.Invoke("createAsset", `{"asset": {"assetID": "A1", "temperature": -5}}`); err == nil {
+		t.Fatal("duplicate createAsset should fail")
+	}
+	entries = readLogEntries(t, buf)
+	last := entries[len(entries)-1]
+	if entries[0].Level != "ERROR" || entries[0].AssetKey != "DEFA1" || entries[0].TxID != last.TxID {
+		t.Errorf("error lines should carry the transaction and asset: %+v", entries[0])
+	}
+	if last.Fields["status"] != "ERROR" || last.Fields["error"] == nil {
+		t.Errorf("closing line should report the failure: %+v", last)
+	}
+
+	log.Info("outside a transaction")
+	if entries = readLogEntries(t, buf); entries[0].TxID != "" || entries[0].Function != "" {
+		t.Errorf("lines outside a transaction should not be correlated: %+v", entries[0])
+	}
+}
+
+func TestLoggerOverlappingTransactions(t *testing.T) {
+	defer resetLog()
+	s := newDeployedStub(t)
+	q := NewTestStub("1.0")
+	q.TxID = "QRY0001"
+	buf := captureLog()
+
+	beginLogContext(q, "query", "readAllAssets", "")
+	if _, err := s.Invoke("createAsset", `{"asset": {"assetID": "A1", "temperature": -5}}`); err != nil {
+		t.Fatalf("createAsset failed: %s", err)
+	}
+	entries := readLogEntries(t, buf)
+	for _, e := range entries[:len(entries)-1] {
+		if e.TxID != "" {
+			t.Errorf("lines written while two transactions are in flight should not be correlated: %+v", e)
+		}
+	}
+	e := entries[len(entries)-1]
+	if e.TxID != "TXN0002" || e.Method != "invoke" || e.AssetKey != "DEFA1" || e.Fields["status"] != "OK" {
+		t.Errorf("closing line should belong to the invoke: %+v", e)
+	}
+
+	log.Info("query only")
+	endLogContext(q, nil)
+	entries = readLogEntries(t, buf)
+	if len(entries) != 2 || entries[0].TxID != "QRY0001" || entries[0].AssetKey != "" {
+		t.Fatalf("the query should still be in flight after the invoke: %+v", entries)
+	}
+	if e := entries[1]; e.TxID != "QRY0001" || e.Function != "readAllAssets" || e.Message != "query complete" {
+		t.Errorf("closing line should belong to the query: %+v", e)
+	}
+}
+
+func TestLoggerModuleLevelsAndRedaction(t *testing.T) {
+	defer resetLog()
+	s := newDeployedStub(t)
+	if _, err := s.Invoke("createAsset", `{"asset": {"assetID": "A1", "temperature": -5, "owner": {"name": "ACME", "city": "Ottawa"}}}`); err != nil {
+		t.Fatalf("createAsset failed: %s", err)
+	}
+	if _, err := s.Invoke("setLoggingLevel", `{"logLevel": "ERROR"}`); err != nil {
+		t.Fatalf("setLoggingLevel failed: %s", err)
+	}
+	if _, err := s.Invoke("setLoggingLevel", `{"logLevel": "DEBUG", "module": "iotcontractplatform.filters", "redact": ["asset.owner.name"]}`); err != nil {
+		t.Fatalf("setLoggingLevel with module failed: %s", err)
+	}
+	if _, err := s.Invoke("setLoggingLevel", `{"logLevel": "LOUD"}`); err == nil {
+		t.Error("setLoggingLevel should reject an unknown level")
+	}
+	if !log.IsEnabledFor(shim.LogError) || log.IsEnabledFor(shim.LogInfo) {
+		t.Error("platform module should be at ERROR")
+	}
+	if !NewContractLogger("iotcontractplatform.filters.deep").IsEnabledFor(shim.LogDebug) {
+		t.Error("sub-modules should inherit the level of their parent")
+	}
+
+	buf := captureLog()
+	if _, err := s.Query("readAllAssets", `{"filter": {"match": "all", "select": [{"qprop": "asset.owner.city", "value": "Ottawa"}]}}`); err != nil {
+		t.Fatalf("readAllAssets failed: %s", err)
+	}
+	out := buf.String()
+	entries := readLogEntries(t, buf)
+	if len(entries) == 0 {
+		t.Fatal("filter module should log at DEBUG")
+	}
+	for _, e := range entries {
+		if e.Module != "iotcontractplatform.filters" || e.Function != "readAllAssets" {
+			t.Errorf("only correlated filter lines should be written: %+v", e)
+		}
+	}
+	if strings.Contains(out, "ACME") || !strings.Contains(out, REDACTED) || !strings.Contains(out, "Ottawa") {
+		t.Errorf("owner name should be redacted and nothing else: %s", out)
+	}
+
+	SetRedactedPaths()
+	log.LogFields(shim.LogCritical, "no redaction", map[string]interface{}{"asset": map[string]interface{}{"owner": map[string]interface{}{"name": "ACME"}}})
+	if entries = readLogEntries(t, buf); !strings.Contains(entries[0].Fields["asset"].(map[string]interface{})["owner"].(map[string]interface{})["name"].(string), "ACME") {
+		t.Errorf("clearing the redacted paths should log values as is: %+v", entries[0])
+	}
+}
@@ -42,7 +42,7 @@ func AsMap(obj interface{}) (toMap map[string]interface{}, ok bool) {
 		}
 	}
 	err = fmt.Errorf("AsMap: incoming type is %T and is not understood", obj)
-	log.Errorf(err.Error())
+	log.Error(err)
 	return nil, false
 }
 
@@ -56,7 +56,7 @@ func AsStringArray(obj interface{}) (toSarr []string, ok bool) {
 			sel, ok := el.(string)
 			if !ok {
 				err = fmt.Errorf("AsStringArray: incoming element %d type is %T from array %#v and is not understood", i, el, obj)
-				log.Errorf(err.Error())
+				log.Error(err)
 				return nil, false
 			}
 			toSarr = append(toSarr, sel)
@@ -78,14 +78,14 @@ func AsStringArray(obj interface{}) (toSarr []string, ok bool) {
 			if err == nil {
 				return AsStringArray(interface{}(data))
 			}
-			log.Errorf(err.Error())
+			log.Error(err)
 			return make([]string, 0), false
 		}
 		// 4. a non-JSON string, just return that as an array
 		return []string{as}, true
 	}
 	err = fmt.Errorf("AsStringArray: incoming type is %T and is not understood", obj)
-	log.Errorf(err.Error())
+	log.Error(err)
 	return make([]string, 0), false
 }
 
@@ -60,7 +60,7 @@ func GETRecentStatesFromLedger(stub shim.ChaincodeStubInterface) (RecentStates,
 	recentStatesBytes, err := stub.GetState(RECENTSTATESKEY)
 	if err != nil {
 		err = fmt.Errorf("Failed to get recent states from world state: %s", err)
-		log.Errorf(err.Error())
+		log.Error(err)
 		return rstates, err
 	}
 	// this MUST be here
@@ -73,7 +73,7 @@ func GETRecentStatesFromLedger(stub shim.ChaincodeStubInterface) (RecentStates,
 		err = PUTRecentStatesToLedger(stub, rstates)
 		if err != nil {
 			err = fmt.Errorf("Failed to store empty recent states: %s", err)
-			log.Errorf(err.Error())
+			log.Error(err)
 			return rstates, err
 		}
 	}
@@ -227,12 +227,12 @@ var readRecentStates = func(stub shim.ChaincodeStubInterface, args []string) ([]
 		a, exists, err := GetAssetFromLedger(stub, r.States[i])
 		if err != nil {
 			err = fmt.Errorf("readRecentStates: failed to get asset from ledger: %s", err)
-			log.Errorf(err.Error())
+			log.Error(err)
 			return nil, err
 		}
 		if !exists {
 			err = fmt.Errorf("readRecentStates: recent asset state does not exist: %s", err)
-			log.Errorf(err.Error())
+			log.Error(err)
 			return nil, err
 		}
 		rstatesout = append(rstatesout, a)
@@ -0,0 +1,640 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+// v0.1 KL -- typed relationships between assets of any class, stored as one world state
+//            key per edge:
+//               IOTCPRelChild  (relation, parent, child)  ranged to find the children of an asset
+//               IOTCPRelParent (relation, child)          the one parent of an asset in a relation
+//            Each edge key is the edge type followed by its asset keys, with U+0000 before
+//            and after every part, so that a range over (relation, parent) can never pick
+//            up the edges of a parent whose key merely starts with the same characters.
+
+package iotcontractplatform
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+)
+
+// world state object types for relationship edges
+const (
+	RELCHILDEDGE  = "IOTCPRelChild"
+	RELPARENTEDGE = "IOTCPRelParent"
+)
+
+// The relations known to every contract. Edges always run from parent to child, so a
+// container contains a kit, an engine is installed-on an aircraft (the aircraft is the
+// parent) and a carrier is custodian-of a shipment.
+const (
+	RELCONTAINS    = "contains"
+	RELINSTALLEDON = "installed-on"
+	RELCUSTODIANOF = "custodian-of"
+)
+
+const compositeKeySeparator = "\x00"
+const compositeKeyMaxRune = "\U0010FFFF"
+
+var relations = make(map[string]struct{}, 0)
+
+// AssetRef identifies an asset of any class in a relationship
+type AssetRef struct {
+	Class    string `json:"class"`
+	AssetID  string `json:"assetID"`
+	AssetKey string `json:"assetkey,omitempty"`
+}
+
+// AssetEdge links a parent asset to a child asset with a typed relation
+type AssetEdge struct {
+	Relation string     `json:"relation"`
+	Parent   AssetRef   `json:"parent"`
+	Child    AssetRef   `json:"child"`
+	TXNID    string     `json:"txnid"`
+	TXNTS    *time.Time `json:"txnts,omitempty"`
+}
+
+// AncestryEdge is an edge on the way up from an asset, depth 1 holds its own parents
+type AncestryEdge struct {
+	AssetEdge
+	Depth int `json:"depth"`
+}
+
+// RelationArgs is the argument to linkAssets, unlinkAssets and the relationship queries,
+// which take the asset in "asset" and an optional relation
+type RelationArgs struct {
+	Relation string   `json:"relation"`
+	Parent   AssetRef `json:"parent"`
+	Child    AssetRef `json:"child"`
+	Asset    AssetRef `json:"asset"`
+}
+
+// PropagationFunc applies a change to a parent asset to one of its descendants, usually by
+// copying properties from the parent's state into the child's state. The child is written
+// back with its own rules and history when the function returns without error.
+type PropagationFunc func(stub shim.ChaincodeStubInterface, parent *Asset, child *Asset) error
+
+// Propagation stores a propagation for an asset class along a relation
+type Propagation struct {
+	Relation string
+	Class    AssetClass
+	Function PropagationFunc
+}
+
+var propagations = make(map[AssetClass][]Propagation, 0)
+
+// AddRelation allows a contract to register a relation in addition to the defaults
+func AddRelation(relation string) error {
+	if _, found := relations[relation]; found {
+		err := fmt.Errorf("AddRelation: relation %s is already registered", relation)
+		log.Error(err)
+		return err
+	}
+	relations[relation] = struct{}{}
+	log.Debugf("Added relation %s", relation)
+	return nil
+}
+
+// AddPropagation allows a class to push its updates down a relation, e.g. a container's
+// temperature into the kits it contains. The function is called for every descendant in
+// the relation, to any depth, after each create, update, replace or property delete of an
+// asset of the class. Propagated writes do not propagate further.
+func AddPropagation(relation string, class AssetClass, f PropagationFunc) error {
+	if _, found := relations[relation]; !found {
+		err := fmt.Errorf("AddPropagation: class %s attempt to propagate along unknown relation %s", class.Name, relation)
+		log.Error(err)
+		return err
+	}
+	for _, p := range propagations[class] {
+		if p.Relation == relation {
+			err := fmt.Errorf("AddPropagation: class %s already propagates along relation %s", class.Name, relation)
+			log.Error(err)
+			return err
+		}
+	}
+	propagations[class] = append(propagations[class], Propagation{relation, class, f})
+	log.Debugf("Class %s added propagation along relation %s", class.Name, relation)
+	return nil
+}
+
+// PropagateProperties returns a PropagationFunc that copies properties from the parent's
+// state to the child's state, mapping parent qualified property names to child names.
+// Properties that the parent does not have are left alone in the child.
+func PropagateProperties(qprops map[string]string) PropagationFunc {
+	return func(stub shim.ChaincodeStubInterface, parent *Asset, child *Asset) error {
+		for from, to := range qprops {
+			v, found := GetObject(parent.State, from)
+			if !found {
+				continue
+			}
+			if !PutObject(child.State, to, v) {
+				return fmt.Errorf("PropagateProperties failed to put %s into %s", to, child.AssetKey)
+			}
+		}
+		return nil
+	}
+}
+
+// createCompositeKey joins an edge type and its attributes into a single world state key
+func createCompositeKey(objectType string, attributes []string) string {
+	key := compositeKeySeparator + objectType + compositeKeySeparator
+	for _, a := range attributes {
+		key += a + compositeKeySeparator
+	}
+	return key
+}
+
+// rangeCompositeKey returns the values of all keys that start with the partial key, in key order
+func rangeCompositeKey(stub shim.ChaincodeStubInterface, objectType string, attributes []string) ([][]byte, error) {
+	prefix := createCompositeKey(objectType, attributes)
+	iter, err := stub.RangeQueryState(prefix, prefix+compositeKeyMaxRune)
+	if err != nil {
+		err = fmt.Errorf("rangeCompositeKey failed to get a range query iterator for %s: %s", objectType, err)
+		log.Error(err)
+		return nil, err
+	}
+	defer iter.Close()
+	var results = make([][]byte, 0)
+	for iter.HasNext() {
+		_, valueBytes, err := iter.Next()
+		if err != nil {
+			err = fmt.Errorf("rangeCompositeKey iter.Next() failed for %s: %s", objectType, err)
+			log.Error(err)
+			return nil, err
+		}
+		results = append(results, valueBytes)
+	}
+	return results, nil
+}
+
+// classByName finds a class by name among the registered routes
+func classByName(name string) (AssetClass, bool) {
+	for _, r := range router {
+		if r.Class.Name == name && r.Class != SystemClass {
+			return r.Class, true
+		}
+	}
+	return AssetClass{}, false
+}
+
+// resolve fills in the asset key and checks that the asset exists
+func (ref *AssetRef) resolve(stub shim.ChaincodeStubInterface) error {
+	c, found := classByName(ref.Class)
+	if !found {
+		return fmt.Errorf("asset class %s is not registered", ref.Class)
+	}
+	if ref.AssetID == "" {
+		return fmt.Errorf("asset of class %s has no assetID", ref.Class)
+	}
+	ref.AssetKey = c.Prefix + ref.AssetID
+	_, exists, err := c.getAssetFromWorldState(stub, ref.AssetKey)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("asset %s of class %s does not exist", ref.AssetID, ref.Class)
+	}
+	return nil
+}
+
+func checkRelation(relation string) error {
+	if _, found := relations[relation]; !found {
+		return fmt.Errorf("relation %s is not registered", relation)
+	}
+	return nil
+}
+
+// relationsOrAll returns the named relation, or all relations in name order when it is blank
+func relationsOrAll(relation string) ([]string, error) {
+	if relation != "" {
+		return []string{relation}, checkRelation(relation)
+	}
+	var all = make([]string, 0, len(relations))
+	for r := range relations {
+		all = append(all, r)
+	}
+	sort.Strings(all)
+	return all, nil
+}
+
+func getUnmarshalledRelationArgs(function string, args []string) (RelationArgs, error) {
+	var ra RelationArgs
+	if len(args) != 1 {
+		err := fmt.Errorf("%s expects a single parameter", function)
+		log.Error(err)
+		return ra, err
+	}
+	err := json.Unmarshal([]byte(args[0]), &ra)
+	if err != nil {
+		err = fmt.Errorf("%s failed to unmarshal arg: %s", function, err)
+		log.Error(err)
+		return ra, err
+	}
+	return ra, nil
+}
+
+// GetParentEdge returns the edge to an asset's parent in a relation
+func GetParentEdge(stub shim.ChaincodeStubInterface, relation string, assetKey string) (AssetEdge, bool, error) {
+	var edge AssetEdge
+	edgeBytes, err := stub.GetState(createCompositeKey(RELPARENTEDGE, []string{relation, assetKey}))
+	if err != nil {
+		err = fmt.Errorf("GetParentEdge failed to get %s parent edge for %s: %s", relation, assetKey, err)
+		log.Error(err)
+		return edge, false, err
+	}
+	if len(edgeBytes) == 0 {
+		return edge, false, nil
+	}
+	err = json.Unmarshal(edgeBytes, &edge)
+	if err != nil {
+		err = fmt.Errorf("GetParentEdge failed to unmarshal %s parent edge for %s: %s", relation, assetKey, err)
+		log.Error(err)
+		return edge, false, err
+	}
+	return edge, true, nil
+}
+
+// GetChildEdges returns the edges to an asset's children in a relation
+func GetChildEdges(stub shim.ChaincodeStubInterface, relation string, assetKey string) ([]AssetEdge, error) {
+	edgesBytes, err := rangeCompositeKey(stub, RELCHILDEDGE, []string{relation, assetKey})
+	if err != nil {
+		return nil, err
+	}
+	var edges = make([]AssetEdge, 0, len(edgesBytes))
+	for _, edgeBytes := range edgesBytes {
+		var edge AssetEdge
+		err = json.Unmarshal(edgeBytes, &edge)
+		if err != nil {
+			err = fmt.Errorf("GetChildEdges failed to unmarshal %s child edge for %s: %s", relation, assetKey, err)
+			log.Error(err)
+			return nil, err
+		}
+		edges = append(edges, edge)
+	}
+	return edges, nil
+}
+
+// GetDescendantEdges returns the edges below an asset in a relation, depth first
+func GetDescendantEdges(stub shim.ChaincodeStubInterface, relation string, assetKey string) ([]AssetEdge, error) {
+	children, err := GetChildEdges(stub, relation, assetKey)
+	if err != nil {
+		return nil, err
+	}
+	var descendants = make([]AssetEdge, 0)
+	for _, edge := range children {
+		descendants = append(descendants, edge)
+		below, err := GetDescendantEdges(stub, relation, edge.Child.AssetKey)
+		if err != nil {
+			return nil, err
+		}
+		descendants = append(descendants, below...)
+	}
+	return descendants, nil
+}
+
+// GetAncestry returns the edges above an asset in the given relations, nearest first. An
+// asset has at most one parent per relation, so with several relations the ancestry is a
+// graph, which is walked breadth first and visits each ancestor once.
+func GetAncestry(stub shim.ChaincodeStubInterface, rels []string, assetKey string) ([]AncestryEdge, error) {
+	var ancestry = make([]AncestryEdge, 0)
+	var visited = map[string]struct{}{assetKey: {}}
+	var level = []string{assetKey}
+	for depth := 1; len(level) > 0; depth++ {
+		var next = make([]string, 0)
+		for _, key := range level {
+			for _, relation := range rels {
+				edge, found, err := GetParentEdge(stub, relation, key)
+				if err != nil {
+					return nil, err
+				}
+				if !found {
+					continue
+				}
+				ancestry = append(ancestry, AncestryEdge{edge, depth})
+				if _, seen := visited[edge.Parent.AssetKey]; !seen {
+					visited[edge.Parent.AssetKey] = struct{}{}
+					next = append(next, edge.Parent.AssetKey)
+				}
+			}
+		}
+		level = next
+	}
+	return ancestry, nil
+}
+
+func putEdge(stub shim.ChaincodeStubInterface, edge AssetEdge) error {
+	edgeBytes, err := json.Marshal(&edge)
+	if err != nil {
+		err = fmt.Errorf("putEdge failed to marshal %s edge %s -> %s: %s", edge.Relation, edge.Parent.AssetKey, edge.Child.AssetKey, err)
+		log.Error(err)
+		return err
+	}
+	err = stub.PutState(createCompositeKey(RELCHILDEDGE, []string{edge.Relation, edge.Parent.AssetKey, edge.Child.AssetKey}), edgeBytes)
+	if err != nil {
+		err = fmt.Errorf("putEdge failed to put %s child edge %s -> %s: %s", edge.Relation, edge.Parent.AssetKey, edge.Child.AssetKey, err)
+		log.Error(err)
+		return err
+	}
+	err = stub.PutState(createCompositeKey(RELPARENTEDGE, []string{edge.Relation, edge.Child.AssetKey}), edgeBytes)
+	if err != nil {
+		err = fmt.Errorf("putEdge failed to put %s parent edge %s -> %s: %s", edge.Relation, edge.Parent.AssetKey, edge.Child.AssetKey, err)
+		log.Error(err)
+		return err
+	}
+	return nil
+}
+
+func delEdge(stub shim.ChaincodeStubInterface, edge AssetEdge) error {
+	err := stub.DelState(createCompositeKey(RELCHILDEDGE, []string{edge.Relation, edge.Parent.AssetKey, edge.Child.AssetKey}))
+	if err != nil {
+		err = fmt.Errorf("delEdge failed to delete %s child edge %s -> %s: %s", edge.Relation, edge.Parent.AssetKey, edge.Child.AssetKey, err)
+		log.Error(err)
+		return err
+	}
+	err = stub.DelState(createCompositeKey(RELPARENTEDGE, []string{edge.Relation, edge.Child.AssetKey}))
+	if err != nil {
+		err = fmt.Errorf("delEdge failed to delete %s parent edge %s -> %s: %s", edge.Relation, edge.Parent.AssetKey, edge.Child.AssetKey, err)
+		log.Error(err)
+		return err
+	}
+	return nil
+}
+
+// removeAssetEdges deletes every edge to and from an asset, so that a deleted asset
+// leaves no dangling relationships
+func removeAssetEdges(stub shim.ChaincodeStubInterface, assetKey string) error {
+	for r := range relations {
+		edge, found, err := GetParentEdge(stub, r, assetKey)
+		if err != nil {
+			return err
+		}
+		if found {
+			if err = delEdge(stub, edge); err != nil {
+				return err
+			}
+		}
+		children, err := GetChildEdges(stub, r, assetKey)
+		if err != nil {
+			return err
+		}
+		for _, child := range children {
+			if err = delEdge(stub, child); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// propagate runs the propagations registered for the asset's class against all of its
+// descendants in each relation, returning the keys of the assets written by relation
+func (a *Asset) propagate(stub shim.ChaincodeStubInterface) (map[string][]string, error) {
+	var written = make(map[string][]string, 0)
+	for _, p := range propagations[a.Class] {
+		descendants, err := GetDescendantEdges(stub, p.Relation, a.AssetKey)
+		if err != nil {
+			return nil, err
+		}
+		var propagated = make([]string, 0, len(descendants))
+		for _, edge := range descendants {
+			child, exists, err := GetAssetFromLedger(stub, edge.Child.AssetKey)
+			if err != nil {
+				return nil, err
+			}
+			if !exists {
+				continue
+			}
+			child.EventIn = a.EventIn
+			child.FunctionIn = a.FunctionIn
+			child.EventOut = nil
+			if err = p.Function(stub, a, &child); err != nil {
+				err = fmt.Errorf("propagate along %s from %s to %s failed: %s", p.Relation, a.AssetKey, child.AssetKey, err)
+				log.Error(err)
+				return nil, err
+			}
+			if err = child.addTXNTimestampToState(stub); err != nil {
+				return nil, err
+			}
+			if err = child.ExecuteRules(stub); err != nil {
+				err = fmt.Errorf("propagate along %s from %s failed in rules engine for %s, err is %s", p.Relation, a.AssetKey, child.AssetKey, err)
+				log.Error(err)
+				return nil, err
+			}
+			if _, err = child.putMarshalledState(stub); err != nil {
+				return nil, err
+			}
+			propagated = append(propagated, child.AssetKey)
+		}
+		if len(propagated) > 0 {
+			written[p.Relation] = propagated
+		}
+	}
+	return written, nil
+}
+
+// ************************************
+// linkAssets
+// ************************************
+
+// linkAssets links a child to a parent, moving the child if it already has a parent in
+// the relation
+var linkAssets ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	ra, err := getUnmarshalledRelationArgs("linkAssets", args)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkRelation(ra.Relation); err != nil {
+		err = fmt.Errorf("linkAssets: %s", err)
+		log.Error(err)
+		return nil, err
+	}
+	for _, ref := range []*AssetRef{&ra.Parent, &ra.Child} {
+		if err = ref.resolve(stub); err != nil {
+			err = fmt.Errorf("linkAssets: %s", err)
+			log.Error(err)
+			return nil, err
+		}
+	}
+	if ra.Parent.AssetKey == ra.Child.AssetKey {
+		err = errors.New("linkAssets cannot link an asset to itself")
+		log.Error(err)
+		return nil, err
+	}
+	ancestry, err := GetAncestry(stub, []string{ra.Relation}, ra.Parent.AssetKey)
+	if err != nil {
+		return nil, err
+	}
+	for _, edge := range ancestry {
+		if edge.Parent.AssetKey == ra.Child.AssetKey {
+			err = fmt.Errorf("linkAssets: %s is above %s in relation %s, the link would make a cycle", ra.Child.AssetKey, ra.Parent.AssetKey, ra.Relation)
+			log.Error(err)
+			return nil, err
+		}
+	}
+
+	old, found, err := GetParentEdge(stub, ra.Relation, ra.Child.AssetKey)
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		if old.Parent.AssetKey == ra.Parent.AssetKey {
+			return nil, nil
+		}
+		if err = delEdge(stub, old); err != nil {
+			return nil, err
+		}
+	}
+
+	var edge = AssetEdge{Relation: ra.Relation, Parent: ra.Parent, Child: ra.Child, TXNID: stub.GetTxID()}
+	txnunixtime, err := stub.GetTxTimestamp()
+	if err != nil {
+		err = fmt.Errorf("linkAssets error getting transaction timestamp, err is %s", err)
+		log.Error(err)
+		return nil, err
+	}
+	if txnunixtime != nil {
+		txntimestamp := time.Unix(txnunixtime.Seconds, int64(txnunixtime.Nanos))
+		edge.TXNTS = &txntimestamp
+	}
+	return nil, putEdge(stub, edge)
+}
+
+// ************************************
+// unlinkAssets
+// ************************************
+var unlinkAssets ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	ra, err := getUnmarshalledRelationArgs("unlinkAssets", args)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkRelation(ra.Relation); err != nil {
+		err = fmt.Errorf("unlinkAssets: %s", err)
+		log.Error(err)
+		return nil, err
+	}
+	for _, ref := range []*AssetRef{&ra.Parent, &ra.Child} {
+		c, found := classByName(ref.Class)
+		if !found {
+			err = fmt.Errorf("unlinkAssets: asset class %s is not registered", ref.Class)
+			log.Error(err)
+			return nil, err
+		}
+		ref.AssetKey = c.Prefix + ref.AssetID
+	}
+	edge, found, err := GetParentEdge(stub, ra.Relation, ra.Child.AssetKey)
+	if err != nil {
+		return nil, err
+	}
+	if !found || edge.Parent.AssetKey != ra.Parent.AssetKey {
+		err = fmt.Errorf("unlinkAssets: %s is not linked to %s in relation %s", ra.Child.AssetKey, ra.Parent.AssetKey, ra.Relation)
+		log.Error(err)
+		return nil, err
+	}
+	return nil, delEdge(stub, edge)
+}
+
+// ************************************
+// readAssetParents
+// ************************************
+
+// readAssetParents returns the edges to the asset's parents, one per relation at most
+var readAssetParents ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	ra, rels, err := getRelationQuery("readAssetParents", stub, args)
+	if err != nil {
+		return nil, err
+	}
+	var parents = make([]AssetEdge, 0)
+	for _, relation := range rels {
+		edge, found, err := GetParentEdge(stub, relation, ra.Asset.AssetKey)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			parents = append(parents, edge)
+		}
+	}
+	return json.Marshal(parents)
+}
+
+// ************************************
+// readAssetChildren
+// ************************************
+var readAssetChildren ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	ra, rels, err := getRelationQuery("readAssetChildren", stub, args)
+	if err != nil {
+		return nil, err
+	}
+	var children = make([]AssetEdge, 0)
+	for _, relation := range rels {
+		edges, err := GetChildEdges(stub, relation, ra.Asset.AssetKey)
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, edges...)
+	}
+	return json.Marshal(children)
+}
+
+// ************************************
+// readAssetAncestry
+// ************************************
+
+// readAssetAncestry returns every edge above the asset in the relation, or in all relations
+// when none is given, nearest first
+var readAssetAncestry ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	ra, rels, err := getRelationQuery("readAssetAncestry", stub, args)
+	if err != nil {
+		return nil, err
+	}
+	ancestry, err := GetAncestry(stub, rels, ra.Asset.AssetKey)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(ancestry)
+}
+
+func getRelationQuery(function string, stub shim.ChaincodeStubInterface, args []string) (RelationArgs, []string, error) {
+	ra, err := getUnmarshalledRelationArgs(function, args)
+	if err != nil {
+		return ra, nil, err
+	}
+	rels, err := relationsOrAll(ra.Relation)
+	if err != nil {
+		err = fmt.Errorf("%s: %s", function, err)
+		log.Error(err)
+		return ra, nil, err
+	}
+	if err = ra.Asset.resolve(stub); err != nil {
+		err = fmt.Errorf("%s: %s", function, err)
+		log.Error(err)
+		return ra, nil, err
+	}
+	return ra, rels, nil
+}
+
+func init() {
+	for _, r := range []string{RELCONTAINS, RELINSTALLEDON, RELCUSTODIANOF} {
+		AddRelation(r)
+	}
+	AddRoute("linkAssets", "invoke", SystemClass, linkAssets)
+	AddRoute("unlinkAssets", "invoke", SystemClass, unlinkAssets)
+	AddRoute("readAssetParents", "query", SystemClass, readAssetParents)
+	AddRoute("readAssetChildren", "query", SystemClass, readAssetChildren)
+	AddRoute("readAssetAncestry", "query", SystemClass, readAssetAncestry)
+}
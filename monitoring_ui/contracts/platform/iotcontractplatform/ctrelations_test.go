@@ -0,0 +1,150 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+package iotcontractplatform
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+)
+
+var testContainerClass = AssetClass{
+	Name:        "container",
+	Prefix:      "CON",
+	AssetIDPath: "container.barcode",
+}
+
+func init() {
+	AddRoute("createAssetContainer", "invoke", testContainerClass, func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+		return testContainerClass.CreateAsset(stub, args, "createAssetContainer", []QPropNV{})
+	})
+	AddRoute("updateAssetContainer", "invoke", testContainerClass, func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+		return testContainerClass.UpdateAsset(stub, args, "updateAssetContainer", []QPropNV{})
+	})
+	AddPropagation(RELCONTAINS, testContainerClass, PropagateProperties(map[string]string{"container.temperature": "asset.temperature"}))
+}
+
+func link(relation string, parentClass string, parentID string, childClass string, childID string) string {
+	return fmt.Sprintf(`{"relation": "%s", "parent": {"class": "%s", "assetID": "%s"}, "child": {"class": "%s", "assetID": "%s"}}`,
+		relation, parentClass, parentID, childClass, childID)
+}
+
+func readEdges(t *testing.T, s *TestStub, function string, assetClass string, assetID string, edges interface{}) {
+	result, err := s.Query(function, fmt.Sprintf(`{"asset": {"class": "%s", "assetID": "%s"}}`, assetClass, assetID))
+	if err == nil {
+		err = json.Unmarshal(result, edges)
+	}
+	if err != nil {
+		t.Fatalf("%s for %s failed: %s", function, assetID, err)
+	}
+}
+
+func TestAssetRelations(t *testing.T) {
+	s := newDeployedStub(t)
+	for _, step := range []crudStep{
+		{function: "createAssetContainer", arg: `{"container": {"barcode": "C1", "temperature": -5}}`},
+		{function: "createAssetContainer", arg: `{"container": {"barcode": "C2", "temperature": -5}}`},
+		{function: "createAsset", arg: `{"asset": {"assetID": "A1", "temperature": -5}}`},
+		{function: "createAsset", arg: `{"asset": {"assetID": "A2", "temperature": -5}}`},
+		{function: "createAsset", arg: `{"asset": {"assetID": "A3", "temperature": -5}}`},
+		{function: "linkAssets", arg: link(RELCONTAINS, "container", "C1", "default", "A1")},
+		{function: "linkAssets", arg: link(RELCONTAINS, "default", "A1", "default", "A2")},
+		{function: "linkAssets", arg: link(RELCUSTODIANOF, "container", "C2", "container", "C1")},
+	} {
+		if _, err := s.Invoke(step.function, step.arg); err != nil {
+			t.Fatalf("%s failed: %s", step.function, err)
+		}
+	}
+
+	for _, arg := range []string{
+		link(RELCONTAINS, "default", "A2", "container", "C1"),
+		link(RELCONTAINS, "default", "A1", "default", "A1"),
+		link("owns", "default", "A1", "default", "A3"),
+		link(RELCONTAINS, "default", "A1", "default", "A9"),
+	} {
+		if _, err := s.Invoke("linkAssets", arg); err == nil {
+			t.Errorf("linkAssets %s should fail", arg)
+		}
+	}
+
+	var edges []AssetEdge
+	readEdges(t, s, "readAssetChildren", "container", "C1", &edges)
+	if len(edges) != 1 || edges[0].Child.AssetKey != "DEFA1" || edges[0].Relation != RELCONTAINS {
+		t.Errorf("C1 should contain only A1, got %+v", edges)
+	}
+	readEdges(t, s, "readAssetParents", "default", "A2", &edges)
+	if len(edges) != 1 || edges[0].Parent.AssetKey != "DEFA1" {
+		t.Errorf("A2 should have A1 as its only parent, got %+v", edges)
+	}
+
+	var ancestry []AncestryEdge
+	readEdges(t, s, "readAssetAncestry", "default", "A2", &ancestry)
+	var want = []struct {
+		relation string
+		parent   string
+		depth    int
+	}{{RELCONTAINS, "DEFA1", 1}, {RELCONTAINS, "CONC1", 2}, {RELCUSTODIANOF, "CONC2", 3}}
+	if len(ancestry) != len(want) {
+		t.Fatalf("A2 ancestry has %d edges, want %d: %+v", len(ancestry), len(want), ancestry)
+	}
+	for i, w := range want {
+		if ancestry[i].Relation != w.relation || ancestry[i].Parent.AssetKey != w.parent || ancestry[i].Depth != w.depth {
+			t.Errorf("A2 ancestry edge %d is %+v, want %+v", i, ancestry[i], w)
+		}
+	}
+
+	// the container's temperature propagates to everything it contains, to any depth
+	if _, err := s.Invoke("updateAssetContainer", `{"container": {"barcode": "C1", "temperature": 3}}`); err != nil {
+		t.Fatalf("updateAssetContainer failed: %s", err)
+	}
+	s.AssertCompliance(t, DefaultClass, "A1", false, overtempAlert)
+	s.AssertCompliance(t, DefaultClass, "A2", false, overtempAlert)
+	s.AssertCompliance(t, DefaultClass, "A3", true)
+	if history, _ := s.ReadHistory("readAssetStateHistory", DefaultClass, "A2"); len(history) != 2 || history[0].FunctionIn != "updateAssetContainer" {
+		t.Errorf("A2 history should end with the propagated update, got %+v", history)
+	}
+	if event, _ := s.LastEvent(); event["propagated"] == nil {
+		t.Errorf("the update event should list the propagated assets, got %+v", event)
+	}
+
+	// relinking moves the child
+	if _, err := s.Invoke("linkAssets", link(RELCONTAINS, "container", "C2", "default", "A1")); err != nil {
+		t.Fatalf("linkAssets move failed: %s", err)
+	}
+	if readEdges(t, s, "readAssetChildren", "container", "C1", &edges); len(edges) != 0 {
+		t.Errorf("C1 should be empty after A1 moved, got %+v", edges)
+	}
+	if _, err := s.Invoke("unlinkAssets", link(RELCONTAINS, "container", "C1", "default", "A1")); err == nil {
+		t.Error("unlinkAssets from the old parent should fail")
+	}
+	if _, err := s.Invoke("unlinkAssets", link(RELCONTAINS, "container", "C2", "default", "A1")); err != nil {
+		t.Fatalf("unlinkAssets failed: %s", err)
+	}
+	if readEdges(t, s, "readAssetParents", "default", "A1", &edges); len(edges) != 0 {
+		t.Errorf("A1 should have no parents after unlink, got %+v", edges)
+	}
+
+	// deleting an asset removes its edges
+	if _, err := s.Invoke("deleteAsset", `{"asset": {"assetID": "A1"}}`); err != nil {
+		t.Fatalf("deleteAsset failed: %s", err)
+	}
+	if readEdges(t, s, "readAssetParents", "default", "A2", &edges); len(edges) != 0 {
+		t.Errorf("A2 should have no parents after A1 was deleted, got %+v", edges)
+	}
+}
@@ -101,7 +101,9 @@ func setStubEvent(stub shim.ChaincodeStubInterface, err error, info map[string]i
 }
 
 // Init is called by deploy messages
-func Init(stub shim.ChaincodeStubInterface, function string, args []string, ContractVersion string) ([]byte, error) {
+func Init(stub shim.ChaincodeStubInterface, function string, args []string, ContractVersion string) (result []byte, err error) {
+	beginLogContext(stub, "deploy", function, "")
+	defer func() { endLogContext(stub, err) }()
 	var iargs = make([]string, 2)
 	if len(args) == 0 {
 		err := fmt.Errorf("Init received no args, expecting a json object in args[0]")
@@ -132,7 +134,9 @@ func Init(stub shim.ChaincodeStubInterface, function string, args []string, Cont
 }
 
 // Invoke is called when an invoke message is received
-func Invoke(stub shim.ChaincodeStubInterface, function string, args []string) ([]byte, error) {
+func Invoke(stub shim.ChaincodeStubInterface, function string, args []string) (result []byte, err error) {
+	beginLogContext(stub, "invoke", function, "")
+	defer func() { endLogContext(stub, err) }()
 	var r ChaincodeRoute
 	r, found := router[function]
 	if !found {
@@ -141,6 +145,7 @@ func Invoke(stub shim.ChaincodeStubInterface, function string, args []string) ([
 		setStubEvent(stub, err, nil)
 		return nil, err
 	}
+	setLogContextAsset(stub, r.Class.Name, "")
 	eventToReportBytes, err := r.Function(stub, args)
 	if err != nil {
 		err := fmt.Errorf("Invoke (%s) failed with error %s", function, err)
@@ -165,7 +170,9 @@ func Invoke(stub shim.ChaincodeStubInterface, function string, args []string) ([
 }
 
 // Query is called when a query message is received
-func Query(stub shim.ChaincodeStubInterface, function string, args []string) ([]byte, error) {
+func Query(stub shim.ChaincodeStubInterface, function string, args []string) (result []byte, err error) {
+	beginLogContext(stub, "query", function, "")
+	defer func() { endLogContext(stub, err) }()
 	var r ChaincodeRoute
 	r, found := router[function]
 	if !found {
@@ -173,7 +180,8 @@ func Query(stub shim.ChaincodeStubInterface, function string, args []string) ([]
 		log.Error(err)
 		return nil, err
 	}
-	result, err := r.Function(stub, args)
+	setLogContextAsset(stub, r.Class.Name, "")
+	result, err = r.Function(stub, args)
 	if err != nil {
 		err := fmt.Errorf("Query (%s) failed with error %s", function, err)
 		log.Error(err)
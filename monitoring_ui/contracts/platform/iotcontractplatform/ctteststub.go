@@ -0,0 +1,467 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+// v0.1 KL -- in-memory stub and helpers for testing contracts built on the platform
+
+package iotcontractplatform
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/chaincode/shim/crypto/attr"
+)
+
+// TestStub is an in-memory ChaincodeStubInterface for contract tests. Unlike the shim's
+// MockStub it supplies transaction timestamps, records events, honours the start key of a
+// range query and discards the writes of a failed invoke, as a peer would. Tables and
+// certificate attributes are not supported.
+type TestStub struct {
+	ContractVersion string            // passed to Init, must match the deploy version argument
+	State           map[string][]byte // committed world state
+	Now             time.Time         // timestamp of the next transaction
+	Step            time.Duration     // clock advance after each invoke
+	Events          []TestEvent       // last event set by each invoke, in order
+	TxID            string            // current transaction, empty during a query
+	txCount         int
+	args            [][]byte
+}
+
+// TestEvent is an event set by an invoke through SetEvent
+type TestEvent struct {
+	TxID    string
+	Name    string
+	Payload []byte
+}
+
+// TestingT is the subset of *testing.T used by the helpers, which keeps the testing
+// package out of contract binaries
+type TestingT interface {
+	Errorf(format string, args ...interface{})
+	Fatalf(format string, args ...interface{})
+	Helper()
+}
+
+// testStubStart is the clock at which all test stubs start, so that histories are repeatable
+var testStubStart = time.Date(2016, time.November, 1, 0, 0, 0, 0, time.UTC)
+
+// NewTestStub returns an empty stub for a contract of the given version, with a clock
+// that advances one second per invoke
+func NewTestStub(contractVersion string) *TestStub {
+	return &TestStub{
+		ContractVersion: contractVersion,
+		State:           make(map[string][]byte),
+		Now:             testStubStart,
+		Step:            time.Second,
+		Events:          make([]TestEvent, 0),
+	}
+}
+
+// Init deploys the contract through the router, args[0] is normally {"version": ...}
+func (s *TestStub) Init(args ...string) ([]byte, error) {
+	return s.transaction("init", args, func() ([]byte, error) {
+		return Init(s, "init", args, s.ContractVersion)
+	})
+}
+
+// Invoke runs a registered function as an invoke transaction
+func (s *TestStub) Invoke(function string, args ...string) ([]byte, error) {
+	return s.transaction(function, args, func() ([]byte, error) {
+		return Invoke(s, function, args)
+	})
+}
+
+// Query runs a registered function as a query, with no transaction and no writes allowed
+func (s *TestStub) Query(function string, args ...string) ([]byte, error) {
+	s.args = stubArgs(function, args)
+	return Query(s, function, args)
+}
+
+// Call runs a registered function by name as an invoke or query according to its route
+func (s *TestStub) Call(function string, args ...string) ([]byte, error) {
+	r, found := router[function]
+	if !found {
+		return nil, fmt.Errorf("Call did not find registered function %s", function)
+	}
+	switch r.Method {
+	case "invoke":
+		return s.Invoke(function, args...)
+	case "query":
+		return s.Query(function, args...)
+	}
+	return nil, fmt.Errorf("Call cannot run function %s with method %s", function, r.Method)
+}
+
+// transaction wraps an invoke or init with a transaction ID and timestamp, rolling back
+// world state when the function fails
+func (s *TestStub) transaction(function string, args []string, f func() ([]byte, error)) ([]byte, error) {
+	s.txCount++
+	s.TxID = fmt.Sprintf("TXN%04d", s.txCount)
+	s.args = stubArgs(function, args)
+	var committed = make(map[string][]byte, len(s.State))
+	for k, v := range s.State {
+		committed[k] = v
+	}
+	result, err := f()
+	if err != nil {
+		s.State = committed
+	}
+	s.TxID = ""
+	s.Now = s.Now.Add(s.Step)
+	return result, err
+}
+
+func stubArgs(function string, args []string) [][]byte {
+	var b = [][]byte{[]byte(function)}
+	for _, a := range args {
+		b = append(b, []byte(a))
+	}
+	return b
+}
+
+// LastEvent returns the payload of the event set by the most recent invoke
+func (s *TestStub) LastEvent() (map[string]interface{}, bool) {
+	if len(s.Events) == 0 {
+		return nil, false
+	}
+	var payload map[string]interface{}
+	if err := json.Unmarshal(s.Events[len(s.Events)-1].Payload, &payload); err != nil {
+		return nil, false
+	}
+	return payload, true
+}
+
+// ReadAsset returns an asset's current state directly from world state
+func (s *TestStub) ReadAsset(class AssetClass, assetID string) (Asset, bool) {
+	a, exists, err := GetAssetFromLedger(s, class.Prefix+assetID)
+	return a, exists && err == nil
+}
+
+// ReadHistory returns an asset's state history, newest first
+func (s *TestStub) ReadHistory(function string, class AssetClass, assetID string) (AssetArray, error) {
+	var arg = make(map[string]interface{})
+	PutObject(&arg, class.AssetIDPath, assetID)
+	argBytes, _ := json.Marshal(arg)
+	return s.queryAssets(function, string(argBytes))
+}
+
+// ReadRecentStates returns the recent states across all assets, most recent first
+func (s *TestStub) ReadRecentStates() (AssetArray, error) {
+	return s.queryAssets("readRecentStates")
+}
+
+func (s *TestStub) queryAssets(function string, args ...string) (AssetArray, error) {
+	var assets AssetArray
+	result, err := s.Query(function, args...)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(result, &assets)
+	return assets, err
+}
+
+// ReplaySamples runs the named API samples from a contract's generated samples.go, in
+// order, each as an invoke or query according to its route, and stops at the first failure
+func (s *TestStub) ReplaySamples(samples string, functions ...string) (map[string][]byte, error) {
+	var sampleAPI struct {
+		API map[string]struct {
+			Function string        `json:"function"`
+			Args     []interface{} `json:"args"`
+		} `json:"API"`
+	}
+	if err := json.Unmarshal([]byte(samples), &sampleAPI); err != nil {
+		return nil, fmt.Errorf("ReplaySamples cannot unmarshal samples: %s", err)
+	}
+	var results = make(map[string][]byte)
+	for _, function := range functions {
+		sample, found := sampleAPI.API[function]
+		if !found {
+			return results, fmt.Errorf("ReplaySamples found no sample for %s", function)
+		}
+		var args = make([]string, 0, len(sample.Args))
+		for _, a := range sample.Args {
+			argBytes, err := json.Marshal(a)
+			if err != nil {
+				return results, fmt.Errorf("ReplaySamples cannot marshal sample args for %s: %s", function, err)
+			}
+			args = append(args, string(argBytes))
+		}
+		result, err := s.Call(function, args...)
+		if err != nil {
+			return results, fmt.Errorf("ReplaySamples %s failed: %s", function, err)
+		}
+		results[function] = result
+	}
+	return results, nil
+}
+
+// AssertAlertDeltas checks the alerts raised and cleared by the most recent invoke, as
+// reported in its invoke result event
+func (s *TestStub) AssertAlertDeltas(t TestingT, raised []AlertName, cleared []AlertName) {
+	t.Helper()
+	payload, found := s.LastEvent()
+	if !found {
+		t.Fatalf("no invoke result event to check for alert deltas")
+		return
+	}
+	for _, d := range []struct {
+		key  string
+		want []AlertName
+	}{{"alertsRaised", raised}, {"alertsCleared", cleared}} {
+		var got = make([]AlertName, 0)
+		names, _ := AsStringArray(payload[d.key])
+		for _, n := range names {
+			got = append(got, AlertName(n))
+		}
+		if !sameAlerts(got, d.want) {
+			t.Errorf("%s in %s: got %v, want %v", d.key, s.Events[len(s.Events)-1].TxID, got, d.want)
+		}
+	}
+}
+
+// AssertCompliance checks an asset's active alerts and compliance in world state
+func (s *TestStub) AssertCompliance(t TestingT, class AssetClass, assetID string, compliant bool, active ...AlertName) {
+	t.Helper()
+	a, found := s.ReadAsset(class, assetID)
+	if !found {
+		t.Fatalf("asset %s%s not found in world state", class.Prefix, assetID)
+		return
+	}
+	if a.Compliant != compliant {
+		t.Errorf("asset %s compliant is %t, want %t", a.AssetKey, a.Compliant, compliant)
+	}
+	if !sameAlerts(a.AlertsActive, active) {
+		t.Errorf("asset %s active alerts are %v, want %v", a.AssetKey, a.AlertsActive, active)
+	}
+}
+
+func sameAlerts(got []AlertName, want []AlertName) bool {
+	var g = append(AlertNameArray{}, got...)
+	var w = append(AlertNameArray{}, want...)
+	sort.Sort(g)
+	sort.Sort(w)
+	return reflect.DeepEqual(g, w)
+}
+
+// sortedStateKeys returns world state keys in lexical order, as the ledger holds them
+func (s *TestStub) sortedStateKeys() []string {
+	keys := make([]string, 0, len(s.State))
+	for k := range s.State {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+//********** ChaincodeStubInterface
+
+// GetArgs returns the function and arguments of the current call
+func (s *TestStub) GetArgs() [][]byte {
+	return s.args
+}
+
+// GetStringArgs returns the function and arguments of the current call as strings
+func (s *TestStub) GetStringArgs() []string {
+	var args = make([]string, 0, len(s.args))
+	for _, a := range s.args {
+		args = append(args, string(a))
+	}
+	return args
+}
+
+// GetTxID returns the current transaction ID
+func (s *TestStub) GetTxID() string {
+	return s.TxID
+}
+
+// InvokeChaincode is not supported
+func (s *TestStub) InvokeChaincode(chaincodeName string, args [][]byte) ([]byte, error) {
+	return nil, errors.New("TestStub does not support chaincode to chaincode invocation")
+}
+
+// QueryChaincode is not supported
+func (s *TestStub) QueryChaincode(chaincodeName string, args [][]byte) ([]byte, error) {
+	return nil, errors.New("TestStub does not support chaincode to chaincode queries")
+}
+
+// GetState returns the value at key, nil when it does not exist
+func (s *TestStub) GetState(key string) ([]byte, error) {
+	return s.State[key], nil
+}
+
+// PutState writes a key, which is only allowed inside a transaction
+func (s *TestStub) PutState(key string, value []byte) error {
+	if s.TxID == "" {
+		return fmt.Errorf("TestStub cannot PutState %s outside a transaction", key)
+	}
+	s.State[key] = value
+	return nil
+}
+
+// DelState deletes a key, which is only allowed inside a transaction
+func (s *TestStub) DelState(key string) error {
+	if s.TxID == "" {
+		return fmt.Errorf("TestStub cannot DelState %s outside a transaction", key)
+	}
+	delete(s.State, key)
+	return nil
+}
+
+// RangeQueryState iterates over the keys from startKey to endKey inclusive, in lexical order
+func (s *TestStub) RangeQueryState(startKey, endKey string) (shim.StateRangeQueryIteratorInterface, error) {
+	var iter = testRangeIterator{stub: s}
+	for _, k := range s.sortedStateKeys() {
+		if k >= startKey && k <= endKey {
+			iter.keys = append(iter.keys, k)
+		}
+	}
+	return &iter, nil
+}
+
+// CreateTable is not supported
+func (s *TestStub) CreateTable(name string, columnDefinitions []*shim.ColumnDefinition) error {
+	return errors.New("TestStub does not support tables")
+}
+
+// GetTable is not supported
+func (s *TestStub) GetTable(tableName string) (*shim.Table, error) {
+	return nil, errors.New("TestStub does not support tables")
+}
+
+// DeleteTable is not supported
+func (s *TestStub) DeleteTable(tableName string) error {
+	return errors.New("TestStub does not support tables")
+}
+
+// InsertRow is not supported
+func (s *TestStub) InsertRow(tableName string, row shim.Row) (bool, error) {
+	return false, errors.New("TestStub does not support tables")
+}
+
+// ReplaceRow is not supported
+func (s *TestStub) ReplaceRow(tableName string, row shim.Row) (bool, error) {
+	return false, errors.New("TestStub does not support tables")
+}
+
+// GetRow is not supported
+func (s *TestStub) GetRow(tableName string, key []shim.Column) (shim.Row, error) {
+	return shim.Row{}, errors.New("TestStub does not support tables")
+}
+
+// GetRows is not supported
+func (s *TestStub) GetRows(tableName string, key []shim.Column) (<-chan shim.Row, error) {
+	return nil, errors.New("TestStub does not support tables")
+}
+
+// DeleteRow is not supported
+func (s *TestStub) DeleteRow(tableName string, key []shim.Column) error {
+	return errors.New("TestStub does not support tables")
+}
+
+// ReadCertAttribute is not supported
+func (s *TestStub) ReadCertAttribute(attributeName string) ([]byte, error) {
+	return nil, errors.New("TestStub does not support certificate attributes")
+}
+
+// VerifyAttribute is not supported
+func (s *TestStub) VerifyAttribute(attributeName string, attributeValue []byte) (bool, error) {
+	return false, errors.New("TestStub does not support certificate attributes")
+}
+
+// VerifyAttributes is not supported
+func (s *TestStub) VerifyAttributes(attrs ...*attr.Attribute) (bool, error) {
+	return false, errors.New("TestStub does not support certificate attributes")
+}
+
+// VerifySignature is not supported
+func (s *TestStub) VerifySignature(certificate, signature, message []byte) (bool, error) {
+	return false, errors.New("TestStub does not support signatures")
+}
+
+// GetCallerCertificate returns no certificate
+func (s *TestStub) GetCallerCertificate() ([]byte, error) {
+	return nil, nil
+}
+
+// GetCallerMetadata returns no metadata
+func (s *TestStub) GetCallerMetadata() ([]byte, error) {
+	return nil, nil
+}
+
+// GetBinding returns no binding
+func (s *TestStub) GetBinding() ([]byte, error) {
+	return nil, nil
+}
+
+// GetPayload returns no payload
+func (s *TestStub) GetPayload() ([]byte, error) {
+	return nil, nil
+}
+
+// GetTxTimestamp returns the stub's clock
+func (s *TestStub) GetTxTimestamp() (*timestamp.Timestamp, error) {
+	return &timestamp.Timestamp{Seconds: s.Now.Unix(), Nanos: int32(s.Now.Nanosecond())}, nil
+}
+
+// SetEvent records the event for the current transaction, replacing any earlier event as
+// a transaction carries only one
+func (s *TestStub) SetEvent(name string, payload []byte) error {
+	if s.TxID == "" {
+		return fmt.Errorf("TestStub cannot SetEvent %s outside a transaction", name)
+	}
+	var e = TestEvent{s.TxID, name, payload}
+	if len(s.Events) > 0 && s.Events[len(s.Events)-1].TxID == s.TxID {
+		s.Events[len(s.Events)-1] = e
+	} else {
+		s.Events = append(s.Events, e)
+	}
+	return nil
+}
+
+// testRangeIterator walks a snapshot of the keys in range, reading values as it goes
+type testRangeIterator struct {
+	stub   *TestStub
+	keys   []string
+	next   int
+	closed bool
+}
+
+// HasNext returns true while keys remain
+func (iter *testRangeIterator) HasNext() bool {
+	return !iter.closed && iter.next < len(iter.keys)
+}
+
+// Next returns the next key and value
+func (iter *testRangeIterator) Next() (string, []byte, error) {
+	if !iter.HasNext() {
+		return "", nil, errors.New("testRangeIterator has no next key")
+	}
+	k := iter.keys[iter.next]
+	iter.next++
+	return k, iter.stub.State[k], nil
+}
+
+// Close closes the iterator
+func (iter *testRangeIterator) Close() error {
+	iter.closed = true
+	return nil
+}
@@ -21,15 +21,20 @@ package main
 
 import (
 	"bufio"
+	"bytes"
 	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
+	"go/format"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"text/template"
 	"time"
+	"unicode"
 )
 
 // Config defines contents of "generate.json" colocated in scripts folder with this script
@@ -45,6 +50,18 @@ type Config struct {
 		API              []string `json:"API"`
 		Model            []string `json:"Model"`
 	} `json:"samples"`
+	Classes []ClassConfig `json:"classes"`
+}
+
+// ClassConfig defines an asset class for which a Go file is generated, containing the class,
+// typed structs for its model, typed accessors for its qualified property paths, and the
+// complete set of CRUD routes
+type ClassConfig struct {
+	Name        string `json:"name"`        // asset class name, e.g. "container" (routes are named e.g. createAssetContainer)
+	Prefix      string `json:"prefix"`      // world state key prefix for the class
+	AssetIDPath string `json:"assetIDPath"` // qualified path to the asset ID, e.g. "container.barcode"
+	Model       string `json:"model"`       // model entry for the class, defaults to the first segment of assetIDPath
+	GoFilename  string `json:"goFilename"`  // defaults to "class<Name>.go"
 }
 
 var configFile = flag.String("configFile", "generate.json", "json file that selects API to be exposed")
@@ -493,6 +510,345 @@ func getIncludedFile(path string, localpath string) string {
 	return string(retstr)
 }
 
+// goIdentifier turns a schema name such as "surgicalkit" or "skitID" into an exported
+// Go identifier, dropping characters that Go does not allow
+func goIdentifier(name string) string {
+	var id string
+	var upper = true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		id += string(r)
+	}
+	if id == "" || unicode.IsDigit(rune(id[0])) {
+		id = "X" + id
+	}
+	return id
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// typeGenerator emits Go types from the Model section of the schema *before* references are
+// resolved, so that a shared model entry such as geo becomes one named type rather than a
+// copy per property; types are emitted once across all class files
+type typeGenerator struct {
+	model   map[string]interface{} // unresolved Model definitions
+	names   map[string]string      // Model name to Go type name overrides
+	structs map[string]bool        // Go struct types already emitted
+	out     string                 // declarations for the class file being generated
+}
+
+// resolve follows a reference into the Model section, returning the referenced definition and its name
+func (g *typeGenerator) resolve(obj interface{}) (map[string]interface{}, string) {
+	o, found := obj.(map[string]interface{})
+	if !found {
+		return nil, ""
+	}
+	ref, found := o["$ref"].(string)
+	if !found {
+		return o, ""
+	}
+	name := strings.TrimPrefix(ref, "#/definitions/Model/")
+	target, found := g.model[name].(map[string]interface{})
+	if !found {
+		fmt.Printf("** WARN ** cannot resolve reference %s for typed struct generation\n", ref)
+		return nil, name
+	}
+	return target, name
+}
+
+func isStructSchema(o map[string]interface{}) bool {
+	_, found := o["properties"].(map[string]interface{})
+	return found && o["type"] == "object"
+}
+
+// goType returns the Go type for a schema element, declaring named structs as needed
+func (g *typeGenerator) goType(typeName string, obj interface{}) string {
+	o, refName := g.resolve(obj)
+	if o == nil {
+		return "interface{}"
+	}
+	if refName != "" {
+		if tn, found := g.names[refName]; found {
+			typeName = tn
+		} else {
+			typeName = goIdentifier(refName)
+		}
+	}
+	if _, found := o["oneOf"]; found {
+		return "interface{}"
+	}
+	switch o["type"] {
+	case "string":
+		return "string"
+	case "number":
+		return "float64"
+	case "integer":
+		return "int"
+	case "boolean":
+		return "bool"
+	case "array":
+		items, found := o["items"]
+		if !found {
+			return "[]interface{}"
+		}
+		return "[]" + g.goType(typeName+"Item", items)
+	case "object":
+		if isStructSchema(o) {
+			g.declare(typeName, o)
+			return typeName
+		}
+		if pp, found := o["patternProperties"].(map[string]interface{}); found && len(pp) > 0 {
+			return "map[string]" + g.goType(typeName+"Value", pp[sortedKeys(pp)[0]])
+		}
+		return "map[string]interface{}"
+	}
+	return "interface{}"
+}
+
+// declare emits a struct for an object schema, nested structs are pointers so that a partial
+// state such as an update event marshals back without empty objects
+func (g *typeGenerator) declare(typeName string, o map[string]interface{}) {
+	if g.structs[typeName] {
+		return
+	}
+	g.structs[typeName] = true
+	// nested types follow their parent
+	var start = len(g.out)
+	var fields string
+	props := o["properties"].(map[string]interface{})
+	for _, k := range sortedKeys(props) {
+		t := g.goType(typeName+goIdentifier(k), props[k])
+		if g.structs[t] {
+			t = "*" + t
+		}
+		fields += fmt.Sprintf("\t%s %s `json:\"%s,omitempty\"`\n", goIdentifier(k), t, k)
+	}
+	var comment = fmt.Sprintf("// %s is generated from the schema", typeName)
+	if desc, found := o["description"].(string); found && desc != "" {
+		comment += "\n// " + desc
+	}
+	g.out = g.out[:start] + fmt.Sprintf("%s\ntype %s struct {\n%s}\n\n", comment, typeName, fields) + g.out[start:]
+}
+
+// Accessor is a typed getter for one qualified property path in an asset's state
+type Accessor struct {
+	Func   string
+	Path   string
+	GoType string
+	Getter string
+}
+
+var getters = map[string][2]string{
+	"string":   {"string", "GetObjectAsString"},
+	"number":   {"float64", "GetObjectAsNumber"},
+	"integer":  {"int", "GetObjectAsInteger"},
+	"boolean":  {"bool", "GetObjectAsBoolean"},
+	"[]string": {"[]string", "GetObjectAsStringArray"},
+}
+
+// accessors walks an object schema and returns a typed accessor for every scalar leaf, and for
+// string arrays; depth guards against recursive references
+func (g *typeGenerator) accessors(funcName string, qpath string, obj interface{}, depth int) []Accessor {
+	var acc = make([]Accessor, 0)
+	o, _ := g.resolve(obj)
+	if o == nil || depth > 10 {
+		return acc
+	}
+	t, _ := o["type"].(string)
+	if t == "array" {
+		if items, _ := g.resolve(o["items"]); items != nil && items["type"] == "string" {
+			t = "[]string"
+		}
+	}
+	if getter, found := getters[t]; found {
+		return append(acc, Accessor{funcName, qpath, getter[0], getter[1]})
+	}
+	if isStructSchema(o) {
+		props := o["properties"].(map[string]interface{})
+		for _, k := range sortedKeys(props) {
+			acc = append(acc, g.accessors(funcName+goIdentifier(k), qpath+"."+k, props[k], depth+1)...)
+		}
+	}
+	return acc
+}
+
+// ClassFile holds everything needed to generate one class file
+type ClassFile struct {
+	ClassConfig
+	GoName    string
+	Schema    string
+	Types     string
+	Accessors []Accessor
+}
+
+var classTemplate = template.Must(template.New("class").Parse(`// Code generated by processSchema.go from {{.Schema}}. DO NOT EDIT.
+
+package main
+
+import (
+	"encoding/json"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	iot "github.com/ibm-watson-iot/blockchain-samples/contracts/platform/iotcontractplatform"
+)
+
+// {{.GoName}}Class acts as the class of all {{.GoName}} assets
+var {{.GoName}}Class = iot.AssetClass{
+	Name:        "{{.Name}}",
+	Prefix:      "{{.Prefix}}",
+	AssetIDPath: "{{.AssetIDPath}}",
+}
+
+{{.Types}}
+// as{{.GoName}} returns the {{.Model}} object in an asset's state as a typed struct
+func as{{.GoName}}(a *iot.Asset) (*{{.GoName}}, bool) {
+	obj, found := iot.GetObject(a.State, "{{.Model}}")
+	if !found {
+		return nil, false
+	}
+	bytes, err := json.Marshal(obj)
+	if err != nil {
+		return nil, false
+	}
+	var t {{.GoName}}
+	if err = json.Unmarshal(bytes, &t); err != nil {
+		return nil, false
+	}
+	return &t, true
+}
+{{range .Accessors}}
+// {{.Func}} returns {{.Path}} from an asset's state
+func {{.Func}}(a *iot.Asset) ({{.GoType}}, bool) {
+	return iot.{{.Getter}}(a.State, "{{.Path}}")
+}
+{{end}}
+var createAsset{{.GoName}} iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	return {{.GoName}}Class.CreateAsset(stub, args, "createAsset{{.GoName}}", []iot.QPropNV{})
+}
+
+var replaceAsset{{.GoName}} iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	return {{.GoName}}Class.ReplaceAsset(stub, args, "replaceAsset{{.GoName}}", []iot.QPropNV{})
+}
+
+var updateAsset{{.GoName}} iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	return {{.GoName}}Class.UpdateAsset(stub, args, "updateAsset{{.GoName}}", []iot.QPropNV{})
+}
+
+var deleteAsset{{.GoName}} iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	return {{.GoName}}Class.DeleteAsset(stub, args)
+}
+
+var deleteAssetStateHistory{{.GoName}} iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	return {{.GoName}}Class.DeleteAssetStateHistory(stub, args)
+}
+
+var deleteAllAssets{{.GoName}} iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	return {{.GoName}}Class.DeleteAllAssets(stub, args)
+}
+
+var deletePropertiesFromAsset{{.GoName}} iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	return {{.GoName}}Class.DeletePropertiesFromAsset(stub, args, "deletePropertiesFromAsset{{.GoName}}", []iot.QPropNV{})
+}
+
+var readAsset{{.GoName}} iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	return {{.GoName}}Class.ReadAsset(stub, args)
+}
+
+var readAllAssets{{.GoName}} iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	return {{.GoName}}Class.ReadAllAssets(stub, args)
+}
+
+var readAssetStateHistory{{.GoName}} iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	return {{.GoName}}Class.ReadAssetStateHistory(stub, args)
+}
+
+var readAssetAsOf{{.GoName}} iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	return {{.GoName}}Class.ReadAssetAsOf(stub, args)
+}
+
+var readAssetStateAggregates{{.GoName}} iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	return {{.GoName}}Class.ReadAssetStateAggregates(stub, args)
+}
+
+func init() {
+	iot.AddRoute("createAsset{{.GoName}}", "invoke", {{.GoName}}Class, createAsset{{.GoName}})
+	iot.AddRoute("replaceAsset{{.GoName}}", "invoke", {{.GoName}}Class, replaceAsset{{.GoName}})
+	iot.AddRoute("updateAsset{{.GoName}}", "invoke", {{.GoName}}Class, updateAsset{{.GoName}})
+	iot.AddRoute("deleteAsset{{.GoName}}", "invoke", {{.GoName}}Class, deleteAsset{{.GoName}})
+	iot.AddRoute("deleteAssetStateHistory{{.GoName}}", "invoke", {{.GoName}}Class, deleteAssetStateHistory{{.GoName}})
+	iot.AddRoute("deleteAllAssets{{.GoName}}", "invoke", {{.GoName}}Class, deleteAllAssets{{.GoName}})
+	iot.AddRoute("deletePropertiesFromAsset{{.GoName}}", "invoke", {{.GoName}}Class, deletePropertiesFromAsset{{.GoName}})
+	iot.AddRoute("readAsset{{.GoName}}", "query", {{.GoName}}Class, readAsset{{.GoName}})
+	iot.AddRoute("readAssetStateHistory{{.GoName}}", "query", {{.GoName}}Class, readAssetStateHistory{{.GoName}})
+	iot.AddRoute("readAssetAsOf{{.GoName}}", "query", {{.GoName}}Class, readAssetAsOf{{.GoName}})
+	iot.AddRoute("readAssetStateAggregates{{.GoName}}", "query", {{.GoName}}Class, readAssetStateAggregates{{.GoName}})
+	iot.AddRoute("readAllAssets{{.GoName}}", "query", {{.GoName}}Class, readAllAssets{{.GoName}})
+}
+`))
+
+// Generates a file class<Name>.go for each class in the config, so that adding a class to
+// generate.json and regenerating is all that is needed to expose it; rules are written by hand
+// in a separate file using the typed accessors
+func generateGoClassFiles(schema map[string]interface{}, config Config) {
+	model, found := schema["definitions"].(map[string]interface{})["Model"].(map[string]interface{})
+	if !found {
+		fmt.Println("** ERR ** no Model section found in schema for class generation")
+		return
+	}
+	var g = typeGenerator{model, make(map[string]string), make(map[string]bool), ""}
+	var classes = make([]ClassFile, len(config.Classes))
+	for i, c := range config.Classes {
+		if c.Model == "" {
+			c.Model = strings.Split(c.AssetIDPath, ".")[0]
+		}
+		if c.GoFilename == "" {
+			c.GoFilename = "class" + goIdentifier(c.Name) + ".go"
+		}
+		if _, found := model[c.Model]; !found {
+			fmt.Printf("** ERR ** class %s has no model %s in the schema\n", c.Name, c.Model)
+			os.Exit(1)
+		}
+		classes[i] = ClassFile{ClassConfig: c, GoName: goIdentifier(c.Name), Schema: config.Schemas.SchemaFilename}
+		// the class's own model is named after the class so that routes and types match
+		g.names[c.Model] = classes[i].GoName
+	}
+	for _, cf := range classes {
+		var ref = map[string]interface{}{"$ref": "#/definitions/Model/" + cf.Model}
+		g.out = ""
+		g.goType(cf.GoName, ref)
+		cf.Types = g.out
+		cf.Accessors = g.accessors("get"+cf.GoName, cf.Model, ref, 0)
+		var buf bytes.Buffer
+		if err := classTemplate.Execute(&buf, cf); err != nil {
+			fmt.Printf("** ERR ** [%s] generating class file for %s\n", err, cf.Name)
+			return
+		}
+		src, err := format.Source(buf.Bytes())
+		if err != nil {
+			fmt.Printf("** WARN ** [%s] formatting class file %s, writing it unformatted\n", err, cf.GoFilename)
+			src = buf.Bytes()
+		}
+		if *verbose {
+			fmt.Println("Writing class file to: " + cf.GoFilename)
+		}
+		ioutil.WriteFile(cf.GoFilename, src, 0644)
+	}
+}
+
 // Reads payloadschema.json api file
 // encodes as a string literal in payloadschema.go
 func main() {
@@ -621,4 +977,12 @@ func main() {
 	generateGoSchemaFile(finalschema, config, imports, regReadSchemas)
 	generateGoSampleFile(finalschema, config, imports, regReadSamples)
 
+	// ************** Stage 6
+	// generate a file per asset class with its typed structs, typed accessors and routes,
+	// working from a fresh copy of the schema as reference resolution alters nested levels
+	// in place and the class generator needs the references to name shared types
+	var unresolved map[string]interface{}
+	_ = json.Unmarshal([]byte(api), &unresolved)
+	generateGoClassFiles(unresolved, config)
+
 }
@@ -0,0 +1,47 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+// v0.1 KL -- shipping containers that carry surgical kits
+
+package main
+
+import (
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	iot "github.com/ibm-watson-iot/blockchain-samples/contracts/platform/iotcontractplatform"
+)
+
+// containerToKit maps a container's properties to the kit properties that follow them
+// while the container holds the kit, so that the kit's geofence sees the container move
+var containerToKit = map[string]string{
+	"container.common.location.latitude":  "surgicalkit.sensors.endlocation.latitude",
+	"container.common.location.longitude": "surgicalkit.sensors.endlocation.longitude",
+	"container.carrier":                   "surgicalkit.transit.carrier",
+}
+
+var containerToKitPropagation = iot.PropagateProperties(containerToKit)
+
+// containerPropagation moves the kits in a container with it, a container may hold other
+// containers, which keep their own location and carrier
+var containerPropagation iot.PropagationFunc = func(stub shim.ChaincodeStubInterface, container *iot.Asset, child *iot.Asset) error {
+	if child.Class.Name != SurgicalKitClass.Name {
+		return nil
+	}
+	return containerToKitPropagation(stub, container, child)
+}
+
+func init() {
+	iot.AddPropagation(iot.RELCONTAINS, ContainerClass, containerPropagation)
+}
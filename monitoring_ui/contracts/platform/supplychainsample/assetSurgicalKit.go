@@ -25,73 +25,9 @@ import (
 	iot "github.com/ibm-watson-iot/blockchain-samples/contracts/platform/iotcontractplatform"
 )
 
-// SurgicalKitClass acts as the class of all SurgicalKits
-var SurgicalKitClass = iot.AssetClass{
-	Name:        "SurgicalKit",
-	Prefix:      "SKT",
-	AssetIDPath: "surgicalkit.skitID",
-}
-
-func newSurgicalKit() iot.Asset {
-	return iot.Asset{
-		Class:        SurgicalKitClass,
-		AssetKey:     "",
-		State:        nil,
-		EventIn:      nil,
-		TXNID:        "",
-		TXNTS:        nil,
-		EventOut:     nil,
-		AlertsActive: nil,
-		Compliant:    true,
-	}
-}
-
-var createAssetSurgicalKit iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return SurgicalKitClass.CreateAsset(stub, args, "createAssetSurgicalKit", []iot.QPropNV{})
-}
-
-var replaceAssetSurgicalKit iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return SurgicalKitClass.ReplaceAsset(stub, args, "replaceAssetSurgicalKit", []iot.QPropNV{})
-}
-
-var updateAssetSurgicalKit iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	if len(args) > 0 {
-		log.Debugf("Payload received in updateAssetSurgicalKit: %s", args[0])
-	}
-	return SurgicalKitClass.UpdateAsset(stub, args, "updateAssetSurgicalKit", []iot.QPropNV{})
-}
-
-var deleteAssetSurgicalKit iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return SurgicalKitClass.DeleteAsset(stub, args)
-}
-
-var deleteAssetStateHistorySurgicalKit iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return SurgicalKitClass.DeleteAssetStateHistory(stub, args)
-}
-
-var deleteAllAssetsSurgicalKit iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return SurgicalKitClass.DeleteAllAssets(stub, args)
-}
-
-var deletePropertiesFromAssetSurgicalKit iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return SurgicalKitClass.DeletePropertiesFromAsset(stub, args, "deletePropertiesFromAssetSurgicalKit", []iot.QPropNV{})
-}
-
-var readAssetSurgicalKit iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return SurgicalKitClass.ReadAsset(stub, args)
-}
-
-var readAllAssetsSurgicalKit iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return SurgicalKitClass.ReadAllAssets(stub, args)
-}
-
-var readAssetStateHistorySurgicalKit = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return SurgicalKitClass.ReadAssetStateHistory(stub, args)
-}
-
 var excessForceAlert iot.AlertName = "EXCESSFORCE"
 var excessForceRule iot.RuleFunc = func(stub shim.ChaincodeStubInterface, SurgicalKit *iot.Asset) error {
-	force, found := iot.GetObjectAsNumber(SurgicalKit.State, "surgicalkit.sensors.maxgforce")
+	force, found := getSurgicalKitSensorsMaxgforce(SurgicalKit)
 	if found {
 		if force > 2 {
 			iot.RaiseAlert(SurgicalKit, excessForceAlert)
@@ -104,7 +40,7 @@ var excessForceRule iot.RuleFunc = func(stub shim.ChaincodeStubInterface, Surgic
 
 var excessTiltAlert iot.AlertName = "EXCESSTILT"
 var excessTiltRule iot.RuleFunc = func(stub shim.ChaincodeStubInterface, SurgicalKit *iot.Asset) error {
-	tilt, found := iot.GetObjectAsNumber(SurgicalKit.State, "surgicalkit.sensors.maxtilt")
+	tilt, found := getSurgicalKitSensorsMaxtilt(SurgicalKit)
 	if found {
 		if tilt > 90 || tilt < -90 {
 			iot.RaiseAlert(SurgicalKit, excessTiltAlert)
@@ -117,27 +53,27 @@ var excessTiltRule iot.RuleFunc = func(stub shim.ChaincodeStubInterface, Surgica
 
 var outOfAreaAlert iot.AlertName = "OUTOFAREA"
 var outOfAreaRule iot.RuleFunc = func(stub shim.ChaincodeStubInterface, SurgicalKit *iot.Asset) error {
-	status, found := iot.GetObjectAsString(SurgicalKit.State, "surgicalkit.status")
+	status, found := getSurgicalKitStatus(SurgicalKit)
 	if !found || status != "hospital" {
 		return nil
 	}
-	lat, found := iot.GetObjectAsNumber(SurgicalKit.State, "surgicalkit.sensors.endlocation.latitude")
+	lat, found := getSurgicalKitSensorsEndlocationLatitude(SurgicalKit)
 	if !found {
 		return nil
 	}
-	long, found := iot.GetObjectAsNumber(SurgicalKit.State, "surgicalkit.sensors.endlocation.longitude")
+	long, found := getSurgicalKitSensorsEndlocationLongitude(SurgicalKit)
 	if !found {
 		return nil
 	}
-	flat, found := iot.GetObjectAsNumber(SurgicalKit.State, "surgicalkit.hospital.fence.center.latitude")
+	flat, found := getSurgicalKitHospitalFenceCenterLatitude(SurgicalKit)
 	if !found {
 		return nil
 	}
-	flong, found := iot.GetObjectAsNumber(SurgicalKit.State, "surgicalkit.hospital.fence.center.longitude")
+	flong, found := getSurgicalKitHospitalFenceCenterLongitude(SurgicalKit)
 	if !found {
 		return nil
 	}
-	radius, found := iot.GetObjectAsNumber(SurgicalKit.State, "surgicalkit.hospital.fence.radius")
+	radius, found := getSurgicalKitHospitalFenceRadius(SurgicalKit)
 	if !found {
 		return nil
 	}
@@ -156,15 +92,4 @@ func init() {
 	iot.AddRule("Excess Force Alert", SurgicalKitClass, []iot.AlertName{excessForceAlert}, excessForceRule)
 	iot.AddRule("Excess Tilt Alert", SurgicalKitClass, []iot.AlertName{excessTiltAlert}, excessTiltRule)
 	iot.AddRule("Out Of Area Alert", SurgicalKitClass, []iot.AlertName{outOfAreaAlert}, outOfAreaRule)
-
-	iot.AddRoute("createAssetSurgicalKit", "invoke", SurgicalKitClass, createAssetSurgicalKit)
-	iot.AddRoute("replaceAssetSurgicalKit", "invoke", SurgicalKitClass, replaceAssetSurgicalKit)
-	iot.AddRoute("updateAssetSurgicalKit", "invoke", SurgicalKitClass, updateAssetSurgicalKit)
-	iot.AddRoute("deleteAssetSurgicalKit", "invoke", SurgicalKitClass, deleteAssetSurgicalKit)
-	iot.AddRoute("deleteAssetStateHistorySurgicalKit", "invoke", SurgicalKitClass, deleteAssetStateHistorySurgicalKit)
-	iot.AddRoute("deleteAllAssetsSurgicalKit", "invoke", SurgicalKitClass, deleteAllAssetsSurgicalKit)
-	iot.AddRoute("deletePropertiesFromAssetSurgicalKit", "invoke", SurgicalKitClass, deletePropertiesFromAssetSurgicalKit)
-	iot.AddRoute("readAssetSurgicalKit", "query", SurgicalKitClass, readAssetSurgicalKit)
-	iot.AddRoute("readAssetStateHistorySurgicalKit", "query", SurgicalKitClass, readAssetStateHistorySurgicalKit)
-	iot.AddRoute("readAllAssetsSurgicalKit", "query", SurgicalKitClass, readAllAssetsSurgicalKit)
 }
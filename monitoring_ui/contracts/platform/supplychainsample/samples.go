@@ -47,7 +47,141 @@ var samples = `
             "function": "readAssetStateHistorySurgicalKit",
             "result": [
                 {
-                    "^CON": "INVALID OBJECT - MISSING PROPERTIES"
+                    "^CON": {
+                        "AssetKey": "This surgicalkit's world state surgicalkit ID",
+                        "alerts": [
+                            "An alert name"
+                        ],
+                        "class": {},
+                        "compliant": true,
+                        "eventin": {
+                            "surgicalkit": {
+                                "common": {
+                                    "appdata": [
+                                        {
+                                            "K": "carpe noctem",
+                                            "V": "carpe noctem"
+                                        }
+                                    ],
+                                    "deviceID": "A unique identifier for the device that sent the current event",
+                                    "devicetimestamp": "A timestamp recoded by the device that sent the current event",
+                                    "location": {
+                                        "latitude": 123.456,
+                                        "longitude": 123.456
+                                    }
+                                },
+                                "hospital": {
+                                    "address": {
+                                        "city": "carpe noctem",
+                                        "country": "carpe noctem",
+                                        "postcode": "carpe noctem",
+                                        "streetandnumber": "carpe noctem"
+                                    },
+                                    "fence": {
+                                        "center": {
+                                            "latitude": 123.456,
+                                            "longitude": 123.456
+                                        },
+                                        "radius": 123.456
+                                    },
+                                    "name": "carpe noctem"
+                                },
+                                "sensors": {
+                                    "begin": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                    "currtilt": 123.456,
+                                    "end": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                    "endlocation": {
+                                        "latitude": 123.456,
+                                        "longitude": 123.456
+                                    },
+                                    "maxgforce": 123.456,
+                                    "maxtilt": 123.456,
+                                    "startlocation": {
+                                        "latitude": 123.456,
+                                        "longitude": 123.456
+                                    }
+                                },
+                                "skitID": "A surgicalkit's ID",
+                                "status": "oem",
+                                "transit": {
+                                    "begintransit": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                    "carrier": "carpe noctem",
+                                    "endtransit": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                    "receiver": "oem",
+                                    "shipper": "oem"
+                                }
+                            }
+                        },
+                        "eventout": {
+                            "surgicalkit": {
+                                "name": "EVT.IOTCP.INVOKE.RESULT",
+                                "payload": {
+                                    "properties": "NO TYPE PROPERTY"
+                                }
+                            }
+                        },
+                        "state": {
+                            "distanceFromCenter": 123.456,
+                            "surgicalkit": {
+                                "common": {
+                                    "appdata": [
+                                        {
+                                            "K": "carpe noctem",
+                                            "V": "carpe noctem"
+                                        }
+                                    ],
+                                    "deviceID": "A unique identifier for the device that sent the current event",
+                                    "devicetimestamp": "A timestamp recoded by the device that sent the current event",
+                                    "location": {
+                                        "latitude": 123.456,
+                                        "longitude": 123.456
+                                    }
+                                },
+                                "hospital": {
+                                    "address": {
+                                        "city": "carpe noctem",
+                                        "country": "carpe noctem",
+                                        "postcode": "carpe noctem",
+                                        "streetandnumber": "carpe noctem"
+                                    },
+                                    "fence": {
+                                        "center": {
+                                            "latitude": 123.456,
+                                            "longitude": 123.456
+                                        },
+                                        "radius": 123.456
+                                    },
+                                    "name": "carpe noctem"
+                                },
+                                "sensors": {
+                                    "begin": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                    "currtilt": 123.456,
+                                    "end": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                    "endlocation": {
+                                        "latitude": 123.456,
+                                        "longitude": 123.456
+                                    },
+                                    "maxgforce": 123.456,
+                                    "maxtilt": 123.456,
+                                    "startlocation": {
+                                        "latitude": 123.456,
+                                        "longitude": 123.456
+                                    }
+                                },
+                                "skitID": "A surgicalkit's ID",
+                                "status": "oem",
+                                "transit": {
+                                    "begintransit": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                    "carrier": "carpe noctem",
+                                    "endtransit": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                    "receiver": "oem",
+                                    "shipper": "oem"
+                                }
+                            }
+                        },
+                        "txnid": "Transaction UUID matching the blockchain",
+                        "txnts": "Transaction timestamp matching the blockchain"
+                    }
                 }
             ]
         },
@@ -127,8 +261,10 @@ var samples = `
                 },
                 "eventout": {
                     "surgicalkit": {
-                        "name": "The chaincode event's name",
-                        "payload": {}
+                        "name": "EVT.IOTCP.INVOKE.RESULT",
+                        "payload": {
+                            "properties": "NO TYPE PROPERTY"
+                        }
                     }
                 },
                 "state": {
@@ -196,9 +332,11 @@ var samples = `
         }
     },
     "Model": {
-        "invokeevent": {
-            "name": "The chaincode event's name",
-            "payload": {}
+        "eventIOTContractPlatformInvokeResult": {
+            "name": "EVT.IOTCP.INVOKE.RESULT",
+            "payload": {
+                "properties": "NO TYPE PROPERTY"
+            }
         },
         "ioteventcommon": {
             "appdata": [
@@ -346,8 +484,10 @@ var samples = `
             },
             "eventout": {
                 "surgicalkit": {
-                    "name": "The chaincode event's name",
-                    "payload": {}
+                    "name": "EVT.IOTCP.INVOKE.RESULT",
+                    "payload": {
+                        "properties": "NO TYPE PROPERTY"
+                    }
                 }
             },
             "state": {
@@ -414,11 +554,279 @@ var samples = `
         },
         "surgicalkitstatearray": [
             {
-                "^CON": "INVALID OBJECT - MISSING PROPERTIES"
+                "^CON": {
+                    "AssetKey": "This surgicalkit's world state surgicalkit ID",
+                    "alerts": [
+                        "An alert name"
+                    ],
+                    "class": {},
+                    "compliant": true,
+                    "eventin": {
+                        "surgicalkit": {
+                            "common": {
+                                "appdata": [
+                                    {
+                                        "K": "carpe noctem",
+                                        "V": "carpe noctem"
+                                    }
+                                ],
+                                "deviceID": "A unique identifier for the device that sent the current event",
+                                "devicetimestamp": "A timestamp recoded by the device that sent the current event",
+                                "location": {
+                                    "latitude": 123.456,
+                                    "longitude": 123.456
+                                }
+                            },
+                            "hospital": {
+                                "address": {
+                                    "city": "carpe noctem",
+                                    "country": "carpe noctem",
+                                    "postcode": "carpe noctem",
+                                    "streetandnumber": "carpe noctem"
+                                },
+                                "fence": {
+                                    "center": {
+                                        "latitude": 123.456,
+                                        "longitude": 123.456
+                                    },
+                                    "radius": 123.456
+                                },
+                                "name": "carpe noctem"
+                            },
+                            "sensors": {
+                                "begin": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                "currtilt": 123.456,
+                                "end": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                "endlocation": {
+                                    "latitude": 123.456,
+                                    "longitude": 123.456
+                                },
+                                "maxgforce": 123.456,
+                                "maxtilt": 123.456,
+                                "startlocation": {
+                                    "latitude": 123.456,
+                                    "longitude": 123.456
+                                }
+                            },
+                            "skitID": "A surgicalkit's ID",
+                            "status": "oem",
+                            "transit": {
+                                "begintransit": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                "carrier": "carpe noctem",
+                                "endtransit": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                "receiver": "oem",
+                                "shipper": "oem"
+                            }
+                        }
+                    },
+                    "eventout": {
+                        "surgicalkit": {
+                            "name": "EVT.IOTCP.INVOKE.RESULT",
+                            "payload": {
+                                "properties": "NO TYPE PROPERTY"
+                            }
+                        }
+                    },
+                    "state": {
+                        "distanceFromCenter": 123.456,
+                        "surgicalkit": {
+                            "common": {
+                                "appdata": [
+                                    {
+                                        "K": "carpe noctem",
+                                        "V": "carpe noctem"
+                                    }
+                                ],
+                                "deviceID": "A unique identifier for the device that sent the current event",
+                                "devicetimestamp": "A timestamp recoded by the device that sent the current event",
+                                "location": {
+                                    "latitude": 123.456,
+                                    "longitude": 123.456
+                                }
+                            },
+                            "hospital": {
+                                "address": {
+                                    "city": "carpe noctem",
+                                    "country": "carpe noctem",
+                                    "postcode": "carpe noctem",
+                                    "streetandnumber": "carpe noctem"
+                                },
+                                "fence": {
+                                    "center": {
+                                        "latitude": 123.456,
+                                        "longitude": 123.456
+                                    },
+                                    "radius": 123.456
+                                },
+                                "name": "carpe noctem"
+                            },
+                            "sensors": {
+                                "begin": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                "currtilt": 123.456,
+                                "end": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                "endlocation": {
+                                    "latitude": 123.456,
+                                    "longitude": 123.456
+                                },
+                                "maxgforce": 123.456,
+                                "maxtilt": 123.456,
+                                "startlocation": {
+                                    "latitude": 123.456,
+                                    "longitude": 123.456
+                                }
+                            },
+                            "skitID": "A surgicalkit's ID",
+                            "status": "oem",
+                            "transit": {
+                                "begintransit": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                "carrier": "carpe noctem",
+                                "endtransit": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                "receiver": "oem",
+                                "shipper": "oem"
+                            }
+                        }
+                    },
+                    "txnid": "Transaction UUID matching the blockchain",
+                    "txnts": "Transaction timestamp matching the blockchain"
+                }
             }
         ],
         "surgicalkitstateexternal": {
-            "^CON": "INVALID OBJECT - MISSING PROPERTIES"
+            "^CON": {
+                "AssetKey": "This surgicalkit's world state surgicalkit ID",
+                "alerts": [
+                    "An alert name"
+                ],
+                "class": {},
+                "compliant": true,
+                "eventin": {
+                    "surgicalkit": {
+                        "common": {
+                            "appdata": [
+                                {
+                                    "K": "carpe noctem",
+                                    "V": "carpe noctem"
+                                }
+                            ],
+                            "deviceID": "A unique identifier for the device that sent the current event",
+                            "devicetimestamp": "A timestamp recoded by the device that sent the current event",
+                            "location": {
+                                "latitude": 123.456,
+                                "longitude": 123.456
+                            }
+                        },
+                        "hospital": {
+                            "address": {
+                                "city": "carpe noctem",
+                                "country": "carpe noctem",
+                                "postcode": "carpe noctem",
+                                "streetandnumber": "carpe noctem"
+                            },
+                            "fence": {
+                                "center": {
+                                    "latitude": 123.456,
+                                    "longitude": 123.456
+                                },
+                                "radius": 123.456
+                            },
+                            "name": "carpe noctem"
+                        },
+                        "sensors": {
+                            "begin": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                            "currtilt": 123.456,
+                            "end": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                            "endlocation": {
+                                "latitude": 123.456,
+                                "longitude": 123.456
+                            },
+                            "maxgforce": 123.456,
+                            "maxtilt": 123.456,
+                            "startlocation": {
+                                "latitude": 123.456,
+                                "longitude": 123.456
+                            }
+                        },
+                        "skitID": "A surgicalkit's ID",
+                        "status": "oem",
+                        "transit": {
+                            "begintransit": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                            "carrier": "carpe noctem",
+                            "endtransit": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                            "receiver": "oem",
+                            "shipper": "oem"
+                        }
+                    }
+                },
+                "eventout": {
+                    "surgicalkit": {
+                        "name": "EVT.IOTCP.INVOKE.RESULT",
+                        "payload": {
+                            "properties": "NO TYPE PROPERTY"
+                        }
+                    }
+                },
+                "state": {
+                    "distanceFromCenter": 123.456,
+                    "surgicalkit": {
+                        "common": {
+                            "appdata": [
+                                {
+                                    "K": "carpe noctem",
+                                    "V": "carpe noctem"
+                                }
+                            ],
+                            "deviceID": "A unique identifier for the device that sent the current event",
+                            "devicetimestamp": "A timestamp recoded by the device that sent the current event",
+                            "location": {
+                                "latitude": 123.456,
+                                "longitude": 123.456
+                            }
+                        },
+                        "hospital": {
+                            "address": {
+                                "city": "carpe noctem",
+                                "country": "carpe noctem",
+                                "postcode": "carpe noctem",
+                                "streetandnumber": "carpe noctem"
+                            },
+                            "fence": {
+                                "center": {
+                                    "latitude": 123.456,
+                                    "longitude": 123.456
+                                },
+                                "radius": 123.456
+                            },
+                            "name": "carpe noctem"
+                        },
+                        "sensors": {
+                            "begin": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                            "currtilt": 123.456,
+                            "end": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                            "endlocation": {
+                                "latitude": 123.456,
+                                "longitude": 123.456
+                            },
+                            "maxgforce": 123.456,
+                            "maxtilt": 123.456,
+                            "startlocation": {
+                                "latitude": 123.456,
+                                "longitude": 123.456
+                            }
+                        },
+                        "skitID": "A surgicalkit's ID",
+                        "status": "oem",
+                        "transit": {
+                            "begintransit": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                            "carrier": "carpe noctem",
+                            "endtransit": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                            "receiver": "oem",
+                            "shipper": "oem"
+                        }
+                    }
+                },
+                "txnid": "Transaction UUID matching the blockchain",
+                "txnts": "Transaction timestamp matching the blockchain"
+            }
         }
     }
 }`
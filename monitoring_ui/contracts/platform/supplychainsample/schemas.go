@@ -10,6 +10,92 @@ var schemas = `
 
 {
     "API": {
+        "createAssetContainer": {
+            "description": "Creates a new container (e.g. put new)",
+            "properties": {
+                "args": {
+                    "items": {
+                        "properties": {
+                            "container": {
+                                "description": "The changeable properties for a container, also considered its 'event' as a partial state",
+                                "properties": {
+                                    "barcode": {
+                                        "description": "A container's ID",
+                                        "type": "string"
+                                    },
+                                    "carrier": {
+                                        "description": "The carrier in possession of this container",
+                                        "type": "string"
+                                    },
+                                    "common": {
+                                        "description": "Common properties for all assets",
+                                        "properties": {
+                                            "appdata": {
+                                                "description": "Application managed information as an array of key:value pairs",
+                                                "items": {
+                                                    "properties": {
+                                                        "K": {
+                                                            "type": "string"
+                                                        },
+                                                        "V": {
+                                                            "type": "string"
+                                                        }
+                                                    },
+                                                    "type": "object"
+                                                },
+                                                "minItems": 0,
+                                                "type": "array"
+                                            },
+                                            "deviceID": {
+                                                "description": "A unique identifier for the device that sent the current event",
+                                                "type": "string"
+                                            },
+                                            "devicetimestamp": {
+                                                "description": "A timestamp recoded by the device that sent the current event",
+                                                "type": "string"
+                                            },
+                                            "location": {
+                                                "description": "A geographical coordinate",
+                                                "properties": {
+                                                    "latitude": {
+                                                        "type": "number"
+                                                    },
+                                                    "longitude": {
+                                                        "type": "number"
+                                                    }
+                                                },
+                                                "type": "object"
+                                            }
+                                        },
+                                        "type": "object"
+                                    },
+                                    "temperature": {
+                                        "description": "Temperature of a container's contents in degrees Celsuis",
+                                        "type": "number"
+                                    }
+                                },
+                                "required": [
+                                    "barcode"
+                                ],
+                                "type": "object"
+                            }
+                        },
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
+                    "type": "array"
+                },
+                "function": {
+                    "enum": [
+                        "createAssetContainer"
+                    ],
+                    "type": "string"
+                },
+                "method": "invoke"
+            },
+            "type": "object"
+        },
         "createAssetSurgicalKit": {
             "description": "Creates a new surgicalkit (e.g. put new)",
             "properties": {
@@ -248,6 +334,62 @@ var schemas = `
             },
             "type": "object"
         },
+        "deleteAllAssetsContainer": {
+            "description": "Delete all containers from world state, supports filters",
+            "properties": {
+                "args": {
+                    "items": {
+                        "properties": {
+                            "filter": {
+                                "description": "Filter asset states",
+                                "properties": {
+                                    "match": {
+                                        "description": "Defines how to match properties, missing property always fails match",
+                                        "enum": [
+                                            "n/a",
+                                            "all",
+                                            "any",
+                                            "none"
+                                        ],
+                                        "type": "string"
+                                    },
+                                    "select": {
+                                        "description": "Qualified property names and values match",
+                                        "items": {
+                                            "properties": {
+                                                "qprop": {
+                                                    "description": "Qualified property to compare, for example 'asset.assetID'",
+                                                    "type": "string"
+                                                },
+                                                "value": {
+                                                    "description": "Value to be compared",
+                                                    "type": "string"
+                                                }
+                                            },
+                                            "type": "object"
+                                        },
+                                        "type": "array"
+                                    }
+                                },
+                                "type": "object"
+                            }
+                        },
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 0,
+                    "type": "array"
+                },
+                "function": {
+                    "enum": [
+                        "deleteAllAssetsContainer"
+                    ],
+                    "type": "string"
+                },
+                "method": "invoke"
+            },
+            "type": "object"
+        },
         "deleteAllAssetsSurgicalKit": {
             "description": "Delete all surgicalkits from world state, supports filters",
             "properties": {
@@ -304,6 +446,70 @@ var schemas = `
             },
             "type": "object"
         },
+        "deleteAssetContainer": {
+            "description": "Delete a container from world state, transactions remain on the blockchain",
+            "properties": {
+                "args": {
+                    "items": {
+                        "properties": {
+                            "container": {
+                                "properties": {
+                                    "barcode": {
+                                        "description": "A container's ID",
+                                        "type": "string"
+                                    }
+                                },
+                                "type": "object"
+                            }
+                        },
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
+                    "type": "array"
+                },
+                "function": {
+                    "enum": [
+                        "deleteAssetContainer"
+                    ],
+                    "type": "string"
+                },
+                "method": "invoke"
+            },
+            "type": "object"
+        },
+        "deleteAssetStateHistoryContainer": {
+            "description": "Delete a container's history from world state, transactions remain on the blockchain",
+            "properties": {
+                "args": {
+                    "items": {
+                        "properties": {
+                            "container": {
+                                "properties": {
+                                    "barcode": {
+                                        "description": "A container's ID",
+                                        "type": "string"
+                                    }
+                                },
+                                "type": "object"
+                            }
+                        },
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
+                    "type": "array"
+                },
+                "function": {
+                    "enum": [
+                        "deleteAssetStateHistoryContainer"
+                    ],
+                    "type": "string"
+                },
+                "method": "invoke"
+            },
+            "type": "object"
+        },
         "deleteAssetStateHistorySurgicalKit": {
             "description": "Delete a surgicalkit's history from world state, transactions remain on the blockchain",
             "properties": {
@@ -368,6 +574,45 @@ var schemas = `
             },
             "type": "object"
         },
+        "deletePropertiesFromAssetContainer": {
+            "description": "Delete one or more properties from a container's state, an example being temperature, which is only relevant for sensitive (as in frozen) shipments",
+            "properties": {
+                "args": {
+                    "items": {
+                        "properties": {
+                            "container": {
+                                "properties": {
+                                    "barcode": {
+                                        "description": "A container's ID",
+                                        "type": "string"
+                                    }
+                                },
+                                "type": "object"
+                            },
+                            "qprops": {
+                                "description": "Qualified property names, e.g. container.barcode",
+                                "items": {
+                                    "type": "string"
+                                },
+                                "type": "array"
+                            }
+                        },
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
+                    "type": "array"
+                },
+                "function": {
+                    "enum": [
+                        "deletePropertiesFromAssetContainer"
+                    ],
+                    "type": "string"
+                },
+                "method": "invoke"
+            },
+            "type": "object"
+        },
         "deletePropertiesFromAssetSurgicalKit": {
             "description": "Delete one or more properties from a surgicalkit's state, an example being temperature, which is only relevant for sensitive (as in frozen) shipments",
             "properties": {
@@ -458,241 +703,93 @@ var schemas = `
             },
             "type": "object"
         },
-        "readAllAssetsSurgicalKit": {
-            "description": "Returns the state of all surgicalkits, supports filters",
+        "linkAssets": {
+            "description": "Links a child asset to a parent asset with a typed relation, moving the child if it already has a parent in that relation",
             "properties": {
                 "args": {
                     "items": {
                         "properties": {
-                            "filter": {
-                                "description": "Filter asset states",
+                            "child": {
+                                "description": "Identifies an asset of any class",
                                 "properties": {
-                                    "match": {
-                                        "description": "Defines how to match properties, missing property always fails match",
-                                        "enum": [
-                                            "n/a",
-                                            "all",
-                                            "any",
-                                            "none"
-                                        ],
+                                    "assetID": {
+                                        "description": "An asset's unique ID, e.g. barcode, VIN, etc.",
                                         "type": "string"
                                     },
-                                    "select": {
-                                        "description": "Qualified property names and values match",
-                                        "items": {
-                                            "properties": {
-                                                "qprop": {
-                                                    "description": "Qualified property to compare, for example 'asset.assetID'",
-                                                    "type": "string"
-                                                },
-                                                "value": {
-                                                    "description": "Value to be compared",
-                                                    "type": "string"
-                                                }
-                                            },
-                                            "type": "object"
-                                        },
-                                        "type": "array"
+                                    "assetkey": {
+                                        "description": "The asset's world state key, returned by queries",
+                                        "type": "string"
+                                    },
+                                    "class": {
+                                        "description": "The asset class name",
+                                        "type": "string"
                                     }
                                 },
+                                "required": [
+                                    "class",
+                                    "assetID"
+                                ],
                                 "type": "object"
-                            }
-                        },
-                        "type": "object"
-                    },
-                    "maxItems": 1,
-                    "minItems": 0,
-                    "type": "array"
-                },
-                "function": {
-                    "enum": [
-                        "readAllAssetsSurgicalKit"
-                    ],
-                    "type": "string"
-                },
-                "method": "query",
-                "result": {
-                    "description": "Array of surgicalkit states, can mix asset classes",
-                    "items": {
-                        "patternProperties": {
-                            "^CON": {
-                                "description": "The external state of one surgicalkit asset, named by its world state ID",
-                                "type": "object"
-                            }
-                        },
-                        "type": "object"
-                    },
-                    "minItems": 0,
-                    "type": "array"
-                }
-            },
-            "type": "object"
-        },
-        "readAllRoutes": {
-            "description": "Returns an array of registered API calls by function (debugging)",
-            "properties": {
-                "args": {
-                    "items": {},
-                    "maxItems": 0,
-                    "minItems": 0,
-                    "type": "array"
-                },
-                "function": {
-                    "enum": [
-                        "readAllRoutes"
-                    ],
-                    "type": "string"
-                },
-                "method": "query",
-                "result": {
-                    "description": "An array of routes",
-                    "items": {
-                        "description": "A route defines a contract API that can be called to perform a service",
-                        "properties": {
-                            "class": {
-                                "description": "An asset's classifier definition",
-                                "properties": {
-                                    "assetidpath": "An asset's primary key, expressed as a qualified property path (see example contracts)",
-                                    "name": "An asset's class name",
-                                    "prefix": "An asset's world state prefix, used to allow iteration over all assets of a class"
-                                },
-                                "type": "object"
-                            },
-                            "functionname": {
-                                "type": "string"
-                            },
-                            "method": {
-                                "type": "string"
-                            }
-                        },
-                        "type": "object"
-                    },
-                    "minItems": 0,
-                    "type": "object"
-                }
-            },
-            "type": "object"
-        },
-        "readAllRules": {
-            "description": "Returns an array of registered rules by class (debugging)",
-            "properties": {
-                "args": {
-                    "items": {},
-                    "maxItems": 0,
-                    "minItems": 0,
-                    "type": "array"
-                },
-                "function": {
-                    "enum": [
-                        "readAllRules"
-                    ],
-                    "type": "string"
-                },
-                "method": "query",
-                "result": {
-                    "description": "An array of rules",
-                    "items": {
-                        "description": "A rule defines a behavior that is applied to every new asset state just before writing to world state, often raises or clears alerts",
-                        "properties": {
-                            "alerts": {
-                                "description": "An array of alert names",
-                                "items": {
-                                    "description": "An alert name",
-                                    "type": "string"
-                                },
-                                "type": "array"
                             },
-                            "class": {
-                                "description": "An asset's classifier definition",
+                            "parent": {
+                                "description": "Identifies an asset of any class",
                                 "properties": {
-                                    "assetidpath": "An asset's primary key, expressed as a qualified property path (see example contracts)",
-                                    "name": "An asset's class name",
-                                    "prefix": "An asset's world state prefix, used to allow iteration over all assets of a class"
+                                    "assetID": {
+                                        "description": "An asset's unique ID, e.g. barcode, VIN, etc.",
+                                        "type": "string"
+                                    },
+                                    "assetkey": {
+                                        "description": "The asset's world state key, returned by queries",
+                                        "type": "string"
+                                    },
+                                    "class": {
+                                        "description": "The asset class name",
+                                        "type": "string"
+                                    }
                                 },
+                                "required": [
+                                    "class",
+                                    "assetID"
+                                ],
                                 "type": "object"
                             },
-                            "rulename": {
+                            "relation": {
+                                "description": "A relation between a parent and a child asset, contracts can register more",
+                                "enum": [
+                                    "contains",
+                                    "installed-on",
+                                    "custodian-of"
+                                ],
                                 "type": "string"
                             }
                         },
+                        "required": [
+                            "relation",
+                            "parent",
+                            "child"
+                        ],
                         "type": "object"
                     },
-                    "minItems": 0,
-                    "type": "object"
-                }
-            },
-            "type": "object"
-        },
-        "readAssetSamples": {
-            "description": "Returns samples of selected contract objects",
-            "properties": {
-                "args": {
-                    "items": {},
-                    "maxItems": 0,
-                    "minItems": 0,
-                    "type": "array"
-                },
-                "function": {
-                    "enum": [
-                        "readAssetSamples"
-                    ],
-                    "type": "string"
-                },
-                "method": "query",
-                "result": {
-                    "properties": {},
-                    "type": "object"
-                }
-            },
-            "type": "object"
-        },
-        "readAssetSchemas": {
-            "description": "Returns the API for this contract for the use of self-configuring applications; is MANDATORY for integration with the Watson IoT Platform",
-            "properties": {
-                "args": {
-                    "items": {},
-                    "maxItems": 0,
-                    "minItems": 0,
+                    "maxItems": 1,
+                    "minItems": 1,
                     "type": "array"
                 },
                 "function": {
                     "enum": [
-                        "readAssetSchemas"
+                        "linkAssets"
                     ],
                     "type": "string"
                 },
-                "method": "query",
-                "result": {
-                    "properties": {},
-                    "type": "object"
-                }
+                "method": "invoke"
             },
             "type": "object"
         },
-        "readAssetStateHistorySurgicalKit": {
-            "description": "Returns history states for a surgicalkit",
+        "readAllAssetsContainer": {
+            "description": "Returns the state of all containers, supports filters",
             "properties": {
                 "args": {
                     "items": {
                         "properties": {
-                            "daterange": {
-                                "description": "if specified, dates must fall in between these values, inclusive",
-                                "properties": {
-                                    "begin": {
-                                        "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
-                                        "format": "date-time",
-                                        "sample": "yyyy-mm-dd hh:mm:ss",
-                                        "type": "string"
-                                    },
-                                    "end": {
-                                        "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
-                                        "format": "date-time",
-                                        "sample": "yyyy-mm-dd hh:mm:ss",
-                                        "type": "string"
-                                    }
-                                },
-                                "type": "object"
-                            },
                             "filter": {
                                 "description": "Filter asset states",
                                 "properties": {
@@ -725,620 +822,968 @@ var schemas = `
                                     }
                                 },
                                 "type": "object"
-                            },
-                            "surgicalkit": {
-                                "properties": {
-                                    "skitID": {
-                                        "description": "A surgicalkit's ID",
-                                        "type": "string"
-                                    }
-                                },
-                                "type": "object"
                             }
                         },
-                        "required": [
-                            "surgicalkit"
-                        ],
                         "type": "object"
                     },
                     "maxItems": 1,
-                    "minItems": 1,
+                    "minItems": 0,
                     "type": "array"
                 },
                 "function": {
                     "enum": [
-                        "readAssetStateHistorySurgicalKit"
+                        "readAllAssetsContainer"
                     ],
                     "type": "string"
                 },
                 "method": "query",
                 "result": {
-                    "description": "Array of surgicalkit states, can mix asset classes",
+                    "description": "Array of container states, can mix asset classes",
                     "items": {
                         "patternProperties": {
                             "^CON": {
-                                "description": "The external state of one surgicalkit asset, named by its world state ID",
-                                "type": "object"
-                            }
-                        },
-                        "type": "object"
-                    },
-                    "minItems": 0,
-                    "type": "array"
-                }
-            },
-            "type": "object"
-        },
-        "readAssetSurgicalKit": {
-            "description": "Returns the state a surgicalkit",
-            "properties": {
-                "args": {
-                    "items": {
-                        "properties": {
-                            "surgicalkit": {
+                                "description": "A container's complete state",
                                 "properties": {
-                                    "skitID": {
-                                        "description": "A surgicalkit's ID",
+                                    "AssetKey": {
+                                        "description": "This container's world state container ID",
                                         "type": "string"
-                                    }
-                                },
-                                "type": "object"
-                            }
-                        },
-                        "type": "object"
-                    },
-                    "maxItems": 1,
-                    "minItems": 1,
-                    "type": "array"
-                },
-                "function": {
-                    "enum": [
-                        "readAssetSurgicalKit"
-                    ],
-                    "type": "string"
-                },
-                "method": "query",
-                "result": {
-                    "description": "A surgicalkit's complete state",
-                    "properties": {
-                        "AssetKey": {
-                            "description": "This surgicalkit's world state surgicalkit ID",
-                            "type": "string"
-                        },
-                        "alerts": {
-                            "description": "An array of alert names",
-                            "items": {
-                                "description": "An alert name",
-                                "type": "string"
-                            },
-                            "type": "array"
-                        },
-                        "class": {
-                            "description": "An asset's classifier definition",
-                            "properties": {
-                                "assetidpath": "An asset's primary key, expressed as a qualified property path (see example contracts)",
-                                "name": "An asset's class name",
-                                "prefix": "An asset's world state prefix, used to allow iteration over all assets of a class"
-                            },
-                            "type": "object"
-                        },
-                        "compliant": {
-                            "description": "This surgicalkit has no active alerts",
-                            "type": "boolean"
-                        },
-                        "eventin": {
-                            "description": "The contract event that created this state, for example updateAssetSurgicalKit",
-                            "properties": {
-                                "surgicalkit": {
-                                    "description": "The changeable properties for a surgicalkit, also considered its 'event' as a partial state",
-                                    "properties": {
-                                        "common": {
-                                            "description": "Common properties for all assets",
-                                            "properties": {
-                                                "appdata": {
-                                                    "description": "Application managed information as an array of key:value pairs",
-                                                    "items": {
+                                    },
+                                    "alerts": {
+                                        "description": "An array of alert names",
+                                        "items": {
+                                            "description": "An alert name",
+                                            "type": "string"
+                                        },
+                                        "type": "array"
+                                    },
+                                    "assetIDpath": {
+                                        "description": "Qualified property path to the container's ID, declared in the contract code",
+                                        "type": "string"
+                                    },
+                                    "class": {
+                                        "description": "The container's asset class",
+                                        "type": "string"
+                                    },
+                                    "compliant": {
+                                        "description": "This container has no active alerts",
+                                        "type": "boolean"
+                                    },
+                                    "eventin": {
+                                        "description": "The contract event that created this state, for example updateAssetContainer",
+                                        "properties": {
+                                            "container": {
+                                                "description": "The changeable properties for a container, also considered its 'event' as a partial state",
+                                                "properties": {
+                                                    "barcode": {
+                                                        "description": "A container's ID",
+                                                        "type": "string"
+                                                    },
+                                                    "carrier": {
+                                                        "description": "The carrier in possession of this container",
+                                                        "type": "string"
+                                                    },
+                                                    "common": {
+                                                        "description": "Common properties for all assets",
                                                         "properties": {
-                                                            "K": {
+                                                            "appdata": {
+                                                                "description": "Application managed information as an array of key:value pairs",
+                                                                "items": {
+                                                                    "properties": {
+                                                                        "K": {
+                                                                            "type": "string"
+                                                                        },
+                                                                        "V": {
+                                                                            "type": "string"
+                                                                        }
+                                                                    },
+                                                                    "type": "object"
+                                                                },
+                                                                "minItems": 0,
+                                                                "type": "array"
+                                                            },
+                                                            "deviceID": {
+                                                                "description": "A unique identifier for the device that sent the current event",
                                                                 "type": "string"
                                                             },
-                                                            "V": {
+                                                            "devicetimestamp": {
+                                                                "description": "A timestamp recoded by the device that sent the current event",
                                                                 "type": "string"
+                                                            },
+                                                            "location": {
+                                                                "description": "A geographical coordinate",
+                                                                "properties": {
+                                                                    "latitude": {
+                                                                        "type": "number"
+                                                                    },
+                                                                    "longitude": {
+                                                                        "type": "number"
+                                                                    }
+                                                                },
+                                                                "type": "object"
                                                             }
                                                         },
                                                         "type": "object"
                                                     },
-                                                    "minItems": 0,
-                                                    "type": "array"
-                                                },
-                                                "deviceID": {
-                                                    "description": "A unique identifier for the device that sent the current event",
-                                                    "type": "string"
-                                                },
-                                                "devicetimestamp": {
-                                                    "description": "A timestamp recoded by the device that sent the current event",
-                                                    "type": "string"
+                                                    "temperature": {
+                                                        "description": "Temperature of a container's contents in degrees Celsuis",
+                                                        "type": "number"
+                                                    }
                                                 },
-                                                "location": {
-                                                    "description": "A geographical coordinate",
-                                                    "properties": {
-                                                        "latitude": {
-                                                            "type": "number"
-                                                        },
-                                                        "longitude": {
-                                                            "type": "number"
-                                                        }
-                                                    },
-                                                    "type": "object"
-                                                }
-                                            },
-                                            "type": "object"
+                                                "required": [
+                                                    "barcode"
+                                                ],
+                                                "type": "object"
+                                            }
                                         },
-                                        "hospital": {
-                                            "description": "the hospital within which the surgical kit is used, and within which it is geofenced",
-                                            "properties": {
-                                                "address": {
-                                                    "properties": {
-                                                        "city": {
-                                                            "type": "string"
-                                                        },
-                                                        "country": {
-                                                            "type": "string"
-                                                        },
-                                                        "postcode": {
-                                                            "type": "string"
-                                                        },
-                                                        "streetandnumber": {
-                                                            "type": "string"
-                                                        }
+                                        "type": "object"
+                                    },
+                                    "eventout": {
+                                        "description": "The chaincode event emitted on invoke exit, if any",
+                                        "properties": {
+                                            "container": {
+                                                "description": "A chaincode event defining the standard platform-generated result event for a contract invoke, contains an array of contributed results",
+                                                "properties": {
+                                                    "name": {
+                                                        "default": "EVT.IOTCP.INVOKE.RESULT",
+                                                        "enum": [
+                                                            "EVT.IOTCP.INVOKE.RESULT"
+                                                        ],
+                                                        "type": "string"
                                                     },
-                                                    "type": "object"
-                                                },
-                                                "fence": {
-                                                    "properties": {
-                                                        "center": {
-                                                            "description": "A geographical coordinate",
+                                                    "payload": {
+                                                        "description": "A map of contributed results",
+                                                        "properties": {
+                                                            "description": "the overall status of the invoke result, defined by err",
                                                             "properties": {
-                                                                "latitude": {
-                                                                    "type": "number"
+                                                                "activeAlerts": {
+                                                                    "description": "An array of alert names",
+                                                                    "items": {
+                                                                        "description": "An alert name",
+                                                                        "type": "string"
+                                                                    },
+                                                                    "type": "array"
                                                                 },
-                                                                "longitude": {
-                                                                    "type": "number"
+                                                                "alertsCleared": {
+                                                                    "description": "An array of alert names",
+                                                                    "items": {
+                                                                        "description": "An alert name",
+                                                                        "type": "string"
+                                                                    },
+                                                                    "type": "array"
+                                                                },
+                                                                "alertsRaised": {
+                                                                    "description": "An array of alert names",
+                                                                    "items": {
+                                                                        "description": "An alert name",
+                                                                        "type": "string"
+                                                                    },
+                                                                    "type": "array"
+                                                                },
+                                                                "invokeresult": {
+                                                                    "description": "status: OK==txn succeeded, ERROR==txn failed",
+                                                                    "properties": {
+                                                                        "message": {
+                                                                            "type": "string"
+                                                                        },
+                                                                        "status": {
+                                                                            "enum": [
+                                                                                "OK",
+                                                                                "ERROR"
+                                                                            ],
+                                                                            "type": "string"
+                                                                        }
+                                                                    },
+                                                                    "type": "object"
                                                                 }
                                                             },
                                                             "type": "object"
                                                         },
-                                                        "radius": {
-                                                            "type": "number"
-                                                        }
+                                                        "type": "object"
+                                                    }
+                                                },
+                                                "type": "object"
+                                            }
+                                        },
+                                        "type": "object"
+                                    },
+                                    "prefix": {
+                                        "description": "The container's asset class prefix in world state",
+                                        "type": "string"
+                                    },
+                                    "state": {
+                                        "description": "Properties that have been received or calculated for this container",
+                                        "properties": {
+                                            "container": {
+                                                "description": "The changeable properties for a container, also considered its 'event' as a partial state",
+                                                "properties": {
+                                                    "barcode": {
+                                                        "description": "A container's ID",
+                                                        "type": "string"
                                                     },
-                                                    "type": "object"
+                                                    "carrier": {
+                                                        "description": "The carrier in possession of this container",
+                                                        "type": "string"
+                                                    },
+                                                    "common": {
+                                                        "description": "Common properties for all assets",
+                                                        "properties": {
+                                                            "appdata": {
+                                                                "description": "Application managed information as an array of key:value pairs",
+                                                                "items": {
+                                                                    "properties": {
+                                                                        "K": {
+                                                                            "type": "string"
+                                                                        },
+                                                                        "V": {
+                                                                            "type": "string"
+                                                                        }
+                                                                    },
+                                                                    "type": "object"
+                                                                },
+                                                                "minItems": 0,
+                                                                "type": "array"
+                                                            },
+                                                            "deviceID": {
+                                                                "description": "A unique identifier for the device that sent the current event",
+                                                                "type": "string"
+                                                            },
+                                                            "devicetimestamp": {
+                                                                "description": "A timestamp recoded by the device that sent the current event",
+                                                                "type": "string"
+                                                            },
+                                                            "location": {
+                                                                "description": "A geographical coordinate",
+                                                                "properties": {
+                                                                    "latitude": {
+                                                                        "type": "number"
+                                                                    },
+                                                                    "longitude": {
+                                                                        "type": "number"
+                                                                    }
+                                                                },
+                                                                "type": "object"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    },
+                                                    "temperature": {
+                                                        "description": "Temperature of a container's contents in degrees Celsuis",
+                                                        "type": "number"
+                                                    }
                                                 },
-                                                "name": {
-                                                    "type": "string"
-                                                }
-                                            },
-                                            "type": "object"
+                                                "required": [
+                                                    "barcode"
+                                                ],
+                                                "type": "object"
+                                            }
                                         },
-                                        "sensors": {
-                                            "description": "sensor readings for the surgical kit",
+                                        "type": "object"
+                                    },
+                                    "txnid": {
+                                        "description": "Transaction UUID matching the blockchain",
+                                        "type": "string"
+                                    },
+                                    "txnts": {
+                                        "description": "Transaction timestamp matching the blockchain",
+                                        "type": "string"
+                                    }
+                                },
+                                "type": "object"
+                            }
+                        },
+                        "type": "object"
+                    },
+                    "minItems": 0,
+                    "type": "array"
+                }
+            },
+            "type": "object"
+        },
+        "readAllAssetsSurgicalKit": {
+            "description": "Returns the state of all surgicalkits, supports filters",
+            "properties": {
+                "args": {
+                    "items": {
+                        "properties": {
+                            "filter": {
+                                "description": "Filter asset states",
+                                "properties": {
+                                    "match": {
+                                        "description": "Defines how to match properties, missing property always fails match",
+                                        "enum": [
+                                            "n/a",
+                                            "all",
+                                            "any",
+                                            "none"
+                                        ],
+                                        "type": "string"
+                                    },
+                                    "select": {
+                                        "description": "Qualified property names and values match",
+                                        "items": {
                                             "properties": {
-                                                "begin": {
-                                                    "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
-                                                    "format": "date-time",
-                                                    "sample": "yyyy-mm-dd hh:mm:ss",
+                                                "qprop": {
+                                                    "description": "Qualified property to compare, for example 'asset.assetID'",
                                                     "type": "string"
                                                 },
-                                                "currtilt": {
-                                                    "description": "The current tilt that the kit is experiencing",
-                                                    "type": "number"
-                                                },
-                                                "end": {
-                                                    "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
-                                                    "format": "date-time",
-                                                    "sample": "yyyy-mm-dd hh:mm:ss",
+                                                "value": {
+                                                    "description": "Value to be compared",
                                                     "type": "string"
-                                                },
-                                                "endlocation": {
-                                                    "description": "A geographical coordinate",
-                                                    "properties": {
-                                                        "latitude": {
-                                                            "type": "number"
-                                                        },
-                                                        "longitude": {
-                                                            "type": "number"
-                                                        }
-                                                    },
-                                                    "type": "object"
-                                                },
-                                                "maxgforce": {
-                                                    "description": "The highest (in Gs) force that the kit experienced during the sample",
-                                                    "type": "number"
-                                                },
-                                                "maxtilt": {
-                                                    "description": "The highest (in degrees from horizontal) tilt that the kit experienced during the sample",
-                                                    "type": "number"
-                                                },
-                                                "startlocation": {
-                                                    "description": "A geographical coordinate",
-                                                    "properties": {
-                                                        "latitude": {
-                                                            "type": "number"
-                                                        },
-                                                        "longitude": {
-                                                            "type": "number"
-                                                        }
-                                                    },
-                                                    "type": "object"
                                                 }
                                             },
                                             "type": "object"
                                         },
-                                        "skitID": {
-                                            "description": "A surgicalkit's ID",
-                                            "type": "string"
-                                        },
-                                        "status": {
-                                            "description": "current kit status as a named entity in possession of the kit",
-                                            "enum": [
-                                                "",
-                                                "oem",
-                                                "warehouse",
-                                                "dealer",
-                                                "retailer",
-                                                "hospital",
-                                                "scrapped"
-                                            ],
-                                            "type": "string"
-                                        },
-                                        "transit": {
-                                            "description": "shipping data during transit periods",
-                                            "properties": {
-                                                "begintransit": {
-                                                    "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
-                                                    "format": "date-time",
-                                                    "sample": "yyyy-mm-dd hh:mm:ss",
-                                                    "type": "string"
-                                                },
-                                                "carrier": {
-                                                    "type": "string"
-                                                },
-                                                "endtransit": {
-                                                    "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
-                                                    "format": "date-time",
-                                                    "sample": "yyyy-mm-dd hh:mm:ss",
-                                                    "type": "string"
-                                                },
-                                                "receiver": {
-                                                    "description": "current kit status as a named entity in possession of the kit",
-                                                    "enum": [
-                                                        "",
-                                                        "oem",
-                                                        "warehouse",
-                                                        "dealer",
-                                                        "retailer",
-                                                        "hospital",
-                                                        "scrapped"
-                                                    ],
-                                                    "type": "string"
-                                                },
-                                                "shipper": {
-                                                    "description": "current kit status as a named entity in possession of the kit",
-                                                    "enum": [
-                                                        "",
-                                                        "oem",
-                                                        "warehouse",
-                                                        "dealer",
-                                                        "retailer",
-                                                        "hospital",
-                                                        "scrapped"
-                                                    ],
-                                                    "type": "string"
-                                                }
-                                            },
-                                            "type": "object"
-                                        }
-                                    },
-                                    "required": [
-                                        "skitID"
-                                    ],
-                                    "type": "object"
-                                }
-                            },
-                            "type": "object"
+                                        "type": "array"
+                                    }
+                                },
+                                "type": "object"
+                            }
                         },
-                        "eventout": {
-                            "description": "The chaincode event emitted on invoke exit, if any",
-                            "properties": {
-                                "surgicalkit": {
-                                    "description": "An chaincode event emitted by a contract invoke",
-                                    "properties": {
-                                        "name": {
-                                            "description": "The chaincode event's name",
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 0,
+                    "type": "array"
+                },
+                "function": {
+                    "enum": [
+                        "readAllAssetsSurgicalKit"
+                    ],
+                    "type": "string"
+                },
+                "method": "query",
+                "result": {
+                    "description": "Array of surgicalkit states, can mix asset classes",
+                    "items": {
+                        "patternProperties": {
+                            "^CON": {
+                                "description": "A surgicalkit's complete state",
+                                "properties": {
+                                    "AssetKey": {
+                                        "description": "This surgicalkit's world state surgicalkit ID",
+                                        "type": "string"
+                                    },
+                                    "alerts": {
+                                        "description": "An array of alert names",
+                                        "items": {
+                                            "description": "An alert name",
                                             "type": "string"
                                         },
-                                        "payload": {
-                                            "description": "The chaincode event's properties",
-                                            "properties": {},
-                                            "type": "object"
-                                        }
+                                        "type": "array"
                                     },
-                                    "type": "object"
-                                }
-                            },
-                            "type": "object"
-                        },
-                        "state": {
-                            "description": "Properties that have been received or calculated for this surgicalkit",
-                            "properties": {
-                                "distanceFromCenter": {
-                                    "description": "calculated distance from the fence center, can be compared to fence radius",
-                                    "type": "number"
-                                },
-                                "surgicalkit": {
-                                    "description": "The changeable properties for a surgicalkit, also considered its 'event' as a partial state",
-                                    "properties": {
-                                        "common": {
-                                            "description": "Common properties for all assets",
-                                            "properties": {
-                                                "appdata": {
-                                                    "description": "Application managed information as an array of key:value pairs",
-                                                    "items": {
+                                    "class": {
+                                        "description": "An asset's classifier definition",
+                                        "properties": {
+                                            "assetidpath": "An asset's primary key, expressed as a qualified property path (see example contracts)",
+                                            "name": "An asset's class name",
+                                            "prefix": "An asset's world state prefix, used to allow iteration over all assets of a class"
+                                        },
+                                        "type": "object"
+                                    },
+                                    "compliant": {
+                                        "description": "This surgicalkit has no active alerts",
+                                        "type": "boolean"
+                                    },
+                                    "eventin": {
+                                        "description": "The contract event that created this state, for example updateAssetSurgicalKit",
+                                        "properties": {
+                                            "surgicalkit": {
+                                                "description": "The changeable properties for a surgicalkit, also considered its 'event' as a partial state",
+                                                "properties": {
+                                                    "common": {
+                                                        "description": "Common properties for all assets",
                                                         "properties": {
-                                                            "K": {
+                                                            "appdata": {
+                                                                "description": "Application managed information as an array of key:value pairs",
+                                                                "items": {
+                                                                    "properties": {
+                                                                        "K": {
+                                                                            "type": "string"
+                                                                        },
+                                                                        "V": {
+                                                                            "type": "string"
+                                                                        }
+                                                                    },
+                                                                    "type": "object"
+                                                                },
+                                                                "minItems": 0,
+                                                                "type": "array"
+                                                            },
+                                                            "deviceID": {
+                                                                "description": "A unique identifier for the device that sent the current event",
                                                                 "type": "string"
                                                             },
-                                                            "V": {
+                                                            "devicetimestamp": {
+                                                                "description": "A timestamp recoded by the device that sent the current event",
                                                                 "type": "string"
+                                                            },
+                                                            "location": {
+                                                                "description": "A geographical coordinate",
+                                                                "properties": {
+                                                                    "latitude": {
+                                                                        "type": "number"
+                                                                    },
+                                                                    "longitude": {
+                                                                        "type": "number"
+                                                                    }
+                                                                },
+                                                                "type": "object"
                                                             }
                                                         },
                                                         "type": "object"
                                                     },
-                                                    "minItems": 0,
-                                                    "type": "array"
-                                                },
-                                                "deviceID": {
-                                                    "description": "A unique identifier for the device that sent the current event",
-                                                    "type": "string"
-                                                },
-                                                "devicetimestamp": {
-                                                    "description": "A timestamp recoded by the device that sent the current event",
-                                                    "type": "string"
-                                                },
-                                                "location": {
-                                                    "description": "A geographical coordinate",
-                                                    "properties": {
-                                                        "latitude": {
-                                                            "type": "number"
+                                                    "hospital": {
+                                                        "description": "the hospital within which the surgical kit is used, and within which it is geofenced",
+                                                        "properties": {
+                                                            "address": {
+                                                                "properties": {
+                                                                    "city": {
+                                                                        "type": "string"
+                                                                    },
+                                                                    "country": {
+                                                                        "type": "string"
+                                                                    },
+                                                                    "postcode": {
+                                                                        "type": "string"
+                                                                    },
+                                                                    "streetandnumber": {
+                                                                        "type": "string"
+                                                                    }
+                                                                },
+                                                                "type": "object"
+                                                            },
+                                                            "fence": {
+                                                                "properties": {
+                                                                    "center": {
+                                                                        "description": "A geographical coordinate",
+                                                                        "properties": {
+                                                                            "latitude": {
+                                                                                "type": "number"
+                                                                            },
+                                                                            "longitude": {
+                                                                                "type": "number"
+                                                                            }
+                                                                        },
+                                                                        "type": "object"
+                                                                    },
+                                                                    "radius": {
+                                                                        "type": "number"
+                                                                    }
+                                                                },
+                                                                "type": "object"
+                                                            },
+                                                            "name": {
+                                                                "type": "string"
+                                                            }
                                                         },
-                                                        "longitude": {
-                                                            "type": "number"
-                                                        }
+                                                        "type": "object"
                                                     },
-                                                    "type": "object"
-                                                }
-                                            },
-                                            "type": "object"
-                                        },
-                                        "hospital": {
-                                            "description": "the hospital within which the surgical kit is used, and within which it is geofenced",
-                                            "properties": {
-                                                "address": {
-                                                    "properties": {
-                                                        "city": {
-                                                            "type": "string"
-                                                        },
-                                                        "country": {
-                                                            "type": "string"
-                                                        },
-                                                        "postcode": {
-                                                            "type": "string"
+                                                    "sensors": {
+                                                        "description": "sensor readings for the surgical kit",
+                                                        "properties": {
+                                                            "begin": {
+                                                                "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                                "format": "date-time",
+                                                                "sample": "yyyy-mm-dd hh:mm:ss",
+                                                                "type": "string"
+                                                            },
+                                                            "currtilt": {
+                                                                "description": "The current tilt that the kit is experiencing",
+                                                                "type": "number"
+                                                            },
+                                                            "end": {
+                                                                "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                                "format": "date-time",
+                                                                "sample": "yyyy-mm-dd hh:mm:ss",
+                                                                "type": "string"
+                                                            },
+                                                            "endlocation": {
+                                                                "description": "A geographical coordinate",
+                                                                "properties": {
+                                                                    "latitude": {
+                                                                        "type": "number"
+                                                                    },
+                                                                    "longitude": {
+                                                                        "type": "number"
+                                                                    }
+                                                                },
+                                                                "type": "object"
+                                                            },
+                                                            "maxgforce": {
+                                                                "description": "The highest (in Gs) force that the kit experienced during the sample",
+                                                                "type": "number"
+                                                            },
+                                                            "maxtilt": {
+                                                                "description": "The highest (in degrees from horizontal) tilt that the kit experienced during the sample",
+                                                                "type": "number"
+                                                            },
+                                                            "startlocation": {
+                                                                "description": "A geographical coordinate",
+                                                                "properties": {
+                                                                    "latitude": {
+                                                                        "type": "number"
+                                                                    },
+                                                                    "longitude": {
+                                                                        "type": "number"
+                                                                    }
+                                                                },
+                                                                "type": "object"
+                                                            }
                                                         },
-                                                        "streetandnumber": {
-                                                            "type": "string"
-                                                        }
+                                                        "type": "object"
                                                     },
-                                                    "type": "object"
+                                                    "skitID": {
+                                                        "description": "A surgicalkit's ID",
+                                                        "type": "string"
+                                                    },
+                                                    "status": {
+                                                        "description": "current kit status as a named entity in possession of the kit",
+                                                        "enum": [
+                                                            "",
+                                                            "oem",
+                                                            "warehouse",
+                                                            "dealer",
+                                                            "retailer",
+                                                            "hospital",
+                                                            "scrapped"
+                                                        ],
+                                                        "type": "string"
+                                                    },
+                                                    "transit": {
+                                                        "description": "shipping data during transit periods",
+                                                        "properties": {
+                                                            "begintransit": {
+                                                                "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                                "format": "date-time",
+                                                                "sample": "yyyy-mm-dd hh:mm:ss",
+                                                                "type": "string"
+                                                            },
+                                                            "carrier": {
+                                                                "type": "string"
+                                                            },
+                                                            "endtransit": {
+                                                                "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                                "format": "date-time",
+                                                                "sample": "yyyy-mm-dd hh:mm:ss",
+                                                                "type": "string"
+                                                            },
+                                                            "receiver": {
+                                                                "description": "current kit status as a named entity in possession of the kit",
+                                                                "enum": [
+                                                                    "",
+                                                                    "oem",
+                                                                    "warehouse",
+                                                                    "dealer",
+                                                                    "retailer",
+                                                                    "hospital",
+                                                                    "scrapped"
+                                                                ],
+                                                                "type": "string"
+                                                            },
+                                                            "shipper": {
+                                                                "description": "current kit status as a named entity in possession of the kit",
+                                                                "enum": [
+                                                                    "",
+                                                                    "oem",
+                                                                    "warehouse",
+                                                                    "dealer",
+                                                                    "retailer",
+                                                                    "hospital",
+                                                                    "scrapped"
+                                                                ],
+                                                                "type": "string"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    }
                                                 },
-                                                "fence": {
-                                                    "properties": {
-                                                        "center": {
-                                                            "description": "A geographical coordinate",
+                                                "required": [
+                                                    "skitID"
+                                                ],
+                                                "type": "object"
+                                            }
+                                        },
+                                        "type": "object"
+                                    },
+                                    "eventout": {
+                                        "description": "The chaincode event emitted on invoke exit, if any",
+                                        "properties": {
+                                            "surgicalkit": {
+                                                "description": "A chaincode event defining the standard platform-generated result event for a contract invoke, contains an array of contributed results",
+                                                "properties": {
+                                                    "name": {
+                                                        "default": "EVT.IOTCP.INVOKE.RESULT",
+                                                        "enum": [
+                                                            "EVT.IOTCP.INVOKE.RESULT"
+                                                        ],
+                                                        "type": "string"
+                                                    },
+                                                    "payload": {
+                                                        "description": "A map of contributed results",
+                                                        "properties": {
+                                                            "description": "the overall status of the invoke result, defined by err",
                                                             "properties": {
-                                                                "latitude": {
-                                                                    "type": "number"
+                                                                "activeAlerts": {
+                                                                    "description": "An array of alert names",
+                                                                    "items": {
+                                                                        "description": "An alert name",
+                                                                        "type": "string"
+                                                                    },
+                                                                    "type": "array"
                                                                 },
-                                                                "longitude": {
-                                                                    "type": "number"
+                                                                "alertsCleared": {
+                                                                    "description": "An array of alert names",
+                                                                    "items": {
+                                                                        "description": "An alert name",
+                                                                        "type": "string"
+                                                                    },
+                                                                    "type": "array"
+                                                                },
+                                                                "alertsRaised": {
+                                                                    "description": "An array of alert names",
+                                                                    "items": {
+                                                                        "description": "An alert name",
+                                                                        "type": "string"
+                                                                    },
+                                                                    "type": "array"
+                                                                },
+                                                                "invokeresult": {
+                                                                    "description": "status: OK==txn succeeded, ERROR==txn failed",
+                                                                    "properties": {
+                                                                        "message": {
+                                                                            "type": "string"
+                                                                        },
+                                                                        "status": {
+                                                                            "enum": [
+                                                                                "OK",
+                                                                                "ERROR"
+                                                                            ],
+                                                                            "type": "string"
+                                                                        }
+                                                                    },
+                                                                    "type": "object"
                                                                 }
                                                             },
                                                             "type": "object"
                                                         },
-                                                        "radius": {
-                                                            "type": "number"
-                                                        }
-                                                    },
-                                                    "type": "object"
+                                                        "type": "object"
+                                                    }
                                                 },
-                                                "name": {
-                                                    "type": "string"
-                                                }
-                                            },
-                                            "type": "object"
+                                                "type": "object"
+                                            }
                                         },
-                                        "sensors": {
-                                            "description": "sensor readings for the surgical kit",
-                                            "properties": {
-                                                "begin": {
-                                                    "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
-                                                    "format": "date-time",
-                                                    "sample": "yyyy-mm-dd hh:mm:ss",
-                                                    "type": "string"
-                                                },
-                                                "currtilt": {
-                                                    "description": "The current tilt that the kit is experiencing",
-                                                    "type": "number"
-                                                },
-                                                "end": {
-                                                    "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
-                                                    "format": "date-time",
-                                                    "sample": "yyyy-mm-dd hh:mm:ss",
-                                                    "type": "string"
-                                                },
-                                                "endlocation": {
-                                                    "description": "A geographical coordinate",
-                                                    "properties": {
-                                                        "latitude": {
-                                                            "type": "number"
+                                        "type": "object"
+                                    },
+                                    "state": {
+                                        "description": "Properties that have been received or calculated for this surgicalkit",
+                                        "properties": {
+                                            "distanceFromCenter": {
+                                                "description": "calculated distance from the fence center, can be compared to fence radius",
+                                                "type": "number"
+                                            },
+                                            "surgicalkit": {
+                                                "description": "The changeable properties for a surgicalkit, also considered its 'event' as a partial state",
+                                                "properties": {
+                                                    "common": {
+                                                        "description": "Common properties for all assets",
+                                                        "properties": {
+                                                            "appdata": {
+                                                                "description": "Application managed information as an array of key:value pairs",
+                                                                "items": {
+                                                                    "properties": {
+                                                                        "K": {
+                                                                            "type": "string"
+                                                                        },
+                                                                        "V": {
+                                                                            "type": "string"
+                                                                        }
+                                                                    },
+                                                                    "type": "object"
+                                                                },
+                                                                "minItems": 0,
+                                                                "type": "array"
+                                                            },
+                                                            "deviceID": {
+                                                                "description": "A unique identifier for the device that sent the current event",
+                                                                "type": "string"
+                                                            },
+                                                            "devicetimestamp": {
+                                                                "description": "A timestamp recoded by the device that sent the current event",
+                                                                "type": "string"
+                                                            },
+                                                            "location": {
+                                                                "description": "A geographical coordinate",
+                                                                "properties": {
+                                                                    "latitude": {
+                                                                        "type": "number"
+                                                                    },
+                                                                    "longitude": {
+                                                                        "type": "number"
+                                                                    }
+                                                                },
+                                                                "type": "object"
+                                                            }
                                                         },
-                                                        "longitude": {
-                                                            "type": "number"
-                                                        }
+                                                        "type": "object"
                                                     },
-                                                    "type": "object"
-                                                },
-                                                "maxgforce": {
-                                                    "description": "The highest (in Gs) force that the kit experienced during the sample",
-                                                    "type": "number"
-                                                },
-                                                "maxtilt": {
-                                                    "description": "The highest (in degrees from horizontal) tilt that the kit experienced during the sample",
-                                                    "type": "number"
-                                                },
-                                                "startlocation": {
-                                                    "description": "A geographical coordinate",
-                                                    "properties": {
-                                                        "latitude": {
-                                                            "type": "number"
+                                                    "hospital": {
+                                                        "description": "the hospital within which the surgical kit is used, and within which it is geofenced",
+                                                        "properties": {
+                                                            "address": {
+                                                                "properties": {
+                                                                    "city": {
+                                                                        "type": "string"
+                                                                    },
+                                                                    "country": {
+                                                                        "type": "string"
+                                                                    },
+                                                                    "postcode": {
+                                                                        "type": "string"
+                                                                    },
+                                                                    "streetandnumber": {
+                                                                        "type": "string"
+                                                                    }
+                                                                },
+                                                                "type": "object"
+                                                            },
+                                                            "fence": {
+                                                                "properties": {
+                                                                    "center": {
+                                                                        "description": "A geographical coordinate",
+                                                                        "properties": {
+                                                                            "latitude": {
+                                                                                "type": "number"
+                                                                            },
+                                                                            "longitude": {
+                                                                                "type": "number"
+                                                                            }
+                                                                        },
+                                                                        "type": "object"
+                                                                    },
+                                                                    "radius": {
+                                                                        "type": "number"
+                                                                    }
+                                                                },
+                                                                "type": "object"
+                                                            },
+                                                            "name": {
+                                                                "type": "string"
+                                                            }
                                                         },
-                                                        "longitude": {
-                                                            "type": "number"
-                                                        }
+                                                        "type": "object"
                                                     },
-                                                    "type": "object"
-                                                }
-                                            },
-                                            "type": "object"
-                                        },
-                                        "skitID": {
-                                            "description": "A surgicalkit's ID",
-                                            "type": "string"
-                                        },
-                                        "status": {
-                                            "description": "current kit status as a named entity in possession of the kit",
-                                            "enum": [
-                                                "",
-                                                "oem",
-                                                "warehouse",
-                                                "dealer",
-                                                "retailer",
-                                                "hospital",
-                                                "scrapped"
-                                            ],
-                                            "type": "string"
-                                        },
-                                        "transit": {
-                                            "description": "shipping data during transit periods",
-                                            "properties": {
-                                                "begintransit": {
-                                                    "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
-                                                    "format": "date-time",
-                                                    "sample": "yyyy-mm-dd hh:mm:ss",
-                                                    "type": "string"
-                                                },
-                                                "carrier": {
-                                                    "type": "string"
-                                                },
-                                                "endtransit": {
-                                                    "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
-                                                    "format": "date-time",
-                                                    "sample": "yyyy-mm-dd hh:mm:ss",
-                                                    "type": "string"
-                                                },
-                                                "receiver": {
-                                                    "description": "current kit status as a named entity in possession of the kit",
-                                                    "enum": [
-                                                        "",
-                                                        "oem",
-                                                        "warehouse",
-                                                        "dealer",
-                                                        "retailer",
-                                                        "hospital",
-                                                        "scrapped"
-                                                    ],
-                                                    "type": "string"
+                                                    "sensors": {
+                                                        "description": "sensor readings for the surgical kit",
+                                                        "properties": {
+                                                            "begin": {
+                                                                "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                                "format": "date-time",
+                                                                "sample": "yyyy-mm-dd hh:mm:ss",
+                                                                "type": "string"
+                                                            },
+                                                            "currtilt": {
+                                                                "description": "The current tilt that the kit is experiencing",
+                                                                "type": "number"
+                                                            },
+                                                            "end": {
+                                                                "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                                "format": "date-time",
+                                                                "sample": "yyyy-mm-dd hh:mm:ss",
+                                                                "type": "string"
+                                                            },
+                                                            "endlocation": {
+                                                                "description": "A geographical coordinate",
+                                                                "properties": {
+                                                                    "latitude": {
+                                                                        "type": "number"
+                                                                    },
+                                                                    "longitude": {
+                                                                        "type": "number"
+                                                                    }
+                                                                },
+                                                                "type": "object"
+                                                            },
+                                                            "maxgforce": {
+                                                                "description": "The highest (in Gs) force that the kit experienced during the sample",
+                                                                "type": "number"
+                                                            },
+                                                            "maxtilt": {
+                                                                "description": "The highest (in degrees from horizontal) tilt that the kit experienced during the sample",
+                                                                "type": "number"
+                                                            },
+                                                            "startlocation": {
+                                                                "description": "A geographical coordinate",
+                                                                "properties": {
+                                                                    "latitude": {
+                                                                        "type": "number"
+                                                                    },
+                                                                    "longitude": {
+                                                                        "type": "number"
+                                                                    }
+                                                                },
+                                                                "type": "object"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    },
+                                                    "skitID": {
+                                                        "description": "A surgicalkit's ID",
+                                                        "type": "string"
+                                                    },
+                                                    "status": {
+                                                        "description": "current kit status as a named entity in possession of the kit",
+                                                        "enum": [
+                                                            "",
+                                                            "oem",
+                                                            "warehouse",
+                                                            "dealer",
+                                                            "retailer",
+                                                            "hospital",
+                                                            "scrapped"
+                                                        ],
+                                                        "type": "string"
+                                                    },
+                                                    "transit": {
+                                                        "description": "shipping data during transit periods",
+                                                        "properties": {
+                                                            "begintransit": {
+                                                                "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                                "format": "date-time",
+                                                                "sample": "yyyy-mm-dd hh:mm:ss",
+                                                                "type": "string"
+                                                            },
+                                                            "carrier": {
+                                                                "type": "string"
+                                                            },
+                                                            "endtransit": {
+                                                                "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                                "format": "date-time",
+                                                                "sample": "yyyy-mm-dd hh:mm:ss",
+                                                                "type": "string"
+                                                            },
+                                                            "receiver": {
+                                                                "description": "current kit status as a named entity in possession of the kit",
+                                                                "enum": [
+                                                                    "",
+                                                                    "oem",
+                                                                    "warehouse",
+                                                                    "dealer",
+                                                                    "retailer",
+                                                                    "hospital",
+                                                                    "scrapped"
+                                                                ],
+                                                                "type": "string"
+                                                            },
+                                                            "shipper": {
+                                                                "description": "current kit status as a named entity in possession of the kit",
+                                                                "enum": [
+                                                                    "",
+                                                                    "oem",
+                                                                    "warehouse",
+                                                                    "dealer",
+                                                                    "retailer",
+                                                                    "hospital",
+                                                                    "scrapped"
+                                                                ],
+                                                                "type": "string"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    }
                                                 },
-                                                "shipper": {
-                                                    "description": "current kit status as a named entity in possession of the kit",
-                                                    "enum": [
-                                                        "",
-                                                        "oem",
-                                                        "warehouse",
-                                                        "dealer",
-                                                        "retailer",
-                                                        "hospital",
-                                                        "scrapped"
-                                                    ],
-                                                    "type": "string"
-                                                }
-                                            },
-                                            "type": "object"
-                                        }
+                                                "required": [
+                                                    "skitID"
+                                                ],
+                                                "type": "object"
+                                            }
+                                        },
+                                        "type": "object"
                                     },
-                                    "required": [
-                                        "skitID"
-                                    ],
-                                    "type": "object"
-                                }
-                            },
-                            "type": "object"
-                        },
-                        "txnid": {
-                            "description": "Transaction UUID matching the blockchain",
-                            "type": "string"
+                                    "txnid": {
+                                        "description": "Transaction UUID matching the blockchain",
+                                        "type": "string"
+                                    },
+                                    "txnts": {
+                                        "description": "Transaction timestamp matching the blockchain",
+                                        "type": "string"
+                                    }
+                                },
+                                "type": "object"
+                            }
                         },
-                        "txnts": {
-                            "description": "Transaction timestamp matching the blockchain",
-                            "type": "string"
-                        }
+                        "type": "object"
                     },
-                    "type": "object"
+                    "minItems": 0,
+                    "type": "array"
                 }
             },
             "type": "object"
         },
-        "readRecentStates": {
-            "description": "Returns the state of recently updated assets",
+        "readAllRoutes": {
+            "description": "Returns an array of registered API calls by function (debugging)",
             "properties": {
                 "args": {
+                    "items": {},
+                    "maxItems": 0,
+                    "minItems": 0,
+                    "type": "array"
+                },
+                "function": {
+                    "enum": [
+                        "readAllRoutes"
+                    ],
+                    "type": "string"
+                },
+                "method": "query",
+                "result": {
+                    "description": "An array of routes",
                     "items": {
+                        "description": "A route defines a contract API that can be called to perform a service",
                         "properties": {
-                            "begin": {
-                                "description": "zero based beginning of range",
-                                "type": "integer"
+                            "class": {
+                                "description": "An asset's classifier definition",
+                                "properties": {
+                                    "assetidpath": "An asset's primary key, expressed as a qualified property path (see example contracts)",
+                                    "name": "An asset's class name",
+                                    "prefix": "An asset's world state prefix, used to allow iteration over all assets of a class"
+                                },
+                                "type": "object"
                             },
-                            "end": {
-                                "description": "zero based end of range, absence means to end",
-                                "type": "integer"
+                            "functionname": {
+                                "type": "string"
+                            },
+                            "method": {
+                                "type": "string"
                             }
                         },
                         "type": "object"
                     },
+                    "minItems": 0,
+                    "type": "object"
+                }
+            },
+            "type": "object"
+        },
+        "readAllRules": {
+            "description": "Returns an array of registered rules by class (debugging)",
+            "properties": {
+                "args": {
+                    "items": {},
                     "maxItems": 0,
                     "minItems": 0,
                     "type": "array"
                 },
                 "function": {
                     "enum": [
-                        "readRecentStates"
+                        "readAllRules"
                     ],
                     "type": "string"
                 },
                 "method": "query",
                 "result": {
-                    "description": "Array of asset states, can mix asset classes",
+                    "description": "An array of rules",
                     "items": {
-                        "description": "A asset's complete state",
+                        "description": "A rule defines a behavior that is applied to every new asset state just before writing to world state, often raises or clears alerts",
                         "properties": {
                             "alerts": {
                                 "description": "An array of alert names",
@@ -1348,10 +1793,6 @@ var schemas = `
                                 },
                                 "type": "array"
                             },
-                            "assetID": {
-                                "description": "This asset's world state asset ID",
-                                "type": "string"
-                            },
                             "class": {
                                 "description": "An asset's classifier definition",
                                 "properties": {
@@ -1361,175 +1802,139 @@ var schemas = `
                                 },
                                 "type": "object"
                             },
-                            "compliant": {
-                                "description": "This asset has no active alerts",
-                                "type": "boolean"
-                            },
-                            "eventin": {
-                                "description": "The contract event that created this state, for example updateAsset",
-                                "properties": {
-                                    "asset": {
-                                        "description": "The changeable properties for an asset, also considered its 'event' as a partial state",
-                                        "properties": {
-                                            "assetID": {
-                                                "description": "An asset's unique ID, e.g. barcode, VIN, etc.",
-                                                "type": "string"
-                                            },
-                                            "carrier": {
-                                                "description": "The carrier in possession of this asset",
-                                                "type": "string"
-                                            },
-                                            "common": {
-                                                "description": "Common properties for all assets",
-                                                "properties": {
-                                                    "appdata": {
-                                                        "description": "Application managed information as an array of key:value pairs",
-                                                        "items": {
-                                                            "properties": {
-                                                                "K": {
-                                                                    "type": "string"
-                                                                },
-                                                                "V": {
-                                                                    "type": "string"
-                                                                }
-                                                            },
-                                                            "type": "object"
-                                                        },
-                                                        "minItems": 0,
-                                                        "type": "array"
-                                                    },
-                                                    "deviceID": {
-                                                        "description": "A unique identifier for the device that sent the current event",
-                                                        "type": "string"
-                                                    },
-                                                    "devicetimestamp": {
-                                                        "description": "A timestamp recoded by the device that sent the current event",
-                                                        "type": "string"
-                                                    },
-                                                    "location": {
-                                                        "description": "A geographical coordinate",
-                                                        "properties": {
-                                                            "latitude": {
-                                                                "type": "number"
-                                                            },
-                                                            "longitude": {
-                                                                "type": "number"
-                                                            }
-                                                        },
-                                                        "type": "object"
-                                                    }
-                                                },
-                                                "type": "object"
-                                            },
-                                            "temperature": {
-                                                "description": "Temperature of an asset's contents in degrees Celsuis",
-                                                "type": "number"
-                                            }
-                                        },
-                                        "required": [
-                                            "assetID"
-                                        ],
-                                        "type": "object"
+                            "rulename": {
+                                "type": "string"
+                            }
+                        },
+                        "type": "object"
+                    },
+                    "minItems": 0,
+                    "type": "object"
+                }
+            },
+            "type": "object"
+        },
+        "readAssetAncestry": {
+            "description": "Returns every edge above an asset in the relation or in all relations, nearest first",
+            "properties": {
+                "args": {
+                    "items": {
+                        "properties": {
+                            "asset": {
+                                "description": "Identifies an asset of any class",
+                                "properties": {
+                                    "assetID": {
+                                        "description": "An asset's unique ID, e.g. barcode, VIN, etc.",
+                                        "type": "string"
+                                    },
+                                    "assetkey": {
+                                        "description": "The asset's world state key, returned by queries",
+                                        "type": "string"
+                                    },
+                                    "class": {
+                                        "description": "The asset class name",
+                                        "type": "string"
                                     }
                                 },
+                                "required": [
+                                    "class",
+                                    "assetID"
+                                ],
                                 "type": "object"
                             },
-                            "eventout": {
-                                "description": "The chaincode event emitted on invoke exit, if any",
+                            "relation": {
+                                "description": "A relation between a parent and a child asset, contracts can register more",
+                                "enum": [
+                                    "contains",
+                                    "installed-on",
+                                    "custodian-of"
+                                ],
+                                "type": "string"
+                            }
+                        },
+                        "required": [
+                            "asset"
+                        ],
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
+                    "type": "array"
+                },
+                "function": {
+                    "enum": [
+                        "readAssetAncestry"
+                    ],
+                    "type": "string"
+                },
+                "method": "query",
+                "result": {
+                    "description": "An array of edges above an asset, nearest first",
+                    "items": {
+                        "description": "A link from a parent asset to a child asset",
+                        "properties": {
+                            "child": {
+                                "description": "Identifies an asset of any class",
                                 "properties": {
-                                    "asset": {
-                                        "description": "An chaincode event emitted by a contract invoke",
-                                        "properties": {
-                                            "name": {
-                                                "description": "The chaincode event's name",
-                                                "type": "string"
-                                            },
-                                            "payload": {
-                                                "description": "The chaincode event's properties",
-                                                "properties": {},
-                                                "type": "object"
-                                            }
-                                        },
-                                        "type": "object"
+                                    "assetID": {
+                                        "description": "An asset's unique ID, e.g. barcode, VIN, etc.",
+                                        "type": "string"
+                                    },
+                                    "assetkey": {
+                                        "description": "The asset's world state key, returned by queries",
+                                        "type": "string"
+                                    },
+                                    "class": {
+                                        "description": "The asset class name",
+                                        "type": "string"
                                     }
                                 },
+                                "required": [
+                                    "class",
+                                    "assetID"
+                                ],
                                 "type": "object"
                             },
-                            "state": {
-                                "description": "Properties that have been received or calculated for this asset",
+                            "depth": {
+                                "description": "Ancestry only, 1 for the asset's own parents",
+                                "type": "integer"
+                            },
+                            "parent": {
+                                "description": "Identifies an asset of any class",
                                 "properties": {
-                                    "asset": {
-                                        "description": "The changeable properties for an asset, also considered its 'event' as a partial state",
-                                        "properties": {
-                                            "assetID": {
-                                                "description": "An asset's unique ID, e.g. barcode, VIN, etc.",
-                                                "type": "string"
-                                            },
-                                            "carrier": {
-                                                "description": "The carrier in possession of this asset",
-                                                "type": "string"
-                                            },
-                                            "common": {
-                                                "description": "Common properties for all assets",
-                                                "properties": {
-                                                    "appdata": {
-                                                        "description": "Application managed information as an array of key:value pairs",
-                                                        "items": {
-                                                            "properties": {
-                                                                "K": {
-                                                                    "type": "string"
-                                                                },
-                                                                "V": {
-                                                                    "type": "string"
-                                                                }
-                                                            },
-                                                            "type": "object"
-                                                        },
-                                                        "minItems": 0,
-                                                        "type": "array"
-                                                    },
-                                                    "deviceID": {
-                                                        "description": "A unique identifier for the device that sent the current event",
-                                                        "type": "string"
-                                                    },
-                                                    "devicetimestamp": {
-                                                        "description": "A timestamp recoded by the device that sent the current event",
-                                                        "type": "string"
-                                                    },
-                                                    "location": {
-                                                        "description": "A geographical coordinate",
-                                                        "properties": {
-                                                            "latitude": {
-                                                                "type": "number"
-                                                            },
-                                                            "longitude": {
-                                                                "type": "number"
-                                                            }
-                                                        },
-                                                        "type": "object"
-                                                    }
-                                                },
-                                                "type": "object"
-                                            },
-                                            "temperature": {
-                                                "description": "Temperature of an asset's contents in degrees Celsuis",
-                                                "type": "number"
-                                            }
-                                        },
-                                        "required": [
-                                            "assetID"
-                                        ],
-                                        "type": "object"
+                                    "assetID": {
+                                        "description": "An asset's unique ID, e.g. barcode, VIN, etc.",
+                                        "type": "string"
+                                    },
+                                    "assetkey": {
+                                        "description": "The asset's world state key, returned by queries",
+                                        "type": "string"
+                                    },
+                                    "class": {
+                                        "description": "The asset class name",
+                                        "type": "string"
                                     }
                                 },
+                                "required": [
+                                    "class",
+                                    "assetID"
+                                ],
                                 "type": "object"
                             },
+                            "relation": {
+                                "description": "A relation between a parent and a child asset, contracts can register more",
+                                "enum": [
+                                    "contains",
+                                    "installed-on",
+                                    "custodian-of"
+                                ],
+                                "type": "string"
+                            },
                             "txnid": {
-                                "description": "Transaction UUID matching the blockchain",
                                 "type": "string"
                             },
                             "txnts": {
-                                "description": "Transaction timestamp matching the blockchain",
+                                "format": "date-time",
                                 "type": "string"
                             }
                         },
@@ -1541,312 +1946,323 @@ var schemas = `
             },
             "type": "object"
         },
-        "readWorldState": {
-            "description": "Returns the entire contents of world state",
+        "readAssetAsOfContainer": {
+            "description": "Returns a container as it was at a point in time, rebuilt from its history",
             "properties": {
                 "args": {
-                    "items": {},
-                    "maxItems": 0,
-                    "minItems": 0,
+                    "items": {
+                        "properties": {
+                            "asof": {
+                                "description": "the point in time, the latest state written at or before it is returned",
+                                "format": "date-time",
+                                "sample": "yyyy-mm-ddThh:mm:ssZ",
+                                "type": "string"
+                            },
+                            "container": {
+                                "properties": {
+                                    "barcode": {
+                                        "description": "A container's ID",
+                                        "type": "string"
+                                    }
+                                },
+                                "type": "object"
+                            }
+                        },
+                        "required": [
+                            "container",
+                            "asof"
+                        ],
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
                     "type": "array"
                 },
                 "function": {
                     "enum": [
-                        "readWorldState"
+                        "readAssetAsOfContainer"
                     ],
                     "type": "string"
                 },
                 "method": "query",
                 "result": {
-                    "properties": {},
-                    "type": "object"
-                }
-            },
-            "type": "object"
-        },
-        "replaceAssetSurgicalKit": {
-            "description": "Replaces a surgicalkit's state (e.g. put existing)",
-            "properties": {
-                "args": {
-                    "items": {
-                        "properties": {
-                            "surgicalkit": {
-                                "description": "The changeable properties for a surgicalkit, also considered its 'event' as a partial state",
-                                "properties": {
-                                    "common": {
-                                        "description": "Common properties for all assets",
-                                        "properties": {
-                                            "appdata": {
-                                                "description": "Application managed information as an array of key:value pairs",
-                                                "items": {
-                                                    "properties": {
-                                                        "K": {
-                                                            "type": "string"
-                                                        },
-                                                        "V": {
-                                                            "type": "string"
-                                                        }
-                                                    },
-                                                    "type": "object"
-                                                },
-                                                "minItems": 0,
-                                                "type": "array"
-                                            },
-                                            "deviceID": {
-                                                "description": "A unique identifier for the device that sent the current event",
-                                                "type": "string"
-                                            },
-                                            "devicetimestamp": {
-                                                "description": "A timestamp recoded by the device that sent the current event",
-                                                "type": "string"
-                                            },
-                                            "location": {
-                                                "description": "A geographical coordinate",
-                                                "properties": {
-                                                    "latitude": {
-                                                        "type": "number"
+                    "description": "A container's complete state",
+                    "properties": {
+                        "AssetKey": {
+                            "description": "This container's world state container ID",
+                            "type": "string"
+                        },
+                        "alerts": {
+                            "description": "An array of alert names",
+                            "items": {
+                                "description": "An alert name",
+                                "type": "string"
+                            },
+                            "type": "array"
+                        },
+                        "assetIDpath": {
+                            "description": "Qualified property path to the container's ID, declared in the contract code",
+                            "type": "string"
+                        },
+                        "class": {
+                            "description": "The container's asset class",
+                            "type": "string"
+                        },
+                        "compliant": {
+                            "description": "This container has no active alerts",
+                            "type": "boolean"
+                        },
+                        "eventin": {
+                            "description": "The contract event that created this state, for example updateAssetContainer",
+                            "properties": {
+                                "container": {
+                                    "description": "The changeable properties for a container, also considered its 'event' as a partial state",
+                                    "properties": {
+                                        "barcode": {
+                                            "description": "A container's ID",
+                                            "type": "string"
+                                        },
+                                        "carrier": {
+                                            "description": "The carrier in possession of this container",
+                                            "type": "string"
+                                        },
+                                        "common": {
+                                            "description": "Common properties for all assets",
+                                            "properties": {
+                                                "appdata": {
+                                                    "description": "Application managed information as an array of key:value pairs",
+                                                    "items": {
+                                                        "properties": {
+                                                            "K": {
+                                                                "type": "string"
+                                                            },
+                                                            "V": {
+                                                                "type": "string"
+                                                            }
+                                                        },
+                                                        "type": "object"
                                                     },
-                                                    "longitude": {
-                                                        "type": "number"
-                                                    }
+                                                    "minItems": 0,
+                                                    "type": "array"
                                                 },
-                                                "type": "object"
-                                            }
+                                                "deviceID": {
+                                                    "description": "A unique identifier for the device that sent the current event",
+                                                    "type": "string"
+                                                },
+                                                "devicetimestamp": {
+                                                    "description": "A timestamp recoded by the device that sent the current event",
+                                                    "type": "string"
+                                                },
+                                                "location": {
+                                                    "description": "A geographical coordinate",
+                                                    "properties": {
+                                                        "latitude": {
+                                                            "type": "number"
+                                                        },
+                                                        "longitude": {
+                                                            "type": "number"
+                                                        }
+                                                    },
+                                                    "type": "object"
+                                                }
+                                            },
+                                            "type": "object"
                                         },
-                                        "type": "object"
+                                        "temperature": {
+                                            "description": "Temperature of a container's contents in degrees Celsuis",
+                                            "type": "number"
+                                        }
                                     },
-                                    "hospital": {
-                                        "description": "the hospital within which the surgical kit is used, and within which it is geofenced",
-                                        "properties": {
-                                            "address": {
+                                    "required": [
+                                        "barcode"
+                                    ],
+                                    "type": "object"
+                                }
+                            },
+                            "type": "object"
+                        },
+                        "eventout": {
+                            "description": "The chaincode event emitted on invoke exit, if any",
+                            "properties": {
+                                "container": {
+                                    "description": "A chaincode event defining the standard platform-generated result event for a contract invoke, contains an array of contributed results",
+                                    "properties": {
+                                        "name": {
+                                            "default": "EVT.IOTCP.INVOKE.RESULT",
+                                            "enum": [
+                                                "EVT.IOTCP.INVOKE.RESULT"
+                                            ],
+                                            "type": "string"
+                                        },
+                                        "payload": {
+                                            "description": "A map of contributed results",
+                                            "properties": {
+                                                "description": "the overall status of the invoke result, defined by err",
                                                 "properties": {
-                                                    "city": {
-                                                        "type": "string"
+                                                    "activeAlerts": {
+                                                        "description": "An array of alert names",
+                                                        "items": {
+                                                            "description": "An alert name",
+                                                            "type": "string"
+                                                        },
+                                                        "type": "array"
                                                     },
-                                                    "country": {
-                                                        "type": "string"
+                                                    "alertsCleared": {
+                                                        "description": "An array of alert names",
+                                                        "items": {
+                                                            "description": "An alert name",
+                                                            "type": "string"
+                                                        },
+                                                        "type": "array"
                                                     },
-                                                    "postcode": {
-                                                        "type": "string"
+                                                    "alertsRaised": {
+                                                        "description": "An array of alert names",
+                                                        "items": {
+                                                            "description": "An alert name",
+                                                            "type": "string"
+                                                        },
+                                                        "type": "array"
                                                     },
-                                                    "streetandnumber": {
-                                                        "type": "string"
-                                                    }
-                                                },
-                                                "type": "object"
-                                            },
-                                            "fence": {
-                                                "properties": {
-                                                    "center": {
-                                                        "description": "A geographical coordinate",
+                                                    "invokeresult": {
+                                                        "description": "status: OK==txn succeeded, ERROR==txn failed",
                                                         "properties": {
-                                                            "latitude": {
-                                                                "type": "number"
+                                                            "message": {
+                                                                "type": "string"
                                                             },
-                                                            "longitude": {
-                                                                "type": "number"
+                                                            "status": {
+                                                                "enum": [
+                                                                    "OK",
+                                                                    "ERROR"
+                                                                ],
+                                                                "type": "string"
                                                             }
                                                         },
                                                         "type": "object"
-                                                    },
-                                                    "radius": {
-                                                        "type": "number"
                                                     }
                                                 },
                                                 "type": "object"
                                             },
-                                            "name": {
-                                                "type": "string"
-                                            }
-                                        },
-                                        "type": "object"
+                                            "type": "object"
+                                        }
                                     },
-                                    "sensors": {
-                                        "description": "sensor readings for the surgical kit",
-                                        "properties": {
-                                            "begin": {
-                                                "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
-                                                "format": "date-time",
-                                                "sample": "yyyy-mm-dd hh:mm:ss",
-                                                "type": "string"
-                                            },
-                                            "currtilt": {
-                                                "description": "The current tilt that the kit is experiencing",
-                                                "type": "number"
-                                            },
-                                            "end": {
-                                                "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
-                                                "format": "date-time",
-                                                "sample": "yyyy-mm-dd hh:mm:ss",
-                                                "type": "string"
-                                            },
-                                            "endlocation": {
-                                                "description": "A geographical coordinate",
-                                                "properties": {
-                                                    "latitude": {
-                                                        "type": "number"
+                                    "type": "object"
+                                }
+                            },
+                            "type": "object"
+                        },
+                        "prefix": {
+                            "description": "The container's asset class prefix in world state",
+                            "type": "string"
+                        },
+                        "state": {
+                            "description": "Properties that have been received or calculated for this container",
+                            "properties": {
+                                "container": {
+                                    "description": "The changeable properties for a container, also considered its 'event' as a partial state",
+                                    "properties": {
+                                        "barcode": {
+                                            "description": "A container's ID",
+                                            "type": "string"
+                                        },
+                                        "carrier": {
+                                            "description": "The carrier in possession of this container",
+                                            "type": "string"
+                                        },
+                                        "common": {
+                                            "description": "Common properties for all assets",
+                                            "properties": {
+                                                "appdata": {
+                                                    "description": "Application managed information as an array of key:value pairs",
+                                                    "items": {
+                                                        "properties": {
+                                                            "K": {
+                                                                "type": "string"
+                                                            },
+                                                            "V": {
+                                                                "type": "string"
+                                                            }
+                                                        },
+                                                        "type": "object"
                                                     },
-                                                    "longitude": {
-                                                        "type": "number"
-                                                    }
+                                                    "minItems": 0,
+                                                    "type": "array"
                                                 },
-                                                "type": "object"
-                                            },
-                                            "maxgforce": {
-                                                "description": "The highest (in Gs) force that the kit experienced during the sample",
-                                                "type": "number"
-                                            },
-                                            "maxtilt": {
-                                                "description": "The highest (in degrees from horizontal) tilt that the kit experienced during the sample",
-                                                "type": "number"
-                                            },
-                                            "startlocation": {
-                                                "description": "A geographical coordinate",
-                                                "properties": {
-                                                    "latitude": {
-                                                        "type": "number"
-                                                    },
-                                                    "longitude": {
-                                                        "type": "number"
-                                                    }
+                                                "deviceID": {
+                                                    "description": "A unique identifier for the device that sent the current event",
+                                                    "type": "string"
                                                 },
-                                                "type": "object"
-                                            }
+                                                "devicetimestamp": {
+                                                    "description": "A timestamp recoded by the device that sent the current event",
+                                                    "type": "string"
+                                                },
+                                                "location": {
+                                                    "description": "A geographical coordinate",
+                                                    "properties": {
+                                                        "latitude": {
+                                                            "type": "number"
+                                                        },
+                                                        "longitude": {
+                                                            "type": "number"
+                                                        }
+                                                    },
+                                                    "type": "object"
+                                                }
+                                            },
+                                            "type": "object"
                                         },
-                                        "type": "object"
-                                    },
-                                    "skitID": {
-                                        "description": "A surgicalkit's ID",
-                                        "type": "string"
-                                    },
-                                    "status": {
-                                        "description": "current kit status as a named entity in possession of the kit",
-                                        "enum": [
-                                            "",
-                                            "oem",
-                                            "warehouse",
-                                            "dealer",
-                                            "retailer",
-                                            "hospital",
-                                            "scrapped"
-                                        ],
-                                        "type": "string"
+                                        "temperature": {
+                                            "description": "Temperature of a container's contents in degrees Celsuis",
+                                            "type": "number"
+                                        }
                                     },
-                                    "transit": {
-                                        "description": "shipping data during transit periods",
-                                        "properties": {
-                                            "begintransit": {
-                                                "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
-                                                "format": "date-time",
-                                                "sample": "yyyy-mm-dd hh:mm:ss",
-                                                "type": "string"
-                                            },
-                                            "carrier": {
-                                                "type": "string"
-                                            },
-                                            "endtransit": {
-                                                "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
-                                                "format": "date-time",
-                                                "sample": "yyyy-mm-dd hh:mm:ss",
-                                                "type": "string"
-                                            },
-                                            "receiver": {
-                                                "description": "current kit status as a named entity in possession of the kit",
-                                                "enum": [
-                                                    "",
-                                                    "oem",
-                                                    "warehouse",
-                                                    "dealer",
-                                                    "retailer",
-                                                    "hospital",
-                                                    "scrapped"
-                                                ],
-                                                "type": "string"
-                                            },
-                                            "shipper": {
-                                                "description": "current kit status as a named entity in possession of the kit",
-                                                "enum": [
-                                                    "",
-                                                    "oem",
-                                                    "warehouse",
-                                                    "dealer",
-                                                    "retailer",
-                                                    "hospital",
-                                                    "scrapped"
-                                                ],
-                                                "type": "string"
-                                            }
-                                        },
-                                        "type": "object"
-                                    }
-                                },
-                                "required": [
-                                    "skitID"
-                                ],
-                                "type": "object"
-                            }
+                                    "required": [
+                                        "barcode"
+                                    ],
+                                    "type": "object"
+                                }
+                            },
+                            "type": "object"
                         },
-                        "type": "object"
-                    },
-                    "maxItems": 1,
-                    "minItems": 1,
-                    "type": "array"
-                },
-                "function": {
-                    "enum": [
-                        "replaceAssetSurgicalKit"
-                    ],
-                    "type": "string"
-                },
-                "method": "invoke"
-            },
-            "type": "object"
-        },
-        "setCreateOnFirstUpdate": {
-            "description": "Allow updateAsset to create an asset upon receipt of its first event",
-            "properties": {
-                "args": {
-                    "items": {
-                        "properties": {
-                            "setCreateOnFirstUpdate": {
-                                "description": "Allows updates to create missing assets on first event",
-                                "type": "boolean"
-                            }
+                        "txnid": {
+                            "description": "Transaction UUID matching the blockchain",
+                            "type": "string"
                         },
-                        "type": "object"
+                        "txnts": {
+                            "description": "Transaction timestamp matching the blockchain",
+                            "type": "string"
+                        }
                     },
-                    "maxItems": 1,
-                    "minItems": 1,
-                    "type": "array"
-                },
-                "function": {
-                    "enum": [
-                        "setCreateOnFirstUpdate"
-                    ],
-                    "type": "string"
-                },
-                "method": "invoke"
+                    "type": "object"
+                }
             },
             "type": "object"
         },
-        "setLoggingLevel": {
-            "description": "Sets the logging level for the contract",
+        "readAssetAsOfSurgicalKit": {
+            "description": "Returns a surgicalkit as it was at a point in time, rebuilt from its history",
             "properties": {
                 "args": {
                     "items": {
                         "properties": {
-                            "logLevel": {
-                                "enum": [
-                                    "CRITICAL",
-                                    "ERROR",
-                                    "WARNING",
-                                    "NOTICE",
-                                    "INFO",
-                                    "DEBUG"
-                                ],
+                            "asof": {
+                                "description": "the point in time, the latest state written at or before it is returned",
+                                "format": "date-time",
+                                "sample": "yyyy-mm-ddThh:mm:ssZ",
                                 "type": "string"
+                            },
+                            "surgicalkit": {
+                                "properties": {
+                                    "skitID": {
+                                        "description": "A surgicalkit's ID",
+                                        "type": "string"
+                                    }
+                                },
+                                "type": "object"
                             }
                         },
+                        "required": [
+                            "surgicalkit",
+                            "asof"
+                        ],
                         "type": "object"
                     },
                     "maxItems": 1,
@@ -1855,382 +2271,5632 @@ var schemas = `
                 },
                 "function": {
                     "enum": [
-                        "setLoggingLevel"
+                        "readAssetAsOfSurgicalKit"
                     ],
                     "type": "string"
                 },
-                "method": "invoke"
-            },
-            "type": "object"
-        },
-        "updateAssetSurgicalKit": {
-            "description": "Update a contaner's state with one or more property changes (e.g. patch existing)",
-            "properties": {
-                "args": {
-                    "items": {
-                        "properties": {
-                            "surgicalkit": {
-                                "description": "The changeable properties for a surgicalkit, also considered its 'event' as a partial state",
-                                "properties": {
-                                    "common": {
-                                        "description": "Common properties for all assets",
-                                        "properties": {
-                                            "appdata": {
-                                                "description": "Application managed information as an array of key:value pairs",
-                                                "items": {
+                "method": "query",
+                "result": {
+                    "description": "A surgicalkit's complete state",
+                    "properties": {
+                        "AssetKey": {
+                            "description": "This surgicalkit's world state surgicalkit ID",
+                            "type": "string"
+                        },
+                        "alerts": {
+                            "description": "An array of alert names",
+                            "items": {
+                                "description": "An alert name",
+                                "type": "string"
+                            },
+                            "type": "array"
+                        },
+                        "class": {
+                            "description": "An asset's classifier definition",
+                            "properties": {
+                                "assetidpath": "An asset's primary key, expressed as a qualified property path (see example contracts)",
+                                "name": "An asset's class name",
+                                "prefix": "An asset's world state prefix, used to allow iteration over all assets of a class"
+                            },
+                            "type": "object"
+                        },
+                        "compliant": {
+                            "description": "This surgicalkit has no active alerts",
+                            "type": "boolean"
+                        },
+                        "eventin": {
+                            "description": "The contract event that created this state, for example updateAssetSurgicalKit",
+                            "properties": {
+                                "surgicalkit": {
+                                    "description": "The changeable properties for a surgicalkit, also considered its 'event' as a partial state",
+                                    "properties": {
+                                        "common": {
+                                            "description": "Common properties for all assets",
+                                            "properties": {
+                                                "appdata": {
+                                                    "description": "Application managed information as an array of key:value pairs",
+                                                    "items": {
+                                                        "properties": {
+                                                            "K": {
+                                                                "type": "string"
+                                                            },
+                                                            "V": {
+                                                                "type": "string"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    },
+                                                    "minItems": 0,
+                                                    "type": "array"
+                                                },
+                                                "deviceID": {
+                                                    "description": "A unique identifier for the device that sent the current event",
+                                                    "type": "string"
+                                                },
+                                                "devicetimestamp": {
+                                                    "description": "A timestamp recoded by the device that sent the current event",
+                                                    "type": "string"
+                                                },
+                                                "location": {
+                                                    "description": "A geographical coordinate",
                                                     "properties": {
-                                                        "K": {
-                                                            "type": "string"
+                                                        "latitude": {
+                                                            "type": "number"
                                                         },
-                                                        "V": {
-                                                            "type": "string"
+                                                        "longitude": {
+                                                            "type": "number"
                                                         }
                                                     },
                                                     "type": "object"
-                                                },
-                                                "minItems": 0,
-                                                "type": "array"
-                                            },
-                                            "deviceID": {
-                                                "description": "A unique identifier for the device that sent the current event",
-                                                "type": "string"
-                                            },
-                                            "devicetimestamp": {
-                                                "description": "A timestamp recoded by the device that sent the current event",
-                                                "type": "string"
+                                                }
                                             },
-                                            "location": {
-                                                "description": "A geographical coordinate",
-                                                "properties": {
-                                                    "latitude": {
-                                                        "type": "number"
+                                            "type": "object"
+                                        },
+                                        "hospital": {
+                                            "description": "the hospital within which the surgical kit is used, and within which it is geofenced",
+                                            "properties": {
+                                                "address": {
+                                                    "properties": {
+                                                        "city": {
+                                                            "type": "string"
+                                                        },
+                                                        "country": {
+                                                            "type": "string"
+                                                        },
+                                                        "postcode": {
+                                                            "type": "string"
+                                                        },
+                                                        "streetandnumber": {
+                                                            "type": "string"
+                                                        }
                                                     },
-                                                    "longitude": {
-                                                        "type": "number"
-                                                    }
+                                                    "type": "object"
                                                 },
-                                                "type": "object"
-                                            }
-                                        },
-                                        "type": "object"
-                                    },
-                                    "hospital": {
-                                        "description": "the hospital within which the surgical kit is used, and within which it is geofenced",
-                                        "properties": {
-                                            "address": {
-                                                "properties": {
-                                                    "city": {
-                                                        "type": "string"
+                                                "fence": {
+                                                    "properties": {
+                                                        "center": {
+                                                            "description": "A geographical coordinate",
+                                                            "properties": {
+                                                                "latitude": {
+                                                                    "type": "number"
+                                                                },
+                                                                "longitude": {
+                                                                    "type": "number"
+                                                                }
+                                                            },
+                                                            "type": "object"
+                                                        },
+                                                        "radius": {
+                                                            "type": "number"
+                                                        }
                                                     },
-                                                    "country": {
-                                                        "type": "string"
+                                                    "type": "object"
+                                                },
+                                                "name": {
+                                                    "type": "string"
+                                                }
+                                            },
+                                            "type": "object"
+                                        },
+                                        "sensors": {
+                                            "description": "sensor readings for the surgical kit",
+                                            "properties": {
+                                                "begin": {
+                                                    "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                    "format": "date-time",
+                                                    "sample": "yyyy-mm-dd hh:mm:ss",
+                                                    "type": "string"
+                                                },
+                                                "currtilt": {
+                                                    "description": "The current tilt that the kit is experiencing",
+                                                    "type": "number"
+                                                },
+                                                "end": {
+                                                    "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                    "format": "date-time",
+                                                    "sample": "yyyy-mm-dd hh:mm:ss",
+                                                    "type": "string"
+                                                },
+                                                "endlocation": {
+                                                    "description": "A geographical coordinate",
+                                                    "properties": {
+                                                        "latitude": {
+                                                            "type": "number"
+                                                        },
+                                                        "longitude": {
+                                                            "type": "number"
+                                                        }
                                                     },
-                                                    "postcode": {
-                                                        "type": "string"
+                                                    "type": "object"
+                                                },
+                                                "maxgforce": {
+                                                    "description": "The highest (in Gs) force that the kit experienced during the sample",
+                                                    "type": "number"
+                                                },
+                                                "maxtilt": {
+                                                    "description": "The highest (in degrees from horizontal) tilt that the kit experienced during the sample",
+                                                    "type": "number"
+                                                },
+                                                "startlocation": {
+                                                    "description": "A geographical coordinate",
+                                                    "properties": {
+                                                        "latitude": {
+                                                            "type": "number"
+                                                        },
+                                                        "longitude": {
+                                                            "type": "number"
+                                                        }
                                                     },
-                                                    "streetandnumber": {
-                                                        "type": "string"
-                                                    }
+                                                    "type": "object"
+                                                }
+                                            },
+                                            "type": "object"
+                                        },
+                                        "skitID": {
+                                            "description": "A surgicalkit's ID",
+                                            "type": "string"
+                                        },
+                                        "status": {
+                                            "description": "current kit status as a named entity in possession of the kit",
+                                            "enum": [
+                                                "",
+                                                "oem",
+                                                "warehouse",
+                                                "dealer",
+                                                "retailer",
+                                                "hospital",
+                                                "scrapped"
+                                            ],
+                                            "type": "string"
+                                        },
+                                        "transit": {
+                                            "description": "shipping data during transit periods",
+                                            "properties": {
+                                                "begintransit": {
+                                                    "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                    "format": "date-time",
+                                                    "sample": "yyyy-mm-dd hh:mm:ss",
+                                                    "type": "string"
                                                 },
-                                                "type": "object"
+                                                "carrier": {
+                                                    "type": "string"
+                                                },
+                                                "endtransit": {
+                                                    "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                    "format": "date-time",
+                                                    "sample": "yyyy-mm-dd hh:mm:ss",
+                                                    "type": "string"
+                                                },
+                                                "receiver": {
+                                                    "description": "current kit status as a named entity in possession of the kit",
+                                                    "enum": [
+                                                        "",
+                                                        "oem",
+                                                        "warehouse",
+                                                        "dealer",
+                                                        "retailer",
+                                                        "hospital",
+                                                        "scrapped"
+                                                    ],
+                                                    "type": "string"
+                                                },
+                                                "shipper": {
+                                                    "description": "current kit status as a named entity in possession of the kit",
+                                                    "enum": [
+                                                        "",
+                                                        "oem",
+                                                        "warehouse",
+                                                        "dealer",
+                                                        "retailer",
+                                                        "hospital",
+                                                        "scrapped"
+                                                    ],
+                                                    "type": "string"
+                                                }
                                             },
-                                            "fence": {
+                                            "type": "object"
+                                        }
+                                    },
+                                    "required": [
+                                        "skitID"
+                                    ],
+                                    "type": "object"
+                                }
+                            },
+                            "type": "object"
+                        },
+                        "eventout": {
+                            "description": "The chaincode event emitted on invoke exit, if any",
+                            "properties": {
+                                "surgicalkit": {
+                                    "description": "A chaincode event defining the standard platform-generated result event for a contract invoke, contains an array of contributed results",
+                                    "properties": {
+                                        "name": {
+                                            "default": "EVT.IOTCP.INVOKE.RESULT",
+                                            "enum": [
+                                                "EVT.IOTCP.INVOKE.RESULT"
+                                            ],
+                                            "type": "string"
+                                        },
+                                        "payload": {
+                                            "description": "A map of contributed results",
+                                            "properties": {
+                                                "description": "the overall status of the invoke result, defined by err",
                                                 "properties": {
-                                                    "center": {
-                                                        "description": "A geographical coordinate",
+                                                    "activeAlerts": {
+                                                        "description": "An array of alert names",
+                                                        "items": {
+                                                            "description": "An alert name",
+                                                            "type": "string"
+                                                        },
+                                                        "type": "array"
+                                                    },
+                                                    "alertsCleared": {
+                                                        "description": "An array of alert names",
+                                                        "items": {
+                                                            "description": "An alert name",
+                                                            "type": "string"
+                                                        },
+                                                        "type": "array"
+                                                    },
+                                                    "alertsRaised": {
+                                                        "description": "An array of alert names",
+                                                        "items": {
+                                                            "description": "An alert name",
+                                                            "type": "string"
+                                                        },
+                                                        "type": "array"
+                                                    },
+                                                    "invokeresult": {
+                                                        "description": "status: OK==txn succeeded, ERROR==txn failed",
                                                         "properties": {
-                                                            "latitude": {
-                                                                "type": "number"
+                                                            "message": {
+                                                                "type": "string"
                                                             },
-                                                            "longitude": {
-                                                                "type": "number"
+                                                            "status": {
+                                                                "enum": [
+                                                                    "OK",
+                                                                    "ERROR"
+                                                                ],
+                                                                "type": "string"
                                                             }
                                                         },
                                                         "type": "object"
-                                                    },
-                                                    "radius": {
-                                                        "type": "number"
                                                     }
                                                 },
                                                 "type": "object"
                                             },
-                                            "name": {
-                                                "type": "string"
-                                            }
-                                        },
-                                        "type": "object"
+                                            "type": "object"
+                                        }
                                     },
-                                    "sensors": {
-                                        "description": "sensor readings for the surgical kit",
-                                        "properties": {
-                                            "begin": {
-                                                "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
-                                                "format": "date-time",
-                                                "sample": "yyyy-mm-dd hh:mm:ss",
-                                                "type": "string"
-                                            },
-                                            "currtilt": {
-                                                "description": "The current tilt that the kit is experiencing",
-                                                "type": "number"
-                                            },
-                                            "end": {
-                                                "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
-                                                "format": "date-time",
-                                                "sample": "yyyy-mm-dd hh:mm:ss",
-                                                "type": "string"
-                                            },
+                                    "type": "object"
+                                }
+                            },
+                            "type": "object"
+                        },
+                        "state": {
+                            "description": "Properties that have been received or calculated for this surgicalkit",
+                            "properties": {
+                                "distanceFromCenter": {
+                                    "description": "calculated distance from the fence center, can be compared to fence radius",
+                                    "type": "number"
+                                },
+                                "surgicalkit": {
+                                    "description": "The changeable properties for a surgicalkit, also considered its 'event' as a partial state",
+                                    "properties": {
+                                        "common": {
+                                            "description": "Common properties for all assets",
+                                            "properties": {
+                                                "appdata": {
+                                                    "description": "Application managed information as an array of key:value pairs",
+                                                    "items": {
+                                                        "properties": {
+                                                            "K": {
+                                                                "type": "string"
+                                                            },
+                                                            "V": {
+                                                                "type": "string"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    },
+                                                    "minItems": 0,
+                                                    "type": "array"
+                                                },
+                                                "deviceID": {
+                                                    "description": "A unique identifier for the device that sent the current event",
+                                                    "type": "string"
+                                                },
+                                                "devicetimestamp": {
+                                                    "description": "A timestamp recoded by the device that sent the current event",
+                                                    "type": "string"
+                                                },
+                                                "location": {
+                                                    "description": "A geographical coordinate",
+                                                    "properties": {
+                                                        "latitude": {
+                                                            "type": "number"
+                                                        },
+                                                        "longitude": {
+                                                            "type": "number"
+                                                        }
+                                                    },
+                                                    "type": "object"
+                                                }
+                                            },
+                                            "type": "object"
+                                        },
+                                        "hospital": {
+                                            "description": "the hospital within which the surgical kit is used, and within which it is geofenced",
+                                            "properties": {
+                                                "address": {
+                                                    "properties": {
+                                                        "city": {
+                                                            "type": "string"
+                                                        },
+                                                        "country": {
+                                                            "type": "string"
+                                                        },
+                                                        "postcode": {
+                                                            "type": "string"
+                                                        },
+                                                        "streetandnumber": {
+                                                            "type": "string"
+                                                        }
+                                                    },
+                                                    "type": "object"
+                                                },
+                                                "fence": {
+                                                    "properties": {
+                                                        "center": {
+                                                            "description": "A geographical coordinate",
+                                                            "properties": {
+                                                                "latitude": {
+                                                                    "type": "number"
+                                                                },
+                                                                "longitude": {
+                                                                    "type": "number"
+                                                                }
+                                                            },
+                                                            "type": "object"
+                                                        },
+                                                        "radius": {
+                                                            "type": "number"
+                                                        }
+                                                    },
+                                                    "type": "object"
+                                                },
+                                                "name": {
+                                                    "type": "string"
+                                                }
+                                            },
+                                            "type": "object"
+                                        },
+                                        "sensors": {
+                                            "description": "sensor readings for the surgical kit",
+                                            "properties": {
+                                                "begin": {
+                                                    "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                    "format": "date-time",
+                                                    "sample": "yyyy-mm-dd hh:mm:ss",
+                                                    "type": "string"
+                                                },
+                                                "currtilt": {
+                                                    "description": "The current tilt that the kit is experiencing",
+                                                    "type": "number"
+                                                },
+                                                "end": {
+                                                    "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                    "format": "date-time",
+                                                    "sample": "yyyy-mm-dd hh:mm:ss",
+                                                    "type": "string"
+                                                },
+                                                "endlocation": {
+                                                    "description": "A geographical coordinate",
+                                                    "properties": {
+                                                        "latitude": {
+                                                            "type": "number"
+                                                        },
+                                                        "longitude": {
+                                                            "type": "number"
+                                                        }
+                                                    },
+                                                    "type": "object"
+                                                },
+                                                "maxgforce": {
+                                                    "description": "The highest (in Gs) force that the kit experienced during the sample",
+                                                    "type": "number"
+                                                },
+                                                "maxtilt": {
+                                                    "description": "The highest (in degrees from horizontal) tilt that the kit experienced during the sample",
+                                                    "type": "number"
+                                                },
+                                                "startlocation": {
+                                                    "description": "A geographical coordinate",
+                                                    "properties": {
+                                                        "latitude": {
+                                                            "type": "number"
+                                                        },
+                                                        "longitude": {
+                                                            "type": "number"
+                                                        }
+                                                    },
+                                                    "type": "object"
+                                                }
+                                            },
+                                            "type": "object"
+                                        },
+                                        "skitID": {
+                                            "description": "A surgicalkit's ID",
+                                            "type": "string"
+                                        },
+                                        "status": {
+                                            "description": "current kit status as a named entity in possession of the kit",
+                                            "enum": [
+                                                "",
+                                                "oem",
+                                                "warehouse",
+                                                "dealer",
+                                                "retailer",
+                                                "hospital",
+                                                "scrapped"
+                                            ],
+                                            "type": "string"
+                                        },
+                                        "transit": {
+                                            "description": "shipping data during transit periods",
+                                            "properties": {
+                                                "begintransit": {
+                                                    "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                    "format": "date-time",
+                                                    "sample": "yyyy-mm-dd hh:mm:ss",
+                                                    "type": "string"
+                                                },
+                                                "carrier": {
+                                                    "type": "string"
+                                                },
+                                                "endtransit": {
+                                                    "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                    "format": "date-time",
+                                                    "sample": "yyyy-mm-dd hh:mm:ss",
+                                                    "type": "string"
+                                                },
+                                                "receiver": {
+                                                    "description": "current kit status as a named entity in possession of the kit",
+                                                    "enum": [
+                                                        "",
+                                                        "oem",
+                                                        "warehouse",
+                                                        "dealer",
+                                                        "retailer",
+                                                        "hospital",
+                                                        "scrapped"
+                                                    ],
+                                                    "type": "string"
+                                                },
+                                                "shipper": {
+                                                    "description": "current kit status as a named entity in possession of the kit",
+                                                    "enum": [
+                                                        "",
+                                                        "oem",
+                                                        "warehouse",
+                                                        "dealer",
+                                                        "retailer",
+                                                        "hospital",
+                                                        "scrapped"
+                                                    ],
+                                                    "type": "string"
+                                                }
+                                            },
+                                            "type": "object"
+                                        }
+                                    },
+                                    "required": [
+                                        "skitID"
+                                    ],
+                                    "type": "object"
+                                }
+                            },
+                            "type": "object"
+                        },
+                        "txnid": {
+                            "description": "Transaction UUID matching the blockchain",
+                            "type": "string"
+                        },
+                        "txnts": {
+                            "description": "Transaction timestamp matching the blockchain",
+                            "type": "string"
+                        }
+                    },
+                    "type": "object"
+                }
+            },
+            "type": "object"
+        },
+        "readAssetChildren": {
+            "description": "Returns the edges to an asset's children in the relation or in all relations",
+            "properties": {
+                "args": {
+                    "items": {
+                        "properties": {
+                            "asset": {
+                                "description": "Identifies an asset of any class",
+                                "properties": {
+                                    "assetID": {
+                                        "description": "An asset's unique ID, e.g. barcode, VIN, etc.",
+                                        "type": "string"
+                                    },
+                                    "assetkey": {
+                                        "description": "The asset's world state key, returned by queries",
+                                        "type": "string"
+                                    },
+                                    "class": {
+                                        "description": "The asset class name",
+                                        "type": "string"
+                                    }
+                                },
+                                "required": [
+                                    "class",
+                                    "assetID"
+                                ],
+                                "type": "object"
+                            },
+                            "relation": {
+                                "description": "A relation between a parent and a child asset, contracts can register more",
+                                "enum": [
+                                    "contains",
+                                    "installed-on",
+                                    "custodian-of"
+                                ],
+                                "type": "string"
+                            }
+                        },
+                        "required": [
+                            "asset"
+                        ],
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
+                    "type": "array"
+                },
+                "function": {
+                    "enum": [
+                        "readAssetChildren"
+                    ],
+                    "type": "string"
+                },
+                "method": "query",
+                "result": {
+                    "description": "An array of edges",
+                    "items": {
+                        "description": "A link from a parent asset to a child asset",
+                        "properties": {
+                            "child": {
+                                "description": "Identifies an asset of any class",
+                                "properties": {
+                                    "assetID": {
+                                        "description": "An asset's unique ID, e.g. barcode, VIN, etc.",
+                                        "type": "string"
+                                    },
+                                    "assetkey": {
+                                        "description": "The asset's world state key, returned by queries",
+                                        "type": "string"
+                                    },
+                                    "class": {
+                                        "description": "The asset class name",
+                                        "type": "string"
+                                    }
+                                },
+                                "required": [
+                                    "class",
+                                    "assetID"
+                                ],
+                                "type": "object"
+                            },
+                            "depth": {
+                                "description": "Ancestry only, 1 for the asset's own parents",
+                                "type": "integer"
+                            },
+                            "parent": {
+                                "description": "Identifies an asset of any class",
+                                "properties": {
+                                    "assetID": {
+                                        "description": "An asset's unique ID, e.g. barcode, VIN, etc.",
+                                        "type": "string"
+                                    },
+                                    "assetkey": {
+                                        "description": "The asset's world state key, returned by queries",
+                                        "type": "string"
+                                    },
+                                    "class": {
+                                        "description": "The asset class name",
+                                        "type": "string"
+                                    }
+                                },
+                                "required": [
+                                    "class",
+                                    "assetID"
+                                ],
+                                "type": "object"
+                            },
+                            "relation": {
+                                "description": "A relation between a parent and a child asset, contracts can register more",
+                                "enum": [
+                                    "contains",
+                                    "installed-on",
+                                    "custodian-of"
+                                ],
+                                "type": "string"
+                            },
+                            "txnid": {
+                                "type": "string"
+                            },
+                            "txnts": {
+                                "format": "date-time",
+                                "type": "string"
+                            }
+                        },
+                        "type": "object"
+                    },
+                    "minItems": 0,
+                    "type": "array"
+                }
+            },
+            "type": "object"
+        },
+        "readAssetContainer": {
+            "description": "Returns the state a container",
+            "properties": {
+                "args": {
+                    "items": {
+                        "properties": {
+                            "container": {
+                                "properties": {
+                                    "barcode": {
+                                        "description": "A container's ID",
+                                        "type": "string"
+                                    }
+                                },
+                                "type": "object"
+                            }
+                        },
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
+                    "type": "array"
+                },
+                "function": {
+                    "enum": [
+                        "readAssetContainer"
+                    ],
+                    "type": "string"
+                },
+                "method": "query",
+                "result": {
+                    "description": "A container's complete state",
+                    "properties": {
+                        "AssetKey": {
+                            "description": "This container's world state container ID",
+                            "type": "string"
+                        },
+                        "alerts": {
+                            "description": "An array of alert names",
+                            "items": {
+                                "description": "An alert name",
+                                "type": "string"
+                            },
+                            "type": "array"
+                        },
+                        "assetIDpath": {
+                            "description": "Qualified property path to the container's ID, declared in the contract code",
+                            "type": "string"
+                        },
+                        "class": {
+                            "description": "The container's asset class",
+                            "type": "string"
+                        },
+                        "compliant": {
+                            "description": "This container has no active alerts",
+                            "type": "boolean"
+                        },
+                        "eventin": {
+                            "description": "The contract event that created this state, for example updateAssetContainer",
+                            "properties": {
+                                "container": {
+                                    "description": "The changeable properties for a container, also considered its 'event' as a partial state",
+                                    "properties": {
+                                        "barcode": {
+                                            "description": "A container's ID",
+                                            "type": "string"
+                                        },
+                                        "carrier": {
+                                            "description": "The carrier in possession of this container",
+                                            "type": "string"
+                                        },
+                                        "common": {
+                                            "description": "Common properties for all assets",
+                                            "properties": {
+                                                "appdata": {
+                                                    "description": "Application managed information as an array of key:value pairs",
+                                                    "items": {
+                                                        "properties": {
+                                                            "K": {
+                                                                "type": "string"
+                                                            },
+                                                            "V": {
+                                                                "type": "string"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    },
+                                                    "minItems": 0,
+                                                    "type": "array"
+                                                },
+                                                "deviceID": {
+                                                    "description": "A unique identifier for the device that sent the current event",
+                                                    "type": "string"
+                                                },
+                                                "devicetimestamp": {
+                                                    "description": "A timestamp recoded by the device that sent the current event",
+                                                    "type": "string"
+                                                },
+                                                "location": {
+                                                    "description": "A geographical coordinate",
+                                                    "properties": {
+                                                        "latitude": {
+                                                            "type": "number"
+                                                        },
+                                                        "longitude": {
+                                                            "type": "number"
+                                                        }
+                                                    },
+                                                    "type": "object"
+                                                }
+                                            },
+                                            "type": "object"
+                                        },
+                                        "temperature": {
+                                            "description": "Temperature of a container's contents in degrees Celsuis",
+                                            "type": "number"
+                                        }
+                                    },
+                                    "required": [
+                                        "barcode"
+                                    ],
+                                    "type": "object"
+                                }
+                            },
+                            "type": "object"
+                        },
+                        "eventout": {
+                            "description": "The chaincode event emitted on invoke exit, if any",
+                            "properties": {
+                                "container": {
+                                    "description": "A chaincode event defining the standard platform-generated result event for a contract invoke, contains an array of contributed results",
+                                    "properties": {
+                                        "name": {
+                                            "default": "EVT.IOTCP.INVOKE.RESULT",
+                                            "enum": [
+                                                "EVT.IOTCP.INVOKE.RESULT"
+                                            ],
+                                            "type": "string"
+                                        },
+                                        "payload": {
+                                            "description": "A map of contributed results",
+                                            "properties": {
+                                                "description": "the overall status of the invoke result, defined by err",
+                                                "properties": {
+                                                    "activeAlerts": {
+                                                        "description": "An array of alert names",
+                                                        "items": {
+                                                            "description": "An alert name",
+                                                            "type": "string"
+                                                        },
+                                                        "type": "array"
+                                                    },
+                                                    "alertsCleared": {
+                                                        "description": "An array of alert names",
+                                                        "items": {
+                                                            "description": "An alert name",
+                                                            "type": "string"
+                                                        },
+                                                        "type": "array"
+                                                    },
+                                                    "alertsRaised": {
+                                                        "description": "An array of alert names",
+                                                        "items": {
+                                                            "description": "An alert name",
+                                                            "type": "string"
+                                                        },
+                                                        "type": "array"
+                                                    },
+                                                    "invokeresult": {
+                                                        "description": "status: OK==txn succeeded, ERROR==txn failed",
+                                                        "properties": {
+                                                            "message": {
+                                                                "type": "string"
+                                                            },
+                                                            "status": {
+                                                                "enum": [
+                                                                    "OK",
+                                                                    "ERROR"
+                                                                ],
+                                                                "type": "string"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    }
+                                                },
+                                                "type": "object"
+                                            },
+                                            "type": "object"
+                                        }
+                                    },
+                                    "type": "object"
+                                }
+                            },
+                            "type": "object"
+                        },
+                        "prefix": {
+                            "description": "The container's asset class prefix in world state",
+                            "type": "string"
+                        },
+                        "state": {
+                            "description": "Properties that have been received or calculated for this container",
+                            "properties": {
+                                "container": {
+                                    "description": "The changeable properties for a container, also considered its 'event' as a partial state",
+                                    "properties": {
+                                        "barcode": {
+                                            "description": "A container's ID",
+                                            "type": "string"
+                                        },
+                                        "carrier": {
+                                            "description": "The carrier in possession of this container",
+                                            "type": "string"
+                                        },
+                                        "common": {
+                                            "description": "Common properties for all assets",
+                                            "properties": {
+                                                "appdata": {
+                                                    "description": "Application managed information as an array of key:value pairs",
+                                                    "items": {
+                                                        "properties": {
+                                                            "K": {
+                                                                "type": "string"
+                                                            },
+                                                            "V": {
+                                                                "type": "string"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    },
+                                                    "minItems": 0,
+                                                    "type": "array"
+                                                },
+                                                "deviceID": {
+                                                    "description": "A unique identifier for the device that sent the current event",
+                                                    "type": "string"
+                                                },
+                                                "devicetimestamp": {
+                                                    "description": "A timestamp recoded by the device that sent the current event",
+                                                    "type": "string"
+                                                },
+                                                "location": {
+                                                    "description": "A geographical coordinate",
+                                                    "properties": {
+                                                        "latitude": {
+                                                            "type": "number"
+                                                        },
+                                                        "longitude": {
+                                                            "type": "number"
+                                                        }
+                                                    },
+                                                    "type": "object"
+                                                }
+                                            },
+                                            "type": "object"
+                                        },
+                                        "temperature": {
+                                            "description": "Temperature of a container's contents in degrees Celsuis",
+                                            "type": "number"
+                                        }
+                                    },
+                                    "required": [
+                                        "barcode"
+                                    ],
+                                    "type": "object"
+                                }
+                            },
+                            "type": "object"
+                        },
+                        "txnid": {
+                            "description": "Transaction UUID matching the blockchain",
+                            "type": "string"
+                        },
+                        "txnts": {
+                            "description": "Transaction timestamp matching the blockchain",
+                            "type": "string"
+                        }
+                    },
+                    "type": "object"
+                }
+            },
+            "type": "object"
+        },
+        "readAssetParents": {
+            "description": "Returns the edges to an asset's parents, at most one per relation, in the relation or in all relations",
+            "properties": {
+                "args": {
+                    "items": {
+                        "properties": {
+                            "asset": {
+                                "description": "Identifies an asset of any class",
+                                "properties": {
+                                    "assetID": {
+                                        "description": "An asset's unique ID, e.g. barcode, VIN, etc.",
+                                        "type": "string"
+                                    },
+                                    "assetkey": {
+                                        "description": "The asset's world state key, returned by queries",
+                                        "type": "string"
+                                    },
+                                    "class": {
+                                        "description": "The asset class name",
+                                        "type": "string"
+                                    }
+                                },
+                                "required": [
+                                    "class",
+                                    "assetID"
+                                ],
+                                "type": "object"
+                            },
+                            "relation": {
+                                "description": "A relation between a parent and a child asset, contracts can register more",
+                                "enum": [
+                                    "contains",
+                                    "installed-on",
+                                    "custodian-of"
+                                ],
+                                "type": "string"
+                            }
+                        },
+                        "required": [
+                            "asset"
+                        ],
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
+                    "type": "array"
+                },
+                "function": {
+                    "enum": [
+                        "readAssetParents"
+                    ],
+                    "type": "string"
+                },
+                "method": "query",
+                "result": {
+                    "description": "An array of edges",
+                    "items": {
+                        "description": "A link from a parent asset to a child asset",
+                        "properties": {
+                            "child": {
+                                "description": "Identifies an asset of any class",
+                                "properties": {
+                                    "assetID": {
+                                        "description": "An asset's unique ID, e.g. barcode, VIN, etc.",
+                                        "type": "string"
+                                    },
+                                    "assetkey": {
+                                        "description": "The asset's world state key, returned by queries",
+                                        "type": "string"
+                                    },
+                                    "class": {
+                                        "description": "The asset class name",
+                                        "type": "string"
+                                    }
+                                },
+                                "required": [
+                                    "class",
+                                    "assetID"
+                                ],
+                                "type": "object"
+                            },
+                            "depth": {
+                                "description": "Ancestry only, 1 for the asset's own parents",
+                                "type": "integer"
+                            },
+                            "parent": {
+                                "description": "Identifies an asset of any class",
+                                "properties": {
+                                    "assetID": {
+                                        "description": "An asset's unique ID, e.g. barcode, VIN, etc.",
+                                        "type": "string"
+                                    },
+                                    "assetkey": {
+                                        "description": "The asset's world state key, returned by queries",
+                                        "type": "string"
+                                    },
+                                    "class": {
+                                        "description": "The asset class name",
+                                        "type": "string"
+                                    }
+                                },
+                                "required": [
+                                    "class",
+                                    "assetID"
+                                ],
+                                "type": "object"
+                            },
+                            "relation": {
+                                "description": "A relation between a parent and a child asset, contracts can register more",
+                                "enum": [
+                                    "contains",
+                                    "installed-on",
+                                    "custodian-of"
+                                ],
+                                "type": "string"
+                            },
+                            "txnid": {
+                                "type": "string"
+                            },
+                            "txnts": {
+                                "format": "date-time",
+                                "type": "string"
+                            }
+                        },
+                        "type": "object"
+                    },
+                    "minItems": 0,
+                    "type": "array"
+                }
+            },
+            "type": "object"
+        },
+        "readAssetSamples": {
+            "description": "Returns samples of selected contract objects",
+            "properties": {
+                "args": {
+                    "items": {},
+                    "maxItems": 0,
+                    "minItems": 0,
+                    "type": "array"
+                },
+                "function": {
+                    "enum": [
+                        "readAssetSamples"
+                    ],
+                    "type": "string"
+                },
+                "method": "query",
+                "result": {
+                    "properties": {},
+                    "type": "object"
+                }
+            },
+            "type": "object"
+        },
+        "readAssetSchemas": {
+            "description": "Returns the API for this contract for the use of self-configuring applications; is MANDATORY for integration with the Watson IoT Platform",
+            "properties": {
+                "args": {
+                    "items": {},
+                    "maxItems": 0,
+                    "minItems": 0,
+                    "type": "array"
+                },
+                "function": {
+                    "enum": [
+                        "readAssetSchemas"
+                    ],
+                    "type": "string"
+                },
+                "method": "query",
+                "result": {
+                    "properties": {},
+                    "type": "object"
+                }
+            },
+            "type": "object"
+        },
+        "readAssetStateAggregatesContainer": {
+            "description": "Returns min, max, mean, count, first and last of a numeric container property per time bucket, with the percentage of time spent in each requested alert",
+            "properties": {
+                "args": {
+                    "items": {
+                        "properties": {
+                            "alerts": {
+                                "description": "alert names for which to return the percentage of time active",
+                                "items": {
+                                    "type": "string"
+                                },
+                                "type": "array"
+                            },
+                            "bucket": {
+                                "description": "size of the time buckets, aligned to UTC",
+                                "enum": [
+                                    "minute",
+                                    "hour",
+                                    "day"
+                                ],
+                                "type": "string"
+                            },
+                            "container": {
+                                "properties": {
+                                    "barcode": {
+                                        "description": "A container's ID",
+                                        "type": "string"
+                                    }
+                                },
+                                "type": "object"
+                            },
+                            "daterange": {
+                                "description": "if specified, dates must fall in between these values, inclusive",
+                                "properties": {
+                                    "begin": {
+                                        "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                        "format": "date-time",
+                                        "sample": "yyyy-mm-dd hh:mm:ss",
+                                        "type": "string"
+                                    },
+                                    "end": {
+                                        "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                        "format": "date-time",
+                                        "sample": "yyyy-mm-dd hh:mm:ss",
+                                        "type": "string"
+                                    }
+                                },
+                                "type": "object"
+                            },
+                            "qprop": {
+                                "description": "qualified property name of a numeric property in the asset state",
+                                "sample": "asset.temperature",
+                                "type": "string"
+                            }
+                        },
+                        "required": [
+                            "container",
+                            "bucket"
+                        ],
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
+                    "type": "array"
+                },
+                "function": {
+                    "enum": [
+                        "readAssetStateAggregatesContainer"
+                    ],
+                    "type": "string"
+                },
+                "method": "query",
+                "result": {
+                    "properties": {
+                        "assetkey": {
+                            "type": "string"
+                        },
+                        "bucket": {
+                            "type": "string"
+                        },
+                        "buckets": {
+                            "description": "buckets that hold readings or covered time, oldest first",
+                            "items": {
+                                "properties": {
+                                    "alertPercent": {
+                                        "description": "percentage of the bucket's covered time for which each alert was active",
+                                        "type": "object"
+                                    },
+                                    "begin": {
+                                        "format": "date-time",
+                                        "type": "string"
+                                    },
+                                    "count": {
+                                        "type": "integer"
+                                    },
+                                    "end": {
+                                        "format": "date-time",
+                                        "type": "string"
+                                    },
+                                    "first": {
+                                        "type": "number"
+                                    },
+                                    "last": {
+                                        "type": "number"
+                                    },
+                                    "max": {
+                                        "type": "number"
+                                    },
+                                    "mean": {
+                                        "type": "number"
+                                    },
+                                    "min": {
+                                        "type": "number"
+                                    }
+                                },
+                                "type": "object"
+                            },
+                            "type": "array"
+                        },
+                        "qprop": {
+                            "type": "string"
+                        }
+                    },
+                    "type": "object"
+                }
+            },
+            "type": "object"
+        },
+        "readAssetStateAggregatesSurgicalKit": {
+            "description": "Returns min, max, mean, count, first and last of a numeric surgicalkit property per time bucket, with the percentage of time spent in each requested alert",
+            "properties": {
+                "args": {
+                    "items": {
+                        "properties": {
+                            "alerts": {
+                                "description": "alert names for which to return the percentage of time active",
+                                "items": {
+                                    "type": "string"
+                                },
+                                "type": "array"
+                            },
+                            "bucket": {
+                                "description": "size of the time buckets, aligned to UTC",
+                                "enum": [
+                                    "minute",
+                                    "hour",
+                                    "day"
+                                ],
+                                "type": "string"
+                            },
+                            "daterange": {
+                                "description": "if specified, dates must fall in between these values, inclusive",
+                                "properties": {
+                                    "begin": {
+                                        "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                        "format": "date-time",
+                                        "sample": "yyyy-mm-dd hh:mm:ss",
+                                        "type": "string"
+                                    },
+                                    "end": {
+                                        "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                        "format": "date-time",
+                                        "sample": "yyyy-mm-dd hh:mm:ss",
+                                        "type": "string"
+                                    }
+                                },
+                                "type": "object"
+                            },
+                            "qprop": {
+                                "description": "qualified property name of a numeric property in the asset state",
+                                "sample": "asset.temperature",
+                                "type": "string"
+                            },
+                            "surgicalkit": {
+                                "properties": {
+                                    "skitID": {
+                                        "description": "A surgicalkit's ID",
+                                        "type": "string"
+                                    }
+                                },
+                                "type": "object"
+                            }
+                        },
+                        "required": [
+                            "surgicalkit",
+                            "bucket"
+                        ],
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
+                    "type": "array"
+                },
+                "function": {
+                    "enum": [
+                        "readAssetStateAggregatesSurgicalKit"
+                    ],
+                    "type": "string"
+                },
+                "method": "query",
+                "result": {
+                    "properties": {
+                        "assetkey": {
+                            "type": "string"
+                        },
+                        "bucket": {
+                            "type": "string"
+                        },
+                        "buckets": {
+                            "description": "buckets that hold readings or covered time, oldest first",
+                            "items": {
+                                "properties": {
+                                    "alertPercent": {
+                                        "description": "percentage of the bucket's covered time for which each alert was active",
+                                        "type": "object"
+                                    },
+                                    "begin": {
+                                        "format": "date-time",
+                                        "type": "string"
+                                    },
+                                    "count": {
+                                        "type": "integer"
+                                    },
+                                    "end": {
+                                        "format": "date-time",
+                                        "type": "string"
+                                    },
+                                    "first": {
+                                        "type": "number"
+                                    },
+                                    "last": {
+                                        "type": "number"
+                                    },
+                                    "max": {
+                                        "type": "number"
+                                    },
+                                    "mean": {
+                                        "type": "number"
+                                    },
+                                    "min": {
+                                        "type": "number"
+                                    }
+                                },
+                                "type": "object"
+                            },
+                            "type": "array"
+                        },
+                        "qprop": {
+                            "type": "string"
+                        }
+                    },
+                    "type": "object"
+                }
+            },
+            "type": "object"
+        },
+        "readAssetStateHistoryContainer": {
+            "description": "Returns history states for a container",
+            "properties": {
+                "args": {
+                    "items": {
+                        "properties": {
+                            "container": {
+                                "properties": {
+                                    "barcode": {
+                                        "description": "A container's ID",
+                                        "type": "string"
+                                    }
+                                },
+                                "type": "object"
+                            },
+                            "daterange": {
+                                "description": "if specified, dates must fall in between these values, inclusive",
+                                "properties": {
+                                    "begin": {
+                                        "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                        "format": "date-time",
+                                        "sample": "yyyy-mm-dd hh:mm:ss",
+                                        "type": "string"
+                                    },
+                                    "end": {
+                                        "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                        "format": "date-time",
+                                        "sample": "yyyy-mm-dd hh:mm:ss",
+                                        "type": "string"
+                                    }
+                                },
+                                "type": "object"
+                            },
+                            "filter": {
+                                "description": "Filter asset states",
+                                "properties": {
+                                    "match": {
+                                        "description": "Defines how to match properties, missing property always fails match",
+                                        "enum": [
+                                            "n/a",
+                                            "all",
+                                            "any",
+                                            "none"
+                                        ],
+                                        "type": "string"
+                                    },
+                                    "select": {
+                                        "description": "Qualified property names and values match",
+                                        "items": {
+                                            "properties": {
+                                                "qprop": {
+                                                    "description": "Qualified property to compare, for example 'asset.assetID'",
+                                                    "type": "string"
+                                                },
+                                                "value": {
+                                                    "description": "Value to be compared",
+                                                    "type": "string"
+                                                }
+                                            },
+                                            "type": "object"
+                                        },
+                                        "type": "array"
+                                    }
+                                },
+                                "type": "object"
+                            }
+                        },
+                        "required": [
+                            "container"
+                        ],
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
+                    "type": "array"
+                },
+                "function": {
+                    "enum": [
+                        "readAssetStateHistoryContainer"
+                    ],
+                    "type": "string"
+                },
+                "method": "query",
+                "result": {
+                    "description": "Array of container states, can mix asset classes",
+                    "items": {
+                        "patternProperties": {
+                            "^CON": {
+                                "description": "A container's complete state",
+                                "properties": {
+                                    "AssetKey": {
+                                        "description": "This container's world state container ID",
+                                        "type": "string"
+                                    },
+                                    "alerts": {
+                                        "description": "An array of alert names",
+                                        "items": {
+                                            "description": "An alert name",
+                                            "type": "string"
+                                        },
+                                        "type": "array"
+                                    },
+                                    "assetIDpath": {
+                                        "description": "Qualified property path to the container's ID, declared in the contract code",
+                                        "type": "string"
+                                    },
+                                    "class": {
+                                        "description": "The container's asset class",
+                                        "type": "string"
+                                    },
+                                    "compliant": {
+                                        "description": "This container has no active alerts",
+                                        "type": "boolean"
+                                    },
+                                    "eventin": {
+                                        "description": "The contract event that created this state, for example updateAssetContainer",
+                                        "properties": {
+                                            "container": {
+                                                "description": "The changeable properties for a container, also considered its 'event' as a partial state",
+                                                "properties": {
+                                                    "barcode": {
+                                                        "description": "A container's ID",
+                                                        "type": "string"
+                                                    },
+                                                    "carrier": {
+                                                        "description": "The carrier in possession of this container",
+                                                        "type": "string"
+                                                    },
+                                                    "common": {
+                                                        "description": "Common properties for all assets",
+                                                        "properties": {
+                                                            "appdata": {
+                                                                "description": "Application managed information as an array of key:value pairs",
+                                                                "items": {
+                                                                    "properties": {
+                                                                        "K": {
+                                                                            "type": "string"
+                                                                        },
+                                                                        "V": {
+                                                                            "type": "string"
+                                                                        }
+                                                                    },
+                                                                    "type": "object"
+                                                                },
+                                                                "minItems": 0,
+                                                                "type": "array"
+                                                            },
+                                                            "deviceID": {
+                                                                "description": "A unique identifier for the device that sent the current event",
+                                                                "type": "string"
+                                                            },
+                                                            "devicetimestamp": {
+                                                                "description": "A timestamp recoded by the device that sent the current event",
+                                                                "type": "string"
+                                                            },
+                                                            "location": {
+                                                                "description": "A geographical coordinate",
+                                                                "properties": {
+                                                                    "latitude": {
+                                                                        "type": "number"
+                                                                    },
+                                                                    "longitude": {
+                                                                        "type": "number"
+                                                                    }
+                                                                },
+                                                                "type": "object"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    },
+                                                    "temperature": {
+                                                        "description": "Temperature of a container's contents in degrees Celsuis",
+                                                        "type": "number"
+                                                    }
+                                                },
+                                                "required": [
+                                                    "barcode"
+                                                ],
+                                                "type": "object"
+                                            }
+                                        },
+                                        "type": "object"
+                                    },
+                                    "eventout": {
+                                        "description": "The chaincode event emitted on invoke exit, if any",
+                                        "properties": {
+                                            "container": {
+                                                "description": "A chaincode event defining the standard platform-generated result event for a contract invoke, contains an array of contributed results",
+                                                "properties": {
+                                                    "name": {
+                                                        "default": "EVT.IOTCP.INVOKE.RESULT",
+                                                        "enum": [
+                                                            "EVT.IOTCP.INVOKE.RESULT"
+                                                        ],
+                                                        "type": "string"
+                                                    },
+                                                    "payload": {
+                                                        "description": "A map of contributed results",
+                                                        "properties": {
+                                                            "description": "the overall status of the invoke result, defined by err",
+                                                            "properties": {
+                                                                "activeAlerts": {
+                                                                    "description": "An array of alert names",
+                                                                    "items": {
+                                                                        "description": "An alert name",
+                                                                        "type": "string"
+                                                                    },
+                                                                    "type": "array"
+                                                                },
+                                                                "alertsCleared": {
+                                                                    "description": "An array of alert names",
+                                                                    "items": {
+                                                                        "description": "An alert name",
+                                                                        "type": "string"
+                                                                    },
+                                                                    "type": "array"
+                                                                },
+                                                                "alertsRaised": {
+                                                                    "description": "An array of alert names",
+                                                                    "items": {
+                                                                        "description": "An alert name",
+                                                                        "type": "string"
+                                                                    },
+                                                                    "type": "array"
+                                                                },
+                                                                "invokeresult": {
+                                                                    "description": "status: OK==txn succeeded, ERROR==txn failed",
+                                                                    "properties": {
+                                                                        "message": {
+                                                                            "type": "string"
+                                                                        },
+                                                                        "status": {
+                                                                            "enum": [
+                                                                                "OK",
+                                                                                "ERROR"
+                                                                            ],
+                                                                            "type": "string"
+                                                                        }
+                                                                    },
+                                                                    "type": "object"
+                                                                }
+                                                            },
+                                                            "type": "object"
+                                                        },
+                                                        "type": "object"
+                                                    }
+                                                },
+                                                "type": "object"
+                                            }
+                                        },
+                                        "type": "object"
+                                    },
+                                    "prefix": {
+                                        "description": "The container's asset class prefix in world state",
+                                        "type": "string"
+                                    },
+                                    "state": {
+                                        "description": "Properties that have been received or calculated for this container",
+                                        "properties": {
+                                            "container": {
+                                                "description": "The changeable properties for a container, also considered its 'event' as a partial state",
+                                                "properties": {
+                                                    "barcode": {
+                                                        "description": "A container's ID",
+                                                        "type": "string"
+                                                    },
+                                                    "carrier": {
+                                                        "description": "The carrier in possession of this container",
+                                                        "type": "string"
+                                                    },
+                                                    "common": {
+                                                        "description": "Common properties for all assets",
+                                                        "properties": {
+                                                            "appdata": {
+                                                                "description": "Application managed information as an array of key:value pairs",
+                                                                "items": {
+                                                                    "properties": {
+                                                                        "K": {
+                                                                            "type": "string"
+                                                                        },
+                                                                        "V": {
+                                                                            "type": "string"
+                                                                        }
+                                                                    },
+                                                                    "type": "object"
+                                                                },
+                                                                "minItems": 0,
+                                                                "type": "array"
+                                                            },
+                                                            "deviceID": {
+                                                                "description": "A unique identifier for the device that sent the current event",
+                                                                "type": "string"
+                                                            },
+                                                            "devicetimestamp": {
+                                                                "description": "A timestamp recoded by the device that sent the current event",
+                                                                "type": "string"
+                                                            },
+                                                            "location": {
+                                                                "description": "A geographical coordinate",
+                                                                "properties": {
+                                                                    "latitude": {
+                                                                        "type": "number"
+                                                                    },
+                                                                    "longitude": {
+                                                                        "type": "number"
+                                                                    }
+                                                                },
+                                                                "type": "object"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    },
+                                                    "temperature": {
+                                                        "description": "Temperature of a container's contents in degrees Celsuis",
+                                                        "type": "number"
+                                                    }
+                                                },
+                                                "required": [
+                                                    "barcode"
+                                                ],
+                                                "type": "object"
+                                            }
+                                        },
+                                        "type": "object"
+                                    },
+                                    "txnid": {
+                                        "description": "Transaction UUID matching the blockchain",
+                                        "type": "string"
+                                    },
+                                    "txnts": {
+                                        "description": "Transaction timestamp matching the blockchain",
+                                        "type": "string"
+                                    }
+                                },
+                                "type": "object"
+                            }
+                        },
+                        "type": "object"
+                    },
+                    "minItems": 0,
+                    "type": "array"
+                }
+            },
+            "type": "object"
+        },
+        "readAssetStateHistorySurgicalKit": {
+            "description": "Returns history states for a surgicalkit",
+            "properties": {
+                "args": {
+                    "items": {
+                        "properties": {
+                            "daterange": {
+                                "description": "if specified, dates must fall in between these values, inclusive",
+                                "properties": {
+                                    "begin": {
+                                        "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                        "format": "date-time",
+                                        "sample": "yyyy-mm-dd hh:mm:ss",
+                                        "type": "string"
+                                    },
+                                    "end": {
+                                        "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                        "format": "date-time",
+                                        "sample": "yyyy-mm-dd hh:mm:ss",
+                                        "type": "string"
+                                    }
+                                },
+                                "type": "object"
+                            },
+                            "filter": {
+                                "description": "Filter asset states",
+                                "properties": {
+                                    "match": {
+                                        "description": "Defines how to match properties, missing property always fails match",
+                                        "enum": [
+                                            "n/a",
+                                            "all",
+                                            "any",
+                                            "none"
+                                        ],
+                                        "type": "string"
+                                    },
+                                    "select": {
+                                        "description": "Qualified property names and values match",
+                                        "items": {
+                                            "properties": {
+                                                "qprop": {
+                                                    "description": "Qualified property to compare, for example 'asset.assetID'",
+                                                    "type": "string"
+                                                },
+                                                "value": {
+                                                    "description": "Value to be compared",
+                                                    "type": "string"
+                                                }
+                                            },
+                                            "type": "object"
+                                        },
+                                        "type": "array"
+                                    }
+                                },
+                                "type": "object"
+                            },
+                            "surgicalkit": {
+                                "properties": {
+                                    "skitID": {
+                                        "description": "A surgicalkit's ID",
+                                        "type": "string"
+                                    }
+                                },
+                                "type": "object"
+                            }
+                        },
+                        "required": [
+                            "surgicalkit"
+                        ],
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
+                    "type": "array"
+                },
+                "function": {
+                    "enum": [
+                        "readAssetStateHistorySurgicalKit"
+                    ],
+                    "type": "string"
+                },
+                "method": "query",
+                "result": {
+                    "description": "Array of surgicalkit states, can mix asset classes",
+                    "items": {
+                        "patternProperties": {
+                            "^CON": {
+                                "description": "A surgicalkit's complete state",
+                                "properties": {
+                                    "AssetKey": {
+                                        "description": "This surgicalkit's world state surgicalkit ID",
+                                        "type": "string"
+                                    },
+                                    "alerts": {
+                                        "description": "An array of alert names",
+                                        "items": {
+                                            "description": "An alert name",
+                                            "type": "string"
+                                        },
+                                        "type": "array"
+                                    },
+                                    "class": {
+                                        "description": "An asset's classifier definition",
+                                        "properties": {
+                                            "assetidpath": "An asset's primary key, expressed as a qualified property path (see example contracts)",
+                                            "name": "An asset's class name",
+                                            "prefix": "An asset's world state prefix, used to allow iteration over all assets of a class"
+                                        },
+                                        "type": "object"
+                                    },
+                                    "compliant": {
+                                        "description": "This surgicalkit has no active alerts",
+                                        "type": "boolean"
+                                    },
+                                    "eventin": {
+                                        "description": "The contract event that created this state, for example updateAssetSurgicalKit",
+                                        "properties": {
+                                            "surgicalkit": {
+                                                "description": "The changeable properties for a surgicalkit, also considered its 'event' as a partial state",
+                                                "properties": {
+                                                    "common": {
+                                                        "description": "Common properties for all assets",
+                                                        "properties": {
+                                                            "appdata": {
+                                                                "description": "Application managed information as an array of key:value pairs",
+                                                                "items": {
+                                                                    "properties": {
+                                                                        "K": {
+                                                                            "type": "string"
+                                                                        },
+                                                                        "V": {
+                                                                            "type": "string"
+                                                                        }
+                                                                    },
+                                                                    "type": "object"
+                                                                },
+                                                                "minItems": 0,
+                                                                "type": "array"
+                                                            },
+                                                            "deviceID": {
+                                                                "description": "A unique identifier for the device that sent the current event",
+                                                                "type": "string"
+                                                            },
+                                                            "devicetimestamp": {
+                                                                "description": "A timestamp recoded by the device that sent the current event",
+                                                                "type": "string"
+                                                            },
+                                                            "location": {
+                                                                "description": "A geographical coordinate",
+                                                                "properties": {
+                                                                    "latitude": {
+                                                                        "type": "number"
+                                                                    },
+                                                                    "longitude": {
+                                                                        "type": "number"
+                                                                    }
+                                                                },
+                                                                "type": "object"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    },
+                                                    "hospital": {
+                                                        "description": "the hospital within which the surgical kit is used, and within which it is geofenced",
+                                                        "properties": {
+                                                            "address": {
+                                                                "properties": {
+                                                                    "city": {
+                                                                        "type": "string"
+                                                                    },
+                                                                    "country": {
+                                                                        "type": "string"
+                                                                    },
+                                                                    "postcode": {
+                                                                        "type": "string"
+                                                                    },
+                                                                    "streetandnumber": {
+                                                                        "type": "string"
+                                                                    }
+                                                                },
+                                                                "type": "object"
+                                                            },
+                                                            "fence": {
+                                                                "properties": {
+                                                                    "center": {
+                                                                        "description": "A geographical coordinate",
+                                                                        "properties": {
+                                                                            "latitude": {
+                                                                                "type": "number"
+                                                                            },
+                                                                            "longitude": {
+                                                                                "type": "number"
+                                                                            }
+                                                                        },
+                                                                        "type": "object"
+                                                                    },
+                                                                    "radius": {
+                                                                        "type": "number"
+                                                                    }
+                                                                },
+                                                                "type": "object"
+                                                            },
+                                                            "name": {
+                                                                "type": "string"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    },
+                                                    "sensors": {
+                                                        "description": "sensor readings for the surgical kit",
+                                                        "properties": {
+                                                            "begin": {
+                                                                "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                                "format": "date-time",
+                                                                "sample": "yyyy-mm-dd hh:mm:ss",
+                                                                "type": "string"
+                                                            },
+                                                            "currtilt": {
+                                                                "description": "The current tilt that the kit is experiencing",
+                                                                "type": "number"
+                                                            },
+                                                            "end": {
+                                                                "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                                "format": "date-time",
+                                                                "sample": "yyyy-mm-dd hh:mm:ss",
+                                                                "type": "string"
+                                                            },
+                                                            "endlocation": {
+                                                                "description": "A geographical coordinate",
+                                                                "properties": {
+                                                                    "latitude": {
+                                                                        "type": "number"
+                                                                    },
+                                                                    "longitude": {
+                                                                        "type": "number"
+                                                                    }
+                                                                },
+                                                                "type": "object"
+                                                            },
+                                                            "maxgforce": {
+                                                                "description": "The highest (in Gs) force that the kit experienced during the sample",
+                                                                "type": "number"
+                                                            },
+                                                            "maxtilt": {
+                                                                "description": "The highest (in degrees from horizontal) tilt that the kit experienced during the sample",
+                                                                "type": "number"
+                                                            },
+                                                            "startlocation": {
+                                                                "description": "A geographical coordinate",
+                                                                "properties": {
+                                                                    "latitude": {
+                                                                        "type": "number"
+                                                                    },
+                                                                    "longitude": {
+                                                                        "type": "number"
+                                                                    }
+                                                                },
+                                                                "type": "object"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    },
+                                                    "skitID": {
+                                                        "description": "A surgicalkit's ID",
+                                                        "type": "string"
+                                                    },
+                                                    "status": {
+                                                        "description": "current kit status as a named entity in possession of the kit",
+                                                        "enum": [
+                                                            "",
+                                                            "oem",
+                                                            "warehouse",
+                                                            "dealer",
+                                                            "retailer",
+                                                            "hospital",
+                                                            "scrapped"
+                                                        ],
+                                                        "type": "string"
+                                                    },
+                                                    "transit": {
+                                                        "description": "shipping data during transit periods",
+                                                        "properties": {
+                                                            "begintransit": {
+                                                                "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                                "format": "date-time",
+                                                                "sample": "yyyy-mm-dd hh:mm:ss",
+                                                                "type": "string"
+                                                            },
+                                                            "carrier": {
+                                                                "type": "string"
+                                                            },
+                                                            "endtransit": {
+                                                                "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                                "format": "date-time",
+                                                                "sample": "yyyy-mm-dd hh:mm:ss",
+                                                                "type": "string"
+                                                            },
+                                                            "receiver": {
+                                                                "description": "current kit status as a named entity in possession of the kit",
+                                                                "enum": [
+                                                                    "",
+                                                                    "oem",
+                                                                    "warehouse",
+                                                                    "dealer",
+                                                                    "retailer",
+                                                                    "hospital",
+                                                                    "scrapped"
+                                                                ],
+                                                                "type": "string"
+                                                            },
+                                                            "shipper": {
+                                                                "description": "current kit status as a named entity in possession of the kit",
+                                                                "enum": [
+                                                                    "",
+                                                                    "oem",
+                                                                    "warehouse",
+                                                                    "dealer",
+                                                                    "retailer",
+                                                                    "hospital",
+                                                                    "scrapped"
+                                                                ],
+                                                                "type": "string"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    }
+                                                },
+                                                "required": [
+                                                    "skitID"
+                                                ],
+                                                "type": "object"
+                                            }
+                                        },
+                                        "type": "object"
+                                    },
+                                    "eventout": {
+                                        "description": "The chaincode event emitted on invoke exit, if any",
+                                        "properties": {
+                                            "surgicalkit": {
+                                                "description": "A chaincode event defining the standard platform-generated result event for a contract invoke, contains an array of contributed results",
+                                                "properties": {
+                                                    "name": {
+                                                        "default": "EVT.IOTCP.INVOKE.RESULT",
+                                                        "enum": [
+                                                            "EVT.IOTCP.INVOKE.RESULT"
+                                                        ],
+                                                        "type": "string"
+                                                    },
+                                                    "payload": {
+                                                        "description": "A map of contributed results",
+                                                        "properties": {
+                                                            "description": "the overall status of the invoke result, defined by err",
+                                                            "properties": {
+                                                                "activeAlerts": {
+                                                                    "description": "An array of alert names",
+                                                                    "items": {
+                                                                        "description": "An alert name",
+                                                                        "type": "string"
+                                                                    },
+                                                                    "type": "array"
+                                                                },
+                                                                "alertsCleared": {
+                                                                    "description": "An array of alert names",
+                                                                    "items": {
+                                                                        "description": "An alert name",
+                                                                        "type": "string"
+                                                                    },
+                                                                    "type": "array"
+                                                                },
+                                                                "alertsRaised": {
+                                                                    "description": "An array of alert names",
+                                                                    "items": {
+                                                                        "description": "An alert name",
+                                                                        "type": "string"
+                                                                    },
+                                                                    "type": "array"
+                                                                },
+                                                                "invokeresult": {
+                                                                    "description": "status: OK==txn succeeded, ERROR==txn failed",
+                                                                    "properties": {
+                                                                        "message": {
+                                                                            "type": "string"
+                                                                        },
+                                                                        "status": {
+                                                                            "enum": [
+                                                                                "OK",
+                                                                                "ERROR"
+                                                                            ],
+                                                                            "type": "string"
+                                                                        }
+                                                                    },
+                                                                    "type": "object"
+                                                                }
+                                                            },
+                                                            "type": "object"
+                                                        },
+                                                        "type": "object"
+                                                    }
+                                                },
+                                                "type": "object"
+                                            }
+                                        },
+                                        "type": "object"
+                                    },
+                                    "state": {
+                                        "description": "Properties that have been received or calculated for this surgicalkit",
+                                        "properties": {
+                                            "distanceFromCenter": {
+                                                "description": "calculated distance from the fence center, can be compared to fence radius",
+                                                "type": "number"
+                                            },
+                                            "surgicalkit": {
+                                                "description": "The changeable properties for a surgicalkit, also considered its 'event' as a partial state",
+                                                "properties": {
+                                                    "common": {
+                                                        "description": "Common properties for all assets",
+                                                        "properties": {
+                                                            "appdata": {
+                                                                "description": "Application managed information as an array of key:value pairs",
+                                                                "items": {
+                                                                    "properties": {
+                                                                        "K": {
+                                                                            "type": "string"
+                                                                        },
+                                                                        "V": {
+                                                                            "type": "string"
+                                                                        }
+                                                                    },
+                                                                    "type": "object"
+                                                                },
+                                                                "minItems": 0,
+                                                                "type": "array"
+                                                            },
+                                                            "deviceID": {
+                                                                "description": "A unique identifier for the device that sent the current event",
+                                                                "type": "string"
+                                                            },
+                                                            "devicetimestamp": {
+                                                                "description": "A timestamp recoded by the device that sent the current event",
+                                                                "type": "string"
+                                                            },
+                                                            "location": {
+                                                                "description": "A geographical coordinate",
+                                                                "properties": {
+                                                                    "latitude": {
+                                                                        "type": "number"
+                                                                    },
+                                                                    "longitude": {
+                                                                        "type": "number"
+                                                                    }
+                                                                },
+                                                                "type": "object"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    },
+                                                    "hospital": {
+                                                        "description": "the hospital within which the surgical kit is used, and within which it is geofenced",
+                                                        "properties": {
+                                                            "address": {
+                                                                "properties": {
+                                                                    "city": {
+                                                                        "type": "string"
+                                                                    },
+                                                                    "country": {
+                                                                        "type": "string"
+                                                                    },
+                                                                    "postcode": {
+                                                                        "type": "string"
+                                                                    },
+                                                                    "streetandnumber": {
+                                                                        "type": "string"
+                                                                    }
+                                                                },
+                                                                "type": "object"
+                                                            },
+                                                            "fence": {
+                                                                "properties": {
+                                                                    "center": {
+                                                                        "description": "A geographical coordinate",
+                                                                        "properties": {
+                                                                            "latitude": {
+                                                                                "type": "number"
+                                                                            },
+                                                                            "longitude": {
+                                                                                "type": "number"
+                                                                            }
+                                                                        },
+                                                                        "type": "object"
+                                                                    },
+                                                                    "radius": {
+                                                                        "type": "number"
+                                                                    }
+                                                                },
+                                                                "type": "object"
+                                                            },
+                                                            "name": {
+                                                                "type": "string"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    },
+                                                    "sensors": {
+                                                        "description": "sensor readings for the surgical kit",
+                                                        "properties": {
+                                                            "begin": {
+                                                                "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                                "format": "date-time",
+                                                                "sample": "yyyy-mm-dd hh:mm:ss",
+                                                                "type": "string"
+                                                            },
+                                                            "currtilt": {
+                                                                "description": "The current tilt that the kit is experiencing",
+                                                                "type": "number"
+                                                            },
+                                                            "end": {
+                                                                "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                                "format": "date-time",
+                                                                "sample": "yyyy-mm-dd hh:mm:ss",
+                                                                "type": "string"
+                                                            },
+                                                            "endlocation": {
+                                                                "description": "A geographical coordinate",
+                                                                "properties": {
+                                                                    "latitude": {
+                                                                        "type": "number"
+                                                                    },
+                                                                    "longitude": {
+                                                                        "type": "number"
+                                                                    }
+                                                                },
+                                                                "type": "object"
+                                                            },
+                                                            "maxgforce": {
+                                                                "description": "The highest (in Gs) force that the kit experienced during the sample",
+                                                                "type": "number"
+                                                            },
+                                                            "maxtilt": {
+                                                                "description": "The highest (in degrees from horizontal) tilt that the kit experienced during the sample",
+                                                                "type": "number"
+                                                            },
+                                                            "startlocation": {
+                                                                "description": "A geographical coordinate",
+                                                                "properties": {
+                                                                    "latitude": {
+                                                                        "type": "number"
+                                                                    },
+                                                                    "longitude": {
+                                                                        "type": "number"
+                                                                    }
+                                                                },
+                                                                "type": "object"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    },
+                                                    "skitID": {
+                                                        "description": "A surgicalkit's ID",
+                                                        "type": "string"
+                                                    },
+                                                    "status": {
+                                                        "description": "current kit status as a named entity in possession of the kit",
+                                                        "enum": [
+                                                            "",
+                                                            "oem",
+                                                            "warehouse",
+                                                            "dealer",
+                                                            "retailer",
+                                                            "hospital",
+                                                            "scrapped"
+                                                        ],
+                                                        "type": "string"
+                                                    },
+                                                    "transit": {
+                                                        "description": "shipping data during transit periods",
+                                                        "properties": {
+                                                            "begintransit": {
+                                                                "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                                "format": "date-time",
+                                                                "sample": "yyyy-mm-dd hh:mm:ss",
+                                                                "type": "string"
+                                                            },
+                                                            "carrier": {
+                                                                "type": "string"
+                                                            },
+                                                            "endtransit": {
+                                                                "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                                "format": "date-time",
+                                                                "sample": "yyyy-mm-dd hh:mm:ss",
+                                                                "type": "string"
+                                                            },
+                                                            "receiver": {
+                                                                "description": "current kit status as a named entity in possession of the kit",
+                                                                "enum": [
+                                                                    "",
+                                                                    "oem",
+                                                                    "warehouse",
+                                                                    "dealer",
+                                                                    "retailer",
+                                                                    "hospital",
+                                                                    "scrapped"
+                                                                ],
+                                                                "type": "string"
+                                                            },
+                                                            "shipper": {
+                                                                "description": "current kit status as a named entity in possession of the kit",
+                                                                "enum": [
+                                                                    "",
+                                                                    "oem",
+                                                                    "warehouse",
+                                                                    "dealer",
+                                                                    "retailer",
+                                                                    "hospital",
+                                                                    "scrapped"
+                                                                ],
+                                                                "type": "string"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    }
+                                                },
+                                                "required": [
+                                                    "skitID"
+                                                ],
+                                                "type": "object"
+                                            }
+                                        },
+                                        "type": "object"
+                                    },
+                                    "txnid": {
+                                        "description": "Transaction UUID matching the blockchain",
+                                        "type": "string"
+                                    },
+                                    "txnts": {
+                                        "description": "Transaction timestamp matching the blockchain",
+                                        "type": "string"
+                                    }
+                                },
+                                "type": "object"
+                            }
+                        },
+                        "type": "object"
+                    },
+                    "minItems": 0,
+                    "type": "array"
+                }
+            },
+            "type": "object"
+        },
+        "readAssetSurgicalKit": {
+            "description": "Returns the state a surgicalkit",
+            "properties": {
+                "args": {
+                    "items": {
+                        "properties": {
+                            "surgicalkit": {
+                                "properties": {
+                                    "skitID": {
+                                        "description": "A surgicalkit's ID",
+                                        "type": "string"
+                                    }
+                                },
+                                "type": "object"
+                            }
+                        },
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
+                    "type": "array"
+                },
+                "function": {
+                    "enum": [
+                        "readAssetSurgicalKit"
+                    ],
+                    "type": "string"
+                },
+                "method": "query",
+                "result": {
+                    "description": "A surgicalkit's complete state",
+                    "properties": {
+                        "AssetKey": {
+                            "description": "This surgicalkit's world state surgicalkit ID",
+                            "type": "string"
+                        },
+                        "alerts": {
+                            "description": "An array of alert names",
+                            "items": {
+                                "description": "An alert name",
+                                "type": "string"
+                            },
+                            "type": "array"
+                        },
+                        "class": {
+                            "description": "An asset's classifier definition",
+                            "properties": {
+                                "assetidpath": "An asset's primary key, expressed as a qualified property path (see example contracts)",
+                                "name": "An asset's class name",
+                                "prefix": "An asset's world state prefix, used to allow iteration over all assets of a class"
+                            },
+                            "type": "object"
+                        },
+                        "compliant": {
+                            "description": "This surgicalkit has no active alerts",
+                            "type": "boolean"
+                        },
+                        "eventin": {
+                            "description": "The contract event that created this state, for example updateAssetSurgicalKit",
+                            "properties": {
+                                "surgicalkit": {
+                                    "description": "The changeable properties for a surgicalkit, also considered its 'event' as a partial state",
+                                    "properties": {
+                                        "common": {
+                                            "description": "Common properties for all assets",
+                                            "properties": {
+                                                "appdata": {
+                                                    "description": "Application managed information as an array of key:value pairs",
+                                                    "items": {
+                                                        "properties": {
+                                                            "K": {
+                                                                "type": "string"
+                                                            },
+                                                            "V": {
+                                                                "type": "string"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    },
+                                                    "minItems": 0,
+                                                    "type": "array"
+                                                },
+                                                "deviceID": {
+                                                    "description": "A unique identifier for the device that sent the current event",
+                                                    "type": "string"
+                                                },
+                                                "devicetimestamp": {
+                                                    "description": "A timestamp recoded by the device that sent the current event",
+                                                    "type": "string"
+                                                },
+                                                "location": {
+                                                    "description": "A geographical coordinate",
+                                                    "properties": {
+                                                        "latitude": {
+                                                            "type": "number"
+                                                        },
+                                                        "longitude": {
+                                                            "type": "number"
+                                                        }
+                                                    },
+                                                    "type": "object"
+                                                }
+                                            },
+                                            "type": "object"
+                                        },
+                                        "hospital": {
+                                            "description": "the hospital within which the surgical kit is used, and within which it is geofenced",
+                                            "properties": {
+                                                "address": {
+                                                    "properties": {
+                                                        "city": {
+                                                            "type": "string"
+                                                        },
+                                                        "country": {
+                                                            "type": "string"
+                                                        },
+                                                        "postcode": {
+                                                            "type": "string"
+                                                        },
+                                                        "streetandnumber": {
+                                                            "type": "string"
+                                                        }
+                                                    },
+                                                    "type": "object"
+                                                },
+                                                "fence": {
+                                                    "properties": {
+                                                        "center": {
+                                                            "description": "A geographical coordinate",
+                                                            "properties": {
+                                                                "latitude": {
+                                                                    "type": "number"
+                                                                },
+                                                                "longitude": {
+                                                                    "type": "number"
+                                                                }
+                                                            },
+                                                            "type": "object"
+                                                        },
+                                                        "radius": {
+                                                            "type": "number"
+                                                        }
+                                                    },
+                                                    "type": "object"
+                                                },
+                                                "name": {
+                                                    "type": "string"
+                                                }
+                                            },
+                                            "type": "object"
+                                        },
+                                        "sensors": {
+                                            "description": "sensor readings for the surgical kit",
+                                            "properties": {
+                                                "begin": {
+                                                    "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                    "format": "date-time",
+                                                    "sample": "yyyy-mm-dd hh:mm:ss",
+                                                    "type": "string"
+                                                },
+                                                "currtilt": {
+                                                    "description": "The current tilt that the kit is experiencing",
+                                                    "type": "number"
+                                                },
+                                                "end": {
+                                                    "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                    "format": "date-time",
+                                                    "sample": "yyyy-mm-dd hh:mm:ss",
+                                                    "type": "string"
+                                                },
+                                                "endlocation": {
+                                                    "description": "A geographical coordinate",
+                                                    "properties": {
+                                                        "latitude": {
+                                                            "type": "number"
+                                                        },
+                                                        "longitude": {
+                                                            "type": "number"
+                                                        }
+                                                    },
+                                                    "type": "object"
+                                                },
+                                                "maxgforce": {
+                                                    "description": "The highest (in Gs) force that the kit experienced during the sample",
+                                                    "type": "number"
+                                                },
+                                                "maxtilt": {
+                                                    "description": "The highest (in degrees from horizontal) tilt that the kit experienced during the sample",
+                                                    "type": "number"
+                                                },
+                                                "startlocation": {
+                                                    "description": "A geographical coordinate",
+                                                    "properties": {
+                                                        "latitude": {
+                                                            "type": "number"
+                                                        },
+                                                        "longitude": {
+                                                            "type": "number"
+                                                        }
+                                                    },
+                                                    "type": "object"
+                                                }
+                                            },
+                                            "type": "object"
+                                        },
+                                        "skitID": {
+                                            "description": "A surgicalkit's ID",
+                                            "type": "string"
+                                        },
+                                        "status": {
+                                            "description": "current kit status as a named entity in possession of the kit",
+                                            "enum": [
+                                                "",
+                                                "oem",
+                                                "warehouse",
+                                                "dealer",
+                                                "retailer",
+                                                "hospital",
+                                                "scrapped"
+                                            ],
+                                            "type": "string"
+                                        },
+                                        "transit": {
+                                            "description": "shipping data during transit periods",
+                                            "properties": {
+                                                "begintransit": {
+                                                    "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                    "format": "date-time",
+                                                    "sample": "yyyy-mm-dd hh:mm:ss",
+                                                    "type": "string"
+                                                },
+                                                "carrier": {
+                                                    "type": "string"
+                                                },
+                                                "endtransit": {
+                                                    "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                    "format": "date-time",
+                                                    "sample": "yyyy-mm-dd hh:mm:ss",
+                                                    "type": "string"
+                                                },
+                                                "receiver": {
+                                                    "description": "current kit status as a named entity in possession of the kit",
+                                                    "enum": [
+                                                        "",
+                                                        "oem",
+                                                        "warehouse",
+                                                        "dealer",
+                                                        "retailer",
+                                                        "hospital",
+                                                        "scrapped"
+                                                    ],
+                                                    "type": "string"
+                                                },
+                                                "shipper": {
+                                                    "description": "current kit status as a named entity in possession of the kit",
+                                                    "enum": [
+                                                        "",
+                                                        "oem",
+                                                        "warehouse",
+                                                        "dealer",
+                                                        "retailer",
+                                                        "hospital",
+                                                        "scrapped"
+                                                    ],
+                                                    "type": "string"
+                                                }
+                                            },
+                                            "type": "object"
+                                        }
+                                    },
+                                    "required": [
+                                        "skitID"
+                                    ],
+                                    "type": "object"
+                                }
+                            },
+                            "type": "object"
+                        },
+                        "eventout": {
+                            "description": "The chaincode event emitted on invoke exit, if any",
+                            "properties": {
+                                "surgicalkit": {
+                                    "description": "A chaincode event defining the standard platform-generated result event for a contract invoke, contains an array of contributed results",
+                                    "properties": {
+                                        "name": {
+                                            "default": "EVT.IOTCP.INVOKE.RESULT",
+                                            "enum": [
+                                                "EVT.IOTCP.INVOKE.RESULT"
+                                            ],
+                                            "type": "string"
+                                        },
+                                        "payload": {
+                                            "description": "A map of contributed results",
+                                            "properties": {
+                                                "description": "the overall status of the invoke result, defined by err",
+                                                "properties": {
+                                                    "activeAlerts": {
+                                                        "description": "An array of alert names",
+                                                        "items": {
+                                                            "description": "An alert name",
+                                                            "type": "string"
+                                                        },
+                                                        "type": "array"
+                                                    },
+                                                    "alertsCleared": {
+                                                        "description": "An array of alert names",
+                                                        "items": {
+                                                            "description": "An alert name",
+                                                            "type": "string"
+                                                        },
+                                                        "type": "array"
+                                                    },
+                                                    "alertsRaised": {
+                                                        "description": "An array of alert names",
+                                                        "items": {
+                                                            "description": "An alert name",
+                                                            "type": "string"
+                                                        },
+                                                        "type": "array"
+                                                    },
+                                                    "invokeresult": {
+                                                        "description": "status: OK==txn succeeded, ERROR==txn failed",
+                                                        "properties": {
+                                                            "message": {
+                                                                "type": "string"
+                                                            },
+                                                            "status": {
+                                                                "enum": [
+                                                                    "OK",
+                                                                    "ERROR"
+                                                                ],
+                                                                "type": "string"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    }
+                                                },
+                                                "type": "object"
+                                            },
+                                            "type": "object"
+                                        }
+                                    },
+                                    "type": "object"
+                                }
+                            },
+                            "type": "object"
+                        },
+                        "state": {
+                            "description": "Properties that have been received or calculated for this surgicalkit",
+                            "properties": {
+                                "distanceFromCenter": {
+                                    "description": "calculated distance from the fence center, can be compared to fence radius",
+                                    "type": "number"
+                                },
+                                "surgicalkit": {
+                                    "description": "The changeable properties for a surgicalkit, also considered its 'event' as a partial state",
+                                    "properties": {
+                                        "common": {
+                                            "description": "Common properties for all assets",
+                                            "properties": {
+                                                "appdata": {
+                                                    "description": "Application managed information as an array of key:value pairs",
+                                                    "items": {
+                                                        "properties": {
+                                                            "K": {
+                                                                "type": "string"
+                                                            },
+                                                            "V": {
+                                                                "type": "string"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    },
+                                                    "minItems": 0,
+                                                    "type": "array"
+                                                },
+                                                "deviceID": {
+                                                    "description": "A unique identifier for the device that sent the current event",
+                                                    "type": "string"
+                                                },
+                                                "devicetimestamp": {
+                                                    "description": "A timestamp recoded by the device that sent the current event",
+                                                    "type": "string"
+                                                },
+                                                "location": {
+                                                    "description": "A geographical coordinate",
+                                                    "properties": {
+                                                        "latitude": {
+                                                            "type": "number"
+                                                        },
+                                                        "longitude": {
+                                                            "type": "number"
+                                                        }
+                                                    },
+                                                    "type": "object"
+                                                }
+                                            },
+                                            "type": "object"
+                                        },
+                                        "hospital": {
+                                            "description": "the hospital within which the surgical kit is used, and within which it is geofenced",
+                                            "properties": {
+                                                "address": {
+                                                    "properties": {
+                                                        "city": {
+                                                            "type": "string"
+                                                        },
+                                                        "country": {
+                                                            "type": "string"
+                                                        },
+                                                        "postcode": {
+                                                            "type": "string"
+                                                        },
+                                                        "streetandnumber": {
+                                                            "type": "string"
+                                                        }
+                                                    },
+                                                    "type": "object"
+                                                },
+                                                "fence": {
+                                                    "properties": {
+                                                        "center": {
+                                                            "description": "A geographical coordinate",
+                                                            "properties": {
+                                                                "latitude": {
+                                                                    "type": "number"
+                                                                },
+                                                                "longitude": {
+                                                                    "type": "number"
+                                                                }
+                                                            },
+                                                            "type": "object"
+                                                        },
+                                                        "radius": {
+                                                            "type": "number"
+                                                        }
+                                                    },
+                                                    "type": "object"
+                                                },
+                                                "name": {
+                                                    "type": "string"
+                                                }
+                                            },
+                                            "type": "object"
+                                        },
+                                        "sensors": {
+                                            "description": "sensor readings for the surgical kit",
+                                            "properties": {
+                                                "begin": {
+                                                    "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                    "format": "date-time",
+                                                    "sample": "yyyy-mm-dd hh:mm:ss",
+                                                    "type": "string"
+                                                },
+                                                "currtilt": {
+                                                    "description": "The current tilt that the kit is experiencing",
+                                                    "type": "number"
+                                                },
+                                                "end": {
+                                                    "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                    "format": "date-time",
+                                                    "sample": "yyyy-mm-dd hh:mm:ss",
+                                                    "type": "string"
+                                                },
+                                                "endlocation": {
+                                                    "description": "A geographical coordinate",
+                                                    "properties": {
+                                                        "latitude": {
+                                                            "type": "number"
+                                                        },
+                                                        "longitude": {
+                                                            "type": "number"
+                                                        }
+                                                    },
+                                                    "type": "object"
+                                                },
+                                                "maxgforce": {
+                                                    "description": "The highest (in Gs) force that the kit experienced during the sample",
+                                                    "type": "number"
+                                                },
+                                                "maxtilt": {
+                                                    "description": "The highest (in degrees from horizontal) tilt that the kit experienced during the sample",
+                                                    "type": "number"
+                                                },
+                                                "startlocation": {
+                                                    "description": "A geographical coordinate",
+                                                    "properties": {
+                                                        "latitude": {
+                                                            "type": "number"
+                                                        },
+                                                        "longitude": {
+                                                            "type": "number"
+                                                        }
+                                                    },
+                                                    "type": "object"
+                                                }
+                                            },
+                                            "type": "object"
+                                        },
+                                        "skitID": {
+                                            "description": "A surgicalkit's ID",
+                                            "type": "string"
+                                        },
+                                        "status": {
+                                            "description": "current kit status as a named entity in possession of the kit",
+                                            "enum": [
+                                                "",
+                                                "oem",
+                                                "warehouse",
+                                                "dealer",
+                                                "retailer",
+                                                "hospital",
+                                                "scrapped"
+                                            ],
+                                            "type": "string"
+                                        },
+                                        "transit": {
+                                            "description": "shipping data during transit periods",
+                                            "properties": {
+                                                "begintransit": {
+                                                    "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                    "format": "date-time",
+                                                    "sample": "yyyy-mm-dd hh:mm:ss",
+                                                    "type": "string"
+                                                },
+                                                "carrier": {
+                                                    "type": "string"
+                                                },
+                                                "endtransit": {
+                                                    "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                    "format": "date-time",
+                                                    "sample": "yyyy-mm-dd hh:mm:ss",
+                                                    "type": "string"
+                                                },
+                                                "receiver": {
+                                                    "description": "current kit status as a named entity in possession of the kit",
+                                                    "enum": [
+                                                        "",
+                                                        "oem",
+                                                        "warehouse",
+                                                        "dealer",
+                                                        "retailer",
+                                                        "hospital",
+                                                        "scrapped"
+                                                    ],
+                                                    "type": "string"
+                                                },
+                                                "shipper": {
+                                                    "description": "current kit status as a named entity in possession of the kit",
+                                                    "enum": [
+                                                        "",
+                                                        "oem",
+                                                        "warehouse",
+                                                        "dealer",
+                                                        "retailer",
+                                                        "hospital",
+                                                        "scrapped"
+                                                    ],
+                                                    "type": "string"
+                                                }
+                                            },
+                                            "type": "object"
+                                        }
+                                    },
+                                    "required": [
+                                        "skitID"
+                                    ],
+                                    "type": "object"
+                                }
+                            },
+                            "type": "object"
+                        },
+                        "txnid": {
+                            "description": "Transaction UUID matching the blockchain",
+                            "type": "string"
+                        },
+                        "txnts": {
+                            "description": "Transaction timestamp matching the blockchain",
+                            "type": "string"
+                        }
+                    },
+                    "type": "object"
+                }
+            },
+            "type": "object"
+        },
+        "readRecentStates": {
+            "description": "Returns the state of recently updated assets",
+            "properties": {
+                "args": {
+                    "items": {
+                        "properties": {
+                            "begin": {
+                                "description": "zero based beginning of range",
+                                "type": "integer"
+                            },
+                            "end": {
+                                "description": "zero based end of range, absence means to end",
+                                "type": "integer"
+                            }
+                        },
+                        "type": "object"
+                    },
+                    "maxItems": 0,
+                    "minItems": 0,
+                    "type": "array"
+                },
+                "function": {
+                    "enum": [
+                        "readRecentStates"
+                    ],
+                    "type": "string"
+                },
+                "method": "query",
+                "result": {
+                    "description": "Array of asset states, can mix asset classes",
+                    "items": {
+                        "description": "A asset's complete state",
+                        "properties": {
+                            "alerts": {
+                                "description": "An array of alert names",
+                                "items": {
+                                    "description": "An alert name",
+                                    "type": "string"
+                                },
+                                "type": "array"
+                            },
+                            "assetID": {
+                                "description": "This asset's world state asset ID",
+                                "type": "string"
+                            },
+                            "class": {
+                                "description": "An asset's classifier definition",
+                                "properties": {
+                                    "assetidpath": "An asset's primary key, expressed as a qualified property path (see example contracts)",
+                                    "name": "An asset's class name",
+                                    "prefix": "An asset's world state prefix, used to allow iteration over all assets of a class"
+                                },
+                                "type": "object"
+                            },
+                            "compliant": {
+                                "description": "This asset has no active alerts",
+                                "type": "boolean"
+                            },
+                            "eventin": {
+                                "description": "The contract event that created this state, for example updateAsset",
+                                "properties": {
+                                    "asset": {
+                                        "description": "The changeable properties for an asset, also considered its 'event' as a partial state",
+                                        "properties": {
+                                            "assetID": {
+                                                "description": "An asset's unique ID, e.g. barcode, VIN, etc.",
+                                                "type": "string"
+                                            },
+                                            "carrier": {
+                                                "description": "The carrier in possession of this asset",
+                                                "type": "string"
+                                            },
+                                            "common": {
+                                                "description": "Common properties for all assets",
+                                                "properties": {
+                                                    "appdata": {
+                                                        "description": "Application managed information as an array of key:value pairs",
+                                                        "items": {
+                                                            "properties": {
+                                                                "K": {
+                                                                    "type": "string"
+                                                                },
+                                                                "V": {
+                                                                    "type": "string"
+                                                                }
+                                                            },
+                                                            "type": "object"
+                                                        },
+                                                        "minItems": 0,
+                                                        "type": "array"
+                                                    },
+                                                    "deviceID": {
+                                                        "description": "A unique identifier for the device that sent the current event",
+                                                        "type": "string"
+                                                    },
+                                                    "devicetimestamp": {
+                                                        "description": "A timestamp recoded by the device that sent the current event",
+                                                        "type": "string"
+                                                    },
+                                                    "location": {
+                                                        "description": "A geographical coordinate",
+                                                        "properties": {
+                                                            "latitude": {
+                                                                "type": "number"
+                                                            },
+                                                            "longitude": {
+                                                                "type": "number"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    }
+                                                },
+                                                "type": "object"
+                                            },
+                                            "temperature": {
+                                                "description": "Temperature of an asset's contents in degrees Celsuis",
+                                                "type": "number"
+                                            }
+                                        },
+                                        "required": [
+                                            "assetID"
+                                        ],
+                                        "type": "object"
+                                    }
+                                },
+                                "type": "object"
+                            },
+                            "eventout": {
+                                "description": "The chaincode event emitted on invoke exit, if any",
+                                "properties": {
+                                    "asset": {
+                                        "description": "A chaincode event defining the standard platform-generated result event for a contract invoke, contains an array of contributed results",
+                                        "properties": {
+                                            "name": {
+                                                "default": "EVT.IOTCP.INVOKE.RESULT",
+                                                "enum": [
+                                                    "EVT.IOTCP.INVOKE.RESULT"
+                                                ],
+                                                "type": "string"
+                                            },
+                                            "payload": {
+                                                "description": "A map of contributed results",
+                                                "properties": {
+                                                    "description": "the overall status of the invoke result, defined by err",
+                                                    "properties": {
+                                                        "activeAlerts": {
+                                                            "description": "An array of alert names",
+                                                            "items": {
+                                                                "description": "An alert name",
+                                                                "type": "string"
+                                                            },
+                                                            "type": "array"
+                                                        },
+                                                        "alertsCleared": {
+                                                            "description": "An array of alert names",
+                                                            "items": {
+                                                                "description": "An alert name",
+                                                                "type": "string"
+                                                            },
+                                                            "type": "array"
+                                                        },
+                                                        "alertsRaised": {
+                                                            "description": "An array of alert names",
+                                                            "items": {
+                                                                "description": "An alert name",
+                                                                "type": "string"
+                                                            },
+                                                            "type": "array"
+                                                        },
+                                                        "invokeresult": {
+                                                            "description": "status: OK==txn succeeded, ERROR==txn failed",
+                                                            "properties": {
+                                                                "message": {
+                                                                    "type": "string"
+                                                                },
+                                                                "status": {
+                                                                    "enum": [
+                                                                        "OK",
+                                                                        "ERROR"
+                                                                    ],
+                                                                    "type": "string"
+                                                                }
+                                                            },
+                                                            "type": "object"
+                                                        }
+                                                    },
+                                                    "type": "object"
+                                                },
+                                                "type": "object"
+                                            }
+                                        },
+                                        "type": "object"
+                                    }
+                                },
+                                "type": "object"
+                            },
+                            "state": {
+                                "description": "Properties that have been received or calculated for this asset",
+                                "properties": {
+                                    "asset": {
+                                        "description": "The changeable properties for an asset, also considered its 'event' as a partial state",
+                                        "properties": {
+                                            "assetID": {
+                                                "description": "An asset's unique ID, e.g. barcode, VIN, etc.",
+                                                "type": "string"
+                                            },
+                                            "carrier": {
+                                                "description": "The carrier in possession of this asset",
+                                                "type": "string"
+                                            },
+                                            "common": {
+                                                "description": "Common properties for all assets",
+                                                "properties": {
+                                                    "appdata": {
+                                                        "description": "Application managed information as an array of key:value pairs",
+                                                        "items": {
+                                                            "properties": {
+                                                                "K": {
+                                                                    "type": "string"
+                                                                },
+                                                                "V": {
+                                                                    "type": "string"
+                                                                }
+                                                            },
+                                                            "type": "object"
+                                                        },
+                                                        "minItems": 0,
+                                                        "type": "array"
+                                                    },
+                                                    "deviceID": {
+                                                        "description": "A unique identifier for the device that sent the current event",
+                                                        "type": "string"
+                                                    },
+                                                    "devicetimestamp": {
+                                                        "description": "A timestamp recoded by the device that sent the current event",
+                                                        "type": "string"
+                                                    },
+                                                    "location": {
+                                                        "description": "A geographical coordinate",
+                                                        "properties": {
+                                                            "latitude": {
+                                                                "type": "number"
+                                                            },
+                                                            "longitude": {
+                                                                "type": "number"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    }
+                                                },
+                                                "type": "object"
+                                            },
+                                            "temperature": {
+                                                "description": "Temperature of an asset's contents in degrees Celsuis",
+                                                "type": "number"
+                                            }
+                                        },
+                                        "required": [
+                                            "assetID"
+                                        ],
+                                        "type": "object"
+                                    }
+                                },
+                                "type": "object"
+                            },
+                            "txnid": {
+                                "description": "Transaction UUID matching the blockchain",
+                                "type": "string"
+                            },
+                            "txnts": {
+                                "description": "Transaction timestamp matching the blockchain",
+                                "type": "string"
+                            }
+                        },
+                        "type": "object"
+                    },
+                    "minItems": 0,
+                    "type": "array"
+                }
+            },
+            "type": "object"
+        },
+        "readWorldState": {
+            "description": "Returns the entire contents of world state",
+            "properties": {
+                "args": {
+                    "items": {},
+                    "maxItems": 0,
+                    "minItems": 0,
+                    "type": "array"
+                },
+                "function": {
+                    "enum": [
+                        "readWorldState"
+                    ],
+                    "type": "string"
+                },
+                "method": "query",
+                "result": {
+                    "properties": {},
+                    "type": "object"
+                }
+            },
+            "type": "object"
+        },
+        "replaceAssetContainer": {
+            "description": "Replaces a container's state (e.g. put existing)",
+            "properties": {
+                "args": {
+                    "items": {
+                        "properties": {
+                            "container": {
+                                "description": "The changeable properties for a container, also considered its 'event' as a partial state",
+                                "properties": {
+                                    "barcode": {
+                                        "description": "A container's ID",
+                                        "type": "string"
+                                    },
+                                    "carrier": {
+                                        "description": "The carrier in possession of this container",
+                                        "type": "string"
+                                    },
+                                    "common": {
+                                        "description": "Common properties for all assets",
+                                        "properties": {
+                                            "appdata": {
+                                                "description": "Application managed information as an array of key:value pairs",
+                                                "items": {
+                                                    "properties": {
+                                                        "K": {
+                                                            "type": "string"
+                                                        },
+                                                        "V": {
+                                                            "type": "string"
+                                                        }
+                                                    },
+                                                    "type": "object"
+                                                },
+                                                "minItems": 0,
+                                                "type": "array"
+                                            },
+                                            "deviceID": {
+                                                "description": "A unique identifier for the device that sent the current event",
+                                                "type": "string"
+                                            },
+                                            "devicetimestamp": {
+                                                "description": "A timestamp recoded by the device that sent the current event",
+                                                "type": "string"
+                                            },
+                                            "location": {
+                                                "description": "A geographical coordinate",
+                                                "properties": {
+                                                    "latitude": {
+                                                        "type": "number"
+                                                    },
+                                                    "longitude": {
+                                                        "type": "number"
+                                                    }
+                                                },
+                                                "type": "object"
+                                            }
+                                        },
+                                        "type": "object"
+                                    },
+                                    "temperature": {
+                                        "description": "Temperature of a container's contents in degrees Celsuis",
+                                        "type": "number"
+                                    }
+                                },
+                                "required": [
+                                    "barcode"
+                                ],
+                                "type": "object"
+                            }
+                        },
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
+                    "type": "array"
+                },
+                "function": {
+                    "enum": [
+                        "replaceAssetContainer"
+                    ],
+                    "type": "string"
+                },
+                "method": "invoke"
+            },
+            "type": "object"
+        },
+        "replaceAssetSurgicalKit": {
+            "description": "Replaces a surgicalkit's state (e.g. put existing)",
+            "properties": {
+                "args": {
+                    "items": {
+                        "properties": {
+                            "surgicalkit": {
+                                "description": "The changeable properties for a surgicalkit, also considered its 'event' as a partial state",
+                                "properties": {
+                                    "common": {
+                                        "description": "Common properties for all assets",
+                                        "properties": {
+                                            "appdata": {
+                                                "description": "Application managed information as an array of key:value pairs",
+                                                "items": {
+                                                    "properties": {
+                                                        "K": {
+                                                            "type": "string"
+                                                        },
+                                                        "V": {
+                                                            "type": "string"
+                                                        }
+                                                    },
+                                                    "type": "object"
+                                                },
+                                                "minItems": 0,
+                                                "type": "array"
+                                            },
+                                            "deviceID": {
+                                                "description": "A unique identifier for the device that sent the current event",
+                                                "type": "string"
+                                            },
+                                            "devicetimestamp": {
+                                                "description": "A timestamp recoded by the device that sent the current event",
+                                                "type": "string"
+                                            },
+                                            "location": {
+                                                "description": "A geographical coordinate",
+                                                "properties": {
+                                                    "latitude": {
+                                                        "type": "number"
+                                                    },
+                                                    "longitude": {
+                                                        "type": "number"
+                                                    }
+                                                },
+                                                "type": "object"
+                                            }
+                                        },
+                                        "type": "object"
+                                    },
+                                    "hospital": {
+                                        "description": "the hospital within which the surgical kit is used, and within which it is geofenced",
+                                        "properties": {
+                                            "address": {
+                                                "properties": {
+                                                    "city": {
+                                                        "type": "string"
+                                                    },
+                                                    "country": {
+                                                        "type": "string"
+                                                    },
+                                                    "postcode": {
+                                                        "type": "string"
+                                                    },
+                                                    "streetandnumber": {
+                                                        "type": "string"
+                                                    }
+                                                },
+                                                "type": "object"
+                                            },
+                                            "fence": {
+                                                "properties": {
+                                                    "center": {
+                                                        "description": "A geographical coordinate",
+                                                        "properties": {
+                                                            "latitude": {
+                                                                "type": "number"
+                                                            },
+                                                            "longitude": {
+                                                                "type": "number"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    },
+                                                    "radius": {
+                                                        "type": "number"
+                                                    }
+                                                },
+                                                "type": "object"
+                                            },
+                                            "name": {
+                                                "type": "string"
+                                            }
+                                        },
+                                        "type": "object"
+                                    },
+                                    "sensors": {
+                                        "description": "sensor readings for the surgical kit",
+                                        "properties": {
+                                            "begin": {
+                                                "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                "format": "date-time",
+                                                "sample": "yyyy-mm-dd hh:mm:ss",
+                                                "type": "string"
+                                            },
+                                            "currtilt": {
+                                                "description": "The current tilt that the kit is experiencing",
+                                                "type": "number"
+                                            },
+                                            "end": {
+                                                "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                "format": "date-time",
+                                                "sample": "yyyy-mm-dd hh:mm:ss",
+                                                "type": "string"
+                                            },
+                                            "endlocation": {
+                                                "description": "A geographical coordinate",
+                                                "properties": {
+                                                    "latitude": {
+                                                        "type": "number"
+                                                    },
+                                                    "longitude": {
+                                                        "type": "number"
+                                                    }
+                                                },
+                                                "type": "object"
+                                            },
+                                            "maxgforce": {
+                                                "description": "The highest (in Gs) force that the kit experienced during the sample",
+                                                "type": "number"
+                                            },
+                                            "maxtilt": {
+                                                "description": "The highest (in degrees from horizontal) tilt that the kit experienced during the sample",
+                                                "type": "number"
+                                            },
+                                            "startlocation": {
+                                                "description": "A geographical coordinate",
+                                                "properties": {
+                                                    "latitude": {
+                                                        "type": "number"
+                                                    },
+                                                    "longitude": {
+                                                        "type": "number"
+                                                    }
+                                                },
+                                                "type": "object"
+                                            }
+                                        },
+                                        "type": "object"
+                                    },
+                                    "skitID": {
+                                        "description": "A surgicalkit's ID",
+                                        "type": "string"
+                                    },
+                                    "status": {
+                                        "description": "current kit status as a named entity in possession of the kit",
+                                        "enum": [
+                                            "",
+                                            "oem",
+                                            "warehouse",
+                                            "dealer",
+                                            "retailer",
+                                            "hospital",
+                                            "scrapped"
+                                        ],
+                                        "type": "string"
+                                    },
+                                    "transit": {
+                                        "description": "shipping data during transit periods",
+                                        "properties": {
+                                            "begintransit": {
+                                                "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                "format": "date-time",
+                                                "sample": "yyyy-mm-dd hh:mm:ss",
+                                                "type": "string"
+                                            },
+                                            "carrier": {
+                                                "type": "string"
+                                            },
+                                            "endtransit": {
+                                                "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                "format": "date-time",
+                                                "sample": "yyyy-mm-dd hh:mm:ss",
+                                                "type": "string"
+                                            },
+                                            "receiver": {
+                                                "description": "current kit status as a named entity in possession of the kit",
+                                                "enum": [
+                                                    "",
+                                                    "oem",
+                                                    "warehouse",
+                                                    "dealer",
+                                                    "retailer",
+                                                    "hospital",
+                                                    "scrapped"
+                                                ],
+                                                "type": "string"
+                                            },
+                                            "shipper": {
+                                                "description": "current kit status as a named entity in possession of the kit",
+                                                "enum": [
+                                                    "",
+                                                    "oem",
+                                                    "warehouse",
+                                                    "dealer",
+                                                    "retailer",
+                                                    "hospital",
+                                                    "scrapped"
+                                                ],
+                                                "type": "string"
+                                            }
+                                        },
+                                        "type": "object"
+                                    }
+                                },
+                                "required": [
+                                    "skitID"
+                                ],
+                                "type": "object"
+                            }
+                        },
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
+                    "type": "array"
+                },
+                "function": {
+                    "enum": [
+                        "replaceAssetSurgicalKit"
+                    ],
+                    "type": "string"
+                },
+                "method": "invoke"
+            },
+            "type": "object"
+        },
+        "setCreateOnFirstUpdate": {
+            "description": "Allow updateAsset to create an asset upon receipt of its first event",
+            "properties": {
+                "args": {
+                    "items": {
+                        "properties": {
+                            "setCreateOnFirstUpdate": {
+                                "description": "Allows updates to create missing assets on first event",
+                                "type": "boolean"
+                            }
+                        },
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
+                    "type": "array"
+                },
+                "function": {
+                    "enum": [
+                        "setCreateOnFirstUpdate"
+                    ],
+                    "type": "string"
+                },
+                "method": "invoke"
+            },
+            "type": "object"
+        },
+        "setHistoryMode": {
+            "description": "Chooses whether asset history is stored as full snapshots or as deltas with periodic snapshots",
+            "properties": {
+                "args": {
+                    "items": {
+                        "properties": {
+                            "mode": {
+                                "description": "snapshots stores the whole asset on every write, events stores only the change",
+                                "enum": [
+                                    "snapshots",
+                                    "events"
+                                ],
+                                "type": "string"
+                            },
+                            "snapshotInterval": {
+                                "description": "in events mode, the number of deltas written between full snapshots, defaults to 10",
+                                "minimum": 0,
+                                "type": "integer"
+                            }
+                        },
+                        "required": [
+                            "mode"
+                        ],
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
+                    "type": "array"
+                },
+                "function": {
+                    "enum": [
+                        "setHistoryMode"
+                    ],
+                    "type": "string"
+                },
+                "method": "invoke"
+            },
+            "type": "object"
+        },
+        "setLoggingLevel": {
+            "description": "Sets the logging level for the contract",
+            "properties": {
+                "args": {
+                    "items": {
+                        "properties": {
+                            "logLevel": {
+                                "enum": [
+                                    "CRITICAL",
+                                    "ERROR",
+                                    "WARNING",
+                                    "NOTICE",
+                                    "INFO",
+                                    "DEBUG"
+                                ],
+                                "type": "string"
+                            },
+                            "module": {
+                                "description": "Sets the level for this module and its sub-modules only, e.g. iotcontractplatform.filters, all modules are set when omitted",
+                                "type": "string"
+                            },
+                            "redact": {
+                                "description": "Qualified property paths whose values are masked wherever they appear in logged fields, replaces the current list",
+                                "items": {
+                                    "type": "string"
+                                },
+                                "type": "array"
+                            }
+                        },
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
+                    "type": "array"
+                },
+                "function": {
+                    "enum": [
+                        "setLoggingLevel"
+                    ],
+                    "type": "string"
+                },
+                "method": "invoke"
+            },
+            "type": "object"
+        },
+        "unlinkAssets": {
+            "description": "Removes the link between a child asset and its parent in a relation",
+            "properties": {
+                "args": {
+                    "items": {
+                        "properties": {
+                            "child": {
+                                "description": "Identifies an asset of any class",
+                                "properties": {
+                                    "assetID": {
+                                        "description": "An asset's unique ID, e.g. barcode, VIN, etc.",
+                                        "type": "string"
+                                    },
+                                    "assetkey": {
+                                        "description": "The asset's world state key, returned by queries",
+                                        "type": "string"
+                                    },
+                                    "class": {
+                                        "description": "The asset class name",
+                                        "type": "string"
+                                    }
+                                },
+                                "required": [
+                                    "class",
+                                    "assetID"
+                                ],
+                                "type": "object"
+                            },
+                            "parent": {
+                                "description": "Identifies an asset of any class",
+                                "properties": {
+                                    "assetID": {
+                                        "description": "An asset's unique ID, e.g. barcode, VIN, etc.",
+                                        "type": "string"
+                                    },
+                                    "assetkey": {
+                                        "description": "The asset's world state key, returned by queries",
+                                        "type": "string"
+                                    },
+                                    "class": {
+                                        "description": "The asset class name",
+                                        "type": "string"
+                                    }
+                                },
+                                "required": [
+                                    "class",
+                                    "assetID"
+                                ],
+                                "type": "object"
+                            },
+                            "relation": {
+                                "description": "A relation between a parent and a child asset, contracts can register more",
+                                "enum": [
+                                    "contains",
+                                    "installed-on",
+                                    "custodian-of"
+                                ],
+                                "type": "string"
+                            }
+                        },
+                        "required": [
+                            "relation",
+                            "parent",
+                            "child"
+                        ],
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
+                    "type": "array"
+                },
+                "function": {
+                    "enum": [
+                        "unlinkAssets"
+                    ],
+                    "type": "string"
+                },
+                "method": "invoke"
+            },
+            "type": "object"
+        },
+        "updateAssetContainer": {
+            "description": "Update a contaner's state with one or more property changes (e.g. patch existing)",
+            "properties": {
+                "args": {
+                    "items": {
+                        "properties": {
+                            "container": {
+                                "description": "The changeable properties for a container, also considered its 'event' as a partial state",
+                                "properties": {
+                                    "barcode": {
+                                        "description": "A container's ID",
+                                        "type": "string"
+                                    },
+                                    "carrier": {
+                                        "description": "The carrier in possession of this container",
+                                        "type": "string"
+                                    },
+                                    "common": {
+                                        "description": "Common properties for all assets",
+                                        "properties": {
+                                            "appdata": {
+                                                "description": "Application managed information as an array of key:value pairs",
+                                                "items": {
+                                                    "properties": {
+                                                        "K": {
+                                                            "type": "string"
+                                                        },
+                                                        "V": {
+                                                            "type": "string"
+                                                        }
+                                                    },
+                                                    "type": "object"
+                                                },
+                                                "minItems": 0,
+                                                "type": "array"
+                                            },
+                                            "deviceID": {
+                                                "description": "A unique identifier for the device that sent the current event",
+                                                "type": "string"
+                                            },
+                                            "devicetimestamp": {
+                                                "description": "A timestamp recoded by the device that sent the current event",
+                                                "type": "string"
+                                            },
+                                            "location": {
+                                                "description": "A geographical coordinate",
+                                                "properties": {
+                                                    "latitude": {
+                                                        "type": "number"
+                                                    },
+                                                    "longitude": {
+                                                        "type": "number"
+                                                    }
+                                                },
+                                                "type": "object"
+                                            }
+                                        },
+                                        "type": "object"
+                                    },
+                                    "temperature": {
+                                        "description": "Temperature of a container's contents in degrees Celsuis",
+                                        "type": "number"
+                                    }
+                                },
+                                "required": [
+                                    "barcode"
+                                ],
+                                "type": "object"
+                            }
+                        },
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
+                    "type": "array"
+                },
+                "function": {
+                    "enum": [
+                        "updateAssetContainer"
+                    ],
+                    "type": "string"
+                },
+                "method": "invoke"
+            },
+            "type": "object"
+        },
+        "updateAssetSurgicalKit": {
+            "description": "Update a contaner's state with one or more property changes (e.g. patch existing)",
+            "properties": {
+                "args": {
+                    "items": {
+                        "properties": {
+                            "surgicalkit": {
+                                "description": "The changeable properties for a surgicalkit, also considered its 'event' as a partial state",
+                                "properties": {
+                                    "common": {
+                                        "description": "Common properties for all assets",
+                                        "properties": {
+                                            "appdata": {
+                                                "description": "Application managed information as an array of key:value pairs",
+                                                "items": {
+                                                    "properties": {
+                                                        "K": {
+                                                            "type": "string"
+                                                        },
+                                                        "V": {
+                                                            "type": "string"
+                                                        }
+                                                    },
+                                                    "type": "object"
+                                                },
+                                                "minItems": 0,
+                                                "type": "array"
+                                            },
+                                            "deviceID": {
+                                                "description": "A unique identifier for the device that sent the current event",
+                                                "type": "string"
+                                            },
+                                            "devicetimestamp": {
+                                                "description": "A timestamp recoded by the device that sent the current event",
+                                                "type": "string"
+                                            },
+                                            "location": {
+                                                "description": "A geographical coordinate",
+                                                "properties": {
+                                                    "latitude": {
+                                                        "type": "number"
+                                                    },
+                                                    "longitude": {
+                                                        "type": "number"
+                                                    }
+                                                },
+                                                "type": "object"
+                                            }
+                                        },
+                                        "type": "object"
+                                    },
+                                    "hospital": {
+                                        "description": "the hospital within which the surgical kit is used, and within which it is geofenced",
+                                        "properties": {
+                                            "address": {
+                                                "properties": {
+                                                    "city": {
+                                                        "type": "string"
+                                                    },
+                                                    "country": {
+                                                        "type": "string"
+                                                    },
+                                                    "postcode": {
+                                                        "type": "string"
+                                                    },
+                                                    "streetandnumber": {
+                                                        "type": "string"
+                                                    }
+                                                },
+                                                "type": "object"
+                                            },
+                                            "fence": {
+                                                "properties": {
+                                                    "center": {
+                                                        "description": "A geographical coordinate",
+                                                        "properties": {
+                                                            "latitude": {
+                                                                "type": "number"
+                                                            },
+                                                            "longitude": {
+                                                                "type": "number"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    },
+                                                    "radius": {
+                                                        "type": "number"
+                                                    }
+                                                },
+                                                "type": "object"
+                                            },
+                                            "name": {
+                                                "type": "string"
+                                            }
+                                        },
+                                        "type": "object"
+                                    },
+                                    "sensors": {
+                                        "description": "sensor readings for the surgical kit",
+                                        "properties": {
+                                            "begin": {
+                                                "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                "format": "date-time",
+                                                "sample": "yyyy-mm-dd hh:mm:ss",
+                                                "type": "string"
+                                            },
+                                            "currtilt": {
+                                                "description": "The current tilt that the kit is experiencing",
+                                                "type": "number"
+                                            },
+                                            "end": {
+                                                "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                "format": "date-time",
+                                                "sample": "yyyy-mm-dd hh:mm:ss",
+                                                "type": "string"
+                                            },
                                             "endlocation": {
                                                 "description": "A geographical coordinate",
                                                 "properties": {
-                                                    "latitude": {
-                                                        "type": "number"
+                                                    "latitude": {
+                                                        "type": "number"
+                                                    },
+                                                    "longitude": {
+                                                        "type": "number"
+                                                    }
+                                                },
+                                                "type": "object"
+                                            },
+                                            "maxgforce": {
+                                                "description": "The highest (in Gs) force that the kit experienced during the sample",
+                                                "type": "number"
+                                            },
+                                            "maxtilt": {
+                                                "description": "The highest (in degrees from horizontal) tilt that the kit experienced during the sample",
+                                                "type": "number"
+                                            },
+                                            "startlocation": {
+                                                "description": "A geographical coordinate",
+                                                "properties": {
+                                                    "latitude": {
+                                                        "type": "number"
+                                                    },
+                                                    "longitude": {
+                                                        "type": "number"
+                                                    }
+                                                },
+                                                "type": "object"
+                                            }
+                                        },
+                                        "type": "object"
+                                    },
+                                    "skitID": {
+                                        "description": "A surgicalkit's ID",
+                                        "type": "string"
+                                    },
+                                    "status": {
+                                        "description": "current kit status as a named entity in possession of the kit",
+                                        "enum": [
+                                            "",
+                                            "oem",
+                                            "warehouse",
+                                            "dealer",
+                                            "retailer",
+                                            "hospital",
+                                            "scrapped"
+                                        ],
+                                        "type": "string"
+                                    },
+                                    "transit": {
+                                        "description": "shipping data during transit periods",
+                                        "properties": {
+                                            "begintransit": {
+                                                "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                "format": "date-time",
+                                                "sample": "yyyy-mm-dd hh:mm:ss",
+                                                "type": "string"
+                                            },
+                                            "carrier": {
+                                                "type": "string"
+                                            },
+                                            "endtransit": {
+                                                "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                "format": "date-time",
+                                                "sample": "yyyy-mm-dd hh:mm:ss",
+                                                "type": "string"
+                                            },
+                                            "receiver": {
+                                                "description": "current kit status as a named entity in possession of the kit",
+                                                "enum": [
+                                                    "",
+                                                    "oem",
+                                                    "warehouse",
+                                                    "dealer",
+                                                    "retailer",
+                                                    "hospital",
+                                                    "scrapped"
+                                                ],
+                                                "type": "string"
+                                            },
+                                            "shipper": {
+                                                "description": "current kit status as a named entity in possession of the kit",
+                                                "enum": [
+                                                    "",
+                                                    "oem",
+                                                    "warehouse",
+                                                    "dealer",
+                                                    "retailer",
+                                                    "hospital",
+                                                    "scrapped"
+                                                ],
+                                                "type": "string"
+                                            }
+                                        },
+                                        "type": "object"
+                                    }
+                                },
+                                "required": [
+                                    "skitID"
+                                ],
+                                "type": "object"
+                            }
+                        },
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
+                    "type": "array"
+                },
+                "function": {
+                    "enum": [
+                        "updateAssetSurgicalKit"
+                    ],
+                    "type": "string"
+                },
+                "method": "invoke"
+            },
+            "type": "object"
+        }
+    },
+    "Model": {
+        "container": {
+            "description": "The changeable properties for a container, also considered its 'event' as a partial state",
+            "properties": {
+                "barcode": {
+                    "description": "A container's ID",
+                    "type": "string"
+                },
+                "carrier": {
+                    "description": "The carrier in possession of this container",
+                    "type": "string"
+                },
+                "common": {
+                    "description": "Common properties for all assets",
+                    "properties": {
+                        "appdata": {
+                            "description": "Application managed information as an array of key:value pairs",
+                            "items": {
+                                "properties": {
+                                    "K": {
+                                        "type": "string"
+                                    },
+                                    "V": {
+                                        "type": "string"
+                                    }
+                                },
+                                "type": "object"
+                            },
+                            "minItems": 0,
+                            "type": "array"
+                        },
+                        "deviceID": {
+                            "description": "A unique identifier for the device that sent the current event",
+                            "type": "string"
+                        },
+                        "devicetimestamp": {
+                            "description": "A timestamp recoded by the device that sent the current event",
+                            "type": "string"
+                        },
+                        "location": {
+                            "description": "A geographical coordinate",
+                            "properties": {
+                                "latitude": {
+                                    "type": "number"
+                                },
+                                "longitude": {
+                                    "type": "number"
+                                }
+                            },
+                            "type": "object"
+                        }
+                    },
+                    "type": "object"
+                },
+                "temperature": {
+                    "description": "Temperature of a container's contents in degrees Celsuis",
+                    "type": "number"
+                }
+            },
+            "required": [
+                "barcode"
+            ],
+            "type": "object"
+        },
+        "containerstate": {
+            "description": "A container's complete state",
+            "properties": {
+                "AssetKey": {
+                    "description": "This container's world state container ID",
+                    "type": "string"
+                },
+                "alerts": {
+                    "description": "An array of alert names",
+                    "items": {
+                        "description": "An alert name",
+                        "type": "string"
+                    },
+                    "type": "array"
+                },
+                "assetIDpath": {
+                    "description": "Qualified property path to the container's ID, declared in the contract code",
+                    "type": "string"
+                },
+                "class": {
+                    "description": "The container's asset class",
+                    "type": "string"
+                },
+                "compliant": {
+                    "description": "This container has no active alerts",
+                    "type": "boolean"
+                },
+                "eventin": {
+                    "description": "The contract event that created this state, for example updateAssetContainer",
+                    "properties": {
+                        "container": {
+                            "description": "The changeable properties for a container, also considered its 'event' as a partial state",
+                            "properties": {
+                                "barcode": {
+                                    "description": "A container's ID",
+                                    "type": "string"
+                                },
+                                "carrier": {
+                                    "description": "The carrier in possession of this container",
+                                    "type": "string"
+                                },
+                                "common": {
+                                    "description": "Common properties for all assets",
+                                    "properties": {
+                                        "appdata": {
+                                            "description": "Application managed information as an array of key:value pairs",
+                                            "items": {
+                                                "properties": {
+                                                    "K": {
+                                                        "type": "string"
+                                                    },
+                                                    "V": {
+                                                        "type": "string"
+                                                    }
+                                                },
+                                                "type": "object"
+                                            },
+                                            "minItems": 0,
+                                            "type": "array"
+                                        },
+                                        "deviceID": {
+                                            "description": "A unique identifier for the device that sent the current event",
+                                            "type": "string"
+                                        },
+                                        "devicetimestamp": {
+                                            "description": "A timestamp recoded by the device that sent the current event",
+                                            "type": "string"
+                                        },
+                                        "location": {
+                                            "description": "A geographical coordinate",
+                                            "properties": {
+                                                "latitude": {
+                                                    "type": "number"
+                                                },
+                                                "longitude": {
+                                                    "type": "number"
+                                                }
+                                            },
+                                            "type": "object"
+                                        }
+                                    },
+                                    "type": "object"
+                                },
+                                "temperature": {
+                                    "description": "Temperature of a container's contents in degrees Celsuis",
+                                    "type": "number"
+                                }
+                            },
+                            "required": [
+                                "barcode"
+                            ],
+                            "type": "object"
+                        }
+                    },
+                    "type": "object"
+                },
+                "eventout": {
+                    "description": "The chaincode event emitted on invoke exit, if any",
+                    "properties": {
+                        "container": {
+                            "description": "A chaincode event defining the standard platform-generated result event for a contract invoke, contains an array of contributed results",
+                            "properties": {
+                                "name": {
+                                    "default": "EVT.IOTCP.INVOKE.RESULT",
+                                    "enum": [
+                                        "EVT.IOTCP.INVOKE.RESULT"
+                                    ],
+                                    "type": "string"
+                                },
+                                "payload": {
+                                    "description": "A map of contributed results",
+                                    "properties": {
+                                        "description": "the overall status of the invoke result, defined by err",
+                                        "properties": {
+                                            "activeAlerts": {
+                                                "description": "An array of alert names",
+                                                "items": {
+                                                    "description": "An alert name",
+                                                    "type": "string"
+                                                },
+                                                "type": "array"
+                                            },
+                                            "alertsCleared": {
+                                                "description": "An array of alert names",
+                                                "items": {
+                                                    "description": "An alert name",
+                                                    "type": "string"
+                                                },
+                                                "type": "array"
+                                            },
+                                            "alertsRaised": {
+                                                "description": "An array of alert names",
+                                                "items": {
+                                                    "description": "An alert name",
+                                                    "type": "string"
+                                                },
+                                                "type": "array"
+                                            },
+                                            "invokeresult": {
+                                                "description": "status: OK==txn succeeded, ERROR==txn failed",
+                                                "properties": {
+                                                    "message": {
+                                                        "type": "string"
+                                                    },
+                                                    "status": {
+                                                        "enum": [
+                                                            "OK",
+                                                            "ERROR"
+                                                        ],
+                                                        "type": "string"
+                                                    }
+                                                },
+                                                "type": "object"
+                                            }
+                                        },
+                                        "type": "object"
+                                    },
+                                    "type": "object"
+                                }
+                            },
+                            "type": "object"
+                        }
+                    },
+                    "type": "object"
+                },
+                "prefix": {
+                    "description": "The container's asset class prefix in world state",
+                    "type": "string"
+                },
+                "state": {
+                    "description": "Properties that have been received or calculated for this container",
+                    "properties": {
+                        "container": {
+                            "description": "The changeable properties for a container, also considered its 'event' as a partial state",
+                            "properties": {
+                                "barcode": {
+                                    "description": "A container's ID",
+                                    "type": "string"
+                                },
+                                "carrier": {
+                                    "description": "The carrier in possession of this container",
+                                    "type": "string"
+                                },
+                                "common": {
+                                    "description": "Common properties for all assets",
+                                    "properties": {
+                                        "appdata": {
+                                            "description": "Application managed information as an array of key:value pairs",
+                                            "items": {
+                                                "properties": {
+                                                    "K": {
+                                                        "type": "string"
+                                                    },
+                                                    "V": {
+                                                        "type": "string"
+                                                    }
+                                                },
+                                                "type": "object"
+                                            },
+                                            "minItems": 0,
+                                            "type": "array"
+                                        },
+                                        "deviceID": {
+                                            "description": "A unique identifier for the device that sent the current event",
+                                            "type": "string"
+                                        },
+                                        "devicetimestamp": {
+                                            "description": "A timestamp recoded by the device that sent the current event",
+                                            "type": "string"
+                                        },
+                                        "location": {
+                                            "description": "A geographical coordinate",
+                                            "properties": {
+                                                "latitude": {
+                                                    "type": "number"
+                                                },
+                                                "longitude": {
+                                                    "type": "number"
+                                                }
+                                            },
+                                            "type": "object"
+                                        }
+                                    },
+                                    "type": "object"
+                                },
+                                "temperature": {
+                                    "description": "Temperature of a container's contents in degrees Celsuis",
+                                    "type": "number"
+                                }
+                            },
+                            "required": [
+                                "barcode"
+                            ],
+                            "type": "object"
+                        }
+                    },
+                    "type": "object"
+                },
+                "txnid": {
+                    "description": "Transaction UUID matching the blockchain",
+                    "type": "string"
+                },
+                "txnts": {
+                    "description": "Transaction timestamp matching the blockchain",
+                    "type": "string"
+                }
+            },
+            "type": "object"
+        },
+        "containerstatearray": {
+            "description": "Array of container states, can mix asset classes",
+            "items": {
+                "patternProperties": {
+                    "^CON": {
+                        "description": "A container's complete state",
+                        "properties": {
+                            "AssetKey": {
+                                "description": "This container's world state container ID",
+                                "type": "string"
+                            },
+                            "alerts": {
+                                "description": "An array of alert names",
+                                "items": {
+                                    "description": "An alert name",
+                                    "type": "string"
+                                },
+                                "type": "array"
+                            },
+                            "assetIDpath": {
+                                "description": "Qualified property path to the container's ID, declared in the contract code",
+                                "type": "string"
+                            },
+                            "class": {
+                                "description": "The container's asset class",
+                                "type": "string"
+                            },
+                            "compliant": {
+                                "description": "This container has no active alerts",
+                                "type": "boolean"
+                            },
+                            "eventin": {
+                                "description": "The contract event that created this state, for example updateAssetContainer",
+                                "properties": {
+                                    "container": {
+                                        "description": "The changeable properties for a container, also considered its 'event' as a partial state",
+                                        "properties": {
+                                            "barcode": {
+                                                "description": "A container's ID",
+                                                "type": "string"
+                                            },
+                                            "carrier": {
+                                                "description": "The carrier in possession of this container",
+                                                "type": "string"
+                                            },
+                                            "common": {
+                                                "description": "Common properties for all assets",
+                                                "properties": {
+                                                    "appdata": {
+                                                        "description": "Application managed information as an array of key:value pairs",
+                                                        "items": {
+                                                            "properties": {
+                                                                "K": {
+                                                                    "type": "string"
+                                                                },
+                                                                "V": {
+                                                                    "type": "string"
+                                                                }
+                                                            },
+                                                            "type": "object"
+                                                        },
+                                                        "minItems": 0,
+                                                        "type": "array"
+                                                    },
+                                                    "deviceID": {
+                                                        "description": "A unique identifier for the device that sent the current event",
+                                                        "type": "string"
+                                                    },
+                                                    "devicetimestamp": {
+                                                        "description": "A timestamp recoded by the device that sent the current event",
+                                                        "type": "string"
+                                                    },
+                                                    "location": {
+                                                        "description": "A geographical coordinate",
+                                                        "properties": {
+                                                            "latitude": {
+                                                                "type": "number"
+                                                            },
+                                                            "longitude": {
+                                                                "type": "number"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    }
+                                                },
+                                                "type": "object"
+                                            },
+                                            "temperature": {
+                                                "description": "Temperature of a container's contents in degrees Celsuis",
+                                                "type": "number"
+                                            }
+                                        },
+                                        "required": [
+                                            "barcode"
+                                        ],
+                                        "type": "object"
+                                    }
+                                },
+                                "type": "object"
+                            },
+                            "eventout": {
+                                "description": "The chaincode event emitted on invoke exit, if any",
+                                "properties": {
+                                    "container": {
+                                        "description": "A chaincode event defining the standard platform-generated result event for a contract invoke, contains an array of contributed results",
+                                        "properties": {
+                                            "name": {
+                                                "default": "EVT.IOTCP.INVOKE.RESULT",
+                                                "enum": [
+                                                    "EVT.IOTCP.INVOKE.RESULT"
+                                                ],
+                                                "type": "string"
+                                            },
+                                            "payload": {
+                                                "description": "A map of contributed results",
+                                                "properties": {
+                                                    "description": "the overall status of the invoke result, defined by err",
+                                                    "properties": {
+                                                        "activeAlerts": {
+                                                            "description": "An array of alert names",
+                                                            "items": {
+                                                                "description": "An alert name",
+                                                                "type": "string"
+                                                            },
+                                                            "type": "array"
+                                                        },
+                                                        "alertsCleared": {
+                                                            "description": "An array of alert names",
+                                                            "items": {
+                                                                "description": "An alert name",
+                                                                "type": "string"
+                                                            },
+                                                            "type": "array"
+                                                        },
+                                                        "alertsRaised": {
+                                                            "description": "An array of alert names",
+                                                            "items": {
+                                                                "description": "An alert name",
+                                                                "type": "string"
+                                                            },
+                                                            "type": "array"
+                                                        },
+                                                        "invokeresult": {
+                                                            "description": "status: OK==txn succeeded, ERROR==txn failed",
+                                                            "properties": {
+                                                                "message": {
+                                                                    "type": "string"
+                                                                },
+                                                                "status": {
+                                                                    "enum": [
+                                                                        "OK",
+                                                                        "ERROR"
+                                                                    ],
+                                                                    "type": "string"
+                                                                }
+                                                            },
+                                                            "type": "object"
+                                                        }
+                                                    },
+                                                    "type": "object"
+                                                },
+                                                "type": "object"
+                                            }
+                                        },
+                                        "type": "object"
+                                    }
+                                },
+                                "type": "object"
+                            },
+                            "prefix": {
+                                "description": "The container's asset class prefix in world state",
+                                "type": "string"
+                            },
+                            "state": {
+                                "description": "Properties that have been received or calculated for this container",
+                                "properties": {
+                                    "container": {
+                                        "description": "The changeable properties for a container, also considered its 'event' as a partial state",
+                                        "properties": {
+                                            "barcode": {
+                                                "description": "A container's ID",
+                                                "type": "string"
+                                            },
+                                            "carrier": {
+                                                "description": "The carrier in possession of this container",
+                                                "type": "string"
+                                            },
+                                            "common": {
+                                                "description": "Common properties for all assets",
+                                                "properties": {
+                                                    "appdata": {
+                                                        "description": "Application managed information as an array of key:value pairs",
+                                                        "items": {
+                                                            "properties": {
+                                                                "K": {
+                                                                    "type": "string"
+                                                                },
+                                                                "V": {
+                                                                    "type": "string"
+                                                                }
+                                                            },
+                                                            "type": "object"
+                                                        },
+                                                        "minItems": 0,
+                                                        "type": "array"
+                                                    },
+                                                    "deviceID": {
+                                                        "description": "A unique identifier for the device that sent the current event",
+                                                        "type": "string"
+                                                    },
+                                                    "devicetimestamp": {
+                                                        "description": "A timestamp recoded by the device that sent the current event",
+                                                        "type": "string"
+                                                    },
+                                                    "location": {
+                                                        "description": "A geographical coordinate",
+                                                        "properties": {
+                                                            "latitude": {
+                                                                "type": "number"
+                                                            },
+                                                            "longitude": {
+                                                                "type": "number"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    }
+                                                },
+                                                "type": "object"
+                                            },
+                                            "temperature": {
+                                                "description": "Temperature of a container's contents in degrees Celsuis",
+                                                "type": "number"
+                                            }
+                                        },
+                                        "required": [
+                                            "barcode"
+                                        ],
+                                        "type": "object"
+                                    }
+                                },
+                                "type": "object"
+                            },
+                            "txnid": {
+                                "description": "Transaction UUID matching the blockchain",
+                                "type": "string"
+                            },
+                            "txnts": {
+                                "description": "Transaction timestamp matching the blockchain",
+                                "type": "string"
+                            }
+                        },
+                        "type": "object"
+                    }
+                },
+                "type": "object"
+            },
+            "minItems": 0,
+            "type": "array"
+        },
+        "containerstateexternal": {
+            "patternProperties": {
+                "^CON": {
+                    "description": "A container's complete state",
+                    "properties": {
+                        "AssetKey": {
+                            "description": "This container's world state container ID",
+                            "type": "string"
+                        },
+                        "alerts": {
+                            "description": "An array of alert names",
+                            "items": {
+                                "description": "An alert name",
+                                "type": "string"
+                            },
+                            "type": "array"
+                        },
+                        "assetIDpath": {
+                            "description": "Qualified property path to the container's ID, declared in the contract code",
+                            "type": "string"
+                        },
+                        "class": {
+                            "description": "The container's asset class",
+                            "type": "string"
+                        },
+                        "compliant": {
+                            "description": "This container has no active alerts",
+                            "type": "boolean"
+                        },
+                        "eventin": {
+                            "description": "The contract event that created this state, for example updateAssetContainer",
+                            "properties": {
+                                "container": {
+                                    "description": "The changeable properties for a container, also considered its 'event' as a partial state",
+                                    "properties": {
+                                        "barcode": {
+                                            "description": "A container's ID",
+                                            "type": "string"
+                                        },
+                                        "carrier": {
+                                            "description": "The carrier in possession of this container",
+                                            "type": "string"
+                                        },
+                                        "common": {
+                                            "description": "Common properties for all assets",
+                                            "properties": {
+                                                "appdata": {
+                                                    "description": "Application managed information as an array of key:value pairs",
+                                                    "items": {
+                                                        "properties": {
+                                                            "K": {
+                                                                "type": "string"
+                                                            },
+                                                            "V": {
+                                                                "type": "string"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    },
+                                                    "minItems": 0,
+                                                    "type": "array"
+                                                },
+                                                "deviceID": {
+                                                    "description": "A unique identifier for the device that sent the current event",
+                                                    "type": "string"
+                                                },
+                                                "devicetimestamp": {
+                                                    "description": "A timestamp recoded by the device that sent the current event",
+                                                    "type": "string"
+                                                },
+                                                "location": {
+                                                    "description": "A geographical coordinate",
+                                                    "properties": {
+                                                        "latitude": {
+                                                            "type": "number"
+                                                        },
+                                                        "longitude": {
+                                                            "type": "number"
+                                                        }
+                                                    },
+                                                    "type": "object"
+                                                }
+                                            },
+                                            "type": "object"
+                                        },
+                                        "temperature": {
+                                            "description": "Temperature of a container's contents in degrees Celsuis",
+                                            "type": "number"
+                                        }
+                                    },
+                                    "required": [
+                                        "barcode"
+                                    ],
+                                    "type": "object"
+                                }
+                            },
+                            "type": "object"
+                        },
+                        "eventout": {
+                            "description": "The chaincode event emitted on invoke exit, if any",
+                            "properties": {
+                                "container": {
+                                    "description": "A chaincode event defining the standard platform-generated result event for a contract invoke, contains an array of contributed results",
+                                    "properties": {
+                                        "name": {
+                                            "default": "EVT.IOTCP.INVOKE.RESULT",
+                                            "enum": [
+                                                "EVT.IOTCP.INVOKE.RESULT"
+                                            ],
+                                            "type": "string"
+                                        },
+                                        "payload": {
+                                            "description": "A map of contributed results",
+                                            "properties": {
+                                                "description": "the overall status of the invoke result, defined by err",
+                                                "properties": {
+                                                    "activeAlerts": {
+                                                        "description": "An array of alert names",
+                                                        "items": {
+                                                            "description": "An alert name",
+                                                            "type": "string"
+                                                        },
+                                                        "type": "array"
+                                                    },
+                                                    "alertsCleared": {
+                                                        "description": "An array of alert names",
+                                                        "items": {
+                                                            "description": "An alert name",
+                                                            "type": "string"
+                                                        },
+                                                        "type": "array"
+                                                    },
+                                                    "alertsRaised": {
+                                                        "description": "An array of alert names",
+                                                        "items": {
+                                                            "description": "An alert name",
+                                                            "type": "string"
+                                                        },
+                                                        "type": "array"
+                                                    },
+                                                    "invokeresult": {
+                                                        "description": "status: OK==txn succeeded, ERROR==txn failed",
+                                                        "properties": {
+                                                            "message": {
+                                                                "type": "string"
+                                                            },
+                                                            "status": {
+                                                                "enum": [
+                                                                    "OK",
+                                                                    "ERROR"
+                                                                ],
+                                                                "type": "string"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    }
+                                                },
+                                                "type": "object"
+                                            },
+                                            "type": "object"
+                                        }
+                                    },
+                                    "type": "object"
+                                }
+                            },
+                            "type": "object"
+                        },
+                        "prefix": {
+                            "description": "The container's asset class prefix in world state",
+                            "type": "string"
+                        },
+                        "state": {
+                            "description": "Properties that have been received or calculated for this container",
+                            "properties": {
+                                "container": {
+                                    "description": "The changeable properties for a container, also considered its 'event' as a partial state",
+                                    "properties": {
+                                        "barcode": {
+                                            "description": "A container's ID",
+                                            "type": "string"
+                                        },
+                                        "carrier": {
+                                            "description": "The carrier in possession of this container",
+                                            "type": "string"
+                                        },
+                                        "common": {
+                                            "description": "Common properties for all assets",
+                                            "properties": {
+                                                "appdata": {
+                                                    "description": "Application managed information as an array of key:value pairs",
+                                                    "items": {
+                                                        "properties": {
+                                                            "K": {
+                                                                "type": "string"
+                                                            },
+                                                            "V": {
+                                                                "type": "string"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    },
+                                                    "minItems": 0,
+                                                    "type": "array"
+                                                },
+                                                "deviceID": {
+                                                    "description": "A unique identifier for the device that sent the current event",
+                                                    "type": "string"
+                                                },
+                                                "devicetimestamp": {
+                                                    "description": "A timestamp recoded by the device that sent the current event",
+                                                    "type": "string"
+                                                },
+                                                "location": {
+                                                    "description": "A geographical coordinate",
+                                                    "properties": {
+                                                        "latitude": {
+                                                            "type": "number"
+                                                        },
+                                                        "longitude": {
+                                                            "type": "number"
+                                                        }
+                                                    },
+                                                    "type": "object"
+                                                }
+                                            },
+                                            "type": "object"
+                                        },
+                                        "temperature": {
+                                            "description": "Temperature of a container's contents in degrees Celsuis",
+                                            "type": "number"
+                                        }
+                                    },
+                                    "required": [
+                                        "barcode"
+                                    ],
+                                    "type": "object"
+                                }
+                            },
+                            "type": "object"
+                        },
+                        "txnid": {
+                            "description": "Transaction UUID matching the blockchain",
+                            "type": "string"
+                        },
+                        "txnts": {
+                            "description": "Transaction timestamp matching the blockchain",
+                            "type": "string"
+                        }
+                    },
+                    "type": "object"
+                }
+            },
+            "type": "object"
+        },
+        "eventIOTContractPlatformInvokeResult": {
+            "description": "A chaincode event defining the standard platform-generated result event for a contract invoke, contains an array of contributed results",
+            "properties": {
+                "name": {
+                    "default": "EVT.IOTCP.INVOKE.RESULT",
+                    "enum": [
+                        "EVT.IOTCP.INVOKE.RESULT"
+                    ],
+                    "type": "string"
+                },
+                "payload": {
+                    "description": "A map of contributed results",
+                    "properties": {
+                        "description": "the overall status of the invoke result, defined by err",
+                        "properties": {
+                            "activeAlerts": {
+                                "description": "An array of alert names",
+                                "items": {
+                                    "description": "An alert name",
+                                    "type": "string"
+                                },
+                                "type": "array"
+                            },
+                            "alertsCleared": {
+                                "description": "An array of alert names",
+                                "items": {
+                                    "description": "An alert name",
+                                    "type": "string"
+                                },
+                                "type": "array"
+                            },
+                            "alertsRaised": {
+                                "description": "An array of alert names",
+                                "items": {
+                                    "description": "An alert name",
+                                    "type": "string"
+                                },
+                                "type": "array"
+                            },
+                            "invokeresult": {
+                                "description": "status: OK==txn succeeded, ERROR==txn failed",
+                                "properties": {
+                                    "message": {
+                                        "type": "string"
+                                    },
+                                    "status": {
+                                        "enum": [
+                                            "OK",
+                                            "ERROR"
+                                        ],
+                                        "type": "string"
+                                    }
+                                },
+                                "type": "object"
+                            }
+                        },
+                        "type": "object"
+                    },
+                    "type": "object"
+                }
+            },
+            "type": "object"
+        },
+        "ioteventcommon": {
+            "description": "Common properties for all assets",
+            "properties": {
+                "appdata": {
+                    "description": "Application managed information as an array of key:value pairs",
+                    "items": {
+                        "properties": {
+                            "K": {
+                                "type": "string"
+                            },
+                            "V": {
+                                "type": "string"
+                            }
+                        },
+                        "type": "object"
+                    },
+                    "minItems": 0,
+                    "type": "array"
+                },
+                "deviceID": {
+                    "description": "A unique identifier for the device that sent the current event",
+                    "type": "string"
+                },
+                "devicetimestamp": {
+                    "description": "A timestamp recoded by the device that sent the current event",
+                    "type": "string"
+                },
+                "location": {
+                    "description": "A geographical coordinate",
+                    "properties": {
+                        "latitude": {
+                            "type": "number"
+                        },
+                        "longitude": {
+                            "type": "number"
+                        }
+                    },
+                    "type": "object"
+                }
+            },
+            "type": "object"
+        },
+        "stateFilter": {
+            "description": "Filter asset states",
+            "properties": {
+                "match": {
+                    "description": "Defines how to match properties, missing property always fails match",
+                    "enum": [
+                        "n/a",
+                        "all",
+                        "any",
+                        "none"
+                    ],
+                    "type": "string"
+                },
+                "select": {
+                    "description": "Qualified property names and values match",
+                    "items": {
+                        "properties": {
+                            "qprop": {
+                                "description": "Qualified property to compare, for example 'asset.assetID'",
+                                "type": "string"
+                            },
+                            "value": {
+                                "description": "Value to be compared",
+                                "type": "string"
+                            }
+                        },
+                        "type": "object"
+                    },
+                    "type": "array"
+                }
+            },
+            "type": "object"
+        },
+        "surgicalkit": {
+            "description": "The changeable properties for a surgicalkit, also considered its 'event' as a partial state",
+            "properties": {
+                "common": {
+                    "description": "Common properties for all assets",
+                    "properties": {
+                        "appdata": {
+                            "description": "Application managed information as an array of key:value pairs",
+                            "items": {
+                                "properties": {
+                                    "K": {
+                                        "type": "string"
+                                    },
+                                    "V": {
+                                        "type": "string"
+                                    }
+                                },
+                                "type": "object"
+                            },
+                            "minItems": 0,
+                            "type": "array"
+                        },
+                        "deviceID": {
+                            "description": "A unique identifier for the device that sent the current event",
+                            "type": "string"
+                        },
+                        "devicetimestamp": {
+                            "description": "A timestamp recoded by the device that sent the current event",
+                            "type": "string"
+                        },
+                        "location": {
+                            "description": "A geographical coordinate",
+                            "properties": {
+                                "latitude": {
+                                    "type": "number"
+                                },
+                                "longitude": {
+                                    "type": "number"
+                                }
+                            },
+                            "type": "object"
+                        }
+                    },
+                    "type": "object"
+                },
+                "hospital": {
+                    "description": "the hospital within which the surgical kit is used, and within which it is geofenced",
+                    "properties": {
+                        "address": {
+                            "properties": {
+                                "city": {
+                                    "type": "string"
+                                },
+                                "country": {
+                                    "type": "string"
+                                },
+                                "postcode": {
+                                    "type": "string"
+                                },
+                                "streetandnumber": {
+                                    "type": "string"
+                                }
+                            },
+                            "type": "object"
+                        },
+                        "fence": {
+                            "properties": {
+                                "center": {
+                                    "description": "A geographical coordinate",
+                                    "properties": {
+                                        "latitude": {
+                                            "type": "number"
+                                        },
+                                        "longitude": {
+                                            "type": "number"
+                                        }
+                                    },
+                                    "type": "object"
+                                },
+                                "radius": {
+                                    "type": "number"
+                                }
+                            },
+                            "type": "object"
+                        },
+                        "name": {
+                            "type": "string"
+                        }
+                    },
+                    "type": "object"
+                },
+                "sensors": {
+                    "description": "sensor readings for the surgical kit",
+                    "properties": {
+                        "begin": {
+                            "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                            "format": "date-time",
+                            "sample": "yyyy-mm-dd hh:mm:ss",
+                            "type": "string"
+                        },
+                        "currtilt": {
+                            "description": "The current tilt that the kit is experiencing",
+                            "type": "number"
+                        },
+                        "end": {
+                            "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                            "format": "date-time",
+                            "sample": "yyyy-mm-dd hh:mm:ss",
+                            "type": "string"
+                        },
+                        "endlocation": {
+                            "description": "A geographical coordinate",
+                            "properties": {
+                                "latitude": {
+                                    "type": "number"
+                                },
+                                "longitude": {
+                                    "type": "number"
+                                }
+                            },
+                            "type": "object"
+                        },
+                        "maxgforce": {
+                            "description": "The highest (in Gs) force that the kit experienced during the sample",
+                            "type": "number"
+                        },
+                        "maxtilt": {
+                            "description": "The highest (in degrees from horizontal) tilt that the kit experienced during the sample",
+                            "type": "number"
+                        },
+                        "startlocation": {
+                            "description": "A geographical coordinate",
+                            "properties": {
+                                "latitude": {
+                                    "type": "number"
+                                },
+                                "longitude": {
+                                    "type": "number"
+                                }
+                            },
+                            "type": "object"
+                        }
+                    },
+                    "type": "object"
+                },
+                "skitID": {
+                    "description": "A surgicalkit's ID",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "current kit status as a named entity in possession of the kit",
+                    "enum": [
+                        "",
+                        "oem",
+                        "warehouse",
+                        "dealer",
+                        "retailer",
+                        "hospital",
+                        "scrapped"
+                    ],
+                    "type": "string"
+                },
+                "transit": {
+                    "description": "shipping data during transit periods",
+                    "properties": {
+                        "begintransit": {
+                            "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                            "format": "date-time",
+                            "sample": "yyyy-mm-dd hh:mm:ss",
+                            "type": "string"
+                        },
+                        "carrier": {
+                            "type": "string"
+                        },
+                        "endtransit": {
+                            "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                            "format": "date-time",
+                            "sample": "yyyy-mm-dd hh:mm:ss",
+                            "type": "string"
+                        },
+                        "receiver": {
+                            "description": "current kit status as a named entity in possession of the kit",
+                            "enum": [
+                                "",
+                                "oem",
+                                "warehouse",
+                                "dealer",
+                                "retailer",
+                                "hospital",
+                                "scrapped"
+                            ],
+                            "type": "string"
+                        },
+                        "shipper": {
+                            "description": "current kit status as a named entity in possession of the kit",
+                            "enum": [
+                                "",
+                                "oem",
+                                "warehouse",
+                                "dealer",
+                                "retailer",
+                                "hospital",
+                                "scrapped"
+                            ],
+                            "type": "string"
+                        }
+                    },
+                    "type": "object"
+                }
+            },
+            "required": [
+                "skitID"
+            ],
+            "type": "object"
+        },
+        "surgicalkitstate": {
+            "description": "A surgicalkit's complete state",
+            "properties": {
+                "AssetKey": {
+                    "description": "This surgicalkit's world state surgicalkit ID",
+                    "type": "string"
+                },
+                "alerts": {
+                    "description": "An array of alert names",
+                    "items": {
+                        "description": "An alert name",
+                        "type": "string"
+                    },
+                    "type": "array"
+                },
+                "class": {
+                    "description": "An asset's classifier definition",
+                    "properties": {
+                        "assetidpath": "An asset's primary key, expressed as a qualified property path (see example contracts)",
+                        "name": "An asset's class name",
+                        "prefix": "An asset's world state prefix, used to allow iteration over all assets of a class"
+                    },
+                    "type": "object"
+                },
+                "compliant": {
+                    "description": "This surgicalkit has no active alerts",
+                    "type": "boolean"
+                },
+                "eventin": {
+                    "description": "The contract event that created this state, for example updateAssetSurgicalKit",
+                    "properties": {
+                        "surgicalkit": {
+                            "description": "The changeable properties for a surgicalkit, also considered its 'event' as a partial state",
+                            "properties": {
+                                "common": {
+                                    "description": "Common properties for all assets",
+                                    "properties": {
+                                        "appdata": {
+                                            "description": "Application managed information as an array of key:value pairs",
+                                            "items": {
+                                                "properties": {
+                                                    "K": {
+                                                        "type": "string"
                                                     },
-                                                    "longitude": {
-                                                        "type": "number"
+                                                    "V": {
+                                                        "type": "string"
                                                     }
                                                 },
                                                 "type": "object"
                                             },
-                                            "maxgforce": {
-                                                "description": "The highest (in Gs) force that the kit experienced during the sample",
-                                                "type": "number"
+                                            "minItems": 0,
+                                            "type": "array"
+                                        },
+                                        "deviceID": {
+                                            "description": "A unique identifier for the device that sent the current event",
+                                            "type": "string"
+                                        },
+                                        "devicetimestamp": {
+                                            "description": "A timestamp recoded by the device that sent the current event",
+                                            "type": "string"
+                                        },
+                                        "location": {
+                                            "description": "A geographical coordinate",
+                                            "properties": {
+                                                "latitude": {
+                                                    "type": "number"
+                                                },
+                                                "longitude": {
+                                                    "type": "number"
+                                                }
                                             },
-                                            "maxtilt": {
-                                                "description": "The highest (in degrees from horizontal) tilt that the kit experienced during the sample",
-                                                "type": "number"
+                                            "type": "object"
+                                        }
+                                    },
+                                    "type": "object"
+                                },
+                                "hospital": {
+                                    "description": "the hospital within which the surgical kit is used, and within which it is geofenced",
+                                    "properties": {
+                                        "address": {
+                                            "properties": {
+                                                "city": {
+                                                    "type": "string"
+                                                },
+                                                "country": {
+                                                    "type": "string"
+                                                },
+                                                "postcode": {
+                                                    "type": "string"
+                                                },
+                                                "streetandnumber": {
+                                                    "type": "string"
+                                                }
                                             },
-                                            "startlocation": {
-                                                "description": "A geographical coordinate",
-                                                "properties": {
-                                                    "latitude": {
-                                                        "type": "number"
+                                            "type": "object"
+                                        },
+                                        "fence": {
+                                            "properties": {
+                                                "center": {
+                                                    "description": "A geographical coordinate",
+                                                    "properties": {
+                                                        "latitude": {
+                                                            "type": "number"
+                                                        },
+                                                        "longitude": {
+                                                            "type": "number"
+                                                        }
                                                     },
-                                                    "longitude": {
-                                                        "type": "number"
-                                                    }
+                                                    "type": "object"
                                                 },
-                                                "type": "object"
-                                            }
+                                                "radius": {
+                                                    "type": "number"
+                                                }
+                                            },
+                                            "type": "object"
                                         },
-                                        "type": "object"
-                                    },
-                                    "skitID": {
-                                        "description": "A surgicalkit's ID",
-                                        "type": "string"
-                                    },
-                                    "status": {
-                                        "description": "current kit status as a named entity in possession of the kit",
-                                        "enum": [
-                                            "",
-                                            "oem",
-                                            "warehouse",
-                                            "dealer",
-                                            "retailer",
-                                            "hospital",
-                                            "scrapped"
-                                        ],
-                                        "type": "string"
+                                        "name": {
+                                            "type": "string"
+                                        }
                                     },
-                                    "transit": {
-                                        "description": "shipping data during transit periods",
-                                        "properties": {
-                                            "begintransit": {
-                                                "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
-                                                "format": "date-time",
-                                                "sample": "yyyy-mm-dd hh:mm:ss",
-                                                "type": "string"
-                                            },
-                                            "carrier": {
-                                                "type": "string"
-                                            },
-                                            "endtransit": {
-                                                "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
-                                                "format": "date-time",
-                                                "sample": "yyyy-mm-dd hh:mm:ss",
-                                                "type": "string"
-                                            },
-                                            "receiver": {
-                                                "description": "current kit status as a named entity in possession of the kit",
-                                                "enum": [
-                                                    "",
-                                                    "oem",
-                                                    "warehouse",
-                                                    "dealer",
-                                                    "retailer",
-                                                    "hospital",
-                                                    "scrapped"
-                                                ],
-                                                "type": "string"
-                                            },
-                                            "shipper": {
-                                                "description": "current kit status as a named entity in possession of the kit",
-                                                "enum": [
-                                                    "",
-                                                    "oem",
-                                                    "warehouse",
-                                                    "dealer",
-                                                    "retailer",
-                                                    "hospital",
-                                                    "scrapped"
-                                                ],
-                                                "type": "string"
-                                            }
+                                    "type": "object"
+                                },
+                                "sensors": {
+                                    "description": "sensor readings for the surgical kit",
+                                    "properties": {
+                                        "begin": {
+                                            "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                            "format": "date-time",
+                                            "sample": "yyyy-mm-dd hh:mm:ss",
+                                            "type": "string"
+                                        },
+                                        "currtilt": {
+                                            "description": "The current tilt that the kit is experiencing",
+                                            "type": "number"
+                                        },
+                                        "end": {
+                                            "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                            "format": "date-time",
+                                            "sample": "yyyy-mm-dd hh:mm:ss",
+                                            "type": "string"
+                                        },
+                                        "endlocation": {
+                                            "description": "A geographical coordinate",
+                                            "properties": {
+                                                "latitude": {
+                                                    "type": "number"
+                                                },
+                                                "longitude": {
+                                                    "type": "number"
+                                                }
+                                            },
+                                            "type": "object"
                                         },
-                                        "type": "object"
-                                    }
+                                        "maxgforce": {
+                                            "description": "The highest (in Gs) force that the kit experienced during the sample",
+                                            "type": "number"
+                                        },
+                                        "maxtilt": {
+                                            "description": "The highest (in degrees from horizontal) tilt that the kit experienced during the sample",
+                                            "type": "number"
+                                        },
+                                        "startlocation": {
+                                            "description": "A geographical coordinate",
+                                            "properties": {
+                                                "latitude": {
+                                                    "type": "number"
+                                                },
+                                                "longitude": {
+                                                    "type": "number"
+                                                }
+                                            },
+                                            "type": "object"
+                                        }
+                                    },
+                                    "type": "object"
                                 },
-                                "required": [
-                                    "skitID"
-                                ],
-                                "type": "object"
-                            }
-                        },
-                        "type": "object"
-                    },
-                    "maxItems": 1,
-                    "minItems": 1,
-                    "type": "array"
-                },
-                "function": {
-                    "enum": [
-                        "updateAssetSurgicalKit"
-                    ],
-                    "type": "string"
-                },
-                "method": "invoke"
-            },
-            "type": "object"
-        }
-    },
-    "Model": {
-        "invokeevent": {
-            "description": "An chaincode event emitted by a contract invoke",
-            "properties": {
-                "name": {
-                    "description": "The chaincode event's name",
-                    "type": "string"
-                },
-                "payload": {
-                    "description": "The chaincode event's properties",
-                    "properties": {},
-                    "type": "object"
-                }
-            },
-            "type": "object"
-        },
-        "ioteventcommon": {
-            "description": "Common properties for all assets",
-            "properties": {
-                "appdata": {
-                    "description": "Application managed information as an array of key:value pairs",
-                    "items": {
-                        "properties": {
-                            "K": {
-                                "type": "string"
+                                "skitID": {
+                                    "description": "A surgicalkit's ID",
+                                    "type": "string"
+                                },
+                                "status": {
+                                    "description": "current kit status as a named entity in possession of the kit",
+                                    "enum": [
+                                        "",
+                                        "oem",
+                                        "warehouse",
+                                        "dealer",
+                                        "retailer",
+                                        "hospital",
+                                        "scrapped"
+                                    ],
+                                    "type": "string"
+                                },
+                                "transit": {
+                                    "description": "shipping data during transit periods",
+                                    "properties": {
+                                        "begintransit": {
+                                            "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                            "format": "date-time",
+                                            "sample": "yyyy-mm-dd hh:mm:ss",
+                                            "type": "string"
+                                        },
+                                        "carrier": {
+                                            "type": "string"
+                                        },
+                                        "endtransit": {
+                                            "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                            "format": "date-time",
+                                            "sample": "yyyy-mm-dd hh:mm:ss",
+                                            "type": "string"
+                                        },
+                                        "receiver": {
+                                            "description": "current kit status as a named entity in possession of the kit",
+                                            "enum": [
+                                                "",
+                                                "oem",
+                                                "warehouse",
+                                                "dealer",
+                                                "retailer",
+                                                "hospital",
+                                                "scrapped"
+                                            ],
+                                            "type": "string"
+                                        },
+                                        "shipper": {
+                                            "description": "current kit status as a named entity in possession of the kit",
+                                            "enum": [
+                                                "",
+                                                "oem",
+                                                "warehouse",
+                                                "dealer",
+                                                "retailer",
+                                                "hospital",
+                                                "scrapped"
+                                            ],
+                                            "type": "string"
+                                        }
+                                    },
+                                    "type": "object"
+                                }
                             },
-                            "V": {
-                                "type": "string"
-                            }
-                        },
-                        "type": "object"
-                    },
-                    "minItems": 0,
-                    "type": "array"
-                },
-                "deviceID": {
-                    "description": "A unique identifier for the device that sent the current event",
-                    "type": "string"
-                },
-                "devicetimestamp": {
-                    "description": "A timestamp recoded by the device that sent the current event",
-                    "type": "string"
-                },
-                "location": {
-                    "description": "A geographical coordinate",
-                    "properties": {
-                        "latitude": {
-                            "type": "number"
-                        },
-                        "longitude": {
-                            "type": "number"
+                            "required": [
+                                "skitID"
+                            ],
+                            "type": "object"
                         }
                     },
                     "type": "object"
-                }
-            },
-            "type": "object"
-        },
-        "stateFilter": {
-            "description": "Filter asset states",
-            "properties": {
-                "match": {
-                    "description": "Defines how to match properties, missing property always fails match",
-                    "enum": [
-                        "n/a",
-                        "all",
-                        "any",
-                        "none"
-                    ],
-                    "type": "string"
                 },
-                "select": {
-                    "description": "Qualified property names and values match",
-                    "items": {
-                        "properties": {
-                            "qprop": {
-                                "description": "Qualified property to compare, for example 'asset.assetID'",
-                                "type": "string"
-                            },
-                            "value": {
-                                "description": "Value to be compared",
-                                "type": "string"
-                            }
-                        },
-                        "type": "object"
-                    },
-                    "type": "array"
-                }
-            },
-            "type": "object"
-        },
-        "surgicalkit": {
-            "description": "The changeable properties for a surgicalkit, also considered its 'event' as a partial state",
-            "properties": {
-                "common": {
-                    "description": "Common properties for all assets",
+                "eventout": {
+                    "description": "The chaincode event emitted on invoke exit, if any",
                     "properties": {
-                        "appdata": {
-                            "description": "Application managed information as an array of key:value pairs",
-                            "items": {
-                                "properties": {
-                                    "K": {
-                                        "type": "string"
-                                    },
-                                    "V": {
-                                        "type": "string"
-                                    }
-                                },
-                                "type": "object"
-                            },
-                            "minItems": 0,
-                            "type": "array"
-                        },
-                        "deviceID": {
-                            "description": "A unique identifier for the device that sent the current event",
-                            "type": "string"
-                        },
-                        "devicetimestamp": {
-                            "description": "A timestamp recoded by the device that sent the current event",
-                            "type": "string"
-                        },
-                        "location": {
-                            "description": "A geographical coordinate",
+                        "surgicalkit": {
+                            "description": "A chaincode event defining the standard platform-generated result event for a contract invoke, contains an array of contributed results",
                             "properties": {
-                                "latitude": {
-                                    "type": "number"
+                                "name": {
+                                    "default": "EVT.IOTCP.INVOKE.RESULT",
+                                    "enum": [
+                                        "EVT.IOTCP.INVOKE.RESULT"
+                                    ],
+                                    "type": "string"
                                 },
-                                "longitude": {
-                                    "type": "number"
+                                "payload": {
+                                    "description": "A map of contributed results",
+                                    "properties": {
+                                        "description": "the overall status of the invoke result, defined by err",
+                                        "properties": {
+                                            "activeAlerts": {
+                                                "description": "An array of alert names",
+                                                "items": {
+                                                    "description": "An alert name",
+                                                    "type": "string"
+                                                },
+                                                "type": "array"
+                                            },
+                                            "alertsCleared": {
+                                                "description": "An array of alert names",
+                                                "items": {
+                                                    "description": "An alert name",
+                                                    "type": "string"
+                                                },
+                                                "type": "array"
+                                            },
+                                            "alertsRaised": {
+                                                "description": "An array of alert names",
+                                                "items": {
+                                                    "description": "An alert name",
+                                                    "type": "string"
+                                                },
+                                                "type": "array"
+                                            },
+                                            "invokeresult": {
+                                                "description": "status: OK==txn succeeded, ERROR==txn failed",
+                                                "properties": {
+                                                    "message": {
+                                                        "type": "string"
+                                                    },
+                                                    "status": {
+                                                        "enum": [
+                                                            "OK",
+                                                            "ERROR"
+                                                        ],
+                                                        "type": "string"
+                                                    }
+                                                },
+                                                "type": "object"
+                                            }
+                                        },
+                                        "type": "object"
+                                    },
+                                    "type": "object"
                                 }
                             },
                             "type": "object"
@@ -2238,275 +7904,870 @@ var schemas = `
                     },
                     "type": "object"
                 },
-                "hospital": {
-                    "description": "the hospital within which the surgical kit is used, and within which it is geofenced",
+                "state": {
+                    "description": "Properties that have been received or calculated for this surgicalkit",
                     "properties": {
-                        "address": {
+                        "distanceFromCenter": {
+                            "description": "calculated distance from the fence center, can be compared to fence radius",
+                            "type": "number"
+                        },
+                        "surgicalkit": {
+                            "description": "The changeable properties for a surgicalkit, also considered its 'event' as a partial state",
                             "properties": {
-                                "city": {
-                                    "type": "string"
-                                },
-                                "country": {
-                                    "type": "string"
+                                "common": {
+                                    "description": "Common properties for all assets",
+                                    "properties": {
+                                        "appdata": {
+                                            "description": "Application managed information as an array of key:value pairs",
+                                            "items": {
+                                                "properties": {
+                                                    "K": {
+                                                        "type": "string"
+                                                    },
+                                                    "V": {
+                                                        "type": "string"
+                                                    }
+                                                },
+                                                "type": "object"
+                                            },
+                                            "minItems": 0,
+                                            "type": "array"
+                                        },
+                                        "deviceID": {
+                                            "description": "A unique identifier for the device that sent the current event",
+                                            "type": "string"
+                                        },
+                                        "devicetimestamp": {
+                                            "description": "A timestamp recoded by the device that sent the current event",
+                                            "type": "string"
+                                        },
+                                        "location": {
+                                            "description": "A geographical coordinate",
+                                            "properties": {
+                                                "latitude": {
+                                                    "type": "number"
+                                                },
+                                                "longitude": {
+                                                    "type": "number"
+                                                }
+                                            },
+                                            "type": "object"
+                                        }
+                                    },
+                                    "type": "object"
                                 },
-                                "postcode": {
-                                    "type": "string"
+                                "hospital": {
+                                    "description": "the hospital within which the surgical kit is used, and within which it is geofenced",
+                                    "properties": {
+                                        "address": {
+                                            "properties": {
+                                                "city": {
+                                                    "type": "string"
+                                                },
+                                                "country": {
+                                                    "type": "string"
+                                                },
+                                                "postcode": {
+                                                    "type": "string"
+                                                },
+                                                "streetandnumber": {
+                                                    "type": "string"
+                                                }
+                                            },
+                                            "type": "object"
+                                        },
+                                        "fence": {
+                                            "properties": {
+                                                "center": {
+                                                    "description": "A geographical coordinate",
+                                                    "properties": {
+                                                        "latitude": {
+                                                            "type": "number"
+                                                        },
+                                                        "longitude": {
+                                                            "type": "number"
+                                                        }
+                                                    },
+                                                    "type": "object"
+                                                },
+                                                "radius": {
+                                                    "type": "number"
+                                                }
+                                            },
+                                            "type": "object"
+                                        },
+                                        "name": {
+                                            "type": "string"
+                                        }
+                                    },
+                                    "type": "object"
                                 },
-                                "streetandnumber": {
-                                    "type": "string"
-                                }
-                            },
-                            "type": "object"
-                        },
-                        "fence": {
-                            "properties": {
-                                "center": {
-                                    "description": "A geographical coordinate",
+                                "sensors": {
+                                    "description": "sensor readings for the surgical kit",
                                     "properties": {
-                                        "latitude": {
+                                        "begin": {
+                                            "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                            "format": "date-time",
+                                            "sample": "yyyy-mm-dd hh:mm:ss",
+                                            "type": "string"
+                                        },
+                                        "currtilt": {
+                                            "description": "The current tilt that the kit is experiencing",
                                             "type": "number"
                                         },
-                                        "longitude": {
+                                        "end": {
+                                            "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                            "format": "date-time",
+                                            "sample": "yyyy-mm-dd hh:mm:ss",
+                                            "type": "string"
+                                        },
+                                        "endlocation": {
+                                            "description": "A geographical coordinate",
+                                            "properties": {
+                                                "latitude": {
+                                                    "type": "number"
+                                                },
+                                                "longitude": {
+                                                    "type": "number"
+                                                }
+                                            },
+                                            "type": "object"
+                                        },
+                                        "maxgforce": {
+                                            "description": "The highest (in Gs) force that the kit experienced during the sample",
                                             "type": "number"
+                                        },
+                                        "maxtilt": {
+                                            "description": "The highest (in degrees from horizontal) tilt that the kit experienced during the sample",
+                                            "type": "number"
+                                        },
+                                        "startlocation": {
+                                            "description": "A geographical coordinate",
+                                            "properties": {
+                                                "latitude": {
+                                                    "type": "number"
+                                                },
+                                                "longitude": {
+                                                    "type": "number"
+                                                }
+                                            },
+                                            "type": "object"
                                         }
                                     },
                                     "type": "object"
                                 },
-                                "radius": {
-                                    "type": "number"
-                                }
-                            },
-                            "type": "object"
-                        },
-                        "name": {
-                            "type": "string"
-                        }
-                    },
-                    "type": "object"
-                },
-                "sensors": {
-                    "description": "sensor readings for the surgical kit",
-                    "properties": {
-                        "begin": {
-                            "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
-                            "format": "date-time",
-                            "sample": "yyyy-mm-dd hh:mm:ss",
-                            "type": "string"
-                        },
-                        "currtilt": {
-                            "description": "The current tilt that the kit is experiencing",
-                            "type": "number"
-                        },
-                        "end": {
-                            "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
-                            "format": "date-time",
-                            "sample": "yyyy-mm-dd hh:mm:ss",
-                            "type": "string"
-                        },
-                        "endlocation": {
-                            "description": "A geographical coordinate",
-                            "properties": {
-                                "latitude": {
-                                    "type": "number"
+                                "skitID": {
+                                    "description": "A surgicalkit's ID",
+                                    "type": "string"
                                 },
-                                "longitude": {
-                                    "type": "number"
-                                }
-                            },
-                            "type": "object"
-                        },
-                        "maxgforce": {
-                            "description": "The highest (in Gs) force that the kit experienced during the sample",
-                            "type": "number"
-                        },
-                        "maxtilt": {
-                            "description": "The highest (in degrees from horizontal) tilt that the kit experienced during the sample",
-                            "type": "number"
-                        },
-                        "startlocation": {
-                            "description": "A geographical coordinate",
-                            "properties": {
-                                "latitude": {
-                                    "type": "number"
+                                "status": {
+                                    "description": "current kit status as a named entity in possession of the kit",
+                                    "enum": [
+                                        "",
+                                        "oem",
+                                        "warehouse",
+                                        "dealer",
+                                        "retailer",
+                                        "hospital",
+                                        "scrapped"
+                                    ],
+                                    "type": "string"
                                 },
-                                "longitude": {
-                                    "type": "number"
+                                "transit": {
+                                    "description": "shipping data during transit periods",
+                                    "properties": {
+                                        "begintransit": {
+                                            "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                            "format": "date-time",
+                                            "sample": "yyyy-mm-dd hh:mm:ss",
+                                            "type": "string"
+                                        },
+                                        "carrier": {
+                                            "type": "string"
+                                        },
+                                        "endtransit": {
+                                            "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                            "format": "date-time",
+                                            "sample": "yyyy-mm-dd hh:mm:ss",
+                                            "type": "string"
+                                        },
+                                        "receiver": {
+                                            "description": "current kit status as a named entity in possession of the kit",
+                                            "enum": [
+                                                "",
+                                                "oem",
+                                                "warehouse",
+                                                "dealer",
+                                                "retailer",
+                                                "hospital",
+                                                "scrapped"
+                                            ],
+                                            "type": "string"
+                                        },
+                                        "shipper": {
+                                            "description": "current kit status as a named entity in possession of the kit",
+                                            "enum": [
+                                                "",
+                                                "oem",
+                                                "warehouse",
+                                                "dealer",
+                                                "retailer",
+                                                "hospital",
+                                                "scrapped"
+                                            ],
+                                            "type": "string"
+                                        }
+                                    },
+                                    "type": "object"
                                 }
                             },
+                            "required": [
+                                "skitID"
+                            ],
                             "type": "object"
                         }
                     },
                     "type": "object"
                 },
-                "skitID": {
-                    "description": "A surgicalkit's ID",
+                "txnid": {
+                    "description": "Transaction UUID matching the blockchain",
                     "type": "string"
                 },
-                "status": {
-                    "description": "current kit status as a named entity in possession of the kit",
-                    "enum": [
-                        "",
-                        "oem",
-                        "warehouse",
-                        "dealer",
-                        "retailer",
-                        "hospital",
-                        "scrapped"
-                    ],
+                "txnts": {
+                    "description": "Transaction timestamp matching the blockchain",
                     "type": "string"
-                },
-                "transit": {
-                    "description": "shipping data during transit periods",
-                    "properties": {
-                        "begintransit": {
-                            "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
-                            "format": "date-time",
-                            "sample": "yyyy-mm-dd hh:mm:ss",
-                            "type": "string"
-                        },
-                        "carrier": {
-                            "type": "string"
-                        },
-                        "endtransit": {
-                            "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
-                            "format": "date-time",
-                            "sample": "yyyy-mm-dd hh:mm:ss",
-                            "type": "string"
-                        },
-                        "receiver": {
-                            "description": "current kit status as a named entity in possession of the kit",
-                            "enum": [
-                                "",
-                                "oem",
-                                "warehouse",
-                                "dealer",
-                                "retailer",
-                                "hospital",
-                                "scrapped"
-                            ],
-                            "type": "string"
-                        },
-                        "shipper": {
-                            "description": "current kit status as a named entity in possession of the kit",
-                            "enum": [
-                                "",
-                                "oem",
-                                "warehouse",
-                                "dealer",
-                                "retailer",
-                                "hospital",
-                                "scrapped"
-                            ],
-                            "type": "string"
-                        }
-                    },
-                    "type": "object"
                 }
             },
-            "required": [
-                "skitID"
-            ],
             "type": "object"
         },
-        "surgicalkitstate": {
-            "description": "A surgicalkit's complete state",
-            "properties": {
-                "AssetKey": {
-                    "description": "This surgicalkit's world state surgicalkit ID",
-                    "type": "string"
-                },
-                "alerts": {
-                    "description": "An array of alert names",
-                    "items": {
-                        "description": "An alert name",
-                        "type": "string"
-                    },
-                    "type": "array"
-                },
-                "class": {
-                    "description": "An asset's classifier definition",
-                    "properties": {
-                        "assetidpath": "An asset's primary key, expressed as a qualified property path (see example contracts)",
-                        "name": "An asset's class name",
-                        "prefix": "An asset's world state prefix, used to allow iteration over all assets of a class"
-                    },
-                    "type": "object"
-                },
-                "compliant": {
-                    "description": "This surgicalkit has no active alerts",
-                    "type": "boolean"
-                },
-                "eventin": {
-                    "description": "The contract event that created this state, for example updateAssetSurgicalKit",
-                    "properties": {
-                        "surgicalkit": {
-                            "description": "The changeable properties for a surgicalkit, also considered its 'event' as a partial state",
-                            "properties": {
-                                "common": {
-                                    "description": "Common properties for all assets",
-                                    "properties": {
-                                        "appdata": {
-                                            "description": "Application managed information as an array of key:value pairs",
-                                            "items": {
+        "surgicalkitstatearray": {
+            "description": "Array of surgicalkit states, can mix asset classes",
+            "items": {
+                "patternProperties": {
+                    "^CON": {
+                        "description": "A surgicalkit's complete state",
+                        "properties": {
+                            "AssetKey": {
+                                "description": "This surgicalkit's world state surgicalkit ID",
+                                "type": "string"
+                            },
+                            "alerts": {
+                                "description": "An array of alert names",
+                                "items": {
+                                    "description": "An alert name",
+                                    "type": "string"
+                                },
+                                "type": "array"
+                            },
+                            "class": {
+                                "description": "An asset's classifier definition",
+                                "properties": {
+                                    "assetidpath": "An asset's primary key, expressed as a qualified property path (see example contracts)",
+                                    "name": "An asset's class name",
+                                    "prefix": "An asset's world state prefix, used to allow iteration over all assets of a class"
+                                },
+                                "type": "object"
+                            },
+                            "compliant": {
+                                "description": "This surgicalkit has no active alerts",
+                                "type": "boolean"
+                            },
+                            "eventin": {
+                                "description": "The contract event that created this state, for example updateAssetSurgicalKit",
+                                "properties": {
+                                    "surgicalkit": {
+                                        "description": "The changeable properties for a surgicalkit, also considered its 'event' as a partial state",
+                                        "properties": {
+                                            "common": {
+                                                "description": "Common properties for all assets",
                                                 "properties": {
-                                                    "K": {
+                                                    "appdata": {
+                                                        "description": "Application managed information as an array of key:value pairs",
+                                                        "items": {
+                                                            "properties": {
+                                                                "K": {
+                                                                    "type": "string"
+                                                                },
+                                                                "V": {
+                                                                    "type": "string"
+                                                                }
+                                                            },
+                                                            "type": "object"
+                                                        },
+                                                        "minItems": 0,
+                                                        "type": "array"
+                                                    },
+                                                    "deviceID": {
+                                                        "description": "A unique identifier for the device that sent the current event",
+                                                        "type": "string"
+                                                    },
+                                                    "devicetimestamp": {
+                                                        "description": "A timestamp recoded by the device that sent the current event",
+                                                        "type": "string"
+                                                    },
+                                                    "location": {
+                                                        "description": "A geographical coordinate",
+                                                        "properties": {
+                                                            "latitude": {
+                                                                "type": "number"
+                                                            },
+                                                            "longitude": {
+                                                                "type": "number"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    }
+                                                },
+                                                "type": "object"
+                                            },
+                                            "hospital": {
+                                                "description": "the hospital within which the surgical kit is used, and within which it is geofenced",
+                                                "properties": {
+                                                    "address": {
+                                                        "properties": {
+                                                            "city": {
+                                                                "type": "string"
+                                                            },
+                                                            "country": {
+                                                                "type": "string"
+                                                            },
+                                                            "postcode": {
+                                                                "type": "string"
+                                                            },
+                                                            "streetandnumber": {
+                                                                "type": "string"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    },
+                                                    "fence": {
+                                                        "properties": {
+                                                            "center": {
+                                                                "description": "A geographical coordinate",
+                                                                "properties": {
+                                                                    "latitude": {
+                                                                        "type": "number"
+                                                                    },
+                                                                    "longitude": {
+                                                                        "type": "number"
+                                                                    }
+                                                                },
+                                                                "type": "object"
+                                                            },
+                                                            "radius": {
+                                                                "type": "number"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    },
+                                                    "name": {
+                                                        "type": "string"
+                                                    }
+                                                },
+                                                "type": "object"
+                                            },
+                                            "sensors": {
+                                                "description": "sensor readings for the surgical kit",
+                                                "properties": {
+                                                    "begin": {
+                                                        "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                        "format": "date-time",
+                                                        "sample": "yyyy-mm-dd hh:mm:ss",
                                                         "type": "string"
                                                     },
-                                                    "V": {
+                                                    "currtilt": {
+                                                        "description": "The current tilt that the kit is experiencing",
+                                                        "type": "number"
+                                                    },
+                                                    "end": {
+                                                        "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                        "format": "date-time",
+                                                        "sample": "yyyy-mm-dd hh:mm:ss",
                                                         "type": "string"
+                                                    },
+                                                    "endlocation": {
+                                                        "description": "A geographical coordinate",
+                                                        "properties": {
+                                                            "latitude": {
+                                                                "type": "number"
+                                                            },
+                                                            "longitude": {
+                                                                "type": "number"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    },
+                                                    "maxgforce": {
+                                                        "description": "The highest (in Gs) force that the kit experienced during the sample",
+                                                        "type": "number"
+                                                    },
+                                                    "maxtilt": {
+                                                        "description": "The highest (in degrees from horizontal) tilt that the kit experienced during the sample",
+                                                        "type": "number"
+                                                    },
+                                                    "startlocation": {
+                                                        "description": "A geographical coordinate",
+                                                        "properties": {
+                                                            "latitude": {
+                                                                "type": "number"
+                                                            },
+                                                            "longitude": {
+                                                                "type": "number"
+                                                            }
+                                                        },
+                                                        "type": "object"
                                                     }
                                                 },
                                                 "type": "object"
                                             },
-                                            "minItems": 0,
-                                            "type": "array"
-                                        },
-                                        "deviceID": {
-                                            "description": "A unique identifier for the device that sent the current event",
-                                            "type": "string"
+                                            "skitID": {
+                                                "description": "A surgicalkit's ID",
+                                                "type": "string"
+                                            },
+                                            "status": {
+                                                "description": "current kit status as a named entity in possession of the kit",
+                                                "enum": [
+                                                    "",
+                                                    "oem",
+                                                    "warehouse",
+                                                    "dealer",
+                                                    "retailer",
+                                                    "hospital",
+                                                    "scrapped"
+                                                ],
+                                                "type": "string"
+                                            },
+                                            "transit": {
+                                                "description": "shipping data during transit periods",
+                                                "properties": {
+                                                    "begintransit": {
+                                                        "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                        "format": "date-time",
+                                                        "sample": "yyyy-mm-dd hh:mm:ss",
+                                                        "type": "string"
+                                                    },
+                                                    "carrier": {
+                                                        "type": "string"
+                                                    },
+                                                    "endtransit": {
+                                                        "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                        "format": "date-time",
+                                                        "sample": "yyyy-mm-dd hh:mm:ss",
+                                                        "type": "string"
+                                                    },
+                                                    "receiver": {
+                                                        "description": "current kit status as a named entity in possession of the kit",
+                                                        "enum": [
+                                                            "",
+                                                            "oem",
+                                                            "warehouse",
+                                                            "dealer",
+                                                            "retailer",
+                                                            "hospital",
+                                                            "scrapped"
+                                                        ],
+                                                        "type": "string"
+                                                    },
+                                                    "shipper": {
+                                                        "description": "current kit status as a named entity in possession of the kit",
+                                                        "enum": [
+                                                            "",
+                                                            "oem",
+                                                            "warehouse",
+                                                            "dealer",
+                                                            "retailer",
+                                                            "hospital",
+                                                            "scrapped"
+                                                        ],
+                                                        "type": "string"
+                                                    }
+                                                },
+                                                "type": "object"
+                                            }
                                         },
-                                        "devicetimestamp": {
-                                            "description": "A timestamp recoded by the device that sent the current event",
-                                            "type": "string"
+                                        "required": [
+                                            "skitID"
+                                        ],
+                                        "type": "object"
+                                    }
+                                },
+                                "type": "object"
+                            },
+                            "eventout": {
+                                "description": "The chaincode event emitted on invoke exit, if any",
+                                "properties": {
+                                    "surgicalkit": {
+                                        "description": "A chaincode event defining the standard platform-generated result event for a contract invoke, contains an array of contributed results",
+                                        "properties": {
+                                            "name": {
+                                                "default": "EVT.IOTCP.INVOKE.RESULT",
+                                                "enum": [
+                                                    "EVT.IOTCP.INVOKE.RESULT"
+                                                ],
+                                                "type": "string"
+                                            },
+                                            "payload": {
+                                                "description": "A map of contributed results",
+                                                "properties": {
+                                                    "description": "the overall status of the invoke result, defined by err",
+                                                    "properties": {
+                                                        "activeAlerts": {
+                                                            "description": "An array of alert names",
+                                                            "items": {
+                                                                "description": "An alert name",
+                                                                "type": "string"
+                                                            },
+                                                            "type": "array"
+                                                        },
+                                                        "alertsCleared": {
+                                                            "description": "An array of alert names",
+                                                            "items": {
+                                                                "description": "An alert name",
+                                                                "type": "string"
+                                                            },
+                                                            "type": "array"
+                                                        },
+                                                        "alertsRaised": {
+                                                            "description": "An array of alert names",
+                                                            "items": {
+                                                                "description": "An alert name",
+                                                                "type": "string"
+                                                            },
+                                                            "type": "array"
+                                                        },
+                                                        "invokeresult": {
+                                                            "description": "status: OK==txn succeeded, ERROR==txn failed",
+                                                            "properties": {
+                                                                "message": {
+                                                                    "type": "string"
+                                                                },
+                                                                "status": {
+                                                                    "enum": [
+                                                                        "OK",
+                                                                        "ERROR"
+                                                                    ],
+                                                                    "type": "string"
+                                                                }
+                                                            },
+                                                            "type": "object"
+                                                        }
+                                                    },
+                                                    "type": "object"
+                                                },
+                                                "type": "object"
+                                            }
                                         },
-                                        "location": {
-                                            "description": "A geographical coordinate",
-                                            "properties": {
-                                                "latitude": {
-                                                    "type": "number"
+                                        "type": "object"
+                                    }
+                                },
+                                "type": "object"
+                            },
+                            "state": {
+                                "description": "Properties that have been received or calculated for this surgicalkit",
+                                "properties": {
+                                    "distanceFromCenter": {
+                                        "description": "calculated distance from the fence center, can be compared to fence radius",
+                                        "type": "number"
+                                    },
+                                    "surgicalkit": {
+                                        "description": "The changeable properties for a surgicalkit, also considered its 'event' as a partial state",
+                                        "properties": {
+                                            "common": {
+                                                "description": "Common properties for all assets",
+                                                "properties": {
+                                                    "appdata": {
+                                                        "description": "Application managed information as an array of key:value pairs",
+                                                        "items": {
+                                                            "properties": {
+                                                                "K": {
+                                                                    "type": "string"
+                                                                },
+                                                                "V": {
+                                                                    "type": "string"
+                                                                }
+                                                            },
+                                                            "type": "object"
+                                                        },
+                                                        "minItems": 0,
+                                                        "type": "array"
+                                                    },
+                                                    "deviceID": {
+                                                        "description": "A unique identifier for the device that sent the current event",
+                                                        "type": "string"
+                                                    },
+                                                    "devicetimestamp": {
+                                                        "description": "A timestamp recoded by the device that sent the current event",
+                                                        "type": "string"
+                                                    },
+                                                    "location": {
+                                                        "description": "A geographical coordinate",
+                                                        "properties": {
+                                                            "latitude": {
+                                                                "type": "number"
+                                                            },
+                                                            "longitude": {
+                                                                "type": "number"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    }
                                                 },
-                                                "longitude": {
-                                                    "type": "number"
-                                                }
+                                                "type": "object"
                                             },
-                                            "type": "object"
-                                        }
-                                    },
-                                    "type": "object"
+                                            "hospital": {
+                                                "description": "the hospital within which the surgical kit is used, and within which it is geofenced",
+                                                "properties": {
+                                                    "address": {
+                                                        "properties": {
+                                                            "city": {
+                                                                "type": "string"
+                                                            },
+                                                            "country": {
+                                                                "type": "string"
+                                                            },
+                                                            "postcode": {
+                                                                "type": "string"
+                                                            },
+                                                            "streetandnumber": {
+                                                                "type": "string"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    },
+                                                    "fence": {
+                                                        "properties": {
+                                                            "center": {
+                                                                "description": "A geographical coordinate",
+                                                                "properties": {
+                                                                    "latitude": {
+                                                                        "type": "number"
+                                                                    },
+                                                                    "longitude": {
+                                                                        "type": "number"
+                                                                    }
+                                                                },
+                                                                "type": "object"
+                                                            },
+                                                            "radius": {
+                                                                "type": "number"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    },
+                                                    "name": {
+                                                        "type": "string"
+                                                    }
+                                                },
+                                                "type": "object"
+                                            },
+                                            "sensors": {
+                                                "description": "sensor readings for the surgical kit",
+                                                "properties": {
+                                                    "begin": {
+                                                        "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                        "format": "date-time",
+                                                        "sample": "yyyy-mm-dd hh:mm:ss",
+                                                        "type": "string"
+                                                    },
+                                                    "currtilt": {
+                                                        "description": "The current tilt that the kit is experiencing",
+                                                        "type": "number"
+                                                    },
+                                                    "end": {
+                                                        "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                        "format": "date-time",
+                                                        "sample": "yyyy-mm-dd hh:mm:ss",
+                                                        "type": "string"
+                                                    },
+                                                    "endlocation": {
+                                                        "description": "A geographical coordinate",
+                                                        "properties": {
+                                                            "latitude": {
+                                                                "type": "number"
+                                                            },
+                                                            "longitude": {
+                                                                "type": "number"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    },
+                                                    "maxgforce": {
+                                                        "description": "The highest (in Gs) force that the kit experienced during the sample",
+                                                        "type": "number"
+                                                    },
+                                                    "maxtilt": {
+                                                        "description": "The highest (in degrees from horizontal) tilt that the kit experienced during the sample",
+                                                        "type": "number"
+                                                    },
+                                                    "startlocation": {
+                                                        "description": "A geographical coordinate",
+                                                        "properties": {
+                                                            "latitude": {
+                                                                "type": "number"
+                                                            },
+                                                            "longitude": {
+                                                                "type": "number"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    }
+                                                },
+                                                "type": "object"
+                                            },
+                                            "skitID": {
+                                                "description": "A surgicalkit's ID",
+                                                "type": "string"
+                                            },
+                                            "status": {
+                                                "description": "current kit status as a named entity in possession of the kit",
+                                                "enum": [
+                                                    "",
+                                                    "oem",
+                                                    "warehouse",
+                                                    "dealer",
+                                                    "retailer",
+                                                    "hospital",
+                                                    "scrapped"
+                                                ],
+                                                "type": "string"
+                                            },
+                                            "transit": {
+                                                "description": "shipping data during transit periods",
+                                                "properties": {
+                                                    "begintransit": {
+                                                        "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                        "format": "date-time",
+                                                        "sample": "yyyy-mm-dd hh:mm:ss",
+                                                        "type": "string"
+                                                    },
+                                                    "carrier": {
+                                                        "type": "string"
+                                                    },
+                                                    "endtransit": {
+                                                        "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                        "format": "date-time",
+                                                        "sample": "yyyy-mm-dd hh:mm:ss",
+                                                        "type": "string"
+                                                    },
+                                                    "receiver": {
+                                                        "description": "current kit status as a named entity in possession of the kit",
+                                                        "enum": [
+                                                            "",
+                                                            "oem",
+                                                            "warehouse",
+                                                            "dealer",
+                                                            "retailer",
+                                                            "hospital",
+                                                            "scrapped"
+                                                        ],
+                                                        "type": "string"
+                                                    },
+                                                    "shipper": {
+                                                        "description": "current kit status as a named entity in possession of the kit",
+                                                        "enum": [
+                                                            "",
+                                                            "oem",
+                                                            "warehouse",
+                                                            "dealer",
+                                                            "retailer",
+                                                            "hospital",
+                                                            "scrapped"
+                                                        ],
+                                                        "type": "string"
+                                                    }
+                                                },
+                                                "type": "object"
+                                            }
+                                        },
+                                        "required": [
+                                            "skitID"
+                                        ],
+                                        "type": "object"
+                                    }
                                 },
-                                "hospital": {
-                                    "description": "the hospital within which the surgical kit is used, and within which it is geofenced",
+                                "type": "object"
+                            },
+                            "txnid": {
+                                "description": "Transaction UUID matching the blockchain",
+                                "type": "string"
+                            },
+                            "txnts": {
+                                "description": "Transaction timestamp matching the blockchain",
+                                "type": "string"
+                            }
+                        },
+                        "type": "object"
+                    }
+                },
+                "type": "object"
+            },
+            "minItems": 0,
+            "type": "array"
+        },
+        "surgicalkitstateexternal": {
+            "patternProperties": {
+                "^CON": {
+                    "description": "A surgicalkit's complete state",
+                    "properties": {
+                        "AssetKey": {
+                            "description": "This surgicalkit's world state surgicalkit ID",
+                            "type": "string"
+                        },
+                        "alerts": {
+                            "description": "An array of alert names",
+                            "items": {
+                                "description": "An alert name",
+                                "type": "string"
+                            },
+                            "type": "array"
+                        },
+                        "class": {
+                            "description": "An asset's classifier definition",
+                            "properties": {
+                                "assetidpath": "An asset's primary key, expressed as a qualified property path (see example contracts)",
+                                "name": "An asset's class name",
+                                "prefix": "An asset's world state prefix, used to allow iteration over all assets of a class"
+                            },
+                            "type": "object"
+                        },
+                        "compliant": {
+                            "description": "This surgicalkit has no active alerts",
+                            "type": "boolean"
+                        },
+                        "eventin": {
+                            "description": "The contract event that created this state, for example updateAssetSurgicalKit",
+                            "properties": {
+                                "surgicalkit": {
+                                    "description": "The changeable properties for a surgicalkit, also considered its 'event' as a partial state",
                                     "properties": {
-                                        "address": {
+                                        "common": {
+                                            "description": "Common properties for all assets",
                                             "properties": {
-                                                "city": {
-                                                    "type": "string"
+                                                "appdata": {
+                                                    "description": "Application managed information as an array of key:value pairs",
+                                                    "items": {
+                                                        "properties": {
+                                                            "K": {
+                                                                "type": "string"
+                                                            },
+                                                            "V": {
+                                                                "type": "string"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    },
+                                                    "minItems": 0,
+                                                    "type": "array"
                                                 },
-                                                "country": {
+                                                "deviceID": {
+                                                    "description": "A unique identifier for the device that sent the current event",
                                                     "type": "string"
                                                 },
-                                                "postcode": {
+                                                "devicetimestamp": {
+                                                    "description": "A timestamp recoded by the device that sent the current event",
                                                     "type": "string"
                                                 },
-                                                "streetandnumber": {
-                                                    "type": "string"
-                                                }
-                                            },
-                                            "type": "object"
-                                        },
-                                        "fence": {
-                                            "properties": {
-                                                "center": {
+                                                "location": {
                                                     "description": "A geographical coordinate",
                                                     "properties": {
                                                         "latitude": {
@@ -2517,109 +8778,115 @@ var schemas = `
                                                         }
                                                     },
                                                     "type": "object"
-                                                },
-                                                "radius": {
-                                                    "type": "number"
                                                 }
                                             },
                                             "type": "object"
                                         },
-                                        "name": {
-                                            "type": "string"
-                                        }
-                                    },
-                                    "type": "object"
-                                },
-                                "sensors": {
-                                    "description": "sensor readings for the surgical kit",
-                                    "properties": {
-                                        "begin": {
-                                            "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
-                                            "format": "date-time",
-                                            "sample": "yyyy-mm-dd hh:mm:ss",
-                                            "type": "string"
-                                        },
-                                        "currtilt": {
-                                            "description": "The current tilt that the kit is experiencing",
-                                            "type": "number"
-                                        },
-                                        "end": {
-                                            "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
-                                            "format": "date-time",
-                                            "sample": "yyyy-mm-dd hh:mm:ss",
-                                            "type": "string"
-                                        },
-                                        "endlocation": {
-                                            "description": "A geographical coordinate",
+                                        "hospital": {
+                                            "description": "the hospital within which the surgical kit is used, and within which it is geofenced",
                                             "properties": {
-                                                "latitude": {
-                                                    "type": "number"
+                                                "address": {
+                                                    "properties": {
+                                                        "city": {
+                                                            "type": "string"
+                                                        },
+                                                        "country": {
+                                                            "type": "string"
+                                                        },
+                                                        "postcode": {
+                                                            "type": "string"
+                                                        },
+                                                        "streetandnumber": {
+                                                            "type": "string"
+                                                        }
+                                                    },
+                                                    "type": "object"
                                                 },
-                                                "longitude": {
-                                                    "type": "number"
+                                                "fence": {
+                                                    "properties": {
+                                                        "center": {
+                                                            "description": "A geographical coordinate",
+                                                            "properties": {
+                                                                "latitude": {
+                                                                    "type": "number"
+                                                                },
+                                                                "longitude": {
+                                                                    "type": "number"
+                                                                }
+                                                            },
+                                                            "type": "object"
+                                                        },
+                                                        "radius": {
+                                                            "type": "number"
+                                                        }
+                                                    },
+                                                    "type": "object"
+                                                },
+                                                "name": {
+                                                    "type": "string"
                                                 }
                                             },
                                             "type": "object"
                                         },
-                                        "maxgforce": {
-                                            "description": "The highest (in Gs) force that the kit experienced during the sample",
-                                            "type": "number"
-                                        },
-                                        "maxtilt": {
-                                            "description": "The highest (in degrees from horizontal) tilt that the kit experienced during the sample",
-                                            "type": "number"
-                                        },
-                                        "startlocation": {
-                                            "description": "A geographical coordinate",
+                                        "sensors": {
+                                            "description": "sensor readings for the surgical kit",
                                             "properties": {
-                                                "latitude": {
+                                                "begin": {
+                                                    "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                    "format": "date-time",
+                                                    "sample": "yyyy-mm-dd hh:mm:ss",
+                                                    "type": "string"
+                                                },
+                                                "currtilt": {
+                                                    "description": "The current tilt that the kit is experiencing",
                                                     "type": "number"
                                                 },
-                                                "longitude": {
+                                                "end": {
+                                                    "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                    "format": "date-time",
+                                                    "sample": "yyyy-mm-dd hh:mm:ss",
+                                                    "type": "string"
+                                                },
+                                                "endlocation": {
+                                                    "description": "A geographical coordinate",
+                                                    "properties": {
+                                                        "latitude": {
+                                                            "type": "number"
+                                                        },
+                                                        "longitude": {
+                                                            "type": "number"
+                                                        }
+                                                    },
+                                                    "type": "object"
+                                                },
+                                                "maxgforce": {
+                                                    "description": "The highest (in Gs) force that the kit experienced during the sample",
                                                     "type": "number"
-                                                }
-                                            },
-                                            "type": "object"
-                                        }
-                                    },
-                                    "type": "object"
-                                },
-                                "skitID": {
-                                    "description": "A surgicalkit's ID",
-                                    "type": "string"
-                                },
-                                "status": {
-                                    "description": "current kit status as a named entity in possession of the kit",
-                                    "enum": [
-                                        "",
-                                        "oem",
-                                        "warehouse",
-                                        "dealer",
-                                        "retailer",
-                                        "hospital",
-                                        "scrapped"
-                                    ],
-                                    "type": "string"
-                                },
-                                "transit": {
-                                    "description": "shipping data during transit periods",
-                                    "properties": {
-                                        "begintransit": {
-                                            "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
-                                            "format": "date-time",
-                                            "sample": "yyyy-mm-dd hh:mm:ss",
-                                            "type": "string"
-                                        },
-                                        "carrier": {
-                                            "type": "string"
+                                                },
+                                                "maxtilt": {
+                                                    "description": "The highest (in degrees from horizontal) tilt that the kit experienced during the sample",
+                                                    "type": "number"
+                                                },
+                                                "startlocation": {
+                                                    "description": "A geographical coordinate",
+                                                    "properties": {
+                                                        "latitude": {
+                                                            "type": "number"
+                                                        },
+                                                        "longitude": {
+                                                            "type": "number"
+                                                        }
+                                                    },
+                                                    "type": "object"
+                                                }
+                                            },
+                                            "type": "object"
                                         },
-                                        "endtransit": {
-                                            "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
-                                            "format": "date-time",
-                                            "sample": "yyyy-mm-dd hh:mm:ss",
+                                        "skitID": {
+                                            "description": "A surgicalkit's ID",
                                             "type": "string"
                                         },
-                                        "receiver": {
+                                        "status": {
                                             "description": "current kit status as a named entity in possession of the kit",
                                             "enum": [
                                                 "",
@@ -2632,127 +8899,249 @@ var schemas = `
                                             ],
                                             "type": "string"
                                         },
-                                        "shipper": {
-                                            "description": "current kit status as a named entity in possession of the kit",
-                                            "enum": [
-                                                "",
-                                                "oem",
-                                                "warehouse",
-                                                "dealer",
-                                                "retailer",
-                                                "hospital",
-                                                "scrapped"
-                                            ],
-                                            "type": "string"
+                                        "transit": {
+                                            "description": "shipping data during transit periods",
+                                            "properties": {
+                                                "begintransit": {
+                                                    "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                    "format": "date-time",
+                                                    "sample": "yyyy-mm-dd hh:mm:ss",
+                                                    "type": "string"
+                                                },
+                                                "carrier": {
+                                                    "type": "string"
+                                                },
+                                                "endtransit": {
+                                                    "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                    "format": "date-time",
+                                                    "sample": "yyyy-mm-dd hh:mm:ss",
+                                                    "type": "string"
+                                                },
+                                                "receiver": {
+                                                    "description": "current kit status as a named entity in possession of the kit",
+                                                    "enum": [
+                                                        "",
+                                                        "oem",
+                                                        "warehouse",
+                                                        "dealer",
+                                                        "retailer",
+                                                        "hospital",
+                                                        "scrapped"
+                                                    ],
+                                                    "type": "string"
+                                                },
+                                                "shipper": {
+                                                    "description": "current kit status as a named entity in possession of the kit",
+                                                    "enum": [
+                                                        "",
+                                                        "oem",
+                                                        "warehouse",
+                                                        "dealer",
+                                                        "retailer",
+                                                        "hospital",
+                                                        "scrapped"
+                                                    ],
+                                                    "type": "string"
+                                                }
+                                            },
+                                            "type": "object"
                                         }
                                     },
+                                    "required": [
+                                        "skitID"
+                                    ],
                                     "type": "object"
                                 }
                             },
-                            "required": [
-                                "skitID"
-                            ],
                             "type": "object"
-                        }
-                    },
-                    "type": "object"
-                },
-                "eventout": {
-                    "description": "The chaincode event emitted on invoke exit, if any",
-                    "properties": {
-                        "surgicalkit": {
-                            "description": "An chaincode event emitted by a contract invoke",
+                        },
+                        "eventout": {
+                            "description": "The chaincode event emitted on invoke exit, if any",
                             "properties": {
-                                "name": {
-                                    "description": "The chaincode event's name",
-                                    "type": "string"
-                                },
-                                "payload": {
-                                    "description": "The chaincode event's properties",
-                                    "properties": {},
+                                "surgicalkit": {
+                                    "description": "A chaincode event defining the standard platform-generated result event for a contract invoke, contains an array of contributed results",
+                                    "properties": {
+                                        "name": {
+                                            "default": "EVT.IOTCP.INVOKE.RESULT",
+                                            "enum": [
+                                                "EVT.IOTCP.INVOKE.RESULT"
+                                            ],
+                                            "type": "string"
+                                        },
+                                        "payload": {
+                                            "description": "A map of contributed results",
+                                            "properties": {
+                                                "description": "the overall status of the invoke result, defined by err",
+                                                "properties": {
+                                                    "activeAlerts": {
+                                                        "description": "An array of alert names",
+                                                        "items": {
+                                                            "description": "An alert name",
+                                                            "type": "string"
+                                                        },
+                                                        "type": "array"
+                                                    },
+                                                    "alertsCleared": {
+                                                        "description": "An array of alert names",
+                                                        "items": {
+                                                            "description": "An alert name",
+                                                            "type": "string"
+                                                        },
+                                                        "type": "array"
+                                                    },
+                                                    "alertsRaised": {
+                                                        "description": "An array of alert names",
+                                                        "items": {
+                                                            "description": "An alert name",
+                                                            "type": "string"
+                                                        },
+                                                        "type": "array"
+                                                    },
+                                                    "invokeresult": {
+                                                        "description": "status: OK==txn succeeded, ERROR==txn failed",
+                                                        "properties": {
+                                                            "message": {
+                                                                "type": "string"
+                                                            },
+                                                            "status": {
+                                                                "enum": [
+                                                                    "OK",
+                                                                    "ERROR"
+                                                                ],
+                                                                "type": "string"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    }
+                                                },
+                                                "type": "object"
+                                            },
+                                            "type": "object"
+                                        }
+                                    },
                                     "type": "object"
                                 }
                             },
                             "type": "object"
-                        }
-                    },
-                    "type": "object"
-                },
-                "state": {
-                    "description": "Properties that have been received or calculated for this surgicalkit",
-                    "properties": {
-                        "distanceFromCenter": {
-                            "description": "calculated distance from the fence center, can be compared to fence radius",
-                            "type": "number"
                         },
-                        "surgicalkit": {
-                            "description": "The changeable properties for a surgicalkit, also considered its 'event' as a partial state",
+                        "state": {
+                            "description": "Properties that have been received or calculated for this surgicalkit",
                             "properties": {
-                                "common": {
-                                    "description": "Common properties for all assets",
+                                "distanceFromCenter": {
+                                    "description": "calculated distance from the fence center, can be compared to fence radius",
+                                    "type": "number"
+                                },
+                                "surgicalkit": {
+                                    "description": "The changeable properties for a surgicalkit, also considered its 'event' as a partial state",
                                     "properties": {
-                                        "appdata": {
-                                            "description": "Application managed information as an array of key:value pairs",
-                                            "items": {
-                                                "properties": {
-                                                    "K": {
-                                                        "type": "string"
+                                        "common": {
+                                            "description": "Common properties for all assets",
+                                            "properties": {
+                                                "appdata": {
+                                                    "description": "Application managed information as an array of key:value pairs",
+                                                    "items": {
+                                                        "properties": {
+                                                            "K": {
+                                                                "type": "string"
+                                                            },
+                                                            "V": {
+                                                                "type": "string"
+                                                            }
+                                                        },
+                                                        "type": "object"
                                                     },
-                                                    "V": {
-                                                        "type": "string"
-                                                    }
+                                                    "minItems": 0,
+                                                    "type": "array"
                                                 },
-                                                "type": "object"
+                                                "deviceID": {
+                                                    "description": "A unique identifier for the device that sent the current event",
+                                                    "type": "string"
+                                                },
+                                                "devicetimestamp": {
+                                                    "description": "A timestamp recoded by the device that sent the current event",
+                                                    "type": "string"
+                                                },
+                                                "location": {
+                                                    "description": "A geographical coordinate",
+                                                    "properties": {
+                                                        "latitude": {
+                                                            "type": "number"
+                                                        },
+                                                        "longitude": {
+                                                            "type": "number"
+                                                        }
+                                                    },
+                                                    "type": "object"
+                                                }
                                             },
-                                            "minItems": 0,
-                                            "type": "array"
-                                        },
-                                        "deviceID": {
-                                            "description": "A unique identifier for the device that sent the current event",
-                                            "type": "string"
-                                        },
-                                        "devicetimestamp": {
-                                            "description": "A timestamp recoded by the device that sent the current event",
-                                            "type": "string"
+                                            "type": "object"
                                         },
-                                        "location": {
-                                            "description": "A geographical coordinate",
+                                        "hospital": {
+                                            "description": "the hospital within which the surgical kit is used, and within which it is geofenced",
                                             "properties": {
-                                                "latitude": {
-                                                    "type": "number"
+                                                "address": {
+                                                    "properties": {
+                                                        "city": {
+                                                            "type": "string"
+                                                        },
+                                                        "country": {
+                                                            "type": "string"
+                                                        },
+                                                        "postcode": {
+                                                            "type": "string"
+                                                        },
+                                                        "streetandnumber": {
+                                                            "type": "string"
+                                                        }
+                                                    },
+                                                    "type": "object"
+                                                },
+                                                "fence": {
+                                                    "properties": {
+                                                        "center": {
+                                                            "description": "A geographical coordinate",
+                                                            "properties": {
+                                                                "latitude": {
+                                                                    "type": "number"
+                                                                },
+                                                                "longitude": {
+                                                                    "type": "number"
+                                                                }
+                                                            },
+                                                            "type": "object"
+                                                        },
+                                                        "radius": {
+                                                            "type": "number"
+                                                        }
+                                                    },
+                                                    "type": "object"
                                                 },
-                                                "longitude": {
-                                                    "type": "number"
+                                                "name": {
+                                                    "type": "string"
                                                 }
                                             },
                                             "type": "object"
-                                        }
-                                    },
-                                    "type": "object"
-                                },
-                                "hospital": {
-                                    "description": "the hospital within which the surgical kit is used, and within which it is geofenced",
-                                    "properties": {
-                                        "address": {
+                                        },
+                                        "sensors": {
+                                            "description": "sensor readings for the surgical kit",
                                             "properties": {
-                                                "city": {
+                                                "begin": {
+                                                    "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                    "format": "date-time",
+                                                    "sample": "yyyy-mm-dd hh:mm:ss",
                                                     "type": "string"
                                                 },
-                                                "country": {
-                                                    "type": "string"
+                                                "currtilt": {
+                                                    "description": "The current tilt that the kit is experiencing",
+                                                    "type": "number"
                                                 },
-                                                "postcode": {
+                                                "end": {
+                                                    "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                    "format": "date-time",
+                                                    "sample": "yyyy-mm-dd hh:mm:ss",
                                                     "type": "string"
                                                 },
-                                                "streetandnumber": {
-                                                    "type": "string"
-                                                }
-                                            },
-                                            "type": "object"
-                                        },
-                                        "fence": {
-                                            "properties": {
-                                                "center": {
+                                                "endlocation": {
                                                     "description": "A geographical coordinate",
                                                     "properties": {
                                                         "latitude": {
@@ -2764,108 +9153,34 @@ var schemas = `
                                                     },
                                                     "type": "object"
                                                 },
-                                                "radius": {
-                                                    "type": "number"
-                                                }
-                                            },
-                                            "type": "object"
-                                        },
-                                        "name": {
-                                            "type": "string"
-                                        }
-                                    },
-                                    "type": "object"
-                                },
-                                "sensors": {
-                                    "description": "sensor readings for the surgical kit",
-                                    "properties": {
-                                        "begin": {
-                                            "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
-                                            "format": "date-time",
-                                            "sample": "yyyy-mm-dd hh:mm:ss",
-                                            "type": "string"
-                                        },
-                                        "currtilt": {
-                                            "description": "The current tilt that the kit is experiencing",
-                                            "type": "number"
-                                        },
-                                        "end": {
-                                            "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
-                                            "format": "date-time",
-                                            "sample": "yyyy-mm-dd hh:mm:ss",
-                                            "type": "string"
-                                        },
-                                        "endlocation": {
-                                            "description": "A geographical coordinate",
-                                            "properties": {
-                                                "latitude": {
+                                                "maxgforce": {
+                                                    "description": "The highest (in Gs) force that the kit experienced during the sample",
                                                     "type": "number"
                                                 },
-                                                "longitude": {
-                                                    "type": "number"
-                                                }
-                                            },
-                                            "type": "object"
-                                        },
-                                        "maxgforce": {
-                                            "description": "The highest (in Gs) force that the kit experienced during the sample",
-                                            "type": "number"
-                                        },
-                                        "maxtilt": {
-                                            "description": "The highest (in degrees from horizontal) tilt that the kit experienced during the sample",
-                                            "type": "number"
-                                        },
-                                        "startlocation": {
-                                            "description": "A geographical coordinate",
-                                            "properties": {
-                                                "latitude": {
+                                                "maxtilt": {
+                                                    "description": "The highest (in degrees from horizontal) tilt that the kit experienced during the sample",
                                                     "type": "number"
                                                 },
-                                                "longitude": {
-                                                    "type": "number"
+                                                "startlocation": {
+                                                    "description": "A geographical coordinate",
+                                                    "properties": {
+                                                        "latitude": {
+                                                            "type": "number"
+                                                        },
+                                                        "longitude": {
+                                                            "type": "number"
+                                                        }
+                                                    },
+                                                    "type": "object"
                                                 }
                                             },
                                             "type": "object"
-                                        }
-                                    },
-                                    "type": "object"
-                                },
-                                "skitID": {
-                                    "description": "A surgicalkit's ID",
-                                    "type": "string"
-                                },
-                                "status": {
-                                    "description": "current kit status as a named entity in possession of the kit",
-                                    "enum": [
-                                        "",
-                                        "oem",
-                                        "warehouse",
-                                        "dealer",
-                                        "retailer",
-                                        "hospital",
-                                        "scrapped"
-                                    ],
-                                    "type": "string"
-                                },
-                                "transit": {
-                                    "description": "shipping data during transit periods",
-                                    "properties": {
-                                        "begintransit": {
-                                            "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
-                                            "format": "date-time",
-                                            "sample": "yyyy-mm-dd hh:mm:ss",
-                                            "type": "string"
                                         },
-                                        "carrier": {
-                                            "type": "string"
-                                        },
-                                        "endtransit": {
-                                            "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
-                                            "format": "date-time",
-                                            "sample": "yyyy-mm-dd hh:mm:ss",
+                                        "skitID": {
+                                            "description": "A surgicalkit's ID",
                                             "type": "string"
                                         },
-                                        "receiver": {
+                                        "status": {
                                             "description": "current kit status as a named entity in possession of the kit",
                                             "enum": [
                                                 "",
@@ -2878,61 +9193,72 @@ var schemas = `
                                             ],
                                             "type": "string"
                                         },
-                                        "shipper": {
-                                            "description": "current kit status as a named entity in possession of the kit",
-                                            "enum": [
-                                                "",
-                                                "oem",
-                                                "warehouse",
-                                                "dealer",
-                                                "retailer",
-                                                "hospital",
-                                                "scrapped"
-                                            ],
-                                            "type": "string"
+                                        "transit": {
+                                            "description": "shipping data during transit periods",
+                                            "properties": {
+                                                "begintransit": {
+                                                    "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                    "format": "date-time",
+                                                    "sample": "yyyy-mm-dd hh:mm:ss",
+                                                    "type": "string"
+                                                },
+                                                "carrier": {
+                                                    "type": "string"
+                                                },
+                                                "endtransit": {
+                                                    "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                    "format": "date-time",
+                                                    "sample": "yyyy-mm-dd hh:mm:ss",
+                                                    "type": "string"
+                                                },
+                                                "receiver": {
+                                                    "description": "current kit status as a named entity in possession of the kit",
+                                                    "enum": [
+                                                        "",
+                                                        "oem",
+                                                        "warehouse",
+                                                        "dealer",
+                                                        "retailer",
+                                                        "hospital",
+                                                        "scrapped"
+                                                    ],
+                                                    "type": "string"
+                                                },
+                                                "shipper": {
+                                                    "description": "current kit status as a named entity in possession of the kit",
+                                                    "enum": [
+                                                        "",
+                                                        "oem",
+                                                        "warehouse",
+                                                        "dealer",
+                                                        "retailer",
+                                                        "hospital",
+                                                        "scrapped"
+                                                    ],
+                                                    "type": "string"
+                                                }
+                                            },
+                                            "type": "object"
                                         }
                                     },
+                                    "required": [
+                                        "skitID"
+                                    ],
                                     "type": "object"
                                 }
                             },
-                            "required": [
-                                "skitID"
-                            ],
                             "type": "object"
+                        },
+                        "txnid": {
+                            "description": "Transaction UUID matching the blockchain",
+                            "type": "string"
+                        },
+                        "txnts": {
+                            "description": "Transaction timestamp matching the blockchain",
+                            "type": "string"
                         }
                     },
                     "type": "object"
-                },
-                "txnid": {
-                    "description": "Transaction UUID matching the blockchain",
-                    "type": "string"
-                },
-                "txnts": {
-                    "description": "Transaction timestamp matching the blockchain",
-                    "type": "string"
-                }
-            },
-            "type": "object"
-        },
-        "surgicalkitstatearray": {
-            "description": "Array of surgicalkit states, can mix asset classes",
-            "items": {
-                "patternProperties": {
-                    "^CON": {
-                        "description": "The external state of one surgicalkit asset, named by its world state ID",
-                        "type": "object"
-                    }
-                },
-                "type": "object"
-            },
-            "minItems": 0,
-            "type": "array"
-        },
-        "surgicalkitstateexternal": {
-            "patternProperties": {
-                "^CON": {
-                    "description": "The external state of one surgicalkit asset, named by its world state ID",
-                    "type": "object"
                 }
             },
             "type": "object"